@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"jank.com/jank_blog/internal/bench"
+)
+
+// Bench 按负载模型压测接口
+func Bench(args []string) {
+	opts := bench.DefaultOptions()
+
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	fs.StringVar(&opts.Target, "target", opts.Target, "压测目标地址，如 http://localhost:9010/api/v1")
+	fs.StringVar(&opts.Profile, "profile", opts.Profile, "负载模型，可选值: read, mixed, paging")
+	fs.IntVar(&opts.Concurrency, "c", opts.Concurrency, "并发数")
+	fs.DurationVar(&opts.Duration, "d", opts.Duration, "压测时长")
+	fs.IntVar(&opts.MaxPage, "max-page", opts.MaxPage, "分页请求的最大页码")
+	fs.Int64Var(&opts.MaxPostID, "max-post-id", opts.MaxPostID, "随机请求的最大文章 ID")
+	_ = fs.Parse(args)
+
+	start := time.Now()
+	report, err := bench.Run(opts)
+	if err != nil {
+		log.Fatalf("压测失败: %v", err)
+	}
+
+	log.Printf("压测完成，耗时 %s\n%s", time.Since(start).Round(time.Millisecond), report)
+}
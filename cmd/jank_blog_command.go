@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command 命令行子命令
+type command struct {
+	Usage string              // 命令说明
+	Run   func(args []string) // 命令入口
+}
+
+// commands 已注册的子命令
+var commands = map[string]command{
+	"seed":   {Usage: "生成压测用的文章、评论、类目等模拟数据", Run: Seed},
+	"bench":  {Usage: "按负载模型对接口进行压测", Run: Bench},
+	"export": {Usage: "将已发布内容导出为静态站点，或将全部文章导出为 Hugo 兼容的 Markdown 文件", Run: Export},
+	"doctor": {Usage: "检查并修复孤立数据、重复类目与缺失索引", Run: Doctor},
+	"import": {Usage: "从 Ghost、Typecho、WordPress 等博客系统或 Hugo、Jekyll 的 Markdown 文件导入内容", Run: Import},
+}
+
+// Dispatch 分发子命令，未匹配到子命令时返回 false
+func Dispatch(name string, args []string) bool {
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return true
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		return false
+	}
+
+	cmd.Run(args)
+	return true
+}
+
+// printUsage 打印子命令列表
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stdout, "用法: go run main.go [command] [flags]")
+	fmt.Fprintln(os.Stdout, "不携带 command 时启动博客服务，可用命令:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stdout, "  %-10s %s\n", name, commands[name].Usage)
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"flag"
+	"log"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/db"
+	"jank.com/jank_blog/internal/doctor"
+)
+
+// Doctor 检查数据库中的孤立数据、重复数据与缺失索引
+func Doctor(args []string) {
+	var opts doctor.Options
+
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fs.BoolVar(&opts.Fix, "fix", false, "在事务中修复发现的问题")
+	_ = fs.Parse(args)
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("数据库体检时加载配置失败: %v", err)
+	}
+	db.New(config)
+
+	report, err := doctor.Run(opts)
+	if err != nil {
+		log.Fatalf("数据库体检失败: %v", err)
+	}
+
+	log.Printf("数据库体检完成\n%s", report)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"flag"
+	"log"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/db"
+	"jank.com/jank_blog/internal/export"
+)
+
+// 导出格式
+const (
+	exportFormatStatic = "static" // 静态站点
+	exportFormatHugo   = "hugo"   // Hugo 兼容的 Markdown 文件
+)
+
+// Export 将已发布内容导出为静态站点，或将全部文章导出为 Hugo 兼容的 Markdown 文件
+func Export(args []string) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("导出时加载配置失败: %v", err)
+	}
+
+	opts := export.StaticOptions{
+		SiteTitle:  config.SiteConfig.SiteTitle,
+		SiteURL:    config.SiteConfig.SiteURL,
+		SiteAuthor: config.SiteConfig.SiteAuthor,
+		ThemeDir:   config.SiteConfig.SiteTheme,
+	}
+
+	var format string
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.StringVar(&format, "format", exportFormatStatic, "导出格式，可选值: static, hugo")
+	fs.StringVar(&opts.OutputDir, "out", "./public", "输出目录，导出为 Hugo 时通常为站点的 content/posts 目录")
+	fs.StringVar(&opts.ThemeDir, "theme", opts.ThemeDir, "主题模板目录，留空使用内置主题")
+	fs.StringVar(&opts.SiteURL, "url", opts.SiteURL, "站点地址，用于生成绝对链接")
+	_ = fs.Parse(args)
+
+	if format != exportFormatStatic && format != exportFormatHugo {
+		log.Fatalf("导出失败: 不支持的导出格式 %q，可选值: static, hugo", format)
+	}
+	db.New(config)
+
+	if format == exportFormatHugo {
+		report, err := export.ExportMarkdown(export.MarkdownOptions{OutputDir: opts.OutputDir})
+		if err != nil {
+			log.Fatalf("导出 Markdown 文件失败: %v", err)
+		}
+		log.Printf("Markdown 文件导出完成: %s", report)
+		return
+	}
+
+	report, err := export.ExportStatic(opts)
+	if err != nil {
+		log.Fatalf("导出静态站点失败: %v", err)
+	}
+	log.Printf("静态站点导出完成: %s", report)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/db"
+	"jank.com/jank_blog/internal/importer"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// Import 从其他博客系统导入文章、类目与评论
+func Import(args []string) {
+	var opts importer.Options
+	var file, email string
+
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.StringVar(&opts.Format, "format", "", "导入格式，可选值: "+strings.Join(importer.Names(), ", "))
+	fs.StringVar(&file, "file", "", "导出文件路径，文件类导入源使用")
+	fs.StringVar(&opts.Source.Dir, "dir", "", "导出文件目录，目录类导入源使用，如 Hugo 的 content 目录或 Jekyll 的 _posts 目录")
+	fs.StringVar(&opts.Source.Driver, "driver", "mysql", "数据库驱动，数据库类导入源使用，可选值: mysql, sqlite")
+	fs.StringVar(&opts.Source.DSN, "dsn", "", "数据库连接串，数据库类导入源使用")
+	fs.StringVar(&opts.Source.Prefix, "prefix", "", "数据表前缀，留空使用导入源的默认前缀")
+	fs.StringVar(&opts.Source.BaseURL, "url", "", "原站点地址，用于补全图片等相对地址")
+	fs.StringVar(&email, "account", "", "执行导入的用户邮箱，无法匹配评论者时评论归属到该用户")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "试运行，只输出导入报告，不写入数据")
+	_ = fs.Parse(args)
+
+	if email == "" {
+		log.Fatalf("导入失败: 缺少 -account 参数")
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("导入时加载配置失败: %v", err)
+	}
+	db.New(config)
+
+	acc, err := mapper.GetAccountByEmail(email)
+	if err != nil {
+		log.Fatalf("导入失败: 获取用户 %s 失败: %v", email, err)
+	}
+	opts.AccountID = acc.ID
+
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			log.Fatalf("导入失败: 打开导出文件失败: %v", err)
+		}
+		defer f.Close()
+		opts.Source.Reader = f
+	}
+
+	report, err := importer.Run(opts)
+	if err != nil {
+		log.Fatalf("导入失败: %v", err)
+	}
+
+	for _, warning := range report.Warnings {
+		log.Printf("警告: %s", warning)
+	}
+	log.Printf("导入完成: %s", report)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"flag"
+	"log"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/db"
+	"jank.com/jank_blog/internal/seed"
+)
+
+// Seed 生成模拟数据
+func Seed(args []string) {
+	opts := seed.DefaultOptions()
+
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	fs.IntVar(&opts.Accounts, "accounts", opts.Accounts, "生成的用户数量")
+	fs.IntVar(&opts.Categories, "categories", opts.Categories, "生成的类目数量")
+	fs.IntVar(&opts.Posts, "posts", opts.Posts, "生成的文章数量")
+	fs.IntVar(&opts.MaxComments, "max-comments", opts.MaxComments, "单篇文章最大评论数")
+	fs.IntVar(&opts.Days, "days", opts.Days, "文章发布时间分布的天数范围")
+	fs.IntVar(&opts.BatchSize, "batch", opts.BatchSize, "批量写入的大小")
+	fs.Int64Var(&opts.RandSeed, "rand-seed", opts.RandSeed, "随机数种子，相同种子生成相同数据")
+	_ = fs.Parse(args)
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("生成模拟数据时加载配置失败: %v", err)
+	}
+
+	db.New(config)
+
+	report, err := seed.Run(opts)
+	if err != nil {
+		log.Fatalf("生成模拟数据失败: %v", err)
+	}
+
+	log.Printf("模拟数据生成完成: %s", report)
+}
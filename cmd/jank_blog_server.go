@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -9,9 +10,17 @@ import (
 	"jank.com/jank_blog/configs"
 	"jank.com/jank_blog/internal/banner"
 	"jank.com/jank_blog/internal/db"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/ipaccess"
+	"jank.com/jank_blog/internal/job"
 	"jank.com/jank_blog/internal/middleware"
 	"jank.com/jank_blog/internal/redis"
+	"jank.com/jank_blog/internal/scheduler"
 	"jank.com/jank_blog/pkg/router"
+	commentService "jank.com/jank_blog/pkg/serve/service/comment"
+	postService "jank.com/jank_blog/pkg/serve/service/post"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	setupService "jank.com/jank_blog/pkg/serve/service/setup"
 )
 
 // Start 启动服务
@@ -25,6 +34,8 @@ func Start() {
 	// 初始化 echo 实例
 	app := echo.New()
 	app.HideBanner = true
+	// 按可信代理解析客户端 IP，避免伪造 X-Forwarded-For 绕过管理接口的 IP 规则与按 IP 的限流
+	app.IPExtractor = ipaccess.IPExtractor(config.IPAccessConfig.TrustedProxies)
 	banner.InitBanner()
 
 	// 初始化中间件
@@ -36,6 +47,38 @@ func Start() {
 	// 初始化 Redis 连接
 	redis.New(config)
 
+	// 注册并启动定时任务
+	job.Init()
+	scheduler.Start()
+	// 启动时重建一次搜索建议索引
+	if err := scheduler.Trigger(job.SearchSuggestTask); err != nil {
+		global.SysLog.Errorf("重建搜索建议索引失败: %v", err)
+	}
+	// 打开全文索引，索引文件不存在或损坏时从数据库重建
+	if err := searchService.InitIndex(context.Background()); err != nil {
+		global.SysLog.Errorf("初始化全文索引失败: %v", err)
+	}
+	// 为升级前创建的文章生成别名
+	if err := postService.BackfillSlugs(); err != nil {
+		global.SysLog.Errorf("生成文章别名失败: %v", err)
+	}
+	// 为升级前创建的文章计算字数与阅读时间
+	if err := postService.BackfillReadingStats(); err != nil {
+		global.SysLog.Errorf("计算文章字数失败: %v", err)
+	}
+	// 为升级前创建的文章补充作者
+	if err := postService.BackfillPostAuthors(); err != nil {
+		global.SysLog.Errorf("补充文章作者失败: %v", err)
+	}
+	// 为升级前已创建管理员的系统记录安装完成
+	if err := setupService.BackfillSetupCompleted(); err != nil {
+		global.SysLog.Errorf("记录安装状态失败: %v", err)
+	}
+	// 为升级前创建的评论渲染 HTML
+	if err := commentService.BackfillCommentHTML(); err != nil {
+		global.SysLog.Errorf("渲染评论失败: %v", err)
+	}
+
 	// 注册路由
 	router.RegisterRoutes(app)
 
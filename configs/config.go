@@ -1,19 +1,21 @@
 package configs
 
 import (
-	"log"
+	"fmt"
+	"sync/atomic"
 
 	"github.com/spf13/viper"
 )
 
 // AppConfig 存储应用相关配置
 type AppConfig struct {
-	AppName   string `mapstructure:"APP_NAME"`
-	AppHost   string `mapstructure:"APP_HOST"`
-	AppPort   string `mapstructure:"APP_PORT"`
-	EmailType string `mapstructure:"EMAIL_TYPE"`
-	FromEmail string `mapstructure:"FROM_EMAIL"`
-	EmailSmtp string `mapstructure:"EMAIL_SMTP"`
+	AppName          string `mapstructure:"APP_NAME"`
+	AppHost          string `mapstructure:"APP_HOST"`
+	AppPort          string `mapstructure:"APP_PORT"`
+	EmailType        string `mapstructure:"EMAIL_TYPE"`
+	FromEmail        string `mapstructure:"FROM_EMAIL"`
+	EmailSmtp        string `mapstructure:"EMAIL_SMTP"`
+	EmailTemplateDir string `mapstructure:"EMAIL_TEMPLATE_DIR"`
 }
 
 // DatabaseConfig 存储数据库相关配置
@@ -50,31 +52,497 @@ type SwaggerConfig struct {
 	SwaggerHost string `mapstructure:"SWAGGER_HOST"`
 }
 
+// SiteConfig 存储站点相关配置
+type SiteConfig struct {
+	SiteTitle           string `mapstructure:"SITE_TITLE"`
+	SiteURL             string `mapstructure:"SITE_URL"`
+	SiteAuthor          string `mapstructure:"SITE_AUTHOR"`
+	SiteTheme           string `mapstructure:"SITE_THEME"`
+	SiteStaticExportDir string `mapstructure:"SITE_STATIC_EXPORT_DIR"`
+}
+
+// AccessLogConfig 存储请求响应日志相关配置
+type AccessLogConfig struct {
+	AccessLogEnabled      bool     `mapstructure:"ACCESS_LOG_ENABLED"`
+	AccessLogSampleRate   float64  `mapstructure:"ACCESS_LOG_SAMPLE_RATE"`
+	AccessLogMaxBodySize  int      `mapstructure:"ACCESS_LOG_MAX_BODY_SIZE"`
+	AccessLogRoutes       []string `mapstructure:"ACCESS_LOG_ROUTES"`
+	AccessLogSkipRoutes   []string `mapstructure:"ACCESS_LOG_SKIP_ROUTES"`
+	AccessLogRedactFields []string `mapstructure:"ACCESS_LOG_REDACT_FIELDS"`
+}
+
+// SearchConfig 存储文章搜索相关配置
+type SearchConfig struct {
+	SearchFuzzyEnabled       bool    `mapstructure:"SEARCH_FUZZY_ENABLED"`
+	SearchFuzzyMaxDistance   int     `mapstructure:"SEARCH_FUZZY_MAX_DISTANCE"`
+	SearchFuzzyPenalty       float64 `mapstructure:"SEARCH_FUZZY_PENALTY"`
+	SearchIndexEnabled       bool    `mapstructure:"SEARCH_INDEX_ENABLED"`
+	SearchIndexDir           string  `mapstructure:"SEARCH_INDEX_DIR"`
+	SearchBackend            string  `mapstructure:"SEARCH_BACKEND"`
+	SearchBackendURL         string  `mapstructure:"SEARCH_BACKEND_URL"`
+	SearchBackendAPIKey      string  `mapstructure:"SEARCH_BACKEND_API_KEY"`
+	SearchBackendIndexPrefix string  `mapstructure:"SEARCH_BACKEND_INDEX_PREFIX"`
+	SearchBackendTimeout     int     `mapstructure:"SEARCH_BACKEND_TIMEOUT"`
+}
+
+// LLMConfig 存储大模型服务相关配置
+type LLMConfig struct {
+	LLMEnabled  bool   `mapstructure:"LLM_ENABLED"`
+	LLMProvider string `mapstructure:"LLM_PROVIDER"`
+	LLMBaseURL  string `mapstructure:"LLM_BASE_URL"`
+	LLMAPIKey   string `mapstructure:"LLM_API_KEY"`
+	LLMModel    string `mapstructure:"LLM_MODEL"`
+	LLMTimeout  int    `mapstructure:"LLM_TIMEOUT"`
+}
+
+// TrashConfig 存储回收站清理相关配置
+type TrashConfig struct {
+	TrashPostRetentionDays    int `mapstructure:"TRASH_POST_RETENTION_DAYS"`
+	TrashCommentRetentionDays int `mapstructure:"TRASH_COMMENT_RETENTION_DAYS"`
+}
+
+// DuplicateConfig 存储重复内容检测相关配置
+type DuplicateConfig struct {
+	DuplicateCheckMode   string `mapstructure:"DUPLICATE_CHECK_MODE"`
+	DuplicateMaxDistance int    `mapstructure:"DUPLICATE_MAX_DISTANCE"`
+}
+
+// VerificationConfig 存储验证码相关配置
+type VerificationConfig struct {
+	VerificationTargetRateLimit  int    `mapstructure:"VERIFICATION_TARGET_RATE_LIMIT"`
+	VerificationTargetRateWindow int    `mapstructure:"VERIFICATION_TARGET_RATE_WINDOW"`
+	VerificationIPRateLimit      int    `mapstructure:"VERIFICATION_IP_RATE_LIMIT"`
+	VerificationIPRateWindow     int    `mapstructure:"VERIFICATION_IP_RATE_WINDOW"`
+	VerificationResendCooldown   int    `mapstructure:"VERIFICATION_RESEND_COOLDOWN"`
+	VerificationMaxAttempts      int    `mapstructure:"VERIFICATION_MAX_ATTEMPTS"`
+	VerificationCodeLength       int    `mapstructure:"VERIFICATION_CODE_LENGTH"`
+	VerificationCodeFormat       string `mapstructure:"VERIFICATION_CODE_FORMAT"`
+	VerificationAllowGet         bool   `mapstructure:"VERIFICATION_ALLOW_GET"`
+	VerificationAuditEnabled     bool   `mapstructure:"VERIFICATION_AUDIT_ENABLED"`
+	VerificationAuditRetention   int    `mapstructure:"VERIFICATION_AUDIT_RETENTION_DAYS"`
+}
+
+// CaptchaConfig 存储人机验证相关配置
+type CaptchaConfig struct {
+	CaptchaProvider        string  `mapstructure:"CAPTCHA_PROVIDER"`
+	CaptchaSiteKey         string  `mapstructure:"CAPTCHA_SITE_KEY"`
+	CaptchaSecretKey       string  `mapstructure:"CAPTCHA_SECRET_KEY"`
+	CaptchaMinScore        float64 `mapstructure:"CAPTCHA_MIN_SCORE"`
+	CaptchaTimeout         int     `mapstructure:"CAPTCHA_TIMEOUT"`
+	CaptchaImgLength       int     `mapstructure:"CAPTCHA_IMG_LENGTH"`
+	CaptchaImgCharset      string  `mapstructure:"CAPTCHA_IMG_CHARSET"`
+	CaptchaImgNoiseCount   int     `mapstructure:"CAPTCHA_IMG_NOISE_COUNT"`
+	CaptchaImgWidth        int     `mapstructure:"CAPTCHA_IMG_WIDTH"`
+	CaptchaImgHeight       int     `mapstructure:"CAPTCHA_IMG_HEIGHT"`
+	CaptchaSliderWidth     int     `mapstructure:"CAPTCHA_SLIDER_WIDTH"`
+	CaptchaSliderHeight    int     `mapstructure:"CAPTCHA_SLIDER_HEIGHT"`
+	CaptchaSliderTolerance int     `mapstructure:"CAPTCHA_SLIDER_TOLERANCE"`
+}
+
+// SmsConfig 存储短信服务相关配置
+type SmsConfig struct {
+	SmsProvider        string `mapstructure:"SMS_PROVIDER"`
+	SmsAccessKeyID     string `mapstructure:"SMS_ACCESS_KEY_ID"`
+	SmsAccessKeySecret string `mapstructure:"SMS_ACCESS_KEY_SECRET"`
+	SmsSignName        string `mapstructure:"SMS_SIGN_NAME"`
+	SmsTemplateCode    string `mapstructure:"SMS_TEMPLATE_CODE"`
+	SmsSdkAppID        string `mapstructure:"SMS_SDK_APP_ID"`
+	SmsRegion          string `mapstructure:"SMS_REGION"`
+	SmsFrom            string `mapstructure:"SMS_FROM"`
+	SmsTimeout         int    `mapstructure:"SMS_TIMEOUT"`
+}
+
+// MailQueueConfig 存储邮件发送队列相关配置
+type MailQueueConfig struct {
+	MailQueueEnabled      bool `mapstructure:"MAIL_QUEUE_ENABLED"`
+	MailQueuePollInterval int  `mapstructure:"MAIL_QUEUE_POLL_INTERVAL"`
+	MailQueueBatchSize    int  `mapstructure:"MAIL_QUEUE_BATCH_SIZE"`
+	MailQueueMaxAttempts  int  `mapstructure:"MAIL_QUEUE_MAX_ATTEMPTS"`
+	MailQueueBackoffBase  int  `mapstructure:"MAIL_QUEUE_BACKOFF_BASE"`
+	MailQueueBackoffMax   int  `mapstructure:"MAIL_QUEUE_BACKOFF_MAX"`
+}
+
+// DisposableEmailConfig 存储一次性邮箱域名黑名单相关配置
+type DisposableEmailConfig struct {
+	DisposableEmailEnabled         bool   `mapstructure:"DISPOSABLE_EMAIL_ENABLED"`
+	DisposableEmailFile            string `mapstructure:"DISPOSABLE_EMAIL_FILE"`
+	DisposableEmailURL             string `mapstructure:"DISPOSABLE_EMAIL_URL"`
+	DisposableEmailRefreshInterval int    `mapstructure:"DISPOSABLE_EMAIL_REFRESH_INTERVAL"`
+}
+
+// MetricsConfig 存储运行指标相关配置
+type MetricsConfig struct {
+	MetricsEnabled bool   `mapstructure:"METRICS_ENABLED"`
+	MetricsToken   string `mapstructure:"METRICS_TOKEN"`
+}
+
+// CacheConfig 存储缓存驱动相关配置
+type CacheConfig struct {
+	CacheDriver              string `mapstructure:"CACHE_DRIVER"`
+	CacheHealthCheckInterval int    `mapstructure:"CACHE_HEALTH_CHECK_INTERVAL"`
+}
+
+// MailBreakerConfig 存储邮件发送熔断相关配置
+type MailBreakerConfig struct {
+	MailBreakerThreshold    int `mapstructure:"MAIL_BREAKER_THRESHOLD"`
+	MailBreakerOpenDuration int `mapstructure:"MAIL_BREAKER_OPEN_DURATION"`
+	MailSendTimeout         int `mapstructure:"MAIL_SEND_TIMEOUT"`
+}
+
+// MessengerConfig 存储即时通讯验证码相关配置
+type MessengerConfig struct {
+	MessengerTelegramBotToken      string `mapstructure:"MESSENGER_TELEGRAM_BOT_TOKEN"`
+	MessengerWhatsAppToken         string `mapstructure:"MESSENGER_WHATSAPP_TOKEN"`
+	MessengerWhatsAppPhoneNumberID string `mapstructure:"MESSENGER_WHATSAPP_PHONE_NUMBER_ID"`
+	MessengerWhatsAppTemplate      string `mapstructure:"MESSENGER_WHATSAPP_TEMPLATE"`
+	MessengerWhatsAppLanguage      string `mapstructure:"MESSENGER_WHATSAPP_LANGUAGE"`
+	MessengerTimeout               int    `mapstructure:"MESSENGER_TIMEOUT"`
+}
+
+// OAuthConfig 存储第三方登录相关配置
+type OAuthConfig struct {
+	OAuthRedirectBaseURL    string `mapstructure:"OAUTH_REDIRECT_BASE_URL"`
+	OAuthGitHubClientID     string `mapstructure:"OAUTH_GITHUB_CLIENT_ID"`
+	OAuthGitHubClientSecret string `mapstructure:"OAUTH_GITHUB_CLIENT_SECRET"`
+	OAuthGoogleClientID     string `mapstructure:"OAUTH_GOOGLE_CLIENT_ID"`
+	OAuthGoogleClientSecret string `mapstructure:"OAUTH_GOOGLE_CLIENT_SECRET"`
+	OAuthGiteeClientID      string `mapstructure:"OAUTH_GITEE_CLIENT_ID"`
+	OAuthGiteeClientSecret  string `mapstructure:"OAUTH_GITEE_CLIENT_SECRET"`
+	OAuthTimeout            int    `mapstructure:"OAUTH_TIMEOUT"`
+}
+
+// OIDCRoleMapping OIDC 角色声明的值与本地角色的对应关系
+type OIDCRoleMapping struct {
+	Value string `mapstructure:"VALUE"`
+	Role  string `mapstructure:"ROLE"`
+}
+
+// OIDCConfig 存储通用 OpenID Connect 单点登录相关配置
+type OIDCConfig struct {
+	OIDCIssuer       string            `mapstructure:"OIDC_ISSUER"`
+	OIDCClientID     string            `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string            `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCScopes       []string          `mapstructure:"OIDC_SCOPES"`
+	OIDCEmailClaim   string            `mapstructure:"OIDC_EMAIL_CLAIM"`
+	OIDCNameClaim    string            `mapstructure:"OIDC_NAME_CLAIM"`
+	OIDCAvatarClaim  string            `mapstructure:"OIDC_AVATAR_CLAIM"`
+	OIDCRolesClaim   string            `mapstructure:"OIDC_ROLES_CLAIM"`
+	OIDCRoleMappings []OIDCRoleMapping `mapstructure:"OIDC_ROLE_MAPPINGS"`
+	OIDCDefaultRole  string            `mapstructure:"OIDC_DEFAULT_ROLE"`
+	OIDCTrustEmail   bool              `mapstructure:"OIDC_TRUST_EMAIL"`
+	OIDCUseUserInfo  bool              `mapstructure:"OIDC_USE_USERINFO"`
+}
+
+// WebAuthnConfig 存储通行密钥相关配置
+type WebAuthnConfig struct {
+	WebAuthnRPID    string   `mapstructure:"WEBAUTHN_RP_ID"`
+	WebAuthnRPName  string   `mapstructure:"WEBAUTHN_RP_NAME"`
+	WebAuthnOrigins []string `mapstructure:"WEBAUTHN_ORIGINS"`
+	WebAuthnTimeout int      `mapstructure:"WEBAUTHN_TIMEOUT"`
+}
+
+// TotpConfig 存储两步验证相关配置
+type TotpConfig struct {
+	TotpRequiredRoles     []string `mapstructure:"TOTP_REQUIRED_ROLES"`
+	TotpLoginTimeout      int      `mapstructure:"TOTP_LOGIN_TIMEOUT"`
+	TotpRecoveryCodeCount int      `mapstructure:"TOTP_RECOVERY_CODE_COUNT"`
+}
+
+// PasswordResetConfig 存储邮件链接重置密码相关配置
+type PasswordResetConfig struct {
+	PasswordResetURL    string `mapstructure:"PASSWORD_RESET_URL"`
+	PasswordResetExpire int    `mapstructure:"PASSWORD_RESET_EXPIRE"`
+}
+
+// MagicLinkConfig 存储邮件链接免密登录相关配置
+type MagicLinkConfig struct {
+	MagicLinkEnabled bool   `mapstructure:"MAGIC_LINK_ENABLED"`
+	MagicLinkURL     string `mapstructure:"MAGIC_LINK_URL"`
+	MagicLinkExpire  int    `mapstructure:"MAGIC_LINK_EXPIRE"`
+}
+
+// LoginLockoutConfig 存储登录失败锁定相关配置
+type LoginLockoutConfig struct {
+	LoginLockoutAccountThreshold int    `mapstructure:"LOGIN_LOCKOUT_ACCOUNT_THRESHOLD"`
+	LoginLockoutIPThreshold      int    `mapstructure:"LOGIN_LOCKOUT_IP_THRESHOLD"`
+	LoginLockoutWindow           int    `mapstructure:"LOGIN_LOCKOUT_WINDOW"`
+	LoginLockoutDuration         int    `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	LoginLockoutUnlockURL        string `mapstructure:"LOGIN_LOCKOUT_UNLOCK_URL"`
+	LoginCaptchaThreshold        int    `mapstructure:"LOGIN_CAPTCHA_THRESHOLD"`
+	LoginCaptchaDecay            int    `mapstructure:"LOGIN_CAPTCHA_DECAY"`
+}
+
+// PasswordPolicyConfig 存储密码强度策略相关配置
+type PasswordPolicyConfig struct {
+	PasswordPolicyMinLength      int    `mapstructure:"PASSWORD_POLICY_MIN_LENGTH"`
+	PasswordPolicyMaxLength      int    `mapstructure:"PASSWORD_POLICY_MAX_LENGTH"`
+	PasswordPolicyMinCharClasses int    `mapstructure:"PASSWORD_POLICY_MIN_CHAR_CLASSES"`
+	PasswordPolicyRequireLower   bool   `mapstructure:"PASSWORD_POLICY_REQUIRE_LOWER"`
+	PasswordPolicyRequireUpper   bool   `mapstructure:"PASSWORD_POLICY_REQUIRE_UPPER"`
+	PasswordPolicyRequireDigit   bool   `mapstructure:"PASSWORD_POLICY_REQUIRE_DIGIT"`
+	PasswordPolicyRequireSymbol  bool   `mapstructure:"PASSWORD_POLICY_REQUIRE_SYMBOL"`
+	PasswordPolicyBanCommon      bool   `mapstructure:"PASSWORD_POLICY_BAN_COMMON"`
+	PasswordPolicyBannedFile     string `mapstructure:"PASSWORD_POLICY_BANNED_FILE"`
+	PasswordPolicyDisallowEmail  bool   `mapstructure:"PASSWORD_POLICY_DISALLOW_EMAIL"`
+}
+
+// PasswordHashConfig 存储密码哈希相关配置
+type PasswordHashConfig struct {
+	PasswordHashAlgorithm         string `mapstructure:"PASSWORD_HASH_ALGORITHM"`
+	PasswordHashArgon2Memory      int    `mapstructure:"PASSWORD_HASH_ARGON2_MEMORY"`
+	PasswordHashArgon2Iterations  int    `mapstructure:"PASSWORD_HASH_ARGON2_ITERATIONS"`
+	PasswordHashArgon2Parallelism int    `mapstructure:"PASSWORD_HASH_ARGON2_PARALLELISM"`
+	PasswordHashBcryptCost        int    `mapstructure:"PASSWORD_HASH_BCRYPT_COST"`
+}
+
+// LoginHistoryConfig 存储登录记录相关配置
+type LoginHistoryConfig struct {
+	LoginHistoryEnabled        bool   `mapstructure:"LOGIN_HISTORY_ENABLED"`
+	LoginHistoryNewDeviceAlert bool   `mapstructure:"LOGIN_HISTORY_NEW_DEVICE_ALERT"`
+	LoginHistoryGeoHeader      string `mapstructure:"LOGIN_HISTORY_GEO_HEADER"`
+	LoginHistoryGeoAPI         string `mapstructure:"LOGIN_HISTORY_GEO_API"`
+	LoginHistoryGeoTimeout     int    `mapstructure:"LOGIN_HISTORY_GEO_TIMEOUT"`
+}
+
+// LdapGroupRole LDAP 组与本地角色的对应关系
+type LdapGroupRole struct {
+	Group string `mapstructure:"GROUP"`
+	Role  string `mapstructure:"ROLE"`
+}
+
+// LdapConfig 存储 LDAP 登录相关配置
+type LdapConfig struct {
+	LdapEnabled            bool            `mapstructure:"LDAP_ENABLED"`
+	LdapURL                string          `mapstructure:"LDAP_URL"`
+	LdapStartTLS           bool            `mapstructure:"LDAP_START_TLS"`
+	LdapInsecureSkipVerify bool            `mapstructure:"LDAP_INSECURE_SKIP_VERIFY"`
+	LdapBindDN             string          `mapstructure:"LDAP_BIND_DN"`
+	LdapBindPassword       string          `mapstructure:"LDAP_BIND_PASSWORD"`
+	LdapBaseDN             string          `mapstructure:"LDAP_BASE_DN"`
+	LdapUserFilter         string          `mapstructure:"LDAP_USER_FILTER"`
+	LdapUIDAttribute       string          `mapstructure:"LDAP_UID_ATTRIBUTE"`
+	LdapEmailAttribute     string          `mapstructure:"LDAP_EMAIL_ATTRIBUTE"`
+	LdapNicknameAttribute  string          `mapstructure:"LDAP_NICKNAME_ATTRIBUTE"`
+	LdapGroupAttribute     string          `mapstructure:"LDAP_GROUP_ATTRIBUTE"`
+	LdapGroupBaseDN        string          `mapstructure:"LDAP_GROUP_BASE_DN"`
+	LdapGroupFilter        string          `mapstructure:"LDAP_GROUP_FILTER"`
+	LdapGroupRoles         []LdapGroupRole `mapstructure:"LDAP_GROUP_ROLES"`
+	LdapDefaultRole        string          `mapstructure:"LDAP_DEFAULT_ROLE"`
+	LdapAutoProvision      bool            `mapstructure:"LDAP_AUTO_PROVISION"`
+	LdapSyncProfile        bool            `mapstructure:"LDAP_SYNC_PROFILE"`
+	LdapTimeout            int             `mapstructure:"LDAP_TIMEOUT"`
+}
+
+// AccountDeletionConfig 存储注销账户相关配置
+type AccountDeletionConfig struct {
+	AccountDeletionGraceDays int `mapstructure:"ACCOUNT_DELETION_GRACE_DAYS"`
+}
+
+// DataExportConfig 存储个人数据导出相关配置
+type DataExportConfig struct {
+	DataExportDir      string `mapstructure:"DATA_EXPORT_DIR"`
+	DataExportLinkTTL  int    `mapstructure:"DATA_EXPORT_LINK_TTL"`
+	DataExportCooldown int    `mapstructure:"DATA_EXPORT_COOLDOWN"`
+}
+
+// AvatarConfig 存储头像上传相关配置
+type AvatarConfig struct {
+	AvatarDir         string `mapstructure:"AVATAR_DIR"`
+	AvatarSize        int    `mapstructure:"AVATAR_SIZE"`
+	AvatarMaxSize     int    `mapstructure:"AVATAR_MAX_SIZE"`
+	AvatarURLPrefix   string `mapstructure:"AVATAR_URL_PREFIX"`
+	AvatarFallback    string `mapstructure:"AVATAR_FALLBACK"`
+	AvatarGravatarURL string `mapstructure:"AVATAR_GRAVATAR_URL"`
+	AvatarCacheDir    string `mapstructure:"AVATAR_CACHE_DIR"`
+	AvatarCacheTTL    int    `mapstructure:"AVATAR_CACHE_TTL"`
+}
+
+// InviteConfig 存储邀请注册相关配置
+type InviteConfig struct {
+	InviteRequired    bool `mapstructure:"INVITE_REQUIRED"`
+	InviteUserEnabled bool `mapstructure:"INVITE_USER_ENABLED"`
+	InviteUserLimit   int  `mapstructure:"INVITE_USER_LIMIT"`
+	InviteUserMaxUses int  `mapstructure:"INVITE_USER_MAX_USES"`
+	InviteDefaultTTL  int  `mapstructure:"INVITE_DEFAULT_TTL"`
+}
+
+// RegistrationConfig 存储注册开放方式相关配置
+type RegistrationConfig struct {
+	RegistrationMode         string `mapstructure:"REGISTRATION_MODE"`
+	RegistrationNotifyAdmins bool   `mapstructure:"REGISTRATION_NOTIFY_ADMINS"`
+}
+
+// JWTConfig 存储 JWT 签名密钥轮换相关配置
+type JWTConfig struct {
+	JWTKeyRotationDays int `mapstructure:"JWT_KEY_ROTATION_DAYS"`
+	JWTKeyGraceHours   int `mapstructure:"JWT_KEY_GRACE_HOURS"`
+}
+
+// SessionConfig 存储登录会话相关配置
+type SessionConfig struct {
+	SessionSingleActive bool `mapstructure:"SESSION_SINGLE_ACTIVE"`
+	RememberMeEnabled   bool `mapstructure:"REMEMBER_ME_ENABLED"`
+	RememberMeDays      int  `mapstructure:"REMEMBER_ME_DAYS"`
+}
+
+// IPAccessConfig 存储管理接口 IP 访问控制相关配置
+type IPAccessConfig struct {
+	AdminIPAllowlist []string `mapstructure:"ADMIN_IP_ALLOWLIST"`
+	AdminIPDenylist  []string `mapstructure:"ADMIN_IP_DENYLIST"`
+	TrustedProxies   []string `mapstructure:"TRUSTED_PROXIES"`
+}
+
+// VisitorConfig 存储匿名访客身份相关配置
+type VisitorConfig struct {
+	VisitorEnabled bool   `mapstructure:"VISITOR_ENABLED"`
+	VisitorSecret  string `mapstructure:"VISITOR_SECRET"`
+	VisitorTTLDays int    `mapstructure:"VISITOR_TTL_DAYS"`
+}
+
+// SecurityNoticeConfig 存储账户安全提醒邮件相关配置
+type SecurityNoticeConfig struct {
+	SecurityNoticeEnabled bool `mapstructure:"SECURITY_NOTICE_ENABLED"`
+}
+
+// WebhookConfig 存储 Webhook 通知相关配置
+type WebhookConfig struct {
+	WebhookURLs        []string `mapstructure:"WEBHOOK_URLS"`
+	WebhookSecret      string   `mapstructure:"WEBHOOK_SECRET"`
+	WebhookTimeout     int      `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts int      `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+}
+
+// RevisionConfig 存储文章修订记录相关配置
+type RevisionConfig struct {
+	RevisionMaxPerPost    int `mapstructure:"REVISION_MAX_PER_POST"`
+	RevisionRetentionDays int `mapstructure:"REVISION_RETENTION_DAYS"`
+}
+
+// SitemapConfig 存储站点地图相关配置
+type SitemapConfig struct {
+	SitemapEnabled     bool     `mapstructure:"SITEMAP_ENABLED"`
+	SitemapMaxURLs     int      `mapstructure:"SITEMAP_MAX_URLS"`
+	SitemapCacheMaxAge int      `mapstructure:"SITEMAP_CACHE_MAX_AGE"`
+	SitemapStaticPages []string `mapstructure:"SITEMAP_STATIC_PAGES"`
+	SitemapPingURLs    []string `mapstructure:"SITEMAP_PING_URLS"`
+}
+
+// ViewCounterConfig 存储文章浏览量统计相关配置
+type ViewCounterConfig struct {
+	ViewCounterEnabled bool `mapstructure:"VIEW_COUNTER_ENABLED"`
+	ViewDedupWindow    int  `mapstructure:"VIEW_DEDUP_WINDOW"`
+	ViewFlushInterval  int  `mapstructure:"VIEW_FLUSH_INTERVAL"`
+	TrendingDays       int  `mapstructure:"TRENDING_DAYS"`
+}
+
+// ReactionConfig 存储文章表态相关配置
+type ReactionConfig struct {
+	ReactionTypes        []string `mapstructure:"REACTION_TYPES"`
+	ReactionGuestEnabled bool     `mapstructure:"REACTION_GUEST_ENABLED"`
+}
+
+// MarkdownConfig 存储 Markdown 渲染相关配置
+type MarkdownConfig struct {
+	MarkdownExtensions   []string `mapstructure:"MARKDOWN_EXTENSIONS"`
+	MarkdownHardWraps    bool     `mapstructure:"MARKDOWN_HARD_WRAPS"`
+	HighlightEnabled     bool     `mapstructure:"HIGHLIGHT_ENABLED"`
+	HighlightTheme       string   `mapstructure:"HIGHLIGHT_THEME"`
+	HighlightLineNumbers bool     `mapstructure:"HIGHLIGHT_LINE_NUMBERS"`
+}
+
+// SanitizerConfig 存储渲染内容 HTML 过滤相关配置
+type SanitizerConfig struct {
+	SanitizerRawHTML           bool     `mapstructure:"SANITIZER_RAW_HTML"`
+	SanitizerAllowedElements   []string `mapstructure:"SANITIZER_ALLOWED_ELEMENTS"`
+	SanitizerAllowedAttributes []string `mapstructure:"SANITIZER_ALLOWED_ATTRIBUTES"`
+	SanitizerIframeDomains     []string `mapstructure:"SANITIZER_IFRAME_DOMAINS"`
+}
+
 // Config 存储所有配置项
 type Config struct {
-	AppConfig     AppConfig      `mapstructure:"app"`
-	DBConfig      DatabaseConfig `mapstructure:"database"`
-	RedisConfig   RedisConfig    `mapstructure:"redis"`
-	LogConfig     LogConfig      `mapstructure:"log"`
-	SwaggerConfig SwaggerConfig  `mapstructure:"swagger"`
+	AppConfig             AppConfig             `mapstructure:"app"`
+	DBConfig              DatabaseConfig        `mapstructure:"database"`
+	RedisConfig           RedisConfig           `mapstructure:"redis"`
+	LogConfig             LogConfig             `mapstructure:"log"`
+	SwaggerConfig         SwaggerConfig         `mapstructure:"swagger"`
+	SiteConfig            SiteConfig            `mapstructure:"site"`
+	AccessLogConfig       AccessLogConfig       `mapstructure:"access_log"`
+	SearchConfig          SearchConfig          `mapstructure:"search"`
+	LLMConfig             LLMConfig             `mapstructure:"llm"`
+	TrashConfig           TrashConfig           `mapstructure:"trash"`
+	DuplicateConfig       DuplicateConfig       `mapstructure:"duplicate"`
+	VerificationConfig    VerificationConfig    `mapstructure:"verification"`
+	CaptchaConfig         CaptchaConfig         `mapstructure:"captcha"`
+	SmsConfig             SmsConfig             `mapstructure:"sms"`
+	MailQueueConfig       MailQueueConfig       `mapstructure:"mail_queue"`
+	DisposableEmailConfig DisposableEmailConfig `mapstructure:"disposable_email"`
+	MetricsConfig         MetricsConfig         `mapstructure:"metrics"`
+	CacheConfig           CacheConfig           `mapstructure:"cache"`
+	MailBreakerConfig     MailBreakerConfig     `mapstructure:"mail_breaker"`
+	MessengerConfig       MessengerConfig       `mapstructure:"messenger"`
+	OAuthConfig           OAuthConfig           `mapstructure:"oauth"`
+	OIDCConfig            OIDCConfig            `mapstructure:"oidc"`
+	WebAuthnConfig        WebAuthnConfig        `mapstructure:"webauthn"`
+	TotpConfig            TotpConfig            `mapstructure:"totp"`
+	PasswordResetConfig   PasswordResetConfig   `mapstructure:"password_reset"`
+	MagicLinkConfig       MagicLinkConfig       `mapstructure:"magic_link"`
+	LoginLockoutConfig    LoginLockoutConfig    `mapstructure:"login_lockout"`
+	PasswordPolicyConfig  PasswordPolicyConfig  `mapstructure:"password_policy"`
+	PasswordHashConfig    PasswordHashConfig    `mapstructure:"password_hash"`
+	LoginHistoryConfig    LoginHistoryConfig    `mapstructure:"login_history"`
+	LdapConfig            LdapConfig            `mapstructure:"ldap"`
+	AccountDeletionConfig AccountDeletionConfig `mapstructure:"account_deletion"`
+	DataExportConfig      DataExportConfig      `mapstructure:"data_export"`
+	AvatarConfig          AvatarConfig          `mapstructure:"avatar"`
+	InviteConfig          InviteConfig          `mapstructure:"invite"`
+	RegistrationConfig    RegistrationConfig    `mapstructure:"registration"`
+	JWTConfig             JWTConfig             `mapstructure:"jwt"`
+	SessionConfig         SessionConfig         `mapstructure:"session"`
+	IPAccessConfig        IPAccessConfig        `mapstructure:"ip_access"`
+	VisitorConfig         VisitorConfig         `mapstructure:"visitor"`
+	SecurityNoticeConfig  SecurityNoticeConfig  `mapstructure:"security_notice"`
+	WebhookConfig         WebhookConfig         `mapstructure:"webhook"`
+	RevisionConfig        RevisionConfig        `mapstructure:"revision"`
+	SitemapConfig         SitemapConfig         `mapstructure:"sitemap"`
+	ViewCounterConfig     ViewCounterConfig     `mapstructure:"view_counter"`
+	ReactionConfig        ReactionConfig        `mapstructure:"reaction"`
+	MarkdownConfig        MarkdownConfig        `mapstructure:"markdown"`
+	SanitizerConfig       SanitizerConfig       `mapstructure:"sanitizer"`
 }
 
-// LoadConfig 加载配置文件
+const configFile = "./configs/config.yml"
+
+var overrides atomic.Value // 运行时修改的配置项，加载配置文件后覆盖文件中的同名配置
+
+// LoadConfig 加载配置文件，并以 SetOverrides 设置的配置项覆盖文件中的同名配置
 func LoadConfig() (*Config, error) {
-	viper.SetConfigFile("./configs/config.yml")
+	v := viper.New()
+	v.SetConfigFile(configFile)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("配置文件加载失败：%v", err)
+	}
 
-	err := viper.ReadInConfig()
-	if err != nil {
-		log.Fatalf("配置文件加载失败：%v", err)
-		return nil, err
+	if values, ok := overrides.Load().(map[string]string); ok {
+		for key, value := range values {
+			v.Set(key, value)
+		}
 	}
 
 	var config Config
-	err = viper.Unmarshal(&config)
-	if err != nil {
-		log.Fatalf("配置解析失败：%v", err)
-		return nil, err
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("配置解析失败：%v", err)
 	}
 
 	return &config, nil
 }
+
+// SetOverrides 替换运行时修改的配置项，key 格式为 "section.KEY"，如 "site.SITE_TITLE"；
+// 配置项只保存在内存中，不写回配置文件，由调用方负责持久化
+func SetOverrides(values map[string]string) {
+	copied := make(map[string]string, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+	overrides.Store(copied)
+}
@@ -0,0 +1,130 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // 注册 GIF 解码器，动图只取第一帧
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// 未配置时的默认值
+const (
+	DefaultDir       = "./uploads/avatars" // 头像的存放目录
+	DefaultSize      = 256                 // 输出头像的边长
+	DefaultMaxPixels = 40000000            // 上传图片允许的最大像素数，防止解码超大图片耗尽内存
+	minSize          = 32                  // 输出头像的最小边长
+	maxSize          = 1024                // 输出头像的最大边长
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("不支持的图片格式，仅支持 JPEG、PNG、GIF 与 WebP")
+	ErrImageTooLarge     = errors.New("图片尺寸过大")
+	ErrCropOutOfBounds   = errors.New("裁剪区域超出图片范围")
+	ErrInvalidFileName   = errors.New("头像文件名无效")
+)
+
+// fileExt 头像文件的扩展名
+const fileExt = ".png"
+
+// Options 头像处理参数
+type Options struct {
+	Size      int              // 输出头像的边长，超出 32 至 1024 时取边界值，为 0 时使用默认值
+	MaxPixels int              // 上传图片允许的最大像素数，为 0 时使用默认值
+	Crop      *image.Rectangle // 裁剪区域，坐标以原图左上角为原点，为空时从中心裁剪最大的正方形
+}
+
+// Process 解码上传的图片，按裁剪区域裁剪后缩放为正方形头像，返回 PNG 编码的头像
+func Process(r io.Reader, opts Options) ([]byte, error) {
+	if opts.Size == 0 {
+		opts.Size = DefaultSize
+	}
+	opts.Size = min(max(opts.Size, minSize), maxSize)
+	if opts.MaxPixels <= 0 {
+		opts.MaxPixels = DefaultMaxPixels
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取图片失败: %v", err)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > opts.MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("解码图片失败: %v", err)
+	}
+
+	bounds := src.Bounds()
+	var crop image.Rectangle
+	if opts.Crop != nil {
+		crop = opts.Crop.Add(bounds.Min)
+		if crop.Empty() || !crop.In(bounds) {
+			return nil, ErrCropOutOfBounds
+		}
+	} else {
+		side := min(bounds.Dx(), bounds.Dy())
+		x := bounds.Min.X + (bounds.Dx()-side)/2
+		y := bounds.Min.Y + (bounds.Dy()-side)/2
+		crop = image.Rect(x, y, x+side, y+side)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, opts.Size, opts.Size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, dst); err != nil {
+		return nil, fmt.Errorf("编码头像失败: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// FileName 头像的文件名，由账户 ID 与随机 ID 组成，每次上传生成新文件名以避开浏览器与 CDN 缓存
+func FileName(accountID int64, id string) string {
+	return fmt.Sprintf("%d-%s%s", accountID, id, fileExt)
+}
+
+// Path 校验头像文件名并返回头像在 dir 中的路径，文件名只能由 FileName 生成，防止越出目录
+func Path(dir, name string) (string, error) {
+	base, ok := strings.CutSuffix(name, fileExt)
+	if !ok {
+		return "", ErrInvalidFileName
+	}
+	accountID, id, ok := strings.Cut(base, "-")
+	if !ok || id == "" || strings.Trim(id, "0123456789abcdef") != "" {
+		return "", ErrInvalidFileName
+	}
+	if _, err := strconv.ParseInt(accountID, 10, 64); err != nil {
+		return "", ErrInvalidFileName
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// RemoveAccountAvatars 删除 dir 中属于账户的全部头像，目录不存在时不删除
+func RemoveAccountAvatars(dir string, accountID int64) error {
+	matches, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%d-*%s", accountID, fileExt)))
+	if err != nil {
+		return fmt.Errorf("查找头像失败: %v", err)
+	}
+	for _, match := range matches {
+		if err := os.Remove(match); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("删除头像失败: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/global"
+)
+
+// 默认头像的来源
+const (
+	FallbackIdenticon = "identicon" // 根据哈希生成对称像素图案
+	FallbackGravatar  = "gravatar"  // 代理 Gravatar 头像，不存在时回退到像素图案
+)
+
+// 默认头像未配置时的默认值
+const (
+	DefaultFallbackSize = 80                                 // 未指定边长时的默认边长，与 Gravatar 一致
+	DefaultGravatarURL  = "https://www.gravatar.com/avatar/" // Gravatar 头像地址前缀
+	DefaultCacheDir     = "./uploads/avatars/cache"          // Gravatar 头像的本地缓存目录
+	DefaultCacheTTL     = 24 * time.Hour                     // Gravatar 头像的缓存有效期
+	minFallbackSize     = 32                                 // 默认头像的最小边长
+	maxFallbackSize     = 512                                // 默认头像的最大边长，也是从 Gravatar 获取并缓存的边长
+)
+
+// 出站请求限制
+const (
+	gravatarTimeout = 5 * time.Second // 请求 Gravatar 的超时时间
+	gravatarMaxBody = 1 << 20         // 最多读取的响应体字节数
+)
+
+// GravatarMissCacheKeyPrefix 在 Gravatar 上不存在的哈希，缓存有效期内不再请求 Gravatar，键为前缀加哈希
+const GravatarMissCacheKeyPrefix = "AVATAR:GRAVATAR_MISS:"
+
+var (
+	ErrInvalidHash      = errors.New("头像哈希无效")
+	errGravatarNotFound = errors.New("Gravatar 头像不存在")
+)
+
+var (
+	gravatarClient = &http.Client{Timeout: gravatarTimeout}
+	gravatarGroup  singleflight.Group // 合并同一哈希的并发请求
+)
+
+// FallbackOptions 默认头像参数
+type FallbackOptions struct {
+	Source      string        // 默认头像的来源，为空时使用像素图案
+	GravatarURL string        // Gravatar 头像地址前缀，为空时使用默认值
+	CacheDir    string        // Gravatar 头像的本地缓存目录，为空时使用默认值
+	CacheTTL    time.Duration // Gravatar 头像的缓存有效期，为 0 时使用默认值
+}
+
+// EmailHash 邮箱的 SHA-256 哈希，与 Gravatar 的规则一致，邮箱先去除首尾空白并转为小写
+func EmailHash(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
+
+// FallbackSize 将请求的边长限制在 32 至 512 之间，为 0 时使用默认边长
+func FallbackSize(size int) int {
+	if size == 0 {
+		return DefaultFallbackSize
+	}
+	return min(max(size, minFallbackSize), maxFallbackSize)
+}
+
+// Fallback 获取未上传头像时的默认头像，hash 为邮箱的 MD5 或 SHA-256 哈希，返回 PNG 编码的头像；
+// 代理 Gravatar 时由服务端请求并缓存在本地，访客不会直接访问 Gravatar，请求失败或头像不存在时回退到像素图案
+func Fallback(ctx context.Context, hash string, size int, opts FallbackOptions) ([]byte, error) {
+	hash = strings.ToLower(hash)
+	if (len(hash) != 32 && len(hash) != 64) || strings.Trim(hash, "0123456789abcdef") != "" {
+		return nil, ErrInvalidHash
+	}
+	size = FallbackSize(size)
+
+	if opts.Source == FallbackGravatar {
+		fillFallbackOptions(&opts)
+		original, err := cachedGravatar(ctx, hash, opts)
+		if err == nil {
+			return Process(bytes.NewReader(original), Options{Size: size})
+		}
+		if !errors.Is(err, errGravatarNotFound) {
+			global.BizLog.Errorf("获取 Gravatar 头像失败，使用像素图案: %v", err)
+		}
+	}
+	return Identicon(hash, size)
+}
+
+// PurgeCache 删除 dir 中在 before 之前缓存的 Gravatar 头像，返回被删除的文件名，目录不存在时不删除
+func PurgeCache(dir string, before time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取头像缓存目录失败: %v", err)
+	}
+
+	var removed []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fileExt {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("删除头像缓存失败: %v", err)
+		}
+		removed = append(removed, entry.Name())
+	}
+	return removed, nil
+}
+
+// fillFallbackOptions 为未配置的项填入默认值
+func fillFallbackOptions(opts *FallbackOptions) {
+	if opts.GravatarURL == "" {
+		opts.GravatarURL = DefaultGravatarURL
+	}
+	if opts.CacheDir == "" {
+		opts.CacheDir = DefaultCacheDir
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = DefaultCacheTTL
+	}
+}
+
+// cachedGravatar 读取本地缓存的 Gravatar 头像，缓存不存在或已过期时重新请求；
+// 头像不存在时返回 errGravatarNotFound，并在缓存有效期内不再请求
+func cachedGravatar(ctx context.Context, hash string, opts FallbackOptions) ([]byte, error) {
+	path := filepath.Join(opts.CacheDir, hash+fileExt)
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < opts.CacheTTL {
+		if data, err := os.ReadFile(path); err == nil {
+			return data, nil
+		}
+	}
+	missKey := GravatarMissCacheKeyPrefix + hash
+	if _, err := cache.Current().Get(ctx, missKey); err == nil {
+		return nil, errGravatarNotFound
+	}
+
+	data, err, _ := gravatarGroup.Do(hash, func() (interface{}, error) {
+		// 使用独立的上下文，避免首个请求取消时同时等待的请求一并失败
+		ctx, cancel := context.WithTimeout(context.Background(), gravatarTimeout)
+		defer cancel()
+		data, err := fetchGravatar(ctx, opts.GravatarURL, hash)
+		if errors.Is(err, errGravatarNotFound) {
+			_ = cache.Current().Set(ctx, missKey, "1", opts.CacheTTL)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := writeCache(opts.CacheDir, path, data); err != nil {
+			global.BizLog.Errorf("%v", err)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data.([]byte), nil
+}
+
+// fetchGravatar 请求最大边长的 Gravatar 头像并重新编码为 PNG，头像不存在时返回 errGravatarNotFound
+func fetchGravatar(ctx context.Context, baseURL, hash string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s?s=%d&d=404", baseURL, hash, maxFallbackSize), nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建 Gravatar 请求失败: %v", err)
+	}
+	resp, err := gravatarClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求 Gravatar 失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errGravatarNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("请求 Gravatar 失败: HTTP %d", resp.StatusCode)
+	}
+	// 重新编码以统一格式，并剔除图片中的元数据
+	return Process(io.LimitReader(resp.Body, gravatarMaxBody), Options{Size: maxFallbackSize})
+}
+
+// writeCache 先写入临时文件再重命名，避免并发读取到写了一半的文件
+func writeCache(dir, path string, data []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("创建头像缓存目录失败: %v", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".gravatar-*")
+	if err != nil {
+		return fmt.Errorf("写入头像缓存失败: %v", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("写入头像缓存失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/draw"
+)
+
+// identiconGrid 图案的格数，左右对称，只有左侧 3 列由哈希决定
+const identiconGrid = 5
+
+// identiconBackground 图案的背景色
+var identiconBackground = color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+// Identicon 根据哈希生成边长为 size 的对称像素图案，同一哈希始终生成相同的图案，返回 PNG 编码的图片
+func Identicon(hash string, size int) ([]byte, error) {
+	sum, err := hex.DecodeString(hash)
+	if err != nil || len(sum) < 16 {
+		return nil, fmt.Errorf("哈希无效: %s", hash)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: identiconBackground}, image.Point{}, draw.Src)
+
+	// 四周各留半格边距，剩余像素平分给各格，除不尽的部分并入边距
+	cell := size / (identiconGrid + 1)
+	offset := (size - cell*identiconGrid) / 2
+	fg := &image.Uniform{C: identiconColor(sum)}
+	for row := 0; row < identiconGrid; row++ {
+		for col := 0; col < (identiconGrid+1)/2; col++ {
+			if sum[row*3+col]&1 == 1 {
+				continue
+			}
+			for _, c := range []int{col, identiconGrid - 1 - col} {
+				x, y := offset+c*cell, offset+row*cell
+				draw.Draw(img, image.Rect(x, y, x+cell, y+cell), fg, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("编码头像失败: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// identiconColor 由哈希末尾的字节决定色相，饱和度与亮度固定，避免生成过浅或过深的颜色
+func identiconColor(sum []byte) color.RGBA {
+	hue := float64(int(sum[len(sum)-2])<<8|int(sum[len(sum)-1])) / 65536 * 360
+	const saturation, lightness = 0.55, 0.55
+
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := lightness - chroma/2
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	return color.RGBA{R: uint8((r + m) * 255), G: uint8((g + m) * 255), B: uint8((b + m) * 255), A: 0xff}
+}
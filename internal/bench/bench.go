@@ -0,0 +1,214 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options 压测配置
+type Options struct {
+	Target      string        // 压测目标地址
+	Profile     string        // 负载模型
+	Concurrency int           // 并发数
+	Duration    time.Duration // 压测时长
+	MaxPage     int           // 分页请求的最大页码
+	MaxPostID   int64         // 随机请求的最大文章 ID
+}
+
+// DefaultOptions 默认压测配置
+func DefaultOptions() Options {
+	return Options{
+		Target:      "http://localhost:9010/api/v1",
+		Profile:     "read",
+		Concurrency: 16,
+		Duration:    30 * time.Second,
+		MaxPage:     200,
+		MaxPostID:   20000,
+	}
+}
+
+// scenario 负载模型中的单个请求场景
+type scenario struct {
+	Name   string                                      // 场景名称
+	Weight int                                         // 权重
+	Build  func(o Options, r *rand.Rand) *http.Request // 构建请求
+}
+
+// profiles 内置负载模型
+var profiles = map[string][]scenario{
+	"read": {
+		{Name: "getAllPosts", Weight: 6, Build: listPosts},
+		{Name: "getOnePost", Weight: 3, Build: onePost},
+		{Name: "getCommentGraph", Weight: 1, Build: commentGraph},
+	},
+	"mixed": {
+		{Name: "getAllPosts", Weight: 4, Build: listPosts},
+		{Name: "getOnePost", Weight: 3, Build: onePost},
+		{Name: "getCommentGraph", Weight: 2, Build: commentGraph},
+		{Name: "getCategoryTree", Weight: 1, Build: categoryTree},
+	},
+	"paging": {
+		{Name: "getAllPostsDeep", Weight: 1, Build: deepListPosts},
+	},
+}
+
+// stat 单个场景的统计结果
+type stat struct {
+	latencies []time.Duration
+	errors    int
+}
+
+// Run 执行压测并返回报告
+func Run(opts Options) (string, error) {
+	scenarios, ok := profiles[opts.Profile]
+	if !ok {
+		return "", fmt.Errorf("不支持的负载模型: %s", opts.Profile)
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	opts.Target = strings.TrimRight(opts.Target, "/")
+
+	totalWeight := 0
+	for _, s := range scenarios {
+		totalWeight += s.Weight
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Duration)
+	defer cancel()
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	stats := make(map[string]*stat, len(scenarios))
+	for _, s := range scenarios {
+		stats[s.Name] = &stat{}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+
+			for ctx.Err() == nil {
+				s := pickScenario(scenarios, totalWeight, r)
+				req := s.Build(opts, r).WithContext(ctx)
+
+				start := time.Now()
+				failed := doRequest(client, req)
+				elapsed := time.Since(start)
+
+				// 压测结束时被取消的请求不计入统计
+				if ctx.Err() != nil {
+					return
+				}
+
+				mu.Lock()
+				st := stats[s.Name]
+				st.latencies = append(st.latencies, elapsed)
+				if failed {
+					st.errors++
+				}
+				mu.Unlock()
+			}
+		}(time.Now().UnixNano() + int64(i))
+	}
+	wg.Wait()
+
+	return formatReport(scenarios, stats, opts.Duration), nil
+}
+
+// doRequest 发送请求，返回是否失败
+func doRequest(client *http.Client, req *http.Request) bool {
+	resp, err := client.Do(req)
+	if err != nil {
+		return true
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode >= http.StatusBadRequest
+}
+
+// pickScenario 按权重随机选取场景
+func pickScenario(scenarios []scenario, totalWeight int, r *rand.Rand) scenario {
+	n := r.Intn(totalWeight)
+	for _, s := range scenarios {
+		if n < s.Weight {
+			return s
+		}
+		n -= s.Weight
+	}
+	return scenarios[len(scenarios)-1]
+}
+
+// formatReport 格式化压测报告
+func formatReport(scenarios []scenario, stats map[string]*stat, duration time.Duration) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-18s %8s %8s %8s %10s %10s %10s\n", "scenario", "count", "errors", "rps", "p50", "p90", "p99")
+
+	for _, s := range scenarios {
+		st := stats[s.Name]
+		sort.Slice(st.latencies, func(i, j int) bool { return st.latencies[i] < st.latencies[j] })
+		count := len(st.latencies)
+		fmt.Fprintf(&sb, "%-18s %8d %8d %8.1f %10s %10s %10s\n",
+			s.Name, count, st.errors, float64(count)/duration.Seconds(),
+			percentile(st.latencies, 0.50), percentile(st.latencies, 0.90), percentile(st.latencies, 0.99))
+	}
+	return sb.String()
+}
+
+// percentile 计算已排序延迟的分位数
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx].Round(time.Microsecond)
+}
+
+// hotPage 生成偏向前几页的页码，模拟真实访问中首页流量最大的情况
+func hotPage(o Options, r *rand.Rand) int {
+	page := int(r.ExpFloat64()*3) + 1
+	if page > o.MaxPage {
+		page = o.MaxPage
+	}
+	return page
+}
+
+func listPosts(o Options, r *rand.Rand) *http.Request {
+	url := fmt.Sprintf("%s/post/getAllPosts?page=%d&pageSize=10", o.Target, hotPage(o, r))
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	return req
+}
+
+func onePost(o Options, r *rand.Rand) *http.Request {
+	body := fmt.Sprintf(`{"id":%d}`, r.Int63n(o.MaxPostID)+1)
+	req, _ := http.NewRequest(http.MethodPost, o.Target+"/post/getOnePost", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func commentGraph(o Options, r *rand.Rand) *http.Request {
+	url := fmt.Sprintf("%s/comment/getCommentGraph?post_id=%d", o.Target, r.Int63n(o.MaxPostID)+1)
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	return req
+}
+
+func categoryTree(o Options, _ *rand.Rand) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, o.Target+"/category/getCategoryTree", nil)
+	return req
+}
+
+func deepListPosts(o Options, r *rand.Rand) *http.Request {
+	url := fmt.Sprintf("%s/post/getAllPosts?page=%d&pageSize=10", o.Target, r.Intn(o.MaxPage)+1)
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	return req
+}
@@ -0,0 +1,124 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// 熔断器状态
+const (
+	StateClosed   = "closed"    // 正常放行
+	StateOpen     = "open"      // 快速失败
+	StateHalfOpen = "half_open" // 放行一次探测请求
+)
+
+// ErrOpen 熔断器打开，请求未执行
+var ErrOpen = errors.New("熔断器已打开")
+
+// Options 熔断配置，FailureThreshold 小于等于 0 时不熔断
+type Options struct {
+	FailureThreshold int           // 连续失败多少次后打开
+	OpenDuration     time.Duration // 打开多久后放行探测请求
+}
+
+// Status 熔断器状态快照
+type Status struct {
+	Name     string
+	State    string
+	Failures int       // 当前连续失败次数
+	OpenedAt time.Time // 最近一次打开的时间，未打开过时为零值
+	RetryAt  time.Time // 打开时允许探测的时间，其余状态为零值
+}
+
+// Breaker 熔断器
+type Breaker struct {
+	name     string
+	mu       sync.Mutex
+	opts     Options
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New 创建熔断器
+func New(name string, opts Options) *Breaker {
+	return &Breaker{name: name, opts: opts, state: StateClosed}
+}
+
+// Configure 更新熔断配置，不改变当前状态
+func (b *Breaker) Configure(opts Options) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.opts = opts
+}
+
+// Do 经熔断器执行 fn，熔断器打开或已有探测请求在执行时返回 ErrOpen
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.done(err)
+	return err
+}
+
+// Status 获取熔断器状态快照，打开时长已结束时报告为半开
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := Status{Name: b.name, State: b.state, Failures: b.failures, OpenedAt: b.openedAt}
+	if b.state == StateOpen {
+		st.RetryAt = b.openedAt.Add(b.opts.OpenDuration)
+		if !time.Now().Before(st.RetryAt) {
+			st.State = StateHalfOpen
+		}
+	}
+	return st
+}
+
+// allow 判断是否放行请求，打开时长结束后转为半开并只放行一次探测请求
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.opts.FailureThreshold <= 0 {
+		return nil
+	}
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.opts.OpenDuration {
+			return ErrOpen
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+	case StateHalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// done 记录请求结果，半开时探测成功则关闭，失败则重新打开
+func (b *Breaker) done(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.opts.FailureThreshold <= 0 {
+		return
+	}
+	b.probing = false
+	if err == nil {
+		b.state, b.failures = StateClosed, 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.opts.FailureThreshold {
+		b.state, b.openedAt = StateOpen, time.Now()
+	}
+}
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+)
+
+// 缓存驱动
+const (
+	DriverRedis  = "redis"  // 始终使用 Redis，Redis 不可用时请求失败
+	DriverMemory = "memory" // 始终使用内存缓存
+	DriverAuto   = "auto"   // 优先使用 Redis，Redis 不可用时回退到内存缓存
+)
+
+const (
+	defaultHealthCheckInterval = 5 * time.Second // 默认 Redis 健康检查间隔
+	pingTimeout                = 2 * time.Second // 健康检查的超时时间
+)
+
+// ErrMiss 缓存键不存在或已过期
+var ErrMiss = errors.New("缓存键不存在")
+
+// Store 键值缓存，过期时间小于等于 0 时不过期
+type Store interface {
+	// Name 缓存驱动名称
+	Name() string
+	// Get 读取缓存值，键不存在时返回 ErrMiss
+	Get(ctx context.Context, key string) (string, error)
+	// Set 写入缓存值，覆盖已有的值与过期时间
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	// SetNX 键不存在时写入缓存值，返回是否写入
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+	// Del 删除缓存键
+	Del(ctx context.Context, keys ...string) error
+	// TTL 查询剩余过期时间，与 go-redis 一致，键不存在时返回 -2，未设置过期时间时返回 -1
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	// Incr 计数加一并返回新值，计数首次创建时设置过期时间
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+var (
+	mu      sync.RWMutex
+	driver  = DriverAuto
+	remote  *Redis
+	local   = NewMemory()
+	healthy atomic.Bool
+	monitor sync.Once
+)
+
+// Init 按配置初始化缓存，client 为 Redis 客户端，连接失败时也应传入，以便 Redis 恢复后自动切回
+func Init(config *configs.Config, client *redis.Client) {
+	interval := time.Duration(config.CacheConfig.CacheHealthCheckInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	mu.Lock()
+	switch config.CacheConfig.CacheDriver {
+	case DriverRedis, DriverMemory:
+		driver = config.CacheConfig.CacheDriver
+	default:
+		driver = DriverAuto
+	}
+	if client != nil {
+		remote = &Redis{client: client}
+	}
+	mu.Unlock()
+
+	check()
+	monitor.Do(func() {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for range ticker.C {
+				check()
+				local.sweep()
+			}
+		}()
+	})
+}
+
+// Current 获取当前使用的缓存，未初始化 Redis 时使用内存缓存
+func Current() Store {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	switch {
+	case driver == DriverMemory || remote == nil:
+		return local
+	case driver == DriverAuto && !healthy.Load():
+		return local
+	default:
+		return remote
+	}
+}
+
+// check 检查 Redis 是否可用并切换缓存，切回 Redis 时清空内存缓存，避免故障期间的旧数据在下次回退时重新生效
+func check() {
+	mu.RLock()
+	r, mode := remote, driver
+	mu.RUnlock()
+	if r == nil || mode == DriverMemory {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	err := r.client.Ping(ctx).Err()
+
+	switch {
+	case err == nil && !healthy.Load():
+		local.Flush()
+		healthy.Store(true)
+		if mode == DriverAuto {
+			global.SysLog.Infof("Redis 已恢复，缓存切回 Redis")
+		}
+	case err != nil:
+		markDown(err)
+	}
+}
+
+// markDown 将 Redis 标记为不可用，在下次健康检查恢复前使用内存缓存
+func markDown(err error) {
+	if !healthy.CompareAndSwap(true, false) {
+		return
+	}
+
+	mu.RLock()
+	mode := driver
+	mu.RUnlock()
+	if mode == DriverAuto {
+		global.SysLog.Warnf("Redis 不可用，缓存切换到内存缓存: %v", err)
+	}
+}
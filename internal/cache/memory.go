@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Memory 进程内的内存缓存，数据不在实例间共享，进程重启后丢失
+type Memory struct {
+	mu    sync.Mutex
+	items map[string]memoryItem
+}
+
+// memoryItem 缓存值与过期时间，expireAt 为零值时不过期
+type memoryItem struct {
+	value    string
+	expireAt time.Time
+}
+
+// NewMemory 创建内存缓存
+func NewMemory() *Memory {
+	return &Memory{items: make(map[string]memoryItem)}
+}
+
+// Name 缓存驱动名称
+func (m *Memory) Name() string { return DriverMemory }
+
+func (m *Memory) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return memoryTx{m}.Get(key)
+}
+
+func (m *Memory) Set(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	memoryTx{m}.Set(key, value, ttl)
+	return nil
+}
+
+func (m *Memory) SetNX(_ context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx := memoryTx{m}
+	if _, err := tx.Get(key); err == nil {
+		return false, nil
+	}
+	tx.Set(key, value, ttl)
+	return true, nil
+}
+
+func (m *Memory) Del(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	memoryTx{m}.Del(keys...)
+	return nil
+}
+
+func (m *Memory) TTL(_ context.Context, key string) (time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return memoryTx{m}.TTL(key), nil
+}
+
+func (m *Memory) Incr(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return memoryTx{m}.Incr(key, ttl)
+}
+
+// Flush 清空全部缓存
+func (m *Memory) Flush() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = make(map[string]memoryItem)
+}
+
+// sweep 清理已过期的缓存，避免未再访问的键长期占用内存
+func (m *Memory) sweep() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for key, item := range m.items {
+		if item.expired(now) {
+			delete(m.items, key)
+		}
+	}
+}
+
+// atomic 持锁执行 fn，fn 中通过 tx 读写缓存
+func (m *Memory) atomic(fn func(tx MemoryTx) (int64, error)) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fn(memoryTx{m})
+}
+
+func (item memoryItem) expired(now time.Time) bool {
+	return !item.expireAt.IsZero() && !now.Before(item.expireAt)
+}
+
+// MemoryTx 内存缓存脚本中可用的缓存操作，在同一把锁内执行
+type MemoryTx interface {
+	Get(key string) (string, error)
+	Set(key string, value interface{}, ttl time.Duration)
+	Del(keys ...string)
+	TTL(key string) time.Duration
+	Incr(key string, ttl time.Duration) (int64, error)
+	Expire(key string, ttl time.Duration)
+}
+
+// memoryTx 不加锁的内存缓存操作，调用方需持有 Memory.mu
+type memoryTx struct {
+	m *Memory
+}
+
+func (tx memoryTx) Get(key string) (string, error) {
+	item, ok := tx.m.items[key]
+	if !ok {
+		return "", ErrMiss
+	}
+	if item.expired(time.Now()) {
+		delete(tx.m.items, key)
+		return "", ErrMiss
+	}
+	return item.value, nil
+}
+
+func (tx memoryTx) Set(key string, value interface{}, ttl time.Duration) {
+	item := memoryItem{value: fmt.Sprint(value)}
+	if ttl > 0 {
+		item.expireAt = time.Now().Add(ttl)
+	}
+	tx.m.items[key] = item
+}
+
+func (tx memoryTx) Del(keys ...string) {
+	for _, key := range keys {
+		delete(tx.m.items, key)
+	}
+}
+
+func (tx memoryTx) TTL(key string) time.Duration {
+	if _, err := tx.Get(key); err != nil {
+		return -2
+	}
+	item := tx.m.items[key]
+	if item.expireAt.IsZero() {
+		return -1
+	}
+	return time.Until(item.expireAt)
+}
+
+func (tx memoryTx) Incr(key string, ttl time.Duration) (int64, error) {
+	value, err := tx.Get(key)
+	if err != nil {
+		tx.Set(key, 1, ttl)
+		return 1, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("缓存值不是整数: %s", key)
+	}
+	item := tx.m.items[key]
+	item.value = strconv.FormatInt(n+1, 10)
+	tx.m.items[key] = item
+	return n + 1, nil
+}
+
+// expire 设置过期时间，键不存在时忽略
+func (tx memoryTx) Expire(key string, ttl time.Duration) {
+	if item, ok := tx.m.items[key]; ok && ttl > 0 {
+		item.expireAt = time.Now().Add(ttl)
+		tx.m.items[key] = item
+	}
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis 基于 Redis 的缓存，多实例部署时共享
+type Redis struct {
+	client *redis.Client
+}
+
+// Name 缓存驱动名称
+func (r *Redis) Name() string { return DriverRedis }
+
+func (r *Redis) Get(ctx context.Context, key string) (string, error) {
+	value, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrMiss
+	}
+	return value, r.observe(err)
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return r.observe(r.client.Set(ctx, key, value, expiration(ttl)).Err())
+}
+
+func (r *Redis) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(ctx, key, value, expiration(ttl)).Result()
+	return ok, r.observe(err)
+}
+
+func (r *Redis) Del(ctx context.Context, keys ...string) error {
+	return r.observe(r.client.Del(ctx, keys...).Err())
+}
+
+func (r *Redis) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.TTL(ctx, key).Result()
+	return ttl, r.observe(err)
+}
+
+func (r *Redis) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	if ttl > 0 {
+		pipe.ExpireNX(ctx, key, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, r.observe(err)
+	}
+	return incr.Val(), nil
+}
+
+// observe 连接类错误时将 Redis 标记为不可用，Redis 返回的命令错误不影响切换
+func (r *Redis) observe(err error) error {
+	var replyErr redis.Error
+	if err != nil && !errors.As(err, &replyErr) && !errors.Is(err, context.Canceled) {
+		markDown(err)
+	}
+	return err
+}
+
+// expiration 转换为 go-redis 的过期时间，0 表示不过期
+func expiration(ttl time.Duration) time.Duration {
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
+}
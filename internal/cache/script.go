@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Eval 原子执行返回整数的缓存脚本，Redis 中执行 Lua 脚本，内存缓存中持锁执行等价的 fn
+func Eval(ctx context.Context, store Store, script *redis.Script, fn func(tx MemoryTx) (int64, error), keys []string, args ...interface{}) (int64, error) {
+	switch s := store.(type) {
+	case *Redis:
+		result, err := script.Run(ctx, s.client, keys, args...).Int64()
+		return result, s.observe(err)
+	case *Memory:
+		return s.atomic(fn)
+	default:
+		return 0, fmt.Errorf("缓存驱动 %s 不支持脚本", store.Name())
+	}
+}
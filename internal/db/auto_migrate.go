@@ -5,6 +5,7 @@ import (
 
 	"jank.com/jank_blog/internal/global"
 	"jank.com/jank_blog/internal/model"
+	post "jank.com/jank_blog/internal/model/post"
 )
 
 func autoMigrate() {
@@ -18,7 +19,22 @@ func autoMigrate() {
 	if err != nil {
 		log.Fatalf("数据库自动迁移失败: %v", err)
 	}
+	migratePostStatus()
 
 	log.Println("数据库自动迁移成功...")
 	global.SysLog.Infof("数据库自动迁移成功...")
 }
+
+// migratePostStatus 新增发布状态字段前已可见的文章默认被迁移为草稿，按可见性改为已发布；
+// 可见性与发布状态始终同步，可见的草稿只可能来自升级前，重复执行不影响已有数据
+func migratePostStatus() {
+	result := global.DB.Model(&post.Post{}).
+		Where("status = ? AND visibility = ?", post.StatusDraft, true).
+		Update("status", post.StatusPublished)
+	if result.Error != nil {
+		log.Fatalf("迁移文章发布状态失败: %v", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		global.SysLog.Infof("已将 %d 篇可见的文章标记为已发布", result.RowsAffected)
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 	"jank.com/jank_blog/configs"
 	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/jwtkey"
+	"jank.com/jank_blog/internal/setting"
 )
 
 const (
@@ -56,6 +58,15 @@ func New(config *configs.Config) {
 	global.SysLog.Infof("「%s」数据库连接成功！", config.DBConfig.DBName)
 
 	autoMigrate()
+
+	// 加载运行时修改的配置项
+	if err := setting.Load(); err != nil {
+		global.SysLog.Errorf("加载运行时配置失败: %v", err)
+	}
+	// 加载 JWT 签名密钥，新安装的系统在创建用户前生成密钥，不保存内置密钥
+	if err := jwtkey.Init(); err != nil {
+		global.SysLog.Errorf("加载 JWT 签名密钥失败: %v", err)
+	}
 }
 
 // connectToSystemDB 连接到系统数据库
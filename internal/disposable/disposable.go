@@ -0,0 +1,277 @@
+package disposable
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+// 管理员维护的域名，保存在 Redis 中供多个实例共享
+const (
+	BlockedKey = "DISPOSABLE:EMAIL:BLOCKED" // 管理员添加的黑名单域名
+	AllowedKey = "DISPOSABLE:EMAIL:ALLOWED" // 管理员从黑名单中移除的域名，优先于全部来源
+)
+
+// 远程列表的请求限制
+const (
+	fetchTimeout = 10 * time.Second
+	maxListSize  = 8 << 20 // 远程列表最多读取的字节数
+)
+
+//go:embed domains.txt
+var builtinDomains string
+
+// ErrInvalidDomain 域名格式无效
+var ErrInvalidDomain = errors.New("域名格式无效")
+
+// Sources 黑名单来源，留空的来源跳过
+type Sources struct {
+	File string // 本地域名列表文件
+	URL  string // 远程域名列表地址
+}
+
+// Stats 黑名单状态
+type Stats struct {
+	Loaded      int       // 内置、文件与远程来源的域名数
+	Blocked     []string  // 管理员添加的域名
+	Allowed     []string  // 管理员移除的域名
+	RefreshedAt time.Time // 上次刷新时间，未刷新过时为零值
+}
+
+var (
+	mu          sync.RWMutex
+	loaded      = parse(strings.NewReader(builtinDomains))
+	refreshedAt time.Time
+)
+
+// Refresh 重新加载内置、文件与远程来源的域名，任一来源失败时保留已加载的列表并返回错误
+func Refresh(ctx context.Context, sources Sources) (int, error) {
+	domains := parse(strings.NewReader(builtinDomains))
+
+	if sources.File != "" {
+		f, err := os.Open(sources.File)
+		if err != nil {
+			return 0, fmt.Errorf("读取一次性邮箱域名文件失败: %v", err)
+		}
+		for domain := range parse(f) {
+			domains[domain] = struct{}{}
+		}
+		f.Close()
+	}
+
+	if sources.URL != "" {
+		remote, err := fetch(ctx, sources.URL)
+		if err != nil {
+			return 0, err
+		}
+		for domain := range remote {
+			domains[domain] = struct{}{}
+		}
+	}
+
+	mu.Lock()
+	loaded, refreshedAt = domains, time.Now()
+	mu.Unlock()
+	return len(domains), nil
+}
+
+// IsDisposable 判断邮箱是否属于一次性邮箱域名，子域名同样命中
+// Redis 不可用时仅使用内置、文件与远程来源
+func IsDisposable(ctx context.Context, email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := normalize(email[at+1:])
+	if domain == "" {
+		return false
+	}
+
+	// 依次匹配完整域名与各级上级域名，不匹配顶级域名
+	var candidates []string
+	for {
+		candidates = append(candidates, domain)
+		i := strings.Index(domain, ".")
+		if i < 0 || !strings.Contains(domain[i+1:], ".") {
+			break
+		}
+		domain = domain[i+1:]
+	}
+
+	if allowed, blocked, err := managed(ctx, candidates); err == nil {
+		for _, candidate := range candidates {
+			if allowed[candidate] {
+				return false
+			}
+			if blocked[candidate] {
+				return true
+			}
+		}
+	} else {
+		global.SysLog.Warnf("读取管理员维护的一次性邮箱域名失败: %v", err)
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, candidate := range candidates {
+		if _, ok := loaded[candidate]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Add 将域名加入黑名单
+func Add(ctx context.Context, domain string) (string, error) {
+	domain = normalize(domain)
+	if !validDomain(domain) {
+		return "", ErrInvalidDomain
+	}
+	if global.RedisClient == nil {
+		return "", errors.New("Redis 未连接")
+	}
+	pipe := global.RedisClient.TxPipeline()
+	pipe.SRem(ctx, AllowedKey, domain)
+	pipe.SAdd(ctx, BlockedKey, domain)
+	_, err := pipe.Exec(ctx)
+	return domain, err
+}
+
+// Remove 将域名移出黑名单，内置、文件或远程来源中的域名记入放行列表
+func Remove(ctx context.Context, domain string) (string, error) {
+	domain = normalize(domain)
+	if !validDomain(domain) {
+		return "", ErrInvalidDomain
+	}
+	if global.RedisClient == nil {
+		return "", errors.New("Redis 未连接")
+	}
+
+	mu.RLock()
+	_, inLoaded := loaded[domain]
+	mu.RUnlock()
+
+	pipe := global.RedisClient.TxPipeline()
+	pipe.SRem(ctx, BlockedKey, domain)
+	if inLoaded {
+		pipe.SAdd(ctx, AllowedKey, domain)
+	}
+	_, err := pipe.Exec(ctx)
+	return domain, err
+}
+
+// Status 获取黑名单状态
+func Status(ctx context.Context) (Stats, error) {
+	mu.RLock()
+	stats := Stats{Loaded: len(loaded), RefreshedAt: refreshedAt}
+	mu.RUnlock()
+
+	if global.RedisClient == nil {
+		return stats, nil
+	}
+	blocked, err := global.RedisClient.SMembers(ctx, BlockedKey).Result()
+	if err != nil {
+		return stats, err
+	}
+	allowed, err := global.RedisClient.SMembers(ctx, AllowedKey).Result()
+	if err != nil {
+		return stats, err
+	}
+	sort.Strings(blocked)
+	sort.Strings(allowed)
+	stats.Blocked, stats.Allowed = blocked, allowed
+	return stats, nil
+}
+
+// managed 查询候选域名在管理员维护列表中的状态
+func managed(ctx context.Context, candidates []string) (allowed, blocked map[string]bool, err error) {
+	if global.RedisClient == nil {
+		return nil, nil, nil
+	}
+	members := make([]interface{}, len(candidates))
+	for i, candidate := range candidates {
+		members[i] = candidate
+	}
+
+	pipe := global.RedisClient.Pipeline()
+	allowedCmd := pipe.SMIsMember(ctx, AllowedKey, members...)
+	blockedCmd := pipe.SMIsMember(ctx, BlockedKey, members...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	allowed, blocked = make(map[string]bool), make(map[string]bool)
+	for i, candidate := range candidates {
+		allowed[candidate] = allowedCmd.Val()[i]
+		blocked[candidate] = blockedCmd.Val()[i]
+	}
+	return allowed, blocked, nil
+}
+
+// fetch 下载远程域名列表
+func fetch(ctx context.Context, url string) (map[string]struct{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("下载一次性邮箱域名列表失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("下载一次性邮箱域名列表失败，状态码 %d", resp.StatusCode)
+	}
+	return parse(io.LimitReader(resp.Body, maxListSize)), nil
+}
+
+// parse 解析每行一个域名的列表，忽略空行、# 开头的注释与格式无效的行
+func parse(r io.Reader) map[string]struct{} {
+	domains := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := normalize(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || !validDomain(line) {
+			continue
+		}
+		domains[line] = struct{}{}
+	}
+	return domains
+}
+
+// normalize 去除空白与末尾的点并转为小写
+func normalize(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// validDomain 粗略校验域名格式，要求至少两段且仅含字母、数字、连字符
+func validDomain(domain string) bool {
+	if len(domain) > 253 || !strings.Contains(domain, ".") {
+		return false
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
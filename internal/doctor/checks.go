@@ -0,0 +1,345 @@
+package doctor
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	account "jank.com/jank_blog/internal/model/account"
+	bookmark "jank.com/jank_blog/internal/model/bookmark"
+	category "jank.com/jank_blog/internal/model/category"
+	comment "jank.com/jank_blog/internal/model/comment"
+	history "jank.com/jank_blog/internal/model/history"
+	post "jank.com/jank_blog/internal/model/post"
+)
+
+// orphanCommentsCheck 所属文章不存在或已删除的评论，修复时软删除
+var orphanCommentsCheck = check{
+	name:        "orphan_comments",
+	description: "所属文章不存在或已删除的评论",
+	table:       "comments",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		return pluckIDs(db.Model(&comment.Comment{}).
+			Where("deleted = ?", false).
+			Where("NOT EXISTS (SELECT 1 FROM posts p WHERE p.id = comments.post_id AND p.deleted = ?)", false))
+	},
+	fix: func(db *gorm.DB, ids []int64) (int, error) {
+		return softDelete(db, &comment.Comment{}, ids)
+	},
+}
+
+// orphanRepliesCheck 回复目标不存在或已删除的评论，修复时转为顶级评论
+var orphanRepliesCheck = check{
+	name:        "orphan_replies",
+	description: "回复目标不存在或已删除的评论",
+	table:       "comments",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		return pluckIDs(db.Model(&comment.Comment{}).
+			Where("deleted = ? AND reply_to_comment_id IS NOT NULL AND reply_to_comment_id <> ?", false, 0).
+			Where("NOT EXISTS (SELECT 1 FROM comments r WHERE r.id = comments.reply_to_comment_id AND r.deleted = ?)", false))
+	},
+	fix: func(db *gorm.DB, ids []int64) (int, error) {
+		result := db.Model(&comment.Comment{}).Where("id IN ?", ids).Update("reply_to_comment_id", 0)
+		return int(result.RowsAffected), result.Error
+	},
+}
+
+// orphanAccountRolesCheck 用户或角色不存在的用户角色关联，修复时软删除
+var orphanAccountRolesCheck = check{
+	name:        "orphan_account_roles",
+	description: "用户或角色不存在的用户角色关联",
+	table:       "account_roles",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		return pluckIDs(db.Model(&account.AccountRole{}).
+			Where("deleted = ?", false).
+			Where("(NOT EXISTS (SELECT 1 FROM accounts a WHERE a.id = account_roles.account_id AND a.deleted = ?) "+
+				"OR NOT EXISTS (SELECT 1 FROM roles r WHERE r.id = account_roles.role_id AND r.deleted = ?))", false, false))
+	},
+	fix: func(db *gorm.DB, ids []int64) (int, error) {
+		return softDelete(db, &account.AccountRole{}, ids)
+	},
+}
+
+// orphanRolePermissionsCheck 角色或权限不存在的角色权限关联，修复时软删除
+var orphanRolePermissionsCheck = check{
+	name:        "orphan_role_permissions",
+	description: "角色或权限不存在的角色权限关联",
+	table:       "role_permissions",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		return pluckIDs(db.Model(&account.RolePermission{}).
+			Where("deleted = ?", false).
+			Where("(NOT EXISTS (SELECT 1 FROM roles r WHERE r.id = role_permissions.role_id AND r.deleted = ?) "+
+				"OR NOT EXISTS (SELECT 1 FROM permissions p WHERE p.id = role_permissions.permission_id AND p.deleted = ?))", false, false))
+	},
+	fix: func(db *gorm.DB, ids []int64) (int, error) {
+		return softDelete(db, &account.RolePermission{}, ids)
+	},
+}
+
+// orphanReadingHistoriesCheck 用户或文章不存在的阅读历史，修复时软删除
+var orphanReadingHistoriesCheck = check{
+	name:        "orphan_reading_histories",
+	description: "用户或文章不存在的阅读历史",
+	table:       "reading_histories",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		return pluckIDs(db.Model(&history.ReadingHistory{}).
+			Where("deleted = ?", false).
+			Where("(NOT EXISTS (SELECT 1 FROM accounts a WHERE a.id = reading_histories.account_id AND a.deleted = ?) "+
+				"OR NOT EXISTS (SELECT 1 FROM posts p WHERE p.id = reading_histories.post_id AND p.deleted = ?))", false, false))
+	},
+	fix: func(db *gorm.DB, ids []int64) (int, error) {
+		return softDelete(db, &history.ReadingHistory{}, ids)
+	},
+}
+
+// orphanBookmarksCheck 用户或文章不存在的收藏，修复时软删除
+var orphanBookmarksCheck = check{
+	name:        "orphan_bookmarks",
+	description: "用户或文章不存在的收藏",
+	table:       "bookmarks",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		return pluckIDs(db.Model(&bookmark.Bookmark{}).
+			Where("deleted = ?", false).
+			Where("(NOT EXISTS (SELECT 1 FROM accounts a WHERE a.id = bookmarks.account_id AND a.deleted = ?) "+
+				"OR NOT EXISTS (SELECT 1 FROM posts p WHERE p.id = bookmarks.post_id AND p.deleted = ?))", false, false))
+	},
+	fix: func(db *gorm.DB, ids []int64) (int, error) {
+		return softDelete(db, &bookmark.Bookmark{}, ids)
+	},
+}
+
+// orphanCategoriesCheck 父类目不存在或已删除的类目，修复时提升为根类目
+var orphanCategoriesCheck = check{
+	name:        "orphan_categories",
+	description: "父类目不存在或已删除的类目",
+	table:       "categories",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		return pluckIDs(db.Model(&category.Category{}).
+			Where("deleted = ? AND parent_id IS NOT NULL AND parent_id <> ?", false, 0).
+			Where("NOT EXISTS (SELECT 1 FROM categories p WHERE p.id = categories.parent_id AND p.deleted = ?)", false))
+	},
+	fix: func(db *gorm.DB, ids []int64) (int, error) {
+		fixed := 0
+		for _, id := range ids {
+			var cat category.Category
+			if err := db.Where("id = ?", id).First(&cat).Error; err != nil {
+				return fixed, err
+			}
+			// 子孙类目路径以 "{原路径}/{类目ID}" 为前缀，随之改写为 "/{类目ID}"
+			if err := rebasePaths(db, fmt.Sprintf("%s/%d", cat.Path, cat.ID), fmt.Sprintf("/%d", cat.ID)); err != nil {
+				return fixed, err
+			}
+			if err := db.Model(&category.Category{}).Where("id = ?", id).
+				Updates(map[string]interface{}{"parent_id": 0, "path": ""}).Error; err != nil {
+				return fixed, err
+			}
+			fixed++
+		}
+		return fixed, nil
+	},
+}
+
+// duplicateCategoriesCheck 同一父类目下重名的类目，修复时合并到 ID 最小的类目
+var duplicateCategoriesCheck = check{
+	name:        "duplicate_categories",
+	description: "同一父类目下重名的类目",
+	table:       "categories",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		groups, err := duplicateCategoryGroups(db)
+		if err != nil {
+			return nil, err
+		}
+		var ids []int64
+		for _, group := range groups {
+			for _, cat := range group[1:] {
+				ids = append(ids, cat.ID)
+			}
+		}
+		return ids, nil
+	},
+	fix: func(db *gorm.DB, _ []int64) (int, error) {
+		groups, err := duplicateCategoryGroups(db)
+		if err != nil {
+			return 0, err
+		}
+
+		fixed := 0
+		for _, group := range groups {
+			keep := group[0]
+			for _, dup := range group[1:] {
+				if err := mergeCategory(db, dup, keep); err != nil {
+					return fixed, err
+				}
+				fixed++
+			}
+		}
+		return fixed, nil
+	},
+}
+
+// danglingPostCategoriesCheck 引用了不存在或已删除类目的文章，修复时移除失效的类目 ID
+var danglingPostCategoriesCheck = check{
+	name:        "dangling_post_categories",
+	description: "引用了不存在或已删除类目的文章",
+	table:       "posts",
+	detect: func(db *gorm.DB) ([]int64, error) {
+		posts, _, err := danglingPosts(db)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int64, len(posts))
+		for i, pos := range posts {
+			ids[i] = pos.ID
+		}
+		return ids, nil
+	},
+	fix: func(db *gorm.DB, _ []int64) (int, error) {
+		posts, active, err := danglingPosts(db)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, pos := range posts {
+			var kept post.CategoryIDsArray
+			for _, id := range pos.CategoryIDs {
+				if active[id] {
+					kept = append(kept, id)
+				}
+			}
+			if err := db.Model(&post.Post{}).Where("id = ?", pos.ID).Update("category_ids", kept).Error; err != nil {
+				return 0, err
+			}
+		}
+		return len(posts), nil
+	},
+}
+
+// duplicateCategoryGroups 按父类目与名称分组的重名类目，每组按 ID 升序排列
+func duplicateCategoryGroups(db *gorm.DB) ([][]*category.Category, error) {
+	var categories []*category.Category
+	if err := db.Where("deleted = ?", false).Order("id").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	groups := make(map[string][]*category.Category)
+	for _, cat := range categories {
+		key := fmt.Sprintf("%d:%s", cat.ParentID, strings.TrimSpace(cat.Name))
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], cat)
+	}
+
+	var result [][]*category.Category
+	for _, key := range keys {
+		if len(groups[key]) > 1 {
+			result = append(result, groups[key])
+		}
+	}
+	return result, nil
+}
+
+// mergeCategory 将重复类目合并到保留类目：改写文章引用、迁移子类目并软删除重复类目
+func mergeCategory(db *gorm.DB, dup, keep *category.Category) error {
+	var posts []*post.Post
+	if err := db.Select("id", "category_ids").Where("deleted = ?", false).Find(&posts).Error; err != nil {
+		return err
+	}
+	for _, pos := range posts {
+		replaced, changed := replaceCategoryID(pos.CategoryIDs, dup.ID, keep.ID)
+		if !changed {
+			continue
+		}
+		if err := db.Model(&post.Post{}).Where("id = ?", pos.ID).Update("category_ids", replaced).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := db.Model(&category.Category{}).Where("parent_id = ? AND deleted = ?", dup.ID, false).
+		Update("parent_id", keep.ID).Error; err != nil {
+		return err
+	}
+	if err := rebasePaths(db, fmt.Sprintf("%s/%d", dup.Path, dup.ID), fmt.Sprintf("%s/%d", keep.Path, keep.ID)); err != nil {
+		return err
+	}
+
+	_, err := softDelete(db, &category.Category{}, []int64{dup.ID})
+	return err
+}
+
+// replaceCategoryID 将类目 ID 列表中的 from 替换为 to 并去重
+func replaceCategoryID(ids post.CategoryIDsArray, from, to int64) (post.CategoryIDsArray, bool) {
+	changed := false
+	seen := make(map[int64]bool, len(ids))
+	result := make(post.CategoryIDsArray, 0, len(ids))
+	for _, id := range ids {
+		if id == from {
+			id = to
+			changed = true
+		}
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result, changed
+}
+
+// danglingPosts 查找引用了失效类目的文章，同时返回有效类目 ID 集合
+func danglingPosts(db *gorm.DB) ([]*post.Post, map[int64]bool, error) {
+	var categoryIDs []int64
+	if err := db.Model(&category.Category{}).Where("deleted = ?", false).Pluck("id", &categoryIDs).Error; err != nil {
+		return nil, nil, err
+	}
+	active := make(map[int64]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		active[id] = true
+	}
+
+	var posts []*post.Post
+	if err := db.Select("id", "category_ids").Where("deleted = ?", false).Order("id").Find(&posts).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var dangling []*post.Post
+	for _, pos := range posts {
+		for _, id := range pos.CategoryIDs {
+			if !active[id] {
+				dangling = append(dangling, pos)
+				break
+			}
+		}
+	}
+	return dangling, active, nil
+}
+
+// rebasePaths 将路径以 oldPrefix 开头的类目改写为以 newPrefix 开头
+func rebasePaths(db *gorm.DB, oldPrefix, newPrefix string) error {
+	var categories []*category.Category
+	if err := db.Where("deleted = ? AND (path = ? OR path LIKE ?)", false, oldPrefix, oldPrefix+"/%").
+		Find(&categories).Error; err != nil {
+		return err
+	}
+	for _, cat := range categories {
+		path := newPrefix + strings.TrimPrefix(cat.Path, oldPrefix)
+		if err := db.Model(&category.Category{}).Where("id = ?", cat.ID).Update("path", path).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pluckIDs 查询满足条件的记录 ID
+func pluckIDs(query *gorm.DB) ([]int64, error) {
+	var ids []int64
+	err := query.Order("id").Pluck("id", &ids).Error
+	return ids, err
+}
+
+// softDelete 按 ID 软删除记录
+func softDelete(db *gorm.DB, model interface{}, ids []int64) (int, error) {
+	result := db.Model(model).Where("id IN ?", ids).Update("deleted", true)
+	return int(result.RowsAffected), result.Error
+}
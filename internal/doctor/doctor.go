@@ -0,0 +1,186 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/model"
+)
+
+const maxSamples = 10 // 每项检查展示的样例数量
+
+// Options 数据库体检配置
+type Options struct {
+	Fix bool // 是否修复发现的问题
+}
+
+// Finding 单项检查结果
+type Finding struct {
+	Name        string   // 检查项名称
+	Description string   // 检查项说明
+	Found       int      // 发现的问题数
+	Fixed       int      // 修复的问题数
+	Samples     []string // 问题样例
+}
+
+// Report 数据库体检结果
+type Report struct {
+	Findings []*Finding
+	Fixed    bool
+	Elapsed  time.Duration
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	total := 0
+	for _, f := range r.Findings {
+		total += f.Found
+		status := "正常"
+		if f.Found > 0 {
+			status = fmt.Sprintf("发现 %d 项", f.Found)
+			if r.Fixed {
+				status += fmt.Sprintf("，已修复 %d 项", f.Fixed)
+			}
+		}
+		fmt.Fprintf(&b, "[%s] %s: %s\n", f.Name, f.Description, status)
+		if len(f.Samples) > 0 {
+			fmt.Fprintf(&b, "    样例: %s\n", strings.Join(f.Samples, ", "))
+		}
+	}
+	fmt.Fprintf(&b, "共发现问题 %d 项，耗时 %s", total, r.Elapsed.Round(time.Millisecond))
+	return b.String()
+}
+
+// check 数据检查项，detect 返回存在问题的记录 ID，fix 修复这些记录并返回修复数量
+type check struct {
+	name        string
+	description string
+	table       string
+	detect      func(db *gorm.DB) ([]int64, error)
+	fix         func(db *gorm.DB, ids []int64) (int, error)
+}
+
+// dataChecks 数据一致性检查项，按顺序执行，修复模式下在同一事务中完成
+var dataChecks = []check{
+	orphanCommentsCheck,
+	orphanRepliesCheck,
+	orphanAccountRolesCheck,
+	orphanRolePermissionsCheck,
+	orphanReadingHistoriesCheck,
+	orphanBookmarksCheck,
+	orphanCategoriesCheck,
+	duplicateCategoriesCheck,
+	danglingPostCategoriesCheck,
+}
+
+// Run 执行数据库体检，Fix 为 true 时修复发现的问题
+func Run(opts Options) (*Report, error) {
+	if global.DB == nil {
+		return nil, fmt.Errorf("数据库未初始化")
+	}
+
+	start := time.Now()
+	report := &Report{Fixed: opts.Fix}
+
+	run := func(db *gorm.DB) error {
+		for _, c := range dataChecks {
+			finding, err := runCheck(db, c, opts.Fix)
+			if err != nil {
+				return err
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+		return nil
+	}
+
+	var err error
+	if opts.Fix {
+		err = global.DB.Transaction(run)
+	} else {
+		err = run(global.DB)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 部分数据库中 DDL 会隐式提交事务，索引在数据修复完成后单独处理
+	finding, err := checkIndexes(global.DB, model.GetAllModels(), opts.Fix)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, finding)
+
+	report.Elapsed = time.Since(start)
+	return report, nil
+}
+
+// runCheck 执行单项检查
+func runCheck(db *gorm.DB, c check, fix bool) (*Finding, error) {
+	ids, err := c.detect(db)
+	if err != nil {
+		return nil, fmt.Errorf("执行检查 %s 失败: %v", c.name, err)
+	}
+
+	samples := make([]string, len(ids))
+	for i, id := range ids {
+		samples[i] = fmt.Sprintf("%s#%d", c.table, id)
+	}
+
+	finding := &Finding{Name: c.name, Description: c.description, Found: len(ids), Samples: limit(samples)}
+	if fix && finding.Found > 0 {
+		if finding.Fixed, err = c.fix(db, ids); err != nil {
+			return nil, fmt.Errorf("修复 %s 失败: %v", c.name, err)
+		}
+	}
+	return finding, nil
+}
+
+// checkIndexes 检查模型声明的索引是否存在，修复模式下创建缺失的索引
+func checkIndexes(db *gorm.DB, models []interface{}, fix bool) (*Finding, error) {
+	finding := &Finding{Name: "missing_indexes", Description: "模型声明但数据库中缺失的索引"}
+
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return nil, fmt.Errorf("解析模型 %T 失败: %v", m, err)
+		}
+
+		indexes := stmt.Schema.ParseIndexes()
+		names := make([]string, 0, len(indexes))
+		for name := range indexes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if db.Migrator().HasIndex(m, name) {
+				continue
+			}
+			finding.Found++
+			finding.Samples = append(finding.Samples, stmt.Schema.Table+"."+name)
+
+			if fix {
+				if err := db.Migrator().CreateIndex(m, name); err != nil {
+					return nil, fmt.Errorf("创建索引 %s.%s 失败: %v", stmt.Schema.Table, name, err)
+				}
+				finding.Fixed++
+			}
+		}
+	}
+
+	finding.Samples = limit(finding.Samples)
+	return finding, nil
+}
+
+// limit 截取问题样例
+func limit(samples []string) []string {
+	if len(samples) > maxSamples {
+		return append(samples[:maxSamples:maxSamples], fmt.Sprintf("等 %d 项", len(samples)))
+	}
+	return samples
+}
@@ -1,5 +1,10 @@
 package biz_err
 
+import (
+	"net/http"
+	"sort"
+)
+
 const (
 	Success     = 200
 	UnKnowErr   = 00000
@@ -8,16 +13,273 @@ const (
 
 	SendImgVerificationCodeFail   = 10001
 	SendEmailVerificationCodeFail = 10002
+	LLMDisabled                   = 10003
+	DraftUnavailable              = 10004
+	EditLockUnavailable           = 10005
+	SendSmsVerificationCodeFail   = 10006
+	SmsDisabled                   = 10007
+	MailServiceUnavailable        = 10008
+	SendMessengerCodeFail         = 10009
+	MessengerDisabled             = 10010
+	OAuthDisabled                 = 10011
+	OAuthLoginFail                = 10012
+	SendPasswordResetLinkFail     = 10013
+	MagicLinkDisabled             = 10014
+	SendMagicLinkFail             = 10015
+	LdapDisabled                  = 10016
+	LdapLoginFail                 = 10017
+	RememberMeDisabled            = 10018
+	VisitorDisabled               = 10019
+	SearchIndexDisabled           = 10020
+	SitemapDisabled               = 10021
+
+	ShortLinkNotFound         = 20001
+	DraftConflict             = 20002
+	PostEditLocked            = 20003
+	EditLockLost              = 20004
+	PostDuplicate             = 20005
+	TooManyRequests           = 20006
+	VerificationCodeCooldown  = 20007
+	VerificationCodeLocked    = 20008
+	VerificationTicketInvalid = 20009
+	DisposableEmailBlocked    = 20010
+	TotpNotEnrolled           = 20011
+	TotpAlreadyEnabled        = 20012
+	MessengerNotLinked        = 20013
+	OAuthStateInvalid         = 20014
+	OAuthEmailUnavailable     = 20015
+	PasskeyVerifyFail         = 20016
+	PasskeyDuplicate          = 20017
+	PasskeyNotFound           = 20018
+	TotpLoginTicketInvalid    = 20019
+	RecoveryCodeInvalid       = 20020
+	TotpSetupRequired         = 20021
+	RefreshTokenInvalid       = 20022
+	APIKeyInvalid             = 20023
+	APIKeyScopeDenied         = 20024
+	APIKeyNotFound            = 20025
+	SessionNotFound           = 20026
+	PasswordResetLinkInvalid  = 20027
+	MagicLinkInvalid          = 20028
+	MagicLinkDeviceMismatch   = 20029
+	LoginLocked               = 20030
+	AccountUnlockLinkInvalid  = 20031
+	PasswordTooWeak           = 20032
+	LdapInvalidCredentials    = 20033
+	LdapAccountUnavailable    = 20034
+	AccountDeletionLastAdmin  = 20035
+	DataExportCooldown        = 20036
+	DataExportLinkInvalid     = 20037
+	AvatarInvalid             = 20038
+	AvatarTooLarge            = 20039
+	ProfileNotFound           = 20040
+	AvatarNotFound            = 20041
+	AdminUserNotFound         = 20042
+	AdminSelfOperation        = 20043
+	AdminLastAdmin            = 20044
+	AccountDisabled           = 20045
+	InviteRequired            = 20046
+	InviteInvalid             = 20047
+	InviteNotFound            = 20048
+	InviteCreateDenied        = 20049
+	InviteLimitExceeded       = 20050
+	RegistrationClosed        = 20051
+	AccountPendingApproval    = 20052
+	AccountRejected           = 20053
+	RegistrationNotPending    = 20054
+	SessionSuperseded         = 20055
+	AdminIPDenied             = 20056
+	IPRuleInvalid             = 20057
+	IPRuleSelfLockout         = 20058
+	LoginCaptchaRequired      = 20059
+	AccountBanNotFound        = 20060
+	DeviceTokenInvalid        = 20061
+	DeviceTokenNotFound       = 20062
+	PostStatusConflict        = 20063
+	PostInReview              = 20064
+	PublishAtPassed           = 20065
+	RevisionNotFound          = 20066
+	SearchReindexRunning      = 20067
+	SitemapNotFound           = 20068
+	PostNotFound              = 20069
+	ReactionTypeInvalid       = 20070
+	ReactionIdentityRequired  = 20071
+	SeriesNotFound            = 20072
+	SeriesPostConflict        = 20073
+	SeriesOrderMismatch       = 20074
+	ImportRunning             = 20075
+	ImportTaskNotFound        = 20076
+	TrashPostNotFound         = 20077
+	TrashCommentNotFound      = 20078
+	PostAuthorsInvalid        = 20079
+	PostAuthorForbidden       = 20080
+	AuthorNotFound            = 20081
+	PostLoginRequired         = 20082
+	PostPasswordIncorrect     = 20083
+	PostPasswordMissing       = 20084
+	PostNotProtected          = 20085
+	PostUnlockLimited         = 20086
+	PostTranslationInvalid    = 20087
+	PostTranslationConflict   = 20088
 )
 
-var CodeMsg = map[int]string{
-	Success:     "请求成功",
-	UnKnowErr:   "未知业务异常",
-	ServerError: "服务端异常",
-	BadRequest:  "错误请求",
+// Definition 错误码定义
+type Definition struct {
+	Code        int    // 业务错误码
+	HTTPStatus  int    // 对应的 HTTP 状态码
+	Message     string // 默认错误信息
+	I18nKey     string // 国际化资源键，供客户端映射本地化文案
+	Description string // 错误码说明
+}
+
+// definitions 错误码注册表，新增错误码时在此登记
+var definitions = map[int]Definition{}
 
-	SendImgVerificationCodeFail:   "发送图形验证码失败",
-	SendEmailVerificationCodeFail: "发送邮箱验证码失败",
+// CodeMsg 错误码与默认错误信息的映射，由注册表生成
+var CodeMsg = map[int]string{}
+
+func init() {
+	for _, def := range []Definition{
+		{Success, http.StatusOK, "请求成功", "success", "请求处理成功"},
+		{UnKnowErr, http.StatusInternalServerError, "未知业务异常", "error.unknown", "未登记的错误码均归为此类"},
+		{ServerError, http.StatusInternalServerError, "服务端异常", "error.server", "服务端处理请求时发生异常"},
+		{BadRequest, http.StatusBadRequest, "错误请求", "error.bad_request", "请求参数绑定或校验失败"},
+
+		{SendImgVerificationCodeFail, http.StatusInternalServerError, "发送图形验证码失败", "error.verification.send_img_code", "生成或缓存图形验证码失败"},
+		{SendEmailVerificationCodeFail, http.StatusInternalServerError, "发送邮箱验证码失败", "error.verification.send_email_code", "发送或缓存邮箱验证码失败"},
+		{LLMDisabled, http.StatusServiceUnavailable, "大模型服务未开启", "error.llm.disabled", "配置中未开启大模型服务，无法生成摘要等文本"},
+		{DraftUnavailable, http.StatusServiceUnavailable, "草稿存储不可用", "error.draft.unavailable", "草稿自动保存依赖 Redis，Redis 未连接时不可用"},
+		{EditLockUnavailable, http.StatusServiceUnavailable, "编辑锁不可用", "error.edit_lock.unavailable", "编辑锁依赖 Redis，Redis 未连接时不可用"},
+		{SendSmsVerificationCodeFail, http.StatusInternalServerError, "发送短信验证码失败", "error.verification.send_sms_code", "发送或缓存短信验证码失败"},
+		{SmsDisabled, http.StatusServiceUnavailable, "短信服务未开启", "error.sms.disabled", "配置中未开启短信服务，无法发送短信验证码"},
+		{MailServiceUnavailable, http.StatusServiceUnavailable, "邮件服务暂不可用，请稍后再试", "error.mail.unavailable", "SMTP 服务连续发送失败触发熔断，熔断期间不再发送邮件，到期后自动探测恢复"},
+		{SendMessengerCodeFail, http.StatusInternalServerError, "发送即时通讯验证码失败", "error.verification.send_messenger_code", "发送或缓存 Telegram、WhatsApp 验证码失败"},
+		{MessengerDisabled, http.StatusServiceUnavailable, "即时通讯服务未开启", "error.messenger.disabled", "配置中未开启请求的 Telegram 或 WhatsApp 服务"},
+		{OAuthDisabled, http.StatusServiceUnavailable, "第三方登录未开启", "error.oauth.disabled", "配置中未填写请求的第三方登录服务的客户端 ID 与密钥"},
+		{OAuthLoginFail, http.StatusBadGateway, "第三方登录失败", "error.oauth.login_fail", "使用授权码换取令牌或获取第三方账号信息失败"},
+		{SendPasswordResetLinkFail, http.StatusInternalServerError, "发送重置密码邮件失败", "error.password_reset.send_fail", "生成、缓存或发送重置密码链接失败"},
+		{MagicLinkDisabled, http.StatusServiceUnavailable, "免密登录未开启", "error.magic_link.disabled", "配置中未开启 MAGIC_LINK_ENABLED，无法使用邮件链接登录"},
+		{SendMagicLinkFail, http.StatusInternalServerError, "发送登录链接邮件失败", "error.magic_link.send_fail", "生成、缓存或发送免密登录链接失败"},
+		{LdapDisabled, http.StatusServiceUnavailable, "LDAP 登录未开启", "error.ldap.disabled", "配置中未开启 LDAP_ENABLED 或未填写 LDAP_URL"},
+		{LdapLoginFail, http.StatusBadGateway, "LDAP 登录失败", "error.ldap.login_fail", "连接目录服务、服务账号绑定或搜索用户失败"},
+		{RememberMeDisabled, http.StatusServiceUnavailable, "记住我未开启", "error.remember_me.disabled", "配置中未开启 REMEMBER_ME_ENABLED，无法签发或使用设备令牌"},
+		{VisitorDisabled, http.StatusServiceUnavailable, "匿名访客身份未开启", "error.visitor.disabled", "配置中未开启 VISITOR_ENABLED，无法签发访客 ID"},
+		{SearchIndexDisabled, http.StatusServiceUnavailable, "全文检索未开启", "error.search.disabled", "配置中未开启 SEARCH_INDEX_ENABLED，请使用 /post/search 搜索文章"},
+		{SitemapDisabled, http.StatusServiceUnavailable, "站点地图未开启", "error.sitemap.disabled", "配置中未开启 SITEMAP_ENABLED，不提供 /sitemap.xml"},
+
+		{ShortLinkNotFound, http.StatusNotFound, "短链接不存在", "error.shortlink.not_found", "短码不存在或对应的文章已删除"},
+		{DraftConflict, http.StatusConflict, "草稿版本冲突", "error.draft.conflict", "草稿已在其他窗口或设备中更新，提交的版本号已过期"},
+		{PostEditLocked, http.StatusConflict, "文章正在被其他账户编辑", "error.edit_lock.locked", "文章的编辑锁由其他账户持有，可等待过期或接管"},
+		{EditLockLost, http.StatusConflict, "编辑锁已失效", "error.edit_lock.lost", "编辑锁已过期或已被其他账户接管，续期失败"},
+		{PostDuplicate, http.StatusConflict, "文章内容与已有文章几乎相同", "error.post.duplicate", "重复内容检测为 block 模式时，拒绝创建与已有文章内容指纹相近的文章"},
+		{TooManyRequests, http.StatusTooManyRequests, "请求过于频繁，请稍后再试", "error.too_many_requests", "超过限流规则允许的请求次数，响应头 Retry-After 给出等待秒数"},
+		{VerificationCodeCooldown, http.StatusTooManyRequests, "验证码已发送，请稍后再重新发送", "error.verification.cooldown", "验证码仍在有效期内，冷却期结束后可调用重新发送接口"},
+		{VerificationCodeLocked, http.StatusBadRequest, "验证码错误次数过多，请重新获取", "error.verification.locked", "同一验证码输错次数达到 VERIFICATION_MAX_ATTEMPTS 后作废，需重新发送验证码"},
+		{VerificationTicketInvalid, http.StatusBadRequest, "验证凭证无效或已使用", "error.verification.ticket_invalid", "验证凭证签名无效、已过期、已使用，或与请求的渠道与接收方不匹配"},
+		{DisposableEmailBlocked, http.StatusBadRequest, "不支持使用一次性邮箱", "error.email.disposable", "邮箱域名在一次性邮箱黑名单中，发送邮箱验证码与注册时拒绝"},
+		{TotpNotEnrolled, http.StatusBadRequest, "账户未开启两步验证", "error.totp.not_enrolled", "提交了 TOTP 动态码，但账户未开启两步验证，需改用邮箱验证码"},
+		{TotpAlreadyEnabled, http.StatusConflict, "账户已开启两步验证", "error.totp.already_enabled", "重新绑定验证器前需先关闭两步验证"},
+		{MessengerNotLinked, http.StatusBadRequest, "账户未绑定该即时通讯账号", "error.messenger.not_linked", "发送即时通讯验证码前需先在账户中绑定 Telegram 或 WhatsApp 账号"},
+		{OAuthStateInvalid, http.StatusBadRequest, "第三方登录状态无效，请重新登录", "error.oauth.state_invalid", "回调中的 state 与发起授权时不一致、已使用或已过期"},
+		{OAuthEmailUnavailable, http.StatusBadRequest, "第三方账号未提供已验证的邮箱", "error.oauth.email_unavailable", "第三方账号未公开邮箱或邮箱未经验证，无法关联或创建账户"},
+		{PasskeyVerifyFail, http.StatusBadRequest, "通行密钥验证失败", "error.passkey.verify_fail", "挑战值已过期或已使用，或通行密钥的来源、签名、签名计数器校验未通过"},
+		{PasskeyDuplicate, http.StatusConflict, "通行密钥已绑定", "error.passkey.duplicate", "提交的凭证已绑定到账户，无需重复注册"},
+		{PasskeyNotFound, http.StatusNotFound, "通行密钥不存在", "error.passkey.not_found", "通行密钥不存在或不属于当前账户"},
+		{TotpLoginTicketInvalid, http.StatusBadRequest, "登录凭证无效或已过期，请重新登录", "error.totp.login_ticket_invalid", "两步验证登录凭证已过期、已使用或恢复码输错次数过多，需重新使用密码登录"},
+		{RecoveryCodeInvalid, http.StatusBadRequest, "恢复码错误或已使用", "error.totp.recovery_code_invalid", "恢复码不存在或已使用，每个恢复码只能使用一次"},
+		{TotpSetupRequired, http.StatusForbidden, "请先开启两步验证", "error.totp.setup_required", "账户角色在 TOTP_REQUIRED_ROLES 中，开启两步验证前不能访问管理接口与需要权限的接口"},
+		{RefreshTokenInvalid, http.StatusUnauthorized, "refresh token 无效，请重新登录", "error.refresh_token.invalid", "refresh token 签名无效、已过期，或已在刷新、登出、全部设备登出时吊销"},
+		{APIKeyInvalid, http.StatusUnauthorized, "API Key 无效或已过期", "error.api_key.invalid", "Authorization 请求头中的 API Key 不存在、已删除、已过期，或所属账户处于注销冷静期"},
+		{APIKeyScopeDenied, http.StatusForbidden, "API Key 权限不足", "error.api_key.scope_denied", "read 范围的 Key 仅能发起 GET 请求，仅 admin 范围的 Key 可访问管理接口，API Key 不能管理 API Key，非管理员不能创建 admin 范围的 Key"},
+		{APIKeyNotFound, http.StatusNotFound, "API Key 不存在", "error.api_key.not_found", "API Key 不存在或不属于当前账户"},
+		{SessionNotFound, http.StatusNotFound, "会话不存在或已结束", "error.session.not_found", "登录会话不存在、已过期、已结束或不属于当前账户"},
+		{PasswordResetLinkInvalid, http.StatusBadRequest, "重置密码链接无效或已过期", "error.password_reset.link_invalid", "重置密码链接已过期、已使用，或重新发送后已被新链接替代"},
+		{MagicLinkInvalid, http.StatusBadRequest, "登录链接无效或已过期", "error.magic_link.invalid", "免密登录链接已过期、已使用，或重新发送后已被新链接替代"},
+		{MagicLinkDeviceMismatch, http.StatusConflict, "请在发起登录的设备上打开链接，或确认本次登录", "error.magic_link.device_mismatch", "打开链接的设备未持有发起请求时返回的请求 ID，确认登录后发起请求的设备才会登录，响应中返回发起请求的设备与 IP"},
+		{LoginLocked, http.StatusLocked, "登录失败次数过多，请稍后再试", "error.login.locked", "账户或 IP 在统计窗口内登录失败次数达到阈值，锁定期间拒绝密码登录，响应中返回剩余锁定秒数"},
+		{AccountUnlockLinkInvalid, http.StatusBadRequest, "解锁链接无效或已过期", "error.login.unlock_link_invalid", "账户解锁链接已过期、已使用，或账户已被解锁"},
+		{PasswordTooWeak, http.StatusBadRequest, "密码不符合安全要求", "error.password.too_weak", "密码不满足密码强度策略，错误信息中给出具体原因，策略可通过 /account/passwordPolicy 获取"},
+		{LdapInvalidCredentials, http.StatusUnauthorized, "用户名或密码错误", "error.ldap.invalid_credentials", "目录中不存在该用户、用户不唯一或密码错误"},
+		{LdapAccountUnavailable, http.StatusForbidden, "目录账号无法登录本站", "error.ldap.account_unavailable", "目录中未填写用户的邮箱，或关闭了 LDAP_AUTO_PROVISION 且邮箱未注册"},
+		{AccountDeletionLastAdmin, http.StatusConflict, "站点唯一的管理员不能注销账户", "error.account_deletion.last_admin", "注销后站点将没有管理员，需先将其他账户设为管理员"},
+		{DataExportCooldown, http.StatusTooManyRequests, "数据导出申请过于频繁，请稍后再试", "error.data_export.cooldown", "距上次申请导出个人数据未超过 DATA_EXPORT_COOLDOWN 分钟"},
+		{DataExportLinkInvalid, http.StatusBadRequest, "下载链接无效或已过期", "error.data_export.link_invalid", "下载链接签名无效、已超过 DATA_EXPORT_LINK_TTL 小时，或导出文件已删除"},
+		{AvatarInvalid, http.StatusBadRequest, "头像图片无效", "error.avatar.invalid", "上传的文件不是 JPEG、PNG、GIF 或 WebP 图片，图片像素过多，或裁剪区域超出图片范围"},
+		{AvatarTooLarge, http.StatusRequestEntityTooLarge, "头像图片过大", "error.avatar.too_large", "上传的图片超过 AVATAR_MAX_SIZE KB"},
+		{ProfileNotFound, http.StatusNotFound, "用户不存在", "error.profile.not_found", "账户不存在或处于注销冷静期"},
+		{AdminUserNotFound, http.StatusNotFound, "用户不存在", "error.admin.user_not_found", "用户管理接口中指定的账户 ID 不存在或已被永久删除"},
+		{AdminSelfOperation, http.StatusConflict, "不能对自己的账户执行此操作", "error.admin.self_operation", "管理员不能停用自己的账户或变更自己的角色，避免失去管理权限"},
+		{AdminLastAdmin, http.StatusConflict, "站点至少需要保留一名管理员", "error.admin.last_admin", "停用账户或变更角色后站点将没有可用的管理员，需先将其他账户设为管理员"},
+		{AccountDisabled, http.StatusForbidden, "账户已被停用，请联系管理员", "error.account.disabled", "账户已被管理员停用，停用期间不能通过任何方式登录，也不能使用 API Key，响应中返回停用原因；限时停用返回到期时间，到期后自动恢复"},
+		{AvatarNotFound, http.StatusNotFound, "头像不存在", "error.avatar.not_found", "头像文件名或默认头像的邮箱哈希无效，或头像已被新上传的头像替换"},
+		{InviteRequired, http.StatusForbidden, "注册需要邀请码", "error.invite.required", "站点开启了 INVITE_REQUIRED，注册时需填写邀请码，GitHub、Google、Gitee 登录也不能自动创建账户"},
+		{InviteInvalid, http.StatusBadRequest, "邀请码无效、已过期或已用完", "error.invite.invalid", "邀请码不存在、已被撤销、已过期或使用次数已达上限"},
+		{InviteNotFound, http.StatusNotFound, "邀请码不存在", "error.invite.not_found", "邀请码不存在、已被撤销，或不是当前用户创建的"},
+		{InviteCreateDenied, http.StatusForbidden, "没有权限创建该邀请码", "error.invite.create_denied", "站点关闭了 INVITE_USER_ENABLED，或普通用户创建的邀请码超过了 INVITE_USER_MAX_USES 次或 INVITE_DEFAULT_TTL 天"},
+		{InviteLimitExceeded, http.StatusConflict, "可用的邀请码数量已达上限", "error.invite.limit_exceeded", "普通用户未用完且未过期的邀请码已达 INVITE_USER_LIMIT 个，需等待使用、过期或撤销后再创建"},
+		{RegistrationClosed, http.StatusForbidden, "站点已关闭注册", "error.registration.closed", "REGISTRATION_MODE 为 closed 时不接受新用户注册，GitHub、Google、Gitee 登录也不再自动创建账户"},
+		{AccountPendingApproval, http.StatusForbidden, "账户正在等待管理员审核", "error.registration.pending", "REGISTRATION_MODE 为 approval 时新注册的账户需管理员审核通过后才能登录"},
+		{AccountRejected, http.StatusForbidden, "账户注册申请未通过审核", "error.registration.rejected", "管理员拒绝了该账户的注册申请，登录时返回管理员填写的原因"},
+		{RegistrationNotPending, http.StatusConflict, "用户的注册无需审核", "error.registration.not_pending", "只能拒绝待审核或已拒绝的注册申请，已正常使用的账户应改为停用"},
+		{SessionSuperseded, http.StatusUnauthorized, "账户已在其他设备登录，请重新登录", "error.session.superseded", "SESSION_SINGLE_ACTIVE 为 true 时每个用户只保留最近一次登录的会话，此前的会话在下次访问时结束"},
+		{AdminIPDenied, http.StatusForbidden, "当前 IP 不允许访问管理接口", "error.ip_access.denied", "请求来源 IP 命中了管理接口的黑名单，或配置了白名单但不在白名单中"},
+		{IPRuleInvalid, http.StatusBadRequest, "IP 或 CIDR 格式无效", "error.ip_access.invalid_rule", "规则需为 IPv4、IPv6 地址或 CIDR，如 203.0.113.7 或 10.0.0.0/8"},
+		{IPRuleSelfLockout, http.StatusConflict, "修改后当前 IP 将无法访问管理接口", "error.ip_access.self_lockout", "修改后当前管理员的来源 IP 会命中黑名单或不在白名单中，为避免锁定自己拒绝修改"},
+		{LoginCaptchaRequired, http.StatusBadRequest, "登录需要完成人机验证", "error.login.captcha_required", "LOGIN_CAPTCHA_THRESHOLD 大于 0 时，账户或 IP 登录失败次数达到阈值后，登录请求需携带图形验证码或验证凭证"},
+		{AccountBanNotFound, http.StatusNotFound, "停用记录不存在", "error.account_ban.not_found", "请求的停用记录 ID 不存在"},
+		{DeviceTokenInvalid, http.StatusUnauthorized, "设备令牌无效或已过期，请重新登录", "error.device_token.invalid", "设备令牌不存在、已过期、已删除或已轮换，或提交的设备标识与开启记住我时不一致；设备标识不一致时令牌随即删除"},
+		{DeviceTokenNotFound, http.StatusNotFound, "设备令牌不存在", "error.device_token.not_found", "请求删除的设备令牌不存在或不属于当前账户"},
+		{PostStatusConflict, http.StatusConflict, "不允许的文章状态变更", "error.post.status_transition", "请求的发布状态不能由文章的当前状态变更而来，或状态已被其他请求修改"},
+		{PostInReview, http.StatusConflict, "文章正在审核", "error.post.in_review", "文章正在审核或等待定时发布，需先完成审核流程才能变更发布状态"},
+		{PublishAtPassed, http.StatusBadRequest, "定时发布时间已过", "error.post.publish_at_passed", "定时发布时间需晚于当前时间，立即发布请变更发布状态"},
+		{RevisionNotFound, http.StatusNotFound, "文章修订不存在", "error.post.revision_not_found", "文章没有该版本号的修订，或修订已超过保留期限被清理"},
+		{SearchReindexRunning, http.StatusConflict, "全文索引正在重建", "error.search.reindex_running", "上一次重建尚未完成，可通过 /task/listTasks 查看 search_index_rebuild 任务的运行状态"},
+		{SitemapNotFound, http.StatusNotFound, "站点地图不存在", "error.sitemap.not_found", "站点地图分页超出范围，或地址数未超过 SITEMAP_MAX_URLS 无需分页，请访问 /sitemap.xml"},
+		{PostNotFound, http.StatusNotFound, "文章不存在", "error.post.not_found", "别名不对应任何文章，或文章已删除；草稿与归档的文章对作者与管理员以外的请求也视为不存在"},
+		{ReactionTypeInvalid, http.StatusBadRequest, "不支持的表态类型", "error.reaction.type_invalid", "表态类型需为 REACTION_TYPES 中配置的类型"},
+		{ReactionIdentityRequired, http.StatusUnauthorized, "请登录后再表态", "error.reaction.identity_required", "未登录且未携带有效的访客 ID；站点未开启访客表态或匿名访客身份时需登录"},
+		{SeriesNotFound, http.StatusNotFound, "系列不存在", "error.series.not_found", "系列不存在或已删除"},
+		{SeriesPostConflict, http.StatusConflict, "文章已属于其他系列", "error.series.post_conflict", "每篇文章最多属于一个系列，需先从原系列中移出"},
+		{SeriesOrderMismatch, http.StatusBadRequest, "系列文章列表不一致", "error.series.order_mismatch", "重新排序时需提交系列中全部未删除的文章 ID，且不能重复或包含系列之外的文章"},
+		{ImportRunning, http.StatusConflict, "已有导入任务正在进行", "error.import.running", "同一时间只能运行一个后台导入任务，需等待当前任务完成"},
+		{ImportTaskNotFound, http.StatusNotFound, "导入任务不存在", "error.import.task_not_found", "任务 ID 错误，或任务结束已超过 24 小时，记录已过期"},
+		{TrashPostNotFound, http.StatusNotFound, "回收站中不存在该文章", "error.trash.post_not_found", "文章未被删除，或删除后已超过保留天数被永久删除"},
+		{TrashCommentNotFound, http.StatusNotFound, "回收站中不存在该评论", "error.trash.comment_not_found", "评论未被删除，或删除后已超过保留天数被永久删除"},
+		{PostAuthorsInvalid, http.StatusBadRequest, "文章作者列表无效", "error.post.authors_invalid", "作者列表不能重复，至少包含一位角色为 author 的作者，且账户均需存在"},
+		{PostAuthorForbidden, http.StatusForbidden, "无权修改文章作者", "error.post.author_forbidden", "仅管理员与文章角色为 author 的作者可以修改作者列表"},
+		{AuthorNotFound, http.StatusNotFound, "作者不存在", "error.post.author_not_found", "账户不存在、已停用或正在注销"},
+		{PostLoginRequired, http.StatusUnauthorized, "文章仅登录用户可见", "error.post.login_required", "文章的访问权限为 login，需要携带登录令牌访问"},
+		{PostPasswordIncorrect, http.StatusForbidden, "文章访问密码错误", "error.post.password_incorrect", "访问密码与文章设置的密码不一致"},
+		{PostPasswordMissing, http.StatusBadRequest, "密码保护的文章需要设置访问密码", "error.post.password_missing", "访问权限设置为 password 时，文章尚未设置过访问密码则必须填写 access_password"},
+		{PostNotProtected, http.StatusBadRequest, "文章未设置密码保护", "error.post.not_protected", "只有访问权限为 password 的文章需要解锁"},
+		{PostUnlockLimited, http.StatusTooManyRequests, "解锁尝试次数过多，请稍后再试", "error.post.unlock_limited", "同一 IP 在 15 分钟内解锁同一篇文章失败 10 次后暂停解锁"},
+		{PostTranslationInvalid, http.StatusBadRequest, "文章翻译关联无效", "error.post.translation_invalid", "不能关联文章自身，已属于其他翻译组的文章需先解除关联"},
+		{PostTranslationConflict, http.StatusConflict, "翻译组中已有该语言的文章", "error.post.translation_conflict", "翻译组中每种语言只能有一篇文章，需先修改文章语言或解除原有翻译的关联"},
+	} {
+		Register(def)
+	}
+}
+
+// Register 登记错误码，重复登记时覆盖原定义
+func Register(def Definition) {
+	definitions[def.Code] = def
+	CodeMsg[def.Code] = def.Message
+}
+
+// Lookup 查询错误码定义
+func Lookup(code int) (Definition, bool) {
+	def, ok := definitions[code]
+	return def, ok
+}
+
+// Catalog 按错误码升序返回全部错误码定义
+func Catalog() []Definition {
+	catalog := make([]Definition, 0, len(definitions))
+	for _, def := range definitions {
+		catalog = append(catalog, def)
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code })
+	return catalog
 }
 
 func GetMessage(code int) string {
@@ -26,3 +288,11 @@ func GetMessage(code int) string {
 	}
 	return CodeMsg[UnKnowErr]
 }
+
+// HTTPStatus 获取错误码对应的 HTTP 状态码，未登记的错误码返回 500
+func HTTPStatus(code int) int {
+	if def, ok := definitions[code]; ok {
+		return def.HTTPStatus
+	}
+	return http.StatusInternalServerError
+}
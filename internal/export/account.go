@@ -0,0 +1,332 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	account "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// 个人数据导出文件未配置时的默认值
+const (
+	DefaultAccountExportDir = "./exports"    // 导出文件的存放目录
+	DefaultAccountExportTTL = 24 * time.Hour // 导出文件与下载链接的有效期
+)
+
+// accountExportExt 导出文件的扩展名
+const accountExportExt = ".zip"
+
+// AccountReport 个人数据导出结果
+type AccountReport struct {
+	Posts        int // 导出文章数
+	Comments     int // 导出评论数
+	LoginRecords int // 导出登录记录数
+}
+
+func (r AccountReport) String() string {
+	return fmt.Sprintf("文章 %d 篇, 评论 %d 条, 登录记录 %d 条", r.Posts, r.Comments, r.LoginRecords)
+}
+
+// profileData 导出的账户资料，不包含密码、TOTP 密钥与恢复码
+type profileData struct {
+	ID          int64             `json:"id"`
+	Email       string            `json:"email"`
+	Phone       string            `json:"phone,omitempty"`
+	Nickname    string            `json:"nickname"`
+	Avatar      string            `json:"avatar,omitempty"`
+	DisplayName string            `json:"display_name,omitempty"`
+	Bio         string            `json:"bio,omitempty"`
+	Website     string            `json:"website,omitempty"`
+	SocialLinks map[string]string `json:"social_links,omitempty"`
+	Role        string            `json:"role,omitempty"`
+	TotpEnabled bool              `json:"totp_enabled"`
+	CreatedAt   string            `json:"created_at"`
+	Messengers  map[string]string `json:"messengers,omitempty"`
+	Preferences map[string]bool   `json:"preferences"`
+	Identities  []identityData    `json:"linked_accounts"`
+	Passkeys    []passkeyData     `json:"passkeys"`
+}
+
+// identityData 关联的第三方账号
+type identityData struct {
+	Provider string `json:"provider"`
+	Email    string `json:"email,omitempty"`
+	LinkedAt string `json:"linked_at"`
+}
+
+// passkeyData 绑定的通行密钥，不包含公钥
+type passkeyData struct {
+	Name       string `json:"name"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+}
+
+// postData 导出的文章，Markdown 正文另存为 File 指向的文件
+type postData struct {
+	ID           int64   `json:"id"`
+	Title        string  `json:"title"`
+	Visibility   bool    `json:"visibility"`
+	Status       string  `json:"status"`
+	ReviewStatus string  `json:"review_status,omitempty"`
+	CategoryIDs  []int64 `json:"category_ids"`
+	CreatedAt    string  `json:"created_at"`
+	ModifiedAt   string  `json:"modified_at"`
+	File         string  `json:"file"`
+}
+
+// commentData 导出的评论
+type commentData struct {
+	ID               int64  `json:"id"`
+	PostID           int64  `json:"post_id"`
+	ReplyToCommentID int64  `json:"reply_to_comment_id,omitempty"`
+	Content          string `json:"content"`
+	CreatedAt        string `json:"created_at"`
+}
+
+// loginRecordData 导出的登录记录
+type loginRecordData struct {
+	Time         string `json:"time"`
+	Method       string `json:"method"`
+	SecondFactor string `json:"second_factor,omitempty"`
+	Success      bool   `json:"success"`
+	FailReason   string `json:"fail_reason,omitempty"`
+	IP           string `json:"ip"`
+	Location     string `json:"location,omitempty"`
+	Device       string `json:"device"`
+	UserAgent    string `json:"user_agent"`
+}
+
+// ExportAccount 将账户的资料、提交过审核的文章、评论与登录记录写入 ZIP，数据以 JSON 保存，文章与评论另附 Markdown
+func ExportAccount(accountID int64, w io.Writer) (*AccountReport, error) {
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := mapper.GetPostsSubmittedByAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+	comments, err := mapper.GetCommentsByUserID(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("获取评论失败: %v", err)
+	}
+	records, err := mapper.GetLoginRecordsByAccountID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := newProfileData(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	zw := zip.NewWriter(w)
+	postViews := make([]postData, len(posts))
+	for i, pos := range posts {
+		postViews[i] = postData{
+			ID:           pos.ID,
+			Title:        pos.Title,
+			Visibility:   pos.Visibility,
+			Status:       pos.Status,
+			ReviewStatus: pos.ReviewStatus,
+			CategoryIDs:  pos.CategoryIDs,
+			CreatedAt:    formatTime(pos.GmtCreate),
+			ModifiedAt:   formatTime(pos.GmtModified),
+			File:         fmt.Sprintf("posts/%d.md", pos.ID),
+		}
+		var md strings.Builder
+		fmt.Fprintf(&md, "# %s\n\n", pos.Title)
+		fmt.Fprintf(&md, "> 创建于 %s，更新于 %s\n\n", postViews[i].CreatedAt, postViews[i].ModifiedAt)
+		md.WriteString(pos.ContentMarkdown)
+		md.WriteString("\n")
+		if err := writeZipFile(zw, postViews[i].File, []byte(md.String())); err != nil {
+			return nil, err
+		}
+	}
+
+	commentViews := make([]commentData, len(comments))
+	var commentsMD strings.Builder
+	commentsMD.WriteString("# 评论\n")
+	for i, cmt := range comments {
+		commentViews[i] = commentData{
+			ID:               cmt.ID,
+			PostID:           cmt.PostId,
+			ReplyToCommentID: cmt.ReplyToCommentId,
+			Content:          cmt.Content,
+			CreatedAt:        formatTime(cmt.GmtCreate),
+		}
+		fmt.Fprintf(&commentsMD, "\n## %s · 文章 %d\n\n%s\n", commentViews[i].CreatedAt, cmt.PostId, cmt.Content)
+	}
+
+	recordViews := make([]loginRecordData, len(records))
+	for i, record := range records {
+		recordViews[i] = loginRecordData{
+			Time:         formatTime(record.GmtCreate),
+			Method:       record.Method,
+			SecondFactor: record.SecondFactor,
+			Success:      record.Success,
+			FailReason:   record.FailReason,
+			IP:           record.IP,
+			Location:     record.Location,
+			Device:       record.Device,
+			UserAgent:    record.UserAgent,
+		}
+	}
+
+	report := &AccountReport{Posts: len(posts), Comments: len(comments), LoginRecords: len(records)}
+	for _, file := range []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", profile},
+		{"posts.json", postViews},
+		{"comments.json", commentViews},
+		{"login_history.json", recordViews},
+	} {
+		content, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("序列化 %s 失败: %v", file.name, err)
+		}
+		if err := writeZipFile(zw, file.name, content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeZipFile(zw, "comments.md", []byte(commentsMD.String())); err != nil {
+		return nil, err
+	}
+	if err := writeZipFile(zw, "README.md", []byte(accountReadme(acc, report))); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("写入导出文件失败: %v", err)
+	}
+	return report, nil
+}
+
+// AccountExportPath 导出文件的路径，文件名为账户 ID 加导出 ID，导出 ID 只能是十六进制字符串
+func AccountExportPath(dir string, accountID int64, exportID string) (string, error) {
+	if exportID == "" || strings.Trim(exportID, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("导出 ID 无效: %s", exportID)
+	}
+	return filepath.Join(dir, fmt.Sprintf("%d-%s%s", accountID, exportID, accountExportExt)), nil
+}
+
+// PurgeAccountExports 删除 dir 中在 before 之前生成的导出文件，返回被删除的文件名，目录不存在时不删除
+func PurgeAccountExports(dir string, before time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取导出目录失败: %v", err)
+	}
+
+	var removed []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != accountExportExt {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("删除导出文件失败: %v", err)
+		}
+		removed = append(removed, entry.Name())
+	}
+	return removed, nil
+}
+
+// newProfileData 整理账户资料、角色、关联的第三方账号与通行密钥
+func newProfileData(acc *account.Account) (*profileData, error) {
+	profile := &profileData{
+		ID:          acc.ID,
+		Email:       acc.Email,
+		Phone:       acc.Phone,
+		Nickname:    acc.Nickname,
+		Avatar:      acc.Avatar,
+		DisplayName: acc.DisplayName,
+		Bio:         acc.Bio,
+		Website:     acc.Website,
+		SocialLinks: acc.SocialLinks(),
+		TotpEnabled: acc.TotpEnabled,
+		CreatedAt:   formatTime(acc.GmtCreate),
+		Preferences: map[string]bool{
+			account.PreferenceReadingHistory: acc.Preference(account.PreferenceReadingHistory, true),
+			account.PreferenceLoginAlert:     acc.Preference(account.PreferenceLoginAlert, true),
+		},
+		Identities: []identityData{},
+		Passkeys:   []passkeyData{},
+	}
+	if providers := acc.MessengerProviders(); len(providers) > 0 {
+		profile.Messengers = make(map[string]string, len(providers))
+		for _, provider := range providers {
+			profile.Messengers[provider] = acc.MessengerHandle(provider)
+		}
+	}
+	if accountRole, err := mapper.GetRoleByAccountID(acc.ID); err == nil {
+		if role, err := mapper.GetRoleByID(accountRole.RoleID); err == nil {
+			profile.Role = role.Code
+		}
+	}
+
+	identities, err := mapper.GetOAuthIdentitiesByAccountID(acc.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		profile.Identities = append(profile.Identities, identityData{
+			Provider: identity.Provider,
+			Email:    identity.Email,
+			LinkedAt: formatTime(identity.GmtCreate),
+		})
+	}
+	credentials, err := mapper.GetWebAuthnCredentialsByAccountID(acc.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, credential := range credentials {
+		passkey := passkeyData{Name: credential.Name, CreatedAt: formatTime(credential.GmtCreate)}
+		if credential.LastUsedAt > 0 {
+			passkey.LastUsedAt = formatTime(credential.LastUsedAt)
+		}
+		profile.Passkeys = append(profile.Passkeys, passkey)
+	}
+	return profile, nil
+}
+
+// accountReadme 导出文件的说明
+func accountReadme(acc *account.Account, report *AccountReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s 的个人数据\n\n", acc.Nickname)
+	fmt.Fprintf(&b, "导出时间：%s\n\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "- `profile.json`：账户资料、角色、偏好设置、关联的第三方账号与通行密钥\n")
+	fmt.Fprintf(&b, "- `posts.json`：提交过审核的文章 %d 篇，正文见 `posts/` 目录下的 Markdown 文件\n", report.Posts)
+	fmt.Fprintf(&b, "- `comments.json`：发表的评论 %d 条，`comments.md` 为便于阅读的版本\n", report.Comments)
+	fmt.Fprintf(&b, "- `login_history.json`：登录记录 %d 条\n", report.LoginRecords)
+	return b.String()
+}
+
+// writeZipFile 向 ZIP 写入一个文件
+func writeZipFile(zw *zip.Writer, name string, content []byte) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("写入 %s 失败: %v", name, err)
+	}
+	if _, err := f.Write(content); err != nil {
+		return fmt.Errorf("写入 %s 失败: %v", name, err)
+	}
+	return nil
+}
+
+// formatTime 将 Unix 时间戳格式化为 RFC 3339 时间
+func formatTime(ts int64) string {
+	return time.Unix(ts, 0).Format(time.RFC3339)
+}
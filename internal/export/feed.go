@@ -0,0 +1,156 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/internal/feed"
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// FeedOptions 订阅源的站点信息
+type FeedOptions struct {
+	SiteTitle  string // 站点标题
+	SiteURL    string // 站点地址
+	SiteAuthor string // 站点作者，文章没有作者记录时沿用，留空时不输出
+	Language   string // 订阅源语言，留空时使用文章的默认语言
+}
+
+// NewPostFeed 将已发布的文章构建为订阅源，RSS、Atom 与 JSON Feed 共用；
+// 条目附带分类标签、作者与正文中的音视频及文档附件，作者取文章最早一条修订的保存人
+func NewPostFeed(opts FeedOptions, posts []*post.Post) (*feed.Feed, error) {
+	siteURL := strings.TrimRight(opts.SiteURL, "/")
+	language := opts.Language
+	if language == "" {
+		language = post.DefaultLanguage
+	}
+	f := &feed.Feed{
+		Title:       opts.SiteTitle,
+		Link:        siteURL + "/",
+		Description: opts.SiteTitle,
+		Language:    language,
+		Updated:     time.Now(),
+	}
+	if opts.SiteAuthor != "" {
+		f.Authors = []*feed.Author{{Name: opts.SiteAuthor, URL: siteURL + "/"}}
+	}
+
+	tags, err := postTags(posts)
+	if err != nil {
+		return nil, err
+	}
+	authors, err := postAuthors(posts, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pos := range posts {
+		link := fmt.Sprintf("%s/posts/%d/", siteURL, pos.ID)
+		summary, _ := pos.Ext[post.ExtSummary].(string)
+		if summary == "" {
+			summary = summarize(pos.ContentHTML)
+		}
+		item := &feed.Item{
+			ID:          link,
+			Title:       pos.Title,
+			Link:        link,
+			Summary:     summary,
+			ContentHTML: pos.ContentHTML,
+			Image:       absoluteURL(pos.Image, siteURL),
+			Attachments: feed.ExtractAttachments(pos.ContentHTML, link),
+			Published:   time.Unix(pos.GmtCreate, 0),
+			Updated:     time.Unix(pos.GmtModified, 0),
+		}
+		for _, id := range pos.CategoryIDs {
+			if name, ok := tags[id]; ok {
+				item.Tags = append(item.Tags, name)
+			}
+		}
+		if author, ok := authors[pos.ID]; ok {
+			item.Authors = []*feed.Author{author}
+		}
+		f.Items = append(f.Items, item)
+	}
+	return f, nil
+}
+
+// postTags 获取文章分类的名称，返回分类 ID 到名称的映射
+func postTags(posts []*post.Post) (map[int64]string, error) {
+	var ids []int64
+	for _, pos := range posts {
+		ids = append(ids, pos.CategoryIDs...)
+	}
+	categories, err := mapper.GetCategoriesByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("获取文章分类失败: %v", err)
+	}
+	names := make(map[int64]string, len(categories))
+	for _, cat := range categories {
+		names[cat.ID] = cat.Name
+	}
+	return names, nil
+}
+
+// postAuthors 获取文章署名顺序最靠前的作者，返回文章 ID 到作者的映射
+func postAuthors(posts []*post.Post, siteURL string) (map[int64]*feed.Author, error) {
+	postIDs := make([]int64, len(posts))
+	for i, pos := range posts {
+		postIDs[i] = pos.ID
+	}
+	authorIDs, err := mapper.GetLeadAuthorIDs(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	accountIDs := make([]int64, 0, len(authorIDs))
+	for _, id := range authorIDs {
+		accountIDs = append(accountIDs, id)
+	}
+	accounts, err := mapper.GetAccountsByIDs(accountIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*feed.Author, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = &feed.Author{Name: acc.Nickname, Avatar: absoluteURL(acc.Avatar, siteURL)}
+	}
+
+	authors := make(map[int64]*feed.Author, len(authorIDs))
+	for postID, accountID := range authorIDs {
+		if author, ok := byID[accountID]; ok {
+			authors[postID] = author
+		}
+	}
+	return authors, nil
+}
+
+// absoluteURL 将站内的相对地址补全为绝对地址
+func absoluteURL(link, siteURL string) string {
+	if strings.HasPrefix(link, "/") && !strings.HasPrefix(link, "//") {
+		return siteURL + link
+	}
+	return link
+}
+
+// preferLanguage 按语言合并翻译组，规则与文章列表一致：每个翻译组只保留 language 对应的版本，
+// 组内没有该语言的版本时保留原文，没有关联翻译的文章不受影响
+func preferLanguage(posts []*post.Post, language string) []*post.Post {
+	covered := make(map[int64]bool)
+	for _, pos := range posts {
+		if pos.TranslationGroupID != 0 && pos.Language == language {
+			covered[pos.TranslationGroupID] = true
+		}
+	}
+
+	preferred := make([]*post.Post, 0, len(posts))
+	for _, pos := range posts {
+		switch {
+		case pos.TranslationGroupID == 0, pos.Language == language:
+			preferred = append(preferred, pos)
+		case pos.ID == pos.TranslationGroupID && !covered[pos.TranslationGroupID]:
+			preferred = append(preferred, pos)
+		}
+	}
+	return preferred
+}
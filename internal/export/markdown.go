@@ -0,0 +1,141 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// MarkdownOptions Markdown 导出配置
+type MarkdownOptions struct {
+	OutputDir string // 输出目录，通常为 Hugo 站点的 content/posts 目录
+}
+
+// MarkdownReport Markdown 导出结果
+type MarkdownReport struct {
+	Posts   int // 导出文章数
+	Drafts  int // 其中草稿与已归档的文章数
+	Elapsed time.Duration
+}
+
+func (r MarkdownReport) String() string {
+	return fmt.Sprintf("文章 %d 篇（草稿 %d 篇）, 耗时 %s", r.Posts, r.Drafts, r.Elapsed.Round(time.Millisecond))
+}
+
+// hugoFrontMatter Hugo 的 YAML front matter，字段顺序即输出顺序
+type hugoFrontMatter struct {
+	Title          string    `yaml:"title"`
+	Slug           string    `yaml:"slug,omitempty"`
+	Date           time.Time `yaml:"date"`
+	Lastmod        time.Time `yaml:"lastmod"`
+	Draft          bool      `yaml:"draft"`
+	Tags           []string  `yaml:"tags,omitempty"`
+	Summary        string    `yaml:"summary,omitempty"`
+	Image          string    `yaml:"image,omitempty"`
+	Lang           string    `yaml:"lang,omitempty"`
+	TranslationKey string    `yaml:"translationKey,omitempty"`
+}
+
+// ExportMarkdown 将全部未删除的文章导出为带 YAML front matter 的 Markdown 文件，与 Hugo 的内容目录兼容；
+// 文件以别名命名，类目导出为标签，未发布的文章标记为草稿，可通过 markdown 导入源重新导入
+func ExportMarkdown(opts MarkdownOptions) (*MarkdownReport, error) {
+	start := time.Now()
+
+	posts, err := mapper.GetAllPosts()
+	if err != nil {
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+	names, err := categoryNames()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("创建目录失败: %v", err)
+	}
+
+	report := &MarkdownReport{}
+	for _, pos := range posts {
+		content, err := hugoMarkdown(pos, names)
+		if err != nil {
+			return nil, fmt.Errorf("生成文章 %q 失败: %v", pos.Title, err)
+		}
+		target := filepath.Join(opts.OutputDir, markdownFilename(pos))
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return nil, fmt.Errorf("写入文件 %s 失败: %v", target, err)
+		}
+		report.Posts++
+		if pos.Status != post.StatusPublished {
+			report.Drafts++
+		}
+	}
+
+	report.Elapsed = time.Since(start)
+	return report, nil
+}
+
+// hugoMarkdown 生成文章的 Markdown 文件内容
+func hugoMarkdown(pos *post.Post, names map[int64]string) ([]byte, error) {
+	summary, _ := pos.Ext[post.ExtSummary].(string)
+	meta := hugoFrontMatter{
+		Title:   pos.Title,
+		Slug:    pos.Slug,
+		Date:    time.Unix(pos.GmtCreate, 0),
+		Lastmod: time.Unix(pos.GmtModified, 0),
+		Draft:   pos.Status != post.StatusPublished,
+		Summary: strings.TrimSpace(summary),
+		Image:   pos.Image,
+		Lang:    pos.Language,
+	}
+	if pos.TranslationGroupID != 0 {
+		meta.TranslationKey = fmt.Sprintf("post-%d", pos.TranslationGroupID)
+	}
+	for _, id := range pos.CategoryIDs {
+		if name, ok := names[id]; ok {
+			meta.Tags = append(meta.Tags, name)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(meta); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteString("---\n\n")
+	buf.WriteString(strings.TrimSpace(pos.ContentMarkdown))
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// markdownFilename 文章的文件名，没有别名时使用文章 ID
+func markdownFilename(pos *post.Post) string {
+	if pos.Slug != "" {
+		return pos.Slug + ".md"
+	}
+	return fmt.Sprintf("post-%d.md", pos.ID)
+}
+
+// categoryNames 获取未删除类目的名称
+func categoryNames() (map[int64]string, error) {
+	categories, err := mapper.GetAllActivatedCategories()
+	if err != nil {
+		return nil, fmt.Errorf("获取类目失败: %v", err)
+	}
+	names := make(map[int64]string, len(categories))
+	for _, cat := range categories {
+		names[cat.ID] = cat.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,410 @@
+package export
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/internal/feed"
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/sitemap"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+//go:embed templates/*.html
+var defaultTheme embed.FS
+
+const (
+	pageSize    = 10  // 首页每页文章数
+	summaryLen  = 150 // 摘要长度（字符）
+	feedItemMax = 20  // 订阅源条目上限
+)
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// StaticOptions 静态站点导出配置
+type StaticOptions struct {
+	OutputDir  string // 输出目录
+	ThemeDir   string // 主题模板目录，留空使用内置主题
+	SiteTitle  string // 站点标题
+	SiteURL    string // 站点地址
+	SiteAuthor string // 站点作者，用于订阅源
+}
+
+// StaticReport 静态站点导出结果
+type StaticReport struct {
+	Posts   int // 导出文章数
+	Pages   int // 生成页面数
+	Elapsed time.Duration
+}
+
+func (r StaticReport) String() string {
+	return fmt.Sprintf("文章 %d 篇, 页面 %d 个, 耗时 %s", r.Posts, r.Pages, r.Elapsed.Round(time.Millisecond))
+}
+
+// site 模板中可用的站点信息
+type site struct {
+	Title string
+	URL   string
+}
+
+// postView 模板中可用的文章信息
+type postView struct {
+	ID          int64
+	Title       string
+	Image       string
+	Summary     string
+	ContentHTML template.HTML
+	Date        string
+	Path        string
+	Created     time.Time
+	Modified    time.Time
+}
+
+// archiveView 按年月归档的文章分组
+type archiveView struct {
+	Label string
+	Path  string
+	Posts []*postView
+}
+
+// pageData 模板渲染数据
+type pageData struct {
+	Site     site
+	Title    string
+	Posts    []*postView
+	Post     *postView
+	Archives []*archiveView
+	PrevPage string
+	NextPage string
+}
+
+// exporter 静态站点导出器
+type exporter struct {
+	opts   StaticOptions
+	site   site
+	themes map[string]*template.Template
+	pages  int
+}
+
+// ExportStatic 将已发布的文章、归档、订阅源与站点地图导出为静态站点
+func ExportStatic(opts StaticOptions) (*StaticReport, error) {
+	start := time.Now()
+
+	e := &exporter{
+		opts: opts,
+		site: site{Title: opts.SiteTitle, URL: strings.TrimRight(opts.SiteURL, "/")},
+	}
+	if err := e.loadTheme(); err != nil {
+		return nil, err
+	}
+
+	// 静态站点无法校验登录与密码，仅导出公开的文章
+	posts, err := mapper.GetAllPublishedPosts([]string{post.AccessPublic})
+	if err != nil {
+		return nil, fmt.Errorf("获取已发布文章失败: %v", err)
+	}
+
+	views := make([]*postView, len(posts))
+	byID := make(map[int64]*postView, len(posts))
+	for i, pos := range posts {
+		views[i] = newPostView(pos)
+		byID[pos.ID] = views[i]
+	}
+	// 每篇翻译都生成详情页，首页与归档中每个翻译组只列出默认语言的版本
+	listed := make([]*postView, 0, len(posts))
+	for _, pos := range preferLanguage(posts, post.DefaultLanguage) {
+		listed = append(listed, byID[pos.ID])
+	}
+
+	if err := e.writePosts(views); err != nil {
+		return nil, err
+	}
+	if err := e.writeIndex(listed); err != nil {
+		return nil, err
+	}
+	archives, err := e.writeArchives(listed)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.writeFeeds(posts); err != nil {
+		return nil, err
+	}
+	if err := e.writeSitemap(views, archives); err != nil {
+		return nil, err
+	}
+
+	return &StaticReport{Posts: len(views), Pages: e.pages, Elapsed: time.Since(start)}, nil
+}
+
+// loadTheme 加载主题模板，主题目录中缺失的模板回退到内置主题
+func (e *exporter) loadTheme() error {
+	var theme fs.FS
+	if e.opts.ThemeDir != "" {
+		theme = os.DirFS(e.opts.ThemeDir)
+	}
+	builtin, _ := fs.Sub(defaultTheme, "templates")
+
+	read := func(name string) ([]byte, error) {
+		if theme != nil {
+			if content, err := fs.ReadFile(theme, name); err == nil {
+				return content, nil
+			}
+		}
+		return fs.ReadFile(builtin, name)
+	}
+
+	layout, err := read("layout.html")
+	if err != nil {
+		return fmt.Errorf("读取主题模板 layout.html 失败: %v", err)
+	}
+
+	e.themes = make(map[string]*template.Template)
+	for _, name := range []string{"index.html", "post.html", "archive.html"} {
+		content, err := read(name)
+		if err != nil {
+			return fmt.Errorf("读取主题模板 %s 失败: %v", name, err)
+		}
+
+		tpl, err := template.New(name).Parse(string(layout))
+		if err == nil {
+			tpl, err = tpl.Parse(string(content))
+		}
+		if err != nil {
+			return fmt.Errorf("解析主题模板 %s 失败: %v", name, err)
+		}
+		e.themes[name] = tpl
+	}
+	return nil
+}
+
+// writePosts 生成文章详情页
+func (e *exporter) writePosts(views []*postView) error {
+	for _, v := range views {
+		data := pageData{Site: e.site, Title: v.Title, Post: v}
+		if err := e.render("post.html", v.Path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeIndex 生成分页首页
+func (e *exporter) writeIndex(views []*postView) error {
+	totalPages := (len(views) + pageSize - 1) / pageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	for page := 1; page <= totalPages; page++ {
+		from := (page - 1) * pageSize
+		to := from + pageSize
+		if to > len(views) {
+			to = len(views)
+		}
+
+		data := pageData{Site: e.site, Posts: views[from:to]}
+		if page > 1 {
+			data.PrevPage = indexPath(page - 1)
+		}
+		if page < totalPages {
+			data.NextPage = indexPath(page + 1)
+		}
+
+		if err := e.render("index.html", indexPath(page), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeArchives 生成总归档页及按年月的归档页
+func (e *exporter) writeArchives(views []*postView) ([]*archiveView, error) {
+	var archives []*archiveView
+	groups := make(map[string]*archiveView)
+
+	for _, v := range views {
+		key := v.Created.Format("2006/01")
+		group, ok := groups[key]
+		if !ok {
+			group = &archiveView{Label: v.Created.Format("2006 年 01 月"), Path: "/archives/" + key + "/"}
+			groups[key] = group
+			archives = append(archives, group)
+		}
+		group.Posts = append(group.Posts, v)
+	}
+
+	if err := e.render("archive.html", "/archives/", pageData{Site: e.site, Title: "归档", Archives: archives}); err != nil {
+		return nil, err
+	}
+	for _, group := range archives {
+		data := pageData{Site: e.site, Title: group.Label, Archives: []*archiveView{group}}
+		if err := e.render("archive.html", group.Path, data); err != nil {
+			return nil, err
+		}
+	}
+	return archives, nil
+}
+
+// writeFeeds 生成 RSS、Atom 与 JSON Feed 订阅源；根目录的订阅源按默认语言合并翻译组，
+// 其他语言的文章另在以语言标签命名的目录下生成只包含该语言文章的订阅源
+func (e *exporter) writeFeeds(posts []*post.Post) error {
+	if err := e.writeFeedSet("", post.DefaultLanguage, preferLanguage(posts, post.DefaultLanguage)); err != nil {
+		return err
+	}
+
+	var languages []string
+	byLanguage := make(map[string][]*post.Post)
+	for _, pos := range posts {
+		if pos.Language == post.DefaultLanguage {
+			continue
+		}
+		if _, ok := byLanguage[pos.Language]; !ok {
+			languages = append(languages, pos.Language)
+		}
+		byLanguage[pos.Language] = append(byLanguage[pos.Language], pos)
+	}
+	for _, language := range languages {
+		if err := e.writeFeedSet(language+"/", language, byLanguage[language]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFeedSet 在 dir 目录下生成一种语言的三种订阅源，只包含最新的 feedItemMax 篇文章
+func (e *exporter) writeFeedSet(dir, language string, posts []*post.Post) error {
+	if len(posts) > feedItemMax {
+		posts = posts[:feedItemMax]
+	}
+	f, err := NewPostFeed(FeedOptions{SiteTitle: e.opts.SiteTitle, SiteURL: e.site.URL, SiteAuthor: e.opts.SiteAuthor, Language: language}, posts)
+	if err != nil {
+		return err
+	}
+
+	f.FeedLink = e.site.URL + "/" + dir + "feed.xml"
+	rss, err := feed.BuildRSS(f)
+	if err != nil {
+		return err
+	}
+	if err := e.write(dir+"feed.xml", rss); err != nil {
+		return err
+	}
+
+	f.FeedLink = e.site.URL + "/" + dir + "atom.xml"
+	atom, err := feed.BuildAtom(f)
+	if err != nil {
+		return err
+	}
+	if err := e.write(dir+"atom.xml", atom); err != nil {
+		return err
+	}
+
+	f.FeedLink = e.site.URL + "/" + dir + "feed.json"
+	jsonFeed, err := feed.BuildJSON(f)
+	if err != nil {
+		return err
+	}
+	return e.write(dir+"feed.json", jsonFeed)
+}
+
+// writeSitemap 生成站点地图，地址数超过 sitemap.MaxURLs 时生成站点地图索引
+func (e *exporter) writeSitemap(views []*postView, archives []*archiveView) error {
+	urls := []sitemap.URL{{Loc: e.site.URL + "/", LastMod: time.Now(), ChangeFreq: "daily", Priority: 1.0}}
+	for _, v := range views {
+		urls = append(urls, sitemap.URL{Loc: e.site.URL + v.Path, LastMod: v.Modified, ChangeFreq: "weekly", Priority: 0.8})
+	}
+	for _, a := range archives {
+		urls = append(urls, sitemap.URL{Loc: e.site.URL + a.Path, ChangeFreq: "monthly", Priority: 0.3})
+	}
+
+	groups := sitemap.Split(urls, sitemap.MaxURLs)
+	if len(groups) == 1 {
+		content, err := sitemap.Build(urls)
+		if err != nil {
+			return err
+		}
+		return e.write("sitemap.xml", content)
+	}
+
+	// 地址数超过上限时拆分为多个文件，sitemap.xml 为站点地图索引
+	sitemaps := make([]sitemap.Sitemap, len(groups))
+	for i, group := range groups {
+		name := fmt.Sprintf("sitemap-%d.xml", i+1)
+		content, err := sitemap.Build(group)
+		if err != nil {
+			return err
+		}
+		if err := e.write(name, content); err != nil {
+			return err
+		}
+		sitemaps[i] = sitemap.Sitemap{Loc: e.site.URL + "/" + name, LastMod: sitemap.LastMod(group)}
+	}
+	content, err := sitemap.BuildIndex(sitemaps)
+	if err != nil {
+		return err
+	}
+	return e.write("sitemap.xml", content)
+}
+
+// render 渲染模板并写入 path 对应目录下的 index.html
+func (e *exporter) render(name, path string, data pageData) error {
+	var buf bytes.Buffer
+	if err := e.themes[name].ExecuteTemplate(&buf, "layout", data); err != nil {
+		return fmt.Errorf("渲染模板 %s 失败: %v", name, err)
+	}
+	e.pages++
+	return e.write(filepath.Join(path, "index.html"), buf.Bytes())
+}
+
+// write 写入输出目录下的文件
+func (e *exporter) write(name string, content []byte) error {
+	target := filepath.Join(e.opts.OutputDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		return fmt.Errorf("写入文件 %s 失败: %v", target, err)
+	}
+	return nil
+}
+
+// newPostView 将文章模型转换为模板数据
+func newPostView(pos *post.Post) *postView {
+	created := time.Unix(pos.GmtCreate, 0)
+	return &postView{
+		ID:          pos.ID,
+		Title:       pos.Title,
+		Image:       pos.Image,
+		Summary:     summarize(pos.ContentHTML),
+		ContentHTML: template.HTML(pos.ContentHTML),
+		Date:        created.Format("2006-01-02"),
+		Path:        fmt.Sprintf("/posts/%d/", pos.ID),
+		Created:     created,
+		Modified:    time.Unix(pos.GmtModified, 0),
+	}
+}
+
+// summarize 去除 HTML 标签后截取摘要
+func summarize(html string) string {
+	text := []rune(strings.Join(strings.Fields(htmlTagPattern.ReplaceAllString(html, " ")), " "))
+	if len(text) > summaryLen {
+		return string(text[:summaryLen]) + "…"
+	}
+	return string(text)
+}
+
+// indexPath 首页分页地址
+func indexPath(page int) string {
+	if page <= 1 {
+		return "/"
+	}
+	return fmt.Sprintf("/page/%d/", page)
+}
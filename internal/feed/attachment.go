@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// attachmentPattern 匹配正文中链接与媒体元素的地址
+var attachmentPattern = regexp.MustCompile(`(?i)<(a|audio|video|source)\b[^>]*?\s(?:href|src)\s*=\s*["']([^"']+)["']`)
+
+// attachmentTypes 作为附件的文件扩展名及其 MIME 类型，不依赖系统的 MIME 配置
+var attachmentTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".opus": "audio/opus",
+	".wav":  "audio/wav",
+	".flac": "audio/flac",
+	".mp4":  "video/mp4",
+	".m4v":  "video/mp4",
+	".webm": "video/webm",
+	".ogv":  "video/ogg",
+	".mov":  "video/quicktime",
+	".pdf":  "application/pdf",
+	".epub": "application/epub+zip",
+	".zip":  "application/zip",
+}
+
+// ExtractAttachments 从 HTML 正文中提取音频、视频与文档链接作为条目附件，相对地址按 baseURL 解析，同一地址只保留一次
+func ExtractAttachments(contentHTML, baseURL string) []*Attachment {
+	base, _ := url.Parse(baseURL)
+	seen := make(map[string]bool)
+	var attachments []*Attachment
+	for _, match := range attachmentPattern.FindAllStringSubmatch(contentHTML, -1) {
+		ref, err := url.Parse(html.UnescapeString(strings.TrimSpace(match[2])))
+		if err != nil || ref.Scheme != "" && ref.Scheme != "http" && ref.Scheme != "https" {
+			continue
+		}
+		mimeType, ok := attachmentTypes[strings.ToLower(path.Ext(ref.Path))]
+		if !ok {
+			continue
+		}
+		if base != nil {
+			ref = base.ResolveReference(ref)
+		}
+		link := ref.String()
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		attachments = append(attachments, &Attachment{URL: link, MimeType: mimeType, Title: path.Base(ref.Path)})
+	}
+	return attachments
+}
@@ -0,0 +1,223 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// Feed 订阅源
+type Feed struct {
+	Title       string    // 站点标题
+	Link        string    // 站点地址
+	FeedLink    string    // 订阅源地址
+	NextLink    string    // 下一页订阅源地址，仅 JSON Feed 支持分页，没有下一页时为空
+	Description string    // 站点描述
+	Language    string    // 语言
+	Authors     []*Author // 站点作者，条目未设置作者时沿用
+	Updated     time.Time // 最后更新时间
+	Items       []*Item   // 条目列表
+}
+
+// Item 订阅源条目
+type Item struct {
+	ID          string        // 唯一标识
+	Title       string        // 标题
+	Link        string        // 链接
+	Summary     string        // 摘要
+	ContentHTML string        // HTML 正文
+	Image       string        // 封面图地址
+	Tags        []string      // 标签
+	Authors     []*Author     // 作者
+	Attachments []*Attachment // 附件，RSS 仅输出第一个
+	Published   time.Time     // 发布时间
+	Updated     time.Time     // 更新时间
+}
+
+// Author 作者
+type Author struct {
+	Name   string // 名称
+	URL    string // 主页地址
+	Avatar string // 头像地址，仅 JSON Feed 输出
+}
+
+// Attachment 条目附件，如音频、视频与文档
+type Attachment struct {
+	URL      string // 地址
+	MimeType string // MIME 类型
+	Title    string // 标题
+	Size     int64  // 字节数，未知时为 0
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description"`
+	Author      string        `xml:"author,omitempty"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+	PubDate     string        `xml:"pubDate"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Title  string `xml:"title,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    atomContent    `xml:"content"`
+	Authors    []atomAuthor   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+// BuildRSS 构建 RSS 2.0 订阅源
+func BuildRSS(f *Feed) ([]byte, error) {
+	channel := rssChannel{
+		Title:         f.Title,
+		Link:          f.Link,
+		AtomLink:      atomLink{Href: f.FeedLink, Rel: "self", Type: "application/rss+xml"},
+		Description:   f.Description,
+		Language:      f.Language,
+		LastBuildDate: f.Updated.Format(time.RFC1123Z),
+	}
+
+	for _, item := range f.Items {
+		description := item.Summary
+		if item.ContentHTML != "" {
+			description = item.ContentHTML
+		}
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: item.ID == item.Link, Value: item.ID},
+			Description: description,
+			Categories:  item.Tags,
+			PubDate:     item.Published.Format(time.RFC1123Z),
+		}
+		if authors := itemAuthors(f, item); len(authors) > 0 {
+			entry.Author = authors[0].Name
+		}
+		if len(item.Attachments) > 0 {
+			a := item.Attachments[0]
+			entry.Enclosure = &rssEnclosure{URL: a.URL, Length: a.Size, Type: a.MimeType}
+		}
+		channel.Items = append(channel.Items, entry)
+	}
+
+	return marshal(rss{Version: "2.0", Atom: "http://www.w3.org/2005/Atom", Channel: channel})
+}
+
+// BuildAtom 构建 Atom 1.0 订阅源
+func BuildAtom(f *Feed) ([]byte, error) {
+	atom := atomFeed{
+		Title:   f.Title,
+		ID:      f.Link,
+		Updated: f.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link},
+			{Href: f.FeedLink, Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Links:     []atomLink{{Href: item.Link}},
+			Published: item.Published.Format(time.RFC3339),
+			Updated:   item.Updated.Format(time.RFC3339),
+			Summary:   item.Summary,
+			Content:   atomContent{Type: "html", Value: item.ContentHTML},
+		}
+		for _, a := range item.Attachments {
+			entry.Links = append(entry.Links, atomLink{Href: a.URL, Rel: "enclosure", Type: a.MimeType, Title: a.Title, Length: a.Size})
+		}
+		for _, author := range itemAuthors(f, item) {
+			entry.Authors = append(entry.Authors, atomAuthor{Name: author.Name, URI: author.URL})
+		}
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return marshal(atom)
+}
+
+// itemAuthors 条目的作者，条目未设置时沿用站点作者
+func itemAuthors(f *Feed, item *Item) []*Author {
+	if len(item.Authors) > 0 {
+		return item.Authors
+	}
+	return f.Authors
+}
+
+// marshal 序列化为带 XML 声明的字节
+func marshal(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("订阅源序列化失败: %v", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
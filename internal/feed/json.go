@@ -0,0 +1,113 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JSONFeedVersion JSON Feed 规范版本
+const JSONFeedVersion = "https://jsonfeed.org/version/1.1"
+
+// JSONFeedContentType JSON Feed 的 MIME 类型
+const JSONFeedContentType = "application/feed+json"
+
+type jsonFeed struct {
+	Version     string       `json:"version"`
+	Title       string       `json:"title"`
+	HomePageURL string       `json:"home_page_url,omitempty"`
+	FeedURL     string       `json:"feed_url,omitempty"`
+	Description string       `json:"description,omitempty"`
+	NextURL     string       `json:"next_url,omitempty"`
+	Language    string       `json:"language,omitempty"`
+	Authors     []jsonAuthor `json:"authors,omitempty"`
+	Items       []jsonItem   `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	Title         string           `json:"title,omitempty"`
+	ContentHTML   string           `json:"content_html"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	DateModified  string           `json:"date_modified,omitempty"`
+	Authors       []jsonAuthor     `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Attachments   []jsonAttachment `json:"attachments,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
+
+type jsonAttachment struct {
+	URL         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	Title       string `json:"title,omitempty"`
+	SizeInBytes int64  `json:"size_in_bytes,omitempty"`
+}
+
+// BuildJSON 构建 JSON Feed 1.1 订阅源，条目未设置作者时由阅读器沿用站点作者，NextLink 不为空时输出 next_url 用于分页
+func BuildJSON(f *Feed) ([]byte, error) {
+	feed := jsonFeed{
+		Version:     JSONFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedLink,
+		Description: f.Description,
+		NextURL:     f.NextLink,
+		Language:    f.Language,
+		Authors:     jsonAuthors(f.Authors),
+		Items:       make([]jsonItem, 0, len(f.Items)),
+	}
+
+	for _, item := range f.Items {
+		entry := jsonItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.ContentHTML,
+			Summary:       item.Summary,
+			Image:         item.Image,
+			DatePublished: jsonTime(item.Published),
+			DateModified:  jsonTime(item.Updated),
+			Authors:       jsonAuthors(item.Authors),
+			Tags:          item.Tags,
+		}
+		for _, a := range item.Attachments {
+			entry.Attachments = append(entry.Attachments, jsonAttachment{URL: a.URL, MimeType: a.MimeType, Title: a.Title, SizeInBytes: a.Size})
+		}
+		feed.Items = append(feed.Items, entry)
+	}
+
+	// 正文本身即为 HTML，不转义其中的 <、> 与 &
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return nil, fmt.Errorf("订阅源序列化失败: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func jsonAuthors(authors []*Author) []jsonAuthor {
+	result := make([]jsonAuthor, 0, len(authors))
+	for _, a := range authors {
+		result = append(result, jsonAuthor{Name: a.Name, URL: a.URL, Avatar: a.Avatar})
+	}
+	return result
+}
+
+// jsonTime 格式化为 RFC 3339 时间，零值时为空
+func jsonTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,109 @@
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/internal/cache"
+)
+
+const (
+	CacheKeyPrefix = "GEOIP:"        // 查询结果缓存，键为前缀加 IP
+	cacheTTL       = 24 * time.Hour  // 查询结果的缓存时间
+	defaultTimeout = 3 * time.Second // 未配置时请求查询接口的超时时间
+	maxResponse    = 64 << 10        // 查询接口响应最多读取的字节数
+	LocalNetwork   = "局域网"           // 内网与本机地址的位置
+)
+
+// responseFields 查询接口响应中依次读取的字段，兼容 ip-api.com、ipinfo.io 等常见格式
+var responseFields = [][]string{
+	{"country", "country_name"},
+	{"regionName", "region", "region_name", "province"},
+	{"city"},
+}
+
+// Options 查询配置
+type Options struct {
+	API     string        // 查询接口地址，{ip} 替换为待查询的 IP，响应为 JSON，留空时不查询
+	Timeout time.Duration // 请求查询接口的超时时间
+}
+
+// Lookup 查询 IP 的地理位置，如「中国 广东 深圳」，内网地址返回「局域网」，未配置查询接口时返回空字符串
+func Lookup(ctx context.Context, ip string, opts Options) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", nil
+	}
+	if parsed.IsPrivate() || parsed.IsLoopback() || parsed.IsLinkLocalUnicast() || parsed.IsUnspecified() {
+		return LocalNetwork, nil
+	}
+	if opts.API == "" {
+		return "", nil
+	}
+
+	key := CacheKeyPrefix + ip
+	if location, err := cache.Current().Get(ctx, key); err == nil {
+		return location, nil
+	}
+
+	location, err := query(ctx, strings.ReplaceAll(opts.API, "{ip}", url.PathEscape(ip)), opts.Timeout)
+	if err != nil {
+		return "", err
+	}
+	_ = cache.Current().Set(ctx, key, location, cacheTTL)
+	return location, nil
+}
+
+// query 请求查询接口并拼接国家、地区与城市
+func query(ctx context.Context, api string, timeout time.Duration) (string, error) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api, nil)
+	if err != nil {
+		return "", fmt.Errorf("IP 地理位置查询地址无效: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("请求 IP 地理位置查询接口失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("IP 地理位置查询接口返回状态码 %d", resp.StatusCode)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponse)).Decode(&body); err != nil {
+		return "", fmt.Errorf("解析 IP 地理位置查询结果失败: %v", err)
+	}
+	if status, _ := body["status"].(string); status == "fail" {
+		return "", nil
+	}
+
+	var parts []string
+	for _, names := range responseFields {
+		for _, name := range names {
+			value, _ := body[name].(string)
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			// 直辖市等地区与城市同名时只保留一个
+			if len(parts) == 0 || parts[len(parts)-1] != value {
+				parts = append(parts, value)
+			}
+			break
+		}
+	}
+	return strings.Join(parts, " "), nil
+}
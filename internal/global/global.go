@@ -6,6 +6,8 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/metrics"
 )
 
 var (
@@ -18,3 +20,5 @@ var (
 	BizLog  *logrus.Entry  // 全局业务级日志对象
 	LogFile *os.File       // 全局日志文件对象
 )
+
+var Metrics = metrics.NewRegistry() // 全局指标注册表，由 /metrics 接口按 Prometheus 文本格式输出
@@ -0,0 +1,207 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var builtinLocales embed.FS
+
+// 内置语言
+const (
+	LocaleZhCN = "zh-CN" // 简体中文，默认语言
+	LocaleEnUS = "en-US" // 美式英语
+)
+
+// DefaultLocale 未匹配到支持的语言时使用的语言
+const DefaultLocale = LocaleZhCN
+
+var (
+	mu       sync.RWMutex
+	catalogs = make(map[string]map[string]string)
+)
+
+func init() {
+	entries, _ := builtinLocales.ReadDir("locales")
+	for _, entry := range entries {
+		content, err := builtinLocales.ReadFile("locales/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(content, &messages); err != nil {
+			panic(fmt.Sprintf("解析内置语言包 %s 失败: %v", entry.Name(), err))
+		}
+		Register(strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())), messages)
+	}
+}
+
+// Register 向语言的消息目录追加消息，同名消息覆盖原有文案
+func Register(locale string, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = make(map[string]string, len(messages))
+		catalogs[locale] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+// Locales 已注册的语言
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// T 获取指定语言的消息并按 fmt 占位符填充参数，缺失时回退到默认语言，仍缺失时返回消息键
+func T(locale, key string, args ...interface{}) string {
+	mu.RLock()
+	message, ok := catalogs[locale][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	mu.RUnlock()
+
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// FromRequest 按 lang 查询参数、Accept-Language 请求头的顺序选择请求的语言
+func FromRequest(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		if locale, ok := match(lang); ok {
+			return locale
+		}
+	}
+	return Match(r.Header.Get("Accept-Language"))
+}
+
+// Match 按 Accept-Language 的权重选择最匹配的已注册语言，均不匹配时返回默认语言
+func Match(acceptLanguage string) string {
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if locale, ok := match(tag); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Negotiate 按 lang 查询参数、Accept-Language 请求头的顺序在 available 中选择请求的语言，
+// 用于文章翻译等不依赖消息目录的内容，均不匹配时返回 false
+func Negotiate(r *http.Request, available []string) (string, bool) {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		if tag, ok := MatchAmong(lang, available); ok {
+			return tag, true
+		}
+	}
+	for _, tag := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		if matched, ok := MatchAmong(tag, available); ok {
+			return matched, true
+		}
+	}
+	return "", false
+}
+
+// Normalize 将语言标签规范为 BCP 47 的书写形式，如 en_us 规范为 en-US、zh-hans-cn 规范为 zh-Hans-CN
+func Normalize(tag string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 2:
+			parts[i] = strings.ToUpper(part)
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		default:
+			parts[i] = strings.ToLower(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// parseAcceptLanguage 解析 Accept-Language 请求头，返回按权重降序排列的语言标签，忽略通配符与权重为 0 的语言
+func parseAcceptLanguage(acceptLanguage string) []string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag, q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	tags := make([]string, len(candidates))
+	for i, c := range candidates {
+		tags[i] = c.tag
+	}
+	return tags
+}
+
+// match 将语言标签匹配到已注册的语言，先按完整标签匹配，再按主语言匹配
+func match(tag string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	return MatchAmong(tag, locales)
+}
+
+// MatchAmong 将语言标签匹配到 available 中的语言，先按完整标签匹配，再按主语言匹配，主语言匹配多个时取字典序最小的
+func MatchAmong(tag string, available []string) (string, bool) {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	base, _, _ := strings.Cut(tag, "-")
+
+	var baseMatch string
+	for _, locale := range available {
+		if strings.EqualFold(locale, tag) {
+			return locale, true
+		}
+		localeBase, _, _ := strings.Cut(locale, "-")
+		if strings.EqualFold(localeBase, base) && (baseMatch == "" || locale < baseMatch) {
+			baseMatch = locale
+		}
+	}
+	return baseMatch, baseMatch != ""
+}
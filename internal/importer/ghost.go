@@ -0,0 +1,204 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const ghostURLPlaceholder = "__GHOST_URL__" // Ghost 导出文件中站点地址的占位符
+
+func init() {
+	Register(&ghostImporter{})
+}
+
+// ghostImporter Ghost JSON 导出文件导入源
+type ghostImporter struct{}
+
+// ghostExport Ghost 导出文件结构，兼容带 db 数组与不带 db 数组两种格式
+type ghostExport struct {
+	DB   []struct{ Data ghostData } `json:"db"`
+	Data *ghostData                 `json:"data"`
+}
+
+type ghostData struct {
+	Posts     []ghostPost    `json:"posts"`
+	Tags      []ghostTag     `json:"tags"`
+	PostsTags []ghostPostTag `json:"posts_tags"`
+	Members   []ghostMember  `json:"members"`
+	Comments  []ghostComment `json:"comments"`
+}
+
+type ghostPost struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	Slug         string `json:"slug"`
+	Mobiledoc    string `json:"mobiledoc"`
+	HTML         string `json:"html"`
+	FeatureImage string `json:"feature_image"`
+	Type         string `json:"type"`
+	Status       string `json:"status"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+	PublishedAt  string `json:"published_at"`
+}
+
+type ghostTag struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ghostPostTag struct {
+	PostID string `json:"post_id"`
+	TagID  string `json:"tag_id"`
+}
+
+type ghostMember struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type ghostComment struct {
+	ID        string `json:"id"`
+	PostID    string `json:"post_id"`
+	MemberID  string `json:"member_id"`
+	ParentID  string `json:"parent_id"`
+	Status    string `json:"status"`
+	HTML      string `json:"html"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ghostMobiledoc Mobiledoc 正文结构，仅用于提取 Markdown 卡片
+type ghostMobiledoc struct {
+	Cards [][]json.RawMessage `json:"cards"`
+}
+
+func (g *ghostImporter) Name() string {
+	return "ghost"
+}
+
+// Parse 解析 Ghost 导出的 JSON 文件，标签以类目形式导入，页面类型的内容会被忽略
+func (g *ghostImporter) Parse(src Source) ([]*Document, error) {
+	if src.Reader == nil {
+		return nil, fmt.Errorf("缺少 Ghost 导出文件")
+	}
+	content, err := io.ReadAll(src.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("读取 Ghost 导出文件失败: %v", err)
+	}
+
+	var export ghostExport
+	if err := json.Unmarshal(content, &export); err != nil {
+		return nil, fmt.Errorf("解析 Ghost 导出文件失败: %v", err)
+	}
+	data := export.Data
+	if data == nil {
+		if len(export.DB) == 0 {
+			return nil, fmt.Errorf("Ghost 导出文件中缺少 data 字段")
+		}
+		data = &export.DB[0].Data
+	}
+
+	tags := make(map[string]string, len(data.Tags))
+	for _, tag := range data.Tags {
+		tags[tag.ID] = tag.Name
+	}
+	postTags := make(map[string][]string)
+	for _, pt := range data.PostsTags {
+		if name, ok := tags[pt.TagID]; ok {
+			postTags[pt.PostID] = append(postTags[pt.PostID], name)
+		}
+	}
+
+	members := make(map[string]ghostMember, len(data.Members))
+	for _, m := range data.Members {
+		members[m.ID] = m
+	}
+	postComments := make(map[string][]*Comment)
+	for _, cmt := range data.Comments {
+		if cmt.Status != "" && cmt.Status != "published" {
+			continue
+		}
+		member := members[cmt.MemberID]
+		postComments[cmt.PostID] = append(postComments[cmt.PostID], &Comment{
+			SourceID:    cmt.ID,
+			ParentID:    cmt.ParentID,
+			AuthorName:  member.Name,
+			AuthorEmail: member.Email,
+			Content:     cmt.HTML,
+			CreatedAt:   parseGhostTime(cmt.CreatedAt),
+		})
+	}
+
+	var docs []*Document
+	for _, p := range data.Posts {
+		if p.Type != "" && p.Type != "post" {
+			continue
+		}
+
+		created := parseGhostTime(p.PublishedAt)
+		if created.IsZero() {
+			created = parseGhostTime(p.CreatedAt)
+		}
+
+		docs = append(docs, &Document{
+			SourceID:  p.ID,
+			Title:     p.Title,
+			Slug:      p.Slug,
+			Markdown:  g.replaceURL(ghostMarkdown(p.Mobiledoc), src.BaseURL),
+			HTML:      g.replaceURL(p.HTML, src.BaseURL),
+			Image:     g.replaceURL(p.FeatureImage, src.BaseURL),
+			Published: p.Status == "published",
+			CreatedAt: created,
+			UpdatedAt: parseGhostTime(p.UpdatedAt),
+			Tags:      postTags[p.ID],
+			Comments:  postComments[p.ID],
+		})
+	}
+	return docs, nil
+}
+
+// replaceURL 将站点地址占位符替换为原站点地址
+func (g *ghostImporter) replaceURL(content, baseURL string) string {
+	return strings.ReplaceAll(content, ghostURLPlaceholder, strings.TrimRight(baseURL, "/"))
+}
+
+// ghostMarkdown 正文仅由一张 Markdown 卡片组成时返回其内容，否则返回空字符串
+func ghostMarkdown(mobiledoc string) string {
+	if mobiledoc == "" {
+		return ""
+	}
+
+	var doc ghostMobiledoc
+	if err := json.Unmarshal([]byte(mobiledoc), &doc); err != nil || len(doc.Cards) != 1 || len(doc.Cards[0]) < 2 {
+		return ""
+	}
+
+	var name string
+	var payload struct {
+		Markdown string `json:"markdown"`
+	}
+	if json.Unmarshal(doc.Cards[0][0], &name) != nil || name != "markdown" {
+		return ""
+	}
+	if json.Unmarshal(doc.Cards[0][1], &payload) != nil {
+		return ""
+	}
+	return payload.Markdown
+}
+
+// parseGhostTime 解析 Ghost 导出文件中的时间
+func parseGhostTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
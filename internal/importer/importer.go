@@ -0,0 +1,137 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Document 待导入的文章，由各导入源解析得到
+type Document struct {
+	SourceID   string     // 源系统中的文章 ID
+	Title      string     // 标题
+	Slug       string     // 源系统中的别名
+	Markdown   string     // Markdown 正文，为空时使用 HTML 正文
+	HTML       string     // HTML 正文
+	Image      string     // 封面图片
+	Published  bool       // 是否已发布
+	CreatedAt  time.Time  // 创建时间
+	UpdatedAt  time.Time  // 更新时间
+	Categories []string   // 分类名称
+	Tags       []string   // 标签名称，当前以类目形式导入
+	Comments   []*Comment // 评论
+	Media      []string   // 封面与正文引用的媒体文件地址，记录在导入来源信息中，便于之后迁移
+}
+
+// Comment 待导入的评论
+type Comment struct {
+	SourceID    string    // 源系统中的评论 ID
+	ParentID    string    // 源系统中的父评论 ID
+	AuthorName  string    // 评论者昵称
+	AuthorEmail string    // 评论者邮箱，与已有用户邮箱一致时归属到该用户
+	Content     string    // 评论内容
+	CreatedAt   time.Time // 评论时间
+}
+
+// Source 导入源输入
+type Source struct {
+	Reader  io.Reader // 导出文件内容，文件类导入源使用
+	Dir     string    // 导出文件目录，目录类导入源使用
+	Driver  string    // 数据库驱动，数据库类导入源使用，可选值: mysql, sqlite
+	DSN     string    // 数据库连接串，数据库类导入源使用
+	Prefix  string    // 数据表前缀，数据库类导入源使用
+	BaseURL string    // 原站点地址，用于补全图片等相对地址
+}
+
+// Importer 导入源
+type Importer interface {
+	// Name 导入源名称，作为 API 与命令行的格式参数
+	Name() string
+	// Parse 解析导入源，返回待导入的文章
+	Parse(src Source) ([]*Document, error)
+}
+
+var importers = make(map[string]Importer)
+
+// Register 注册导入源
+func Register(imp Importer) {
+	importers[imp.Name()] = imp
+}
+
+// Get 根据名称获取导入源
+func Get(name string) (Importer, error) {
+	imp, ok := importers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("不支持的导入格式 %q，可选值: %s", name, strings.Join(Names(), ", "))
+	}
+	return imp, nil
+}
+
+// Names 已注册的导入源名称
+func Names() []string {
+	names := make([]string, 0, len(importers))
+	for name := range importers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Options 导入配置
+type Options struct {
+	Format    string                // 导入格式
+	Source    Source                // 导入源输入
+	AccountID int64                 // 执行导入的用户 ID，无法匹配评论者时评论归属到该用户
+	DryRun    bool                  // 试运行，按正常流程写入后回滚，只生成导入报告
+	Progress  func(done, total int) // 每处理完一篇文章时调用，total 为待导入的文章总数
+}
+
+// Report 导入结果
+type Report struct {
+	Format     string
+	Posts      int
+	Categories int
+	Comments   int
+	Media      int
+	Skipped    int
+	Warnings   []string
+	DryRun     bool
+	Elapsed    time.Duration
+}
+
+func (r Report) String() string {
+	s := fmt.Sprintf("格式 %s, 文章 %d 篇, 类目 %d 个, 评论 %d 条, 媒体引用 %d 个, 跳过 %d 项, 耗时 %s",
+		r.Format, r.Posts, r.Categories, r.Comments, r.Media, r.Skipped, r.Elapsed.Round(time.Millisecond))
+	if r.DryRun {
+		s += "（试运行，未写入）"
+	}
+	return s
+}
+
+// errDryRun 试运行结束时回滚事务
+var errDryRun = errors.New("试运行")
+
+// Run 按配置解析导入源并写入数据库，试运行时写入后回滚
+func Run(opts Options) (*Report, error) {
+	imp, err := Get(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	docs, err := imp.Parse(opts.Source)
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s 导入源失败: %v", imp.Name(), err)
+	}
+
+	report := &Report{Format: imp.Name(), DryRun: opts.DryRun}
+	if err := newWriter(opts, report).write(docs); err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	report.Elapsed = time.Since(start)
+	return report, nil
+}
@@ -0,0 +1,325 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	jekyllFilenamePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+	markdownTitlePattern  = regexp.MustCompile(`(?m)^#\s+(.+?)\s*#*\s*$`)
+)
+
+// markdownTimeLayouts front matter 中常见的时间格式，不带时区的时间按 UTC 解析
+var markdownTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func init() {
+	Register(&markdownImporter{})
+}
+
+// markdownImporter Hugo、Jekyll 等静态站点生成器的 Markdown 文件导入源，读取目录或 ZIP 压缩包中带 front matter 的 Markdown 文件
+type markdownImporter struct{}
+
+// markdownFile 待解析的 Markdown 文件
+type markdownFile struct {
+	path    string // 相对导入根目录的路径，以 / 分隔
+	content []byte
+}
+
+func (m *markdownImporter) Name() string {
+	return "markdown"
+}
+
+// Parse 解析 Markdown 文件，支持 YAML（---）与 TOML（+++）格式的 front matter；
+// 没有 front matter 的文件与 Hugo 的列表页 _index.md 不导入，Jekyll 的 _drafts 目录中的文件导入为草稿
+func (m *markdownImporter) Parse(src Source) ([]*Document, error) {
+	var files []markdownFile
+	var err error
+	switch {
+	case src.Dir != "":
+		files, err = m.readDir(src.Dir)
+	case src.Reader != nil:
+		files, err = m.readZip(src.Reader)
+	default:
+		return nil, fmt.Errorf("缺少 Markdown 文件目录或 ZIP 压缩包")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*Document
+	for _, file := range files {
+		doc, err := m.document(file)
+		if err != nil {
+			return nil, fmt.Errorf("解析 %s 失败: %v", file.path, err)
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// readDir 读取目录中的 Markdown 文件，跳过以 . 开头的文件与目录
+func (m *markdownImporter) readDir(dir string) ([]markdownFile, error) {
+	var files []markdownFile
+	err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !isMarkdownFile(entry.Name()) {
+			return nil
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		files = append(files, markdownFile{path: filepath.ToSlash(rel), content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("读取 Markdown 文件目录失败: %v", err)
+	}
+	return files, nil
+}
+
+// readZip 读取 ZIP 压缩包中的 Markdown 文件，按路径排序，跳过以 . 开头的文件与目录
+func (m *markdownImporter) readZip(r io.Reader) ([]markdownFile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 ZIP 压缩包失败: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("解析 ZIP 压缩包失败: %v", err)
+	}
+
+	var files []markdownFile
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(path.Clean("/"+f.Name), "/")
+		if f.FileInfo().IsDir() || !isMarkdownFile(name) || hasHiddenSegment(name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("读取 %s 失败: %v", name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("读取 %s 失败: %v", name, err)
+		}
+		files = append(files, markdownFile{path: name, content: content})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+// document 将 Markdown 文件转换为待导入的文章，不需要导入的文件返回 nil
+func (m *markdownImporter) document(file markdownFile) (*Document, error) {
+	base := path.Base(file.path)
+	if strings.HasPrefix(base, "_index.") {
+		return nil, nil
+	}
+	meta, body, ok, err := splitFrontMatter(file.content)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	// 文件名作为默认别名；Hugo 的页面包以目录名为别名，Jekyll 的文件名以日期开头
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	if stem == "index" && path.Dir(file.path) != "." {
+		stem = path.Base(path.Dir(file.path))
+	}
+	var fileDate time.Time
+	if match := jekyllFilenamePattern.FindStringSubmatch(stem); match != nil {
+		fileDate, _ = time.Parse("2006-01-02", match[1])
+		stem = match[2]
+	}
+
+	doc := &Document{
+		SourceID:   file.path,
+		Title:      metaString(meta, "title"),
+		Slug:       metaString(meta, "slug"),
+		Markdown:   strings.TrimSpace(body),
+		Image:      metaString(meta, "image", "cover", "featured_image", "feature_image", "thumbnail"),
+		Published:  true,
+		CreatedAt:  metaTime(meta, "date", "publishDate"),
+		UpdatedAt:  metaTime(meta, "lastmod", "last_modified_at", "updated", "modified"),
+		Categories: metaList(meta, "categories", "category"),
+		Tags:       metaList(meta, "tags", "tag"),
+	}
+	if doc.Slug == "" {
+		doc.Slug = stem
+	}
+	if doc.CreatedAt.IsZero() {
+		doc.CreatedAt = fileDate
+	}
+	if doc.Title == "" {
+		// 没有标题时使用正文中的第一个一级标题
+		if match := markdownTitlePattern.FindStringSubmatch(doc.Markdown); match != nil {
+			doc.Title = match[1]
+		}
+	}
+	if draft, ok := meta["draft"].(bool); ok && draft {
+		doc.Published = false
+	}
+	if published, ok := meta["published"].(bool); ok && !published {
+		doc.Published = false
+	}
+	if strings.HasPrefix(file.path, "_drafts/") || strings.Contains(file.path, "/_drafts/") {
+		doc.Published = false
+	}
+	if doc.Image != "" {
+		doc.Media = []string{doc.Image}
+	}
+	return doc, nil
+}
+
+// splitFrontMatter 拆分 front matter 与正文，文件不以 front matter 开头时返回 false
+func splitFrontMatter(content []byte) (map[string]interface{}, string, bool, error) {
+	text := strings.ReplaceAll(strings.TrimPrefix(string(content), "\ufeff"), "\r\n", "\n")
+	var delimiter string
+	switch {
+	case strings.HasPrefix(text, "---\n"):
+		delimiter = "---"
+	case strings.HasPrefix(text, "+++\n"):
+		delimiter = "+++"
+	default:
+		return nil, "", false, nil
+	}
+
+	rest := text[len(delimiter)+1:]
+	var raw, body string
+	if strings.HasPrefix(rest, delimiter+"\n") || rest == delimiter {
+		body = strings.TrimPrefix(rest, delimiter)
+	} else {
+		end := strings.Index(rest, "\n"+delimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+delimiter) {
+				return nil, "", false, fmt.Errorf("front matter 缺少结束分隔符 %s", delimiter)
+			}
+			end = len(rest) - len(delimiter) - 1
+		}
+		raw, body = rest[:end], rest[min(end+len(delimiter)+2, len(rest)):]
+	}
+
+	meta := make(map[string]interface{})
+	var err error
+	if delimiter == "---" {
+		err = yaml.Unmarshal([]byte(raw), &meta)
+	} else {
+		err = toml.Unmarshal([]byte(raw), &meta)
+	}
+	if err != nil {
+		return nil, "", false, fmt.Errorf("解析 front matter 失败: %v", err)
+	}
+	return meta, body, true, nil
+}
+
+// metaString 返回第一个非空的字符串字段
+func metaString(meta map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := meta[key].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// metaTime 返回第一个可解析的时间字段，YAML 与 TOML 中未加引号的时间已解析为 time.Time
+func metaTime(meta map[string]interface{}, keys ...string) time.Time {
+	for _, key := range keys {
+		switch value := meta[key].(type) {
+		case time.Time:
+			return value
+		case toml.LocalDateTime:
+			return value.AsTime(time.UTC)
+		case toml.LocalDate:
+			return value.AsTime(time.UTC)
+		case string:
+			for _, layout := range markdownTimeLayouts {
+				if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+					return t
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// metaList 合并列表字段，Jekyll 允许以空格分隔的字符串，包含逗号时按逗号分隔
+func metaList(meta map[string]interface{}, keys ...string) []string {
+	var list []string
+	for _, key := range keys {
+		switch value := meta[key].(type) {
+		case []interface{}:
+			for _, item := range value {
+				if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+					list = append(list, strings.TrimSpace(s))
+				}
+			}
+		case string:
+			if strings.Contains(value, ",") {
+				for _, item := range strings.Split(value, ",") {
+					if item = strings.TrimSpace(item); item != "" {
+						list = append(list, item)
+					}
+				}
+			} else {
+				list = append(list, strings.Fields(value)...)
+			}
+		}
+	}
+	return list
+}
+
+// isMarkdownFile 判断是否为 Markdown 文件
+func isMarkdownFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// hasHiddenSegment 路径中是否有以 . 开头的文件或目录，或 macOS 压缩时生成的 __MACOSX 目录
+func hasHiddenSegment(name string) bool {
+	for _, segment := range strings.Split(name, "/") {
+		if strings.HasPrefix(segment, ".") || segment == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
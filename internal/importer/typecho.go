@@ -0,0 +1,184 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	typechoDefaultPrefix  = "typecho_"        // Typecho 默认数据表前缀
+	typechoMarkdownMarker = "<!--markdown-->" // Typecho Markdown 正文标记
+)
+
+// typechoUploadPattern 匹配 Typecho 附件的站内相对地址
+var typechoUploadPattern = regexp.MustCompile(`([("'])(/usr/uploads/)`)
+
+func init() {
+	Register(&typechoImporter{})
+}
+
+// typechoImporter Typecho 数据库导入源
+type typechoImporter struct{}
+
+type typechoContent struct {
+	Cid      int64
+	Title    string
+	Slug     string
+	Created  int64
+	Modified int64
+	Text     string
+	Type     string
+	Status   string
+}
+
+type typechoMeta struct {
+	Cid  int64
+	Name string
+	Type string
+}
+
+type typechoComment struct {
+	Coid    int64
+	Cid     int64
+	Created int64
+	Author  string
+	Mail    string
+	Text    string
+	Parent  int64
+}
+
+func (t *typechoImporter) Name() string {
+	return "typecho"
+}
+
+// Parse 读取 Typecho 数据库中的文章、分类、标签与评论，分类与标签均以类目形式导入
+func (t *typechoImporter) Parse(src Source) ([]*Document, error) {
+	db, err := t.open(src)
+	if err != nil {
+		return nil, err
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	prefix := src.Prefix
+	if prefix == "" {
+		prefix = typechoDefaultPrefix
+	}
+
+	var contents []typechoContent
+	if err := db.Raw(fmt.Sprintf(
+		"SELECT cid, title, slug, created, modified, text, type, status FROM %scontents WHERE type IN ('post', 'post_draft') ORDER BY cid",
+		prefix)).Scan(&contents).Error; err != nil {
+		return nil, fmt.Errorf("读取 Typecho 文章失败: %v", err)
+	}
+
+	var metas []typechoMeta
+	if err := db.Raw(fmt.Sprintf(
+		"SELECT r.cid, m.name, m.type FROM %srelationships r JOIN %smetas m ON m.mid = r.mid WHERE m.type IN ('category', 'tag')",
+		prefix, prefix)).Scan(&metas).Error; err != nil {
+		return nil, fmt.Errorf("读取 Typecho 分类与标签失败: %v", err)
+	}
+
+	var comments []typechoComment
+	if err := db.Raw(fmt.Sprintf(
+		"SELECT coid, cid, created, author, mail, text, parent FROM %scomments WHERE type = 'comment' AND status = 'approved' ORDER BY coid",
+		prefix)).Scan(&comments).Error; err != nil {
+		return nil, fmt.Errorf("读取 Typecho 评论失败: %v", err)
+	}
+
+	docs := make(map[int64]*Document, len(contents))
+	ordered := make([]*Document, 0, len(contents))
+	for _, content := range contents {
+		doc := &Document{
+			SourceID:  strconv.FormatInt(content.Cid, 10),
+			Title:     content.Title,
+			Slug:      content.Slug,
+			Published: content.Type == "post" && content.Status == "publish",
+			CreatedAt: time.Unix(content.Created, 0),
+			UpdatedAt: time.Unix(content.Modified, 0),
+		}
+
+		text := t.resolveUploads(content.Text, src.BaseURL)
+		if strings.HasPrefix(text, typechoMarkdownMarker) {
+			doc.Markdown = strings.TrimPrefix(text, typechoMarkdownMarker)
+		} else {
+			doc.HTML = text
+		}
+
+		docs[content.Cid] = doc
+		ordered = append(ordered, doc)
+	}
+
+	for _, meta := range metas {
+		doc, ok := docs[meta.Cid]
+		if !ok {
+			continue
+		}
+		if meta.Type == "category" {
+			doc.Categories = append(doc.Categories, meta.Name)
+		} else {
+			doc.Tags = append(doc.Tags, meta.Name)
+		}
+	}
+
+	for _, cmt := range comments {
+		doc, ok := docs[cmt.Cid]
+		if !ok {
+			continue
+		}
+		var parentID string
+		if cmt.Parent > 0 {
+			parentID = strconv.FormatInt(cmt.Parent, 10)
+		}
+		doc.Comments = append(doc.Comments, &Comment{
+			SourceID:    strconv.FormatInt(cmt.Coid, 10),
+			ParentID:    parentID,
+			AuthorName:  cmt.Author,
+			AuthorEmail: cmt.Mail,
+			Content:     cmt.Text,
+			CreatedAt:   time.Unix(cmt.Created, 0),
+		})
+	}
+
+	return ordered, nil
+}
+
+// open 连接 Typecho 数据库
+func (t *typechoImporter) open(src Source) (*gorm.DB, error) {
+	if src.DSN == "" {
+		return nil, fmt.Errorf("缺少 Typecho 数据库连接串")
+	}
+
+	var dialector gorm.Dialector
+	switch strings.ToLower(src.Driver) {
+	case "", "mysql":
+		dialector = mysql.Open(src.DSN)
+	case "sqlite":
+		dialector = sqlite.Open(src.DSN)
+	default:
+		return nil, fmt.Errorf("不支持的 Typecho 数据库驱动 %q，可选值: mysql, sqlite", src.Driver)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return nil, fmt.Errorf("连接 Typecho 数据库失败: %v", err)
+	}
+	return db, nil
+}
+
+// resolveUploads 使用原站点地址补全附件的相对地址
+func (t *typechoImporter) resolveUploads(text, baseURL string) string {
+	if baseURL == "" {
+		return text
+	}
+	return typechoUploadPattern.ReplaceAllString(text, "${1}"+strings.TrimRight(baseURL, "/")+"${2}")
+}
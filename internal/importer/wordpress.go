@@ -0,0 +1,266 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	wordpressPageCategory = "页面"                   // Jank 没有独立页面，WordPress 页面以文章导入并归入该类目
+	wordpressUploadsPath  = "/wp-content/uploads/" // WordPress 媒体文件的存放路径
+)
+
+var (
+	wordpressCaptionPattern = regexp.MustCompile(`(?s)\[caption[^\]]*\](.*?)\[/caption\]`)
+	wordpressCaptionImage   = regexp.MustCompile(`(?s)^\s*((?:<a[^>]*>)?\s*<img[^>]*>\s*(?:</a>)?)(.*)$`)
+	wordpressBlockPattern   = regexp.MustCompile(`(?i)^<(?:p|div|h[1-6]|ul|ol|li|dl|blockquote|pre|table|figure|hr|iframe|address|section|!--)[\s>/]`)
+	wordpressParagraphTag   = regexp.MustCompile(`(?i)<p[\s>]`)
+	wordpressMediaPattern   = regexp.MustCompile(`(?i)(?:src|href)="([^"]*` + regexp.QuoteMeta(wordpressUploadsPath) + `[^"]*)"`)
+	wordpressRelativeMedia  = regexp.MustCompile(`(?i)((?:src|href)=")(` + regexp.QuoteMeta(wordpressUploadsPath) + `)`)
+	wordpressBlankLines     = regexp.MustCompile(`\n\s*\n`)
+)
+
+func init() {
+	Register(&wordpressImporter{})
+}
+
+// wordpressImporter WordPress WXR 导出文件导入源
+type wordpressImporter struct{}
+
+// wordpressExport WXR 文件结构，仅保留导入所需的字段；wp 命名空间随 WXR 版本变化，按本地名称匹配
+type wordpressExport struct {
+	Channel struct {
+		BaseSiteURL string          `xml:"base_site_url"`
+		Items       []wordpressItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type wordpressItem struct {
+	Title         string              `xml:"title"`
+	Content       string              `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostID        string              `xml:"post_id"`
+	PostDate      string              `xml:"post_date"`
+	PostDateGMT   string              `xml:"post_date_gmt"`
+	ModifiedGMT   string              `xml:"post_modified_gmt"`
+	PostName      string              `xml:"post_name"`
+	Status        string              `xml:"status"`
+	PostType      string              `xml:"post_type"`
+	AttachmentURL string              `xml:"attachment_url"`
+	Categories    []wordpressCategory `xml:"category"`
+	Meta          []wordpressPostMeta `xml:"postmeta"`
+	Comments      []wordpressComment  `xml:"comment"`
+}
+
+type wordpressCategory struct {
+	Domain string `xml:"domain,attr"`
+	Name   string `xml:",chardata"`
+}
+
+type wordpressPostMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+type wordpressComment struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+}
+
+func (w *wordpressImporter) Name() string {
+	return "wordpress"
+}
+
+// Parse 解析 WordPress 导出的 WXR 文件，导入文章与页面，页面归入「页面」类目，分类与标签以类目形式导入；
+// 附件不单独导入，特色图片作为封面，正文中引用的媒体地址记录在文章的导入来源信息中
+func (w *wordpressImporter) Parse(src Source) ([]*Document, error) {
+	if src.Reader == nil {
+		return nil, fmt.Errorf("缺少 WordPress 导出文件")
+	}
+
+	var export wordpressExport
+	decoder := xml.NewDecoder(src.Reader)
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	if err := decoder.Decode(&export); err != nil {
+		return nil, fmt.Errorf("解析 WordPress 导出文件失败: %v", err)
+	}
+
+	baseURL := strings.TrimRight(src.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = strings.TrimRight(strings.TrimSpace(export.Channel.BaseSiteURL), "/")
+	}
+
+	attachments := make(map[string]string)
+	for _, item := range export.Channel.Items {
+		if item.PostType == "attachment" && item.AttachmentURL != "" {
+			attachments[item.PostID] = w.absoluteURL(strings.TrimSpace(item.AttachmentURL), baseURL)
+		}
+	}
+
+	var docs []*Document
+	for _, item := range export.Channel.Items {
+		if item.PostType != "post" && item.PostType != "page" {
+			continue
+		}
+		published, ok := wordpressStatus(item.Status)
+		if !ok {
+			continue
+		}
+
+		doc := &Document{
+			SourceID:  item.PostID,
+			Title:     item.Title,
+			Slug:      item.PostName,
+			HTML:      w.content(item.Content, baseURL),
+			Published: published,
+			CreatedAt: parseWordPressTime(item.PostDateGMT, item.PostDate),
+			UpdatedAt: parseWordPressTime(item.ModifiedGMT, ""),
+			Comments:  w.comments(item.Comments),
+		}
+		for _, cat := range item.Categories {
+			switch cat.Domain {
+			case "category":
+				doc.Categories = append(doc.Categories, cat.Name)
+			case "post_tag":
+				doc.Tags = append(doc.Tags, cat.Name)
+			}
+		}
+		if item.PostType == "page" {
+			doc.Categories = append(doc.Categories, wordpressPageCategory)
+		}
+		for _, meta := range item.Meta {
+			if meta.Key == "_thumbnail_id" {
+				doc.Image = attachments[strings.TrimSpace(meta.Value)]
+			}
+		}
+		doc.Media = wordpressMedia(doc.Image, doc.HTML)
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// content 将 WordPress 正文转换为 HTML：展开图片说明短代码，补全站内相对的媒体地址，经典编辑器的正文按空行分段
+func (w *wordpressImporter) content(content, baseURL string) string {
+	content = strings.ReplaceAll(strings.TrimSpace(content), "\r\n", "\n")
+	content = wordpressCaptionPattern.ReplaceAllStringFunc(content, func(match string) string {
+		inner := wordpressCaptionPattern.FindStringSubmatch(match)[1]
+		parts := wordpressCaptionImage.FindStringSubmatch(inner)
+		if parts == nil {
+			return inner
+		}
+		caption := strings.TrimSpace(parts[2])
+		if caption == "" {
+			return "<figure>" + parts[1] + "</figure>"
+		}
+		return "<figure>" + parts[1] + "<figcaption>" + caption + "</figcaption></figure>"
+	})
+	if baseURL != "" {
+		content = wordpressRelativeMedia.ReplaceAllString(content, "${1}"+baseURL+"${2}")
+	}
+	// 块编辑器的正文已包含段落标签，经典编辑器的正文依赖 WordPress 在展示时自动分段
+	if !wordpressParagraphTag.MatchString(content) {
+		content = wordpressAutoParagraph(content)
+	}
+	return content
+}
+
+// comments 转换已通过审核的评论，pingback 与 trackback 不导入
+func (w *wordpressImporter) comments(items []wordpressComment) []*Comment {
+	var comments []*Comment
+	for _, cmt := range items {
+		if cmt.Approved != "1" || cmt.Type == "pingback" || cmt.Type == "trackback" {
+			continue
+		}
+		parent := cmt.Parent
+		if parent == "0" {
+			parent = ""
+		}
+		comments = append(comments, &Comment{
+			SourceID:    cmt.ID,
+			ParentID:    parent,
+			AuthorName:  cmt.Author,
+			AuthorEmail: cmt.AuthorEmail,
+			Content:     cmt.Content,
+			CreatedAt:   parseWordPressTime(cmt.DateGMT, cmt.Date),
+		})
+	}
+	return comments
+}
+
+// absoluteURL 以 / 开头的站内地址补全为原站点地址
+func (w *wordpressImporter) absoluteURL(link, baseURL string) string {
+	if baseURL != "" && strings.HasPrefix(link, "/") && !strings.HasPrefix(link, "//") {
+		return baseURL + link
+	}
+	return link
+}
+
+// wordpressStatus 转换发布状态，publish 为已发布，草稿、待审、私密与定时发布导入为草稿，回收站与自动草稿不导入
+func wordpressStatus(status string) (bool, bool) {
+	switch status {
+	case "publish":
+		return true, true
+	case "draft", "pending", "private", "future":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// wordpressAutoParagraph 按空行分段，段内换行转换为换行标签，已是块级元素的段落保持不变
+func wordpressAutoParagraph(content string) string {
+	var b strings.Builder
+	for _, block := range wordpressBlankLines.Split(content, -1) {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+		if wordpressBlockPattern.MatchString(block) {
+			b.WriteString(block + "\n")
+			continue
+		}
+		b.WriteString("<p>" + strings.ReplaceAll(block, "\n", "<br />\n") + "</p>\n")
+	}
+	return b.String()
+}
+
+// wordpressMedia 收集封面与正文中引用的 WordPress 媒体文件地址，去重后按出现顺序返回
+func wordpressMedia(image, content string) []string {
+	var media []string
+	seen := make(map[string]bool)
+	add := func(link string) {
+		if link != "" && !seen[link] {
+			seen[link] = true
+			media = append(media, link)
+		}
+	}
+	add(image)
+	for _, match := range wordpressMediaPattern.FindAllStringSubmatch(content, -1) {
+		add(match[1])
+	}
+	return media
+}
+
+// parseWordPressTime 解析 WXR 中的时间，优先使用 GMT 时间，GMT 时间为空或为零值时使用站点时区的时间
+func parseWordPressTime(gmt, local string) time.Time {
+	for _, value := range []string{gmt, local} {
+		value = strings.TrimSpace(value)
+		if value == "" || strings.HasPrefix(value, "0000-00-00") {
+			continue
+		}
+		if t, err := time.Parse("2006-01-02 15:04:05", value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
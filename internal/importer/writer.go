@@ -0,0 +1,311 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/model/base"
+	category "jank.com/jank_blog/internal/model/category"
+	comment "jank.com/jank_blog/internal/model/comment"
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+)
+
+const (
+	maxTitleLen   = 255  // 文章标题长度上限
+	maxCommentLen = 1024 // 评论内容长度上限
+)
+
+var (
+	htmlTagPattern  = regexp.MustCompile(`<[^>]*>`)
+	imageSrcPattern = regexp.MustCompile(`<img[^>]+src="([^"]+)"`)
+)
+
+// writer 将解析结果写入数据库
+type writer struct {
+	opts       Options
+	report     *Report
+	db         *gorm.DB
+	categories map[string]int64 // 类目名称 -> 类目 ID
+	accounts   map[string]int64 // 邮箱 -> 用户 ID
+}
+
+func newWriter(opts Options, report *Report) *writer {
+	return &writer{
+		opts:       opts,
+		report:     report,
+		categories: make(map[string]int64),
+		accounts:   make(map[string]int64),
+	}
+}
+
+// write 在同一事务中写入全部文章、类目与评论，试运行时写入后返回 errDryRun 回滚事务
+func (w *writer) write(docs []*Document) error {
+	if global.DB == nil {
+		return fmt.Errorf("数据库未初始化")
+	}
+
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		// 跳过 BeforeCreate 钩子，保留源站点的发布时间
+		w.db = tx.Session(&gorm.Session{SkipHooks: true})
+
+		if err := w.loadCategories(); err != nil {
+			return err
+		}
+		if w.opts.Progress != nil {
+			w.opts.Progress(0, len(docs))
+		}
+		for i, doc := range docs {
+			if err := w.writeDocument(doc); err != nil {
+				return err
+			}
+			if w.opts.Progress != nil {
+				w.opts.Progress(i+1, len(docs))
+			}
+		}
+		if w.opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+}
+
+// loadCategories 加载已有的根类目，同名类目直接复用
+func (w *writer) loadCategories() error {
+	var roots []*category.Category
+	if err := w.db.Where("parent_id = ? AND deleted = ?", 0, false).Find(&roots).Error; err != nil {
+		return fmt.Errorf("获取已有类目失败: %v", err)
+	}
+	for _, cat := range roots {
+		w.categories[cat.Name] = cat.ID
+	}
+	return nil
+}
+
+// writeDocument 写入单篇文章及其评论
+func (w *writer) writeDocument(doc *Document) error {
+	title := truncate(strings.TrimSpace(doc.Title), maxTitleLen)
+	if title == "" {
+		w.skip("文章 %s 缺少标题，已跳过", doc.SourceID)
+		return nil
+	}
+
+	contentHTML := doc.HTML
+	contentMarkdown := doc.Markdown
+	if contentMarkdown == "" {
+		sanitized, err := utils.SanitizeHTML(contentHTML)
+		if err != nil {
+			return fmt.Errorf("过滤文章 %q 的 HTML 失败: %v", title, err)
+		}
+		contentHTML = sanitized
+	}
+	if contentMarkdown != "" {
+		rendered, err := utils.RenderMarkdown([]byte(contentMarkdown))
+		if err != nil {
+			w.skip("文章 %q 渲染 Markdown 失败，已跳过: %v", title, err)
+			return nil
+		}
+		contentHTML = rendered
+	} else {
+		// 源站点仅提供 HTML 时，编辑器中展示原始 HTML
+		contentMarkdown = contentHTML
+	}
+
+	image := doc.Image
+	if image == "" {
+		if match := imageSrcPattern.FindStringSubmatch(contentHTML); match != nil {
+			image = match[1]
+		}
+	}
+
+	categoryIDs, err := w.ensureCategories(append(append([]string{}, doc.Categories...), doc.Tags...))
+	if err != nil {
+		return err
+	}
+
+	created := unixOrNow(doc.CreatedAt)
+	modified := unixOrNow(doc.UpdatedAt)
+	if modified < created {
+		modified = created
+	}
+
+	origin := w.origin(doc.SourceID, doc.Slug)
+	if len(doc.Media) > 0 {
+		origin["media"] = doc.Media
+	}
+	pos := &post.Post{
+		Base:            w.newBase(created, modified, origin),
+		Title:           title,
+		Image:           truncate(image, 255),
+		Visibility:      doc.Published,
+		Status:          post.StatusFromVisibility(doc.Published),
+		Access:          post.AccessPublic,
+		ContentMarkdown: contentMarkdown,
+		ContentHTML:     contentHTML,
+		CategoryIDs:     categoryIDs,
+	}
+	pos.WordCount, pos.ReadingTime = utils.ReadingStats(contentHTML)
+	if err := w.db.Create(pos).Error; err != nil {
+		return fmt.Errorf("写入文章 %q 失败: %v", title, err)
+	}
+	w.report.Posts++
+	w.report.Media += len(doc.Media)
+
+	return w.writeComments(pos.ID, doc.Comments)
+}
+
+// writeComments 按时间顺序写入评论，并还原回复关系
+func (w *writer) writeComments(postID int64, comments []*Comment) error {
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+
+	ids := make(map[string]int64, len(comments))
+	for _, cmt := range comments {
+		content := truncate(strings.TrimSpace(htmlTagPattern.ReplaceAllString(cmt.Content, "")), maxCommentLen)
+		if content == "" {
+			w.skip("文章 %d 的评论 %s 内容为空，已跳过", postID, cmt.SourceID)
+			continue
+		}
+
+		userID, err := w.resolveAccount(cmt.AuthorEmail)
+		if err != nil {
+			return err
+		}
+
+		created := unixOrNow(cmt.CreatedAt)
+		origin := w.origin(cmt.SourceID, "")
+		origin["author"] = cmt.AuthorName
+
+		contentHTML, err := utils.RenderCommentMarkdown([]byte(content))
+		if err != nil {
+			return fmt.Errorf("渲染评论失败: %v", err)
+		}
+
+		record := &comment.Comment{
+			Base:             w.newBase(created, created, origin),
+			Content:          content,
+			ContentHTML:      contentHTML,
+			UserId:           userID,
+			PostId:           postID,
+			ReplyToCommentId: ids[cmt.ParentID],
+		}
+		if err := w.db.Create(record).Error; err != nil {
+			return fmt.Errorf("写入评论失败: %v", err)
+		}
+		if cmt.SourceID != "" {
+			ids[cmt.SourceID] = record.ID
+		}
+		w.report.Comments++
+	}
+	return nil
+}
+
+// ensureCategories 将分类与标签名称转换为类目 ID，不存在的类目作为根类目创建
+func (w *writer) ensureCategories(names []string) (post.CategoryIDsArray, error) {
+	var ids post.CategoryIDsArray
+	seen := make(map[int64]bool)
+
+	for _, name := range names {
+		name = truncate(strings.TrimSpace(name), 255)
+		if name == "" {
+			continue
+		}
+
+		id, ok := w.categories[name]
+		if !ok {
+			now := time.Now().Unix()
+			cat := &category.Category{
+				Base: w.newBase(now, now, w.origin("", "")),
+				Name: name,
+				Path: "",
+			}
+			if err := w.db.Create(cat).Error; err != nil {
+				return nil, fmt.Errorf("写入类目 %q 失败: %v", name, err)
+			}
+			id = cat.ID
+			w.categories[name] = id
+			w.report.Categories++
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// resolveAccount 根据评论者邮箱匹配已有用户，未匹配时归属到执行导入的用户
+func (w *writer) resolveAccount(email string) (int64, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return w.opts.AccountID, nil
+	}
+	if id, ok := w.accounts[email]; ok {
+		return id, nil
+	}
+
+	var acc account.Account
+	err := w.db.Select("id").Where("email = ? AND deleted = ?", email, false).First(&acc).Error
+	switch {
+	case err == nil:
+		w.accounts[email] = acc.ID
+	case err == gorm.ErrRecordNotFound:
+		w.accounts[email] = w.opts.AccountID
+	default:
+		return 0, fmt.Errorf("匹配评论用户失败: %v", err)
+	}
+	return w.accounts[email], nil
+}
+
+// origin 构造导入来源信息，记录在扩展字段的 import 键下
+func (w *writer) origin(sourceID, slug string) map[string]interface{} {
+	origin := map[string]interface{}{"source": w.report.Format}
+	if sourceID != "" {
+		origin["id"] = sourceID
+	}
+	if slug != "" {
+		origin["slug"] = slug
+	}
+	return origin
+}
+
+// newBase 构造带有导入来源信息的通用字段
+func (w *writer) newBase(created, modified int64, origin map[string]interface{}) base.Base {
+	return base.Base{
+		GmtCreate:   created,
+		GmtModified: modified,
+		Ext:         base.JSONMap{"import": origin},
+	}
+}
+
+// skip 记录被跳过的条目
+func (w *writer) skip(format string, args ...interface{}) {
+	w.report.Skipped++
+	w.report.Warnings = append(w.report.Warnings, fmt.Sprintf(format, args...))
+}
+
+// unixOrNow 转换为秒级时间戳，零值时使用当前时间
+func unixOrNow(t time.Time) int64 {
+	if t.IsZero() {
+		return time.Now().Unix()
+	}
+	return t.Unix()
+}
+
+// truncate 按字符截断字符串
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return s
+}
@@ -0,0 +1,192 @@
+package ipaccess
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+)
+
+// 规则列表
+const (
+	ListAllow = "allow" // 白名单，非空时仅允许其中的 IP 访问管理接口
+	ListDeny  = "deny"  // 黑名单，其中的 IP 不能访问管理接口，优先于白名单
+)
+
+// 管理员在运行时维护的规则，保存在 Redis 中供多个实例共享
+const (
+	AllowKey = "IP_ACCESS:ADMIN:ALLOW" // 管理员添加的白名单
+	DenyKey  = "IP_ACCESS:ADMIN:DENY"  // 管理员添加的黑名单
+)
+
+var (
+	ErrInvalidRule = errors.New("IP 或 CIDR 格式无效")
+	ErrDenied      = errors.New("当前 IP 已被禁止访问管理接口")
+	ErrNotAllowed  = errors.New("当前 IP 不在管理接口的白名单中")
+)
+
+// Rules 管理接口的访问规则，规则均为规范化的 CIDR
+type Rules struct {
+	ConfigAllow []string // 配置文件中的白名单
+	ConfigDeny  []string // 配置文件中的黑名单
+	Allow       []string // 管理员添加的白名单
+	Deny        []string // 管理员添加的黑名单
+}
+
+// Normalize 将 IP 或 CIDR 规范化为网络地址形式的 CIDR，单个 IP 转为 /32 或 /128
+func Normalize(rule string) (string, error) {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return "", ErrInvalidRule
+	}
+	if !strings.Contains(rule, "/") {
+		addr, err := netip.ParseAddr(rule)
+		if err != nil {
+			return "", ErrInvalidRule
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+	}
+	prefix, err := netip.ParsePrefix(rule)
+	if err != nil {
+		return "", ErrInvalidRule
+	}
+	if prefix.Addr().Is4In6() {
+		return "", ErrInvalidRule
+	}
+	return prefix.Masked().String(), nil
+}
+
+// Check 校验 IP 能否访问管理接口，命中黑名单时返回 ErrDenied，配置了白名单且未命中时返回 ErrNotAllowed
+// Redis 不可用时仅使用配置文件中的规则
+func Check(ctx context.Context, ip string) error {
+	rules, err := Load(ctx)
+	if err != nil {
+		global.SysLog.Warnf("读取管理员维护的 IP 访问规则失败: %v", err)
+	}
+	return rules.Evaluate(ip)
+}
+
+// IPExtractor 按可信代理解析客户端 IP，trusted 为空时直接使用连接的对端地址，不读取客户端可伪造的请求头；
+// 否则仅当请求来自 trusted 中的地址时按 X-Forwarded-For 从右向左取第一个不可信的地址
+func IPExtractor(trusted []string) echo.IPExtractor {
+	ranges := normalizeAll(trusted)
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, rule := range ranges {
+		if _, ipNet, err := net.ParseCIDR(rule); err == nil {
+			options = append(options, echo.TrustIPRange(ipNet))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// Evaluate 按规则校验 IP，IP 无法解析时视为未命中任何规则
+func (r Rules) Evaluate(ip string) error {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	valid := err == nil
+	addr = addr.Unmap()
+
+	if valid && (match(r.ConfigDeny, addr) || match(r.Deny, addr)) {
+		return ErrDenied
+	}
+	if len(r.ConfigAllow) == 0 && len(r.Allow) == 0 {
+		return nil
+	}
+	if valid && (match(r.ConfigAllow, addr) || match(r.Allow, addr)) {
+		return nil
+	}
+	return ErrNotAllowed
+}
+
+// Load 读取配置文件与 Redis 中的规则，Redis 读取失败时返回配置文件中的规则与错误
+func Load(ctx context.Context) (Rules, error) {
+	var rules Rules
+	if config, err := configs.LoadConfig(); err == nil {
+		rules.ConfigAllow = normalizeAll(config.IPAccessConfig.AdminIPAllowlist)
+		rules.ConfigDeny = normalizeAll(config.IPAccessConfig.AdminIPDenylist)
+	}
+	if global.RedisClient == nil {
+		return rules, nil
+	}
+
+	pipe := global.RedisClient.Pipeline()
+	allowCmd := pipe.SMembers(ctx, AllowKey)
+	denyCmd := pipe.SMembers(ctx, DenyKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return rules, err
+	}
+	rules.Allow, rules.Deny = allowCmd.Val(), denyCmd.Val()
+	sort.Strings(rules.Allow)
+	sort.Strings(rules.Deny)
+	return rules, nil
+}
+
+// Add 将规则加入列表，同一规则只能在一个列表中
+func Add(ctx context.Context, list, rule string) error {
+	if global.RedisClient == nil {
+		return errors.New("Redis 未连接")
+	}
+	pipe := global.RedisClient.TxPipeline()
+	pipe.SRem(ctx, otherKey(list), rule)
+	pipe.SAdd(ctx, listKey(list), rule)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Remove 将规则移出列表，配置文件中的规则不能移除
+func Remove(ctx context.Context, list, rule string) error {
+	if global.RedisClient == nil {
+		return errors.New("Redis 未连接")
+	}
+	return global.RedisClient.SRem(ctx, listKey(list), rule).Err()
+}
+
+// listKey 列表对应的 Redis 键
+func listKey(list string) string {
+	if list == ListDeny {
+		return DenyKey
+	}
+	return AllowKey
+}
+
+// otherKey 另一个列表对应的 Redis 键
+func otherKey(list string) string {
+	if list == ListDeny {
+		return AllowKey
+	}
+	return DenyKey
+}
+
+// match IP 是否命中任一规则，无法解析的规则跳过
+func match(rules []string, addr netip.Addr) bool {
+	for _, rule := range rules {
+		if prefix, err := netip.ParsePrefix(rule); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAll 规范化配置文件中的规则，格式无效的规则记录日志后跳过
+func normalizeAll(rules []string) []string {
+	result := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		normalized, err := Normalize(rule)
+		if err != nil {
+			global.SysLog.Warnf("忽略格式无效的 IP 访问规则 %q", rule)
+			continue
+		}
+		result = append(result, normalized)
+	}
+	return result
+}
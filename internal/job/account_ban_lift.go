@@ -0,0 +1,55 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// AccountBanLiftTask 限时停用到期解除任务名称
+const AccountBanLiftTask = "account_ban_lift"
+
+// accountBanLiftTask 每 5 分钟解除已到期的限时停用，并为本次解除的账户写入一条审计日志；
+// 用户在任务执行前登录时由登录流程立即解除，不必等待任务
+func accountBanLiftTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        AccountBanLiftTask,
+		Description: "解除已到期的限时停用",
+		Interval:    5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			now := time.Now().Unix()
+			ids, err := mapper.GetAccountIDsWithExpiredBan(now)
+			if err != nil {
+				return err
+			}
+
+			// 解除失败时中止本轮处理，已解除的账户仍写入审计日志
+			lifted := make([]int64, 0, len(ids))
+			for _, id := range ids {
+				if err = ctx.Err(); err != nil {
+					break
+				}
+				var ok bool
+				if ok, err = mapper.LiftExpiredAccountBan(id, now); err != nil {
+					break
+				}
+				if ok {
+					lifted = append(lifted, id)
+				}
+			}
+			if len(lifted) > 0 {
+				global.SysLog.WithFields(logrus.Fields{
+					"audit": AccountBanLiftTask,
+					"count": len(lifted),
+					"ids":   lifted,
+				}).Info("限时停用到期解除完成")
+			}
+			return err
+		},
+	}
+}
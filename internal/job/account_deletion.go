@@ -0,0 +1,68 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/avatar"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// AccountDeletionTask 注销账户清理任务名称
+const AccountDeletionTask = "account_deletion"
+
+// accountDeletionTask 每小时永久删除注销冷静期已到期的账户及其上传的头像，并为本次删除的账户写入一条审计日志
+func accountDeletionTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        AccountDeletionTask,
+		Description: "永久删除注销冷静期已到期的账户并匿名化其评论",
+		Interval:    time.Hour,
+		Run: func(ctx context.Context) error {
+			before := time.Now().Unix()
+			ids, err := mapper.GetAccountIDsDueForDeletion(before)
+			if err != nil {
+				return err
+			}
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+			avatarDir := config.AvatarConfig.AvatarDir
+			if avatarDir == "" {
+				avatarDir = avatar.DefaultDir
+			}
+
+			// 删除失败时中止本轮清理，已删除的账户仍写入审计日志
+			purged := make([]int64, 0, len(ids))
+			for _, id := range ids {
+				if err = ctx.Err(); err != nil {
+					break
+				}
+				// 查询后重新登录撤销注销的账户不删除
+				var ok bool
+				if ok, err = mapper.PurgeAccount(id, before); err != nil {
+					break
+				}
+				if ok {
+					purged = append(purged, id)
+					if err := avatar.RemoveAccountAvatars(avatarDir, id); err != nil {
+						global.SysLog.Errorf("删除账户 %d 的头像失败: %v", id, err)
+					}
+				}
+			}
+			if len(purged) > 0 {
+				global.SysLog.WithFields(logrus.Fields{
+					"audit": AccountDeletionTask,
+					"count": len(purged),
+					"ids":   purged,
+				}).Info("注销账户清理完成")
+			}
+			return err
+		},
+	}
+}
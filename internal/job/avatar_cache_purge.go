@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/avatar"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+)
+
+// AvatarCachePurgeTask Gravatar 头像缓存清理任务名称
+const AvatarCachePurgeTask = "avatar_cache_purge"
+
+// avatarCachePurgeTask 每天删除已过期的 Gravatar 头像缓存，头像被删除或账户注销后缓存不会一直保留
+func avatarCachePurgeTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        AvatarCachePurgeTask,
+		Description: "删除已过期的 Gravatar 头像缓存",
+		Interval:    24 * time.Hour,
+		Run: func(ctx context.Context) error {
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			dir := config.AvatarConfig.AvatarCacheDir
+			if dir == "" {
+				dir = avatar.DefaultCacheDir
+			}
+			ttl := avatar.DefaultCacheTTL
+			if config.AvatarConfig.AvatarCacheTTL > 0 {
+				ttl = time.Duration(config.AvatarConfig.AvatarCacheTTL) * time.Hour
+			}
+
+			removed, err := avatar.PurgeCache(dir, time.Now().Add(-ttl))
+			if len(removed) > 0 {
+				global.SysLog.WithFields(logrus.Fields{
+					"audit": AvatarCachePurgeTask,
+					"count": len(removed),
+				}).Info("Gravatar 头像缓存清理完成")
+			}
+			return err
+		},
+	}
+}
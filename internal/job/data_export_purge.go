@@ -0,0 +1,50 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/export"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+)
+
+// DataExportPurgeTask 个人数据导出文件清理任务名称
+const DataExportPurgeTask = "data_export_purge"
+
+// dataExportPurgeTask 每小时删除下载链接已过期的个人数据导出文件
+func dataExportPurgeTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        DataExportPurgeTask,
+		Description: "删除下载链接已过期的个人数据导出文件",
+		Interval:    time.Hour,
+		Run: func(ctx context.Context) error {
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			dir := config.DataExportConfig.DataExportDir
+			if dir == "" {
+				dir = export.DefaultAccountExportDir
+			}
+			ttl := export.DefaultAccountExportTTL
+			if config.DataExportConfig.DataExportLinkTTL > 0 {
+				ttl = time.Duration(config.DataExportConfig.DataExportLinkTTL) * time.Hour
+			}
+
+			removed, err := export.PurgeAccountExports(dir, time.Now().Add(-ttl))
+			if len(removed) > 0 {
+				global.SysLog.WithFields(logrus.Fields{
+					"audit": DataExportPurgeTask,
+					"count": len(removed),
+					"files": removed,
+				}).Info("个人数据导出文件清理完成")
+			}
+			return err
+		},
+	}
+}
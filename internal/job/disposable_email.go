@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/disposable"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+)
+
+// disposableEmailTask 按配置的间隔重新加载一次性邮箱域名的文件与远程列表，启动时先加载一次
+func disposableEmailTask() scheduler.Task {
+	interval := 24 * time.Hour
+	if config, err := configs.LoadConfig(); err == nil {
+		if config.DisposableEmailConfig.DisposableEmailRefreshInterval > 0 {
+			interval = time.Duration(config.DisposableEmailConfig.DisposableEmailRefreshInterval) * time.Second
+		}
+		go refreshDisposableDomains(context.Background(), config.DisposableEmailConfig)
+	}
+
+	return scheduler.Task{
+		Name:        "disposable_email_refresh",
+		Description: "重新加载一次性邮箱域名的文件与远程列表",
+		Interval:    interval,
+		Run: func(ctx context.Context) error {
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+			return refreshDisposableDomains(ctx, config.DisposableEmailConfig)
+		},
+	}
+}
+
+// refreshDisposableDomains 未开启黑名单时跳过
+func refreshDisposableDomains(ctx context.Context, cfg configs.DisposableEmailConfig) error {
+	if !cfg.DisposableEmailEnabled {
+		return nil
+	}
+	count, err := disposable.Refresh(ctx, disposable.Sources{File: cfg.DisposableEmailFile, URL: cfg.DisposableEmailURL})
+	if err != nil {
+		global.SysLog.Errorf("加载一次性邮箱域名失败: %v", err)
+		return err
+	}
+	global.SysLog.Infof("一次性邮箱域名加载完成，共 %d 个", count)
+	return nil
+}
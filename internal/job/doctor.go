@@ -0,0 +1,31 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/internal/doctor"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+)
+
+// doctorTask 每日执行只读的数据库体检，发现问题时写入系统日志，修复需通过 doctor -fix 命令手动执行
+func doctorTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        "db_doctor",
+		Description: "检查孤立数据、重复类目与缺失索引",
+		Interval:    24 * time.Hour,
+		Run: func(ctx context.Context) error {
+			report, err := doctor.Run(doctor.Options{})
+			if err != nil {
+				return err
+			}
+			for _, f := range report.Findings {
+				if f.Found > 0 {
+					global.SysLog.Warnf("数据库体检发现问题 [%s] %s: %d 项", f.Name, f.Description, f.Found)
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"jank.com/jank_blog/internal/scheduler"
+)
+
+// Init 注册内置定时任务
+func Init() {
+	scheduler.Register(doctorTask())
+	scheduler.Register(searchSuggestTask())
+	scheduler.Register(searchIndexTask())
+	scheduler.Register(trashPurgeTask())
+	scheduler.Register(scheduledPublishTask())
+	scheduler.Register(mailQueueTask())
+	scheduler.Register(disposableEmailTask())
+	scheduler.Register(verificationLogPurgeTask())
+	scheduler.Register(accountDeletionTask())
+	scheduler.Register(accountBanLiftTask())
+	scheduler.Register(dataExportPurgeTask())
+	scheduler.Register(avatarCachePurgeTask())
+	scheduler.Register(jwtKeyRotationTask())
+	scheduler.Register(revisionPurgeTask())
+	scheduler.Register(viewFlushTask())
+	scheduler.Register(settingReloadTask())
+}
@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/jwtkey"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// JWTKeyRotationTask JWT 签名密钥轮换任务名称
+const JWTKeyRotationTask = "jwt_key_rotation"
+
+// jwtKeyRotationTask 每小时检查签名密钥，使用时长达到 JWT_KEY_ROTATION_DAYS 天时轮换，并永久删除已停止校验的旧密钥
+func jwtKeyRotationTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        JWTKeyRotationTask,
+		Description: "按计划轮换 JWT 签名密钥并删除已失效的旧密钥",
+		Interval:    time.Hour,
+		Run: func(ctx context.Context) error {
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			interval := time.Duration(config.JWTConfig.JWTKeyRotationDays) * 24 * time.Hour
+			rotated, err := jwtkey.RotateIfDue(interval)
+			if err != nil {
+				return err
+			}
+			if rotated {
+				global.SysLog.WithFields(logrus.Fields{
+					"audit": JWTKeyRotationTask,
+					"days":  config.JWTConfig.JWTKeyRotationDays,
+				}).Info("JWT 签名密钥已按计划轮换")
+			}
+
+			count, err := mapper.PurgeExpiredJWTSigningKeys(time.Now().Unix())
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				global.SysLog.Infof("已删除 %d 个失效的 JWT 签名密钥", count)
+			}
+			return nil
+		},
+	}
+}
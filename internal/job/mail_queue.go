@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/breaker"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/mail"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// mailQueueTask 按配置的间隔发送邮件队列中已到发送时间的邮件
+func mailQueueTask() scheduler.Task {
+	interval := 2 * time.Second
+	if config, err := configs.LoadConfig(); err == nil && config.MailQueueConfig.MailQueuePollInterval > 0 {
+		interval = time.Duration(config.MailQueueConfig.MailQueuePollInterval) * time.Second
+	}
+
+	return scheduler.Task{
+		Name:        "mail_queue",
+		Description: "发送邮件队列中的邮件，失败时按指数退避重试",
+		Interval:    interval,
+		Run: func(ctx context.Context) error {
+			// 邮件发送熔断期间不领取邮件，避免队列中的邮件白白消耗重试次数
+			if global.RedisClient == nil || utils.MailBreakerStatus().State == breaker.StateOpen {
+				return nil
+			}
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+			cfg := config.MailQueueConfig
+
+			sent, dead, err := mail.ProcessQueue(ctx, sendMail, mail.QueueOptions{
+				BatchSize:   cfg.MailQueueBatchSize,
+				MaxAttempts: cfg.MailQueueMaxAttempts,
+				BackoffBase: time.Duration(cfg.MailQueueBackoffBase) * time.Second,
+				BackoffMax:  time.Duration(cfg.MailQueueBackoffMax) * time.Second,
+			})
+			if sent > 0 || dead > 0 {
+				global.SysLog.Infof("邮件队列发送成功 %d 封，移入死信列表 %d 封", sent, dead)
+			}
+			return err
+		},
+	}
+}
+
+// sendMail 通过 SMTP 发送队列中的邮件
+func sendMail(subject, text, html string, to []string) error {
+	start := time.Now()
+	_, err := utils.SendHTMLEmail(subject, text, html, to)
+	mail.ObserveDelivery(mail.DeliveryQueue, time.Since(start), err)
+	return err
+}
@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// revisionPurgeTask 每日永久删除超过保留天数的文章修订，每篇文章的最新修订始终保留
+func revisionPurgeTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        "revision_purge",
+		Description: "永久删除超过保留天数的文章修订",
+		Interval:    24 * time.Hour,
+		Run: func(ctx context.Context) error {
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+			days := config.RevisionConfig.RevisionRetentionDays
+			if days <= 0 {
+				return nil
+			}
+
+			count, err := mapper.PurgePostRevisions(time.Now().AddDate(0, 0, -days).Unix())
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				global.SysLog.Infof("文章修订清理完成，删除 %d 条", count)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+	service "jank.com/jank_blog/pkg/serve/service/post"
+)
+
+// scheduledPublishTask 每分钟发布定时发布时间已到的文章，包括审核通过后等待发布与作者直接定时发布的文章
+func scheduledPublishTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        "scheduled_publish",
+		Description: "发布到达定时发布时间的文章，并通知 Webhook 与重新导出静态站点",
+		Interval:    time.Minute,
+		Run: func(ctx context.Context) error {
+			published, err := service.PublishScheduledPosts(ctx)
+			if published > 0 {
+				global.SysLog.Infof("定时发布文章 %d 篇", published)
+			}
+			return err
+		},
+	}
+}
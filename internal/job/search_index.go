@@ -0,0 +1,29 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+)
+
+// searchIndexTask 每日根据已发布文章及其评论重建全文索引，修正增量更新失败或分类改名造成的偏差
+func searchIndexTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        searchService.RebuildTask,
+		Description: "重建文章标题、正文、标签与评论的全文索引",
+		Interval:    24 * time.Hour,
+		Run: func(ctx context.Context) error {
+			posts, comments, err := searchService.RebuildIndex(ctx)
+			if err != nil {
+				return err
+			}
+			if posts > 0 || comments > 0 {
+				global.SysLog.Infof("全文索引重建完成，共 %d 篇文章、%d 条评论", posts, comments)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/internal/global"
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// SearchSuggestTask 搜索建议索引重建任务名称
+const SearchSuggestTask = "search_suggest_rebuild"
+
+// searchSuggestTask 定期根据已发布文章重建搜索建议索引，清理已下线文章与不再使用的标签
+func searchSuggestTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        SearchSuggestTask,
+		Description: "重建文章标题与标签的搜索建议索引",
+		Interval:    6 * time.Hour,
+		Run: func(ctx context.Context) error {
+			posts, err := mapper.GetAllPublishedPosts(post.VisibleAccess(false))
+			if err != nil {
+				return err
+			}
+			categories, err := mapper.GetAllActivatedCategories()
+			if err != nil {
+				return err
+			}
+			names := make(map[int64]string, len(categories))
+			for _, cat := range categories {
+				names[cat.ID] = cat.Name
+			}
+
+			var suggestions []search.Suggestion
+			tags := make(map[int64]bool)
+			for _, pos := range posts {
+				suggestions = append(suggestions, search.Suggestion{Type: search.SuggestTitle, ID: pos.ID, Text: pos.Title})
+				for _, id := range pos.CategoryIDs {
+					if name, ok := names[id]; ok && !tags[id] {
+						tags[id] = true
+						suggestions = append(suggestions, search.Suggestion{Type: search.SuggestTag, ID: id, Text: name})
+					}
+				}
+			}
+
+			if err := search.RebuildSuggestions(ctx, suggestions); err != nil {
+				return err
+			}
+			global.SysLog.Infof("搜索建议索引重建完成，共 %d 条", len(suggestions))
+			return nil
+		},
+	}
+}
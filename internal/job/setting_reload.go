@@ -0,0 +1,21 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/internal/setting"
+)
+
+// settingReloadTask 每分钟重新加载运行时配置项，使其他实例修改的配置生效
+func settingReloadTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        "setting_reload",
+		Description: "重新加载其他实例修改的运行时配置",
+		Interval:    time.Minute,
+		Run: func(ctx context.Context) error {
+			return setting.Load()
+		},
+	}
+}
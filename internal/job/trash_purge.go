@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// TrashPurgeTask 回收站清理任务名称
+const TrashPurgeTask = "trash_purge"
+
+// trashPurgeTask 每日永久删除超过保留天数的已删除文章与评论，并为每类数据写入一条审计日志
+func trashPurgeTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        TrashPurgeTask,
+		Description: "永久删除超过保留天数的已删除文章与评论",
+		Interval:    24 * time.Hour,
+		Run: func(ctx context.Context) error {
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+
+			targets := []struct {
+				kind  string
+				days  int
+				purge func(before int64) ([]int64, error)
+			}{
+				{"post", config.TrashConfig.TrashPostRetentionDays, mapper.PurgeDeletedPosts},
+				{"comment", config.TrashConfig.TrashCommentRetentionDays, mapper.PurgeDeletedComments},
+			}
+			for _, target := range targets {
+				if target.days <= 0 {
+					continue
+				}
+
+				before := time.Now().AddDate(0, 0, -target.days).Unix()
+				ids, err := target.purge(before)
+				if err != nil {
+					return err
+				}
+				if len(ids) == 0 {
+					continue
+				}
+				global.SysLog.WithFields(logrus.Fields{
+					"audit":         TrashPurgeTask,
+					"type":          target.kind,
+					"retentionDays": target.days,
+					"count":         len(ids),
+					"ids":           ids,
+				}).Info("回收站清理完成")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// verificationLogPurgeTask 每日永久删除超过保留天数的验证码审计日志
+func verificationLogPurgeTask() scheduler.Task {
+	return scheduler.Task{
+		Name:        "verification_log_purge",
+		Description: "永久删除超过保留天数的验证码审计日志",
+		Interval:    24 * time.Hour,
+		Run: func(ctx context.Context) error {
+			config, err := configs.LoadConfig()
+			if err != nil {
+				return err
+			}
+			days := config.VerificationConfig.VerificationAuditRetention
+			if days <= 0 {
+				return nil
+			}
+
+			count, err := mapper.PurgeVerificationLogs(time.Now().AddDate(0, 0, -days).Unix())
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				global.SysLog.Infof("验证码审计日志清理完成，删除 %d 条", count)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/scheduler"
+	service "jank.com/jank_blog/pkg/serve/service/post"
+)
+
+// viewFlushTask 按配置的间隔将 Redis 中累计的文章浏览量写入数据库
+func viewFlushTask() scheduler.Task {
+	interval := time.Minute
+	if config, err := configs.LoadConfig(); err == nil && config.ViewCounterConfig.ViewFlushInterval > 0 {
+		interval = time.Duration(config.ViewCounterConfig.ViewFlushInterval) * time.Second
+	}
+
+	return scheduler.Task{
+		Name:        service.ViewFlushTask,
+		Description: "将 Redis 中累计的文章浏览量写入数据库",
+		Interval:    interval,
+		Run: func(ctx context.Context) error {
+			_, err := service.FlushViews(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,376 @@
+package jwtkey
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// 签名密钥用途
+const (
+	PurposeAccess  = "access"  // access token
+	PurposeRefresh = "refresh" // refresh token
+)
+
+const (
+	DefaultGraceWindow = 48 * time.Hour   // 轮换后旧密钥继续有效的默认时长，与 refresh token 有效期一致
+	reloadInterval     = time.Minute      // 定期从数据库重新加载密钥的间隔，其他实例轮换的密钥最迟在该间隔后生效
+	missReloadInterval = 10 * time.Second // 遇到未知 kid 时重新加载密钥的最小间隔，避免伪造的 kid 频繁查询数据库
+	secretSize         = 32
+)
+
+// ErrUnavailable 数据库未初始化或读取签名密钥失败，调用方应拒绝签发与校验，不回退到内置密钥
+var ErrUnavailable = errors.New("JWT 签名密钥不可用")
+
+// ErrUnknownKey kid 对应的密钥不存在或已停止校验
+var ErrUnknownKey = errors.New("JWT 签名密钥不存在或已失效")
+
+// Key 签名密钥
+type Key struct {
+	Kid       string // 密钥 ID
+	Purpose   string // 用途
+	Secret    []byte // HMAC 密钥
+	CreatedAt int64  // 创建时间
+	RetiredAt int64  // 被新密钥替换的时间，当前使用的密钥为 0
+	ExpiresAt int64  // 停止校验的时间，当前使用的密钥为 0
+}
+
+// valid 密钥在 now 时是否仍可用于校验
+func (k *Key) valid(now int64) bool {
+	return k.ExpiresAt == 0 || now < k.ExpiresAt
+}
+
+var (
+	legacySecrets = make(map[string][]byte) // 按用途索引的升级前使用的内置密钥
+
+	loadMu   sync.Mutex // 保证同一时间只有一个请求从数据库加载密钥
+	mu       sync.RWMutex
+	keys     map[string]*Key // 按 kid 索引的全部有效密钥
+	current  map[string]*Key // 按用途索引的当前签发密钥
+	loadedAt time.Time       // 最近一次加载密钥的时间
+	missAt   time.Time       // 最近一次因未知 kid 重新加载的时间
+)
+
+// LegacyKid 升级前不带 kid 头部的 token 对应的密钥 ID
+func LegacyKid(purpose string) string {
+	return "legacy-" + purpose
+}
+
+// SetLegacySecret 登记升级前使用的内置密钥，已有用户的系统升级后首次创建签名密钥时将其保存为已替换的密钥，
+// 升级前签发的不带 kid 头部的 token 在宽限期内继续有效；新安装的系统不保存内置密钥
+func SetLegacySecret(purpose string, secret []byte) {
+	mu.Lock()
+	defer mu.Unlock()
+	legacySecrets[purpose] = secret
+}
+
+// Init 启动时加载签名密钥，尚无密钥时创建；应在创建任何用户之前调用，以便按是否已有用户判断是否为升级
+func Init() error {
+	return ensureLoaded()
+}
+
+// Signing 获取用途对应的当前签发密钥，尚无密钥时自动创建
+func Signing(purpose string) (*Key, error) {
+	if err := ensureLoaded(); err != nil {
+		return nil, err
+	}
+	mu.RLock()
+	key := current[purpose]
+	mu.RUnlock()
+	if key == nil {
+		return nil, ErrUnavailable
+	}
+	return key, nil
+}
+
+// Lookup 按 kid 获取用于校验的密钥，kid 为空时查找升级前签发的 token 使用的密钥，没有保存内置密钥时返回 ErrUnknownKey；
+// 本地未找到时重新加载一次，以识别其他实例刚轮换的密钥
+func Lookup(purpose, kid string) (*Key, error) {
+	if kid == "" {
+		kid = LegacyKid(purpose)
+	}
+	if err := ensureLoaded(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	if key := find(purpose, kid, now); key != nil {
+		return key, nil
+	}
+	mu.Lock()
+	retry := time.Since(missAt) >= missReloadInterval
+	if retry {
+		missAt = time.Now()
+	}
+	mu.Unlock()
+	if retry && reload() == nil {
+		if key := find(purpose, kid, now); key != nil {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// Keys 获取全部有效的签名密钥，按创建时间倒序排列
+func Keys() ([]*Key, error) {
+	records, err := mapper.GetValidJWTSigningKeys(time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Key, 0, len(records))
+	for _, record := range records {
+		if key, err := keyFromRecord(record); err == nil {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+// Rotate 为 access token 与 refresh token 各生成一个新密钥用于签发，旧密钥在宽限期内继续用于校验；
+// immediate 为 true 时旧密钥立即失效，已签发的 token 全部作废，用于密钥疑似泄露时
+func Rotate(immediate bool) ([]*Key, error) {
+	if global.DB == nil {
+		return nil, ErrUnavailable
+	}
+	now := time.Now()
+	expiresAt := now.Add(graceWindow()).Unix()
+	if immediate {
+		expiresAt = now.Unix()
+	}
+
+	rotated := make([]*Key, 0, 2)
+	for _, purpose := range []string{PurposeAccess, PurposeRefresh} {
+		record, err := newRecord(purpose)
+		if err != nil {
+			return nil, err
+		}
+		if err := mapper.RotateJWTSigningKey(record, now.Unix(), expiresAt); err != nil {
+			return nil, err
+		}
+		key, err := keyFromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		rotated = append(rotated, key)
+	}
+	if err := reload(); err != nil {
+		return nil, err
+	}
+	return rotated, nil
+}
+
+// RotateIfDue 当前签发密钥的使用时长达到 interval 时轮换密钥，返回是否已轮换
+func RotateIfDue(interval time.Duration) (bool, error) {
+	if interval <= 0 {
+		return false, nil
+	}
+	if err := reload(); err != nil {
+		return false, err
+	}
+
+	deadline := time.Now().Add(-interval).Unix()
+	mu.RLock()
+	due := false
+	for _, purpose := range []string{PurposeAccess, PurposeRefresh} {
+		if key := current[purpose]; key == nil || key.CreatedAt <= deadline {
+			due = true
+		}
+	}
+	mu.RUnlock()
+	if !due {
+		return false, nil
+	}
+	if _, err := Rotate(false); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// find 在本地加载的密钥中查找 kid 对应且仍有效的密钥
+func find(purpose, kid string, now int64) *Key {
+	mu.RLock()
+	defer mu.RUnlock()
+	key := keys[kid]
+	if key == nil || key.Purpose != purpose || !key.valid(now) {
+		return nil
+	}
+	return key
+}
+
+// ensureLoaded 尚未加载或距上次加载超过 reloadInterval 时重新加载密钥，
+// 加载失败时继续使用已加载的密钥，从未成功加载时返回 ErrUnavailable
+func ensureLoaded() error {
+	if fresh() {
+		return nil
+	}
+	loadMu.Lock()
+	defer loadMu.Unlock()
+	if fresh() {
+		return nil
+	}
+	if err := load(); err != nil {
+		mu.RLock()
+		defer mu.RUnlock()
+		if keys == nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fresh 密钥已加载且未超过 reloadInterval
+func fresh() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return keys != nil && time.Since(loadedAt) < reloadInterval
+}
+
+// reload 立即从数据库重新加载密钥
+func reload() error {
+	loadMu.Lock()
+	defer loadMu.Unlock()
+	return load()
+}
+
+// load 从数据库加载全部有效密钥，某一用途尚无签发密钥时先创建
+func load() error {
+	if global.DB == nil {
+		return ErrUnavailable
+	}
+	records, err := mapper.GetValidJWTSigningKeys(time.Now().Unix())
+	if err != nil {
+		global.SysLog.Errorf("%v", err)
+		return ErrUnavailable
+	}
+
+	created := false
+	for _, purpose := range []string{PurposeAccess, PurposeRefresh} {
+		if currentRecord(records, purpose) != nil {
+			continue
+		}
+		if err := bootstrap(purpose); err != nil {
+			global.SysLog.Errorf("%v", err)
+			return ErrUnavailable
+		}
+		created = true
+	}
+	if created {
+		if records, err = mapper.GetValidJWTSigningKeys(time.Now().Unix()); err != nil {
+			global.SysLog.Errorf("%v", err)
+			return ErrUnavailable
+		}
+	}
+
+	loaded := make(map[string]*Key, len(records))
+	signing := make(map[string]*Key, 2)
+	for _, record := range records {
+		key, err := keyFromRecord(record)
+		if err != nil {
+			global.SysLog.Errorf("%v", err)
+			continue
+		}
+		loaded[key.Kid] = key
+		// 记录按创建时间倒序排列，多个实例同时创建密钥时使用最新的一个
+		if key.RetiredAt == 0 && signing[key.Purpose] == nil {
+			signing[key.Purpose] = key
+		}
+	}
+
+	mu.Lock()
+	keys, current, loadedAt = loaded, signing, time.Now()
+	mu.Unlock()
+	return nil
+}
+
+// bootstrap 首次启用签名密钥时创建用途对应的签发密钥；已有用户时视为升级，将登记的内置密钥保存为在宽限期后失效的已替换密钥，
+// 新安装的系统不保存内置密钥，公开在源码中的内置密钥签发的 token 一律无效
+func bootstrap(purpose string) error {
+	now := time.Now()
+	mu.RLock()
+	legacy := legacySecrets[purpose]
+	mu.RUnlock()
+	accounts, err := mapper.CountAccounts()
+	if err != nil {
+		return err
+	}
+	if len(legacy) > 0 && accounts > 0 {
+		// 多个实例同时启动时只有一个能写入，其余实例写入失败不影响使用
+		_ = mapper.CreateJWTSigningKey(&model.JWTSigningKey{
+			Kid:       LegacyKid(purpose),
+			Purpose:   purpose,
+			Secret:    hex.EncodeToString(legacy),
+			RetiredAt: now.Unix(),
+			ExpiresAt: now.Add(graceWindow()).Unix(),
+		})
+	}
+
+	record, err := newRecord(purpose)
+	if err != nil {
+		return err
+	}
+	if err := mapper.CreateJWTSigningKey(record); err != nil {
+		return err
+	}
+	global.SysLog.Infof("已创建 %s token 签名密钥 %s", purpose, record.Kid)
+	return nil
+}
+
+// currentRecord 获取用途对应的当前签发密钥记录
+func currentRecord(records []*model.JWTSigningKey, purpose string) *model.JWTSigningKey {
+	for _, record := range records {
+		if record.Purpose == purpose && record.RetiredAt == 0 {
+			return record
+		}
+	}
+	return nil
+}
+
+// newRecord 生成随机的密钥 ID 与密钥
+func newRecord(purpose string) (*model.JWTSigningKey, error) {
+	kid := make([]byte, 8)
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(kid); err != nil {
+		return nil, fmt.Errorf("生成 JWT 签名密钥失败: %v", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("生成 JWT 签名密钥失败: %v", err)
+	}
+	return &model.JWTSigningKey{
+		Kid:     hex.EncodeToString(kid),
+		Purpose: purpose,
+		Secret:  hex.EncodeToString(secret),
+	}, nil
+}
+
+// keyFromRecord 将数据库记录转换为密钥，密钥无法解码或为空时返回错误，避免以空密钥校验 token
+func keyFromRecord(record *model.JWTSigningKey) (*Key, error) {
+	secret, err := hex.DecodeString(record.Secret)
+	if err != nil || len(secret) == 0 {
+		return nil, fmt.Errorf("JWT 签名密钥 %s 无效", record.Kid)
+	}
+	return &Key{
+		Kid:       record.Kid,
+		Purpose:   record.Purpose,
+		Secret:    secret,
+		CreatedAt: record.GmtCreate,
+		RetiredAt: record.RetiredAt,
+		ExpiresAt: record.ExpiresAt,
+	}, nil
+}
+
+// graceWindow 读取配置中的旧密钥宽限期
+func graceWindow() time.Duration {
+	config, err := configs.LoadConfig()
+	if err != nil || config.JWTConfig.JWTKeyGraceHours < 1 {
+		return DefaultGraceWindow
+	}
+	return time.Duration(config.JWTConfig.JWTKeyGraceHours) * time.Hour
+}
@@ -0,0 +1,189 @@
+package ldap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// BER 标识字节中的类别与构造位
+const (
+	classUniversal   = 0x00
+	classApplication = 0x40
+	classContext     = 0x80
+	constructed      = 0x20
+)
+
+// 用到的通用类型标签
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+	tagSet         = 0x31
+)
+
+// maxPacketSize 单个响应的最大字节数，防止异常的长度字段耗尽内存
+const maxPacketSize = 16 << 20
+
+var errMalformedPacket = errors.New("LDAP 响应格式无效")
+
+// packet 解码后的 BER 元素，构造类型的内容解析为子元素
+type packet struct {
+	tag      byte // 完整的标识字节，包含类别与构造位
+	value    []byte
+	children []*packet
+}
+
+// tlv 编码一个 BER 元素，标签只使用单字节形式
+func tlv(tag byte, content []byte) []byte {
+	n := len(content)
+	out := []byte{tag}
+	switch {
+	case n < 0x80:
+		out = append(out, byte(n))
+	case n <= 0xff:
+		out = append(out, 0x81, byte(n))
+	case n <= 0xffff:
+		out = append(out, 0x82, byte(n>>8), byte(n))
+	default:
+		out = append(out, 0x84, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+	return append(out, content...)
+}
+
+// seq 将多个已编码的元素拼接为构造类型
+func seq(tag byte, elements ...[]byte) []byte {
+	var content []byte
+	for _, e := range elements {
+		content = append(content, e...)
+	}
+	return tlv(tag, content)
+}
+
+// octetString 编码字符串
+func octetString(s string) []byte {
+	return tlv(tagOctetString, []byte(s))
+}
+
+// integer 以补码最短形式编码整数
+func integer(tag byte, v int64) []byte {
+	var b []byte
+	for {
+		b = append([]byte{byte(v)}, b...)
+		if (v < 0x80 && v >= -0x80) || len(b) == 8 {
+			break
+		}
+		v >>= 8
+	}
+	return tlv(tag, b)
+}
+
+// boolean 编码布尔值
+func boolean(tag byte, v bool) []byte {
+	if v {
+		return tlv(tag, []byte{0xff})
+	}
+	return tlv(tag, []byte{0x00})
+}
+
+// readPacket 从连接读取一个完整的 BER 元素，只支持定长编码
+func readPacket(r *bufio.Reader) (*packet, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if tag&0x1f == 0x1f {
+		return nil, errMalformedPacket
+	}
+	first, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	length := int(first)
+	if first&0x80 != 0 {
+		n := int(first & 0x7f)
+		if n == 0 || n > 4 {
+			return nil, errMalformedPacket
+		}
+		length = 0
+		for i := 0; i < n; i++ {
+			b, err := r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxPacketSize {
+		return nil, fmt.Errorf("LDAP 响应过大: %d 字节", length)
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+	return decode(tag, content)
+}
+
+// decode 解码元素内容，构造类型递归解析子元素
+func decode(tag byte, content []byte) (*packet, error) {
+	p := &packet{tag: tag, value: content}
+	if tag&constructed == 0 {
+		return p, nil
+	}
+	for len(content) > 0 {
+		child, rest, err := parseElement(content)
+		if err != nil {
+			return nil, err
+		}
+		p.children = append(p.children, child)
+		content = rest
+	}
+	return p, nil
+}
+
+// parseElement 从字节切片头部解析一个元素，返回剩余部分
+func parseElement(b []byte) (*packet, []byte, error) {
+	if len(b) < 2 || b[0]&0x1f == 0x1f {
+		return nil, nil, errMalformedPacket
+	}
+	tag, length, offset := b[0], int(b[1]), 2
+	if b[1]&0x80 != 0 {
+		n := int(b[1] & 0x7f)
+		if n == 0 || n > 4 || len(b) < 2+n {
+			return nil, nil, errMalformedPacket
+		}
+		length = 0
+		for _, c := range b[2 : 2+n] {
+			length = length<<8 | int(c)
+		}
+		offset += n
+	}
+	if length < 0 || len(b)-offset < length {
+		return nil, nil, errMalformedPacket
+	}
+	p, err := decode(tag, b[offset:offset+length])
+	return p, b[offset+length:], err
+}
+
+// int 将元素内容解析为整数
+func (p *packet) int() int64 {
+	var v int64
+	for i, b := range p.value {
+		if i == 0 && b&0x80 != 0 {
+			v = -1
+		}
+		v = v<<8 | int64(b)
+	}
+	return v
+}
+
+// child 返回第 i 个子元素，不存在时返回空元素，便于链式读取
+func (p *packet) child(i int) *packet {
+	if i < len(p.children) {
+		return p.children[i]
+	}
+	return &packet{}
+}
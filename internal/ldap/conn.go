@@ -0,0 +1,273 @@
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LDAP 协议操作的应用标签，见 RFC 4511 4.2 至 4.12
+const (
+	opBindRequest       = classApplication | constructed | 0
+	opBindResponse      = classApplication | constructed | 1
+	opUnbindRequest     = classApplication | 2
+	opSearchRequest     = classApplication | constructed | 3
+	opSearchEntry       = classApplication | constructed | 4
+	opSearchDone        = classApplication | constructed | 5
+	opSearchReference   = classApplication | constructed | 19
+	opExtendedRequest   = classApplication | constructed | 23
+	opExtendedResponse  = classApplication | constructed | 24
+	startTLSOID         = "1.3.6.1.4.1.1466.20037"
+	resultSuccess       = 0
+	resultSizeExceeded  = 4
+	resultInvalidCreds  = 49
+	scopeWholeSubtree   = 2
+	derefAliasesNever   = 0
+	protocolVersion     = 3
+	defaultTimeout      = 10 * time.Second
+	defaultPort         = "389"
+	defaultTLSPort      = "636"
+	searchAttributeNone = "1.1" // 只返回 DN，不返回任何属性
+)
+
+// ErrInvalidCredentials 用户不存在或密码错误
+var ErrInvalidCredentials = errors.New("LDAP 用户名或密码错误")
+
+// ResultError 目录服务返回的非成功结果
+type ResultError struct {
+	Code    int64
+	Message string
+}
+
+func (e *ResultError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("LDAP 请求失败，结果码 %d", e.Code)
+	}
+	return fmt.Sprintf("LDAP 请求失败，结果码 %d: %s", e.Code, e.Message)
+}
+
+// Entry 搜索返回的条目，属性名统一为小写
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Get 返回属性的第一个值，属性不存在时返回空字符串
+func (e *Entry) Get(attr string) string {
+	if values := e.Attributes[strings.ToLower(attr)]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// conn 与目录服务的连接，请求按顺序发送并等待响应，不支持并发
+type conn struct {
+	conn    net.Conn
+	r       *bufio.Reader
+	msgID   int64
+	timeout time.Duration
+}
+
+// dial 连接目录服务，地址形如 ldap://host:389 或 ldaps://host:636，startTLS 为 true 时在明文连接上升级为 TLS
+func dial(ctx context.Context, rawURL string, startTLS, insecureSkipVerify bool, timeout time.Duration) (*conn, error) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP 地址无效: %v", err)
+	}
+	host, port := u.Hostname(), u.Port()
+	var useTLS bool
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+		if port == "" {
+			port = defaultPort
+		}
+	case "ldaps":
+		useTLS = true
+		if port == "" {
+			port = defaultTLSPort
+		}
+	default:
+		return nil, fmt.Errorf("LDAP 地址 %q 的协议应为 ldap 或 ldaps", rawURL)
+	}
+	tlsConfig := &tls.Config{ServerName: host, InsecureSkipVerify: insecureSkipVerify}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var nc net.Conn
+	if useTLS {
+		nc, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	} else {
+		nc, err = dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("连接 LDAP 服务失败: %v", err)
+	}
+
+	c := &conn{conn: nc, r: bufio.NewReader(nc), timeout: timeout}
+	if !useTLS && startTLS {
+		if err := c.startTLS(tlsConfig); err != nil {
+			nc.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// close 发送解绑请求并关闭连接
+func (c *conn) close() {
+	c.msgID++
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	_, _ = c.conn.Write(seq(tagSequence, integer(tagInteger, c.msgID), tlv(opUnbindRequest, nil)))
+	c.conn.Close()
+}
+
+// startTLS 发送 StartTLS 扩展请求，成功后在当前连接上完成 TLS 握手
+func (c *conn) startTLS(tlsConfig *tls.Config) error {
+	resp, err := c.request(seq(opExtendedRequest, tlv(classContext|0, []byte(startTLSOID))))
+	if err != nil {
+		return fmt.Errorf("LDAP StartTLS 失败: %v", err)
+	}
+	if err := checkResult(resp, opExtendedResponse); err != nil {
+		return fmt.Errorf("LDAP StartTLS 失败: %v", err)
+	}
+	tc := tls.Client(c.conn, tlsConfig)
+	_ = tc.SetDeadline(time.Now().Add(c.timeout))
+	if err := tc.Handshake(); err != nil {
+		return fmt.Errorf("LDAP StartTLS 握手失败: %v", err)
+	}
+	c.conn, c.r = tc, bufio.NewReader(tc)
+	return nil
+}
+
+// bind 简单绑定，用户不存在或密码错误时返回 ErrInvalidCredentials
+func (c *conn) bind(dn, password string) error {
+	resp, err := c.request(seq(opBindRequest,
+		integer(tagInteger, protocolVersion),
+		octetString(dn),
+		tlv(classContext|0, []byte(password)),
+	))
+	if err != nil {
+		return err
+	}
+	err = checkResult(resp, opBindResponse)
+	var result *ResultError
+	if errors.As(err, &result) && result.Code == resultInvalidCreds {
+		return ErrInvalidCredentials
+	}
+	return err
+}
+
+// search 在 baseDN 下搜索整个子树，sizeLimit 为 0 时不限制条数，返回条数超过限制时返回已收到的条目
+func (c *conn) search(baseDN, filter string, attributes []string, sizeLimit int) ([]*Entry, error) {
+	encodedFilter, err := compileFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	attrs := make([][]byte, len(attributes))
+	for i, attr := range attributes {
+		attrs[i] = octetString(attr)
+	}
+
+	id, err := c.send(seq(opSearchRequest,
+		octetString(baseDN),
+		integer(tagEnumerated, scopeWholeSubtree),
+		integer(tagEnumerated, derefAliasesNever),
+		integer(tagInteger, int64(sizeLimit)),
+		integer(tagInteger, int64(c.timeout/time.Second)),
+		boolean(tagBoolean, false),
+		encodedFilter,
+		seq(tagSequence, attrs...),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for {
+		op, err := c.receive(id)
+		if err != nil {
+			return nil, err
+		}
+		switch op.tag {
+		case opSearchEntry:
+			entries = append(entries, parseEntry(op))
+		case opSearchReference:
+			// 不跟随引用
+		case opSearchDone:
+			err := checkResult(op, opSearchDone)
+			var result *ResultError
+			if errors.As(err, &result) && result.Code == resultSizeExceeded {
+				return entries, nil
+			}
+			return entries, err
+		default:
+			return nil, errMalformedPacket
+		}
+	}
+}
+
+// request 发送请求并读取对应的单个响应
+func (c *conn) request(op []byte) (*packet, error) {
+	id, err := c.send(op)
+	if err != nil {
+		return nil, err
+	}
+	return c.receive(id)
+}
+
+// send 为请求分配消息 ID 并写入连接
+func (c *conn) send(op []byte) (int64, error) {
+	c.msgID++
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(seq(tagSequence, integer(tagInteger, c.msgID), op)); err != nil {
+		return 0, fmt.Errorf("发送 LDAP 请求失败: %v", err)
+	}
+	return c.msgID, nil
+}
+
+// receive 读取下一个响应并返回其中的协议操作，消息 ID 不一致时视为错误
+func (c *conn) receive(id int64) (*packet, error) {
+	_ = c.conn.SetReadDeadline(time.Now().Add(c.timeout))
+	msg, err := readPacket(c.r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 LDAP 响应失败: %v", err)
+	}
+	if msg.tag != tagSequence || len(msg.children) < 2 {
+		return nil, errMalformedPacket
+	}
+	if got := msg.child(0).int(); got != id {
+		return nil, fmt.Errorf("LDAP 响应的消息 ID %d 与请求 %d 不一致", got, id)
+	}
+	return msg.child(1), nil
+}
+
+// checkResult 校验响应类型与 LDAPResult 中的结果码
+func checkResult(op *packet, want byte) error {
+	if op.tag != want || len(op.children) < 3 {
+		return errMalformedPacket
+	}
+	if code := op.child(0).int(); code != resultSuccess {
+		return &ResultError{Code: code, Message: string(op.child(2).value)}
+	}
+	return nil
+}
+
+// parseEntry 解析搜索结果条目
+func parseEntry(op *packet) *Entry {
+	entry := &Entry{DN: string(op.child(0).value), Attributes: make(map[string][]string)}
+	for _, attr := range op.child(1).children {
+		name := strings.ToLower(string(attr.child(0).value))
+		for _, v := range attr.child(1).children {
+			entry.Attributes[name] = append(entry.Attributes[name], string(v.value))
+		}
+	}
+	return entry
+}
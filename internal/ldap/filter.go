@@ -0,0 +1,219 @@
+package ldap
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// 过滤器的上下文标签，见 RFC 4511 4.5.1
+const (
+	filterAnd             = classContext | constructed | 0
+	filterOr              = classContext | constructed | 1
+	filterNot             = classContext | constructed | 2
+	filterEqualityMatch   = classContext | constructed | 3
+	filterSubstrings      = classContext | constructed | 4
+	filterGreaterOrEqual  = classContext | constructed | 5
+	filterLessOrEqual     = classContext | constructed | 6
+	filterPresent         = classContext | 7
+	filterApproxMatch     = classContext | constructed | 8
+	filterExtensibleMatch = classContext | constructed | 9
+)
+
+// EscapeFilter 转义替换进过滤器的值中的特殊字符，见 RFC 4515 3
+func EscapeFilter(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '*', '(', ')', '\\', 0:
+			fmt.Fprintf(&b, "\\%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// compileFilter 将字符串形式的过滤器编码为 BER
+func compileFilter(filter string) ([]byte, error) {
+	filter = strings.TrimSpace(filter)
+	if filter != "" && filter[0] != '(' {
+		filter = "(" + filter + ")"
+	}
+	encoded, rest, err := parseFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP 过滤器 %q 无效: %v", filter, err)
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("LDAP 过滤器 %q 无效: 多余的内容 %q", filter, rest)
+	}
+	return encoded, nil
+}
+
+// parseFilter 解析一个带括号的过滤器，返回编码结果与剩余部分
+func parseFilter(s string) ([]byte, string, error) {
+	if len(s) < 2 || s[0] != '(' {
+		return nil, "", fmt.Errorf("缺少左括号")
+	}
+	s = s[1:]
+
+	switch s[0] {
+	case '&', '|':
+		tag := byte(filterAnd)
+		if s[0] == '|' {
+			tag = filterOr
+		}
+		s = s[1:]
+		var children [][]byte
+		for len(s) > 0 && s[0] != ')' {
+			child, rest, err := parseFilter(s)
+			if err != nil {
+				return nil, "", err
+			}
+			children, s = append(children, child), rest
+		}
+		if len(s) == 0 {
+			return nil, "", fmt.Errorf("缺少右括号")
+		}
+		return seq(tag, children...), s[1:], nil
+	case '!':
+		child, rest, err := parseFilter(s[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		if len(rest) == 0 || rest[0] != ')' {
+			return nil, "", fmt.Errorf("缺少右括号")
+		}
+		return seq(filterNot, child), rest[1:], nil
+	}
+
+	end := strings.IndexByte(s, ')')
+	if end < 0 {
+		return nil, "", fmt.Errorf("缺少右括号")
+	}
+	encoded, err := parseItem(s[:end])
+	return encoded, s[end+1:], err
+}
+
+// parseItem 解析不含括号的单个条件
+func parseItem(item string) ([]byte, error) {
+	eq := strings.IndexByte(item, '=')
+	if eq <= 0 {
+		return nil, fmt.Errorf("条件 %q 缺少属性或运算符", item)
+	}
+	attr, raw := item[:eq], item[eq+1:]
+
+	tag := byte(filterEqualityMatch)
+	switch attr[len(attr)-1] {
+	case '~':
+		tag = filterApproxMatch
+	case '>':
+		tag = filterGreaterOrEqual
+	case '<':
+		tag = filterLessOrEqual
+	case ':':
+		return parseExtensible(attr[:len(attr)-1], raw)
+	}
+	if tag != filterEqualityMatch {
+		attr = attr[:len(attr)-1]
+	}
+	if attr == "" {
+		return nil, fmt.Errorf("条件 %q 缺少属性", item)
+	}
+
+	if tag == filterEqualityMatch {
+		if raw == "*" {
+			return tlv(filterPresent, []byte(attr)), nil
+		}
+		if strings.Contains(raw, "*") {
+			return parseSubstrings(attr, raw)
+		}
+	}
+	value, err := unescapeFilter(raw)
+	if err != nil {
+		return nil, err
+	}
+	return seq(tag, octetString(attr), octetString(value)), nil
+}
+
+// parseSubstrings 解析包含通配符的子串条件
+func parseSubstrings(attr, raw string) ([]byte, error) {
+	parts := strings.Split(raw, "*")
+	var subs [][]byte
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		value, err := unescapeFilter(part)
+		if err != nil {
+			return nil, err
+		}
+		tag := byte(classContext | 1) // any
+		switch i {
+		case 0:
+			tag = classContext | 0 // initial
+		case len(parts) - 1:
+			tag = classContext | 2 // final
+		}
+		subs = append(subs, tlv(tag, []byte(value)))
+	}
+	return seq(filterSubstrings, octetString(attr), seq(tagSequence, subs...)), nil
+}
+
+// parseExtensible 解析扩展匹配条件，形如 attr[:dn][:rule]:=value
+func parseExtensible(left, raw string) ([]byte, error) {
+	fields := strings.Split(left, ":")
+	attr, fields := fields[0], fields[1:]
+	dnAttributes, rule := false, ""
+	for _, f := range fields {
+		if strings.EqualFold(f, "dn") {
+			dnAttributes = true
+		} else {
+			rule = f
+		}
+	}
+	if attr == "" && rule == "" {
+		return nil, fmt.Errorf("扩展匹配条件缺少属性与匹配规则")
+	}
+	value, err := unescapeFilter(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var elements [][]byte
+	if rule != "" {
+		elements = append(elements, tlv(classContext|1, []byte(rule)))
+	}
+	if attr != "" {
+		elements = append(elements, tlv(classContext|2, []byte(attr)))
+	}
+	elements = append(elements, tlv(classContext|3, []byte(value)))
+	if dnAttributes {
+		elements = append(elements, boolean(classContext|4, true))
+	}
+	return seq(filterExtensibleMatch, elements...), nil
+}
+
+// unescapeFilter 还原 \XX 形式的转义字符
+func unescapeFilter(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+3 > len(s) {
+			return "", fmt.Errorf("转义字符 %q 不完整", s[i:])
+		}
+		decoded, err := hex.DecodeString(s[i+1 : i+3])
+		if err != nil {
+			return "", fmt.Errorf("转义字符 %q 无效", s[i:i+3])
+		}
+		b.Write(decoded)
+		i += 2
+	}
+	return b.String(), nil
+}
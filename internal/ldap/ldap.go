@@ -0,0 +1,186 @@
+package ldap
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 未配置时使用的默认值，适用于 Active Directory
+const (
+	DefaultUserFilter        = "(&(objectClass=user)(sAMAccountName={username}))"
+	DefaultUIDAttribute      = "objectGUID"
+	DefaultEmailAttribute    = "mail"
+	DefaultNicknameAttribute = "displayName"
+	DefaultGroupAttribute    = "memberOf"
+)
+
+// Options 连接与搜索配置
+type Options struct {
+	URL                string        // 目录服务地址，如 ldaps://dc.example.com:636
+	StartTLS           bool          // 使用 ldap:// 时是否通过 StartTLS 升级为加密连接
+	InsecureSkipVerify bool          // 是否跳过证书校验，仅用于测试环境
+	BindDN             string        // 搜索用户使用的服务账号，留空时匿名搜索
+	BindPassword       string        // 服务账号密码
+	BaseDN             string        // 搜索用户的起点
+	UserFilter         string        // 搜索用户的过滤器，{username} 替换为转义后的用户名
+	UIDAttribute       string        // 用户唯一 ID 的属性，不存在时使用 DN
+	EmailAttribute     string        // 邮箱属性
+	NicknameAttribute  string        // 昵称属性
+	GroupAttribute     string        // 用户条目上记录所属组的属性
+	GroupBaseDN        string        // 搜索组的起点，留空时使用 BaseDN
+	GroupFilter        string        // 搜索组的过滤器，{dn} 替换为用户 DN，{username} 替换为用户名，留空时不搜索
+	Timeout            time.Duration // 连接与单次请求的超时时间
+}
+
+// User 通过校验的目录用户
+type User struct {
+	DN       string
+	UID      string   // 唯一 ID，二进制属性（如 AD 的 objectGUID）转为十六进制
+	Username string   // 登录时提交的用户名
+	Email    string   // 邮箱，目录中未填写时为空
+	Nickname string   // 昵称，目录中未填写时为空
+	Groups   []string // 所属组的 DN
+}
+
+// Authenticate 使用用户名与密码登录目录服务，用户不存在、不唯一或密码错误时返回 ErrInvalidCredentials
+func Authenticate(ctx context.Context, opts Options, username, password string) (*User, error) {
+	// 多数目录服务将空密码的简单绑定视为匿名绑定并返回成功
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	opts = withDefaults(opts)
+
+	c, err := dial(ctx, opts.URL, opts.StartTLS, opts.InsecureSkipVerify, opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+
+	if err := c.serviceBind(opts); err != nil {
+		return nil, err
+	}
+	filter := strings.ReplaceAll(opts.UserFilter, "{username}", EscapeFilter(username))
+	attrs := []string{opts.UIDAttribute, opts.EmailAttribute, opts.NicknameAttribute, opts.GroupAttribute}
+	entries, err := c.search(opts.BaseDN, filter, attrs, 2)
+	if err != nil {
+		return nil, fmt.Errorf("搜索 LDAP 用户失败: %v", err)
+	}
+	if len(entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	entry := entries[0]
+
+	if err := c.bind(entry.DN, password); err != nil {
+		return nil, err
+	}
+
+	user := &User{
+		DN:       entry.DN,
+		UID:      attributeID(entry.Get(opts.UIDAttribute)),
+		Username: username,
+		Email:    strings.TrimSpace(entry.Get(opts.EmailAttribute)),
+		Nickname: strings.TrimSpace(entry.Get(opts.NicknameAttribute)),
+		Groups:   entry.Attributes[strings.ToLower(opts.GroupAttribute)],
+	}
+	if user.UID == "" {
+		user.UID = entry.DN
+	}
+
+	if opts.GroupFilter != "" {
+		// 普通用户通常没有搜索组的权限，切回服务账号
+		if err := c.serviceBind(opts); err != nil {
+			return nil, err
+		}
+		groupFilter := strings.NewReplacer("{dn}", EscapeFilter(entry.DN), "{username}", EscapeFilter(username)).Replace(opts.GroupFilter)
+		groups, err := c.search(opts.GroupBaseDN, groupFilter, []string{searchAttributeNone}, 0)
+		if err != nil {
+			return nil, fmt.Errorf("搜索 LDAP 用户所属组失败: %v", err)
+		}
+		for _, group := range groups {
+			user.Groups = append(user.Groups, group.DN)
+		}
+	}
+	user.Groups = dedupeDNs(user.Groups)
+	return user, nil
+}
+
+// InGroup 判断用户是否属于指定的组，DN 比较不区分大小写并忽略逗号两侧的空格
+func (u *User) InGroup(groupDN string) bool {
+	want := normalizeDN(groupDN)
+	for _, group := range u.Groups {
+		if normalizeDN(group) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceBind 使用服务账号绑定，未配置服务账号时保持匿名
+func (c *conn) serviceBind(opts Options) error {
+	if opts.BindDN == "" {
+		return nil
+	}
+	if err := c.bind(opts.BindDN, opts.BindPassword); err != nil {
+		return fmt.Errorf("LDAP 服务账号绑定失败: %v", err)
+	}
+	return nil
+}
+
+// withDefaults 为未配置的项填入默认值
+func withDefaults(opts Options) Options {
+	if opts.UserFilter == "" {
+		opts.UserFilter = DefaultUserFilter
+	}
+	if opts.UIDAttribute == "" {
+		opts.UIDAttribute = DefaultUIDAttribute
+	}
+	if opts.EmailAttribute == "" {
+		opts.EmailAttribute = DefaultEmailAttribute
+	}
+	if opts.NicknameAttribute == "" {
+		opts.NicknameAttribute = DefaultNicknameAttribute
+	}
+	if opts.GroupAttribute == "" {
+		opts.GroupAttribute = DefaultGroupAttribute
+	}
+	if opts.GroupBaseDN == "" {
+		opts.GroupBaseDN = opts.BaseDN
+	}
+	return opts
+}
+
+// attributeID 将唯一 ID 属性转为可保存的字符串，非 UTF-8 的二进制值转为十六进制
+func attributeID(value string) string {
+	if value == "" || utf8.ValidString(value) {
+		return value
+	}
+	return hex.EncodeToString([]byte(value))
+}
+
+// normalizeDN 统一 DN 的大小写与逗号两侧的空格
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.ToLower(strings.Join(parts, ","))
+}
+
+// dedupeDNs 去除重复的 DN，保留首次出现的顺序
+func dedupeDNs(dns []string) []string {
+	seen := make(map[string]struct{}, len(dns))
+	result := make([]string, 0, len(dns))
+	for _, dn := range dns {
+		key := normalizeDN(dn)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, dn)
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package linkpreview
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// 出站请求限制，防止抓取接口被用于 SSRF 或拖垮服务
+const (
+	fetchTimeout = 5 * time.Second // 单次抓取的总超时时间
+	maxRedirects = 3               // 最大重定向次数
+	maxBodySize  = 1 << 20         // 最多读取的响应体字节数
+)
+
+// ErrForbiddenAddress 目标地址为内网、回环等禁止访问的地址
+var ErrForbiddenAddress = fmt.Errorf("禁止访问内网地址")
+
+// newClient 创建仅允许访问公网地址的 HTTP 客户端
+// 地址校验放在建立连接时进行，对重定向与 DNS 重绑定同样生效
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: fetchTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !publicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: fetchTimeout,
+		Transport: &http.Transport{
+			Proxy:                 nil, // 不走代理，确保地址校验作用于真实目标
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   fetchTimeout,
+			ResponseHeaderTimeout: fetchTimeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("重定向次数过多")
+			}
+			return validateURL(req.URL)
+		},
+	}
+}
+
+// validateURL 校验地址协议与端口，仅允许默认端口的 http 与 https
+func validateURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("不支持的协议: %s", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("缺少主机名")
+	}
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		return fmt.Errorf("不允许的端口: %s", port)
+	}
+	if u.User != nil {
+		return fmt.Errorf("地址中不允许包含用户信息")
+	}
+	return nil
+}
+
+// publicIP 判断是否为可访问的公网地址
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	// 运营商级 NAT 地址段 100.64.0.0/10
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xc0 == 64 {
+		return false
+	}
+	return true
+}
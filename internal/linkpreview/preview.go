@@ -0,0 +1,196 @@
+package linkpreview
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+const (
+	previewCache           = "LINK:PREVIEW"   // 链接预览缓存
+	previewCacheExpireTime = time.Hour * 24   // 链接预览缓存有效期
+	failureCacheExpireTime = time.Minute * 10 // 抓取失败的缓存有效期，避免重复请求失效的地址
+	maxTextLength          = 300              // 标题与描述的最大字符数
+	userAgent              = "JankBlog-LinkPreview/1.0"
+)
+
+// Preview 链接预览信息
+type Preview struct {
+	URL         string `json:"url"`         // 原始链接
+	Title       string `json:"title"`       // 标题
+	Description string `json:"description"` // 描述
+	Image       string `json:"image"`       // 预览图片地址
+	SiteName    string `json:"site_name"`   // 站点名称
+	Error       string `json:"error"`       // 抓取失败的原因，成功时为空
+}
+
+var client = newClient()
+
+// Fetch 获取链接的预览信息，优先读取缓存；抓取失败时返回带有 Error 的预览并短暂缓存
+func Fetch(ctx context.Context, rawURL string) *Preview {
+	cacheKey := fmt.Sprintf("%s:%s", previewCache, hash(rawURL))
+	if global.RedisClient != nil {
+		if cached, err := global.RedisClient.Get(ctx, cacheKey).Result(); err == nil {
+			var preview Preview
+			if err := json.Unmarshal([]byte(cached), &preview); err == nil {
+				return &preview
+			}
+		}
+	}
+
+	preview, err := fetch(ctx, rawURL)
+	expire := previewCacheExpireTime
+	if err != nil {
+		preview = &Preview{URL: rawURL, Error: err.Error()}
+		expire = failureCacheExpireTime
+	}
+
+	if global.RedisClient != nil {
+		if data, err := json.Marshal(preview); err == nil {
+			if err := global.RedisClient.Set(ctx, cacheKey, data, expire).Err(); err != nil {
+				global.SysLog.Errorf("缓存链接预览失败: %v", err)
+			}
+		}
+	}
+	return preview
+}
+
+// fetch 抓取页面并解析预览信息
+func fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("链接格式错误: %v", err)
+	}
+	if err := validateURL(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("响应状态异常: %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("不支持的内容类型: %s", mediaType)
+	}
+
+	preview := parse(io.LimitReader(resp.Body, maxBodySize), resp.Request.URL)
+	preview.URL = rawURL
+	return preview, nil
+}
+
+// parse 从 HTML 头部解析 Open Graph、Twitter Card 与常规 meta 信息
+func parse(r io.Reader, base *url.URL) *Preview {
+	meta := make(map[string]string)
+	var title string
+	inTitle := false
+
+	z := html.NewTokenizer(r)
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tag, hasAttr := z.TagName()
+			switch string(tag) {
+			case "title":
+				inTitle = title == ""
+			case "meta":
+				var key, content string
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "property", "name":
+						key = strings.ToLower(string(v))
+					case "content":
+						content = string(v)
+					}
+				}
+				if key != "" && meta[key] == "" {
+					meta[key] = strings.TrimSpace(content)
+				}
+			case "body":
+				// 预览信息只存在于头部，无需继续解析正文
+				break loop
+			}
+		case html.TextToken:
+			if inTitle {
+				title += string(z.Text())
+			}
+		case html.EndTagToken:
+			if tag, _ := z.TagName(); string(tag) == "title" {
+				inTitle = false
+			} else if string(tag) == "head" {
+				break loop
+			}
+		}
+	}
+
+	preview := &Preview{
+		Title:       first(meta["og:title"], meta["twitter:title"], title),
+		Description: first(meta["og:description"], meta["twitter:description"], meta["description"]),
+		SiteName:    first(meta["og:site_name"], base.Hostname()),
+	}
+	preview.Title = clip(preview.Title)
+	preview.Description = clip(preview.Description)
+
+	if image := first(meta["og:image"], meta["twitter:image"]); image != "" {
+		if ref, err := url.Parse(image); err == nil {
+			if abs := base.ResolveReference(ref); abs.Scheme == "http" || abs.Scheme == "https" {
+				preview.Image = abs.String()
+			}
+		}
+	}
+	return preview
+}
+
+// first 返回第一个非空字符串
+func first(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// clip 合并空白并截断过长的文本
+func clip(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > maxTextLength {
+		return string(runes[:maxTextLength]) + "…"
+	}
+	return text
+}
+
+// hash 生成链接的缓存键
+func hash(rawURL string) string {
+	sum := sha1.Sum([]byte(rawURL))
+	return hex.EncodeToString(sum[:])
+}
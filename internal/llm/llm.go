@@ -0,0 +1,91 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/configs"
+)
+
+// 对话消息角色
+const (
+	RoleSystem = "system"
+	RoleUser   = "user"
+)
+
+// ErrDisabled 未开启大模型服务
+var ErrDisabled = errors.New("未开启大模型服务")
+
+// Message 对话消息
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Provider 大模型服务
+type Provider interface {
+	// Name 服务名称，对应配置中的 LLM_PROVIDER
+	Name() string
+	// Complete 根据对话消息生成回复
+	Complete(ctx context.Context, messages []Message) (string, error)
+}
+
+// Options 创建大模型服务所需的配置
+type Options struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	Timeout time.Duration
+}
+
+// Factory 大模型服务构造函数
+type Factory func(opts Options) (Provider, error)
+
+var factories = make(map[string]Factory)
+
+// Register 注册大模型服务
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// Names 已注册的大模型服务名称
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New 根据配置创建大模型服务，未开启时返回 ErrDisabled
+func New(config *configs.Config) (Provider, error) {
+	cfg := config.LLMConfig
+	if !cfg.LLMEnabled {
+		return nil, ErrDisabled
+	}
+
+	name := cfg.LLMProvider
+	if name == "" {
+		name = "openai"
+	}
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("不支持的大模型服务: %s，可选值: %s", name, strings.Join(Names(), ", "))
+	}
+
+	timeout := time.Duration(cfg.LLMTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return factory(Options{
+		BaseURL: cfg.LLMBaseURL,
+		APIKey:  cfg.LLMAPIKey,
+		Model:   cfg.LLMModel,
+		Timeout: timeout,
+	})
+}
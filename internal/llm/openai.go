@@ -0,0 +1,89 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register("openai", newOpenAI)
+}
+
+// openAI 兼容 OpenAI Chat Completions 协议的大模型服务
+type openAI struct {
+	opts   Options
+	client *http.Client
+}
+
+func newOpenAI(opts Options) (Provider, error) {
+	if opts.BaseURL == "" {
+		opts.BaseURL = "https://api.openai.com/v1"
+	}
+	if opts.Model == "" {
+		return nil, fmt.Errorf("未配置大模型 LLM_MODEL")
+	}
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+	return &openAI{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+func (p *openAI) Name() string {
+	return "openai"
+}
+
+func (p *openAI) Complete(ctx context.Context, messages []Message) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":       p.opts.Model,
+		"messages":    messages,
+		"temperature": 0.3,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.opts.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.opts.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("请求大模型服务失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("读取大模型响应失败: %v", err)
+	}
+
+	var result struct {
+		Choices []struct {
+			Message Message `json:"message"`
+		} `json:"choices"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return "", fmt.Errorf("解析大模型响应失败，状态码 %d: %v", resp.StatusCode, err)
+	}
+	if result.Error != nil {
+		return "", fmt.Errorf("大模型服务返回错误: %s", result.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("大模型服务响应状态异常: %d", resp.StatusCode)
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("大模型服务未返回结果")
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
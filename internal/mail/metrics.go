@@ -0,0 +1,25 @@
+package mail
+
+import (
+	"time"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+// 邮件发送方式
+const (
+	DeliverySync  = "sync"  // 接口内同步发送
+	DeliveryQueue = "queue" // 发送队列的后台任务发送
+)
+
+var deliveryDuration = global.Metrics.Histogram("jank_mail_delivery_seconds",
+	"SMTP 发送邮件的耗时（秒），按发送方式与结果区分", nil, "mode", "result")
+
+// ObserveDelivery 记录一次 SMTP 发送的耗时与结果
+func ObserveDelivery(mode string, elapsed time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	deliveryDuration.Observe(elapsed.Seconds(), mode, result)
+}
@@ -0,0 +1,204 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+// 邮件队列的 Redis 键
+const (
+	QueueKey      = "MAIL:QUEUE" // 待发送邮件，有序集合，分值为下次发送时间（毫秒）
+	DeadLetterKey = "MAIL:DEAD"  // 多次发送失败的邮件，列表，最新的在最前
+)
+
+const (
+	claimLease    = 2 * time.Minute // 领取后未确认的邮件在租约到期后重新投递
+	deadLetterMax = 1000            // 死信列表保留的最大条数
+)
+
+// ErrQueueUnavailable 邮件队列依赖 Redis，Redis 未连接时不可用
+var ErrQueueUnavailable = errors.New("邮件队列不可用")
+
+// Job 队列中的一封邮件
+type Job struct {
+	ID        string   `json:"id"`
+	To        []string `json:"to"`
+	Subject   string   `json:"subject"`
+	Text      string   `json:"text"`
+	HTML      string   `json:"html"`
+	Attempts  int      `json:"attempts"`             // 已失败的发送次数
+	LastError string   `json:"last_error,omitempty"` // 最近一次发送失败的原因
+	CreatedAt int64    `json:"created_at"`
+}
+
+// Sender 实际发送邮件的函数
+type Sender func(subject, text, html string, to []string) error
+
+// QueueOptions 队列处理配置，零值字段使用默认值
+type QueueOptions struct {
+	BatchSize   int           // 每次领取的最大邮件数
+	MaxAttempts int           // 最大发送次数，达到后移入死信列表
+	BackoffBase time.Duration // 首次重试的等待时间，之后每次翻倍
+	BackoffMax  time.Duration // 重试等待时间上限
+}
+
+// claimScript 领取已到发送时间的邮件，并将其分值推迟到租约到期时间
+var claimScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[2]))
+for _, member in ipairs(due) do
+	redis.call('ZADD', KEYS[1], tonumber(ARGV[3]), member)
+end
+return due
+`)
+
+// Enqueue 将邮件加入发送队列，立即可被领取
+func Enqueue(ctx context.Context, msg *Message, to []string) error {
+	if global.RedisClient == nil {
+		return ErrQueueUnavailable
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	job := Job{
+		ID:        hex.EncodeToString(id),
+		To:        to,
+		Subject:   msg.Subject,
+		Text:      msg.Text,
+		HTML:      msg.HTML,
+		CreatedAt: time.Now().Unix(),
+	}
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return global.RedisClient.ZAdd(ctx, QueueKey, redis.Z{Score: float64(time.Now().UnixMilli()), Member: payload}).Err()
+}
+
+// ProcessQueue 发送已到发送时间的邮件，失败的邮件按指数退避重新排队，达到最大次数后移入死信列表
+// 返回发送成功与移入死信列表的邮件数
+func ProcessQueue(ctx context.Context, send Sender, opts QueueOptions) (sent, dead int, err error) {
+	if global.RedisClient == nil {
+		return 0, 0, ErrQueueUnavailable
+	}
+
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.BackoffBase <= 0 {
+		opts.BackoffBase = 10 * time.Second
+	}
+	if opts.BackoffMax < opts.BackoffBase {
+		opts.BackoffMax = max(10*time.Minute, opts.BackoffBase)
+	}
+
+	now := time.Now()
+	members, err := claimScript.Run(ctx, global.RedisClient, []string{QueueKey},
+		now.UnixMilli(), max(opts.BatchSize, 1), now.Add(claimLease).UnixMilli()).StringSlice()
+	if err != nil {
+		return 0, 0, fmt.Errorf("领取待发送邮件失败: %v", err)
+	}
+
+	for _, member := range members {
+		var job Job
+		if err := json.Unmarshal([]byte(member), &job); err != nil {
+			// 无法解析的邮件无法重试，直接移入死信列表
+			global.SysLog.Errorf("解析待发送邮件失败: %v", err)
+			if err := moveToDeadLetter(ctx, member, member); err != nil {
+				return sent, dead, err
+			}
+			dead++
+			continue
+		}
+
+		sendErr := send(job.Subject, job.Text, job.HTML, job.To)
+		if sendErr == nil {
+			if err := global.RedisClient.ZRem(ctx, QueueKey, member).Err(); err != nil {
+				return sent, dead, fmt.Errorf("移除已发送邮件失败: %v", err)
+			}
+			sent++
+			continue
+		}
+
+		job.Attempts++
+		job.LastError = sendErr.Error()
+		payload, err := json.Marshal(job)
+		if err != nil {
+			return sent, dead, err
+		}
+
+		if job.Attempts >= opts.MaxAttempts {
+			global.SysLog.Errorf("邮件 %s 发送 %d 次均失败，已移入死信列表，收件人: %v, 错误: %v", job.ID, job.Attempts, job.To, sendErr)
+			if err := moveToDeadLetter(ctx, member, string(payload)); err != nil {
+				return sent, dead, err
+			}
+			dead++
+			continue
+		}
+
+		retryAt := time.Now().Add(backoff(job.Attempts, opts))
+		global.SysLog.Warnf("邮件 %s 第 %d 次发送失败，将于 %s 重试，错误: %v", job.ID, job.Attempts, retryAt.Format(time.DateTime), sendErr)
+		_, err = global.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZRem(ctx, QueueKey, member)
+			pipe.ZAdd(ctx, QueueKey, redis.Z{Score: float64(retryAt.UnixMilli()), Member: payload})
+			return nil
+		})
+		if err != nil {
+			return sent, dead, fmt.Errorf("重新排队邮件失败: %v", err)
+		}
+	}
+	return sent, dead, nil
+}
+
+// DeadLetters 获取最近移入死信列表的邮件
+func DeadLetters(ctx context.Context, limit int64) ([]Job, error) {
+	if global.RedisClient == nil {
+		return nil, ErrQueueUnavailable
+	}
+
+	members, err := global.RedisClient.LRange(ctx, DeadLetterKey, 0, limit-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]Job, 0, len(members))
+	for _, member := range members {
+		var job Job
+		if err := json.Unmarshal([]byte(member), &job); err == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+// moveToDeadLetter 将邮件从队列移入死信列表
+func moveToDeadLetter(ctx context.Context, member, payload string) error {
+	_, err := global.RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, QueueKey, member)
+		pipe.LPush(ctx, DeadLetterKey, payload)
+		pipe.LTrim(ctx, DeadLetterKey, 0, deadLetterMax-1)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("邮件移入死信列表失败: %v", err)
+	}
+	return nil
+}
+
+// backoff 第 attempts 次失败后的重试等待时间
+func backoff(attempts int, opts QueueOptions) time.Duration {
+	wait := opts.BackoffBase
+	for i := 1; i < attempts && wait < opts.BackoffMax; i++ {
+		wait *= 2
+	}
+	return min(wait, opts.BackoffMax)
+}
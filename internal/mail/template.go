@@ -0,0 +1,201 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"io/fs"
+	"os"
+	"strings"
+	textTemplate "text/template"
+
+	"jank.com/jank_blog/internal/i18n"
+)
+
+//go:embed templates/*
+var defaultTemplates embed.FS
+
+// 内置的邮件模板名称
+const (
+	TemplateVerificationCode    = "verification_code"    // 验证码邮件
+	TemplatePasswordReset       = "password_reset"       // 重置密码链接邮件
+	TemplateMagicLink           = "magic_link"           // 免密登录链接邮件
+	TemplateAccountUnlock       = "account_unlock"       // 账户锁定与解锁链接邮件
+	TemplateLoginAlert          = "login_alert"          // 新设备登录提醒邮件
+	TemplateAccountDeletion     = "account_deletion"     // 注销账户确认邮件
+	TemplateDataExport          = "data_export"          // 个人数据导出完成邮件
+	TemplateRegistrationPending = "registration_pending" // 新注册待审核通知邮件
+	TemplateRegistrationReview  = "registration_review"  // 注册审核结果邮件
+	TemplateSecurityNotice      = "security_notice"      // 账户安全提醒邮件
+)
+
+// Message 渲染后的邮件
+type Message struct {
+	Subject string // 邮件主题，模板未定义时为空
+	Text    string // 纯文本正文
+	HTML    string // HTML 正文
+}
+
+// VerificationCodeData 验证码邮件模板中可用的变量
+type VerificationCodeData struct {
+	SiteName      string // 站点名称
+	SiteURL       string // 站点地址
+	Code          string // 验证码
+	ExpireMinutes int    // 有效期（分钟）
+	Locale        string // 邮件语言，如 zh-CN、en-US
+}
+
+// PasswordResetData 重置密码链接邮件模板中可用的变量
+type PasswordResetData struct {
+	SiteName      string // 站点名称
+	SiteURL       string // 站点地址
+	ResetURL      string // 重置密码链接
+	ExpireMinutes int    // 有效期（分钟）
+	Locale        string // 邮件语言，如 zh-CN、en-US
+}
+
+// MagicLinkData 免密登录链接邮件模板中可用的变量
+type MagicLinkData struct {
+	SiteName      string // 站点名称
+	SiteURL       string // 站点地址
+	LoginURL      string // 登录链接
+	ExpireMinutes int    // 有效期（分钟）
+	Device        string // 发起登录请求的设备
+	IP            string // 发起登录请求的 IP
+	Locale        string // 邮件语言，如 zh-CN、en-US
+}
+
+// AccountUnlockData 账户锁定与解锁链接邮件模板中可用的变量
+type AccountUnlockData struct {
+	SiteName    string // 站点名称
+	SiteURL     string // 站点地址
+	UnlockURL   string // 解锁链接
+	LockMinutes int    // 锁定时长（分钟），解锁链接同时过期
+	IP          string // 最近一次登录失败的 IP
+	Locale      string // 邮件语言，如 zh-CN、en-US
+}
+
+// LoginAlertData 新设备登录提醒邮件模板中可用的变量
+type LoginAlertData struct {
+	SiteName string // 站点名称
+	SiteURL  string // 站点地址
+	Time     string // 登录时间
+	Device   string // 登录设备
+	IP       string // 登录 IP
+	Location string // IP 对应的地理位置，未知时为空
+	Locale   string // 邮件语言，如 zh-CN、en-US
+}
+
+// AccountDeletionData 注销账户确认邮件模板中可用的变量
+type AccountDeletionData struct {
+	SiteName   string // 站点名称
+	SiteURL    string // 站点地址
+	DeleteDate string // 永久删除账户的时间
+	GraceDays  int    // 冷静期天数
+	Locale     string // 邮件语言，如 zh-CN、en-US
+}
+
+// DataExportData 个人数据导出完成邮件模板中可用的变量
+type DataExportData struct {
+	SiteName    string // 站点名称
+	SiteURL     string // 站点地址
+	DownloadURL string // 导出文件的下载链接
+	ExpireHours int    // 下载链接的有效期（小时）
+	Locale      string // 邮件语言，如 zh-CN、en-US
+}
+
+// RegistrationPendingData 新注册待审核通知邮件模板中可用的变量
+type RegistrationPendingData struct {
+	SiteName string // 站点名称
+	SiteURL  string // 站点地址
+	Email    string // 注册用户的邮箱
+	Nickname string // 注册用户的昵称
+	Time     string // 注册时间
+	Pending  int64  // 待审核的注册申请数量，包含本次注册
+	Locale   string // 邮件语言，如 zh-CN、en-US
+}
+
+// RegistrationReviewData 注册审核结果邮件模板中可用的变量
+type RegistrationReviewData struct {
+	SiteName string // 站点名称
+	SiteURL  string // 站点地址
+	Approved bool   // 是否通过审核
+	Reason   string // 拒绝原因，通过审核或未填写时为空
+	Locale   string // 邮件语言，如 zh-CN、en-US
+}
+
+// SecurityNoticeData 账户安全提醒邮件模板中可用的变量
+type SecurityNoticeData struct {
+	SiteName string // 站点名称
+	SiteURL  string // 站点地址
+	Event    string // 安全事件，如 password_changed、password_admin_reset、email_changed、totp_disabled
+	Time     string // 事件发生的时间
+	Device   string // 发起操作的设备，管理员操作时为空
+	IP       string // 发起操作的 IP，管理员操作时为空
+	Email    string // 修改后的邮箱，仅 email_changed 事件有值
+	Locale   string // 邮件语言，如 zh-CN、en-US
+}
+
+// Render 按语言渲染邮件模板，templateDir 中缺失的模板回退到内置模板
+// 模板中可通过 {{t "消息键" 参数...}} 引用 locale 对应的国际化文案
+func Render(name, locale string, data interface{}, templateDir string) (*Message, error) {
+	var custom fs.FS
+	if templateDir != "" {
+		custom = os.DirFS(templateDir)
+	}
+	builtin, _ := fs.Sub(defaultTemplates, "templates")
+
+	read := func(file string) (string, error) {
+		if custom != nil {
+			if content, err := fs.ReadFile(custom, file); err == nil {
+				return string(content), nil
+			}
+		}
+		content, err := fs.ReadFile(builtin, file)
+		if err != nil {
+			return "", fmt.Errorf("读取邮件模板 %s 失败: %v", file, err)
+		}
+		return string(content), nil
+	}
+
+	textContent, err := read(name + ".txt")
+	if err != nil {
+		return nil, err
+	}
+	htmlContent, err := read(name + ".html")
+	if err != nil {
+		return nil, err
+	}
+
+	translate := func(key string, args ...interface{}) string { return i18n.T(locale, key, args...) }
+
+	textTpl, err := textTemplate.New(name).Funcs(textTemplate.FuncMap{"t": translate}).Parse(textContent)
+	if err != nil {
+		return nil, fmt.Errorf("解析邮件模板 %s.txt 失败: %v", name, err)
+	}
+	htmlTpl, err := htmlTemplate.New(name).Funcs(htmlTemplate.FuncMap{"t": translate}).Parse(htmlContent)
+	if err != nil {
+		return nil, fmt.Errorf("解析邮件模板 %s.html 失败: %v", name, err)
+	}
+
+	msg := &Message{}
+	var buf bytes.Buffer
+	if textTpl.Lookup("subject") != nil {
+		if err := textTpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+			return nil, fmt.Errorf("渲染邮件主题失败: %v", err)
+		}
+		msg.Subject = strings.TrimSpace(buf.String())
+		buf.Reset()
+	}
+	if err := textTpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("渲染邮件模板 %s.txt 失败: %v", name, err)
+	}
+	msg.Text = strings.TrimSpace(buf.String())
+	buf.Reset()
+	if err := htmlTpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("渲染邮件模板 %s.html 失败: %v", name, err)
+	}
+	msg.HTML = buf.String()
+	return msg, nil
+}
@@ -0,0 +1,106 @@
+package messenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/configs"
+)
+
+// 内置的即时通讯服务名称
+const (
+	ProviderTelegram = "telegram" // Telegram 机器人
+	ProviderWhatsApp = "whatsapp" // WhatsApp Business Cloud API
+)
+
+var (
+	// ErrDisabled 未配置该即时通讯服务
+	ErrDisabled = errors.New("未开启该即时通讯服务")
+	// ErrInvalidHandle 账号格式无效
+	ErrInvalidHandle = errors.New("即时通讯账号格式无效")
+)
+
+// Sender 即时通讯服务
+type Sender interface {
+	// Name 服务名称
+	Name() string
+	// SendCode 向绑定的账号发送验证码，ttl 为验证码有效期
+	SendCode(ctx context.Context, handle, code string, ttl time.Duration) error
+}
+
+// Options 创建即时通讯服务所需的配置
+type Options struct {
+	TelegramBotToken      string // Telegram 机器人令牌
+	WhatsAppToken         string // WhatsApp Business 访问令牌
+	WhatsAppPhoneNumberID string // WhatsApp Business 发送号码 ID
+	WhatsAppTemplate      string // WhatsApp 消息模板名称，模板正文的第一个变量为验证码
+	WhatsAppLanguage      string // WhatsApp 消息模板语言
+	Timeout               time.Duration
+}
+
+// Factory 即时通讯服务构造函数，未配置该服务时返回 ErrDisabled
+type Factory func(opts Options) (Sender, error)
+
+var factories = make(map[string]Factory)
+
+// handlePatterns 各服务的账号格式：Telegram 为与机器人对话的 chat ID，WhatsApp 为 E.164 格式的手机号
+var handlePatterns = map[string]*regexp.Regexp{
+	ProviderTelegram: regexp.MustCompile(`^-?[0-9]{1,20}$`),
+	ProviderWhatsApp: regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`),
+}
+
+// Register 注册即时通讯服务
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// Names 已注册的即时通讯服务名称
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New 根据配置创建指定的即时通讯服务
+func New(config *configs.Config, provider string) (Sender, error) {
+	factory, ok := factories[provider]
+	if !ok {
+		return nil, fmt.Errorf("不支持的即时通讯服务: %s，可选值: %s", provider, strings.Join(Names(), ", "))
+	}
+
+	cfg := config.MessengerConfig
+	timeout := time.Duration(cfg.MessengerTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return factory(Options{
+		TelegramBotToken:      cfg.MessengerTelegramBotToken,
+		WhatsAppToken:         cfg.MessengerWhatsAppToken,
+		WhatsAppPhoneNumberID: cfg.MessengerWhatsAppPhoneNumberID,
+		WhatsAppTemplate:      cfg.MessengerWhatsAppTemplate,
+		WhatsAppLanguage:      cfg.MessengerWhatsAppLanguage,
+		Timeout:               timeout,
+	})
+}
+
+// ValidHandle 检查账号格式是否符合服务要求
+func ValidHandle(provider, handle string) error {
+	pattern, ok := handlePatterns[provider]
+	if !ok || !pattern.MatchString(handle) {
+		return ErrInvalidHandle
+	}
+	return nil
+}
+
+// codeMessage 验证码消息正文
+func codeMessage(code string, ttl time.Duration) string {
+	return fmt.Sprintf("您的验证码是: %s , 有效期为 %d 分钟。", code, int(ttl.Round(time.Minute).Minutes()))
+}
@@ -0,0 +1,90 @@
+package messenger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const whatsAppEndpoint = "https://graph.facebook.com/v19.0/%s/messages"
+
+func init() {
+	Register(ProviderWhatsApp, newWhatsApp)
+}
+
+// whatsApp WhatsApp Business Cloud API，配置模板时发送模板消息，否则发送文本消息
+// 文本消息仅能发送给 24 小时内与企业号码对话过的用户，生产环境应配置已审核的验证码模板
+type whatsApp struct {
+	opts   Options
+	client *http.Client
+}
+
+func newWhatsApp(opts Options) (Sender, error) {
+	if opts.WhatsAppToken == "" || opts.WhatsAppPhoneNumberID == "" {
+		return nil, ErrDisabled
+	}
+	if opts.WhatsAppLanguage == "" {
+		opts.WhatsAppLanguage = "zh_CN"
+	}
+	return &whatsApp{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+func (s *whatsApp) Name() string {
+	return ProviderWhatsApp
+}
+
+func (s *whatsApp) SendCode(ctx context.Context, handle, code string, ttl time.Duration) error {
+	message := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"to":                strings.TrimPrefix(handle, "+"),
+	}
+	if s.opts.WhatsAppTemplate != "" {
+		message["type"] = "template"
+		message["template"] = map[string]interface{}{
+			"name":     s.opts.WhatsAppTemplate,
+			"language": map[string]string{"code": s.opts.WhatsAppLanguage},
+			"components": []map[string]interface{}{{
+				"type":       "body",
+				"parameters": []map[string]string{{"type": "text", "text": code}},
+			}},
+		}
+	} else {
+		message["type"] = "text"
+		message["text"] = map[string]string{"body": codeMessage(code, ttl)}
+	}
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf(whatsAppEndpoint, url.PathEscape(s.opts.WhatsAppPhoneNumberID)), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.opts.WhatsAppToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求 WhatsApp 失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var result struct {
+			Error struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return fmt.Errorf("WhatsApp 消息发送失败: 状态码 %d, %d %s", resp.StatusCode, result.Error.Code, result.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package accessLogMiddleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/utils"
+)
+
+const redacted = "***" // 脱敏后的占位值
+
+// redactHeaders 始终脱敏的请求头
+var redactHeaders = []string{"Authorization", "Refresh_Token", "Cookie", echo.HeaderXCSRFToken}
+
+// accessLogConfig 请求响应日志配置
+type accessLogConfig struct {
+	SampleRate   float64  // 采样率
+	MaxBodySize  int      // 请求体与响应体的最大记录字节数
+	Routes       []string // 记录的路由前缀，留空记录全部
+	SkipRoutes   []string // 不记录的路由前缀
+	RedactFields []string // 需要脱敏的字段名片段，不区分大小写
+}
+
+// InitAccessLog 初始化请求响应日志中间件，未开启时不做任何处理
+func InitAccessLog() echo.MiddlewareFunc {
+	cfg, err := configs.LoadConfig()
+	if err != nil || !cfg.AccessLogConfig.AccessLogEnabled {
+		return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+	}
+
+	config := accessLogConfig{
+		SampleRate:  cfg.AccessLogConfig.AccessLogSampleRate,
+		MaxBodySize: cfg.AccessLogConfig.AccessLogMaxBodySize,
+		Routes:      cfg.AccessLogConfig.AccessLogRoutes,
+		SkipRoutes:  cfg.AccessLogConfig.AccessLogSkipRoutes,
+	}
+	for _, field := range cfg.AccessLogConfig.AccessLogRedactFields {
+		config.RedactFields = append(config.RedactFields, strings.ToLower(field))
+	}
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = 4096
+	}
+
+	global.SysLog.Infof("请求响应日志已开启，采样率: %.2f", config.SampleRate)
+	return accessLogWithConfig(config)
+}
+
+// accessLogWithConfig 使用传入的配置生成请求响应日志中间件
+func accessLogWithConfig(config accessLogConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !config.match(c.Request().URL.Path) || rand.Float64() >= config.SampleRate {
+				return next(c)
+			}
+
+			req := c.Request()
+			reqBody := config.readRequestBody(req)
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer, limit: config.MaxBodySize}
+			c.Response().Writer = recorder
+
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// 交由全局错误处理写入响应，以便记录最终的状态码与响应体
+				c.Error(err)
+			}
+
+			headers := make(map[string]string, len(req.Header))
+			for key := range req.Header {
+				headers[key] = req.Header.Get(key)
+			}
+			for _, key := range redactHeaders {
+				if _, ok := headers[http.CanonicalHeaderKey(key)]; ok {
+					headers[http.CanonicalHeaderKey(key)] = redacted
+				}
+			}
+
+			utils.BizLogger(c).WithFields(logrus.Fields{
+				"method":       req.Method,
+				"path":         req.URL.Path,
+				"query":        config.redactQuery(req.URL.RawQuery),
+				"route":        c.Path(),
+				"status":       c.Response().Status,
+				"latency":      time.Since(start).String(),
+				"headers":      headers,
+				"requestBody":  reqBody,
+				"responseBody": config.redactBody(recorder.Header().Get(echo.HeaderContentType), recorder.body.Bytes(), recorder.truncated),
+			}).Info("请求响应日志")
+			return nil
+		}
+	}
+}
+
+// match 判断路径是否需要记录
+func (config accessLogConfig) match(path string) bool {
+	for _, prefix := range config.SkipRoutes {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	if len(config.Routes) == 0 {
+		return true
+	}
+	for _, prefix := range config.Routes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readRequestBody 读取请求体并还原，供后续处理器继续读取
+func (config accessLogConfig) readRequestBody(req *http.Request) string {
+	contentType := req.Header.Get(echo.HeaderContentType)
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if strings.HasPrefix(contentType, echo.MIMEMultipartForm) {
+		return "[multipart 内容已省略]"
+	}
+
+	content, err := io.ReadAll(req.Body)
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	if err != nil {
+		return "[读取请求体失败]"
+	}
+
+	truncated := len(content) > config.MaxBodySize
+	if truncated {
+		content = content[:config.MaxBodySize]
+	}
+	return config.redactBody(contentType, content, truncated)
+}
+
+// redactBody 按内容类型对请求体或响应体脱敏
+func (config accessLogConfig) redactBody(contentType string, content []byte, truncated bool) string {
+	if len(content) == 0 {
+		return ""
+	}
+
+	var body string
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEApplicationJSON):
+		var data interface{}
+		if err := json.Unmarshal(content, &data); err != nil {
+			// 截断或格式错误的 JSON 无法可靠脱敏，不记录原文
+			return "[JSON 内容无法解析，已省略]"
+		}
+		redactedContent, _ := json.Marshal(config.redactValue(data))
+		body = string(redactedContent)
+	case strings.HasPrefix(contentType, echo.MIMEApplicationForm):
+		body = config.redactQuery(string(content))
+	case strings.HasPrefix(contentType, "text/"):
+		body = string(content)
+	default:
+		return "[非文本内容已省略]"
+	}
+
+	if truncated {
+		body += "...[已截断]"
+	}
+	return body
+}
+
+// redactValue 递归脱敏 JSON 中的敏感字段
+func (config accessLogConfig) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if config.sensitive(key) {
+				v[key] = redacted
+			} else {
+				v[key] = config.redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = config.redactValue(item)
+		}
+	}
+	return value
+}
+
+// redactQuery 脱敏查询字符串或表单中的敏感字段
+func (config accessLogConfig) redactQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return "[参数无法解析，已省略]"
+	}
+	for key := range values {
+		if config.sensitive(key) {
+			values[key] = []string{redacted}
+		}
+	}
+	encoded := values.Encode()
+	if decoded, err := url.QueryUnescape(encoded); err == nil {
+		return decoded
+	}
+	return encoded
+}
+
+// sensitive 判断字段是否需要脱敏
+func (config accessLogConfig) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range config.RedactFields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyRecorder 记录响应体的前 limit 个字节
+type bodyRecorder struct {
+	http.ResponseWriter
+	body      bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	if remain := r.limit - r.body.Len(); remain > 0 {
+		if len(b) > remain {
+			r.body.Write(b[:remain])
+			r.truncated = true
+		} else {
+			r.body.Write(b)
+		}
+	} else if len(b) > 0 {
+		r.truncated = true
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *bodyRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *bodyRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := r.ResponseWriter.(http.Hijacker); ok {
+		return hijacker.Hijack()
+	}
+	return nil, nil, errors.New("响应不支持 Hijack")
+}
@@ -0,0 +1,44 @@
+package authMiddleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/ipaccess"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// AdminMiddleware 校验当前用户是否为管理员，需在 AuthMiddleware 之后使用
+// 来源 IP 不符合管理接口的访问规则时拒绝访问；管理员角色要求开启两步验证时，未开启的管理员不能访问；使用 API Key 认证时需为 admin 权限范围
+func AdminMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			roleID, ok := c.Get(ContextRoleID).(int64)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "未登录，请先登录")
+			}
+
+			if err := ipaccess.Check(c.Request().Context(), c.RealIP()); err != nil {
+				global.SysLog.Warnf("拒绝来自 %s 的管理接口请求 %s: %v", c.RealIP(), c.Path(), err)
+				return bizErr.New(bizErr.AdminIPDenied)
+			}
+
+			role, err := mapper.GetRoleByID(roleID)
+			if err != nil || role.Code != model.RoleCodeAdmin {
+				return echo.NewHTTPError(http.StatusForbidden, "权限不足，仅管理员可访问")
+			}
+			if scope, ok := c.Get(ContextAPIKeyScope).(string); ok && scope != model.APIKeyScopeAdmin {
+				return bizErr.New(bizErr.APIKeyScopeDenied)
+			}
+			if err := checkTotpPolicy(c.Get(ContextAccountID).(int64), roleID); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package authMiddleware
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	bizErr "jank.com/jank_blog/internal/error"
 	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/session"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/mapper"
 )
@@ -16,7 +21,6 @@ type JWTConfig struct {
 	Authorization string
 	TokenPrefix   string
 	RefreshToken  string
-	UserCache     string
 }
 
 // DefaultJWTConfig 默认配置
@@ -24,9 +28,15 @@ var DefaultJWTConfig = JWTConfig{
 	Authorization: "Authorization",
 	TokenPrefix:   "Bearer ",
 	RefreshToken:  "Refresh_Token",
-	UserCache:     "User_Cache",
 }
 
+// 认证通过后写入上下文的键
+const (
+	ContextAccountID = "auth_account_id" // 当前用户 ID
+	ContextRoleID    = "auth_role_id"    // 当前角色 ID
+	ContextSessionID = "auth_session_id" // 当前会话 ID，使用 API Key 认证时为空
+)
+
 // RBACConfig 定义了权限缓存前缀的配置
 type RBACConfig struct {
 	CachePrefix string
@@ -37,7 +47,29 @@ var DefaultRBACConfig = RBACConfig{
 	CachePrefix: "RBAC_Permission",
 }
 
-// AuthMiddleware 处理 JWT 认证和权限校验
+// checkAccountBan 账户处于停用状态时吊销其全部 refresh token 与设备令牌并结束全部会话，返回 AccountDisabled 错误码；
+// 停用时通常已结束会话，此处兜底处理停用后仍未失效的登录状态
+func checkAccountBan(accountID int64) error {
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "用户不存在，请重新登录")
+	}
+	if !acc.Disabled(time.Now().Unix()) {
+		return nil
+	}
+	if err := utils.RevokeAllRefreshTokens(accountID); err != nil {
+		global.BizLog.Errorf("%v", err)
+	}
+	if _, err := session.EndAll(accountID, "", model.SessionEndDisabled); err != nil {
+		global.BizLog.Errorf("结束会话失败: %v", err)
+	}
+	if _, err := mapper.DeleteDeviceTokensByAccountID(accountID, ""); err != nil {
+		global.BizLog.Errorf("%v", err)
+	}
+	return bizErr.New(bizErr.AccountDisabled)
+}
+
+// AuthMiddleware 处理 JWT 认证和权限校验，Authorization 请求头以 ApiKey 开头时使用个人 API Key 认证
 // requiredPermissionIDs 参数 :
 //   - 若传入权限 ID，则在 JWT 认证通过后，校验当前角色是否拥有【至少一个】对应权限；
 //   - 若未传入权限 ID，则仅进行 JWT 认证，不校验权限。
@@ -49,9 +81,21 @@ func AuthMiddleware(requiredPermissionIDs ...int64) echo.MiddlewareFunc {
 			if authHeader == "" {
 				return echo.NewHTTPError(http.StatusUnauthorized, "缺少 Authorization 请求头")
 			}
+
+			// 使用 API Key 认证时换成 Key 所属用户的 Access Token，下游业务统一从 Authorization 请求头解析当前用户
+			viaAPIKey := false
+			if key, ok := strings.CutPrefix(authHeader, APIKeyScheme); ok {
+				token, err := authenticateAPIKey(c, key)
+				if err != nil {
+					return err
+				}
+				authHeader, viaAPIKey = DefaultJWTConfig.TokenPrefix+token, true
+				c.Request().Header.Set(DefaultJWTConfig.Authorization, authHeader)
+			}
 			tokenString := strings.TrimPrefix(authHeader, DefaultJWTConfig.TokenPrefix)
 
 			// 验证 JWT Token；若验证失败则尝试使用 Refresh Token 刷新
+			refreshed := false
 			_, err := utils.ValidateJWTToken(tokenString, false)
 			if err != nil {
 				refreshHeader := c.Request().Header.Get(DefaultJWTConfig.RefreshToken)
@@ -65,7 +109,7 @@ func AuthMiddleware(requiredPermissionIDs ...int64) echo.MiddlewareFunc {
 				}
 				c.Response().Header().Set(DefaultJWTConfig.Authorization, DefaultJWTConfig.TokenPrefix+newTokens["accessToken"])
 				c.Response().Header().Set(DefaultJWTConfig.RefreshToken, DefaultJWTConfig.TokenPrefix+newTokens["refreshToken"])
-				tokenString = newTokens["accessToken"]
+				tokenString, refreshed = newTokens["accessToken"], true
 			}
 
 			// 从 Token 中解析 accountID 和 roleID
@@ -74,26 +118,56 @@ func AuthMiddleware(requiredPermissionIDs ...int64) echo.MiddlewareFunc {
 				return echo.NewHTTPError(http.StatusUnauthorized, "无效的 Access Token，请重新登录")
 			}
 
+			sessionID := utils.ParseSessionIDFromJWT(tokenString)
+
 			//  验证数据库中的用户和角色是否匹配
 			accountRole, err := mapper.GetRoleByAccountID(accountID)
 			if err != nil || accountRole.RoleID != roleID {
-				userCacheKey := fmt.Sprintf("%s:%d:%d", DefaultJWTConfig.UserCache, accountID, roleID)
-				if delErr := global.RedisClient.Del(c.Request().Context(), userCacheKey).Err(); delErr != nil {
-					global.BizLog.Errorf("删除用户缓存失败 [%s]: %v", userCacheKey, delErr)
+				if sessionID != "" {
+					if _, endErr := session.End(accountID, sessionID, model.SessionEndRoleChanged); endErr != nil {
+						global.BizLog.Errorf("结束会话失败 [%s]: %v", sessionID, endErr)
+					}
 				}
 				return echo.NewHTTPError(http.StatusUnauthorized, "用户角色发生变更，请重新登录")
 			}
 
-			sessionCacheKey := fmt.Sprintf("%s:%d:%d", DefaultJWTConfig.UserCache, accountID, roleID)
-			if sessionVal, err := global.RedisClient.Get(c.Request().Context(), sessionCacheKey).Result(); err != nil || sessionVal == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "无效会话，请重新登录")
+			// API Key 不依赖登录会话，所属账户的停用状态已在换取 token 时校验
+			if !viaAPIKey {
+				if err := checkAccountBan(accountID); err != nil {
+					return err
+				}
+				state, err := session.Get(sessionID)
+				if err != nil || state.AccountID != accountID {
+					return echo.NewHTTPError(http.StatusUnauthorized, "无效会话，请重新登录")
+				}
+				if err := session.CheckSingleActive(state); errors.Is(err, session.ErrSuperseded) {
+					return bizErr.New(bizErr.SessionSuperseded)
+				} else if err != nil {
+					global.BizLog.Errorf("校验单会话限制失败 [%s]: %v", sessionID, err)
+				}
+				if refreshed {
+					if err := session.Renew(state); err != nil {
+						global.BizLog.Errorf("顺延会话失败 [%s]: %v", sessionID, err)
+					}
+				}
+				if err := session.Touch(state, c.RealIP()); err != nil {
+					global.BizLog.Errorf("更新会话访问记录失败 [%s]: %v", sessionID, err)
+				}
 			}
+			c.Set(ContextAccountID, accountID)
+			c.Set(ContextRoleID, roleID)
+			c.Set(ContextSessionID, sessionID)
 
 			// 如果未传入权限 ID，则仅进行 JWT 认证
 			if len(requiredPermissionIDs) == 0 {
 				return next(c)
 			}
 
+			// 角色要求开启两步验证时，未开启的账户不能访问需要权限的接口
+			if err := checkTotpPolicy(accountID, roleID); err != nil {
+				return err
+			}
+
 			// 校验当前角色是否拥有【至少一个】对应权限
 			rolePermissions, err := mapper.GetPermissionsByRoleID(fmt.Sprint(roleID))
 			if err != nil {
@@ -0,0 +1,59 @@
+package authMiddleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// contextOptionalAccountID 公开接口解析出的可选登录用户，同一请求只解析一次
+const contextOptionalAccountID = "auth_optional_account_id"
+
+// OptionalAccountID 获取公开接口的可选登录用户，未登录或登录状态无效时返回 0
+// 已经过 AuthMiddleware 认证的请求直接返回当前用户；否则按 AuthMiddleware 的规则校验 access token、角色、账户停用状态与登录会话，
+// 不使用 Refresh Token 刷新，也不记录会话访问；使用 API Key 的请求视为未登录
+func OptionalAccountID(c echo.Context) int64 {
+	if accountID, ok := c.Get(ContextAccountID).(int64); ok {
+		return accountID
+	}
+	if accountID, ok := c.Get(contextOptionalAccountID).(int64); ok {
+		return accountID
+	}
+	accountID := resolveOptionalAccount(c)
+	c.Set(contextOptionalAccountID, accountID)
+	return accountID
+}
+
+// resolveOptionalAccount 校验请求携带的 access token 及其登录会话，任一校验未通过时返回 0
+func resolveOptionalAccount(c echo.Context) int64 {
+	authHeader := c.Request().Header.Get(DefaultJWTConfig.Authorization)
+	if authHeader == "" || strings.HasPrefix(authHeader, APIKeyScheme) {
+		return 0
+	}
+	tokenString := strings.TrimPrefix(authHeader, DefaultJWTConfig.TokenPrefix)
+	accountID, roleID, err := utils.ParseAccountAndRoleIDFromJWT(tokenString)
+	if err != nil {
+		return 0
+	}
+
+	accountRole, err := mapper.GetRoleByAccountID(accountID)
+	if err != nil || accountRole.RoleID != roleID {
+		return 0
+	}
+	if err := checkAccountBan(accountID); err != nil {
+		return 0
+	}
+	state, err := session.Get(utils.ParseSessionIDFromJWT(tokenString))
+	if err != nil || state.AccountID != accountID {
+		return 0
+	}
+	if err := session.CheckSingleActive(state); errors.Is(err, session.ErrSuperseded) {
+		return 0
+	}
+	return accountID
+}
@@ -0,0 +1,37 @@
+package authMiddleware
+
+import (
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// checkTotpPolicy 校验角色的两步验证要求，角色在 TOTP_REQUIRED_ROLES 中且账户未开启两步验证时拒绝访问
+// 未配置要求两步验证的角色时不查询数据库
+func checkTotpPolicy(accountID, roleID int64) error {
+	config, err := configs.LoadConfig()
+	if err != nil || len(config.TotpConfig.TotpRequiredRoles) == 0 {
+		return nil
+	}
+
+	role, err := mapper.GetRoleByID(roleID)
+	if err != nil {
+		return nil
+	}
+	required := false
+	for _, code := range config.TotpConfig.TotpRequiredRoles {
+		if code == role.Code {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return nil
+	}
+
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil || !acc.TotpEnabled {
+		return bizErr.New(bizErr.TotpSetupRequired)
+	}
+	return nil
+}
@@ -17,16 +17,18 @@ type corsConfig struct {
 	AllowedOrigins   []string // 允许的源
 	AllowedMethods   []string // 允许的方法
 	AllowedHeaders   []string // 允许的头部
+	ExposedHeaders   []string // 允许前端读取的响应头
 	AllowCredentials bool     // 是否允许携带证书
 }
 
 // DefaultCORSConfig 提供了默认的 CORS 配置
 func defaultCORSConfig() corsConfig {
 	return corsConfig{
-		AllowedOrigins:   []string{"*"},                                                                                                   // 默认允许所有域名
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},                                                             // 默认允许的请求方法
-		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Client-Info", "X-Client-Version", "X-Client-Data", "X-Request-Id"}, // 默认允许的请求头
-		AllowCredentials: false,                                                                                                           // 默认不允许携带证书
+		AllowedOrigins:   []string{"*"},                                                                                                                          // 默认允许所有域名
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},                                                                                    // 默认允许的请求方法
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Client-Info", "X-Client-Version", "X-Client-Data", "X-Request-Id", "X-Post-Access-Token"}, // 默认允许的请求头
+		ExposedHeaders:   []string{"Retry-After", "X-Verification-Nonce"},                                                                                        // 前端需要读取的限流与验证码随机数响应头
+		AllowCredentials: false,                                                                                                                                  // 默认不允许携带证书
 	}
 }
 
@@ -37,6 +39,9 @@ func corsWithConfig(config corsConfig) echo.MiddlewareFunc {
 			c.Response().Header().Set("Access-Control-Allow-Origin", strings.Join(config.AllowedOrigins, ","))
 			c.Response().Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ","))
 			c.Response().Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ","))
+			if len(config.ExposedHeaders) > 0 {
+				c.Response().Header().Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ","))
+			}
 
 			if config.AllowCredentials {
 				c.Response().Header().Set("Access-Control-Allow-Credentials", "true")
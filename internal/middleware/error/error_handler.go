@@ -18,9 +18,11 @@ func InitGlobalError() echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			if err := next(c); err != nil {
 				code := http.StatusInternalServerError
+				status := http.StatusInternalServerError
 				var e *bizerr.Err
 				if errors.As(err, &e) {
 					code = e.Code
+					status = bizerr.HTTPStatus(e.Code)
 				}
 
 				// 捕获请求信息：请求方法、请求URI、客户端IP、User-Agent
@@ -33,7 +35,7 @@ func InitGlobalError() echo.MiddlewareFunc {
 				logMessage := fmt.Sprintf("请求异常: %v | Method: %s | URI: %s | IP: %s | User-Agent: %s", err, requestMethod, requestURI, clientIP, userAgent)
 				global.SysLog.Error(logMessage)
 
-				return c.JSON(code, vo.Fail(nil, bizerr.New(code, err.Error()), c))
+				return c.JSON(status, vo.Fail(nil, bizerr.New(code, err.Error()), c))
 			}
 			return nil
 		}
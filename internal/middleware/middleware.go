@@ -5,6 +5,7 @@ import (
 
 	requestMiddleware "github.com/labstack/echo/v4/middleware"
 	loggerMiddleware "jank.com/jank_blog/internal/logger"
+	accessLogMiddleware "jank.com/jank_blog/internal/middleware/access"
 	corsMiddleware "jank.com/jank_blog/internal/middleware/cors"
 	errorMiddleware "jank.com/jank_blog/internal/middleware/error"
 	recoverMiddleware "jank.com/jank_blog/internal/middleware/recover"
@@ -20,6 +21,8 @@ func InitMiddleware(app *echo.Echo) {
 	app.Use(requestMiddleware.RequestID())
 	// 日志中间件
 	app.Use(loggerMiddleware.New())
+	// 请求响应日志中间件，按配置开启
+	app.Use(accessLogMiddleware.InitAccessLog())
 	// 配置 xss 防御中间件
 	app.Use(secureMiddleware.InitXss())
 	// 配置 csrf 防御中间件
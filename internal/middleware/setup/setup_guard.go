@@ -0,0 +1,30 @@
+package setupMiddleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/setting"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// InitSetupGuard 安装向导守卫，数据库中已记录安装完成时拒绝访问安装接口
+func InitSetupGuard() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			completed, err := mapper.SettingExists(model.KeySetupCompleted)
+			if err != nil {
+				global.SysLog.Errorf("安装向导检查安装状态失败: %v", err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "检查系统安装状态失败")
+			}
+
+			if completed {
+				return echo.NewHTTPError(http.StatusForbidden, "系统已完成初始化，安装向导已关闭")
+			}
+
+			return next(c)
+		}
+	}
+}
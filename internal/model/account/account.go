@@ -5,13 +5,43 @@ import "jank.com/jank_blog/internal/model/base"
 // Account 用户账户模型
 type Account struct {
 	base.Base
-	Phone    string `gorm:"type:varchar(32);unique;default:null" json:"phone"` // 手机号，次登录方式
-	Email    string `gorm:"type:varchar(64);unique;not null" json:"email"`     // 邮箱，主登录方式
-	Password string `gorm:"type:varchar(255);not null" json:"password"`        // 加密密码
-	Nickname string `gorm:"type:varchar(64);not null" json:"nickname"`         // 昵称
-	Avatar   string `gorm:"type:varchar(255);default:null" json:"avatar"`      // 用户头像
+	Phone             string `gorm:"type:varchar(32);unique;default:null" json:"phone"` // 手机号，次登录方式
+	Email             string `gorm:"type:varchar(64);unique;not null" json:"email"`     // 邮箱，主登录方式
+	Password          string `gorm:"type:varchar(255);not null" json:"password"`        // 加密密码
+	PasswordAlgorithm string `gorm:"type:varchar(16);default:null" json:"-"`            // 密码哈希算法，早期版本创建的账户为空，登录后写入
+	Nickname          string `gorm:"type:varchar(64);not null" json:"nickname"`         // 昵称
+	Avatar            string `gorm:"type:varchar(255);default:null" json:"avatar"`      // 用户头像
+	DisplayName       string `gorm:"type:varchar(64);default:null" json:"display_name"` // 公开资料中展示的名称，为空时展示昵称
+	Bio               string `gorm:"type:varchar(512);default:null" json:"bio"`         // 个人简介
+	Website           string `gorm:"type:varchar(255);default:null" json:"website"`     // 个人网站
+
+	TotpSecret        string `gorm:"type:varchar(64);default:null" json:"-"`     // TOTP 密钥，开启两步验证后写入
+	TotpEnabled       bool   `gorm:"not null;default:false" json:"totp_enabled"` // 是否已开启两步验证
+	TotpRecoveryCodes string `gorm:"type:text;default:null" json:"-"`            // 未使用的恢复码的 SHA-256 摘要，以逗号分隔
+
+	DeletionScheduledAt int64 `gorm:"type:bigint;not null;default:0;index" json:"-"` // 申请注销后永久删除账户的时间，未申请注销时为 0
+
+	DisabledAt     int64  `gorm:"type:bigint;not null;default:0;index" json:"-"` // 管理员停用账户的时间，未停用时为 0
+	DisabledUntil  int64  `gorm:"type:bigint;not null;default:0;index" json:"-"` // 停用到期自动解除的时间，永久停用或未停用时为 0
+	DisabledReason string `gorm:"type:varchar(255);default:null" json:"-"`       // 停用原因，登录时展示给用户
+
+	InviteCodeID int64 `gorm:"type:bigint;not null;default:0;index" json:"-"` // 注册时使用的邀请码 ID，未使用邀请码时为 0
+
+	ApprovalStatus string `gorm:"type:varchar(16);default:null;index" json:"-"` // 注册审核状态，无需审核或已通过审核时为空
+	ApprovalReason string `gorm:"type:varchar(255);default:null" json:"-"`      // 拒绝注册的原因，登录时展示给用户
 }
 
+// 注册审核状态
+const (
+	ApprovalStatusPending  = "pending"  // 待审核
+	ApprovalStatusRejected = "rejected" // 已拒绝
+)
+
 func (Account) TableName() string {
 	return "accounts"
 }
+
+// Disabled 账户在 now 时处于停用状态，限时停用已到期时视为未停用
+func (a *Account) Disabled(now int64) bool {
+	return a.DisabledAt > 0 && (a.DisabledUntil == 0 || now < a.DisabledUntil)
+}
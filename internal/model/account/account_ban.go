@@ -0,0 +1,20 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// AccountBan 账户停用记录，管理员每次停用账户时写入一条，解除停用后保留，用于追溯停用历史与申诉处理
+type AccountBan struct {
+	base.Base
+	AccountID  int64  `gorm:"type:bigint;not null;index" json:"account_id"`             // 被停用的账户 ID
+	OperatorID int64  `gorm:"type:bigint;not null;index" json:"operator_id"`            // 执行停用的管理员账户 ID
+	Reason     string `gorm:"type:varchar(255);not null;default:''" json:"reason"`      // 停用原因
+	ExpiresAt  int64  `gorm:"type:bigint;not null;default:0" json:"expires_at"`         // 到期自动解除的时间，永久停用时为 0
+	LiftedAt   int64  `gorm:"type:bigint;not null;default:0;index" json:"lifted_at"`    // 解除停用的时间，仍在停用中时为 0
+	LiftedBy   int64  `gorm:"type:bigint;not null;default:0" json:"lifted_by"`          // 解除停用的管理员账户 ID，到期自动解除时为 0
+	LiftReason string `gorm:"type:varchar(255);not null;default:''" json:"lift_reason"` // 解除原因
+	AppealNote string `gorm:"type:text;default:null" json:"appeal_note"`                // 管理员记录的用户申诉内容与处理情况
+}
+
+func (AccountBan) TableName() string {
+	return "account_bans"
+}
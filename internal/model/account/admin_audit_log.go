@@ -0,0 +1,30 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// 管理员对用户执行的操作
+const (
+	AdminActionResetPassword = "reset_password" // 重置密码
+	AdminActionForceLogout   = "force_logout"   // 强制全部设备下线
+	AdminActionChangeRole    = "change_role"    // 变更角色
+	AdminActionDisable       = "disable"        // 停用账户
+	AdminActionEnable        = "enable"         // 启用账户
+	AdminActionBanAppeal     = "ban_appeal"     // 记录停用申诉
+	AdminActionApprove       = "approve"        // 通过注册审核
+	AdminActionReject        = "reject"         // 拒绝注册
+	AdminActionRegistration  = "registration"   // 修改注册设置
+)
+
+// AdminAuditLog 管理员操作审计日志，记录管理员在用户管理中对账户执行的每次操作
+type AdminAuditLog struct {
+	base.Base
+	OperatorID int64  `gorm:"type:bigint;not null;index" json:"operator_id"`       // 执行操作的管理员账户 ID
+	TargetID   int64  `gorm:"type:bigint;not null;index" json:"target_id"`         // 被操作的账户 ID
+	Action     string `gorm:"type:varchar(32);not null;index" json:"action"`       // 操作
+	Detail     string `gorm:"type:varchar(255);not null;default:''" json:"detail"` // 操作详情，如变更前后的角色、停用原因
+	IP         string `gorm:"type:varchar(64);not null;default:''" json:"ip"`      // 管理员的客户端 IP
+}
+
+func (AdminAuditLog) TableName() string {
+	return "admin_audit_logs"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// API Key 权限范围
+const (
+	APIKeyScopeRead  = "read"  // 只读，仅允许 GET、HEAD 请求
+	APIKeyScopeWrite = "write" // 读写，不能访问管理接口
+	APIKeyScopeAdmin = "admin" // 管理，仅管理员可创建
+)
+
+// APIKey 用户创建的个人 API Key，供脚本与集成免登录调用接口
+type APIKey struct {
+	base.Base
+	AccountID  int64  `gorm:"index;not null" json:"account_id"`                  // 用户ID
+	Name       string `gorm:"type:varchar(64);not null" json:"name"`             // 名称，便于用户区分用途
+	Prefix     string `gorm:"type:varchar(16);not null" json:"prefix"`           // Key 的前几位，用于展示
+	KeyHash    string `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`    // Key 的 SHA-256 摘要
+	Scope      string `gorm:"type:varchar(16);not null" json:"scope"`            // 权限范围
+	ExpiresAt  int64  `gorm:"default:null" json:"expires_at"`                    // 过期的 Unix 时间戳，为 0 时永不过期
+	LastUsedAt int64  `gorm:"default:null" json:"last_used_at"`                  // 最近一次使用的 Unix 时间戳
+	LastUsedIP string `gorm:"type:varchar(64);default:null" json:"last_used_ip"` // 最近一次使用的 IP
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
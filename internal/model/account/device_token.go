@@ -0,0 +1,20 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// DeviceToken 用户在受信任的设备上开启“记住我”后签发的长期设备令牌，用于在会话结束后免登录换取新的 token
+type DeviceToken struct {
+	base.Base
+	AccountID  int64  `gorm:"index;not null" json:"account_id"`                  // 用户ID
+	TokenHash  string `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`    // 令牌的 SHA-256 摘要，每次换取 token 时轮换
+	DeviceHash string `gorm:"type:varchar(64);not null" json:"-"`                // 客户端设备标识的 SHA-256 摘要，换取 token 时需提交相同的设备标识
+	SessionID  string `gorm:"type:varchar(64);index;default:null" json:"-"`      // 最近一次由该令牌创建或开启记住我的会话 ID
+	Device     string `gorm:"type:varchar(128);default:null" json:"device"`      // 由 User-Agent 识别的设备
+	LastUsedAt int64  `gorm:"default:null" json:"last_used_at"`                  // 最近一次换取 token 的 Unix 时间戳
+	LastUsedIP string `gorm:"type:varchar(64);default:null" json:"last_used_ip"` // 最近一次换取 token 的 IP
+	ExpiresAt  int64  `gorm:"not null" json:"expires_at"`                        // 过期的 Unix 时间戳，每次换取 token 时顺延
+}
+
+func (DeviceToken) TableName() string {
+	return "device_tokens"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// InviteCode 邀请码，由管理员或已注册的用户创建，开启邀请注册后注册时需填写有效的邀请码
+type InviteCode struct {
+	base.Base
+	Code      string `gorm:"type:varchar(32);uniqueIndex;not null" json:"code"` // 邀请码
+	CreatorID int64  `gorm:"type:bigint;not null;index" json:"creator_id"`      // 创建邀请码的账户 ID
+	MaxUses   int    `gorm:"not null;default:1" json:"max_uses"`                // 可使用的次数
+	UsedCount int    `gorm:"not null;default:0" json:"used_count"`              // 已使用的次数
+	ExpiresAt int64  `gorm:"type:bigint;not null;default:0" json:"expires_at"`  // 过期的 Unix 时间戳，为 0 时永不过期
+	Note      string `gorm:"type:varchar(64);not null;default:''" json:"note"`  // 备注，便于创建者区分用途
+}
+
+func (InviteCode) TableName() string {
+	return "invite_codes"
+}
+
+// Usable 邀请码在 now 时未过期且未用完
+func (i *InviteCode) Usable(now int64) bool {
+	return i.UsedCount < i.MaxUses && (i.ExpiresAt == 0 || i.ExpiresAt > now)
+}
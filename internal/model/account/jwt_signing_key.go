@@ -0,0 +1,17 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// JWTSigningKey JWT 签名密钥，access token 与 refresh token 各自使用不同用途的密钥，签发时将密钥 ID 写入 kid 头部
+type JWTSigningKey struct {
+	base.Base
+	Kid       string `gorm:"type:varchar(32);uniqueIndex;not null" json:"kid"`       // 密钥 ID
+	Purpose   string `gorm:"type:varchar(16);not null;index" json:"purpose"`         // 用途，access 或 refresh
+	Secret    string `gorm:"type:varchar(128);not null" json:"-"`                    // HMAC 密钥，十六进制编码
+	RetiredAt int64  `gorm:"type:bigint;not null;default:0" json:"retired_at"`       // 被新密钥替换的时间，当前使用的密钥为 0
+	ExpiresAt int64  `gorm:"type:bigint;not null;default:0;index" json:"expires_at"` // 停止校验该密钥签发的 token 的时间，当前使用的密钥为 0
+}
+
+func (JWTSigningKey) TableName() string {
+	return "jwt_signing_keys"
+}
@@ -0,0 +1,39 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// 登录方式，第三方登录为前缀加服务名称，如 oauth:github
+const (
+	LoginMethodPassword    = "password"   // 密码登录
+	LoginMethodPasskey     = "passkey"    // 通行密钥登录
+	LoginMethodMagicLink   = "magic_link" // 邮件链接免密登录
+	LoginMethodLdap        = "ldap"       // LDAP 目录账号登录
+	LoginMethodDeviceToken = "device"     // 记住我设备令牌免登录
+	LoginMethodOAuthPrefix = "oauth:"     // 第三方登录
+)
+
+// 两步验证方式
+const (
+	SecondFactorTotp         = "totp"          // TOTP 动态码
+	SecondFactorRecoveryCode = "recovery_code" // 恢复码
+)
+
+// LoginRecord 登录记录，成功与失败的登录均会记录，供用户排查异常登录
+type LoginRecord struct {
+	base.Base
+	AccountID    int64  `gorm:"index;not null;default:0" json:"account_id"`         // 用户ID，邮箱未注册时为 0
+	Email        string `gorm:"type:varchar(64);index;not null" json:"email"`       // 登录时提交的邮箱
+	Method       string `gorm:"type:varchar(32);not null" json:"method"`            // 登录方式
+	SecondFactor string `gorm:"type:varchar(16);default:null" json:"second_factor"` // 两步验证方式，未经过两步验证时为空
+	Success      bool   `gorm:"not null;default:false" json:"success"`              // 是否登录成功
+	FailReason   string `gorm:"type:varchar(64);default:null" json:"fail_reason"`   // 失败原因
+	IP           string `gorm:"type:varchar(64);default:null" json:"ip"`            // 登录 IP
+	Location     string `gorm:"type:varchar(128);default:null" json:"location"`     // IP 对应的地理位置，未开启查询或查询失败时为空
+	UserAgent    string `gorm:"type:varchar(512);default:null" json:"user_agent"`   // 登录时的 User-Agent
+	Device       string `gorm:"type:varchar(128);default:null" json:"device"`       // 由 User-Agent 识别的设备
+	NewDevice    bool   `gorm:"not null;default:false" json:"new_device"`           // 是否为首次在该设备上登录成功
+}
+
+func (LoginRecord) TableName() string {
+	return "account_login_records"
+}
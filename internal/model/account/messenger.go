@@ -0,0 +1,49 @@
+package model
+
+import "sort"
+
+// messengersExtKey 绑定的即时通讯账号统一存储在 Account.Ext["messengers"] 中，键为服务名称，值为账号
+const messengersExtKey = "messengers"
+
+// MessengerHandle 获取绑定的即时通讯账号，未绑定时返回空字符串
+func (a *Account) MessengerHandle(provider string) string {
+	handles, ok := a.Ext[messengersExtKey].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	handle, _ := handles[provider].(string)
+	return handle
+}
+
+// MessengerProviders 按名称顺序获取已绑定即时通讯账号的服务名称
+func (a *Account) MessengerProviders() []string {
+	handles, ok := a.Ext[messengersExtKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	providers := make([]string, 0, len(handles))
+	for provider, handle := range handles {
+		if h, ok := handle.(string); ok && h != "" {
+			providers = append(providers, provider)
+		}
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// SetMessengerHandle 绑定即时通讯账号，handle 为空时解除绑定
+func (a *Account) SetMessengerHandle(provider, handle string) {
+	if a.Ext == nil {
+		a.Ext = make(map[string]interface{})
+	}
+	handles, ok := a.Ext[messengersExtKey].(map[string]interface{})
+	if !ok {
+		handles = make(map[string]interface{})
+		a.Ext[messengersExtKey] = handles
+	}
+	if handle == "" {
+		delete(handles, provider)
+		return
+	}
+	handles[provider] = handle
+}
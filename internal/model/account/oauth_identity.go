@@ -0,0 +1,16 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// OAuthIdentity 第三方登录账号与用户账户的关联
+type OAuthIdentity struct {
+	base.Base
+	AccountID int64  `gorm:"index;not null" json:"account_id"`                                                 // 用户ID
+	Provider  string `gorm:"type:varchar(16);not null;uniqueIndex:idx_oauth_provider_subject" json:"provider"` // 第三方登录服务名称，LDAP 账号为 ldap
+	Subject   string `gorm:"type:varchar(128);not null;uniqueIndex:idx_oauth_provider_subject" json:"subject"` // 第三方账号的唯一 ID
+	Email     string `gorm:"type:varchar(64);default:null" json:"email"`                                       // 授权时第三方账号的邮箱
+}
+
+func (OAuthIdentity) TableName() string {
+	return "oauth_identities"
+}
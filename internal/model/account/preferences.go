@@ -0,0 +1,35 @@
+package model
+
+// 用户偏好设置键，统一存储在 Account.Ext["preferences"] 中
+const (
+	PreferenceReadingHistory = "reading_history" // 是否记录阅读历史，默认开启
+	PreferenceLoginAlert     = "login_alert"     // 是否接收新设备登录提醒邮件，默认开启；修改密码等必要的安全提醒不受影响
+)
+
+const preferencesExtKey = "preferences"
+
+// Preference 获取布尔类型的偏好设置，未设置时返回默认值
+func (a *Account) Preference(key string, defaultValue bool) bool {
+	prefs, ok := a.Ext[preferencesExtKey].(map[string]interface{})
+	if !ok {
+		return defaultValue
+	}
+	value, ok := prefs[key].(bool)
+	if !ok {
+		return defaultValue
+	}
+	return value
+}
+
+// SetPreference 设置布尔类型的偏好设置
+func (a *Account) SetPreference(key string, value bool) {
+	if a.Ext == nil {
+		a.Ext = make(map[string]interface{})
+	}
+	prefs, ok := a.Ext[preferencesExtKey].(map[string]interface{})
+	if !ok {
+		prefs = make(map[string]interface{})
+		a.Ext[preferencesExtKey] = prefs
+	}
+	prefs[key] = value
+}
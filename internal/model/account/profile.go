@@ -0,0 +1,49 @@
+package model
+
+// 公开资料中支持的社交平台
+const (
+	SocialGitHub   = "github"
+	SocialGitee    = "gitee"
+	SocialTwitter  = "twitter"
+	SocialWeibo    = "weibo"
+	SocialZhihu    = "zhihu"
+	SocialBilibili = "bilibili"
+	SocialLinkedIn = "linkedin"
+	SocialMastodon = "mastodon"
+)
+
+// socialLinksExtKey 社交链接统一存储在 Account.Ext["social_links"] 中，键为平台名称，值为主页地址
+const socialLinksExtKey = "social_links"
+
+// SocialLinks 获取社交链接，未设置时返回空
+func (a *Account) SocialLinks() map[string]string {
+	links, ok := a.Ext[socialLinksExtKey].(map[string]interface{})
+	if !ok || len(links) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(links))
+	for platform, link := range links {
+		if l, ok := link.(string); ok && l != "" {
+			result[platform] = l
+		}
+	}
+	return result
+}
+
+// SetSocialLinks 替换全部社交链接，links 为空时清除
+func (a *Account) SetSocialLinks(links map[string]string) {
+	if len(links) == 0 {
+		delete(a.Ext, socialLinksExtKey)
+		return
+	}
+	if a.Ext == nil {
+		a.Ext = make(map[string]interface{})
+	}
+	values := make(map[string]interface{}, len(links))
+	for platform, link := range links {
+		if link != "" {
+			values[platform] = link
+		}
+	}
+	a.Ext[socialLinksExtKey] = values
+}
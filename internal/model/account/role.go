@@ -2,6 +2,11 @@ package model
 
 import "jank.com/jank_blog/internal/model/base"
 
+const (
+	RoleCodeAdmin = "admin" // 管理员角色编码
+	RoleCodeUser  = "user"  // 普通用户角色编码
+)
+
 // Role 角色模型
 type Role struct {
 	base.Base
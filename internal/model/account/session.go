@@ -0,0 +1,34 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// 会话结束原因
+const (
+	SessionEndLogout        = "logout"         // 用户登出
+	SessionEndRevoked       = "revoked"        // 用户在其他设备上移除了该会话
+	SessionEndLogoutAll     = "logout_all"     // 全部设备登出或移除其他全部会话
+	SessionEndRoleChanged   = "role_changed"   // 账户角色变更
+	SessionEndPasswordReset = "password_reset" // 通过邮件链接重置密码
+	SessionEndDeletion      = "deletion"       // 申请注销账户
+	SessionEndForceLogout   = "force_logout"   // 管理员强制下线或重置密码
+	SessionEndDisabled      = "disabled"       // 管理员停用账户
+	SessionEndSuperseded    = "superseded"     // 开启单会话限制时在其他设备上登录
+)
+
+// AccountSession 登录会话的持久化记录，会话状态以缓存为准，记录用于审计与列出会话
+type AccountSession struct {
+	base.Base
+	SessionID  string `gorm:"type:varchar(64);uniqueIndex;not null" json:"session_id"` // 会话 ID，与 token 中的 sid 一致
+	AccountID  int64  `gorm:"index;not null" json:"account_id"`                        // 用户ID
+	Device     string `gorm:"type:varchar(128);default:null" json:"device"`            // 由 User-Agent 识别的设备
+	UserAgent  string `gorm:"type:varchar(512);default:null" json:"user_agent"`        // 登录时的 User-Agent
+	IP         string `gorm:"type:varchar(64);default:null" json:"ip"`                 // 最近一次访问的 IP
+	LastSeenAt int64  `gorm:"default:null" json:"last_seen_at"`                        // 最近一次访问的 Unix 时间戳
+	ExpiresAt  int64  `gorm:"not null" json:"expires_at"`                              // 会话过期的 Unix 时间戳，刷新 token 时顺延
+	EndedAt    int64  `gorm:"default:null" json:"ended_at"`                            // 会话结束的 Unix 时间戳，为 0 时会话未结束
+	EndReason  string `gorm:"type:varchar(32);default:null" json:"end_reason"`         // 会话结束原因
+}
+
+func (AccountSession) TableName() string {
+	return "account_sessions"
+}
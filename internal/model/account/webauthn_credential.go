@@ -0,0 +1,18 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// WebAuthnCredential 用户绑定的通行密钥
+type WebAuthnCredential struct {
+	base.Base
+	AccountID    int64  `gorm:"index;not null" json:"account_id"`                            // 用户ID
+	CredentialID string `gorm:"type:varchar(512);uniqueIndex;not null" json:"credential_id"` // 凭证 ID，Base64URL 编码
+	PublicKey    []byte `gorm:"not null" json:"-"`                                           // COSE_Key 编码的公钥
+	SignCount    uint32 `gorm:"not null;default:0" json:"sign_count"`                        // 签名计数器
+	Name         string `gorm:"type:varchar(64);not null" json:"name"`                       // 通行密钥名称，便于用户区分设备
+	LastUsedAt   int64  `gorm:"default:null" json:"last_used_at"`                            // 最近一次登录的 Unix 时间戳
+}
+
+func (WebAuthnCredential) TableName() string {
+	return "webauthn_credentials"
+}
@@ -0,0 +1,14 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// Bookmark 用户收藏模型，每个用户对每篇文章仅保留一条记录
+type Bookmark struct {
+	base.Base
+	AccountID int64 `gorm:"type:bigint;not null;uniqueIndex:idx_bookmark_account_post" json:"account_id"`    // 用户ID
+	PostID    int64 `gorm:"type:bigint;not null;uniqueIndex:idx_bookmark_account_post;index" json:"post_id"` // 文章ID
+}
+
+func (Bookmark) TableName() string {
+	return "bookmarks"
+}
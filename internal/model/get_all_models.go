@@ -2,28 +2,79 @@ package model
 
 import (
 	account "jank.com/jank_blog/internal/model/account"
+	bookmark "jank.com/jank_blog/internal/model/bookmark"
 	category "jank.com/jank_blog/internal/model/category"
 	comment "jank.com/jank_blog/internal/model/comment"
+	history "jank.com/jank_blog/internal/model/history"
 	post "jank.com/jank_blog/internal/model/post"
+	reaction "jank.com/jank_blog/internal/model/reaction"
+	review "jank.com/jank_blog/internal/model/review"
+	revision "jank.com/jank_blog/internal/model/revision"
+	series "jank.com/jank_blog/internal/model/series"
+	setting "jank.com/jank_blog/internal/model/setting"
+	shortlink "jank.com/jank_blog/internal/model/shortlink"
+	verification "jank.com/jank_blog/internal/model/verification"
 )
 
 // GetAllModels 获取并注册所有模型
 func GetAllModels() []interface{} {
 	return []interface{}{
 		// account 模块
-		&account.Account{},        // 用户账号模型
-		&account.AccountRole{},    // 用户角色模型
-		&account.Permission{},     // 权限模型
-		&account.Role{},           // 角色模型
-		&account.RolePermission{}, // 角色权限关联模型
+		&account.Account{},            // 用户账号模型
+		&account.AccountRole{},        // 用户角色模型
+		&account.Permission{},         // 权限模型
+		&account.Role{},               // 角色模型
+		&account.RolePermission{},     // 角色权限关联模型
+		&account.OAuthIdentity{},      // 第三方登录关联模型
+		&account.WebAuthnCredential{}, // 通行密钥模型
+		&account.APIKey{},             // 个人 API Key 模型
+		&account.AccountSession{},     // 登录会话模型
+		&account.DeviceToken{},        // 记住我设备令牌模型
+		&account.LoginRecord{},        // 登录记录模型
+		&account.AdminAuditLog{},      // 管理员操作审计日志模型
+		&account.AccountBan{},         // 账户停用记录模型
+		&account.InviteCode{},         // 邀请码模型
+		&account.JWTSigningKey{},      // JWT 签名密钥模型
 
 		// post 模块
 		&post.Post{},
+		&post.PostSlugHistory{}, // 文章历史别名模型
+		&post.PostDailyView{},   // 文章每日浏览量模型
+		&post.PostAuthor{},      // 文章作者模型
 
 		// category 模块
 		&category.Category{},
 
 		// comment 模块
 		&comment.Comment{},
+
+		// series 模块
+		&series.Series{},     // 文章系列模型
+		&series.SeriesPost{}, // 系列文章关联模型
+
+		// bookmark 模块
+		&bookmark.Bookmark{},
+
+		// reaction 模块
+		&reaction.PostReaction{}, // 文章表态模型
+
+		// shortlink 模块
+		&shortlink.ShortLink{},      // 短链接模型
+		&shortlink.ShortLinkClick{}, // 短链接点击记录模型
+
+		// history 模块
+		&history.ReadingHistory{},
+
+		// review 模块
+		&review.PostReview{},
+
+		// revision 模块
+		&revision.PostRevision{}, // 文章修订记录模型
+
+		// verification 模块
+		&verification.VerificationLog{}, // 验证码审计日志模型
+
+		// setting 模块
+		&setting.Setting{}, // 运行时配置项模型
 	}
 }
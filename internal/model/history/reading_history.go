@@ -0,0 +1,16 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// ReadingHistory 用户阅读历史模型，每个用户对每篇文章仅保留一条记录
+type ReadingHistory struct {
+	base.Base
+	AccountID int64 `gorm:"type:bigint;not null;uniqueIndex:idx_reading_history_account_post" json:"account_id"` // 用户ID
+	PostID    int64 `gorm:"type:bigint;not null;uniqueIndex:idx_reading_history_account_post" json:"post_id"`    // 文章ID
+	Position  int64 `gorm:"type:bigint;not null;default:0" json:"position"`                                      // 阅读位置，由客户端定义，如滚动百分比或段落序号
+	ReadAt    int64 `gorm:"type:bigint;not null;index" json:"read_at"`                                           // 最近阅读时间
+}
+
+func (ReadingHistory) TableName() string {
+	return "reading_histories"
+}
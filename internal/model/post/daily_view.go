@@ -0,0 +1,15 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// PostDailyView 文章每日浏览量，用于统计热门文章
+type PostDailyView struct {
+	base.Base
+	PostID int64 `gorm:"type:bigint;not null;uniqueIndex:idx_post_daily_view,priority:1" json:"post_id"` // 文章ID
+	Day    int   `gorm:"type:int;not null;uniqueIndex:idx_post_daily_view,priority:2;index" json:"day"`  // 日期，格式为 20060102
+	Views  int64 `gorm:"type:bigint;not null;default:0" json:"views"`                                    // 当日浏览量
+}
+
+func (PostDailyView) TableName() string {
+	return "post_daily_views"
+}
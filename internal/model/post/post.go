@@ -11,12 +11,28 @@ import (
 // Post 博客文章模型
 type Post struct {
 	base.Base
-	Title           string           `gorm:"type:varchar(255);not null;index" json:"title"`               // 标题
-	Image           string           `gorm:"type:varchar(255)" json:"image"`                              // 图片
-	Visibility      bool             `gorm:"type:boolean;not null;default:false;index" json:"visibility"` // 可见性，默认不可见
-	ContentMarkdown string           `gorm:"type:text" json:"contentMarkdown"`                            // Markdown 内容
-	ContentHTML     string           `gorm:"type:text" json:"contentHtml"`                                // 渲染后的 HTML 内容
-	CategoryIDs     CategoryIDsArray `gorm:"type:text" json:"categoryIds"`                                // 分类 ID 数组
+	Title              string           `gorm:"type:varchar(255);not null;index" json:"title"`                   // 标题
+	Slug               string           `gorm:"type:varchar(80);not null;default:'';index" json:"slug"`          // 别名，用于文章地址，未删除的文章之间唯一
+	Image              string           `gorm:"type:varchar(255)" json:"image"`                                  // 图片
+	Visibility         bool             `gorm:"type:boolean;not null;default:false;index" json:"visibility"`     // 可见性，默认不可见，与发布状态同步，仅 published 时为 true
+	Status             string           `gorm:"type:varchar(16);not null;default:'draft';index" json:"status"`   // 发布状态
+	ContentMarkdown    string           `gorm:"type:text" json:"contentMarkdown"`                                // Markdown 内容
+	ContentHTML        string           `gorm:"type:text" json:"contentHtml"`                                    // 渲染后的 HTML 内容
+	CategoryIDs        CategoryIDsArray `gorm:"type:text" json:"categoryIds"`                                    // 分类 ID 数组
+	ReviewStatus       string           `gorm:"type:varchar(32);not null;default:'';index" json:"reviewStatus"`  // 审核状态，空表示未进入审核流程
+	PublishAt          int64            `gorm:"type:bigint;not null;default:0;index" json:"publishAt"`           // 定时发布时间，审核通过等待定时发布或作者直接定时发布时有效，发布后清零
+	Fingerprint        int64            `gorm:"type:bigint;not null;default:0" json:"fingerprint"`               // 内容 SimHash 指纹，按位存储为有符号整数，0 表示未计算或内容过短
+	ViewCount          int64            `gorm:"type:bigint;not null;default:0" json:"viewCount"`                 // 累计浏览量，定期由 Redis 中的缓冲批量写入
+	WordCount          int              `gorm:"type:int;not null;default:0" json:"wordCount"`                    // 字数，中日韩文字按字计数，保存时计算
+	ReadingTime        int              `gorm:"type:int;not null;default:0" json:"readingTime"`                  // 预计阅读时间，单位为分钟，保存时计算
+	Pinned             bool             `gorm:"type:boolean;not null;default:false;index" json:"pinned"`         // 是否置顶，置顶的文章排在文章列表最前
+	PinOrder           int              `gorm:"type:int;not null;default:0" json:"pinOrder"`                     // 置顶顺序，数值小的在前
+	Featured           bool             `gorm:"type:boolean;not null;default:false;index" json:"featured"`       // 是否精选，用于首页轮播
+	FeatureOrder       int              `gorm:"type:int;not null;default:0" json:"featureOrder"`                 // 精选顺序，数值小的在前
+	Access             string           `gorm:"type:varchar(16);not null;default:'public';index" json:"access"`  // 访问权限，仅对已发布的文章生效
+	AccessPassword     string           `gorm:"type:varchar(255);not null;default:''" json:"-"`                  // 访问密码的哈希，仅密码保护的文章有效
+	Language           string           `gorm:"type:varchar(16);not null;default:'zh-CN';index" json:"language"` // 文章语言，BCP 47 语言标签
+	TranslationGroupID int64            `gorm:"type:bigint;not null;default:0;index" json:"translationGroupId"`  // 翻译组 ID，取组内原文的文章 ID，0 表示没有关联的翻译
 }
 
 func (Post) TableName() string {
@@ -53,3 +69,60 @@ func (a *CategoryIDsArray) Scan(value interface{}) error {
 
 	return json.Unmarshal(bytes, a)
 }
+
+// 文章扩展字段中的 SEO 文本键，存储在 Post.Ext 中
+const (
+	ExtSummary         = "summary"          // 文章摘要
+	ExtMetaDescription = "meta_description" // 页面 meta 描述
+	ExtSocialBlurb     = "social_blurb"     // 社交媒体分享文案
+)
+
+// 文章发布状态，仅 published 对访客可见；状态变更时同步更新 Visibility
+const (
+	StatusDraft     = "draft"     // 草稿，仅登录用户可见
+	StatusPublished = "published" // 已发布
+	StatusArchived  = "archived"  // 已归档，不再对访客展示，内容保留
+)
+
+// StatusFromVisibility 按可见性推断发布状态，用于仅传入 visibility 的旧接口与导入
+func StatusFromVisibility(visible bool) string {
+	if visible {
+		return StatusPublished
+	}
+	return StatusDraft
+}
+
+// 文章访问权限，与发布状态相互独立
+const (
+	AccessPublic   = "public"   // 公开
+	AccessLogin    = "login"    // 仅登录用户可见，不出现在访客的文章列表中
+	AccessPassword = "password" // 密码保护，出现在文章列表中，输入密码解锁后才能阅读正文
+)
+
+// VisibleAccess 文章列表中可见的访问权限，登录用户可见全部文章，返回 nil 表示不按访问权限筛选
+func VisibleAccess(loggedIn bool) []string {
+	if loggedIn {
+		return nil
+	}
+	return []string{AccessPublic, AccessPassword}
+}
+
+// DefaultLanguage 未指定语言时文章使用的语言，与默认的界面语言一致
+const DefaultLanguage = "zh-CN"
+
+// GroupID 文章所在的翻译组 ID，没有关联翻译的文章自成一组，组 ID 为文章 ID
+func (p *Post) GroupID() int64 {
+	if p.TranslationGroupID > 0 {
+		return p.TranslationGroupID
+	}
+	return p.ID
+}
+
+// 文章审核状态，审核流程位于草稿与发布之间
+const (
+	ReviewStatusNone             = ""                  // 未进入审核流程
+	ReviewStatusPending          = "in_review"         // 已提交，等待审核
+	ReviewStatusChangesRequested = "changes_requested" // 审核退回，等待作者修改
+	ReviewStatusScheduled        = "scheduled"         // 审核通过，等待定时发布
+	ReviewStatusApproved         = "approved"          // 审核通过并已发布
+)
@@ -0,0 +1,22 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// 文章作者的署名角色
+const (
+	AuthorRoleAuthor = "author" // 作者，可修改文章的作者列表
+	AuthorRoleEditor = "editor" // 编辑，参与修改的署名编辑
+)
+
+// PostAuthor 文章的署名作者，一篇文章可以有多位作者
+type PostAuthor struct {
+	base.Base
+	PostID    int64  `gorm:"type:bigint;not null;uniqueIndex:idx_post_author,priority:1" json:"post_id"`          // 文章ID
+	AccountID int64  `gorm:"type:bigint;not null;uniqueIndex:idx_post_author,priority:2;index" json:"account_id"` // 作者账户ID
+	Role      string `gorm:"type:varchar(16);not null;default:'author'" json:"role"`                              // 署名角色，可选值: author, editor
+	Position  int    `gorm:"type:int;not null;default:0" json:"position"`                                         // 署名顺序，数值小的在前
+}
+
+func (PostAuthor) TableName() string {
+	return "post_authors"
+}
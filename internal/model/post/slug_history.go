@@ -0,0 +1,14 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// PostSlugHistory 文章修改别名前使用过的别名，访问旧别名时重定向到文章当前的别名
+type PostSlugHistory struct {
+	base.Base
+	PostID int64  `gorm:"type:bigint;not null;index" json:"post_id"`         // 文章ID
+	Slug   string `gorm:"type:varchar(80);not null;uniqueIndex" json:"slug"` // 历史别名
+}
+
+func (PostSlugHistory) TableName() string {
+	return "post_slug_histories"
+}
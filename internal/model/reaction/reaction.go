@@ -0,0 +1,17 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// PostReaction 文章表态模型，每个用户或访客对每篇文章的每种表态仅保留一条记录；
+// 登录用户记录 AccountID，VisitorID 为空，匿名访客记录 VisitorID，AccountID 为 0
+type PostReaction struct {
+	base.Base
+	PostID    int64  `gorm:"type:bigint;not null;uniqueIndex:idx_post_reaction_reactor;index" json:"post_id"`              // 文章ID
+	Reaction  string `gorm:"type:varchar(16);not null;uniqueIndex:idx_post_reaction_reactor" json:"reaction"`              // 表态类型
+	AccountID int64  `gorm:"type:bigint;not null;default:0;uniqueIndex:idx_post_reaction_reactor" json:"account_id"`       // 用户ID，匿名访客为 0
+	VisitorID string `gorm:"type:varchar(32);not null;default:'';uniqueIndex:idx_post_reaction_reactor" json:"visitor_id"` // 访客ID，登录用户为空
+}
+
+func (PostReaction) TableName() string {
+	return "post_reactions"
+}
@@ -0,0 +1,27 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// 审核操作
+const (
+	ActionSubmit         = "submit"          // 作者提交审核
+	ActionApprove        = "approve"         // 审核通过并发布
+	ActionSchedule       = "schedule"        // 审核通过并定时发布
+	ActionRequestChanges = "request_changes" // 审核退回
+	ActionPublish        = "publish"         // 定时任务发布
+)
+
+// PostReview 文章审核记录，按时间顺序记录文章在审核流程中的每次状态变更
+type PostReview struct {
+	base.Base
+	PostID     int64  `gorm:"type:bigint;not null;index" json:"post_id"`             // 文章ID
+	AccountID  int64  `gorm:"type:bigint;not null;default:0" json:"account_id"`      // 操作人ID，定时任务发布时为 0
+	Action     string `gorm:"type:varchar(32);not null" json:"action"`               // 审核操作
+	FromStatus string `gorm:"type:varchar(32);not null;default:''" json:"from"`      // 变更前的审核状态
+	ToStatus   string `gorm:"type:varchar(32);not null;default:''" json:"to"`        // 变更后的审核状态
+	Comment    string `gorm:"type:varchar(1024);not null;default:''" json:"comment"` // 审核意见或提交说明
+}
+
+func (PostReview) TableName() string {
+	return "post_reviews"
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"jank.com/jank_blog/internal/model/base"
+	post "jank.com/jank_blog/internal/model/post"
+)
+
+// 产生修订记录的操作
+const (
+	ActionCreate  = "create"  // 创建文章
+	ActionUpdate  = "update"  // 更新文章
+	ActionRestore = "restore" // 恢复到历史修订
+)
+
+// PostRevision 文章修订记录，每次保存文章时记录标题、封面、正文与分类的快照
+type PostRevision struct {
+	base.Base
+	PostID          int64                 `gorm:"type:bigint;not null;uniqueIndex:idx_post_revision,priority:1" json:"post_id"` // 文章ID
+	Version         int                   `gorm:"type:int;not null;uniqueIndex:idx_post_revision,priority:2" json:"version"`    // 修订版本号，同一文章内从 1 开始递增
+	AccountID       int64                 `gorm:"type:bigint;not null;default:0" json:"account_id"`                             // 保存人ID
+	Action          string                `gorm:"type:varchar(16);not null" json:"action"`                                      // 产生修订的操作
+	RestoredFrom    int                   `gorm:"type:int;not null;default:0" json:"restored_from"`                             // 恢复时来源的修订版本号，其他操作为 0
+	Title           string                `gorm:"type:varchar(255);not null" json:"title"`                                      // 标题
+	Image           string                `gorm:"type:varchar(255)" json:"image"`                                               // 封面图片
+	ContentMarkdown string                `gorm:"type:text" json:"content_markdown"`                                            // Markdown 正文
+	CategoryIDs     post.CategoryIDsArray `gorm:"type:text" json:"category_ids"`                                                // 分类 ID 数组
+}
+
+func (PostRevision) TableName() string {
+	return "post_revisions"
+}
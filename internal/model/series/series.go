@@ -0,0 +1,27 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// Series 文章系列模型，将多篇文章按顺序组织为专栏或连载
+type Series struct {
+	base.Base
+	Title       string `gorm:"type:varchar(255);not null;index" json:"title"` // 系列标题
+	Description string `gorm:"type:text" json:"description"`                  // 系列简介
+	AccountID   int64  `gorm:"type:bigint;not null;index" json:"account_id"`  // 创建者的用户ID
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+// SeriesPost 系列与文章的关联，每篇文章最多属于一个系列
+type SeriesPost struct {
+	base.Base
+	SeriesID int64 `gorm:"type:bigint;not null;index" json:"series_id"`     // 系列ID
+	PostID   int64 `gorm:"type:bigint;not null;uniqueIndex" json:"post_id"` // 文章ID
+	Position int   `gorm:"type:int;not null;default:0" json:"position"`     // 在系列中的顺序，从 1 开始编号
+}
+
+func (SeriesPost) TableName() string {
+	return "series_posts"
+}
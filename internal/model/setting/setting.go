@@ -0,0 +1,17 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// KeySetupCompleted 安装向导已完成的标记，写入后安装接口永久关闭
+const KeySetupCompleted = "setup.SETUP_COMPLETED"
+
+// Setting 运行时修改的配置项，如注册方式与安装向导写入的站点信息，加载配置时覆盖配置文件中的同名配置
+type Setting struct {
+	base.Base
+	Key   string `gorm:"type:varchar(64);uniqueIndex;not null" json:"key"` // 配置项，格式为 "section.KEY"，如 "site.SITE_TITLE"
+	Value string `gorm:"type:text;not null" json:"value"`                  // 配置值
+}
+
+func (Setting) TableName() string {
+	return "settings"
+}
@@ -0,0 +1,28 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// ShortLink 文章短链接模型，每篇文章仅保留一个短码
+type ShortLink struct {
+	base.Base
+	PostID int64  `gorm:"type:bigint;not null;uniqueIndex" json:"post_id"`   // 文章ID
+	Code   string `gorm:"type:varchar(16);not null;uniqueIndex" json:"code"` // 短码
+	Clicks int64  `gorm:"type:bigint;not null;default:0" json:"clicks"`      // 累计点击次数
+}
+
+func (ShortLink) TableName() string {
+	return "short_links"
+}
+
+// ShortLinkClick 短链接点击记录模型
+type ShortLinkClick struct {
+	base.Base
+	ShortLinkID int64  `gorm:"type:bigint;not null;index" json:"short_link_id"` // 短链接ID
+	Referer     string `gorm:"type:varchar(255)" json:"referer"`                // 来源页面
+	UserAgent   string `gorm:"type:varchar(255)" json:"user_agent"`             // 客户端 User-Agent
+	IP          string `gorm:"type:varchar(64)" json:"ip"`                      // 客户端 IP
+}
+
+func (ShortLinkClick) TableName() string {
+	return "short_link_clicks"
+}
@@ -0,0 +1,23 @@
+package model
+
+import "jank.com/jank_blog/internal/model/base"
+
+// 验证码事件
+const (
+	EventSend   = "send"   // 发送验证码
+	EventVerify = "verify" // 校验验证码
+)
+
+// VerificationLog 验证码审计日志，记录每次发送与校验的接收方、渠道、IP 与结果，供排查滥用与投递问题
+type VerificationLog struct {
+	base.Base
+	Event   string `gorm:"type:varchar(16);not null;index" json:"event"`              // 事件，send 或 verify
+	Channel string `gorm:"type:varchar(16);not null;index" json:"channel"`            // 渠道，IMG、EMAIL、SMS、TOTP
+	Target  string `gorm:"type:varchar(128);not null;default:'';index" json:"target"` // 接收方，邮箱或手机号，按 IP 限流时可能为空
+	IP      string `gorm:"type:varchar(64);not null;default:'';index" json:"ip"`      // 客户端 IP
+	Result  string `gorm:"type:varchar(32);not null;index" json:"result"`             // 结果，如 success、failure、rate_limited、mismatch
+}
+
+func (VerificationLog) TableName() string {
+	return "verification_logs"
+}
@@ -0,0 +1,94 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+const (
+	giteeAuthorizeEndpoint = "https://gitee.com/oauth/authorize"
+	giteeTokenEndpoint     = "https://gitee.com/oauth/token"
+	giteeUserEndpoint      = "https://gitee.com/api/v5/user"
+	giteeEmailsEndpoint    = "https://gitee.com/api/v5/emails"
+)
+
+func init() {
+	Register(ProviderGitee, newGitee)
+}
+
+// gitee Gitee 第三方应用，邮箱取自 /emails 中已确认的主邮箱
+type gitee struct {
+	opts   Options
+	client *http.Client
+}
+
+func newGitee(opts Options) (Provider, error) {
+	return &gitee{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+func (p *gitee) Name() string {
+	return ProviderGitee
+}
+
+func (p *gitee) AuthCodeURL(state, _, _, redirectURL string) string {
+	return giteeAuthorizeEndpoint + "?" + url.Values{
+		"client_id":     {p.opts.ClientID},
+		"redirect_uri":  {redirectURL},
+		"response_type": {"code"},
+		"scope":         {"user_info emails"},
+		"state":         {state},
+	}.Encode()
+}
+
+func (p *gitee) Exchange(ctx context.Context, code, _, _, redirectURL string) (*Identity, error) {
+	tok, err := exchangeToken(ctx, p.client, giteeTokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {p.opts.ClientID},
+		"client_secret": {p.opts.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+	})
+	if err != nil {
+		return nil, err
+	}
+	query := "?" + url.Values{"access_token": {tok.AccessToken}}.Encode()
+
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getJSON(ctx, p.client, giteeUserEndpoint+query, "", &user); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider: ProviderGitee,
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Nickname: user.Name,
+		Avatar:   user.AvatarURL,
+	}
+	if identity.Nickname == "" {
+		identity.Nickname = user.Login
+	}
+
+	var emails []struct {
+		Email string   `json:"email"`
+		State string   `json:"state"`
+		Scope []string `json:"scope"`
+	}
+	if err := getJSON(ctx, p.client, giteeEmailsEndpoint+query, "", &emails); err != nil {
+		return nil, err
+	}
+	for _, e := range emails {
+		if slices.Contains(e.Scope, "primary") {
+			identity.Email, identity.EmailVerified = e.Email, e.State == "confirmed"
+			break
+		}
+	}
+	return identity, nil
+}
@@ -0,0 +1,90 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	githubAuthorizeEndpoint = "https://github.com/login/oauth/authorize"
+	githubTokenEndpoint     = "https://github.com/login/oauth/access_token"
+	githubUserEndpoint      = "https://api.github.com/user"
+	githubEmailsEndpoint    = "https://api.github.com/user/emails"
+)
+
+func init() {
+	Register(ProviderGitHub, newGitHub)
+}
+
+// github GitHub OAuth App，邮箱取自 /user/emails 中的主邮箱
+type github struct {
+	opts   Options
+	client *http.Client
+}
+
+func newGitHub(opts Options) (Provider, error) {
+	return &github{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+func (p *github) Name() string {
+	return ProviderGitHub
+}
+
+func (p *github) AuthCodeURL(state, _, _, redirectURL string) string {
+	return githubAuthorizeEndpoint + "?" + url.Values{
+		"client_id":    {p.opts.ClientID},
+		"redirect_uri": {redirectURL},
+		"scope":        {"read:user user:email"},
+		"state":        {state},
+	}.Encode()
+}
+
+func (p *github) Exchange(ctx context.Context, code, _, _, redirectURL string) (*Identity, error) {
+	tok, err := exchangeToken(ctx, p.client, githubTokenEndpoint, url.Values{
+		"client_id":     {p.opts.ClientID},
+		"client_secret": {p.opts.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getJSON(ctx, p.client, githubUserEndpoint, tok.AccessToken, &user); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider: ProviderGitHub,
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Nickname: user.Name,
+		Avatar:   user.AvatarURL,
+	}
+	if identity.Nickname == "" {
+		identity.Nickname = user.Login
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, p.client, githubEmailsEndpoint, tok.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email, identity.EmailVerified = e.Email, e.Verified
+			break
+		}
+	}
+	return identity, nil
+}
@@ -0,0 +1,105 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	googleAuthorizeEndpoint = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenEndpoint     = "https://oauth2.googleapis.com/token"
+)
+
+// googleIssuers Google ID Token 的签发方
+var googleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+func init() {
+	Register(ProviderGoogle, newGoogle)
+}
+
+// google Google OpenID Connect，账号信息取自令牌端点返回的 ID Token
+type google struct {
+	opts   Options
+	client *http.Client
+}
+
+// googleClaims Google ID Token 中的声明
+type googleClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	Nonce         string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+func newGoogle(opts Options) (Provider, error) {
+	return &google{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+func (p *google) Name() string {
+	return ProviderGoogle
+}
+
+func (p *google) AuthCodeURL(state, nonce, codeVerifier, redirectURL string) string {
+	return googleAuthorizeEndpoint + "?" + url.Values{
+		"client_id":             {p.opts.ClientID},
+		"redirect_uri":          {redirectURL},
+		"response_type":         {"code"},
+		"scope":                 {"openid email profile"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+}
+
+// Exchange 换取令牌并解析 ID Token，ID Token 由令牌端点通过 TLS 直接返回，无需校验签名，但需校验签发方、受众与 nonce
+func (p *google) Exchange(ctx context.Context, code, nonce, codeVerifier, redirectURL string) (*Identity, error) {
+	tok, err := exchangeToken(ctx, p.client, googleTokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {p.opts.ClientID},
+		"client_secret": {p.opts.ClientSecret},
+		"code":          {code},
+		"code_verifier": {codeVerifier},
+		"redirect_uri":  {redirectURL},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("Google 未返回 ID Token")
+	}
+
+	claims := &googleClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tok.IDToken, claims); err != nil {
+		return nil, fmt.Errorf("解析 Google ID Token 失败: %v", err)
+	}
+	if err := claims.Valid(); err != nil {
+		return nil, fmt.Errorf("Google ID Token 已过期: %v", err)
+	}
+	if !slices.Contains(googleIssuers, claims.Issuer) {
+		return nil, fmt.Errorf("Google ID Token 签发方无效: %s", claims.Issuer)
+	}
+	if !claims.VerifyAudience(p.opts.ClientID, true) {
+		return nil, errors.New("Google ID Token 受众与客户端 ID 不一致")
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("Google ID Token 中的 nonce 与发起授权时不一致")
+	}
+
+	return &Identity{
+		Provider:      ProviderGoogle,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Nickname:      claims.Name,
+		Avatar:        claims.Picture,
+	}, nil
+}
@@ -0,0 +1,177 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/configs"
+)
+
+// 内置的第三方登录服务名称
+const (
+	ProviderGitHub = "github" // GitHub
+	ProviderGoogle = "google" // Google
+	ProviderGitee  = "gitee"  // Gitee
+	ProviderOIDC   = "oidc"   // 通用 OpenID Connect，如 Keycloak、Auth0、Authentik
+)
+
+// ErrDisabled 未配置该第三方登录服务
+var ErrDisabled = errors.New("未开启该第三方登录")
+
+// Identity 第三方账号信息
+type Identity struct {
+	Provider      string   // 服务名称
+	Subject       string   // 第三方账号的唯一 ID
+	Email         string   // 邮箱，第三方账号未公开邮箱时为空
+	EmailVerified bool     // 邮箱是否已由第三方服务验证
+	Nickname      string   // 昵称
+	Avatar        string   // 头像地址
+	Roles         []string // 角色或组声明的值，仅通用 OpenID Connect 服务配置了角色声明时返回
+}
+
+// Provider 第三方登录服务
+type Provider interface {
+	// Name 服务名称
+	Name() string
+	// AuthCodeURL 用户授权页地址，nonce 仅由支持 OpenID Connect 的服务写入 ID Token，
+	// 支持 PKCE 的服务使用 codeVerifier 生成 code_challenge
+	AuthCodeURL(state, nonce, codeVerifier, redirectURL string) string
+	// Exchange 使用授权码换取访问令牌并获取第三方账号信息，nonce 与 codeVerifier 为发起授权时生成的随机数
+	Exchange(ctx context.Context, code, nonce, codeVerifier, redirectURL string) (*Identity, error)
+}
+
+// Options 创建第三方登录服务所需的配置
+type Options struct {
+	ClientID     string
+	ClientSecret string
+	Timeout      time.Duration
+	OIDC         configs.OIDCConfig // 通用 OpenID Connect 服务的签发方、授权范围与声明映射
+}
+
+// Factory 第三方登录服务构造函数，未配置该服务时返回 ErrDisabled
+type Factory func(opts Options) (Provider, error)
+
+var factories = make(map[string]Factory)
+
+// Register 注册第三方登录服务
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// Names 已注册的第三方登录服务名称
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New 根据配置创建指定的第三方登录服务
+func New(config *configs.Config, provider string) (Provider, error) {
+	factory, ok := factories[provider]
+	if !ok {
+		return nil, fmt.Errorf("不支持的第三方登录服务: %s，可选值: %s", provider, strings.Join(Names(), ", "))
+	}
+
+	cfg := config.OAuthConfig
+	opts := Options{Timeout: time.Duration(cfg.OAuthTimeout) * time.Second}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	switch provider {
+	case ProviderGitHub:
+		opts.ClientID, opts.ClientSecret = cfg.OAuthGitHubClientID, cfg.OAuthGitHubClientSecret
+	case ProviderGoogle:
+		opts.ClientID, opts.ClientSecret = cfg.OAuthGoogleClientID, cfg.OAuthGoogleClientSecret
+	case ProviderGitee:
+		opts.ClientID, opts.ClientSecret = cfg.OAuthGiteeClientID, cfg.OAuthGiteeClientSecret
+	case ProviderOIDC:
+		// 公共客户端没有密钥，仅依靠 PKCE
+		opts.ClientID, opts.ClientSecret, opts.OIDC = config.OIDCConfig.OIDCClientID, config.OIDCConfig.OIDCClientSecret, config.OIDCConfig
+		if opts.ClientID == "" || opts.OIDC.OIDCIssuer == "" {
+			return nil, ErrDisabled
+		}
+		return factory(opts)
+	}
+	if opts.ClientID == "" || opts.ClientSecret == "" {
+		return nil, ErrDisabled
+	}
+	return factory(opts)
+}
+
+// codeChallenge 按 S256 方式由 code_verifier 生成 code_challenge，见 RFC 7636 4.2
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// token 授权码换取的令牌
+type token struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchangeToken 向令牌端点提交授权码，要求以 JSON 格式返回
+func exchangeToken(ctx context.Context, client *http.Client, endpoint string, form url.Values) (*token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求令牌失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result token
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析令牌响应失败: %v", err)
+	}
+	if result.Error != "" || result.AccessToken == "" {
+		return nil, fmt.Errorf("换取令牌失败: 状态码 %d, %s %s", resp.StatusCode, result.Error, result.ErrorDescription)
+	}
+	return &result, nil
+}
+
+// getJSON 携带访问令牌请求接口并解析 JSON 响应，accessToken 为空时由调用方在地址中携带令牌
+func getJSON(ctx context.Context, client *http.Client, endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// 地址中可能含有访问令牌，不记录原始错误
+		return errors.New("请求第三方账号信息失败")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("获取第三方账号信息失败: 状态码 %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("解析第三方账号信息失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,394 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	oidcDiscoveryPath   = "/.well-known/openid-configuration"
+	oidcDiscoveryTTL    = time.Hour        // 发现文档的缓存时间
+	oidcJWKSRefreshWait = time.Minute      // 遇到未知 kid 时重新获取公钥的最小间隔
+	oidcClockSkew       = 60 * time.Second // 校验 ID Token 时间时允许的时钟偏差
+)
+
+// oidcSigningMethods 接受的 ID Token 签名算法，不接受 none 与 HS 系列
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+func init() {
+	Register(ProviderOIDC, newOIDC)
+}
+
+// oidcDiscovery 发现文档中用到的字段
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcProviderCache 签发方的发现文档与公钥缓存，多次创建服务时共用
+type oidcProviderCache struct {
+	mu          sync.Mutex
+	discovery   *oidcDiscovery
+	discoveryAt time.Time
+	keys        map[string]interface{}
+	keysAt      time.Time
+}
+
+var (
+	oidcCachesMu sync.Mutex
+	oidcCaches   = make(map[string]*oidcProviderCache)
+)
+
+// oidc 通用 OpenID Connect 服务，创建时读取发现文档，端点与公钥通过发现文档获取，授权码流程使用 PKCE，ID Token 校验签名、签发方、受众与 nonce
+type oidc struct {
+	opts      Options
+	client    *http.Client
+	issuer    string
+	cache     *oidcProviderCache
+	discovery *oidcDiscovery
+}
+
+func newOIDC(opts Options) (Provider, error) {
+	issuer := strings.TrimRight(opts.OIDC.OIDCIssuer, "/")
+	oidcCachesMu.Lock()
+	cache, ok := oidcCaches[issuer]
+	if !ok {
+		cache = &oidcProviderCache{}
+		oidcCaches[issuer] = cache
+	}
+	oidcCachesMu.Unlock()
+
+	p := &oidc{opts: opts, client: &http.Client{Timeout: opts.Timeout}, issuer: issuer, cache: cache}
+	discovery, err := p.discover(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	p.discovery = discovery
+	return p, nil
+}
+
+func (p *oidc) Name() string {
+	return ProviderOIDC
+}
+
+func (p *oidc) AuthCodeURL(state, nonce, codeVerifier, redirectURL string) string {
+	scopes := p.opts.OIDC.OIDCScopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	if !slices.Contains(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	authURL := p.discovery.AuthorizationEndpoint
+	separator := "?"
+	if strings.Contains(authURL, "?") {
+		separator = "&"
+	}
+	return authURL + separator + url.Values{
+		"client_id":             {p.opts.ClientID},
+		"redirect_uri":          {redirectURL},
+		"response_type":         {"code"},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+}
+
+func (p *oidc) Exchange(ctx context.Context, code, nonce, codeVerifier, redirectURL string) (*Identity, error) {
+	discovery := p.discovery
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {p.opts.ClientID},
+		"code":          {code},
+		"code_verifier": {codeVerifier},
+		"redirect_uri":  {redirectURL},
+	}
+	if p.opts.ClientSecret != "" {
+		form.Set("client_secret", p.opts.ClientSecret)
+	}
+	tok, err := exchangeToken(ctx, p.client, discovery.TokenEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("OIDC 服务未返回 ID Token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, discovery, tok.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if p.opts.OIDC.OIDCUseUserInfo && discovery.UserInfoEndpoint != "" {
+		var info jwt.MapClaims
+		if err := getJSON(ctx, p.client, discovery.UserInfoEndpoint, tok.AccessToken, &info); err != nil {
+			return nil, err
+		}
+		// UserInfo 的 sub 必须与 ID Token 一致，防止替换响应，见 OpenID Connect Core 5.3.2
+		if info["sub"] != claims["sub"] {
+			return nil, errors.New("OIDC UserInfo 的 sub 与 ID Token 不一致")
+		}
+		for key, value := range info {
+			if _, ok := claims[key]; !ok {
+				claims[key] = value
+			}
+		}
+	}
+	return p.identity(claims)
+}
+
+// verifyIDToken 校验 ID Token 的签名、有效期、签发方、受众与 nonce
+func (p *oidc) verifyIDToken(ctx context.Context, discovery *oidcDiscovery, idToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(oidcSigningMethods), jwt.WithoutClaimsValidation())
+	if _, err := parser.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.signingKey(ctx, discovery, kid)
+	}); err != nil {
+		return nil, fmt.Errorf("OIDC ID Token 签名无效: %v", err)
+	}
+
+	now := time.Now()
+	if !claims.VerifyExpiresAt(now.Add(-oidcClockSkew).Unix(), true) {
+		return nil, errors.New("OIDC ID Token 已过期")
+	}
+	if !claims.VerifyIssuedAt(now.Add(oidcClockSkew).Unix(), false) {
+		return nil, errors.New("OIDC ID Token 签发时间无效")
+	}
+	if !claims.VerifyIssuer(discovery.Issuer, true) {
+		return nil, fmt.Errorf("OIDC ID Token 签发方无效: %v", claims["iss"])
+	}
+	if !claims.VerifyAudience(p.opts.ClientID, true) {
+		return nil, errors.New("OIDC ID Token 受众与客户端 ID 不一致")
+	}
+	// 返回 azp 时必须为本客户端，见 OpenID Connect Core 3.1.3.7
+	if azp, ok := claims["azp"].(string); ok && azp != p.opts.ClientID {
+		return nil, errors.New("OIDC ID Token 的 azp 与客户端 ID 不一致")
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return nil, errors.New("OIDC ID Token 中的 nonce 与发起授权时不一致")
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, errors.New("OIDC ID Token 缺少 sub")
+	}
+	return claims, nil
+}
+
+// identity 按配置的声明名称映射第三方账号信息
+func (p *oidc) identity(claims jwt.MapClaims) (*Identity, error) {
+	cfg := p.opts.OIDC
+	identity := &Identity{
+		Provider: ProviderOIDC,
+		Subject:  claimString(claims, "sub"),
+		Email:    claimString(claims, defaultClaim(cfg.OIDCEmailClaim, "email")),
+		Nickname: claimString(claims, defaultClaim(cfg.OIDCNameClaim, "name")),
+		Avatar:   claimString(claims, defaultClaim(cfg.OIDCAvatarClaim, "picture")),
+	}
+	if identity.Nickname == "" {
+		identity.Nickname = claimString(claims, "preferred_username")
+	}
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		// 部分服务以字符串返回
+		identity.EmailVerified = verified == "true"
+	case nil:
+		identity.EmailVerified = cfg.OIDCTrustEmail
+	}
+	if cfg.OIDCRolesClaim != "" {
+		identity.Roles = claimStrings(claims, cfg.OIDCRolesClaim)
+	}
+	return identity, nil
+}
+
+// discover 获取签发方的发现文档，缓存一小时，文档中的 issuer 必须与配置一致
+func (p *oidc) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.cache.mu.Lock()
+	defer p.cache.mu.Unlock()
+	if p.cache.discovery != nil && time.Since(p.cache.discoveryAt) < oidcDiscoveryTTL {
+		return p.cache.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := getJSON(ctx, p.client, p.issuer+oidcDiscoveryPath, "", &discovery); err != nil {
+		return nil, fmt.Errorf("获取 OIDC 发现文档失败: %v", err)
+	}
+	if strings.TrimRight(discovery.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("OIDC 发现文档的 issuer %q 与配置 %q 不一致", discovery.Issuer, p.issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("OIDC 发现文档缺少授权、令牌或公钥端点")
+	}
+	p.cache.discovery, p.cache.discoveryAt = &discovery, time.Now()
+	return &discovery, nil
+}
+
+// signingKey 按 kid 获取签名公钥，未知 kid 时重新获取公钥集以支持密钥轮换
+func (p *oidc) signingKey(ctx context.Context, discovery *oidcDiscovery, kid string) (interface{}, error) {
+	p.cache.mu.Lock()
+	defer p.cache.mu.Unlock()
+
+	if key, ok := lookupKey(p.cache.keys, kid); ok {
+		return key, nil
+	}
+	if p.cache.keys != nil && time.Since(p.cache.keysAt) < oidcJWKSRefreshWait {
+		return nil, fmt.Errorf("未找到 kid 为 %q 的公钥", kid)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := getJSON(ctx, p.client, discovery.JWKSURI, "", &set); err != nil {
+		return nil, fmt.Errorf("获取 OIDC 公钥失败: %v", err)
+	}
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if key, err := k.publicKey(); err == nil {
+			keys[k.Kid] = key
+		}
+	}
+	p.cache.keys, p.cache.keysAt = keys, time.Now()
+
+	if key, ok := lookupKey(keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("未找到 kid 为 %q 的公钥", kid)
+}
+
+// lookupKey 按 kid 查找公钥，ID Token 未携带 kid 且公钥集只有一个公钥时使用该公钥
+func lookupKey(keys map[string]interface{}, kid string) (interface{}, bool) {
+	if key, ok := keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// jwk 公钥集中的单个公钥，只支持 RSA 与 EC
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey 将 JWK 转为公钥
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("RSA 公钥指数无效")
+		}
+		exponent := 0
+		for _, b := range e {
+			exponent = exponent<<8 | int(b)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的椭圆曲线: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("EC 公钥不在曲线上")
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("不支持的公钥类型: %s", k.Kty)
+}
+
+// defaultClaim 未配置声明名称时使用默认名称
+func defaultClaim(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// claimValue 按以点分隔的路径读取声明，如 realm_access.roles
+func claimValue(claims jwt.MapClaims, path string) interface{} {
+	var value interface{} = map[string]interface{}(claims)
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// claimString 读取字符串声明
+func claimString(claims jwt.MapClaims, path string) string {
+	s, _ := claimValue(claims, path).(string)
+	return strings.TrimSpace(s)
+}
+
+// claimStrings 读取字符串或字符串数组声明
+func claimStrings(claims jwt.MapClaims, path string) []string {
+	switch value := claimValue(claims, path).(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package password
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"jank.com/jank_blog/configs"
+)
+
+// 密码哈希算法
+const (
+	AlgorithmArgon2id = "argon2id" // 默认算法，哈希为 PHC 格式 $argon2id$v=19$m=...,t=...,p=...$盐$摘要
+	AlgorithmBcrypt   = "bcrypt"   // 早期版本使用的算法，$2a$、$2b$、$2y$ 开头
+	AlgorithmMD5      = "md5"      // 旧系统迁移的无盐 MD5 十六进制摘要，只用于校验，登录后升级
+)
+
+// Argon2id 参数未配置时的默认值，参考 OWASP 推荐配置
+const (
+	defaultArgon2Memory      = 64 * 1024 // KiB
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 2
+	argon2SaltLength         = 16
+	argon2KeyLength          = 32
+)
+
+// ErrUnknownHash 无法识别的密码哈希格式
+var ErrUnknownHash = errors.New("无法识别的密码哈希格式")
+
+// hashParams 生成新哈希使用的算法与参数
+type hashParams struct {
+	algorithm   string
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	bcryptCost  int
+}
+
+// currentHashParams 读取配置中的哈希算法与参数，未配置的项使用默认值
+func currentHashParams() hashParams {
+	params := hashParams{
+		algorithm:   AlgorithmArgon2id,
+		memory:      defaultArgon2Memory,
+		iterations:  defaultArgon2Iterations,
+		parallelism: defaultArgon2Parallelism,
+		bcryptCost:  bcrypt.DefaultCost,
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return params
+	}
+
+	cfg := config.PasswordHashConfig
+	if cfg.PasswordHashAlgorithm == AlgorithmBcrypt {
+		params.algorithm = AlgorithmBcrypt
+	}
+	if cfg.PasswordHashArgon2Memory > 0 {
+		params.memory = uint32(cfg.PasswordHashArgon2Memory)
+	}
+	if cfg.PasswordHashArgon2Iterations > 0 {
+		params.iterations = uint32(cfg.PasswordHashArgon2Iterations)
+	}
+	if cfg.PasswordHashArgon2Parallelism > 0 {
+		params.parallelism = uint8(min(cfg.PasswordHashArgon2Parallelism, 255))
+	}
+	if cfg.PasswordHashBcryptCost >= bcrypt.MinCost && cfg.PasswordHashBcryptCost <= bcrypt.MaxCost {
+		params.bcryptCost = cfg.PasswordHashBcryptCost
+	}
+	return params
+}
+
+// Hash 按配置的算法与参数哈希密码
+func Hash(password string) (string, error) {
+	params := currentHashParams()
+	if params.algorithm == AlgorithmBcrypt {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), params.bcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("哈希加密失败: %v", err)
+		}
+		return string(hashed), nil
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("生成密码盐失败: %v", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, params.memory, params.iterations, params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Identify 根据哈希格式识别算法，无法识别时返回空字符串
+func Identify(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return AlgorithmArgon2id
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgorithmBcrypt
+	case len(hash) == md5.Size*2 && isHex(hash):
+		return AlgorithmMD5
+	}
+	return ""
+}
+
+// Verify 校验密码与哈希是否匹配，needsRehash 表示哈希的算法或参数与当前配置不一致，
+// 应在校验通过后用 Hash 重新生成并保存
+func Verify(password, hash string) (ok bool, needsRehash bool, err error) {
+	params := currentHashParams()
+	switch Identify(hash) {
+	case AlgorithmArgon2id:
+		stored, err := parseArgon2(hash)
+		if err != nil {
+			return false, false, err
+		}
+		key := argon2.IDKey([]byte(password), stored.salt, stored.iterations, stored.memory, stored.parallelism, uint32(len(stored.key)))
+		if subtle.ConstantTimeCompare(key, stored.key) != 1 {
+			return false, false, nil
+		}
+		return true, params.algorithm != AlgorithmArgon2id || stored.memory != params.memory ||
+			stored.iterations != params.iterations || stored.parallelism != params.parallelism, nil
+	case AlgorithmBcrypt:
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		cost, _ := bcrypt.Cost([]byte(hash))
+		return true, params.algorithm != AlgorithmBcrypt || cost != params.bcryptCost, nil
+	case AlgorithmMD5:
+		sum := md5.Sum([]byte(password))
+		if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(strings.ToLower(hash))) != 1 {
+			return false, false, nil
+		}
+		return true, true, nil
+	}
+	return false, false, ErrUnknownHash
+}
+
+// argon2Hash 解析后的 Argon2id 哈希
+type argon2Hash struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+// parseArgon2 解析 PHC 格式的 Argon2id 哈希
+func parseArgon2(hash string) (*argon2Hash, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return nil, ErrUnknownHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, fmt.Errorf("不支持的 Argon2 版本: %s", parts[2])
+	}
+
+	stored := &argon2Hash{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &stored.memory, &stored.iterations, &stored.parallelism); err != nil {
+		return nil, fmt.Errorf("Argon2 参数格式无效: %v", err)
+	}
+	var err error
+	if stored.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("Argon2 盐格式无效: %v", err)
+	}
+	if stored.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(stored.key) == 0 {
+		return nil, fmt.Errorf("Argon2 摘要格式无效: %v", err)
+	}
+	return stored, nil
+}
+
+// isHex 判断字符串是否只包含十六进制字符
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
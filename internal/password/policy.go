@@ -0,0 +1,208 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+)
+
+// 未配置时的默认策略
+const (
+	defaultMinLength = 8
+	defaultMaxLength = 64
+	maxBcryptBytes   = 72 // bcrypt 只使用密码的前 72 个字节，超出部分不参与校验
+)
+
+// 字符类型
+const (
+	ClassLower  = "lower"  // 小写字母
+	ClassUpper  = "upper"  // 大写字母
+	ClassDigit  = "digit"  // 数字
+	ClassSymbol = "symbol" // 符号，即字母与数字以外的字符
+)
+
+//go:embed common.txt
+var builtinCommon string
+
+var (
+	common     = parse(strings.NewReader(builtinCommon))
+	bannedMu   sync.Mutex
+	bannedFile string              // 已加载的弱密码文件路径
+	banned     map[string]struct{} // 内置列表与文件列表合并后的弱密码
+)
+
+// Policy 密码强度策略
+type Policy struct {
+	MinLength      int      // 最小长度，按字符计
+	MaxLength      int      // 最大长度，按字符计，且不超过 72 个字节
+	MinCharClasses int      // 至少包含的字符类型数
+	RequireClasses []string // 必须包含的字符类型
+	BanCommon      bool     // 是否拒绝常见弱密码
+	DisallowEmail  bool     // 是否拒绝使用邮箱或邮箱用户名作为密码
+	BannedFile     string   // 追加的弱密码列表文件
+}
+
+// Current 读取配置中的密码策略，长度未配置时使用默认值，读取配置失败时使用默认策略
+func Current() Policy {
+	policy := Policy{
+		MinLength:     defaultMinLength,
+		MaxLength:     defaultMaxLength,
+		BanCommon:     true,
+		DisallowEmail: true,
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return policy
+	}
+
+	cfg := config.PasswordPolicyConfig
+	if cfg.PasswordPolicyMinLength > 0 {
+		policy.MinLength = cfg.PasswordPolicyMinLength
+	}
+	if cfg.PasswordPolicyMaxLength > 0 {
+		policy.MaxLength = cfg.PasswordPolicyMaxLength
+	}
+	if policy.MaxLength > maxBcryptBytes {
+		policy.MaxLength = maxBcryptBytes
+	}
+	if policy.MinLength > policy.MaxLength {
+		policy.MinLength = policy.MaxLength
+	}
+	policy.MinCharClasses = min(max(cfg.PasswordPolicyMinCharClasses, 0), 4)
+	for _, class := range []struct {
+		name     string
+		required bool
+	}{
+		{ClassLower, cfg.PasswordPolicyRequireLower},
+		{ClassUpper, cfg.PasswordPolicyRequireUpper},
+		{ClassDigit, cfg.PasswordPolicyRequireDigit},
+		{ClassSymbol, cfg.PasswordPolicyRequireSymbol},
+	} {
+		if class.required {
+			policy.RequireClasses = append(policy.RequireClasses, class.name)
+		}
+	}
+	policy.BanCommon = cfg.PasswordPolicyBanCommon
+	policy.DisallowEmail = cfg.PasswordPolicyDisallowEmail
+	policy.BannedFile = cfg.PasswordPolicyBannedFile
+	return policy
+}
+
+// Check 按当前配置的策略校验密码
+func Check(password, email string) error {
+	return Current().Check(password, email)
+}
+
+// Check 校验密码是否符合策略，不符合时返回说明原因的错误
+func (p Policy) Check(password, email string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return fmt.Errorf("密码长度不能少于 %d 位", p.MinLength)
+	}
+	if length > p.MaxLength || len(password) > maxBcryptBytes {
+		return fmt.Errorf("密码长度不能超过 %d 位", p.MaxLength)
+	}
+
+	classes := charClasses(password)
+	for _, class := range p.RequireClasses {
+		if !classes[class] {
+			return fmt.Errorf("密码必须包含%s", classNames[class])
+		}
+	}
+	if len(classes) < p.MinCharClasses {
+		return fmt.Errorf("密码至少需要包含小写字母、大写字母、数字、符号中的 %d 种", p.MinCharClasses)
+	}
+
+	lower := strings.ToLower(password)
+	if p.DisallowEmail && email != "" {
+		email = strings.ToLower(strings.TrimSpace(email))
+		name, _, _ := strings.Cut(email, "@")
+		if lower == email || lower == name || strings.Contains(lower, email) {
+			return fmt.Errorf("密码不能与邮箱相同")
+		}
+	}
+	if p.BanCommon {
+		if _, ok := p.banned()[lower]; ok {
+			return fmt.Errorf("密码过于常见，请更换")
+		}
+	}
+	return nil
+}
+
+// classNames 字符类型的中文名称
+var classNames = map[string]string{
+	ClassLower:  "小写字母",
+	ClassUpper:  "大写字母",
+	ClassDigit:  "数字",
+	ClassSymbol: "符号",
+}
+
+// charClasses 统计密码包含的字符类型
+func charClasses(password string) map[string]bool {
+	classes := make(map[string]bool, 4)
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			classes[ClassLower] = true
+		case unicode.IsUpper(r):
+			classes[ClassUpper] = true
+		case unicode.IsDigit(r):
+			classes[ClassDigit] = true
+		case unicode.IsLetter(r):
+			// 无大小写之分的文字（如汉字）不计入任何字符类型
+		default:
+			classes[ClassSymbol] = true
+		}
+	}
+	return classes
+}
+
+// banned 返回内置列表与文件列表合并后的弱密码，文件路径变化时重新加载，读取失败时仅使用内置列表
+func (p Policy) banned() map[string]struct{} {
+	if p.BannedFile == "" {
+		return common
+	}
+
+	bannedMu.Lock()
+	defer bannedMu.Unlock()
+	if banned != nil && bannedFile == p.BannedFile {
+		return banned
+	}
+
+	f, err := os.Open(p.BannedFile)
+	if err != nil {
+		global.SysLog.Errorf("读取弱密码列表文件失败，仅使用内置列表: %v", err)
+		return common
+	}
+	defer f.Close()
+
+	merged := parse(f)
+	for word := range common {
+		merged[word] = struct{}{}
+	}
+	banned, bannedFile = merged, p.BannedFile
+	return banned
+}
+
+// parse 解析弱密码列表，每行一个，忽略空行与 # 开头的注释
+func parse(r io.Reader) map[string]struct{} {
+	words := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words[strings.ToLower(line)] = struct{}{}
+	}
+	return words
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+const keyPrefix = "RATE:LIMIT:" // 限流计数键前缀
+
+// slidingWindowScript 清理窗口外的记录后计数，未超限时记录本次请求
+// 返回 {是否允许, 超限时距离最早一条记录移出窗口的毫秒数}
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+if redis.call('ZCARD', KEYS[1]) >= limit then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	return {0, tonumber(oldest[2]) + window - now}
+end
+redis.call('ZADD', KEYS[1], now, ARGV[4])
+redis.call('PEXPIRE', KEYS[1], window)
+return {1, 0}
+`)
+
+// Rule 限流规则，Window 内最多允许 Limit 次请求
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Enabled 规则是否生效，限额或窗口未配置时不限流
+func (r Rule) Enabled() bool {
+	return r.Limit > 0 && r.Window > 0
+}
+
+// Allow 按滑动窗口判断 key 是否仍有剩余限额，允许时计入本次请求
+// 超限时返回需要等待的时长；Redis 不可用或规则未生效时始终允许
+func Allow(ctx context.Context, key string, rule Rule) (bool, time.Duration, error) {
+	if global.RedisClient == nil || !rule.Enabled() {
+		return true, 0, nil
+	}
+
+	now := time.Now().UnixMilli()
+	member := fmt.Sprintf("%d-%d", now, rand.Int63())
+	result, err := slidingWindowScript.Run(ctx, global.RedisClient, []string{keyPrefix + key},
+		now, rule.Window.Milliseconds(), rule.Limit, member).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if result[0] == 1 {
+		return true, 0, nil
+	}
+	return false, time.Duration(result[1]) * time.Millisecond, nil
+}
@@ -10,11 +10,14 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
 	"jank.com/jank_blog/internal/global"
 )
 
 func New(config *configs.Config) {
 	client := newRedisClient(config)
+	// 连接失败时同样交给缓存做健康检查，Redis 恢复后验证码等数据自动切回 Redis
+	cache.Init(config, client)
 	if err := client.Ping(context.Background()).Err(); err != nil {
 		global.SysLog.Errorf("Redis 连接失败: %v", err)
 		return
@@ -0,0 +1,365 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+const maxLogs = 50 // 每个任务保留的最近运行记录数
+
+// 任务触发方式
+const (
+	TriggerSchedule = "schedule" // 按周期自动触发
+	TriggerManual   = "manual"   // 手动触发
+)
+
+var (
+	ErrTaskNotFound = errors.New("定时任务不存在")
+	ErrTaskRunning  = errors.New("定时任务正在运行")
+)
+
+// Task 定时任务
+type Task struct {
+	Name        string                          // 任务名称，全局唯一
+	Description string                          // 任务说明
+	Interval    time.Duration                   // 运行周期
+	Run         func(ctx context.Context) error // 任务逻辑
+}
+
+// RunLog 任务运行记录
+type RunLog struct {
+	Trigger   string        // 触发方式
+	StartedAt time.Time     // 开始时间
+	Duration  time.Duration // 运行耗时
+	Error     string        // 错误信息，成功时为空
+}
+
+// Status 任务运行状态
+type Status struct {
+	Name         string
+	Description  string
+	Interval     time.Duration
+	Paused       bool
+	Running      bool
+	NextRun      time.Time // 下次运行时间，暂停时为零值
+	LastRun      time.Time // 上次运行时间，未运行过时为零值
+	LastDuration time.Duration
+	LastError    string
+}
+
+// entry 已注册任务及其运行状态
+type entry struct {
+	task    Task
+	mu      sync.Mutex
+	paused  bool
+	running bool
+	nextRun time.Time
+	logs    []RunLog
+	resume  chan struct{}
+}
+
+// Scheduler 进程内定时任务调度器
+type Scheduler struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+var defaultScheduler = New()
+
+// New 创建调度器
+func New() *Scheduler {
+	return &Scheduler{entries: make(map[string]*entry)}
+}
+
+// Register 向默认调度器注册任务
+func Register(task Task) {
+	defaultScheduler.Register(task)
+}
+
+// Start 启动默认调度器
+func Start() {
+	defaultScheduler.Start()
+}
+
+// Stop 停止默认调度器，等待运行中的任务结束
+func Stop() {
+	defaultScheduler.Stop()
+}
+
+// List 列出默认调度器中的任务
+func List() []Status {
+	return defaultScheduler.List()
+}
+
+// Trigger 立即运行默认调度器中的任务
+func Trigger(name string) error {
+	return defaultScheduler.Trigger(name)
+}
+
+// Pause 暂停默认调度器中的任务
+func Pause(name string) error {
+	return defaultScheduler.Pause(name)
+}
+
+// Resume 恢复默认调度器中的任务
+func Resume(name string) error {
+	return defaultScheduler.Resume(name)
+}
+
+// Logs 获取默认调度器中任务的最近运行记录
+func Logs(name string) ([]RunLog, error) {
+	return defaultScheduler.Logs(name)
+}
+
+// Register 注册任务，同名任务会被忽略；调度器已启动时立即开始调度
+func (s *Scheduler) Register(task Task) {
+	if task.Name == "" || task.Run == nil || task.Interval <= 0 {
+		global.SysLog.Errorf("注册定时任务 %q 失败: 缺少名称、周期或任务逻辑", task.Name)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.entries[task.Name]; ok {
+		global.SysLog.Warnf("定时任务 %q 已注册，忽略重复注册", task.Name)
+		return
+	}
+
+	e := &entry{task: task, resume: make(chan struct{}, 1)}
+	s.entries[task.Name] = e
+	if s.ctx != nil {
+		s.schedule(e)
+	}
+}
+
+// Start 启动调度器
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx != nil {
+		return
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	for _, e := range s.entries {
+		s.schedule(e)
+	}
+}
+
+// Stop 停止调度器，等待运行中的任务结束
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.ctx, s.cancel = nil, nil
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// List 按名称顺序列出任务状态
+func (s *Scheduler) List() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(s.entries))
+	for _, e := range s.entries {
+		statuses = append(statuses, e.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// Trigger 在后台立即运行任务，不影响其周期调度
+func (s *Scheduler) Trigger(name string) error {
+	e, err := s.get(name)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	if e.running {
+		e.mu.Unlock()
+		return ErrTaskRunning
+	}
+	e.running = true
+	e.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		e.execute(context.Background(), TriggerManual)
+	}()
+	return nil
+}
+
+// Pause 暂停任务的周期调度，正在运行的任务不受影响
+func (s *Scheduler) Pause(name string) error {
+	e, err := s.get(name)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.paused = true
+	e.mu.Unlock()
+	return nil
+}
+
+// Resume 恢复任务的周期调度，从恢复时刻起重新计算下次运行时间
+func (s *Scheduler) Resume(name string) error {
+	e, err := s.get(name)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	wasPaused := e.paused
+	e.paused = false
+	e.mu.Unlock()
+
+	if wasPaused {
+		select {
+		case e.resume <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Logs 获取任务的最近运行记录，按时间倒序排列
+func (s *Scheduler) Logs(name string) ([]RunLog, error) {
+	e, err := s.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	logs := make([]RunLog, len(e.logs))
+	for i, log := range e.logs {
+		logs[len(e.logs)-1-i] = log
+	}
+	return logs, nil
+}
+
+// get 根据名称获取任务
+func (s *Scheduler) get(name string) (*entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.entries[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, name)
+	}
+	return e, nil
+}
+
+// schedule 启动任务的调度协程，调用方需持有 s.mu
+func (s *Scheduler) schedule(e *entry) {
+	ctx := s.ctx
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		e.loop(ctx)
+	}()
+}
+
+// loop 按周期运行任务，上一次运行未结束时跳过本次调度
+func (e *entry) loop(ctx context.Context) {
+	for {
+		e.mu.Lock()
+		e.nextRun = time.Now().Add(e.task.Interval)
+		wait := e.task.Interval
+		e.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-e.resume:
+			timer.Stop()
+			continue
+		case <-timer.C:
+		}
+
+		e.mu.Lock()
+		skip := e.paused || e.running
+		if !skip {
+			e.running = true
+		}
+		e.mu.Unlock()
+
+		if !skip {
+			e.execute(ctx, TriggerSchedule)
+		}
+	}
+}
+
+// execute 运行任务并记录结果，调用前需将 running 置为 true
+func (e *entry) execute(ctx context.Context, trigger string) {
+	start := time.Now()
+	err := e.safeRun(ctx)
+	elapsed := time.Since(start)
+
+	log := RunLog{Trigger: trigger, StartedAt: start, Duration: elapsed}
+	if err != nil {
+		log.Error = err.Error()
+		global.SysLog.Errorf("定时任务 %s 运行失败: %v", e.task.Name, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running = false
+	e.logs = append(e.logs, log)
+	if len(e.logs) > maxLogs {
+		e.logs = e.logs[len(e.logs)-maxLogs:]
+	}
+}
+
+// safeRun 运行任务逻辑，将 panic 转换为错误
+func (e *entry) safeRun(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("任务发生 panic: %v", r)
+		}
+	}()
+	return e.task.Run(ctx)
+}
+
+// status 生成任务状态快照
+func (e *entry) status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	st := Status{
+		Name:        e.task.Name,
+		Description: e.task.Description,
+		Interval:    e.task.Interval,
+		Paused:      e.paused,
+		Running:     e.running,
+	}
+	if !e.paused {
+		st.NextRun = e.nextRun
+	}
+	if n := len(e.logs); n > 0 {
+		last := e.logs[n-1]
+		st.LastRun = last.StartedAt
+		st.LastDuration = last.Duration
+		st.LastError = last.Error
+	}
+	return st
+}
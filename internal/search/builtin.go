@@ -0,0 +1,104 @@
+package search
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+)
+
+func init() {
+	RegisterIndexer("builtin", newBuiltinIndexer)
+}
+
+// builtinIndexer 基于嵌入式 Index 的全文索引服务，每种文档类型保存为目录下的一个 Bleve 索引目录
+type builtinIndexer struct {
+	dir     string
+	mu      sync.Mutex
+	indexes map[string]*Index
+}
+
+func newBuiltinIndexer(opts IndexerOptions) (Indexer, error) {
+	return &builtinIndexer{dir: opts.Dir, indexes: make(map[string]*Index)}, nil
+}
+
+func (b *builtinIndexer) Name() string {
+	return "builtin"
+}
+
+func (b *builtinIndexer) Put(_ context.Context, docType string, docs ...IndexDocument) error {
+	idx, err := b.index(docType)
+	if idx == nil {
+		return err
+	}
+	for _, doc := range docs {
+		if err := idx.Put(doc); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (b *builtinIndexer) Delete(_ context.Context, docType string, ids ...int64) error {
+	idx, err := b.index(docType)
+	if idx == nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := idx.Delete(id); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (b *builtinIndexer) Reindex(_ context.Context, docType string, docs []IndexDocument) error {
+	idx, err := b.index(docType)
+	if idx == nil {
+		return err
+	}
+	return idx.Replace(docs)
+}
+
+func (b *builtinIndexer) Search(_ context.Context, docType string, query Query) ([]IndexHit, int, error) {
+	idx, err := b.index(docType)
+	if idx == nil {
+		return nil, 0, err
+	}
+	hits, total, searchErr := idx.Search(query.Keyword, query.Options, query.Offset, query.Limit)
+	if searchErr != nil {
+		return nil, 0, searchErr
+	}
+	return hits, total, err
+}
+
+func (b *builtinIndexer) Count(_ context.Context, docType string) (int, error) {
+	idx, err := b.index(docType)
+	if idx == nil {
+		return 0, err
+	}
+	count, countErr := idx.Len()
+	if countErr != nil {
+		return 0, countErr
+	}
+	return count, err
+}
+
+// index 获取文档类型对应的索引，首次使用时从目录加载；索引损坏时返回空索引与错误，调用方重建索引；
+// 无法创建索引时返回 nil 与错误，下次使用时重试
+func (b *builtinIndexer) index(docType string) (*Index, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if idx, ok := b.indexes[docType]; ok {
+		return idx, nil
+	}
+
+	var path string
+	if b.dir != "" {
+		path = filepath.Join(b.dir, docType+".bleve")
+	}
+	idx, err := OpenIndex(path)
+	if idx != nil {
+		b.indexes[docType] = idx
+	}
+	return idx, err
+}
@@ -0,0 +1,207 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func init() {
+	RegisterIndexer("elasticsearch", newElasticsearch)
+}
+
+// elasticsearch Elasticsearch 全文索引服务，重建时以内置的 cjk 分析器建立映射，中日韩文字按相邻两字切分
+type elasticsearch struct {
+	remoteClient
+	prefix string
+}
+
+func newElasticsearch(opts IndexerOptions) (Indexer, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("未配置 Elasticsearch 地址 SEARCH_BACKEND_URL")
+	}
+	e := &elasticsearch{
+		remoteClient: remoteClient{name: "Elasticsearch", url: opts.URL, client: &http.Client{Timeout: opts.Timeout}},
+		prefix:       opts.IndexPrefix,
+	}
+	if opts.APIKey != "" {
+		e.auth = "ApiKey " + opts.APIKey
+	}
+	return e, nil
+}
+
+func (e *elasticsearch) Name() string {
+	return "elasticsearch"
+}
+
+func (e *elasticsearch) Put(ctx context.Context, docType string, docs ...IndexDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]interface{}{"index": map[string]string{"_index": e.index(docType), "_id": strconv.FormatInt(doc.ID, 10)}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(toRemoteDocument(doc)); err != nil {
+			return err
+		}
+	}
+	return e.bulk(ctx, body.Bytes())
+}
+
+func (e *elasticsearch) Delete(ctx context.Context, docType string, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, id := range ids {
+		action := map[string]interface{}{"delete": map[string]string{"_index": e.index(docType), "_id": strconv.FormatInt(id, 10)}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+	}
+	return e.bulk(ctx, body.Bytes())
+}
+
+// Reindex 删除索引后按映射重新创建并分批写入，完成后刷新索引使文档立即可被检索
+func (e *elasticsearch) Reindex(ctx context.Context, docType string, docs []IndexDocument) error {
+	path := "/" + url.PathEscape(e.index(docType))
+	if status, err := e.doJSON(ctx, http.MethodDelete, path, nil, nil); err != nil && status != http.StatusNotFound {
+		return err
+	}
+	text := map[string]string{"type": "text", "analyzer": "cjk"}
+	mapping := map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"id":        map[string]string{"type": "long"},
+				"parent_id": map[string]string{"type": "long"},
+				"title":     text,
+				"content":   text,
+				"tags":      text,
+			},
+		},
+	}
+	if _, err := e.doJSON(ctx, http.MethodPut, path, mapping, nil); err != nil {
+		return err
+	}
+	for _, batch := range batches(docs, reindexBatchSize) {
+		if err := e.Put(ctx, docType, batch...); err != nil {
+			return err
+		}
+	}
+	_, err := e.doJSON(ctx, http.MethodPost, path+"/_refresh", nil, nil)
+	return err
+}
+
+func (e *elasticsearch) Search(ctx context.Context, docType string, query Query) ([]IndexHit, int, error) {
+	match := map[string]interface{}{
+		"query":    query.Keyword,
+		"fields":   []string{"title^3", "tags^2", "content"},
+		"operator": "or",
+	}
+	if query.Fuzzy {
+		match["fuzziness"] = "AUTO"
+	}
+	payload := map[string]interface{}{
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"query":            map[string]interface{}{"multi_match": match},
+		"highlight": map[string]interface{}{
+			"pre_tags":  []string{remoteHighlightOpen},
+			"post_tags": []string{remoteHighlightClose},
+			"fields": map[string]interface{}{
+				"title":   map[string]int{"number_of_fragments": 0},
+				"content": map[string]int{"fragment_size": snippetLength, "number_of_fragments": 1, "no_match_size": snippetLength},
+			},
+		},
+	}
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Score     float64             `json:"_score"`
+				Source    remoteDocument      `json:"_source"`
+				Highlight map[string][]string `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	status, err := e.doJSON(ctx, http.MethodPost, "/"+url.PathEscape(e.index(docType))+"/_search", payload, &result)
+	if status == http.StatusNotFound {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]IndexHit, len(result.Hits.Hits))
+	for i, hit := range result.Hits.Hits {
+		title, snippet := remoteHighlight(hit.Source.Title), remoteHighlight(truncate(hit.Source.Content, snippetLength))
+		if fragments := hit.Highlight["title"]; len(fragments) > 0 {
+			title = remoteHighlight(fragments[0])
+		}
+		if fragments := hit.Highlight["content"]; len(fragments) > 0 {
+			snippet = remoteHighlight(fragments[0])
+		}
+		hits[i] = IndexHit{
+			ID:       hit.Source.ID,
+			ParentID: hit.Source.ParentID,
+			Score:    hit.Score,
+			Title:    title,
+			Snippet:  snippet,
+			Tags:     hit.Source.Tags,
+		}
+	}
+	return hits, result.Hits.Total.Value, nil
+}
+
+func (e *elasticsearch) Count(ctx context.Context, docType string) (int, error) {
+	var result struct {
+		Count int `json:"count"`
+	}
+	status, err := e.doJSON(ctx, http.MethodGet, "/"+url.PathEscape(e.index(docType))+"/_count", nil, &result)
+	if status == http.StatusNotFound {
+		return 0, nil
+	}
+	return result.Count, err
+}
+
+// bulk 调用 _bulk 接口，删除不存在的文档不视为失败
+func (e *elasticsearch) bulk(ctx context.Context, body []byte) error {
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if _, err := e.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body, &result); err != nil {
+		return err
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for action, detail := range item {
+			if detail.Status >= 300 && !(action == "delete" && detail.Status == http.StatusNotFound) {
+				return fmt.Errorf("Elasticsearch 批量写入失败: %s", truncate(string(detail.Error), 200))
+			}
+		}
+	}
+	return nil
+}
+
+// index 文档类型对应的索引名称
+func (e *elasticsearch) index(docType string) string {
+	return e.prefix + docType
+}
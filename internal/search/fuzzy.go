@@ -0,0 +1,145 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 匹配字段的权重，标题命中比正文命中更相关
+const (
+	titleWeight   = 3.0
+	contentWeight = 1.0
+)
+
+// minFuzzyTermLen 参与模糊匹配的最短关键词长度，过短的词容易误匹配
+const minFuzzyTermLen = 4
+
+// Document 待检索的文档
+type Document struct {
+	ID      int64
+	Title   string
+	Content string
+}
+
+// Options 检索配置
+type Options struct {
+	Fuzzy       bool    // 是否开启模糊匹配
+	MaxDistance int     // 模糊匹配允许的最大编辑距离
+	Penalty     float64 // 模糊命中的相关度折扣，取值 0 ~ 1
+}
+
+// Hit 检索结果
+type Hit struct {
+	ID    int64   // 文档 ID
+	Score float64 // 相关度
+	Fuzzy bool    // 是否仅通过模糊匹配命中
+}
+
+// Match 在文档中检索关键词，按相关度倒序返回命中的文档，相关度相同时保持文档原有顺序
+// 每个关键词优先按子串精确匹配，未命中时在开启模糊匹配的情况下按编辑距离匹配词语，
+// 模糊命中的得分随编辑距离递减并乘以 (1 - Penalty)
+func Match(query string, docs []Document, opts Options) []Hit {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var hits []Hit
+	for _, doc := range docs {
+		title, content := strings.ToLower(doc.Title), strings.ToLower(doc.Content)
+		var titleTokens, contentTokens []string
+		if opts.Fuzzy {
+			titleTokens, contentTokens = Tokenize(doc.Title), Tokenize(doc.Content)
+		}
+
+		hit := Hit{ID: doc.ID}
+		exact := false
+		for _, term := range terms {
+			switch {
+			case strings.Contains(title, term):
+				hit.Score += titleWeight
+				exact = true
+			case strings.Contains(content, term):
+				hit.Score += contentWeight
+				exact = true
+			case opts.Fuzzy:
+				score := fuzzyScore(term, titleTokens, opts) * titleWeight
+				if score == 0 {
+					score = fuzzyScore(term, contentTokens, opts) * contentWeight
+				}
+				hit.Score += score
+			}
+		}
+		if hit.Score > 0 {
+			hit.Fuzzy = !exact
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits
+}
+
+// fuzzyScore 关键词与词语列表中最相近词语的模糊得分，未命中时为 0
+func fuzzyScore(term string, tokens []string, opts Options) float64 {
+	termLen := utf8.RuneCountInString(term)
+	if termLen < minFuzzyTermLen || opts.MaxDistance <= 0 {
+		return 0
+	}
+
+	// 允许的编辑距离不超过关键词长度的三分之一
+	maxDistance := opts.MaxDistance
+	if limit := termLen / 3; limit < maxDistance {
+		maxDistance = limit
+	}
+
+	best := maxDistance + 1
+	for _, token := range tokens {
+		diff := utf8.RuneCountInString(token) - termLen
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff >= best {
+			continue
+		}
+		if d := Levenshtein(term, token); d < best {
+			best = d
+		}
+	}
+	if best > maxDistance {
+		return 0
+	}
+	return (1 - opts.Penalty) * (1 - float64(best)/float64(maxDistance+1))
+}
+
+// Tokenize 将文本切分为小写词语，连续的字母或数字为一个词
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Levenshtein 计算两个字符串按字符的编辑距离
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// snippetLength 检索结果中正文片段的长度（字符）
+const snippetLength = 160
+
+// snippetLead 正文片段中首个命中的关键词之前保留的字符数
+const snippetLead = 40
+
+// 高亮关键词的 HTML 标签
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+)
+
+// Highlight 将 text 中出现的 terms 以 <mark> 标记并转义为 HTML，terms 应为 Analyze 的结果；
+// maxLen 大于 0 时只返回以首个命中位置为中心、长度约 maxLen 字符的片段，截断处以省略号表示
+func Highlight(text string, terms []string, maxLen int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		pattern := []rune(term)
+		latin := !isCJK(pattern[0])
+		for i := 0; i+len(pattern) <= len(lower); i++ {
+			if !hasPrefix(lower[i:], pattern) {
+				continue
+			}
+			// 西文词语需完整匹配，避免高亮其他单词中的片段
+			if latin && (i > 0 && isLatinWord(lower[i-1]) || i+len(pattern) < len(lower) && isLatinWord(lower[i+len(pattern)])) {
+				continue
+			}
+			for j := i; j < i+len(pattern); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+
+	start, end := 0, len(runes)
+	if maxLen > 0 && len(runes) > maxLen {
+		if first > snippetLead {
+			start = first - snippetLead
+		}
+		end = min(start+maxLen, len(runes))
+		start = max(end-maxLen, 0)
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("…")
+	}
+	for i := start; i < end; i++ {
+		if marked[i] && (i == start || !marked[i-1]) {
+			sb.WriteString(highlightOpen)
+		}
+		sb.WriteString(html.EscapeString(string(runes[i])))
+		if marked[i] && (i == end-1 || !marked[i+1]) {
+			sb.WriteString(highlightClose)
+		}
+	}
+	if end < len(runes) {
+		sb.WriteString("…")
+	}
+	return sb.String()
+}
+
+// hasPrefix 判断 s 是否以 prefix 开头
+func hasPrefix(s, prefix []rune) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if s[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// isLatinWord 判断字符是否属于西文单词，中日韩文字与标点不属于
+func isLatinWord(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !isCJK(r)
+}
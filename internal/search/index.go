@@ -0,0 +1,411 @@
+package search
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	bleveSearch "github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/blevesearch/bleve/v2/search/searcher"
+)
+
+// 索引字段的权重，与 Match 一致，标题命中比标签、正文命中更相关
+const (
+	indexTitleBoost   = 3.0
+	indexTagBoost     = 2.0
+	indexContentBoost = 1.0
+)
+
+// indexMappingVersion 索引映射版本，字段或分词方式变化时旧索引视为无效，需要重建
+const indexMappingVersion = "1"
+
+// indexVersionKey 索引中保存映射版本的内部键
+var indexVersionKey = []byte("jank_mapping_version")
+
+// IndexDocument 写入全文索引的文档
+type IndexDocument struct {
+	ID       int64
+	ParentID int64 // 所属的文档 ID，如评论所属的文章，没有时为 0
+	Title    string
+	Content  string   // 纯文本正文
+	Tags     []string // 标签
+}
+
+// IndexHit 全文检索结果
+type IndexHit struct {
+	ID       int64
+	ParentID int64    // 所属的文档 ID
+	Score    float64  // 相关度
+	Title    string   // 高亮后的标题，已转义为 HTML
+	Snippet  string   // 正文中命中关键词的片段，已高亮并转义为 HTML，正文未命中时为开头的片段
+	Tags     []string // 文档的标签
+}
+
+// Index 基于 Bleve 的嵌入式全文索引，标题、标签与正文使用 CJK 分析器，中日韩文字按相邻两字切分；
+// 索引保存在本地目录中，重启后无需重建，可并发读写
+type Index struct {
+	mu        sync.RWMutex // 保护 index，重建索引时替换
+	path      string
+	index     bleve.Index
+	replaceMu sync.Mutex // 保证同一时间只有一次重建索引
+}
+
+// indexedDoc 写入 Bleve 的文档字段
+type indexedDoc struct {
+	ID       int64    `json:"id"`
+	ParentID int64    `json:"parent_id"`
+	Title    string   `json:"title"`
+	Tags     []string `json:"tags"`
+	Content  string   `json:"content"`
+}
+
+var fieldBoosts = map[string]float64{"title": indexTitleBoost, "tags": indexTagBoost, "content": indexContentBoost}
+
+// OpenIndex 打开 path 处的索引目录，目录不存在时创建空索引，path 为空时索引仅保存在内存中；
+// 索引损坏或映射版本不一致时删除原目录并返回空索引与错误，调用方应重建索引
+func OpenIndex(path string) (*Index, error) {
+	if path == "" {
+		index, err := bleve.NewMemOnly(newIndexMapping())
+		if err != nil {
+			return nil, fmt.Errorf("创建全文索引失败: %v", err)
+		}
+		return &Index{index: index}, nil
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		index, err := createIndex(path)
+		if err != nil {
+			return nil, err
+		}
+		return &Index{path: path, index: index}, nil
+	}
+
+	index, openErr := bleve.Open(path)
+	if openErr == nil {
+		version, err := index.GetInternal(indexVersionKey)
+		if err == nil && string(version) == indexMappingVersion {
+			return &Index{path: path, index: index}, nil
+		}
+		index.Close()
+		if err != nil {
+			openErr = fmt.Errorf("读取全文索引版本失败: %v", err)
+		} else {
+			openErr = fmt.Errorf("全文索引版本 %q 与当前版本 %s 不一致", version, indexMappingVersion)
+		}
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return nil, fmt.Errorf("删除无效的全文索引失败: %v", err)
+	}
+	index, err := createIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Index{path: path, index: index}, fmt.Errorf("打开全文索引失败: %v", openErr)
+}
+
+// Put 写入或更新文档
+func (idx *Index) Put(doc IndexDocument) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if err := idx.index.Index(docID(doc.ID), toIndexed(doc)); err != nil {
+		return fmt.Errorf("写入全文索引失败: %v", err)
+	}
+	return nil
+}
+
+// Delete 删除文档，文档不存在时不做处理
+func (idx *Index) Delete(id int64) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	if err := idx.index.Delete(docID(id)); err != nil {
+		return fmt.Errorf("删除全文索引失败: %v", err)
+	}
+	return nil
+}
+
+// Replace 以 docs 替换索引中的全部文档；新索引先写入临时目录，完成后再替换原目录，重建期间原索引仍可检索
+func (idx *Index) Replace(docs []IndexDocument) error {
+	idx.replaceMu.Lock()
+	defer idx.replaceMu.Unlock()
+
+	var (
+		index bleve.Index
+		err   error
+		tmp   = idx.path + ".tmp"
+	)
+	if idx.path == "" {
+		index, err = bleve.NewMemOnly(newIndexMapping())
+	} else {
+		if err := os.RemoveAll(tmp); err != nil {
+			return fmt.Errorf("删除全文索引临时目录失败: %v", err)
+		}
+		index, err = createIndex(tmp)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range batches(docs, reindexBatchSize) {
+		batch := index.NewBatch()
+		for _, doc := range chunk {
+			if err := batch.Index(docID(doc.ID), toIndexed(doc)); err != nil {
+				index.Close()
+				return fmt.Errorf("写入全文索引失败: %v", err)
+			}
+		}
+		if err := index.Batch(batch); err != nil {
+			index.Close()
+			return fmt.Errorf("写入全文索引失败: %v", err)
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.path == "" {
+		idx.index.Close()
+		idx.index = index
+		return nil
+	}
+
+	// Bleve 的索引目录只能在关闭后替换
+	if err := index.Close(); err != nil {
+		return fmt.Errorf("关闭全文索引失败: %v", err)
+	}
+	if err := idx.index.Close(); err != nil {
+		return fmt.Errorf("关闭全文索引失败: %v", err)
+	}
+	if err := os.RemoveAll(idx.path); err != nil {
+		return fmt.Errorf("删除原全文索引失败: %v", err)
+	}
+	if err := os.Rename(tmp, idx.path); err != nil {
+		return fmt.Errorf("替换全文索引目录失败: %v", err)
+	}
+	if idx.index, err = bleve.Open(idx.path); err != nil {
+		return fmt.Errorf("打开全文索引失败: %v", err)
+	}
+	return nil
+}
+
+// Len 返回索引中的文档数
+func (idx *Index) Len() (int, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	count, err := idx.index.DocCount()
+	if err != nil {
+		return 0, fmt.Errorf("读取全文索引文档数失败: %v", err)
+	}
+	return int(count), nil
+}
+
+// Search 检索关键词，按相关度倒序返回第 offset 条起的至多 limit 条结果及命中的总数，limit 不大于 0 时返回全部结果；
+// 命中的关键词越多排序越靠前，相关度相同时 ID 大的（较新的）文档在前。
+// 开启模糊匹配时，西文关键词同时按编辑距离匹配相近的词语，得分按 opts.Penalty 打折扣
+func (idx *Index) Search(keyword string, opts Options, offset, limit int) ([]IndexHit, int, error) {
+	terms := uniqueTerms(Analyze(keyword))
+	if len(terms) == 0 {
+		return nil, 0, nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	disjuncts := make([]query.Query, 0, len(fieldBoosts))
+	for field, boost := range fieldBoosts {
+		match := bleve.NewMatchQuery(keyword)
+		match.SetField(field)
+		match.Analyzer = cjk.AnalyzerName
+		match.SetBoost(boost)
+		disjuncts = append(disjuncts, match)
+		for _, term := range fuzzyTerms(terms, opts) {
+			fuzzy := bleve.NewFuzzyQuery(term)
+			fuzzy.SetField(field)
+			fuzzy.SetFuzziness(min(opts.MaxDistance, searcher.MaxFuzziness))
+			fuzzy.SetBoost(boost * (1 - opts.Penalty))
+			disjuncts = append(disjuncts, fuzzy)
+		}
+	}
+
+	size := limit
+	if size <= 0 {
+		count, err := idx.index.DocCount()
+		if err != nil {
+			return nil, 0, fmt.Errorf("读取全文索引文档数失败: %v", err)
+		}
+		size = int(count)
+	}
+	req := bleve.NewSearchRequestOptions(bleve.NewDisjunctionQuery(disjuncts...), size, max(offset, 0), false)
+	req.Fields = []string{"parent_id", "title", "tags", "content"}
+	req.IncludeLocations = true
+	req.SortByCustom(bleveSearch.SortOrder{
+		&bleveSearch.SortScore{Desc: true},
+		&bleveSearch.SortField{Field: "id", Type: bleveSearch.SortFieldAsNumber, Desc: true},
+	})
+	result, err := idx.index.Search(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("全文检索失败: %v", err)
+	}
+
+	hits := make([]IndexHit, 0, len(result.Hits))
+	for _, match := range result.Hits {
+		id, err := strconv.ParseInt(match.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		// 以实际命中的索引词语高亮，包含模糊匹配到的相近词语
+		var highlights []string
+		for _, locations := range match.Locations {
+			for term := range locations {
+				highlights = append(highlights, term)
+			}
+		}
+		title, _ := match.Fields["title"].(string)
+		content, _ := match.Fields["content"].(string)
+		parentID, _ := match.Fields["parent_id"].(float64)
+		hits = append(hits, IndexHit{
+			ID:       id,
+			ParentID: int64(parentID),
+			Score:    match.Score,
+			Title:    Highlight(title, highlights, 0),
+			Snippet:  Highlight(content, highlights, snippetLength),
+			Tags:     storedStrings(match.Fields["tags"]),
+		})
+	}
+	return hits, int(result.Total), nil
+}
+
+// fuzzyTerms 参与模糊匹配的西文关键词，过短的关键词与中日韩文字不做模糊匹配
+func fuzzyTerms(terms []string, opts Options) []string {
+	if !opts.Fuzzy || opts.MaxDistance <= 0 {
+		return nil
+	}
+	var result []string
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) >= minFuzzyTermLen && !isCJK([]rune(term)[0]) {
+			result = append(result, term)
+		}
+	}
+	return result
+}
+
+// newIndexMapping 全文索引的字段映射，标题、标签与正文使用 CJK 分析器并保存原文用于高亮，ID 仅用于排序
+func newIndexMapping() mapping.IndexMapping {
+	text := func() *mapping.FieldMapping {
+		field := bleve.NewTextFieldMapping()
+		field.Analyzer = cjk.AnalyzerName
+		return field
+	}
+	id := bleve.NewNumericFieldMapping()
+	id.Store = false
+	parentID := bleve.NewNumericFieldMapping()
+	parentID.Index = false
+	parentID.DocValues = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("id", id)
+	doc.AddFieldMappingsAt("parent_id", parentID)
+	doc.AddFieldMappingsAt("title", text())
+	doc.AddFieldMappingsAt("tags", text())
+	doc.AddFieldMappingsAt("content", text())
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	m.DefaultAnalyzer = cjk.AnalyzerName
+	return m
+}
+
+// createIndex 在 path 处创建空索引并写入映射版本
+func createIndex(path string) (bleve.Index, error) {
+	index, err := bleve.New(path, newIndexMapping())
+	if err != nil {
+		return nil, fmt.Errorf("创建全文索引失败: %v", err)
+	}
+	if err := index.SetInternal(indexVersionKey, []byte(indexMappingVersion)); err != nil {
+		index.Close()
+		return nil, fmt.Errorf("写入全文索引版本失败: %v", err)
+	}
+	return index, nil
+}
+
+// toIndexed 将文档转换为写入 Bleve 的字段
+func toIndexed(doc IndexDocument) indexedDoc {
+	return indexedDoc{ID: doc.ID, ParentID: doc.ParentID, Title: doc.Title, Tags: doc.Tags, Content: doc.Content}
+}
+
+// docID 文档在 Bleve 中的 ID
+func docID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// storedStrings 读取保存的字符串数组字段，只有一个元素时 Bleve 返回字符串
+func storedStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// Analyze 将文本切分为全文索引的小写词语
+// 西文按连续字母或数字切分，中日韩文字按相邻两字切分，单独出现的一个字作为一个词
+func Analyze(text string) []string {
+	var terms []string
+	for _, token := range Tokenize(text) {
+		var latin, cjk []rune
+		flushLatin := func() {
+			if len(latin) > 0 {
+				terms = append(terms, string(latin))
+				latin = latin[:0]
+			}
+		}
+		flushCJK := func() {
+			if len(cjk) == 1 {
+				terms = append(terms, string(cjk))
+			}
+			for i := 1; i < len(cjk); i++ {
+				terms = append(terms, string(cjk[i-1:i+1]))
+			}
+			cjk = cjk[:0]
+		}
+
+		for _, r := range token {
+			if isCJK(r) {
+				flushLatin()
+				cjk = append(cjk, r)
+				continue
+			}
+			flushCJK()
+			latin = append(latin, unicode.ToLower(r))
+		}
+		flushLatin()
+		flushCJK()
+	}
+	return terms
+}
+
+// uniqueTerms 去除重复的词语，保持原有顺序
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	result := terms[:0]
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/configs"
+)
+
+// 全文索引的文档类型，外部搜索服务中对应加上 SEARCH_BACKEND_INDEX_PREFIX 前缀的索引
+const (
+	DocTypePost    = "posts"
+	DocTypeComment = "comments"
+)
+
+// reindexBatchSize 重建外部索引时每批写入的文档数
+const reindexBatchSize = 500
+
+// Query 全文检索条件
+type Query struct {
+	Keyword string
+	Offset  int
+	Limit   int
+	Options // 模糊匹配选项，外部搜索服务按各自的纠错规则处理，仅参考 Fuzzy
+}
+
+// Indexer 全文索引服务
+type Indexer interface {
+	// Name 服务名称，对应配置中的 SEARCH_BACKEND
+	Name() string
+	// Put 写入或覆盖文档
+	Put(ctx context.Context, docType string, docs ...IndexDocument) error
+	// Delete 按 ID 删除文档，文档不存在时不报错
+	Delete(ctx context.Context, docType string, ids ...int64) error
+	// Reindex 清空该类型的索引后写入全部文档
+	Reindex(ctx context.Context, docType string, docs []IndexDocument) error
+	// Search 按相关度倒序返回第 Offset 条起的至多 Limit 条结果及命中的总数
+	Search(ctx context.Context, docType string, query Query) ([]IndexHit, int, error)
+	// Count 该类型已索引的文档数
+	Count(ctx context.Context, docType string) (int, error)
+}
+
+// IndexerOptions 创建全文索引服务所需的配置
+type IndexerOptions struct {
+	Dir         string // 内置索引的文件目录
+	URL         string
+	APIKey      string
+	IndexPrefix string
+	Timeout     time.Duration
+}
+
+// IndexerFactory 全文索引服务构造函数
+type IndexerFactory func(opts IndexerOptions) (Indexer, error)
+
+var indexerFactories = make(map[string]IndexerFactory)
+
+// RegisterIndexer 注册全文索引服务
+func RegisterIndexer(name string, factory IndexerFactory) {
+	indexerFactories[name] = factory
+}
+
+// IndexerNames 已注册的全文索引服务名称
+func IndexerNames() []string {
+	names := make([]string, 0, len(indexerFactories))
+	for name := range indexerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewIndexer 根据配置中的 SEARCH_BACKEND 创建全文索引服务，未配置时使用内置索引
+func NewIndexer(config *configs.Config) (Indexer, error) {
+	cfg := config.SearchConfig
+	name := cfg.SearchBackend
+	if name == "" {
+		name = "builtin"
+	}
+	factory, ok := indexerFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("不支持的全文索引服务: %s，可选值: %s", name, strings.Join(IndexerNames(), ", "))
+	}
+
+	timeout := time.Duration(cfg.SearchBackendTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return factory(IndexerOptions{
+		Dir:         cfg.SearchIndexDir,
+		URL:         strings.TrimRight(cfg.SearchBackendURL, "/"),
+		APIKey:      cfg.SearchBackendAPIKey,
+		IndexPrefix: cfg.SearchBackendIndexPrefix,
+		Timeout:     timeout,
+	})
+}
+
+// batches 将文档按 size 分批
+func batches(docs []IndexDocument, size int) [][]IndexDocument {
+	var result [][]IndexDocument
+	for len(docs) > size {
+		result = append(result, docs[:size])
+		docs = docs[size:]
+	}
+	if len(docs) > 0 {
+		result = append(result, docs)
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+func init() {
+	RegisterIndexer("meilisearch", newMeilisearch)
+}
+
+// meilisearchCropLength Meilisearch 截取正文片段的长度（词）
+const meilisearchCropLength = 40
+
+// meilisearch Meilisearch 全文索引服务，拼写纠错由索引的 typoTolerance 设置决定，默认开启
+type meilisearch struct {
+	remoteClient
+	prefix string
+}
+
+func newMeilisearch(opts IndexerOptions) (Indexer, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("未配置 Meilisearch 地址 SEARCH_BACKEND_URL")
+	}
+	m := &meilisearch{
+		remoteClient: remoteClient{name: "Meilisearch", url: opts.URL, client: &http.Client{Timeout: opts.Timeout}},
+		prefix:       opts.IndexPrefix,
+	}
+	if opts.APIKey != "" {
+		m.auth = "Bearer " + opts.APIKey
+	}
+	return m, nil
+}
+
+func (m *meilisearch) Name() string {
+	return "meilisearch"
+}
+
+func (m *meilisearch) Put(ctx context.Context, docType string, docs ...IndexDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	payload := make([]remoteDocument, len(docs))
+	for i, doc := range docs {
+		payload[i] = toRemoteDocument(doc)
+	}
+	_, err := m.doJSON(ctx, http.MethodPost, m.path(docType, "/documents?primaryKey=id"), payload, nil)
+	return err
+}
+
+func (m *meilisearch) Delete(ctx context.Context, docType string, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := m.doJSON(ctx, http.MethodPost, m.path(docType, "/documents/delete-batch"), ids, nil)
+	return err
+}
+
+// Reindex 清空文档并更新检索字段的优先级后分批写入；Meilisearch 异步处理写入任务，返回时索引可能尚未生效
+func (m *meilisearch) Reindex(ctx context.Context, docType string, docs []IndexDocument) error {
+	if status, err := m.doJSON(ctx, http.MethodDelete, m.path(docType, "/documents"), nil, nil); err != nil && status != http.StatusNotFound {
+		return err
+	}
+	settings := map[string]interface{}{
+		"searchableAttributes": []string{"title", "tags", "content"},
+		"filterableAttributes": []string{"parent_id"},
+	}
+	if _, err := m.doJSON(ctx, http.MethodPatch, m.path(docType, "/settings"), settings, nil); err != nil {
+		return err
+	}
+	for _, batch := range batches(docs, reindexBatchSize) {
+		if err := m.Put(ctx, docType, batch...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *meilisearch) Search(ctx context.Context, docType string, query Query) ([]IndexHit, int, error) {
+	payload := map[string]interface{}{
+		"q":                     query.Keyword,
+		"offset":                query.Offset,
+		"limit":                 query.Limit,
+		"attributesToHighlight": []string{"title", "content"},
+		"attributesToCrop":      []string{"content"},
+		"cropLength":            meilisearchCropLength,
+		"highlightPreTag":       remoteHighlightOpen,
+		"highlightPostTag":      remoteHighlightClose,
+		"showRankingScore":      true,
+	}
+	var result struct {
+		Hits []struct {
+			remoteDocument
+			Formatted struct {
+				Title   string `json:"title"`
+				Content string `json:"content"`
+			} `json:"_formatted"`
+			RankingScore float64 `json:"_rankingScore"`
+		} `json:"hits"`
+		EstimatedTotalHits int `json:"estimatedTotalHits"`
+	}
+	if _, err := m.doJSON(ctx, http.MethodPost, m.path(docType, "/search"), payload, &result); err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]IndexHit, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = IndexHit{
+			ID:       hit.ID,
+			ParentID: hit.ParentID,
+			Score:    hit.RankingScore,
+			Title:    remoteHighlight(hit.Formatted.Title),
+			Snippet:  remoteHighlight(hit.Formatted.Content),
+			Tags:     hit.Tags,
+		}
+	}
+	return hits, result.EstimatedTotalHits, nil
+}
+
+func (m *meilisearch) Count(ctx context.Context, docType string) (int, error) {
+	var result struct {
+		NumberOfDocuments int `json:"numberOfDocuments"`
+	}
+	status, err := m.doJSON(ctx, http.MethodGet, m.path(docType, "/stats"), nil, &result)
+	if status == http.StatusNotFound {
+		return 0, nil
+	}
+	return result.NumberOfDocuments, err
+}
+
+// path 文档类型对应索引下的接口路径
+func (m *meilisearch) path(docType, suffix string) string {
+	return "/indexes/" + url.PathEscape(m.prefix+docType) + suffix
+}
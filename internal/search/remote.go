@@ -0,0 +1,101 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// 外部搜索服务返回的高亮标记，取 Unicode 私用区字符，转义为 HTML 后替换为 <mark>，避免与正文冲突
+const (
+	remoteHighlightOpen  = "\ue000"
+	remoteHighlightClose = "\ue001"
+)
+
+// remoteDocument 写入外部搜索服务的文档
+type remoteDocument struct {
+	ID       int64    `json:"id"`
+	ParentID int64    `json:"parent_id"`
+	Title    string   `json:"title"`
+	Content  string   `json:"content"`
+	Tags     []string `json:"tags"`
+}
+
+func toRemoteDocument(doc IndexDocument) remoteDocument {
+	return remoteDocument{ID: doc.ID, ParentID: doc.ParentID, Title: doc.Title, Content: doc.Content, Tags: doc.Tags}
+}
+
+// remoteClient 外部搜索服务的 HTTP 客户端
+type remoteClient struct {
+	name   string // 服务名称，用于错误信息
+	url    string
+	auth   string // Authorization 请求头，为空时不携带
+	client *http.Client
+}
+
+// do 发送请求并将 JSON 响应解析到 out，返回响应状态码；状态码不是 2xx 时返回错误，调用方按需忽略 404
+func (r *remoteClient) do(ctx context.Context, method, path, contentType string, body []byte, out interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.url+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if r.auth != "" {
+		req.Header.Set("Authorization", r.auth)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("请求 %s 失败: %v", r.name, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("读取 %s 响应失败: %v", r.name, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%s 响应状态异常: %d %s", r.name, resp.StatusCode, truncate(string(content), 200))
+	}
+	if out != nil {
+		if err := json.Unmarshal(content, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("解析 %s 响应失败: %v", r.name, err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// doJSON 以 JSON 发送请求体
+func (r *remoteClient) doJSON(ctx context.Context, method, path string, payload, out interface{}) (int, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return 0, err
+		}
+	}
+	return r.do(ctx, method, path, "application/json", body, out)
+}
+
+// remoteHighlight 将外部搜索服务返回的带高亮标记的文本转义为 HTML，并将高亮标记替换为 <mark>
+func remoteHighlight(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = strings.ReplaceAll(escaped, remoteHighlightOpen, highlightOpen)
+	return strings.ReplaceAll(escaped, remoteHighlightClose, highlightClose)
+}
+
+// truncate 截取字符串的前 n 个字符
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
@@ -0,0 +1,52 @@
+package search
+
+import (
+	"hash/fnv"
+	"math/bits"
+)
+
+// minSimHashTerms 计算指纹所需的最少词语数，过短的文本指纹区分度不足
+const minSimHashTerms = 20
+
+// SimHash 计算文档的 64 位 SimHash 指纹，相似的文档指纹的汉明距离较小
+// 词语数不足 minSimHashTerms 时返回 0，表示不参与重复检测
+func SimHash(doc Document) uint64 {
+	tf := termFrequency(doc)
+	if len(tf) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, freq := range tf {
+		total += freq
+	}
+	if total < minSimHashTerms {
+		return 0
+	}
+
+	var weights [64]float64
+	for term, freq := range tf {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(term))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i] += freq
+			} else {
+				weights[i] -= freq
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for i, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << uint(i)
+		}
+	}
+	return fingerprint
+}
+
+// HammingDistance 计算两个指纹的汉明距离
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
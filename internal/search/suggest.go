@@ -0,0 +1,143 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/internal/global"
+)
+
+// 搜索建议类型
+const (
+	SuggestTitle = "title" // 文章标题
+	SuggestTag   = "tag"   // 标签，即文章所属类目
+)
+
+const (
+	suggestKey     = "SEARCH:SUGGEST"     // 搜索建议前缀索引
+	suggestTempKey = "SEARCH:SUGGEST:TMP" // 重建索引时使用的临时键
+	separator      = "\x00"               // 索引成员各字段之间的分隔符
+)
+
+// Suggestion 搜索建议
+type Suggestion struct {
+	Type string // 建议类型
+	ID   int64  // 文章 ID 或类目 ID
+	Text string // 展示文本
+}
+
+// member 生成索引成员，格式为 "小写文本\x00类型\x00ID\x00展示文本"，
+// 所有成员分值均为 0，Redis 按字典序排列，可通过 ZRANGEBYLEX 做前缀查询
+func (s Suggestion) member() string {
+	return strings.Join([]string{normalize(s.Text), s.Type, strconv.FormatInt(s.ID, 10), s.Text}, separator)
+}
+
+// parseMember 解析索引成员
+func parseMember(member string) (Suggestion, bool) {
+	parts := strings.SplitN(member, separator, 4)
+	if len(parts) != 4 {
+		return Suggestion{}, false
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return Suggestion{}, false
+	}
+	return Suggestion{Type: parts[1], ID: id, Text: parts[3]}, true
+}
+
+// normalize 统一大小写与首尾空白，使前缀匹配不区分大小写
+func normalize(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
+
+// AddSuggestions 将建议写入前缀索引，Redis 不可用时忽略
+func AddSuggestions(ctx context.Context, suggestions ...Suggestion) error {
+	members := make([]redis.Z, 0, len(suggestions))
+	for _, s := range suggestions {
+		if normalize(s.Text) != "" {
+			members = append(members, redis.Z{Member: s.member()})
+		}
+	}
+	if global.RedisClient == nil || len(members) == 0 {
+		return nil
+	}
+	return global.RedisClient.ZAdd(ctx, suggestKey, members...).Err()
+}
+
+// RemoveSuggestions 从前缀索引中移除建议，Redis 不可用时忽略
+func RemoveSuggestions(ctx context.Context, suggestions ...Suggestion) error {
+	if global.RedisClient == nil || len(suggestions) == 0 {
+		return nil
+	}
+	members := make([]interface{}, len(suggestions))
+	for i, s := range suggestions {
+		members[i] = s.member()
+	}
+	return global.RedisClient.ZRem(ctx, suggestKey, members...).Err()
+}
+
+// RebuildSuggestions 使用给定的建议整体替换前缀索引
+func RebuildSuggestions(ctx context.Context, suggestions []Suggestion) error {
+	if global.RedisClient == nil {
+		return fmt.Errorf("Redis 未初始化")
+	}
+
+	if err := global.RedisClient.Del(ctx, suggestTempKey).Err(); err != nil {
+		return err
+	}
+	members := make([]redis.Z, 0, len(suggestions))
+	for _, s := range suggestions {
+		if normalize(s.Text) != "" {
+			members = append(members, redis.Z{Member: s.member()})
+		}
+	}
+	if len(members) == 0 {
+		return global.RedisClient.Del(ctx, suggestKey).Err()
+	}
+
+	// 先写入临时键再原子替换，重建期间查询不受影响
+	if err := global.RedisClient.ZAdd(ctx, suggestTempKey, members...).Err(); err != nil {
+		return err
+	}
+	return global.RedisClient.Rename(ctx, suggestTempKey, suggestKey).Err()
+}
+
+// Suggest 按前缀查询搜索建议，同一类型下相同展示文本只返回一次
+func Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	prefix = normalize(prefix)
+	if global.RedisClient == nil {
+		return nil, fmt.Errorf("Redis 未初始化")
+	}
+	if prefix == "" || limit <= 0 {
+		return []Suggestion{}, nil
+	}
+
+	// 多取一些以抵消去重造成的数量损失
+	members, err := global.RedisClient.ZRangeByLex(ctx, suggestKey, &redis.ZRangeBy{
+		Min:   "[" + prefix,
+		Max:   "[" + prefix + "\xff",
+		Count: int64(limit * 3),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]Suggestion, 0, limit)
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		s, ok := parseMember(member)
+		if !ok || seen[s.Type+separator+s.Text] {
+			continue
+		}
+		seen[s.Type+separator+s.Text] = true
+		suggestions = append(suggestions, s)
+		if len(suggestions) == limit {
+			break
+		}
+	}
+	return suggestions, nil
+}
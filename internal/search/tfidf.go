@@ -0,0 +1,143 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"unicode"
+)
+
+// minTermLen 参与 TF-IDF 计算的最短西文词语长度
+const minTermLen = 2
+
+// Corpus TF-IDF 语料库，保存各文档的词频向量与词语的逆文档频率
+type Corpus struct {
+	docs    []Document
+	vectors []map[string]float64
+	idf     map[string]float64
+}
+
+// NewCorpus 根据文档构建 TF-IDF 语料库，标题中的词语按标题权重计入词频
+func NewCorpus(docs []Document) *Corpus {
+	corpus := &Corpus{docs: docs, vectors: make([]map[string]float64, len(docs)), idf: make(map[string]float64)}
+
+	df := make(map[string]int)
+	for i, doc := range docs {
+		tf := termFrequency(doc)
+		corpus.vectors[i] = tf
+		for term := range tf {
+			df[term]++
+		}
+	}
+
+	// 平滑后的逆文档频率，只在少数文档中出现的词语权重更高
+	for term, n := range df {
+		corpus.idf[term] = math.Log(float64(len(docs)+1)/float64(n+1)) + 1
+	}
+	for _, vector := range corpus.vectors {
+		corpus.weight(vector)
+	}
+	return corpus
+}
+
+// Similar 按余弦相似度倒序返回与文档相近的语料文档，最多返回 limit 条，相似度为 0 的文档不返回
+func (corpus *Corpus) Similar(doc Document, limit int) []Hit {
+	query := corpus.weight(termFrequency(doc))
+
+	var hits []Hit
+	for i, vector := range corpus.vectors {
+		if score := cosine(query, vector); score > 0 {
+			hits = append(hits, Hit{ID: corpus.docs[i].ID, Score: score})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// weight 将词频向量原地转换为 TF-IDF 向量，语料中未出现的词语不参与计算
+func (corpus *Corpus) weight(tf map[string]float64) map[string]float64 {
+	for term, freq := range tf {
+		idf, ok := corpus.idf[term]
+		if !ok {
+			delete(tf, term)
+			continue
+		}
+		tf[term] = (1 + math.Log(freq)) * idf
+	}
+	return tf
+}
+
+// termFrequency 统计文档的词频
+func termFrequency(doc Document) map[string]float64 {
+	tf := make(map[string]float64)
+	for _, term := range Terms(doc.Title) {
+		tf[term] += titleWeight
+	}
+	for _, term := range Terms(doc.Content) {
+		tf[term] += contentWeight
+	}
+	return tf
+}
+
+// cosine 计算两个向量的余弦相似度
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	var dot float64
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+	if dot == 0 {
+		return 0
+	}
+	return dot / (norm(a) * norm(b))
+}
+
+func norm(vector map[string]float64) float64 {
+	var sum float64
+	for _, weight := range vector {
+		sum += weight * weight
+	}
+	return math.Sqrt(sum)
+}
+
+// Terms 将文本切分为用于 TF-IDF 的小写词语
+// 西文按连续字母或数字切分并忽略过短的词，中日韩文字没有分隔符，按相邻两字切分
+func Terms(text string) []string {
+	var terms []string
+	for _, token := range Tokenize(text) {
+		var latin []rune
+		var prev rune
+		flush := func() {
+			if len(latin) >= minTermLen {
+				terms = append(terms, string(latin))
+			}
+			latin = latin[:0]
+		}
+
+		for _, r := range token {
+			if !isCJK(r) {
+				latin = append(latin, r)
+				prev = 0
+				continue
+			}
+			flush()
+			if prev != 0 {
+				terms = append(terms, string([]rune{prev, r}))
+			}
+			prev = r
+		}
+		flush()
+	}
+	return terms
+}
+
+// isCJK 判断字符是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
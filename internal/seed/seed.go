@@ -0,0 +1,402 @@
+package seed
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/model/base"
+	category "jank.com/jank_blog/internal/model/category"
+	comment "jank.com/jank_blog/internal/model/comment"
+	post "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/utils"
+)
+
+const defaultPassword = "jank-seed-123456" // 模拟用户的统一登录密码
+
+// Options 模拟数据生成配置
+type Options struct {
+	Accounts    int   // 用户数量
+	Categories  int   // 类目数量
+	Posts       int   // 文章数量
+	MaxComments int   // 单篇文章最大评论数
+	Days        int   // 文章发布时间分布的天数范围
+	BatchSize   int   // 批量写入大小
+	RandSeed    int64 // 随机数种子
+}
+
+// Report 模拟数据生成结果
+type Report struct {
+	Accounts   int
+	Categories int
+	Posts      int
+	Comments   int
+	Elapsed    time.Duration
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf("用户 %d 个, 类目 %d 个, 文章 %d 篇, 评论 %d 条, 耗时 %s",
+		r.Accounts, r.Categories, r.Posts, r.Comments, r.Elapsed.Round(time.Millisecond))
+}
+
+// DefaultOptions 默认生成配置
+func DefaultOptions() Options {
+	return Options{
+		Accounts:    200,
+		Categories:  30,
+		Posts:       20000,
+		MaxComments: 200,
+		Days:        730,
+		BatchSize:   500,
+		RandSeed:    time.Now().UnixNano(),
+	}
+}
+
+// generator 模拟数据生成器
+type generator struct {
+	opts Options
+	rnd  *rand.Rand
+	db   *gorm.DB
+}
+
+// Run 按配置生成模拟数据
+func Run(opts Options) (*Report, error) {
+	if global.DB == nil {
+		return nil, fmt.Errorf("数据库未初始化")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultOptions().BatchSize
+	}
+	if opts.Days <= 0 {
+		opts.Days = DefaultOptions().Days
+	}
+
+	g := &generator{
+		opts: opts,
+		rnd:  rand.New(rand.NewSource(opts.RandSeed)),
+		// 跳过 BeforeCreate 钩子，保留生成的发布时间
+		db: global.DB.Session(&gorm.Session{SkipHooks: true}),
+	}
+
+	start := time.Now()
+	report := &Report{}
+
+	accountIDs, err := g.seedAccounts()
+	if err != nil {
+		return nil, err
+	}
+	report.Accounts = len(accountIDs)
+
+	categoryIDs, err := g.seedCategories()
+	if err != nil {
+		return nil, err
+	}
+	report.Categories = len(categoryIDs)
+
+	posts, err := g.seedPosts(categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	report.Posts = len(posts)
+
+	report.Comments, err = g.seedComments(posts, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Elapsed = time.Since(start)
+	return report, nil
+}
+
+// seedAccounts 生成模拟用户
+func (g *generator) seedAccounts() ([]int64, error) {
+	if g.opts.Accounts <= 0 {
+		return nil, nil
+	}
+
+	hashed, err := password.Hash(defaultPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix := g.rnd.Int63()
+	accounts := make([]*account.Account, g.opts.Accounts)
+	for i := range accounts {
+		now := g.pastTime().Unix()
+		accounts[i] = &account.Account{
+			Base:              g.newBase(now),
+			Email:             fmt.Sprintf("seed_%d_%d@jank.local", suffix, i),
+			Password:          hashed,
+			PasswordAlgorithm: password.Identify(hashed),
+			Nickname:          fmt.Sprintf("%s%d", pick(g.rnd, nicknames), i),
+		}
+	}
+
+	if err := g.db.CreateInBatches(accounts, g.opts.BatchSize).Error; err != nil {
+		return nil, fmt.Errorf("写入模拟用户失败: %v", err)
+	}
+
+	ids := make([]int64, len(accounts))
+	for i, acc := range accounts {
+		ids[i] = acc.ID
+	}
+	return ids, nil
+}
+
+// seedCategories 生成两级模拟类目
+func (g *generator) seedCategories() ([]int64, error) {
+	if g.opts.Categories <= 0 {
+		return nil, nil
+	}
+
+	var ids []int64
+	var roots []*category.Category
+	rootCount := int(math.Max(1, float64(g.opts.Categories)/5))
+
+	for i := 0; i < rootCount; i++ {
+		now := g.pastTime().Unix()
+		roots = append(roots, &category.Category{
+			Base:        g.newBase(now),
+			Name:        fmt.Sprintf("%s-%d", pick(g.rnd, topics), i),
+			Description: g.sentence(6, 12),
+			Path:        "/",
+		})
+	}
+	if err := g.db.CreateInBatches(roots, g.opts.BatchSize).Error; err != nil {
+		return nil, fmt.Errorf("写入模拟类目失败: %v", err)
+	}
+
+	var children []*category.Category
+	for i := rootCount; i < g.opts.Categories; i++ {
+		parent := roots[g.rnd.Intn(len(roots))]
+		now := g.pastTime().Unix()
+		children = append(children, &category.Category{
+			Base:        g.newBase(now),
+			Name:        fmt.Sprintf("%s-%d", pick(g.rnd, topics), i),
+			Description: g.sentence(6, 12),
+			ParentID:    parent.ID,
+			Path:        fmt.Sprintf("/%d", parent.ID),
+		})
+	}
+	if len(children) > 0 {
+		if err := g.db.CreateInBatches(children, g.opts.BatchSize).Error; err != nil {
+			return nil, fmt.Errorf("写入模拟类目失败: %v", err)
+		}
+	}
+
+	for _, cat := range append(roots, children...) {
+		ids = append(ids, cat.ID)
+	}
+	return ids, nil
+}
+
+// seedPosts 生成模拟文章，发布时间越近越密集，正文长度服从对数正态分布
+func (g *generator) seedPosts(categoryIDs []int64) ([]*post.Post, error) {
+	var all []*post.Post
+
+	for done := 0; done < g.opts.Posts; done += g.opts.BatchSize {
+		size := g.opts.BatchSize
+		if remain := g.opts.Posts - done; remain < size {
+			size = remain
+		}
+
+		batch := make([]*post.Post, size)
+		for i := range batch {
+			markdown := g.markdown()
+			html, err := utils.RenderMarkdown([]byte(markdown))
+			if err != nil {
+				return nil, fmt.Errorf("渲染 Markdown 失败: %v", err)
+			}
+
+			base, title, visible := g.newBase(g.pastTime().Unix()), g.title(), g.rnd.Float64() < 0.9
+			batch[i] = &post.Post{
+				Base:            base,
+				Title:           title,
+				Visibility:      visible,
+				Status:          post.StatusFromVisibility(visible),
+				Access:          post.AccessPublic,
+				ContentMarkdown: markdown,
+				ContentHTML:     html,
+				CategoryIDs:     g.pickCategories(categoryIDs),
+			}
+			batch[i].WordCount, batch[i].ReadingTime = utils.ReadingStats(html)
+		}
+
+		if err := g.db.CreateInBatches(batch, g.opts.BatchSize).Error; err != nil {
+			return nil, fmt.Errorf("写入模拟文章失败: %v", err)
+		}
+		all = append(all, batch...)
+	}
+
+	return all, nil
+}
+
+// seedComments 生成模拟评论，评论数服从 Zipf 分布，少数热门文章拥有大量评论
+func (g *generator) seedComments(posts []*post.Post, accountIDs []int64) (int, error) {
+	if len(posts) == 0 || len(accountIDs) == 0 || g.opts.MaxComments <= 0 {
+		return 0, nil
+	}
+
+	zipf := rand.NewZipf(g.rnd, 1.3, 1, uint64(g.opts.MaxComments))
+	total := 0
+	var batch []*comment.Comment
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := g.db.CreateInBatches(batch, g.opts.BatchSize).Error; err != nil {
+			return fmt.Errorf("写入模拟评论失败: %v", err)
+		}
+		total += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for _, pos := range posts {
+		count := int(zipf.Uint64())
+		// 根评论先落库以获取 ID，回复评论随后引用
+		roots := make([]*comment.Comment, 0, count)
+		for i := 0; i < count; i++ {
+			roots = append(roots, g.newComment(pos, accountIDs, 0))
+		}
+		if len(roots) == 0 {
+			continue
+		}
+		if err := g.db.CreateInBatches(roots, g.opts.BatchSize).Error; err != nil {
+			return total, fmt.Errorf("写入模拟评论失败: %v", err)
+		}
+		total += len(roots)
+
+		// 约三成评论带有回复
+		for _, root := range roots {
+			if g.rnd.Float64() < 0.3 {
+				batch = append(batch, g.newComment(pos, accountIDs, root.ID))
+			}
+		}
+		if len(batch) >= g.opts.BatchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// newComment 创建一条模拟评论
+func (g *generator) newComment(pos *post.Post, accountIDs []int64, replyTo int64) *comment.Comment {
+	created := pos.GmtCreate + g.rnd.Int63n(int64(30*24*time.Hour/time.Second))
+	if now := time.Now().Unix(); created > now {
+		created = now
+	}
+
+	// 模拟评论为不含 Markdown 标记的纯文本，直接生成与渲染结果一致的 HTML，避免逐条渲染
+	content := g.sentence(3, 40)
+	return &comment.Comment{
+		Base:             g.newBase(created),
+		Content:          content,
+		ContentHTML:      "<p>" + html.EscapeString(content) + "</p>\n",
+		UserId:           accountIDs[g.rnd.Intn(len(accountIDs))],
+		PostId:           pos.ID,
+		ReplyToCommentId: replyTo,
+	}
+}
+
+// newBase 构建通用字段，钩子被跳过时需手动填充
+func (g *generator) newBase(ts int64) base.Base {
+	return base.Base{
+		GmtCreate:   ts,
+		GmtModified: ts,
+		Ext:         base.JSONMap{"seed": true},
+	}
+}
+
+// pastTime 生成过去一段时间内的时间点，越接近当前时间越密集
+func (g *generator) pastTime() time.Time {
+	span := float64(g.opts.Days) * 24
+	hoursAgo := math.Min(span, g.rnd.ExpFloat64()*span/3)
+	return time.Now().Add(-time.Duration(hoursAgo * float64(time.Hour)))
+}
+
+// pickCategories 为文章随机挑选 1~3 个类目
+func (g *generator) pickCategories(ids []int64) post.CategoryIDsArray {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	n := 1 + g.rnd.Intn(3)
+	picked := make(map[int64]struct{}, n)
+	result := make(post.CategoryIDsArray, 0, n)
+	for len(result) < n && len(result) < len(ids) {
+		id := ids[g.rnd.Intn(len(ids))]
+		if _, ok := picked[id]; ok {
+			continue
+		}
+		picked[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
+
+// title 生成文章标题
+func (g *generator) title() string {
+	return fmt.Sprintf("%s %s %s", pick(g.rnd, topics), pick(g.rnd, verbs), pick(g.rnd, nouns))
+}
+
+// sentence 生成指定词数范围的句子
+func (g *generator) sentence(minWords, maxWords int) string {
+	n := minWords + g.rnd.Intn(maxWords-minWords+1)
+	words := make([]string, n)
+	for i := range words {
+		words[i] = pick(g.rnd, vocabulary)
+	}
+	return strings.Join(words, " ") + "。"
+}
+
+// markdown 生成 Markdown 正文，段落数服从对数正态分布
+func (g *generator) markdown() string {
+	paragraphs := int(math.Max(1, math.Min(60, math.Exp(g.rnd.NormFloat64()*0.6+1.8))))
+
+	var sb strings.Builder
+	for i := 0; i < paragraphs; i++ {
+		if i%4 == 0 {
+			sb.WriteString("## " + g.title() + "\n\n")
+		}
+		for j := 0; j < 2+g.rnd.Intn(4); j++ {
+			sb.WriteString(g.sentence(8, 24))
+		}
+		sb.WriteString("\n\n")
+		if g.rnd.Float64() < 0.1 {
+			sb.WriteString("```go\nfmt.Println(\"" + pick(g.rnd, nouns) + "\")\n```\n\n")
+		}
+	}
+	return sb.String()
+}
+
+func pick(rnd *rand.Rand, words []string) string {
+	return words[rnd.Intn(len(words))]
+}
+
+var (
+	topics     = []string{"Go", "Rust", "Kubernetes", "Redis", "PostgreSQL", "前端", "架构", "算法", "运维", "生活", "读书", "随笔"}
+	verbs      = []string{"入门", "实践", "原理", "踩坑记录", "性能优化", "源码解析", "最佳实践", "思考"}
+	nouns      = []string{"并发模型", "缓存设计", "分页查询", "全文检索", "中间件", "微服务", "数据迁移", "日志系统", "限流", "鉴权"}
+	nicknames  = []string{"jank", "coder", "gopher", "writer", "reader", "hacker"}
+	vocabulary = []string{
+		"我们", "系统", "缓存", "数据库", "请求", "接口", "性能", "文章", "评论", "用户", "并发", "延迟",
+		"优化", "部署", "配置", "测试", "架构", "服务", "分页", "索引", "查询", "日志", "监控", "告警",
+		"golang", "redis", "echo", "gorm", "docker", "nginx", "latency", "throughput", "cache", "index",
+	}
+)
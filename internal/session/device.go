@@ -0,0 +1,46 @@
+package session
+
+import "strings"
+
+// 按顺序匹配 User-Agent 中的关键字，先匹配的优先，如 Edge 的 User-Agent 同时包含 Chrome
+var (
+	browsers = []struct{ keyword, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+		{"PostmanRuntime/", "Postman"},
+	}
+	platforms = []struct{ keyword, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+)
+
+// DeviceName 由 User-Agent 识别浏览器与操作系统，如 "Chrome / macOS"，无法识别时返回 "未知设备"
+func DeviceName(userAgent string) string {
+	var parts []string
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.keyword) {
+			parts = append(parts, b.name)
+			break
+		}
+	}
+	for _, p := range platforms {
+		if strings.Contains(userAgent, p.keyword) {
+			parts = append(parts, p.name)
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return "未知设备"
+	}
+	return strings.Join(parts, " / ")
+}
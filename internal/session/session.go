@@ -0,0 +1,254 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+const (
+	CacheKeyPrefix        = "SESSION:"         // 会话状态缓存键前缀，键为前缀加会话 ID
+	VersionCacheKeyPrefix = "SESSION:VERSION:" // 账户会话版本号缓存键前缀，键为前缀加账户 ID，每次登录加一
+	Lifetime              = time.Hour * 48     // 会话有效期，与 refresh token 有效期一致
+	touchInterval         = time.Minute        // 最近访问记录的更新间隔
+	maxUserAgent          = 512                // 记录的 User-Agent 最大长度
+)
+
+// ErrNotFound 会话不存在、已过期或已结束
+var ErrNotFound = errors.New("会话不存在或已结束")
+
+// ErrSuperseded 开启单会话限制时账户已在其他设备上重新登录
+var ErrSuperseded = errors.New("账户已在其他设备登录")
+
+// State 缓存中的会话状态
+type State struct {
+	ID         string `json:"id"`           // 会话 ID
+	AccountID  int64  `json:"account_id"`   // 用户ID
+	Device     string `json:"device"`       // 由 User-Agent 识别的设备
+	UserAgent  string `json:"user_agent"`   // 登录时的 User-Agent
+	IP         string `json:"ip"`           // 最近一次访问的 IP
+	CreatedAt  int64  `json:"created_at"`   // 登录的 Unix 时间戳
+	LastSeenAt int64  `json:"last_seen_at"` // 最近一次访问的 Unix 时间戳
+	Version    int64  `json:"version"`      // 登录时账户的会话版本号
+}
+
+// Create 为登录的用户创建会话
+func Create(accountID int64, ip, userAgent string) (*State, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("生成会话 ID 失败: %v", err)
+	}
+	if len(userAgent) > maxUserAgent {
+		userAgent = userAgent[:maxUserAgent]
+	}
+	// 无论是否开启单会话限制都记录版本号，开启后最近一次登录的会话立即成为唯一有效的会话
+	version, err := cache.Current().Incr(context.Background(), fmt.Sprintf("%s%d", VersionCacheKeyPrefix, accountID), 0)
+	if err != nil {
+		return nil, fmt.Errorf("更新会话版本号失败: %v", err)
+	}
+	now := time.Now()
+	state := &State{
+		ID:         hex.EncodeToString(id),
+		AccountID:  accountID,
+		Device:     DeviceName(userAgent),
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now.Unix(),
+		LastSeenAt: now.Unix(),
+		Version:    version,
+	}
+
+	if err := mapper.CreateAccountSession(&model.AccountSession{
+		SessionID:  state.ID,
+		AccountID:  accountID,
+		Device:     state.Device,
+		UserAgent:  userAgent,
+		IP:         ip,
+		LastSeenAt: state.LastSeenAt,
+		ExpiresAt:  now.Add(Lifetime).Unix(),
+	}); err != nil {
+		return nil, err
+	}
+	if err := save(state, Lifetime); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Get 获取会话状态，会话不存在时返回 ErrNotFound
+func Get(sessionID string) (*State, error) {
+	if sessionID == "" {
+		return nil, ErrNotFound
+	}
+	value, err := cache.Current().Get(context.Background(), CacheKeyPrefix+sessionID)
+	if errors.Is(err, cache.ErrMiss) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取会话失败: %v", err)
+	}
+	var state State
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return nil, fmt.Errorf("解析会话失败: %v", err)
+	}
+	return &state, nil
+}
+
+// CheckSingleActive 开启单会话限制时校验会话是否为账户最近一次登录创建的会话，不是时结束该会话并返回 ErrSuperseded
+// 版本号丢失时不做限制，缓存丢失后会话本身也已失效
+func CheckSingleActive(state *State) error {
+	if !singleActive() {
+		return nil
+	}
+	latest, err := latestVersion(state.AccountID)
+	if err != nil || latest == 0 || latest == state.Version {
+		return err
+	}
+	if _, err := End(state.AccountID, state.ID, model.SessionEndSuperseded); err != nil {
+		global.BizLog.Errorf("结束会话失败 [%s]: %v", state.ID, err)
+	}
+	return ErrSuperseded
+}
+
+// Touch 记录会话的最近访问时间与 IP，距上次记录不足一分钟且 IP 未变化时不做处理
+func Touch(state *State, ip string) error {
+	now := time.Now().Unix()
+	if now-state.LastSeenAt < int64(touchInterval.Seconds()) && state.IP == ip {
+		return nil
+	}
+	ttl, err := cache.Current().TTL(context.Background(), CacheKeyPrefix+state.ID)
+	if err != nil || ttl <= 0 {
+		return err
+	}
+
+	state.LastSeenAt, state.IP = now, ip
+	if err := save(state, ttl); err != nil {
+		return err
+	}
+	return mapper.UpdateAccountSession(state.ID, map[string]interface{}{"last_seen_at": now, "ip": ip})
+}
+
+// Renew 顺延会话有效期，在刷新 token 时调用
+func Renew(state *State) error {
+	if err := save(state, Lifetime); err != nil {
+		return err
+	}
+	return mapper.UpdateAccountSession(state.ID, map[string]interface{}{"expires_at": time.Now().Add(Lifetime).Unix()})
+}
+
+// List 获取账户未结束的会话，按登录时间倒序排列
+func List(accountID int64) ([]*State, error) {
+	records, err := mapper.GetActiveAccountSessions(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := int64(0)
+	if singleActive() {
+		if latest, err = latestVersion(accountID); err != nil {
+			return nil, err
+		}
+	}
+
+	states := make([]*State, 0, len(records))
+	for _, record := range records {
+		state, err := Get(record.SessionID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// 开启单会话限制时，已被新登录取代的会话在下次访问时结束，此处不再列出
+		if state.AccountID == accountID && (latest == 0 || state.Version == latest) {
+			states = append(states, state)
+		}
+	}
+	return states, nil
+}
+
+// End 结束账户的单个会话，返回会话是否存在
+func End(accountID int64, sessionID, reason string) (bool, error) {
+	state, err := Get(sessionID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return false, err
+	}
+	found := err == nil && state.AccountID == accountID
+	if found {
+		if err := cache.Current().Del(context.Background(), CacheKeyPrefix+sessionID); err != nil {
+			return false, fmt.Errorf("删除会话失败: %v", err)
+		}
+	}
+
+	ended, err := mapper.EndAccountSession(accountID, sessionID, time.Now().Unix(), reason)
+	if err != nil {
+		return false, err
+	}
+	return found || ended, nil
+}
+
+// EndAll 结束账户除 exceptSessionID 外的全部会话，exceptSessionID 为空时结束全部会话，返回结束的会话数量
+func EndAll(accountID int64, exceptSessionID, reason string) (int, error) {
+	sessionIDs, err := mapper.EndAccountSessions(accountID, exceptSessionID, time.Now().Unix(), reason)
+	if err != nil {
+		return 0, err
+	}
+	if len(sessionIDs) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, len(sessionIDs))
+	for i, id := range sessionIDs {
+		keys[i] = CacheKeyPrefix + id
+	}
+	if err := cache.Current().Del(context.Background(), keys...); err != nil {
+		return 0, fmt.Errorf("删除会话失败: %v", err)
+	}
+	global.BizLog.Infof("账户 %d 结束 %d 个会话，原因: %s", accountID, len(sessionIDs), reason)
+	return len(sessionIDs), nil
+}
+
+// save 写入会话状态
+func save(state *State, ttl time.Duration) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("序列化会话失败: %v", err)
+	}
+	if err := cache.Current().Set(context.Background(), CacheKeyPrefix+state.ID, string(value), ttl); err != nil {
+		return fmt.Errorf("写入会话失败: %v", err)
+	}
+	return nil
+}
+
+// latestVersion 获取账户最近一次登录的会话版本号，版本号不存在时返回 0
+func latestVersion(accountID int64) (int64, error) {
+	value, err := cache.Current().Get(context.Background(), fmt.Sprintf("%s%d", VersionCacheKeyPrefix, accountID))
+	if errors.Is(err, cache.ErrMiss) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("读取会话版本号失败: %v", err)
+	}
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("解析会话版本号失败: %v", err)
+	}
+	return version, nil
+}
+
+// singleActive 是否开启了单会话限制，读取配置失败时按未开启处理
+func singleActive() bool {
+	config, err := configs.LoadConfig()
+	return err == nil && config.SessionConfig.SessionSingleActive
+}
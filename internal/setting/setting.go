@@ -0,0 +1,46 @@
+package setting
+
+import (
+	"errors"
+	"sync"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// ErrUnavailable 数据库未初始化，无法读写运行时配置
+var ErrUnavailable = errors.New("运行时配置不可用")
+
+var loadMu sync.Mutex // 保证同一时间只有一个请求加载配置项，避免旧的结果覆盖新的结果
+
+// Load 从数据库加载全部运行时配置项，作为配置文件的覆盖项，加载失败时继续使用已加载的配置项
+func Load() error {
+	if global.DB == nil {
+		return ErrUnavailable
+	}
+	loadMu.Lock()
+	defer loadMu.Unlock()
+
+	settings, err := mapper.GetAllSettings()
+	if err != nil {
+		return err
+	}
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.Key] = s.Value
+	}
+	configs.SetOverrides(values)
+	return nil
+}
+
+// Update 写入运行时配置项并立即重新加载，key 格式为 "section.KEY"，如 "site.SITE_TITLE"
+func Update(values map[string]string) error {
+	if global.DB == nil {
+		return ErrUnavailable
+	}
+	if err := mapper.UpsertSettings(values); err != nil {
+		return err
+	}
+	return Load()
+}
@@ -0,0 +1,93 @@
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxURLs 单个站点地图文件最多包含的地址数，超过时需拆分并通过站点地图索引引用
+const MaxURLs = 50000
+
+// Sitemap 站点地图索引中的单个站点地图
+type Sitemap struct {
+	Loc     string    // 站点地图地址
+	LastMod time.Time // 其中页面的最后修改时间
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []xmlSitemap `xml:"sitemap"`
+}
+
+type xmlSitemap struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// BuildIndex 构建站点地图索引
+func BuildIndex(sitemaps []Sitemap) ([]byte, error) {
+	index := sitemapIndex{Xmlns: xmlns}
+	for _, s := range sitemaps {
+		item := xmlSitemap{Loc: s.Loc}
+		if !s.LastMod.IsZero() {
+			item.LastMod = s.LastMod.Format(time.RFC3339)
+		}
+		index.Sitemaps = append(index.Sitemaps, item)
+	}
+
+	body, err := xml.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("站点地图索引序列化失败: %v", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// Split 将地址按 size 分组，size 不大于 0 或超过 MaxURLs 时按 MaxURLs 分组
+func Split(urls []URL, size int) [][]URL {
+	if size <= 0 || size > MaxURLs {
+		size = MaxURLs
+	}
+	var groups [][]URL
+	for len(urls) > size {
+		groups = append(groups, urls[:size])
+		urls = urls[size:]
+	}
+	return append(groups, urls)
+}
+
+// LastMod 获取一组地址中最晚的修改时间
+func LastMod(urls []URL) time.Time {
+	var latest time.Time
+	for _, u := range urls {
+		if u.LastMod.After(latest) {
+			latest = u.LastMod
+		}
+	}
+	return latest
+}
+
+// Ping 通知搜索引擎站点地图已更新，endpoint 中的 %s 替换为转义后的站点地图地址
+func Ping(ctx context.Context, client *http.Client, endpoint, sitemapURL string) error {
+	target := strings.Replace(endpoint, "%s", url.QueryEscape(sitemapURL), 1)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("通知 %s 失败: %v", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("通知 %s 失败，响应状态 %d", req.URL.Host, resp.StatusCode)
+	}
+	return nil
+}
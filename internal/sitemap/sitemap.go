@@ -0,0 +1,51 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+const xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL 站点地图中的单个地址
+type URL struct {
+	Loc        string    // 页面地址
+	LastMod    time.Time // 最后修改时间
+	ChangeFreq string    // 更新频率，如 daily、weekly
+	Priority   float64   // 优先级，0.0 ~ 1.0
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []xmlURL `xml:"url"`
+}
+
+type xmlURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+// Build 构建站点地图
+func Build(urls []URL) ([]byte, error) {
+	set := urlSet{Xmlns: xmlns}
+	for _, u := range urls {
+		item := xmlURL{Loc: u.Loc, ChangeFreq: u.ChangeFreq}
+		if !u.LastMod.IsZero() {
+			item.LastMod = u.LastMod.Format(time.RFC3339)
+		}
+		if u.Priority > 0 {
+			item.Priority = fmt.Sprintf("%.1f", u.Priority)
+		}
+		set.URLs = append(set.URLs, item)
+	}
+
+	body, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("站点地图序列化失败: %v", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
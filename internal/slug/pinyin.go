@@ -0,0 +1,419 @@
+package slug
+
+// pinyin 常用汉字到不带声调拼音的映射，由 syllables 生成
+var pinyin = make(map[rune]string)
+
+// syllables 按音节登记的常用汉字，多音字只登记在最常用的读音下；ü 写作 v
+var syllables = []struct {
+	syllable string
+	chars    string
+}{
+	{"a", "阿啊"},
+	{"ai", "爱哀挨埃癌矮艾碍唉皑蔼隘"},
+	{"an", "安按案暗岸俺鞍氨庵黯"},
+	{"ang", "昂肮盎"},
+	{"ao", "奥澳傲熬凹敖袄懊翱"},
+	{"ba", "八把吧爸巴拔霸罢坝扒叭疤芭捌跋靶"},
+	{"bai", "白百摆败拜柏佰掰"},
+	{"ban", "办半班般版板搬伴扮拌颁瓣斑绊"},
+	{"bang", "帮邦榜棒膀绑傍磅谤"},
+	{"bao", "报保包宝暴抱饱爆薄胞豹堡鲍雹苞褒"},
+	{"bei", "被北备背杯悲贝倍辈碑卑惫焙"},
+	{"ben", "本奔笨苯"},
+	{"beng", "崩蹦绷泵甭"},
+	{"bi", "比必笔币毕闭避鼻彼逼壁臂碧弊蔽璧毙庇痹"},
+	{"bian", "变边便编遍辩鞭扁辨贬卞"},
+	{"biao", "表标彪膘镖飙"},
+	{"bie", "别憋鳖瘪"},
+	{"bin", "宾滨彬斌濒殡"},
+	{"bing", "并病兵冰饼丙柄秉炳"},
+	{"bo", "波播伯博拨勃泊驳玻剥膊脖舶帛铂渤"},
+	{"bu", "不部步布补捕卜埠簿哺怖"},
+	{"ca", "擦"},
+	{"cai", "才采财材菜彩猜裁蔡踩睬"},
+	{"can", "参餐残惨灿蚕"},
+	{"cang", "藏仓苍舱沧"},
+	{"cao", "草操曹槽糙"},
+	{"ce", "策测册侧厕"},
+	{"cen", "岑"},
+	{"ceng", "层曾蹭"},
+	{"cha", "查差茶察插叉岔诧搽碴"},
+	{"chai", "拆柴豺"},
+	{"chan", "产缠颤铲蝉馋阐禅"},
+	{"chang", "长场常厂唱尝昌偿肠畅倡敞猖"},
+	{"chao", "超朝潮炒抄吵巢钞"},
+	{"che", "车彻撤扯澈"},
+	{"chen", "陈沉晨臣尘衬趁辰"},
+	{"cheng", "成城程称承乘诚呈惩撑秤澄橙逞"},
+	{"chi", "吃持池迟赤尺齿斥翅驰痴弛耻"},
+	{"chong", "冲充虫崇宠"},
+	{"chou", "抽愁仇筹丑臭酬稠绸"},
+	{"chu", "出处初除础储楚触厨畜锄雏"},
+	{"chuai", "揣"},
+	{"chuan", "传川船穿串喘"},
+	{"chuang", "创窗床闯疮"},
+	{"chui", "吹垂锤炊捶"},
+	{"chun", "春纯唇醇蠢"},
+	{"chuo", "戳绰"},
+	{"ci", "此次词辞刺磁雌慈瓷赐"},
+	{"cong", "从丛聪葱匆"},
+	{"cou", "凑"},
+	{"cu", "粗促醋簇"},
+	{"cuan", "窜篡"},
+	{"cui", "催脆翠崔摧粹"},
+	{"cun", "村存寸"},
+	{"cuo", "错措挫搓"},
+	{"da", "大打达答搭"},
+	{"dai", "代带待袋戴贷呆逮殆怠"},
+	{"dan", "单但担淡蛋弹丹胆旦诞氮"},
+	{"dang", "当党荡档挡"},
+	{"dao", "到道导倒岛刀盗稻蹈悼"},
+	{"de", "的得德"},
+	{"deng", "等灯登邓凳瞪"},
+	{"di", "地第底低帝敌弟滴递堤抵迪笛涤"},
+	{"dian", "点电店典殿垫颠淀奠"},
+	{"diao", "掉吊钓雕刁"},
+	{"die", "跌叠蝶爹碟"},
+	{"ding", "定顶丁订钉盯鼎"},
+	{"diu", "丢"},
+	{"dong", "东动冬懂董洞冻栋"},
+	{"dou", "都斗豆抖陡逗兜"},
+	{"du", "度读独毒督渡杜堵赌肚镀"},
+	{"duan", "断段短端锻"},
+	{"dui", "对队堆兑"},
+	{"dun", "顿吨盾蹲敦墩"},
+	{"duo", "多夺朵躲堕惰舵"},
+	{"e", "额恶饿俄鹅蛾厄扼"},
+	{"en", "恩"},
+	{"er", "而二儿尔耳饵"},
+	{"fa", "发法罚伐乏阀"},
+	{"fan", "反饭犯范翻凡烦返繁帆泛贩番"},
+	{"fang", "方放房防访仿芳妨纺"},
+	{"fei", "非飞费肥废肺沸菲匪诽"},
+	{"fen", "分份粉奋纷愤坟芬粪"},
+	{"feng", "风封丰峰锋疯奉冯逢缝凤蜂"},
+	{"fo", "佛"},
+	{"fou", "否"},
+	{"fu", "服福复府富父负副付夫妇符浮扶腐辅幅伏抚肤赴俘覆弗斧釜"},
+	{"ga", "嘎"},
+	{"gai", "该改概盖钙丐"},
+	{"gan", "感干敢赶甘肝杆竿柑"},
+	{"gang", "刚港钢岗纲缸"},
+	{"gao", "高告搞稿糕膏"},
+	{"ge", "个各歌格哥革隔割阁鸽搁葛戈"},
+	{"gei", "给"},
+	{"gen", "根跟"},
+	{"geng", "更耕耿"},
+	{"gong", "工公共功供攻宫恭巩贡拱躬"},
+	{"gou", "够构狗购沟钩勾苟"},
+	{"gu", "古故顾固骨鼓谷股姑孤雇菇咕"},
+	{"gua", "挂瓜刮寡"},
+	{"guai", "怪乖拐"},
+	{"guan", "关管观官馆惯冠贯灌罐"},
+	{"guang", "光广逛"},
+	{"gui", "规归贵鬼柜轨桂跪龟硅"},
+	{"gun", "滚棍"},
+	{"guo", "国过果锅郭裹"},
+	{"ha", "哈"},
+	{"hai", "还海害孩骇亥"},
+	{"han", "汉含寒喊汗韩旱函涵罕"},
+	{"hang", "航杭"},
+	{"hao", "好号毫豪耗浩郝"},
+	{"he", "和合何河喝核盒贺赫荷禾"},
+	{"hei", "黑嘿"},
+	{"hen", "很恨狠痕"},
+	{"heng", "横衡恒哼"},
+	{"hong", "红洪宏轰虹鸿烘哄"},
+	{"hou", "后候厚猴吼侯"},
+	{"hu", "护湖户呼胡互虎忽壶糊狐蝴沪乎"},
+	{"hua", "话化花华画划滑哗"},
+	{"huai", "坏怀淮槐"},
+	{"huan", "换环欢缓患唤幻焕"},
+	{"huang", "黄皇荒慌晃谎凰"},
+	{"hui", "会回汇挥灰辉毁悔惠慧绘徽"},
+	{"hun", "婚混魂昏浑"},
+	{"huo", "或活火获货伙祸惑霍"},
+	{"ji", "几机及基级记技计集即极际既济积急击纪继迹吉绩挤寄剂籍季辑鸡肌饥忌疾脊姬"},
+	{"jia", "家加价假架甲佳夹嘉驾贾稼"},
+	{"jian", "间见建件简剑检减坚健渐监兼尖艰荐键践鉴舰歼肩煎拣捡"},
+	{"jiang", "将讲江奖降疆酱蒋浆僵"},
+	{"jiao", "教交角叫较脚焦胶骄郊浇娇搅缴"},
+	{"jie", "接结解界节街介阶届姐借杰截戒洁揭劫皆"},
+	{"jin", "进今金近仅紧尽禁劲津锦筋晋浸"},
+	{"jing", "经京精境竟静警景敬惊径井净镜晶睛荆颈"},
+	{"jiong", "窘炯"},
+	{"jiu", "就九究久酒旧救纠揪舅"},
+	{"ju", "局举具据剧居聚巨句拒俱距菊鞠矩"},
+	{"juan", "卷捐倦娟眷"},
+	{"jue", "决觉绝掘爵诀"},
+	{"jun", "军均君俊菌峻"},
+	{"ka", "卡咖"},
+	{"kai", "开凯慨楷"},
+	{"kan", "看刊砍堪勘"},
+	{"kang", "抗康扛"},
+	{"kao", "考靠烤"},
+	{"ke", "可科克客课刻颗渴壳柯棵"},
+	{"ken", "肯啃垦恳"},
+	{"keng", "坑"},
+	{"kong", "空控孔恐"},
+	{"kou", "口扣寇"},
+	{"ku", "苦库哭酷裤枯窟"},
+	{"kua", "跨夸垮"},
+	{"kuai", "快块筷"},
+	{"kuan", "宽款"},
+	{"kuang", "况狂矿框旷"},
+	{"kui", "亏溃愧魁"},
+	{"kun", "困昆捆"},
+	{"kuo", "扩括阔"},
+	{"la", "拉啦辣蜡腊喇"},
+	{"lai", "来赖莱"},
+	{"lan", "兰蓝栏拦篮烂滥懒览"},
+	{"lang", "浪狼朗郎廊"},
+	{"lao", "老劳牢捞姥"},
+	{"le", "了乐勒"},
+	{"lei", "类累雷泪垒"},
+	{"leng", "冷愣"},
+	{"li", "里理力利立离例历李礼丽励黎粒厉璃梨犁"},
+	{"lia", "俩"},
+	{"lian", "连联练脸炼莲恋廉帘怜"},
+	{"liang", "量两良亮辆凉梁粮谅"},
+	{"liao", "料聊疗辽僚寥"},
+	{"lie", "列烈裂猎劣"},
+	{"lin", "林临邻淋琳磷"},
+	{"ling", "领令另灵零龄铃玲凌陵岭"},
+	{"liu", "六流留刘柳溜"},
+	{"long", "龙隆笼拢聋"},
+	{"lou", "楼漏搂"},
+	{"lu", "路陆录露鲁炉鹿卢"},
+	{"lv", "律绿率旅虑吕铝屡"},
+	{"luan", "乱卵"},
+	{"lve", "略掠"},
+	{"lun", "论轮伦"},
+	{"luo", "落罗络洛逻骆萝螺裸"},
+	{"ma", "马吗妈码麻骂嘛"},
+	{"mai", "买卖麦埋迈脉"},
+	{"man", "满慢漫曼蛮瞒"},
+	{"mang", "忙盲茫芒"},
+	{"mao", "毛猫冒帽贸茂矛貌"},
+	{"me", "么"},
+	{"mei", "没美每妹梅媒煤眉霉"},
+	{"men", "们门闷"},
+	{"meng", "梦猛蒙孟盟萌"},
+	{"mi", "米密秘迷弥蜜谜觅"},
+	{"mian", "面免棉眠绵"},
+	{"miao", "秒苗描妙庙"},
+	{"mie", "灭蔑"},
+	{"min", "民敏闵"},
+	{"ming", "名明命鸣铭"},
+	{"miu", "谬"},
+	{"mo", "模末磨摸墨默莫魔膜抹沫"},
+	{"mou", "某谋"},
+	{"mu", "目母木幕牧墓慕暮穆亩"},
+	{"na", "那拿哪纳娜"},
+	{"nai", "乃奶耐"},
+	{"nan", "南难男"},
+	{"nang", "囊"},
+	{"nao", "脑闹恼"},
+	{"ne", "呢"},
+	{"nei", "内"},
+	{"nen", "嫩"},
+	{"neng", "能"},
+	{"ni", "你泥拟尼逆腻"},
+	{"nian", "年念粘"},
+	{"niang", "娘酿"},
+	{"niao", "鸟尿"},
+	{"nie", "捏聂"},
+	{"nin", "您"},
+	{"ning", "宁凝拧"},
+	{"niu", "牛扭纽"},
+	{"nong", "农浓弄"},
+	{"nu", "努怒奴"},
+	{"nv", "女"},
+	{"nuan", "暖"},
+	{"nve", "虐"},
+	{"nuo", "诺挪"},
+	{"o", "哦"},
+	{"ou", "欧偶呕"},
+	{"pa", "怕爬帕"},
+	{"pai", "派排拍牌"},
+	{"pan", "判盘盼攀潘"},
+	{"pang", "旁胖庞"},
+	{"pao", "跑炮泡抛袍"},
+	{"pei", "配陪培佩赔"},
+	{"pen", "喷盆"},
+	{"peng", "朋碰鹏捧棚蓬"},
+	{"pi", "批皮匹屁披疲脾僻"},
+	{"pian", "片篇偏骗"},
+	{"piao", "票漂飘"},
+	{"pie", "撇"},
+	{"pin", "品贫频拼聘"},
+	{"ping", "平评凭瓶屏萍"},
+	{"po", "破迫坡婆颇泼"},
+	{"pou", "剖"},
+	{"pu", "普铺扑朴谱浦葡仆"},
+	{"qi", "起其期气七器企奇齐汽骑旗弃启妻戚欺漆祈岂棋"},
+	{"qia", "恰洽掐"},
+	{"qian", "前钱千签迁潜浅欠牵铅谦遣"},
+	{"qiang", "强枪墙抢腔"},
+	{"qiao", "桥巧敲瞧乔侨悄"},
+	{"qie", "且切窃怯"},
+	{"qin", "亲琴勤侵秦钦禽"},
+	{"qing", "情请清青轻庆倾晴卿"},
+	{"qiong", "穷琼"},
+	{"qiu", "求球秋丘邱"},
+	{"qu", "去取区曲趣屈驱渠趋"},
+	{"quan", "全权圈劝泉券拳"},
+	{"que", "却确缺雀"},
+	{"qun", "群裙"},
+	{"ran", "然燃染冉"},
+	{"rang", "让嚷壤"},
+	{"rao", "绕扰饶"},
+	{"re", "热惹"},
+	{"ren", "人认任仁忍刃"},
+	{"reng", "仍扔"},
+	{"ri", "日"},
+	{"rong", "容荣融溶蓉绒"},
+	{"rou", "肉柔揉"},
+	{"ru", "如入乳儒辱"},
+	{"ruan", "软"},
+	{"rui", "瑞锐"},
+	{"run", "润闰"},
+	{"ruo", "若弱"},
+	{"sa", "撒洒萨"},
+	{"sai", "赛塞腮"},
+	{"san", "三散伞"},
+	{"sang", "桑丧嗓"},
+	{"sao", "扫嫂骚"},
+	{"se", "色涩瑟"},
+	{"sen", "森"},
+	{"seng", "僧"},
+	{"sha", "杀沙啥傻纱刹"},
+	{"shai", "晒筛"},
+	{"shan", "山善闪衫扇陕珊删"},
+	{"shang", "上商伤尚赏"},
+	{"shao", "少烧绍稍哨勺"},
+	{"she", "社设射舍蛇涉摄"},
+	{"shei", "谁"},
+	{"shen", "身深神申甚审肾伸绅慎"},
+	{"sheng", "生声省胜升圣盛剩绳"},
+	{"shi", "是时事实市十使世始式师识示石史视试施势士失室适释饰湿诗尸氏誓"},
+	{"shou", "手受收首守授售寿瘦兽"},
+	{"shu", "书数术属树输述熟束鼠疏舒蔬叔署"},
+	{"shua", "刷耍"},
+	{"shuai", "帅衰摔甩"},
+	{"shuan", "栓拴"},
+	{"shuang", "双霜爽"},
+	{"shui", "水税睡"},
+	{"shun", "顺瞬"},
+	{"shuo", "说硕朔"},
+	{"si", "四思死司私斯似丝寺撕肆"},
+	{"song", "送松宋颂诵"},
+	{"sou", "搜艘"},
+	{"su", "速素诉苏俗塑宿肃"},
+	{"suan", "算酸蒜"},
+	{"sui", "虽随岁碎遂隋"},
+	{"sun", "孙损笋"},
+	{"suo", "所索锁缩琐"},
+	{"ta", "他她它塔踏"},
+	{"tai", "台太态泰抬胎"},
+	{"tan", "谈探坦叹摊滩坛碳"},
+	{"tang", "汤堂唐糖躺趟塘"},
+	{"tao", "讨套逃桃陶涛淘"},
+	{"te", "特"},
+	{"teng", "疼腾藤"},
+	{"ti", "提体题替梯踢"},
+	{"tian", "天田添甜填"},
+	{"tiao", "条调跳挑"},
+	{"tie", "铁贴"},
+	{"ting", "听停庭厅挺亭"},
+	{"tong", "同通统痛童桶筒铜"},
+	{"tou", "头投透偷"},
+	{"tu", "图土突途徒涂吐兔"},
+	{"tuan", "团"},
+	{"tui", "推退腿"},
+	{"tun", "吞屯"},
+	{"tuo", "脱托拖妥"},
+	{"wa", "挖哇娃瓦袜"},
+	{"wai", "外歪"},
+	{"wan", "万完玩晚湾碗顽挽"},
+	{"wang", "王网往望忘亡旺"},
+	{"wei", "为位委未维卫威微围味危伟尾谓违唯胃慰魏"},
+	{"wen", "问文温闻稳纹吻"},
+	{"weng", "翁"},
+	{"wo", "我握窝卧沃"},
+	{"wu", "无五物务午武误屋吴舞雾悟污伍乌"},
+	{"xi", "系西息希喜细席习析洗戏吸稀悉惜夕溪锡熙"},
+	{"xia", "下夏吓峡侠虾狭"},
+	{"xian", "现先线限显县险鲜献闲宪陷仙嫌弦"},
+	{"xiang", "想向相象项香乡像详响箱祥享"},
+	{"xiao", "小校笑消效晓销肖孝萧"},
+	{"xie", "写些协谢斜鞋械泄携邪"},
+	{"xin", "新心信欣辛薪芯"},
+	{"xing", "行性形型星兴幸醒刑姓"},
+	{"xiong", "雄兄胸凶熊"},
+	{"xiu", "修秀休袖锈"},
+	{"xu", "需许续序须虚徐绪叙蓄"},
+	{"xuan", "选宣旋悬轩玄"},
+	{"xue", "学血雪削穴"},
+	{"xun", "讯寻训迅询巡循旬"},
+	{"ya", "压亚呀牙鸭雅押崖"},
+	{"yan", "研眼言严演验颜延沿烟盐岩炎宴艳掩"},
+	{"yang", "样阳养洋扬羊杨仰氧央"},
+	{"yao", "要药摇腰邀遥姚咬耀"},
+	{"ye", "也业夜叶页野爷液"},
+	{"yi", "一以已意义议医易依亿艺异益移疑忆遗宜衣乙仪椅蚁"},
+	{"yin", "因引音印银阴饮隐尹"},
+	{"ying", "应影英营迎硬映赢鹰樱"},
+	{"yo", "哟"},
+	{"yong", "用永拥勇涌泳庸"},
+	{"you", "有由又友油游优右幽邮犹忧"},
+	{"yu", "于与语育鱼雨遇域预玉余愉宇欲羽誉郁豫"},
+	{"yuan", "员元原院远愿园源圆援缘袁怨"},
+	{"yue", "月越约跃阅岳悦"},
+	{"yun", "运云允孕匀韵"},
+	{"za", "杂砸"},
+	{"zai", "在再载灾仔"},
+	{"zan", "赞咱暂"},
+	{"zang", "脏葬"},
+	{"zao", "造早遭燥噪糟枣"},
+	{"ze", "则责泽择"},
+	{"zei", "贼"},
+	{"zen", "怎"},
+	{"zeng", "增赠"},
+	{"zha", "扎炸诈闸渣"},
+	{"zhai", "债摘宅窄"},
+	{"zhan", "站战展占斩盏"},
+	{"zhang", "张章掌涨丈帐障"},
+	{"zhao", "找照招赵召兆"},
+	{"zhe", "这者着哲折遮浙"},
+	{"zhen", "真针阵镇振珍震"},
+	{"zheng", "正政证整争征郑挣睁蒸"},
+	{"zhi", "之只知制治直至指支止值质职纸智志址植致置执旨织枝"},
+	{"zhong", "中种重众终钟忠仲"},
+	{"zhou", "周州洲舟宙轴皱骤"},
+	{"zhu", "主住注助著逐竹朱猪珠祝筑诸柱驻"},
+	{"zhua", "抓"},
+	{"zhuan", "转专砖赚"},
+	{"zhuang", "装状壮庄撞妆"},
+	{"zhui", "追坠"},
+	{"zhun", "准"},
+	{"zhuo", "桌捉卓浊"},
+	{"zi", "自子字资紫姿籽"},
+	{"zong", "总宗纵综踪"},
+	{"zou", "走奏邹"},
+	{"zu", "组族足祖租阻"},
+	{"zuan", "钻"},
+	{"zui", "最罪嘴醉"},
+	{"zun", "尊遵"},
+	{"zuo", "作做坐左座昨"},
+}
+
+func init() {
+	for _, s := range syllables {
+		for _, r := range s.chars {
+			if _, ok := pinyin[r]; !ok {
+				pinyin[r] = s.syllable
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength 别名的最大长度（字节），超出时在单词边界截断
+const MaxLength = 80
+
+// Make 根据标题生成别名：西文转为小写并去除重音符号，常用汉字转写为不带声调的拼音，
+// 其余字符作为分隔符，单词之间以连字符连接；无法转写出任何字符时返回空字符串。
+// 用户填写的别名同样经过 Make 规范化，已规范的别名保持不变
+func Make(title string) string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range norm.NFD.String(title) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// 去除分解后的重音符号，如 é 转为 e
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			word.WriteRune(unicode.ToLower(r))
+		case r >= 0xFF10 && r <= 0xFF5A && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			// 全角字母与数字
+			word.WriteRune(unicode.ToLower(r - 0xFEE0))
+		default:
+			flush()
+			if syllable, ok := pinyin[r]; ok {
+				words = append(words, syllable)
+			}
+		}
+	}
+	flush()
+
+	return truncate(strings.Join(words, "-"))
+}
+
+// WithSuffix 为别名追加数字后缀，用于解决别名冲突，如 hello-world-2；截断时保留后缀
+func WithSuffix(slug string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(slug)+len(suffix) > MaxLength {
+		slug = strings.TrimRight(slug[:MaxLength-len(suffix)], "-")
+	}
+	return slug + suffix
+}
+
+// truncate 将别名截断到 MaxLength 以内，尽量在连字符处截断
+func truncate(slug string) string {
+	if len(slug) <= MaxLength {
+		return slug
+	}
+	cut := slug[:MaxLength]
+	if i := strings.LastIndexByte(cut, '-'); i > 0 && slug[MaxLength] != '-' {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, "-")
+}
@@ -0,0 +1,98 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/configs"
+)
+
+// 内置的短信服务名称
+const (
+	ProviderAliyun  = "aliyun"  // 阿里云短信
+	ProviderTencent = "tencent" // 腾讯云短信
+	ProviderTwilio  = "twilio"  // Twilio
+)
+
+// ErrDisabled 未配置短信服务
+var ErrDisabled = errors.New("未开启短信服务")
+
+// Sender 短信服务
+type Sender interface {
+	// Name 服务名称，对应配置中的 SMS_PROVIDER
+	Name() string
+	// SendCode 向手机号发送验证码，ttl 为验证码有效期
+	SendCode(ctx context.Context, phone, code string, ttl time.Duration) error
+}
+
+// Options 创建短信服务所需的配置
+type Options struct {
+	AccessKeyID     string // 阿里云 AccessKey ID、腾讯云 SecretId 或 Twilio Account SID
+	AccessKeySecret string // 阿里云 AccessKey Secret、腾讯云 SecretKey 或 Twilio Auth Token
+	SignName        string // 短信签名，Twilio 不需要
+	TemplateCode    string // 短信模板 ID，模板中验证码变量名为 code，Twilio 不需要
+	SdkAppID        string // 腾讯云短信应用 ID
+	Region          string // 服务地域
+	From            string // Twilio 发送号码
+	Timeout         time.Duration
+}
+
+// Factory 短信服务构造函数
+type Factory func(opts Options) (Sender, error)
+
+var factories = make(map[string]Factory)
+
+// Register 注册短信服务
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// Names 已注册的短信服务名称
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New 根据配置创建短信服务，未配置服务类型时返回 ErrDisabled
+func New(config *configs.Config) (Sender, error) {
+	cfg := config.SmsConfig
+	if cfg.SmsProvider == "" {
+		return nil, ErrDisabled
+	}
+
+	factory, ok := factories[cfg.SmsProvider]
+	if !ok {
+		return nil, fmt.Errorf("不支持的短信服务: %s，可选值: %s", cfg.SmsProvider, strings.Join(Names(), ", "))
+	}
+	if cfg.SmsAccessKeyID == "" || cfg.SmsAccessKeySecret == "" {
+		return nil, fmt.Errorf("未配置短信服务的 SMS_ACCESS_KEY_ID 或 SMS_ACCESS_KEY_SECRET")
+	}
+
+	timeout := time.Duration(cfg.SmsTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return factory(Options{
+		AccessKeyID:     cfg.SmsAccessKeyID,
+		AccessKeySecret: cfg.SmsAccessKeySecret,
+		SignName:        cfg.SmsSignName,
+		TemplateCode:    cfg.SmsTemplateCode,
+		SdkAppID:        cfg.SmsSdkAppID,
+		Region:          cfg.SmsRegion,
+		From:            cfg.SmsFrom,
+		Timeout:         timeout,
+	})
+}
+
+// codeMessage 不支持模板的服务使用的短信正文
+func codeMessage(code string, ttl time.Duration) string {
+	return fmt.Sprintf("您的验证码是: %s , 有效期为 %d 分钟。", code, int(ttl.Round(time.Minute).Minutes()))
+}
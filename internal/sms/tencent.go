@@ -0,0 +1,126 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	tencentHost    = "sms.tencentcloudapi.com"
+	tencentService = "sms"
+	tencentVersion = "2021-01-11"
+)
+
+func init() {
+	Register(ProviderTencent, newTencent)
+}
+
+// tencent 腾讯云短信服务，使用 API 3.0 与 TC3-HMAC-SHA256 签名
+type tencent struct {
+	opts   Options
+	client *http.Client
+}
+
+func newTencent(opts Options) (Sender, error) {
+	if opts.SignName == "" || opts.TemplateCode == "" || opts.SdkAppID == "" {
+		return nil, fmt.Errorf("腾讯云短信需要配置 SMS_SIGN_NAME、SMS_TEMPLATE_CODE 与 SMS_SDK_APP_ID")
+	}
+	if opts.Region == "" {
+		opts.Region = "ap-guangzhou"
+	}
+	return &tencent{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+func (s *tencent) Name() string {
+	return ProviderTencent
+}
+
+func (s *tencent) SendCode(ctx context.Context, phone, code string, _ time.Duration) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"PhoneNumberSet":   []string{phone},
+		"SmsSdkAppId":      s.opts.SdkAppID,
+		"SignName":         s.opts.SignName,
+		"TemplateId":       s.opts.TemplateCode,
+		"TemplateParamSet": []string{code},
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	date := now.Format("2006-01-02")
+	contentType := "application/json; charset=utf-8"
+
+	canonicalRequest := "POST\n/\n\ncontent-type:" + contentType + "\nhost:" + tencentHost + "\n\ncontent-type;host\n" + sha256Hex(payload)
+	scope := date + "/" + tencentService + "/tc3_request"
+	stringToSign := "TC3-HMAC-SHA256\n" + timestamp + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	secretDate := hmacSHA256([]byte("TC3"+s.opts.AccessKeySecret), date)
+	secretService := hmacSHA256(secretDate, tencentService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+tencentHost, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Host", tencentHost)
+	req.Header.Set("X-TC-Action", "SendSms")
+	req.Header.Set("X-TC-Version", tencentVersion)
+	req.Header.Set("X-TC-Timestamp", timestamp)
+	req.Header.Set("X-TC-Region", s.opts.Region)
+	req.Header.Set("Authorization", fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		s.opts.AccessKeyID, scope, signature))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求腾讯云短信服务失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Response struct {
+			Error *struct {
+				Code    string `json:"Code"`
+				Message string `json:"Message"`
+			} `json:"Error"`
+			SendStatusSet []struct {
+				Code    string `json:"Code"`
+				Message string `json:"Message"`
+			} `json:"SendStatusSet"`
+		} `json:"Response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("解析腾讯云短信响应失败: %v", err)
+	}
+	if e := result.Response.Error; e != nil {
+		return fmt.Errorf("腾讯云短信发送失败: %s %s", e.Code, e.Message)
+	}
+	for _, status := range result.Response.SendStatusSet {
+		if status.Code != "Ok" {
+			return fmt.Errorf("腾讯云短信发送失败: %s %s", status.Code, status.Message)
+		}
+	}
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
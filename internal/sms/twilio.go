@@ -0,0 +1,68 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const twilioEndpoint = "https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json"
+
+func init() {
+	Register(ProviderTwilio, newTwilio)
+}
+
+// twilio Twilio 短信服务，不使用模板，直接发送验证码正文
+type twilio struct {
+	opts   Options
+	client *http.Client
+}
+
+func newTwilio(opts Options) (Sender, error) {
+	if opts.From == "" {
+		return nil, fmt.Errorf("Twilio 需要配置 SMS_FROM")
+	}
+	return &twilio{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+func (s *twilio) Name() string {
+	return ProviderTwilio
+}
+
+func (s *twilio) SendCode(ctx context.Context, phone, code string, ttl time.Duration) error {
+	form := url.Values{"To": {phone}, "Body": {codeMessage(code, ttl)}}
+	// 以 MG 开头的发送方为 Messaging Service
+	if strings.HasPrefix(s.opts.From, "MG") {
+		form.Set("MessagingServiceSid", s.opts.From)
+	} else {
+		form.Set("From", s.opts.From)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf(twilioEndpoint, url.PathEscape(s.opts.AccessKeyID)), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.opts.AccessKeyID, s.opts.AccessKeySecret)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求 Twilio 失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var result struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return fmt.Errorf("Twilio 短信发送失败: 状态码 %d, %d %s", resp.StatusCode, result.Code, result.Message)
+	}
+	return nil
+}
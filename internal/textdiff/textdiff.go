@@ -0,0 +1,267 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 差异行的类型
+const (
+	OpEqual  = ' ' // 两侧相同
+	OpDelete = '-' // 仅在旧文本中
+	OpInsert = '+' // 仅在新文本中
+)
+
+// maxEditDistance 差异过大时不再逐行比较，直接视为删除全部旧行并插入全部新行，避免耗时与内存过高
+const maxEditDistance = 2000
+
+// Line 差异中的一行
+type Line struct {
+	Op      byte   // 差异类型，见 OpEqual、OpDelete、OpInsert
+	Text    string // 行内容，不含换行符
+	OldLine int    // 在旧文本中的行号，从 1 开始，插入的行为 0
+	NewLine int    // 在新文本中的行号，从 1 开始，删除的行为 0
+}
+
+// Diff 两段文本的逐行差异
+type Diff struct {
+	Lines     []Line
+	Additions int // 新增行数
+	Deletions int // 删除行数
+}
+
+// Compare 按行比较 a 与 b，a 为旧文本，b 为新文本；统一换行符后比较，末尾换行符不影响结果
+func Compare(a, b string) *Diff {
+	oldLines, newLines := splitLines(a), splitLines(b)
+	diff := &Diff{Lines: make([]Line, 0, len(oldLines)+len(newLines))}
+
+	// 相同的开头与结尾不参与比较
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	oldLine, newLine := 0, 0
+	emit := func(op byte, text string) {
+		line := Line{Op: op, Text: text}
+		switch op {
+		case OpEqual:
+			oldLine++
+			newLine++
+			line.OldLine, line.NewLine = oldLine, newLine
+		case OpDelete:
+			oldLine++
+			line.OldLine = oldLine
+			diff.Deletions++
+		case OpInsert:
+			newLine++
+			line.NewLine = newLine
+			diff.Additions++
+		}
+		diff.Lines = append(diff.Lines, line)
+	}
+
+	for _, text := range oldLines[:prefix] {
+		emit(OpEqual, text)
+	}
+	for _, edit := range myers(oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix]) {
+		emit(edit.op, edit.text)
+	}
+	for _, text := range oldLines[len(oldLines)-suffix:] {
+		emit(OpEqual, text)
+	}
+	return diff
+}
+
+// Changed 两段文本是否存在差异
+func (d *Diff) Changed() bool {
+	return d.Additions > 0 || d.Deletions > 0
+}
+
+// Unified 生成统一格式的差异文本，context 为每处修改前后保留的相同行数，无差异时返回空字符串
+func (d *Diff) Unified(oldName, newName string, context int) string {
+	if !d.Changed() {
+		return ""
+	}
+	if context < 0 {
+		context = 0
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(d.Lines); {
+		// 找到下一处修改，向前保留 context 行
+		first := start
+		for first < len(d.Lines) && d.Lines[first].Op == OpEqual {
+			first++
+		}
+		if first == len(d.Lines) {
+			break
+		}
+		begin := max(first-context, start)
+
+		// 相邻修改之间的相同行不超过 2*context 时合并为一段
+		end, equal := first, 0
+		for i := first; i < len(d.Lines); i++ {
+			if d.Lines[i].Op != OpEqual {
+				end, equal = i+1, 0
+				continue
+			}
+			equal++
+			if equal > 2*context {
+				break
+			}
+		}
+		end = min(end+context, len(d.Lines))
+
+		oldBefore, newBefore := 0, 0
+		for _, line := range d.Lines[:begin] {
+			if line.Op != OpInsert {
+				oldBefore++
+			}
+			if line.Op != OpDelete {
+				newBefore++
+			}
+		}
+		writeHunk(&sb, d.Lines[begin:end], oldBefore, newBefore)
+		start = end
+	}
+	return sb.String()
+}
+
+// writeHunk 写入一段差异及其 @@ 行号头，oldBefore 与 newBefore 为该段之前两侧的行数
+func writeHunk(sb *strings.Builder, lines []Line, oldBefore, newBefore int) {
+	oldCount, newCount := 0, 0
+	for _, line := range lines {
+		if line.Op != OpInsert {
+			oldCount++
+		}
+		if line.Op != OpDelete {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(oldBefore, oldCount), hunkRange(newBefore, newCount))
+	for _, line := range lines {
+		sb.WriteByte(line.Op)
+		sb.WriteString(line.Text)
+		sb.WriteByte('\n')
+	}
+}
+
+// hunkRange 格式化 @@ 头中的行号范围，只有一行时省略行数，没有行时起始行号为该段之前的行
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// splitLines 统一换行符后按行拆分，忽略末尾的换行符
+func splitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+type edit struct {
+	op   byte
+	text string
+}
+
+// myers 使用 Myers 差分算法计算将 a 变为 b 的最短编辑序列，编辑距离超过 maxEditDistance 时整体替换
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replaceAll(a, b)
+	}
+
+	// v[offset+k] 为对角线 k 上已到达的最远 x；trace[d] 保存第 d 步开始前对角线 -d-1 ~ d+1 的值，用于回溯
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+	found := false
+	for d := 0; d <= n+m && d <= maxEditDistance && !found; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return replaceAll(a, b)
+	}
+
+	// 从终点回溯编辑路径，得到的编辑序列为倒序
+	edits := make([]edit, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := func(k int) int { return trace[d][k+d+1] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || k != d && prev(k-1) < prev(k+1) {
+			prevK = k + 1
+		}
+		prevX := prev(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{OpEqual, a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{OpInsert, b[y]})
+		} else {
+			x--
+			edits = append(edits, edit{OpDelete, a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, edit{OpEqual, a[x]})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// replaceAll 删除全部旧行并插入全部新行
+func replaceAll(a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+	for _, text := range a {
+		edits = append(edits, edit{OpDelete, text})
+	}
+	for _, text := range b {
+		edits = append(edits, edit{OpInsert, text})
+	}
+	return edits
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	apiKeyPrefix    = "jk_" // API Key 的固定前缀，便于在代码仓库中扫描泄露的 Key
+	apiKeySize      = 24    // API Key 的随机字节数
+	apiKeyShownSize = 11    // 展示的前缀长度，包含固定前缀
+)
+
+// GenerateAPIKey 生成 API Key，返回完整的 Key、用于展示的前缀与入库保存的摘要
+func GenerateAPIKey() (string, string, string, error) {
+	b := make([]byte, apiKeySize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", fmt.Errorf("生成 API Key 失败: %v", err)
+	}
+	key := apiKeyPrefix + hex.EncodeToString(b)
+	return key, key[:apiKeyShownSize], HashAPIKey(key), nil
+}
+
+// HashAPIKey 计算 API Key 的摘要，Key 为高熵随机值，无需加盐
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	deviceTokenPrefix = "jkd_" // 记住我设备令牌的固定前缀，与 API Key 区分
+	deviceTokenSize   = 32     // 记住我设备令牌的随机字节数
+)
+
+// GenerateDeviceToken 生成记住我设备令牌，返回完整的令牌与入库保存的摘要
+func GenerateDeviceToken() (string, string, error) {
+	b := make([]byte, deviceTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("生成设备令牌失败: %v", err)
+	}
+	token := deviceTokenPrefix + hex.EncodeToString(b)
+	return token, HashDeviceToken(token), nil
+}
+
+// HashDeviceToken 计算设备令牌或客户端设备标识的摘要，令牌为高熵随机值，无需加盐
+func HashDeviceToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
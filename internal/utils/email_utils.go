@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"crypto/rand"
+	"errors"
 	"fmt"
-	"math/rand"
+	"math/big"
 	"net/smtp"
 	"regexp"
 	"time"
@@ -10,11 +12,23 @@ import (
 	"github.com/jordan-wright/email"
 
 	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/breaker"
 	"jank.com/jank_blog/internal/global"
 )
 
 const SUBJECT = "【Jank Blog】注册验证码"
 
+// ErrMailUnavailable SMTP 服务连续发送失败，熔断期间不再发送邮件
+var ErrMailUnavailable = errors.New("邮件服务暂不可用，请稍后再试")
+
+// mailBreaker 邮件发送熔断器，配置在每次发送时按最新配置刷新
+var mailBreaker = breaker.New("smtp", breaker.Options{})
+
+// MailBreakerStatus 获取邮件发送熔断器状态
+func MailBreakerStatus() breaker.Status {
+	return mailBreaker.Status()
+}
+
 // 邮箱服务器配置
 var emailServers = map[string]struct {
 	Server, Port string
@@ -24,8 +38,13 @@ var emailServers = map[string]struct {
 	"outlook": {"smtp.office365.com", ":587"},
 }
 
-// SendEmail 发送邮件到指定邮箱
+// SendEmail 发送纯文本邮件到指定邮箱
 func SendEmail(content string, toEmail []string) (bool, error) {
+	return SendHTMLEmail(SUBJECT, content, "", toEmail)
+}
+
+// SendHTMLEmail 发送邮件到指定邮箱，html 不为空时以 HTML 正文发送，text 作为纯文本备选正文
+func SendHTMLEmail(subject, text, html string, toEmail []string) (bool, error) {
 	config, err := configs.LoadConfig()
 	if err != nil {
 		global.SysLog.Errorf("加载 SMTP Auth 配置失败, toEmail: %v, 错误信息: %v", toEmail, err)
@@ -44,13 +63,28 @@ func SendEmail(content string, toEmail []string) (bool, error) {
 	e := email.NewEmail()
 	e.From = config.AppConfig.FromEmail
 	e.To = toEmail
-	e.Subject = SUBJECT
-	e.Text = []byte(content)
+	e.Subject = subject
+	e.Text = []byte(text)
+	if html != "" {
+		e.HTML = []byte(html)
+	}
 
 	smtpAddr := serverConfig.Server + serverConfig.Port
 	auth := smtp.PlainAuth("", config.AppConfig.FromEmail, config.AppConfig.EmailSmtp, serverConfig.Server)
 
-	if err := e.Send(smtpAddr, auth); err != nil {
+	cfg := config.MailBreakerConfig
+	mailBreaker.Configure(breaker.Options{
+		FailureThreshold: cfg.MailBreakerThreshold,
+		OpenDuration:     time.Duration(cfg.MailBreakerOpenDuration) * time.Second,
+	})
+	err = mailBreaker.Do(func() error {
+		return sendWithTimeout(e, smtpAddr, auth, time.Duration(cfg.MailSendTimeout)*time.Second)
+	})
+	if errors.Is(err, breaker.ErrOpen) {
+		global.SysLog.Warnf("邮件发送熔断中，未发送邮件, toEmail: %v", toEmail)
+		return false, ErrMailUnavailable
+	}
+	if err != nil {
 		global.SysLog.Errorf("发送邮件失败, toEmail: %v, 错误信息: %v", toEmail, err)
 		return false, fmt.Errorf("发送邮件失败: %v", err)
 	}
@@ -58,10 +92,65 @@ func SendEmail(content string, toEmail []string) (bool, error) {
 	return true, nil
 }
 
-// NewRand 生成六位数随机验证码
-func NewRand() int {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	return r.Intn(900000) + 100000
+// sendWithTimeout 发送邮件，超过 timeout 时返回错误，未完成的发送在后台继续直至连接关闭
+func sendWithTimeout(e *email.Email, addr string, auth smtp.Auth, timeout time.Duration) error {
+	if timeout <= 0 {
+		return e.Send(addr, auth)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- e.Send(addr, auth) }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("发送超时（%s）", timeout)
+	}
+}
+
+// 邮箱与短信验证码格式
+const (
+	CodeFormatDigits       = "digits"       // 纯数字，默认
+	CodeFormatAlphanumeric = "alphanumeric" // 大写字母与数字
+)
+
+// 验证码长度的默认值与取值范围
+const (
+	DefaultCodeLength = 6
+	minCodeLength     = 4
+	maxCodeLength     = 12
+)
+
+// codeSources 各验证码格式对应的字符源，去掉了容易混淆的 0、O、1、I
+var codeSources = map[string]string{
+	CodeFormatDigits:       "0123456789",
+	CodeFormatAlphanumeric: "ABCDEFGHJKLMNPQRSTUVWXYZ23456789",
+}
+
+// NewRand 按长度与格式生成随机验证码，长度为 0 时使用默认长度，超出范围时取边界值
+func NewRand(length int, format string) (string, error) {
+	if length == 0 {
+		length = DefaultCodeLength
+	}
+	length = min(max(length, minCodeLength), maxCodeLength)
+	if format == "" {
+		format = CodeFormatDigits
+	}
+	source, ok := codeSources[format]
+	if !ok {
+		return "", fmt.Errorf("不支持的验证码格式: %s", format)
+	}
+
+	code := make([]byte, length)
+	limit := big.NewInt(int64(len(source)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", fmt.Errorf("生成随机验证码失败: %v", err)
+		}
+		code[i] = source[n.Int64()]
+	}
+	return string(code), nil
 }
 
 // ValidEmail 检查邮箱格式是否有效
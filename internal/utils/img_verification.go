@@ -15,11 +15,38 @@ const (
 	CaptchaLength = 4                                      // 验证码字符长度
 )
 
+// 图形验证码字符集
+const (
+	CaptchaCharsetAlphanumeric = "alphanumeric" // 大写字母与数字，默认
+	CaptchaCharsetDigits       = "digits"       // 纯数字
+	CaptchaCharsetLetters      = "letters"      // 纯大写字母
+	CaptchaCharsetMath         = "math"         // 算术表达式，答案为计算结果
+)
+
+// captchaSources 各字符集对应的字符源
+var captchaSources = map[string]string{
+	CaptchaCharsetAlphanumeric: CaptchaSource,
+	CaptchaCharsetDigits:       "0123456789",
+	CaptchaCharsetLetters:      "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+}
+
+// ImgCaptchaOptions 图形验证码生成配置，零值字段使用默认值
+type ImgCaptchaOptions struct {
+	Length     int    // 字符长度，算术表达式不适用
+	Charset    string // 字符集
+	NoiseCount int    // 干扰字符数量
+	Width      int    // 图片宽度
+	Height     int    // 图片高度
+}
+
 var store = base64Captcha.DefaultMemStore
 
-// GenImgVerificationCode 生成图形验证码
-func GenImgVerificationCode() (string, string, error) {
-	driver := createDriver()
+// GenImgVerificationCode 生成图形验证码，返回 Base64 编码的图片与答案
+func GenImgVerificationCode(opts ImgCaptchaOptions) (string, string, error) {
+	driver, err := createDriver(opts)
+	if err != nil {
+		return "", "", err
+	}
 	captcha := base64Captcha.NewCaptcha(driver, store)
 	_, content, answer := captcha.Driver.GenerateIdQuestionAnswer()
 	item, err := captcha.Driver.DrawCaptcha(content)
@@ -30,14 +57,42 @@ func GenImgVerificationCode() (string, string, error) {
 }
 
 // createDriver 创建验证码的驱动配置
-func createDriver() *base64Captcha.DriverString {
+func createDriver(opts ImgCaptchaOptions) (base64Captcha.Driver, error) {
+	if opts.Width <= 0 {
+		opts.Width = ImgWidth
+	}
+	if opts.Height <= 0 {
+		opts.Height = ImgHeight
+	}
+	if opts.Length <= 0 {
+		opts.Length = CaptchaLength
+	}
+	opts.NoiseCount = max(opts.NoiseCount, NoiseCount)
+	if opts.Charset == "" {
+		opts.Charset = CaptchaCharsetAlphanumeric
+	}
+
+	if opts.Charset == CaptchaCharsetMath {
+		return &base64Captcha.DriverMath{
+			Height:          opts.Height,
+			Width:           opts.Width,
+			NoiseCount:      opts.NoiseCount,
+			ShowLineOptions: base64Captcha.OptionShowSineLine,
+			Fonts:           []string{FontFile},
+		}, nil
+	}
+
+	source, ok := captchaSources[opts.Charset]
+	if !ok {
+		return nil, fmt.Errorf("不支持的图形验证码字符集: %s", opts.Charset)
+	}
 	return &base64Captcha.DriverString{
-		Height:          ImgHeight,
-		Width:           ImgWidth,
-		NoiseCount:      NoiseCount,
+		Height:          opts.Height,
+		Width:           opts.Width,
+		NoiseCount:      opts.NoiseCount,
 		ShowLineOptions: base64Captcha.OptionShowSineLine,
-		Length:          CaptchaLength,
-		Source:          CaptchaSource,
+		Length:          opts.Length,
+		Source:          source,
 		Fonts:           []string{FontFile},
-	}
+	}, nil
 }
@@ -1,30 +1,60 @@
 package utils
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/jwtkey"
+)
+
+const (
+	RefreshRevokedCacheKeyPrefix       = "REFRESH:REVOKED:"        // 已吊销的 refresh token，键为前缀加 jti
+	RefreshRevokedBeforeCacheKeyPrefix = "REFRESH:REVOKED_BEFORE:" // 账户全部设备登出的时间，此前签发的 refresh token 均失效，键为前缀加账户 ID
 )
 
+// 派生密钥的用途标签，不同令牌使用各自的派生密钥，互相不能替代
+const (
+	derivedLabelTicket   = "verification-ticket" // 验证凭证
+	derivedLabelDownload = "download-link"       // 下载链接
+	derivedLabelPost     = "post-access"         // 文章访问令牌
+)
+
+// ErrRefreshTokenRevoked refresh token 已使用或已吊销
+var ErrRefreshTokenRevoked = errors.New("refresh token 已使用或已吊销，请重新登录")
+
 var (
 	// 密钥和有效期配置
-	accessSecret      = []byte("jank-blog-secret")         // Access Token 使用的密钥
-	refreshSecret     = []byte("jank-blog-refresh-secret") // Refresh Token 使用的密钥
+	accessSecret      = []byte("jank-blog-secret")         // Access Token 的内置密钥，仅在升级后的宽限期内校验升级前签发的 token
+	refreshSecret     = []byte("jank-blog-refresh-secret") // Refresh Token 的内置密钥，仅在升级后的宽限期内校验升级前签发的 token
 	accessExpireTime  = time.Hour * 2                      // Access Token 有效期
 	refreshExpireTime = time.Hour * 48                     // Refresh Token 有效期
 	clockSkew         = 5 * time.Second                    // 允许的时间偏差量
 )
 
-// GenerateJWT 生成 Access Token 和 Refresh Token
-func GenerateJWT(accountID, roleID int64) (string, string, error) {
-	accessTokenString, err := generateToken(accountID, roleID, accessSecret, accessExpireTime)
+func init() {
+	jwtkey.SetLegacySecret(jwtkey.PurposeAccess, accessSecret)
+	jwtkey.SetLegacySecret(jwtkey.PurposeRefresh, refreshSecret)
+}
+
+// GenerateJWT 生成 Access Token 和 Refresh Token，sessionID 为登录会话 ID，写入 sid 声明
+func GenerateJWT(accountID, roleID int64, sessionID string) (string, string, error) {
+	accessTokenString, err := generateToken(accountID, roleID, sessionID, jwtkey.PurposeAccess, accessExpireTime)
 	if err != nil {
 		return "", "", err
 	}
 
-	refreshTokenString, err := generateToken(accountID, roleID, refreshSecret, refreshExpireTime)
+	refreshTokenString, err := generateToken(accountID, roleID, sessionID, jwtkey.PurposeRefresh, refreshExpireTime)
 	if err != nil {
 		return "", "", err
 	}
@@ -32,16 +62,21 @@ func GenerateJWT(accountID, roleID int64) (string, string, error) {
 	return accessTokenString, refreshTokenString, nil
 }
 
+// GenerateAccessToken 仅生成不属于任何会话的 Access Token，用于 API Key 认证后供下游业务解析当前用户
+func GenerateAccessToken(accountID, roleID int64) (string, error) {
+	return generateToken(accountID, roleID, "", jwtkey.PurposeAccess, accessExpireTime)
+}
+
 // ValidateJWTToken 验证 Access Token 或 Refresh Token
 func ValidateJWTToken(tokenString string, isRefreshToken bool) (*jwt.Token, error) {
 	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
-	secret := accessSecret
+	purpose := jwtkey.PurposeAccess
 	if isRefreshToken {
-		secret = refreshSecret
+		purpose = jwtkey.PurposeRefresh
 	}
 
-	token, err := validateToken(tokenString, secret)
+	token, err := validateToken(tokenString, purpose)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +99,8 @@ func ValidateJWTToken(tokenString string, isRefreshToken bool) (*jwt.Token, erro
 	return token, nil
 }
 
-// RefreshTokenLogic 负责刷新 Token
+// RefreshTokenLogic 负责刷新 Token，每个 refresh token 只能使用一次，刷新后旧 token 加入吊销列表
+// 新 token 沿用旧 token 的会话 ID，返回值中 sessionID 为会话 ID，由调用方校验会话未结束
 func RefreshTokenLogic(refreshTokenString string) (map[string]string, error) {
 	token, err := ValidateJWTToken(refreshTokenString, true)
 	if err != nil {
@@ -74,8 +110,13 @@ func RefreshTokenLogic(refreshTokenString string) (map[string]string, error) {
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		accountID := int64(claims["account_id"].(float64))
 		roleID := int64(claims["role_id"].(float64))
+		sessionID, _ := claims["sid"].(string)
+
+		if err := revokeRefreshClaims(claims); err != nil {
+			return nil, err
+		}
 
-		newAccessToken, newRefreshToken, err := GenerateJWT(accountID, roleID)
+		newAccessToken, newRefreshToken, err := GenerateJWT(accountID, roleID, sessionID)
 		if err != nil {
 			return nil, err
 		}
@@ -83,12 +124,67 @@ func RefreshTokenLogic(refreshTokenString string) (map[string]string, error) {
 		return map[string]string{
 			"accessToken":  newAccessToken,
 			"refreshToken": newRefreshToken,
+			"sessionID":    sessionID,
 		}, nil
 	}
 
 	return nil, fmt.Errorf("refresh token 验证失败")
 }
 
+// RevokeRefreshToken 吊销 refresh token，已过期或已吊销的 token 不做处理
+func RevokeRefreshToken(refreshTokenString string) error {
+	token, err := ValidateJWTToken(refreshTokenString, true)
+	if err != nil {
+		return nil
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+	if err := revokeRefreshClaims(claims); err != nil && !errors.Is(err, ErrRefreshTokenRevoked) {
+		return err
+	}
+	return nil
+}
+
+// RevokeAllRefreshTokens 吊销账户此前签发的全部 refresh token，用于全部设备登出
+func RevokeAllRefreshTokens(accountID int64) error {
+	key := fmt.Sprintf("%s%d", RefreshRevokedBeforeCacheKeyPrefix, accountID)
+	if err := cache.Current().Set(context.Background(), key, time.Now().UTC().Unix(), refreshExpireTime); err != nil {
+		return fmt.Errorf("吊销 refresh token 失败: %v", err)
+	}
+	return nil
+}
+
+// revokeRefreshClaims 校验 refresh token 未被吊销并将其加入吊销列表，吊销记录保留到 token 过期
+// 使用 SetNX 写入，并发使用同一 token 刷新时只有一个请求成功
+func revokeRefreshClaims(claims jwt.MapClaims) error {
+	jti, _ := claims["jti"].(string)
+	iat, _ := claims["iat"].(float64)
+	exp, _ := claims["exp"].(float64)
+	accountID, _ := claims["account_id"].(float64)
+	if jti == "" {
+		return ErrRefreshTokenRevoked
+	}
+
+	ctx := context.Background()
+	if value, err := cache.Current().Get(ctx, fmt.Sprintf("%s%d", RefreshRevokedBeforeCacheKeyPrefix, int64(accountID))); err == nil {
+		if revokedBefore, _ := strconv.ParseInt(value, 10, 64); int64(iat) <= revokedBefore {
+			return ErrRefreshTokenRevoked
+		}
+	}
+
+	ttl := time.Until(time.Unix(int64(exp), 0)) + clockSkew
+	fresh, err := cache.Current().SetNX(ctx, RefreshRevokedCacheKeyPrefix+jti, 1, ttl)
+	if err != nil {
+		return fmt.Errorf("写入 refresh token 吊销列表失败: %v", err)
+	}
+	if !fresh {
+		return ErrRefreshTokenRevoked
+	}
+	return nil
+}
+
 // ParseAccountAndRoleIDFromJWT 从 JWT 中提取 accountID 和 roleID
 func ParseAccountAndRoleIDFromJWT(tokenString string) (int64, int64, error) {
 	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
@@ -116,28 +212,171 @@ func ParseAccountAndRoleIDFromJWT(tokenString string) (int64, int64, error) {
 	return int64(accountID), int64(roleID), nil
 }
 
-// generateToken 通用的 token 生成函数
-func generateToken(accountID, roleID int64, secret []byte, expireTime time.Duration) (string, error) {
+// ParseSessionIDFromJWT 从 JWT 中提取会话 ID，token 无效或不属于任何会话时返回空字符串
+func ParseSessionIDFromJWT(tokenString string) string {
+	token, err := ValidateJWTToken(tokenString, false)
+	if err != nil {
+		return ""
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	sessionID, _ := claims["sid"].(string)
+	return sessionID
+}
+
+// OptionalAccountIDFromJWT 从可选的 Authorization 请求头中提取 accountID，未登录或 token 无效时返回 0
+// 用于无需登录、但登录后返回个性化内容的接口
+func OptionalAccountIDFromJWT(tokenString string) int64 {
+	if tokenString == "" {
+		return 0
+	}
+	accountID, _, err := ParseAccountAndRoleIDFromJWT(tokenString)
+	if err != nil {
+		return 0
+	}
+	return accountID
+}
+
+// VerificationTicketClaims 验证凭证中的声明
+type VerificationTicketClaims struct {
+	Channel string `json:"channel"`          // 通过校验的验证码渠道
+	Target  string `json:"target"`           // 验证码接收方，邮箱或手机号
+	Action  string `json:"action,omitempty"` // 验证码用途，图形验证码与 TOTP 动态码为空
+	jwt.RegisteredClaims
+}
+
+// GenerateVerificationTicket 生成验证码校验通过后的验证凭证，id 用于保证凭证只能使用一次
+func GenerateVerificationTicket(id, channel, target, action string, expireTime time.Duration) (string, error) {
+	claims := VerificationTicketClaims{
+		Channel: channel,
+		Target:  target,
+		Action:  action,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(expireTime)),
+		},
+	}
+	return signDerived(claims, derivedLabelTicket)
+}
+
+// ParseVerificationTicket 校验验证凭证的签名与有效期并返回其中的声明
+func ParseVerificationTicket(tokenString string) (*VerificationTicketClaims, error) {
+	claims := &VerificationTicketClaims{}
+	if err := parseDerived(tokenString, claims, derivedLabelTicket); err != nil {
+		return nil, fmt.Errorf("验证凭证无效: %v", err)
+	}
+	return claims, nil
+}
+
+// DownloadTokenClaims 下载链接令牌中的声明
+type DownloadTokenClaims struct {
+	AccountID int64 `json:"account_id"` // 文件所属用户 ID
+	jwt.RegisteredClaims
+}
+
+// GenerateDownloadToken 生成下载链接中的令牌，fileID 为待下载文件的 ID，令牌在有效期内可多次使用；
+// 有效期超过签名密钥轮换的宽限期时，签名密钥停止校验后链接提前失效
+func GenerateDownloadToken(accountID int64, fileID string, expireTime time.Duration) (string, error) {
+	claims := DownloadTokenClaims{
+		AccountID: accountID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   fileID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(expireTime)),
+		},
+	}
+	return signDerived(claims, derivedLabelDownload)
+}
+
+// ParseDownloadToken 校验下载链接令牌的签名与有效期并返回其中的声明
+func ParseDownloadToken(tokenString string) (*DownloadTokenClaims, error) {
+	claims := &DownloadTokenClaims{}
+	if err := parseDerived(tokenString, claims, derivedLabelDownload); err != nil {
+		return nil, fmt.Errorf("下载链接无效: %v", err)
+	}
+	return claims, nil
+}
+
+// PostAccessTokenClaims 文章访问令牌中的声明，Subject 为文章 ID
+type PostAccessTokenClaims struct {
+	Stamp string `json:"stamp"` // 签发时访问密码的印记，修改密码后此前签发的令牌失效
+	jwt.RegisteredClaims
+}
+
+// PostID 令牌对应的文章 ID，Subject 不是有效的 ID 时返回 0
+func (c *PostAccessTokenClaims) PostID() int64 {
+	id, _ := strconv.ParseInt(c.Subject, 10, 64)
+	return id
+}
+
+// GeneratePostAccessToken 生成密码保护文章解锁后的访问令牌，令牌仅对 postID 对应的文章有效，在有效期内可多次使用
+func GeneratePostAccessToken(postID int64, stamp string, expireTime time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().UTC().Add(expireTime)
+	claims := PostAccessTokenClaims{
+		Stamp: stamp,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(postID, 10),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := signDerived(claims, derivedLabelPost)
+	return token, expiresAt, err
+}
+
+// ParsePostAccessToken 校验文章访问令牌的签名与有效期并返回其中的声明
+func ParsePostAccessToken(tokenString string) (*PostAccessTokenClaims, error) {
+	claims := &PostAccessTokenClaims{}
+	if err := parseDerived(tokenString, claims, derivedLabelPost); err != nil {
+		return nil, fmt.Errorf("文章访问令牌无效: %v", err)
+	}
+	return claims, nil
+}
+
+// generateToken 通用的 token 生成函数，jti 用于吊销单个 token，sessionID 为空时不写入 sid 声明
+// 使用用途对应的当前签名密钥签名并将密钥 ID 写入 kid 头部，签名密钥不可用时返回错误，不回退到内置密钥
+func generateToken(accountID, roleID int64, sessionID, purpose string, expireTime time.Duration) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
 	claims := jwt.MapClaims{
 		"account_id": accountID,
 		"role_id":    roleID,
-		"exp":        time.Now().UTC().Add(expireTime).Unix(),
+		"jti":        hex.EncodeToString(jti),
+		"iat":        now.Unix(),
+		"exp":        now.Add(expireTime).Unix(),
+	}
+	if sessionID != "" {
+		claims["sid"] = sessionID
+	}
+	key, err := jwtkey.Signing(purpose)
+	if err != nil {
+		return "", err
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(secret)
+	token.Header["kid"] = key.Kid
+	tokenString, err := token.SignedString(key.Secret)
 	if err != nil {
 		return "", err
 	}
 	return tokenString, nil
 }
 
-// validateToken 验证 token 是否有效
-func validateToken(tokenString string, secret []byte) (*jwt.Token, error) {
+// validateToken 验证 token 是否有效，按 kid 头部选择签名密钥，不带 kid 的 token 为升级前签发的，
+// 仅在升级后的宽限期内以保存的内置密钥校验；签名密钥不可用时拒绝全部 token
+func validateToken(tokenString, purpose string) (*jwt.Token, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return secret, nil
+		kid, _ := token.Header["kid"].(string)
+		key, err := jwtkey.Lookup(purpose, kid)
+		if err != nil {
+			return nil, err
+		}
+		return key.Secret, nil
 	})
 
 	if err != nil {
@@ -145,3 +384,42 @@ func validateToken(tokenString string, secret []byte) (*jwt.Token, error) {
 	}
 	return token, nil
 }
+
+// signDerived 使用当前 access token 签名密钥派生的 label 专用密钥签名，并将密钥 ID 写入 kid 头部；
+// 签名密钥不可用时返回错误，不回退到公开在源码中的内置密钥
+func signDerived(claims jwt.Claims, label string) (string, error) {
+	key, err := jwtkey.Signing(jwtkey.PurposeAccess)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(deriveSecret(key.Secret, label))
+}
+
+// parseDerived 按 kid 头部查找签名密钥并派生 label 专用密钥校验令牌，拒绝不带 kid 或使用内置密钥的令牌；
+// 签名密钥轮换后宽限期内签发的令牌继续有效，立即轮换后全部失效
+func parseDerived(tokenString string, claims jwt.Claims, label string) error {
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" || kid == jwtkey.LegacyKid(jwtkey.PurposeAccess) {
+			return nil, jwtkey.ErrUnknownKey
+		}
+		key, err := jwtkey.Lookup(jwtkey.PurposeAccess, kid)
+		if err != nil {
+			return nil, err
+		}
+		return deriveSecret(key.Secret, label), nil
+	})
+	return err
+}
+
+// deriveSecret 以签名密钥为 HMAC 密钥计算 label 的摘要，作为 label 对应的专用密钥
+func deriveSecret(secret []byte, label string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
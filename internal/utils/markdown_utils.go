@@ -2,13 +2,22 @@ package utils
 
 import (
 	"bytes"
+	"strconv"
+	"strings"
 	"sync"
+	"unicode"
 
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
+
+	"jank.com/jank_blog/configs"
 )
 
 // 使用 sync.Pool 复用 buffer
@@ -34,44 +43,175 @@ func NewMarkdownRenderer(config MarkdownConfig) goldmark.Markdown {
 	)
 }
 
-// defaultMarkdownConfig 返回默认的 Markdown 配置
-func defaultMarkdownConfig() MarkdownConfig {
+// defaultHighlightTheme 未配置或配置的主题不存在时使用的代码高亮主题
+const defaultHighlightTheme = "github"
+
+// markdownExtensions 可在 MARKDOWN_EXTENSIONS 中启用的扩展，table、tasklist、strikethrough 与 linkify 组成 GFM
+var markdownExtensions = map[string]goldmark.Extender{
+	"table":           extension.Table,          // 表格支持
+	"tasklist":        extension.TaskList,       // 任务列表支持
+	"strikethrough":   extension.Strikethrough,  // 删除线支持
+	"linkify":         extension.Linkify,        // 自动链接支持
+	"footnote":        extension.Footnote,       // 脚注支持
+	"definition_list": extension.DefinitionList, // 定义列表支持
+	"typographer":     extension.Typographer,    // 智能引号、破折号等排版替换
+}
+
+// markdownConfig 按配置生成 Markdown 渲染器配置；文章按 SANITIZER_RAW_HTML 保留原始 HTML，由调用方负责过滤；
+// 评论始终丢弃原始 HTML，且不生成标题 ID、不支持自定义属性，避免与文章的标题锚点冲突
+func markdownConfig(cfg *configs.Config, comment bool) MarkdownConfig {
+	config := cfg.MarkdownConfig
+	names := config.MarkdownExtensions
+	if len(names) == 0 {
+		names = []string{"table", "tasklist", "strikethrough", "linkify", "footnote", "definition_list", "typographer"}
+	}
+	var extensions []goldmark.Extender
+	for _, name := range names {
+		if ext, ok := markdownExtensions[strings.ToLower(strings.TrimSpace(name))]; ok {
+			extensions = append(extensions, ext)
+		}
+	}
+
+	if config.HighlightEnabled {
+		theme := config.HighlightTheme
+		if _, ok := styles.Registry[theme]; !ok {
+			theme = defaultHighlightTheme
+		}
+		extensions = append(extensions, highlighting.NewHighlighting(
+			highlighting.WithStyle(theme),
+			highlighting.WithFormatOptions(
+				chromahtml.WithLineNumbers(config.HighlightLineNumbers),
+				chromahtml.TabWidth(4),
+			),
+		))
+	}
+
+	rendererOptions := []renderer.Option{
+		html.WithXHTML(), // 生成 XHTML
+	}
+	if config.MarkdownHardWraps {
+		rendererOptions = append(rendererOptions, html.WithHardWraps()) // 硬换行
+	}
+	parserOptions := []parser.Option{
+		parser.WithBlockParsers(),          // 块解析器
+		parser.WithInlineParsers(),         // 内联解析器
+		parser.WithParagraphTransformers(), // 段落转换器
+		parser.WithASTTransformers(),       // AST 转换器
+	}
+	if !comment {
+		if cfg.SanitizerConfig.SanitizerRawHTML {
+			rendererOptions = append(rendererOptions, html.WithUnsafe()) // 保留原始 HTML
+		}
+		parserOptions = append(parserOptions,
+			parser.WithAutoHeadingID(), // 自动生成标题 ID
+			parser.WithAttribute(),     // 启用自定义属性，目前只有标题支持属性。
+		)
+	}
+
 	return MarkdownConfig{
-		Extensions: []goldmark.Extender{
-			extension.Linkify,        // 自动链接支持
-			extension.GFM,            // 启用 GitHub Flavored Markdown
-			extension.Table,          // 表格支持
-			extension.TaskList,       // 任务列表支持
-			extension.Strikethrough,  // 删除线支持
-			extension.Footnote,       // 脚注支持
-			extension.DefinitionList, // 定义列表支持
-			extension.Typographer,    // Typography support
-		},
-		ParserOptions: []parser.Option{
-			parser.WithAutoHeadingID(),         // 自动生成标题 ID
-			parser.WithBlockParsers(),          // 块解析器
-			parser.WithInlineParsers(),         // 内联解析器
-			parser.WithParagraphTransformers(), // 段落转换器
-			parser.WithASTTransformers(),       // AST 转换器
-			parser.WithAttribute(),             // 启用自定义属性，目前只有标题支持属性。
-		},
-		RendererOptions: []renderer.Option{
-			html.WithHardWraps(), // 硬换行
-			html.WithXHTML(),     // 生成 XHTML
-		},
+		Extensions:      extensions,
+		ParserOptions:   parserOptions,
+		RendererOptions: rendererOptions,
 	}
 }
 
-// RenderMarkdown 将 Markdown 渲染为 HTML
+// RenderMarkdown 按 markdown 配置将文章 Markdown 渲染为 HTML，并按 sanitizer 配置的白名单过滤；
+// 启用代码高亮时代码块按语言高亮，未指定或无法识别语言时原样输出
 func RenderMarkdown(content []byte) (string, error) {
-	md := NewMarkdownRenderer(defaultMarkdownConfig())
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	rendered, err := renderMarkdown(content, markdownConfig(config, false))
+	if err != nil {
+		return "", err
+	}
+	return postPolicy(config.SanitizerConfig).Sanitize(rendered), nil
+}
+
+// RenderCommentMarkdown 将评论 Markdown 渲染为 HTML，原始 HTML 始终丢弃，并按评论白名单过滤
+func RenderCommentMarkdown(content []byte) (string, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	rendered, err := renderMarkdown(content, markdownConfig(config, true))
+	if err != nil {
+		return "", err
+	}
+	return commentPolicy().Sanitize(rendered), nil
+}
+
+// renderMarkdown 使用给定配置渲染 Markdown，标题锚点 ID 由 headingIDs 生成
+func renderMarkdown(content []byte, config MarkdownConfig) (string, error) {
+	md := NewMarkdownRenderer(config)
 	buf := bufferPool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer bufferPool.Put(buf)
 
-	if err := md.Convert(content, buf); err != nil {
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	if err := md.Convert(content, buf, parser.WithContext(ctx)); err != nil {
 		return "", err
 	}
 
 	return buf.String(), nil
 }
+
+// headingIDs 生成标题锚点 ID，保留中文等 Unicode 字母与数字，同一标题文本总是生成相同的 ID；
+// goldmark 默认只保留 ASCII 字符，中文标题会按出现顺序编号为 heading-1、heading-2，插入标题后锚点会变化
+type headingIDs struct {
+	values map[string]bool
+}
+
+// newHeadingIDs 创建标题锚点 ID 生成器
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{values: make(map[string]bool)}
+}
+
+// Generate 实现 parser.IDs 接口，根据标题文本生成文档内唯一的 ID，重复时追加 -1、-2 等后缀
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	fallback := "id"
+	if kind == ast.KindHeading {
+		fallback = "heading"
+	}
+	return []byte(s.generate(string(value), fallback))
+}
+
+// Put 实现 parser.IDs 接口，记录已使用的 ID，如通过属性指定的 ID
+func (s *headingIDs) Put(value []byte) {
+	s.values[string(value)] = true
+}
+
+// generate 将文本转换为小写、以连字符分隔的 ID，文本中没有字母或数字时使用 fallback
+func (s *headingIDs) generate(text, fallback string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.TrimSpace(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			dash = true
+		}
+	}
+	id := b.String()
+	if id == "" {
+		id = fallback
+	}
+
+	if !s.values[id] {
+		s.values[id] = true
+		return id
+	}
+	for i := 1; ; i++ {
+		candidate := id + "-" + strconv.Itoa(i)
+		if !s.values[candidate] {
+			s.values[candidate] = true
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+
+	"jank.com/jank_blog/configs"
+)
+
+var (
+	// anchorIDPattern 标题锚点 ID，bluemonday 默认只允许 ASCII 字符，中文标题的锚点会被移除
+	anchorIDPattern = regexp.MustCompile(`^[\p{L}\p{N}:._-]+$`)
+	classPattern    = regexp.MustCompile(`^[\w\- ]+$`)
+	rolePattern     = regexp.MustCompile(`^[a-z\-]+$`)
+)
+
+// unsafeElements 不能通过 SANITIZER_ALLOWED_ELEMENTS 放行的标签，iframe 只能通过 SANITIZER_IFRAME_DOMAINS 放行
+var unsafeElements = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true, "object": true, "embed": true,
+	"applet": true, "base": true, "link": true, "meta": true, "form": true, "svg": true, "math": true,
+}
+
+// highlightStyles chroma 以内联 style 输出代码高亮时使用的 CSS 属性
+var highlightStyles = []string{
+	"color", "background-color", "font-weight", "font-style", "text-decoration", "display", "width",
+	"margin", "margin-right", "padding", "padding-right", "border", "user-select", "white-space",
+	"tab-size", "-moz-tab-size", "-o-tab-size",
+}
+
+// SanitizeHTML 按 sanitizer 配置的文章白名单过滤 HTML，用于不经过 Markdown 渲染的文章正文，如仅提供 HTML 的导入内容
+func SanitizeHTML(content string) (string, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return postPolicy(config.SanitizerConfig).Sanitize(content), nil
+}
+
+// postPolicy 生成文章 HTML 的过滤规则，在评论规则的基础上允许标题锚点、配置的 iframe 域名与额外的标签和属性
+func postPolicy(config configs.SanitizerConfig) *bluemonday.Policy {
+	p := commentPolicy()
+	p.AllowAttrs("id").Matching(anchorIDPattern).Globally()
+
+	for _, name := range config.SanitizerAllowedElements {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" && !unsafeElements[name] {
+			p.AllowElements(name)
+		}
+	}
+	for _, name := range config.SanitizerAllowedAttributes {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && name != "style" && !strings.HasPrefix(name, "on") {
+			p.AllowAttrs(name).Globally()
+		}
+	}
+
+	if src := iframeSrcPattern(config.SanitizerIframeDomains); src != nil {
+		p.AllowAttrs("src").Matching(src).OnElements("iframe")
+		p.AllowAttrs("width", "height").Matching(bluemonday.NumberOrPercent).OnElements("iframe")
+		p.AllowAttrs("title", "allow", "loading", "referrerpolicy").OnElements("iframe")
+		p.AllowAttrs("allowfullscreen", "frameborder", "scrolling").OnElements("iframe")
+	}
+	return p
+}
+
+// commentPolicy 生成评论 HTML 的过滤规则，基于 bluemonday 的 UGC 规则，额外允许代码高亮、任务列表与脚注的标记，
+// 链接添加 nofollow，站外链接在新窗口打开
+func commentPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(classPattern).Globally()
+	p.AllowAttrs("role").Matching(rolePattern).Globally()
+	p.AllowStyles(highlightStyles...).OnElements("pre", "span", "code")
+	p.AllowStyles("text-align").OnElements("th", "td")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// iframeSrcPattern 生成 iframe src 的匹配规则，仅允许 https 或协议相对地址，且域名在白名单中；白名单为空时返回 nil
+func iframeSrcPattern(domains []string) *regexp.Regexp {
+	var hosts []string
+	for _, domain := range domains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			hosts = append(hosts, regexp.QuoteMeta(domain))
+		}
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)^(https:)?//(` + strings.Join(hosts, "|") + `)(/|\?|$)`)
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+)
+
+const (
+	SliderWidth     = 300 // 滑动拼图背景图默认宽度
+	SliderHeight    = 150 // 滑动拼图背景图默认高度
+	SliderPieceSize = 44  // 拼图块主体边长
+	sliderKnob      = 8   // 拼图块右侧凸起的半径
+)
+
+// SliderCaptchaOptions 滑动拼图生成配置，零值字段使用默认值
+type SliderCaptchaOptions struct {
+	Width  int // 背景图宽度
+	Height int // 背景图高度
+}
+
+// SliderCaptcha 滑动拼图，图片为 Base64 编码的 PNG
+type SliderCaptcha struct {
+	Background string // 挖去拼图块的背景图
+	Piece      string // 拼图块，宽度为 SliderPieceSize 加凸起直径，高度为 SliderPieceSize
+	X          int    // 拼图块在背景图中的横坐标，即答案
+	Y          int    // 拼图块在背景图中的纵坐标，前端据此放置拼图块
+}
+
+// GenSliderCaptcha 生成滑动拼图，背景为随机渐变与色块，拼图块从背景图中右侧区域随机位置挖出
+func GenSliderCaptcha(opts SliderCaptchaOptions) (*SliderCaptcha, error) {
+	if opts.Width <= 0 {
+		opts.Width = SliderWidth
+	}
+	if opts.Height <= 0 {
+		opts.Height = SliderHeight
+	}
+	pieceWidth, pieceHeight := SliderPieceSize+2*sliderKnob, SliderPieceSize
+	// 拼图块初始位于最左侧，答案需与初始位置拉开距离
+	minX := pieceWidth + sliderKnob
+	if opts.Width-pieceWidth-sliderKnob <= minX || opts.Height-pieceHeight-sliderKnob <= sliderKnob {
+		return nil, fmt.Errorf("滑动拼图尺寸过小: %dx%d", opts.Width, opts.Height)
+	}
+
+	x := minX + rand.Intn(opts.Width-pieceWidth-sliderKnob-minX)
+	y := sliderKnob + rand.Intn(opts.Height-pieceHeight-2*sliderKnob)
+
+	bg := sliderBackground(opts.Width, opts.Height)
+	piece := image.NewNRGBA(image.Rect(0, 0, pieceWidth, pieceHeight))
+	for py := 0; py < pieceHeight; py++ {
+		for px := 0; px < pieceWidth; px++ {
+			if !sliderMask(px, py) {
+				continue
+			}
+			src := bg.NRGBAAt(x+px, y+py)
+			if sliderEdge(px, py) {
+				piece.SetNRGBA(px, py, color.NRGBA{R: 255, G: 255, B: 255, A: 230})
+			} else {
+				piece.SetNRGBA(px, py, src)
+			}
+			// 背景中挖出的区域压暗，作为拼图块的目标位置
+			bg.SetNRGBA(x+px, y+py, color.NRGBA{R: src.R / 3, G: src.G / 3, B: src.B / 3, A: 255})
+		}
+	}
+
+	background, err := encodePNG(bg)
+	if err != nil {
+		return nil, err
+	}
+	pieceImg, err := encodePNG(piece)
+	if err != nil {
+		return nil, err
+	}
+	return &SliderCaptcha{Background: background, Piece: pieceImg, X: x, Y: y}, nil
+}
+
+// sliderBackground 生成随机渐变背景并叠加半透明色块，增加识别拼图位置的难度
+func sliderBackground(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	from := color.NRGBA{R: uint8(rand.Intn(256)), G: uint8(rand.Intn(256)), B: uint8(rand.Intn(256)), A: 255}
+	to := color.NRGBA{R: uint8(rand.Intn(256)), G: uint8(rand.Intn(256)), B: uint8(rand.Intn(256)), A: 255}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			t := float64(x+y) / float64(width+height)
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(float64(from.R)*(1-t) + float64(to.R)*t),
+				G: uint8(float64(from.G)*(1-t) + float64(to.G)*t),
+				B: uint8(float64(from.B)*(1-t) + float64(to.B)*t),
+				A: 255,
+			})
+		}
+	}
+
+	for i := 0; i < 12; i++ {
+		cx, cy, r := rand.Intn(width), rand.Intn(height), 10+rand.Intn(height/3)
+		shade := color.NRGBA{R: uint8(rand.Intn(256)), G: uint8(rand.Intn(256)), B: uint8(rand.Intn(256)), A: 255}
+		for y := max(cy-r, 0); y < min(cy+r, height); y++ {
+			for x := max(cx-r, 0); x < min(cx+r, width); x++ {
+				if (x-cx)*(x-cx)+(y-cy)*(y-cy) > r*r {
+					continue
+				}
+				c := img.NRGBAAt(x, y)
+				img.SetNRGBA(x, y, color.NRGBA{
+					R: uint8((int(c.R) + int(shade.R)) / 2),
+					G: uint8((int(c.G) + int(shade.G)) / 2),
+					B: uint8((int(c.B) + int(shade.B)) / 2),
+					A: 255,
+				})
+			}
+		}
+	}
+	return img
+}
+
+// sliderMask 判断拼图块坐标系中的点是否属于拼图块，拼图块为正方形主体加右侧半圆凸起
+func sliderMask(px, py int) bool {
+	if px >= 0 && px < SliderPieceSize && py >= 0 && py < SliderPieceSize {
+		return true
+	}
+	cx, cy := SliderPieceSize+sliderKnob/2, SliderPieceSize/2
+	return (px-cx)*(px-cx)+(py-cy)*(py-cy) <= sliderKnob*sliderKnob
+}
+
+// sliderEdge 判断拼图块中的点是否位于轮廓上
+func sliderEdge(px, py int) bool {
+	return !sliderMask(px-1, py) || !sliderMask(px+1, py) || !sliderMask(px, py-1) || !sliderMask(px, py+1)
+}
+
+// encodePNG 将图片编码为 Base64 的 data URI
+func encodePNG(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", fmt.Errorf("编码滑动拼图图片失败: %v", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern   = regexp.MustCompile(`(?is)<h([1-6])(\s[^>]*)?>(.*?)</h[1-6]\s*>`)
+	headingIDPattern = regexp.MustCompile(`(?i)\sid\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	htmlTagPattern   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Heading HTML 中的标题
+type Heading struct {
+	Level int    // 标题级别，1 至 6
+	Text  string // 去除标签后的标题文本
+	ID    string // 锚点 ID
+}
+
+// ExtractHeadings 按出现顺序提取 HTML 中的标题，并为缺少 ID 的标题注入锚点 ID，返回注入后的 HTML；
+// 已有的 ID 保持不变，新 ID 与 RenderMarkdown 的生成规则一致，且不会与已有的 ID 重复；文本为空的标题不返回
+func ExtractHeadings(content string) (string, []Heading) {
+	matches := headingPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content, nil
+	}
+
+	ids := newHeadingIDs()
+	existing := make([]string, len(matches))
+	found := make([]bool, len(matches))
+	for i, m := range matches {
+		if m[4] < 0 {
+			continue
+		}
+		if id := headingIDPattern.FindStringSubmatch(content[m[4]:m[5]]); id != nil {
+			existing[i], found[i] = html.UnescapeString(id[1]+id[2]), true
+			ids.Put([]byte(existing[i]))
+		}
+	}
+
+	var b strings.Builder
+	headings := make([]Heading, 0, len(matches))
+	last := 0
+	for i, m := range matches {
+		level, _ := strconv.Atoi(content[m[2]:m[3]])
+		text := strings.Join(strings.Fields(html.UnescapeString(htmlTagPattern.ReplaceAllString(content[m[6]:m[7]], ""))), " ")
+
+		id := existing[i]
+		if !found[i] {
+			id = ids.generate(text, "heading")
+			// 在标签名之后插入 id 属性
+			b.WriteString(content[last:m[3]])
+			b.WriteString(` id="` + html.EscapeString(id) + `"`)
+			last = m[3]
+		}
+		if text != "" {
+			headings = append(headings, Heading{Level: level, Text: text, ID: id})
+		}
+	}
+	if last == 0 {
+		return content, headings
+	}
+	b.WriteString(content[last:])
+	return b.String(), headings
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/configs"
+)
+
+// TOTP 参数，与 Google Authenticator 等常见验证器的默认值一致（RFC 6238）
+const (
+	totpPeriod     = 30 * time.Second // 动态码的时间步长
+	totpDigits     = 6                // 动态码位数
+	totpSkew       = 1                // 允许前后偏差的时间步数
+	totpSecretSize = 20               // 密钥字节数
+)
+
+// recoveryCodeSize 恢复码字节数，编码后为 10 位十六进制字符，展示时以短横线分为两段
+const recoveryCodeSize = 5
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret 生成 Base32 编码的 TOTP 密钥
+func GenerateTotpSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("生成 TOTP 密钥失败: %v", err)
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TotpURI 生成验证器扫码绑定所需的 otpauth 地址
+func TotpURI(issuer, accountName, secret string) string {
+	query := url.Values{
+		"secret": {secret},
+		"issuer": {issuer},
+		"digits": {fmt.Sprint(totpDigits)},
+		"period": {fmt.Sprint(int(totpPeriod.Seconds()))},
+	}
+	label := url.PathEscape(issuer + ":" + accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// ValidateTotp 校验动态码，允许前后各一个时间步的偏差，通过时返回匹配的时间步序号，供调用方防止重放
+func ValidateTotp(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return 0, false
+	}
+
+	counter := now.Unix() / int64(totpPeriod.Seconds())
+	for offset := int64(-totpSkew); offset <= totpSkew; offset++ {
+		if hmac.Equal([]byte(hotp(key, counter+offset)), []byte(code)) {
+			return counter + offset, true
+		}
+	}
+	return 0, false
+}
+
+// hotp 按 RFC 4226 计算指定计数器的动态码
+func hotp(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// GenerateRecoveryCodes 生成 n 个恢复码，返回展示给用户的恢复码与入库保存的摘要
+func GenerateRecoveryCodes(n int) ([]string, []string, error) {
+	codes, hashes := make([]string, 0, n), make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("生成恢复码失败: %v", err)
+		}
+		code := hex.EncodeToString(b)
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode 计算恢复码的摘要，忽略大小写、空白与短横线
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// TotpRequiredForRole 判断角色是否被配置为必须开启两步验证
+func TotpRequiredForRole(roleCode string) bool {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return false
+	}
+	for _, code := range config.TotpConfig.TotpRequiredRoles {
+		if code == roleCode {
+			return true
+		}
+	}
+	return false
+}
@@ -1,32 +1,143 @@
 package utils
 
-import "github.com/go-playground/validator/v10"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+	"github.com/labstack/echo/v4"
+)
 
 type (
 	ValidErrRes struct {
-		Error bool
-		Field string
-		Tag   string
-		Value interface{}
+		Error   bool
+		Field   string
+		Tag     string
+		Value   interface{}
+		Message string // 本地化的错误信息
 	}
 )
 
+const (
+	LocaleZh = "zh" // 中文，默认语言
+	LocaleEn = "en" // 英文
+)
+
 var NewValidator = validator.New()
 
-// Validator 参数验证器
+var translator *ut.UniversalTranslator
+
+// customRules 自定义校验规则及其本地化错误信息
+var customRules = []struct {
+	tag      string
+	fn       validator.Func
+	messages map[string]string
+}{
+	{
+		tag: "valid_email",
+		fn:  func(fl validator.FieldLevel) bool { return ValidEmail(fl.Field().String()) },
+		messages: map[string]string{
+			LocaleZh: "{0}格式无效",
+			LocaleEn: "{0} must be a valid email address",
+		},
+	},
+}
+
+func init() {
+	// 错误信息中的字段名使用 json、query、form 标签中的名称
+	NewValidator.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, key := range []string{"json", "query", "form"} {
+			name := strings.SplitN(field.Tag.Get(key), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	zhLocale := zh.New()
+	translator = ut.New(zhLocale, zhLocale, en.New())
+	zhTrans, _ := translator.GetTranslator(LocaleZh)
+	enTrans, _ := translator.GetTranslator(LocaleEn)
+	_ = zhTranslations.RegisterDefaultTranslations(NewValidator, zhTrans)
+	_ = enTranslations.RegisterDefaultTranslations(NewValidator, enTrans)
+
+	for _, rule := range customRules {
+		_ = NewValidator.RegisterValidation(rule.tag, rule.fn)
+		for locale, message := range rule.messages {
+			trans, _ := translator.GetTranslator(locale)
+			registerTranslation(trans, rule.tag, message)
+		}
+	}
+}
+
+// Validator 参数验证器，错误信息使用默认语言
 func Validator(data interface{}) []ValidErrRes {
+	return ValidatorWithLocale(data, LocaleZh)
+}
+
+// ValidatorWithLocale 参数验证器，错误信息使用指定语言
+func ValidatorWithLocale(data interface{}, locale string) []ValidErrRes {
 	var Errors []ValidErrRes
 	errs := NewValidator.Struct(data)
 	if errs != nil {
+		trans, _ := translator.GetTranslator(locale)
 		for _, err := range errs.(validator.ValidationErrors) {
 			var el ValidErrRes
 			el.Error = true
 			el.Field = err.Field()
 			el.Tag = err.Tag()
 			el.Value = err.Value()
+			el.Message = err.Translate(trans)
 
 			Errors = append(Errors, el)
 		}
 	}
 	return Errors
 }
+
+// BindAndValidate 绑定请求参数并按结构体标签校验
+// 绑定失败时仅返回 error；校验失败时同时返回字段级错误详情与汇总后的本地化错误信息
+func BindAndValidate(c echo.Context, req interface{}) ([]ValidErrRes, error) {
+	if err := c.Bind(req); err != nil {
+		return nil, fmt.Errorf("请求参数绑定失败: %v", err)
+	}
+
+	errors := ValidatorWithLocale(req, RequestLocale(c))
+	if errors != nil {
+		messages := make([]string, len(errors))
+		for i, e := range errors {
+			messages[i] = e.Message
+		}
+		return errors, fmt.Errorf("请求参数校验失败: %s", strings.Join(messages, "; "))
+	}
+	return nil, nil
+}
+
+// RequestLocale 根据 Accept-Language 请求头选择错误信息语言
+func RequestLocale(c echo.Context) string {
+	lang := strings.ToLower(c.Request().Header.Get("Accept-Language"))
+	if strings.HasPrefix(lang, LocaleEn) {
+		return LocaleEn
+	}
+	return LocaleZh
+}
+
+// registerTranslation 注册自定义校验规则的错误信息
+func registerTranslation(trans ut.Translator, tag, message string) {
+	_ = NewValidator.RegisterTranslation(tag, trans,
+		func(t ut.Translator) error { return t.Add(tag, message, true) },
+		func(t ut.Translator, fe validator.FieldError) string {
+			msg, _ := t.T(tag, fe.Field())
+			return msg
+		})
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"html"
+	"unicode"
+)
+
+const (
+	wordsPerMinute    = 200 // 英文等以空格分词的文字每分钟阅读的词数
+	cjkCharsPerMinute = 400 // 中日韩文字每分钟阅读的字数
+)
+
+// CountWords 统计文本字数，中日韩文字每个字计为一个词，其他字母与数字按连续的片段计为一个词；
+// 返回总字数与其中的中日韩字数
+func CountWords(text string) (int, int) {
+	words, cjk := 0, 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			cjk++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		case r == '\'' || r == '’':
+			// 撇号不拆分单词，如 don't
+		default:
+			inWord = false
+		}
+	}
+	return words + cjk, cjk
+}
+
+// ReadingStats 根据 HTML 正文计算字数与预计阅读分钟数，标签不计入字数，有内容时阅读时间至少 1 分钟
+func ReadingStats(content string) (int, int) {
+	total, cjk := CountWords(html.UnescapeString(htmlTagPattern.ReplaceAllString(content, " ")))
+	if total == 0 {
+		return 0, 0
+	}
+	// 按秒累加后向上取整，避免中英混排时分别取整带来的误差
+	seconds := (total-cjk)*60/wordsPerMinute + cjk*60/cjkCharsPerMinute
+	minutes := (seconds + 59) / 60
+	return total, max(minutes, 1)
+}
+
+// isCJK 判断字符是否为中日韩文字，包括汉字、日文假名与韩文
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
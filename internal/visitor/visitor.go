@@ -0,0 +1,179 @@
+package visitor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/global"
+)
+
+const (
+	CookieName     = "jank_visitor"   // 保存访客 ID 的 Cookie 名称
+	SecretCache    = "VISITOR:SECRET" // 未配置签名密钥时自动生成的密钥
+	defaultTTLDays = 365              // 未配置时访客 ID 的有效天数
+	idSize         = 16
+	secretSize     = 32
+)
+
+// ErrDisabled 站点未开启匿名访客身份
+var ErrDisabled = errors.New("站点未开启匿名访客身份")
+
+// Identity 访客身份
+type Identity struct {
+	ID        string // 访客 ID
+	ExpiresAt int64  // Cookie 过期时间
+	Created   bool   // 是否为本次新签发
+}
+
+var (
+	mu            sync.Mutex
+	generated     []byte // 从缓存读取或本地生成的签名密钥
+	generatedFrom string // generated 读取自的缓存驱动，缓存驱动切换或读取失败时重新读取
+)
+
+// Enabled 站点是否开启匿名访客身份
+func Enabled() bool {
+	config, err := configs.LoadConfig()
+	return err == nil && config.VisitorConfig.VisitorEnabled
+}
+
+// FromRequest 读取并校验请求 Cookie 中的访客 ID，未开启、Cookie 不存在或签名不一致时返回 false，不会签发新的访客 ID
+func FromRequest(c echo.Context) (string, bool) {
+	config, err := configs.LoadConfig()
+	if err != nil || !config.VisitorConfig.VisitorEnabled {
+		return "", false
+	}
+	cookie, err := c.Cookie(CookieName)
+	if err != nil {
+		return "", false
+	}
+	return verify(cookie.Value, config)
+}
+
+// Ensure 获取请求中的访客 ID，不存在或无效时签发新的访客 ID，并重新写入 Cookie 以顺延有效期
+func Ensure(c echo.Context) (*Identity, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if !config.VisitorConfig.VisitorEnabled {
+		return nil, ErrDisabled
+	}
+
+	identity := &Identity{}
+	if cookie, err := c.Cookie(CookieName); err == nil {
+		identity.ID, _ = verify(cookie.Value, config)
+	}
+	if identity.ID == "" {
+		raw := make([]byte, idSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("生成访客 ID 失败: %v", err)
+		}
+		identity.ID, identity.Created = hex.EncodeToString(raw), true
+	}
+
+	secret, err := signingSecret(config)
+	if err != nil {
+		return nil, err
+	}
+	ttl := lifetime(config)
+	expires := time.Now().Add(ttl)
+	identity.ExpiresAt = expires.Unix()
+	c.SetCookie(&http.Cookie{
+		Name:     CookieName,
+		Value:    identity.ID + "." + sign(identity.ID, secret),
+		Path:     "/",
+		Secure:   c.Scheme() == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(ttl / time.Second),
+		Expires:  expires,
+	})
+	return identity, nil
+}
+
+// verify 校验 Cookie 值的签名，返回其中的访客 ID
+func verify(value string, config *configs.Config) (string, bool) {
+	id, signature, ok := strings.Cut(value, ".")
+	if !ok || len(id) != idSize*2 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", false
+	}
+	secret, err := signingSecret(config)
+	if err != nil {
+		return "", false
+	}
+	if !hmac.Equal([]byte(signature), []byte(sign(id, secret))) {
+		return "", false
+	}
+	return id, true
+}
+
+// sign 计算访客 ID 的签名
+func sign(id string, secret []byte) string {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(id))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// signingSecret 获取签名密钥，优先使用配置中的 VISITOR_SECRET；
+// 未配置时使用缓存中自动生成的密钥，多实例共享，缓存不可用时临时使用本地生成的密钥
+func signingSecret(config *configs.Config) ([]byte, error) {
+	if secret := config.VisitorConfig.VisitorSecret; secret != "" {
+		return []byte(secret), nil
+	}
+
+	store := cache.Current()
+	mu.Lock()
+	defer mu.Unlock()
+	if generated != nil && generatedFrom == store.Name() {
+		return generated, nil
+	}
+
+	raw := make([]byte, secretSize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("生成访客签名密钥失败: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := store.SetNX(ctx, SecretCache, hex.EncodeToString(raw), 0); err == nil {
+		if value, err := store.Get(ctx, SecretCache); err == nil {
+			if secret, err := hex.DecodeString(value); err == nil && len(secret) > 0 {
+				generated, generatedFrom = secret, store.Name()
+				return generated, nil
+			}
+		}
+	}
+
+	// 缓存不可用时沿用本地已生成的密钥，避免每次请求都更换密钥
+	global.SysLog.Warnf("读取访客签名密钥失败，临时使用本地生成的密钥")
+	if generated == nil {
+		generated = raw
+	}
+	generatedFrom = ""
+	return generated, nil
+}
+
+// lifetime 读取配置中的访客 ID 有效期
+func lifetime(config *configs.Config) time.Duration {
+	days := config.VisitorConfig.VisitorTTLDays
+	if days < 1 {
+		days = defaultTTLDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
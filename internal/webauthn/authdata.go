@@ -0,0 +1,68 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// 认证器数据标志位
+const (
+	flagUserPresent  = 0x01 // UP：用户在场
+	flagUserVerified = 0x04 // UV：已通过生物识别或 PIN 验证用户身份
+	flagAttested     = 0x40 // AT：包含凭证数据
+	flagExtensions   = 0x80 // ED：包含扩展数据
+)
+
+// authenticatorData 认证器数据
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	credentialID []byte // 仅注册时存在
+	publicKey    []byte // COSE_Key 编码的公钥，仅注册时存在
+}
+
+// parseAuthenticatorData 解析认证器数据，结构为 rpIdHash(32) | flags(1) | signCount(4) | 凭证数据 | 扩展数据
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < 37 {
+		return nil, errors.New("认证器数据长度不足")
+	}
+	ad := &authenticatorData{
+		rpIDHash:  data[:32],
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if ad.flags&flagAttested != 0 {
+		// aaguid(16) | credentialIdLength(2) | credentialId | credentialPublicKey
+		if len(rest) < 18 {
+			return nil, errors.New("凭证数据长度不足")
+		}
+		idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLen == 0 || idLen > 1023 || len(rest) < idLen {
+			return nil, errors.New("凭证 ID 长度无效")
+		}
+		ad.credentialID = rest[:idLen]
+		rest = rest[idLen:]
+
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, err
+		}
+		ad.publicKey = rest[:len(rest)-len(after)]
+		rest = after
+	}
+	if ad.flags&flagExtensions != 0 {
+		_, after, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, err
+		}
+		rest = after
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("认证器数据后存在多余数据")
+	}
+	return ad, nil
+}
@@ -0,0 +1,169 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ChallengeSize 挑战值字节数
+const ChallengeSize = 32
+
+// 客户端数据类型
+const (
+	typeCreate = "webauthn.create"
+	typeGet    = "webauthn.get"
+)
+
+// ErrVerify 注册或登录数据验证失败，具体原因包装在错误信息中
+var ErrVerify = errors.New("通行密钥验证失败")
+
+// RelyingParty 依赖方配置
+type RelyingParty struct {
+	ID      string   // 依赖方 ID，即站点域名
+	Name    string   // 依赖方名称，展示给用户
+	Origins []string // 允许的来源，如 https://example.com
+}
+
+// Credential 注册成功的凭证
+type Credential struct {
+	ID        []byte // 凭证 ID
+	PublicKey []byte // COSE_Key 编码的公钥
+	SignCount uint32 // 签名计数器
+}
+
+// clientData 客户端数据
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// NewChallenge 生成随机挑战值
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, ChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("生成挑战值失败: %v", err)
+	}
+	return challenge, nil
+}
+
+// ClientChallenge 读取客户端数据中的挑战值，供依赖方查找发起登录时保存的挑战值，读取后仍需调用 VerifyAssertion 完整校验
+func ClientChallenge(clientDataJSON []byte) ([]byte, error) {
+	var cd clientData
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return nil, fmt.Errorf("%w: 客户端数据格式错误", ErrVerify)
+	}
+	challenge, err := base64.RawURLEncoding.DecodeString(cd.Challenge)
+	if err != nil || len(challenge) == 0 {
+		return nil, fmt.Errorf("%w: 挑战值格式错误", ErrVerify)
+	}
+	return challenge, nil
+}
+
+// VerifyRegistration 验证注册时认证器返回的证明数据，返回新凭证
+// 不校验证明声明，即不限制认证器的型号与来源，直接信任认证器数据中的公钥
+func (rp *RelyingParty) VerifyRegistration(challenge, clientDataJSON, attestationObject []byte) (*Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, typeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	value, rest, err := decodeCBOR(attestationObject)
+	if err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("%w: 证明对象格式错误", ErrVerify)
+	}
+	attestation, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: 证明对象格式错误", ErrVerify)
+	}
+	authData, _ := attestation["authData"].([]byte)
+
+	ad, err := rp.verifyAuthenticatorData(authData)
+	if err != nil {
+		return nil, err
+	}
+	if ad.credentialID == nil {
+		return nil, fmt.Errorf("%w: 缺少凭证数据", ErrVerify)
+	}
+	if _, err := parsePublicKey(ad.publicKey); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerify, err)
+	}
+	return &Credential{
+		ID:        append([]byte(nil), ad.credentialID...),
+		PublicKey: append([]byte(nil), ad.publicKey...),
+		SignCount: ad.signCount,
+	}, nil
+}
+
+// VerifyAssertion 验证登录时认证器返回的断言，返回新的签名计数器
+// 认证器支持计数器时，新计数器必须大于已保存的计数器，否则视为凭证被克隆
+func (rp *RelyingParty) VerifyAssertion(challenge, clientDataJSON, authData, signature, publicKey []byte, signCount uint32) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, typeGet, challenge); err != nil {
+		return 0, err
+	}
+	ad, err := rp.verifyAuthenticatorData(authData)
+	if err != nil {
+		return 0, err
+	}
+
+	key, err := parsePublicKey(publicKey)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrVerify, err)
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	message := append(append([]byte(nil), authData...), clientDataHash[:]...)
+	if err := key.verify(message, signature); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrVerify, err)
+	}
+
+	if (ad.signCount != 0 || signCount != 0) && ad.signCount <= signCount {
+		return 0, fmt.Errorf("%w: 签名计数器未递增，凭证可能已被克隆", ErrVerify)
+	}
+	return ad.signCount, nil
+}
+
+// verifyClientData 校验客户端数据的类型、挑战值与来源
+func (rp *RelyingParty) verifyClientData(data []byte, typ string, challenge []byte) error {
+	var cd clientData
+	if err := json.Unmarshal(data, &cd); err != nil {
+		return fmt.Errorf("%w: 客户端数据格式错误", ErrVerify)
+	}
+	if cd.Type != typ {
+		return fmt.Errorf("%w: 客户端数据类型不匹配", ErrVerify)
+	}
+	got, err := base64.RawURLEncoding.DecodeString(cd.Challenge)
+	if err != nil || subtle.ConstantTimeCompare(got, challenge) != 1 {
+		return fmt.Errorf("%w: 挑战值不匹配", ErrVerify)
+	}
+	for _, origin := range rp.Origins {
+		if cd.Origin == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: 来源 %s 不在允许列表中", ErrVerify, cd.Origin)
+}
+
+// verifyAuthenticatorData 解析认证器数据并校验依赖方 ID、用户在场与用户验证标志，
+// 要求用户验证使通行密钥同时证明持有设备与用户身份，可代替密码与两步验证
+func (rp *RelyingParty) verifyAuthenticatorData(data []byte) (*authenticatorData, error) {
+	ad, err := parseAuthenticatorData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrVerify, err)
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(ad.rpIDHash, rpIDHash[:]) {
+		return nil, fmt.Errorf("%w: 依赖方 ID 不匹配", ErrVerify)
+	}
+	if ad.flags&flagUserPresent == 0 {
+		return nil, fmt.Errorf("%w: 用户未在场", ErrVerify)
+	}
+	if ad.flags&flagUserVerified == 0 {
+		return nil, fmt.Errorf("%w: 认证器未验证用户身份", ErrVerify)
+	}
+	return ad, nil
+}
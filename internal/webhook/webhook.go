@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+)
+
+// 事件类型
+const (
+	EventPostPublished = "post.published" // 文章发布
+)
+
+const (
+	defaultTimeout     = 5 * time.Second // 未配置时单次请求的超时时间
+	defaultMaxAttempts = 3               // 未配置时每个地址最多尝试的次数
+	retryBaseDelay     = time.Second     // 首次重试前的等待时间，之后翻倍
+	userAgent          = "Jank-Webhook/1.0"
+)
+
+// Event 发送给接收方的事件
+type Event struct {
+	ID        string      `json:"id"`         // 投递 ID，重试时不变
+	Type      string      `json:"type"`       // 事件类型
+	CreatedAt int64       `json:"created_at"` // 事件发生时间
+	Data      interface{} `json:"data"`       // 事件数据
+}
+
+// PostPublishedData post.published 事件的数据
+type PostPublishedData struct {
+	ID          int64  `json:"id"`           // 文章 ID
+	Title       string `json:"title"`        // 文章标题
+	URL         string `json:"url"`          // 文章地址，未配置站点地址时为空
+	PublishedAt int64  `json:"published_at"` // 发布时间
+}
+
+// Fire 异步向全部配置的地址发送事件，未配置地址时不发送，发送失败时仅记录日志
+func Fire(eventType string, data interface{}) {
+	config, err := configs.LoadConfig()
+	if err != nil || len(config.WebhookConfig.WebhookURLs) == 0 {
+		return
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		global.SysLog.Errorf("生成 Webhook 投递 ID 失败: %v", err)
+		return
+	}
+	event := &Event{ID: hex.EncodeToString(id), Type: eventType, CreatedAt: time.Now().Unix(), Data: data}
+	body, err := json.Marshal(event)
+	if err != nil {
+		global.SysLog.Errorf("序列化 Webhook 事件 %s 失败: %v", eventType, err)
+		return
+	}
+
+	cfg := config.WebhookConfig
+	timeout := time.Duration(cfg.WebhookTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	attempts := cfg.WebhookMaxAttempts
+	if attempts < 1 {
+		attempts = defaultMaxAttempts
+	}
+	signature := ""
+	if cfg.WebhookSecret != "" {
+		h := hmac.New(sha256.New, []byte(cfg.WebhookSecret))
+		h.Write(body)
+		signature = "sha256=" + hex.EncodeToString(h.Sum(nil))
+	}
+
+	for _, url := range cfg.WebhookURLs {
+		go func(url string) {
+			delay := retryBaseDelay
+			for attempt := 1; ; attempt++ {
+				err := deliver(url, event, body, signature, timeout)
+				if err == nil {
+					return
+				}
+				if attempt >= attempts {
+					global.SysLog.Errorf("Webhook 事件 %s（%s）发送到 %s 失败，已尝试 %d 次: %v", event.Type, event.ID, url, attempt, err)
+					return
+				}
+				time.Sleep(delay)
+				delay *= 2
+			}
+		}(url)
+	}
+}
+
+// deliver 发送一次请求，响应状态码不是 2xx 时返回错误
+func deliver(url string, event *Event, body []byte, signature string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Jank-Event", event.Type)
+	req.Header.Set("X-Jank-Delivery", event.ID)
+	if signature != "" {
+		req.Header.Set("X-Jank-Signature", signature)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("响应状态码 %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -1,9 +1,16 @@
 package main
 
 import (
+	"os"
+
 	"jank.com/jank_blog/cmd"
 )
 
 func main() {
+	// 携带子命令时执行对应的命令行工具，否则启动服务
+	if len(os.Args) > 1 && cmd.Dispatch(os.Args[1], os.Args[2:]) {
+		return
+	}
+
 	cmd.Start()
 }
@@ -13,22 +13,60 @@ import (
 // @BasePath		/
 // RegisterRoutes  函数用于注册应用程序的路由
 func RegisterRoutes(app *echo.Echo) {
+	// 创建不区分版本的 API 路由组
+	api := app.Group("/api")
 	// 创建多版本 API 路由组
 	api1 := app.Group("/api/v1")
 	//api2 := app.Group("/api/v2")
 
 	// 注册测试相关的路由
 	//routes.RegisterTestRoutes(api1, api2)
+	// 注册安装向导相关的路由
+	routes.RegisterSetupRoutes(api1)
 	// 注册账户相关的路由
-	routes.RegisterAccountRoutes(api1)
+	routes.RegisterAccountRoutes(api1, app.Group(""))
 	// 注册角色权限相关的路由
 	routes.RegisterRolePermissionRoutes(api1)
+	// 注册用户管理相关的路由
+	routes.RegisterUserAdminRoutes(api1)
+	// 注册安全设置相关的路由
+	routes.RegisterSecurityRoutes(api1)
 	// 注册验证相关的路由
 	routes.RegisterVerificationRoutes(api1)
 	// 注册文章相关的路由
 	routes.RegisterPostRoutes(api1)
+	// 注册全文检索相关的路由
+	routes.RegisterSearchRoutes(api1)
 	// 注册类目相关的路由
 	routes.RegisterCategoryRoutes(api1)
+	// 注册文章系列相关的路由
+	routes.RegisterSeriesRoutes(api1)
 	// 注册评论相关的路由
 	routes.RegisterCommentRoutes(api1)
+	// 注册匿名访客相关的路由
+	routes.RegisterVisitorRoutes(api1)
+	// 注册收藏相关的路由
+	routes.RegisterBookmarkRoutes(api1)
+	// 注册文章表态相关的路由
+	routes.RegisterReactionRoutes(api1)
+	// 注册阅读历史相关的路由
+	routes.RegisterHistoryRoutes(api1)
+	// 注册内容导入相关的路由
+	routes.RegisterImportRoutes(api1)
+	// 注册定时任务相关的路由
+	routes.RegisterTaskRoutes(api1)
+	// 注册一次性邮箱域名黑名单相关的路由
+	routes.RegisterDisposableEmailRoutes(api1)
+	// 注册短链接相关的路由
+	routes.RegisterShortLinkRoutes(api1, app.Group(""))
+	// 注册订阅源相关的路由
+	routes.RegisterFeedRoutes(app.Group(""))
+	// 注册站点地图相关的路由
+	routes.RegisterSitemapRoutes(app.Group(""))
+	// 注册运行指标相关的路由
+	routes.RegisterMetricsRoutes(app.Group(""))
+	// 注册健康检查相关的路由
+	routes.RegisterHealthRoutes(app.Group(""))
+	// 注册错误码目录相关的路由
+	routes.RegisterErrorCodeRoutes(api)
 }
@@ -14,39 +14,96 @@ func RegisterAccountRoutes(r ...*echo.Group) {
 	accountGroupV1.POST("/getAccount", account.GetAccount, authMiddleware.AuthMiddleware())
 	accountGroupV1.POST("/registerAccount", account.RegisterAcc)
 	accountGroupV1.POST("/loginAccount", account.LoginAccount)
+	accountGroupV1.POST("/ldapLogin", account.LdapLogin)
 	accountGroupV1.POST("/logoutAccount", account.LogoutAccount, authMiddleware.AuthMiddleware())
-	accountGroupV1.POST("/resetPassword", account.ResetPassword, authMiddleware.AuthMiddleware())
+	accountGroupV1.POST("/logoutAllDevices", account.LogoutAllDevices, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/refreshToken", account.RefreshToken)
+	accountGroupV1.POST("/resetPassword", account.ResetPassword, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/forgotPassword", account.ForgotPassword)
+	accountGroupV1.POST("/checkPasswordResetLink", account.CheckPasswordResetLink)
+	accountGroupV1.POST("/resetPasswordByLink", account.ResetPasswordByLink)
+	accountGroupV1.POST("/magicLink/sendMagicLink", account.SendMagicLink)
+	accountGroupV1.POST("/magicLink/loginByMagicLink", account.LoginByMagicLink)
+	accountGroupV1.POST("/magicLink/confirmMagicLink", account.ConfirmMagicLink)
+	accountGroupV1.POST("/magicLink/pollMagicLink", account.PollMagicLink)
+	accountGroupV1.POST("/unlockAccount", account.UnlockAccount)
+	accountGroupV1.GET("/passwordPolicy", account.GetPasswordPolicy)
+	accountGroupV1.POST("/unlockLogin", account.UnlockLogin, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	accountGroupV1.GET("/getPreferences", account.GetPreferences, authMiddleware.AuthMiddleware())
+	accountGroupV1.POST("/updatePreferences", account.UpdatePreferences, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/setupTotp", account.SetupTotp, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/enableTotp", account.EnableTotp, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/disableTotp", account.DisableTotp, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/regenerateRecoveryCodes", account.RegenerateRecoveryCodes, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/verifyTotpLogin", account.VerifyTotpLogin)
+	accountGroupV1.POST("/linkMessenger", account.LinkMessenger, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/confirmMessenger", account.ConfirmMessenger, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/unlinkMessenger", account.UnlinkMessenger, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/oauth/:provider/login", account.OAuthLogin)
+	accountGroupV1.GET("/oauth/:provider/callback", account.OAuthCallback)
+	accountGroupV1.POST("/passkey/beginRegistration", account.BeginPasskeyRegistration, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/passkey/finishRegistration", account.FinishPasskeyRegistration, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/passkey/listPasskeys", account.ListPasskeys, authMiddleware.AuthMiddleware())
+	accountGroupV1.POST("/passkey/deletePasskey", account.DeletePasskey, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/passkey/beginLogin", account.BeginPasskeyLogin)
+	accountGroupV1.POST("/passkey/finishLogin", account.FinishPasskeyLogin)
+	accountGroupV1.POST("/apiKey/createApiKey", account.CreateAPIKey, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/apiKey/listApiKeys", account.ListAPIKeys, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/apiKey/deleteApiKey", account.DeleteAPIKey, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/device/rememberDevice", account.RememberDevice, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/device/exchangeDeviceToken", account.ExchangeDeviceToken)
+	accountGroupV1.GET("/device/listDevices", account.ListDeviceTokens, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/device/revokeDevice", account.RevokeDeviceToken, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/session/listSessions", account.ListSessions, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/session/revokeSession", account.RevokeSession, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/session/revokeOtherSessions", account.RevokeOtherSessions, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/loginHistory", account.GetLoginHistory, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/deleteAccount", account.DeleteAccount, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/exportData", account.ExportData, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/downloadExport", account.DownloadExport)
+	accountGroupV1.POST("/invite/createInvite", account.CreateInvite, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/invite/listInvites", account.ListInvites, authMiddleware.AuthMiddleware())
+	accountGroupV1.POST("/invite/revokeInvite", account.RevokeInvite, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/invite/checkInvite", account.CheckInvite)
+	accountGroupV1.GET("/profile/:id", account.GetProfile)
+	accountGroupV1.POST("/updateProfile", account.UpdateProfile, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.POST("/uploadAvatar", account.UploadAvatar, authMiddleware.AuthMiddleware(), authMiddleware.SessionOnlyMiddleware())
+	accountGroupV1.GET("/avatar/:file", account.GetAvatar)
+
+	// 根路径 group，默认头像与 Gravatar 地址格式保持一致，不带 API 前缀
+	root := r[1]
+	root.GET("/avatar/:hash", account.GetFallbackAvatar)
 }
 
 func RegisterRolePermissionRoutes(r ...*echo.Group) {
 	// api v1 group
 	apiV1 := r[0]
 
-	// 中间件
+	// 角色与权限管理仅管理员可用，同样受管理接口的 IP 访问规则限制
 
 	// 角色管理
-	roleGroup := apiV1.Group("/role", authMiddleware.AuthMiddleware())
+	roleGroup := apiV1.Group("/role", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
 	roleGroup.POST("/createOneRole", account.CreateRole)
 	roleGroup.POST("/updateOneRole", account.UpdateRole)
 	roleGroup.POST("/deleteOneRole", account.DeleteRole)
 	roleGroup.GET("/listAllRoles", account.ListRoles)
 
 	// 权限管理
-	permissionGroup := apiV1.Group("/permission", authMiddleware.AuthMiddleware())
+	permissionGroup := apiV1.Group("/permission", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
 	permissionGroup.POST("/createOnePermission", account.CreatePermission)
 	permissionGroup.POST("/updateOnePermission", account.UpdatePermission)
 	permissionGroup.POST("/deleteOnePermission", account.DeletePermission)
 	permissionGroup.GET("/listAllPermissions", account.ListPermissions)
 
 	// 用户角色管理
-	accRoleGroup := apiV1.Group("/acc-role", authMiddleware.AuthMiddleware())
+	accRoleGroup := apiV1.Group("/acc-role", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
 	accRoleGroup.POST("/assignRoleToAcc", account.AssignRoleToAcc)
 	accRoleGroup.POST("/updateRoleForAcc", account.UpdateRoleForAcc)
 	accRoleGroup.POST("/deleteRoleFromAcc", account.DeleteRoleFromAcc)
 	accRoleGroup.POST("/getRolesByAcc", account.GetRolesByAcc)
 
 	// 角色权限管理
-	rolePermissionGroup := apiV1.Group("/role-permission", authMiddleware.AuthMiddleware())
+	rolePermissionGroup := apiV1.Group("/role-permission", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
 	rolePermissionGroup.POST("/assignPermissionToRole", account.AssignPermissionToRole)
 	rolePermissionGroup.POST("/updatePermissionForRole", account.UpdatePermissionForRole)
 	rolePermissionGroup.POST("/deletePermissionFromRole", account.DeletePermissionFromRole)
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/bookmark"
+)
+
+func RegisterBookmarkRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	bookmarkGroupV1 := apiV1.Group("/bookmark", authMiddleware.AuthMiddleware())
+	bookmarkGroupV1.POST("/addBookmark", bookmark.AddBookmark)
+	bookmarkGroupV1.POST("/removeBookmark", bookmark.RemoveBookmark)
+	bookmarkGroupV1.GET("/listBookmarks", bookmark.ListBookmarks)
+}
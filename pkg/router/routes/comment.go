@@ -15,4 +15,6 @@ func RegisterCommentRoutes(r ...*echo.Group) {
 	commentGroupV1.GET("/getCommentGraph", comment.GetCommentGraph)
 	commentGroupV1.POST("/createOneComment", comment.CreateOneComment, authMiddleware.AuthMiddleware())
 	commentGroupV1.POST("/deleteOneComment", comment.DeleteOneComment, authMiddleware.AuthMiddleware())
+	commentGroupV1.GET("/trash/getTrashedComments", comment.GetTrashedComments, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	commentGroupV1.POST("/trash/restoreComment", comment.RestoreComment, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
 }
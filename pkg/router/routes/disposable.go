@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/disposable"
+)
+
+func RegisterDisposableEmailRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	disposableGroupV1 := apiV1.Group("/disposableEmail", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	disposableGroupV1.GET("/listDomains", disposable.ListDomains)
+	disposableGroupV1.POST("/addDomain", disposable.AddDomain)
+	disposableGroupV1.POST("/removeDomain", disposable.RemoveDomain)
+	disposableGroupV1.POST("/refreshDomains", disposable.RefreshDomains)
+}
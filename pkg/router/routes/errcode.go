@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/errcode"
+)
+
+func RegisterErrorCodeRoutes(r ...*echo.Group) {
+	// api group，错误码目录与 API 版本无关
+	api := r[0]
+	api.GET("/errors", errcode.ListErrorCodes)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/feed"
+)
+
+func RegisterFeedRoutes(r ...*echo.Group) {
+	// 根路径 group，订阅源地址与静态站点保持一致
+	root := r[0]
+	root.GET("/feed.json", feed.GetJSONFeed)
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/health"
+)
+
+func RegisterHealthRoutes(r ...*echo.Group) {
+	// 根路径 group，供负载均衡与容器编排探活
+	root := r[0]
+	root.GET("/health", health.GetHealth)
+}
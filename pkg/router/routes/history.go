@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/history"
+)
+
+func RegisterHistoryRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	historyGroupV1 := apiV1.Group("/history", authMiddleware.AuthMiddleware())
+	historyGroupV1.POST("/recordReading", history.RecordReading)
+	historyGroupV1.GET("/getReadingHistory", history.GetReadingHistory)
+	historyGroupV1.POST("/deleteReadingHistory", history.DeleteReadingHistory)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/importer"
+)
+
+func RegisterImportRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	importGroupV1 := apiV1.Group("/import", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	importGroupV1.POST("/importContent", importer.ImportContent)
+	importGroupV1.POST("/startImport", importer.StartImport)
+	importGroupV1.GET("/getImportTask", importer.GetImportTask)
+}
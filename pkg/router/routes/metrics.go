@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/metrics"
+)
+
+func RegisterMetricsRoutes(r ...*echo.Group) {
+	// 根路径 group，与 Prometheus 默认的抓取路径一致
+	root := r[0]
+	root.GET("/metrics", metrics.GetMetrics)
+}
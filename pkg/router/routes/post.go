@@ -12,8 +12,55 @@ func RegisterPostRoutes(r ...*echo.Group) {
 	apiV1 := r[0]
 	postGroupV1 := apiV1.Group("/post")
 	postGroupV1.POST("/getOnePost", post.GetOnePost)
+	postGroupV1.GET("/slug/:slug", post.GetPostBySlug)
+	postGroupV1.POST("/unlockPost", post.UnlockPost)
 	postGroupV1.GET("/getAllPosts", post.GetAllPosts)
+	postGroupV1.GET("/getRecommendedPosts", post.GetRecommendedPosts)
+	postGroupV1.GET("/getTrendingPosts", post.GetTrendingPosts)
+	postGroupV1.GET("/getFeaturedPosts", post.GetFeaturedPosts)
+	postGroupV1.GET("/:id/related", post.GetRelatedPosts)
+	postGroupV1.GET("/getLinkPreviews", post.GetLinkPreviews)
+	postGroupV1.GET("/search", post.SearchPosts)
+	postGroupV1.GET("/search/suggest", post.SuggestPosts)
+	postGroupV1.GET("/archive", post.GetArchive)
+	postGroupV1.GET("/archive/getArchives", post.GetArchives)
+	postGroupV1.GET("/archive/getArchivePosts", post.GetArchivePosts)
+	postGroupV1.GET("/author/getAuthors", post.GetAuthors)
+	postGroupV1.GET("/author/getAuthor", post.GetAuthor)
+	postGroupV1.GET("/author/getAuthorPosts", post.GetAuthorPosts)
+	postGroupV1.GET("/translation/getTranslations", post.GetPostTranslations)
 	postGroupV1.POST("/createOnePost", post.CreateOnePost, authMiddleware.AuthMiddleware())
 	postGroupV1.POST("/updateOnePost", post.UpdateOnePost, authMiddleware.AuthMiddleware())
 	postGroupV1.POST("/deleteOnePost", post.DeleteOnePost, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/updatePostStatus", post.UpdatePostStatus, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/schedulePost", post.SchedulePost, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/generateSeoText", post.GenerateSeoText, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/suggestTags", post.SuggestTags, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/author/setAuthors", post.SetPostAuthors, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/translation/linkTranslation", post.LinkPostTranslation, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/translation/unlinkTranslation", post.UnlinkPostTranslation, authMiddleware.AuthMiddleware())
+	postGroupV1.GET("/getDuplicatePosts", post.GetDuplicatePosts, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	postGroupV1.POST("/setPinned", post.SetPostPinned, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	postGroupV1.POST("/setFeatured", post.SetPostFeatured, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	postGroupV1.GET("/trash/getTrashedPosts", post.GetTrashedPosts, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	postGroupV1.POST("/trash/restorePost", post.RestorePost, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	postGroupV1.POST("/draft/saveDraft", post.SaveDraft, authMiddleware.AuthMiddleware())
+	postGroupV1.GET("/draft/getDraft", post.GetDraft, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/draft/discardDraft", post.DiscardDraft, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/lock/acquireEditLock", post.AcquireEditLock, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/lock/renewEditLock", post.RenewEditLock, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/lock/takeOverEditLock", post.TakeOverEditLock, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/lock/releaseEditLock", post.ReleaseEditLock, authMiddleware.AuthMiddleware())
+	postGroupV1.GET("/lock/getEditLock", post.GetEditLock, authMiddleware.AuthMiddleware())
+	postGroupV1.GET("/revision/getRevisions", post.GetPostRevisions, authMiddleware.AuthMiddleware())
+	postGroupV1.GET("/revision/getRevisionDiff", post.GetRevisionDiff, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/revision/restoreRevision", post.RestoreRevision, authMiddleware.AuthMiddleware())
+	postGroupV1.POST("/review/submitForReview", post.SubmitForReview, authMiddleware.AuthMiddleware())
+	postGroupV1.GET("/review/getReviewHistory", post.GetReviewHistory, authMiddleware.AuthMiddleware())
+
+	// 审核操作仅限编辑（管理员）
+	reviewGroupV1 := postGroupV1.Group("/review", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	reviewGroupV1.GET("/getReviewQueue", post.GetReviewQueue)
+	reviewGroupV1.POST("/approveReview", post.ApproveReview)
+	reviewGroupV1.POST("/requestChanges", post.RequestReviewChanges)
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/reaction"
+)
+
+func RegisterReactionRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	reactionGroupV1 := apiV1.Group("/reaction")
+	reactionGroupV1.POST("/addReaction", reaction.AddReaction)
+	reactionGroupV1.POST("/removeReaction", reaction.RemoveReaction)
+	reactionGroupV1.GET("/getReactions", reaction.GetReactions)
+}
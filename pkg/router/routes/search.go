@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/search"
+)
+
+func RegisterSearchRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	apiV1.GET("/search", search.Search)
+	apiV1.GET("/search/comments", search.SearchComments)
+	apiV1.POST("/search/reindex", search.Reindex, authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+}
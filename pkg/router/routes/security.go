@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/security"
+)
+
+func RegisterSecurityRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	securityGroupV1 := apiV1.Group("/admin/security", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	securityGroupV1.GET("/listJwtKeys", security.ListJWTKeys)
+	securityGroupV1.POST("/rotateJwtKey", security.RotateJWTKey)
+	securityGroupV1.GET("/listIpRules", security.ListIPRules)
+	securityGroupV1.POST("/addIpRule", security.AddIPRule)
+	securityGroupV1.POST("/removeIpRule", security.RemoveIPRule)
+}
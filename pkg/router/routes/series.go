@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/series"
+)
+
+func RegisterSeriesRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	seriesGroupV1 := apiV1.Group("/series")
+	seriesGroupV1.GET("/getSeries", series.GetSeries)
+	seriesGroupV1.GET("/listSeries", series.ListSeries)
+	seriesGroupV1.POST("/createSeries", series.CreateSeries, authMiddleware.AuthMiddleware())
+	seriesGroupV1.POST("/updateSeries", series.UpdateSeries, authMiddleware.AuthMiddleware())
+	seriesGroupV1.POST("/deleteSeries", series.DeleteSeries, authMiddleware.AuthMiddleware())
+	seriesGroupV1.POST("/addPost", series.AddSeriesPost, authMiddleware.AuthMiddleware())
+	seriesGroupV1.POST("/removePost", series.RemoveSeriesPost, authMiddleware.AuthMiddleware())
+	seriesGroupV1.POST("/reorderPosts", series.ReorderSeriesPosts, authMiddleware.AuthMiddleware())
+}
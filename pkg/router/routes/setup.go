@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	setupMiddleware "jank.com/jank_blog/internal/middleware/setup"
+	"jank.com/jank_blog/pkg/serve/controller/setup"
+)
+
+func RegisterSetupRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	setupGroupV1 := apiV1.Group("/setup")
+	setupGroupV1.GET("/status", setup.GetSetupStatus)
+	setupGroupV1.POST("/testConnection", setup.TestConnection, setupMiddleware.InitSetupGuard())
+	setupGroupV1.POST("/init", setup.InitSetup, setupMiddleware.InitSetupGuard())
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/shortlink"
+)
+
+func RegisterShortLinkRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	shortLinkGroupV1 := apiV1.Group("/shortlink", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	shortLinkGroupV1.GET("/getShortLinkStats", shortlink.GetShortLinkStats)
+
+	// 根路径 group，短链接需尽量简短，不带 API 前缀
+	root := r[1]
+	root.GET("/s/:code", shortlink.RedirectShortLink)
+}
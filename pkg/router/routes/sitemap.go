@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/sitemap"
+)
+
+func RegisterSitemapRoutes(r ...*echo.Group) {
+	// 根路径 group，站点地图地址与静态站点保持一致
+	root := r[0]
+	root.GET("/sitemap.xml", sitemap.GetSitemap)
+	root.GET("/sitemap-:part", sitemap.GetSitemapPart)
+}
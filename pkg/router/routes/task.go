@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/task"
+)
+
+func RegisterTaskRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	taskGroupV1 := apiV1.Group("/task", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	taskGroupV1.GET("/listTasks", task.ListTasks)
+	taskGroupV1.POST("/triggerTask", task.TriggerTask)
+	taskGroupV1.POST("/pauseTask", task.PauseTask)
+	taskGroupV1.POST("/resumeTask", task.ResumeTask)
+	taskGroupV1.POST("/getTaskLogs", task.GetTaskLogs)
+}
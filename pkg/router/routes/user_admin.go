@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	"jank.com/jank_blog/pkg/serve/controller/account"
+)
+
+func RegisterUserAdminRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	userAdminGroupV1 := apiV1.Group("/admin/user", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	userAdminGroupV1.GET("/listUsers", account.ListUsers)
+	userAdminGroupV1.GET("/getUser", account.GetUser)
+	userAdminGroupV1.POST("/resetPassword", account.AdminResetPassword)
+	userAdminGroupV1.POST("/forceLogout", account.ForceLogout)
+	userAdminGroupV1.POST("/changeRole", account.ChangeUserRole)
+	userAdminGroupV1.POST("/disableUser", account.DisableUser)
+	userAdminGroupV1.POST("/enableUser", account.EnableUser)
+	userAdminGroupV1.GET("/listBans", account.ListUserBans)
+	userAdminGroupV1.POST("/updateBanAppeal", account.UpdateBanAppeal)
+	userAdminGroupV1.POST("/approveUser", account.ApproveUser)
+	userAdminGroupV1.POST("/rejectUser", account.RejectUser)
+	userAdminGroupV1.GET("/getRegistration", account.GetRegistrationSettings)
+	userAdminGroupV1.POST("/updateRegistration", account.UpdateRegistration)
+	userAdminGroupV1.GET("/listAuditLogs", account.ListAdminAuditLogs)
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"github.com/labstack/echo/v4"
 
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
 	"jank.com/jank_blog/pkg/serve/controller/verification"
 )
 
@@ -10,6 +11,26 @@ func RegisterVerificationRoutes(r ...*echo.Group) {
 	// api v1 group
 	apiV1 := r[0]
 	accountGroupV1 := apiV1.Group("/verification")
-	accountGroupV1.GET("/sendImgVerificationCode", verification.SendImgVerificationCode)
-	accountGroupV1.GET("/sendEmailVerificationCode", verification.SendEmailVerificationCode)
+	accountGroupV1.GET("/getCaptchaConfig", verification.GetCaptchaConfig)
+	accountGroupV1.GET("/getLoginCaptcha", verification.GetLoginCaptcha)
+	accountGroupV1.POST("/sendImgVerificationCode", verification.SendImgVerificationCode)
+	accountGroupV1.POST("/sendSliderCaptcha", verification.SendSliderCaptcha)
+	accountGroupV1.POST("/sendEmailVerificationCode", verification.SendEmailVerificationCode)
+	accountGroupV1.POST("/sendSmsVerificationCode", verification.SendSmsVerificationCode)
+	accountGroupV1.POST("/sendMessengerVerificationCode", verification.SendMessengerVerificationCode)
+	accountGroupV1.POST("/resend", verification.ResendEmailVerificationCode)
+
+	// 已废弃的 GET 方式，由 VERIFICATION_ALLOW_GET 控制是否保留
+	accountGroupV1.GET("/sendImgVerificationCode", verification.DeprecatedGET(verification.SendImgVerificationCode))
+	accountGroupV1.GET("/sendEmailVerificationCode", verification.DeprecatedGET(verification.SendEmailVerificationCode))
+	accountGroupV1.GET("/sendSmsVerificationCode", verification.DeprecatedGET(verification.SendSmsVerificationCode))
+	accountGroupV1.GET("/resend", verification.DeprecatedGET(verification.ResendEmailVerificationCode))
+	accountGroupV1.POST("/verify", verification.VerifyCode)
+	accountGroupV1.GET("/checkImgCode", verification.CheckImgCode)
+	accountGroupV1.POST("/checkImgCode", verification.CheckImgCode)
+
+	// 验证码审计日志，仅管理员可用
+	logGroupV1 := apiV1.Group("/verificationLog", authMiddleware.AuthMiddleware(), authMiddleware.AdminMiddleware())
+	logGroupV1.GET("/listLogs", verification.ListVerificationLogs)
+	logGroupV1.GET("/getLogStats", verification.GetVerificationLogStats)
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/controller/visitor"
+)
+
+func RegisterVisitorRoutes(r ...*echo.Group) {
+	// api v1 group
+	apiV1 := r[0]
+	visitorGroupV1 := apiV1.Group("/visitor")
+	visitorGroupV1.POST("/identify", visitor.Identify)
+}
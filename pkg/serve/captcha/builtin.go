@@ -0,0 +1,51 @@
+package captcha
+
+import (
+	"context"
+)
+
+const (
+	BuiltinCacheKeyPrefix = "IMG:VERIFICATION:CODE:CACHE:" // 内置图形验证码答案的缓存键前缀，键为前缀加服务端下发的客户端随机数
+	defaultMaxAttempts    = 5                              // 未配置时同一图形验证码允许的错误次数
+)
+
+func init() {
+	Register(ProviderBuiltin, func(opts Options) (Provider, error) {
+		if opts.MaxAttempts <= 0 {
+			opts.MaxAttempts = defaultMaxAttempts
+		}
+		return builtin{maxAttempts: opts.MaxAttempts}, nil
+	})
+}
+
+// builtin 内置图形验证码，答案由 /verification/sendImgVerificationCode 生成，以客户端随机数作为验证码 ID 缓存
+type builtin struct {
+	maxAttempts int
+}
+
+func (builtin) Name() string {
+	return ProviderBuiltin
+}
+
+func (builtin) SiteKey() string {
+	return ""
+}
+
+// Verify 按随机数查找验证码并与缓存的答案比对，不区分大小写，未提交随机数时校验不通过
+// 校验通过后作废验证码，预校验模式下保留验证码；校验未通过时累加错误次数，达到上限后作废验证码
+func (b builtin) Verify(ctx context.Context, req VerifyRequest) (bool, error) {
+	if req.Nonce == "" {
+		return false, nil
+	}
+	result, err := CheckCode(ctx, CodeCheck{
+		Key:         BuiltinCacheKeyPrefix + req.Nonce,
+		Code:        req.Token,
+		Nonce:       req.Nonce,
+		MaxAttempts: b.maxAttempts,
+		Peek:        req.Peek,
+	})
+	if err != nil {
+		return false, err
+	}
+	return result == CodeMatched, nil
+}
@@ -0,0 +1,116 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"jank.com/jank_blog/configs"
+)
+
+// 内置的人机验证服务名称
+const (
+	ProviderBuiltin   = "builtin"   // 内置图形验证码
+	ProviderSlider    = "slider"    // 内置滑动拼图
+	ProviderTurnstile = "turnstile" // Cloudflare Turnstile
+	ProviderRecaptcha = "recaptcha" // Google reCAPTCHA v3
+	ProviderHCaptcha  = "hcaptcha"  // hCaptcha
+)
+
+// ErrPeekUnsupported 托管人机验证的令牌只能校验一次，不支持仅校验不作废
+var ErrPeekUnsupported = errors.New("当前人机验证服务不支持预校验")
+
+// VerifyRequest 人机验证请求
+type VerifyRequest struct {
+	Token    string // 客户端提交的验证码或验证令牌
+	Subject  string // 验证码的归属标识，如邮箱，用于日志与统计
+	Nonce    string // 获取验证码时服务端下发的客户端随机数，内置图形验证码与滑动拼图以其作为验证码 ID
+	RemoteIP string // 客户端 IP，托管服务用于辅助判断
+	Peek     bool   // 仅校验不作废，供表单即时校验使用，仅内置图形验证码与滑动拼图支持
+}
+
+// Provider 人机验证服务
+type Provider interface {
+	// Name 服务名称，对应配置中的 CAPTCHA_PROVIDER
+	Name() string
+	// SiteKey 前端渲染验证组件所需的公开密钥，内置图形验证码与滑动拼图为空
+	SiteKey() string
+	// Verify 校验客户端提交的验证码，校验未通过时返回 false
+	Verify(ctx context.Context, req VerifyRequest) (bool, error)
+}
+
+// Options 创建人机验证服务所需的配置
+type Options struct {
+	SiteKey     string
+	SecretKey   string
+	MinScore    float64 // reCAPTCHA v3 判定为真人的最低得分
+	Timeout     time.Duration
+	MaxAttempts int // 内置图形验证码允许的错误次数，达到后作废验证码
+	Tolerance   int // 滑动拼图允许的横向误差（像素）
+}
+
+// Factory 人机验证服务构造函数
+type Factory func(opts Options) (Provider, error)
+
+var factories = make(map[string]Factory)
+
+// Register 注册人机验证服务
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// Names 已注册的人机验证服务名称
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New 根据配置创建人机验证服务，未配置时使用内置图形验证码
+func New(config *configs.Config) (Provider, error) {
+	cfg := config.CaptchaConfig
+
+	name := cfg.CaptchaProvider
+	if name == "" {
+		name = ProviderBuiltin
+	}
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("不支持的人机验证服务: %s，可选值: %s", name, strings.Join(Names(), ", "))
+	}
+
+	timeout := time.Duration(cfg.CaptchaTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return factory(Options{
+		SiteKey:     cfg.CaptchaSiteKey,
+		SecretKey:   cfg.CaptchaSecretKey,
+		MinScore:    cfg.CaptchaMinScore,
+		Timeout:     timeout,
+		MaxAttempts: config.VerificationConfig.VerificationMaxAttempts,
+		Tolerance:   cfg.CaptchaSliderTolerance,
+	})
+}
+
+// nonceSeparator 缓存值中客户端随机数与验证码的分隔符
+const nonceSeparator = "|"
+
+// BindNonce 将验证码与客户端随机数拼接为缓存值
+func BindNonce(nonce, code string) string {
+	return nonce + nonceSeparator + code
+}
+
+// SplitNonce 从缓存值中拆分客户端随机数与验证码，未绑定随机数的旧缓存值返回空随机数
+func SplitNonce(value string) (nonce, code string) {
+	if i := strings.Index(value, nonceSeparator); i >= 0 {
+		return value[:i], value[i+len(nonceSeparator):]
+	}
+	return "", value
+}
@@ -0,0 +1,133 @@
+package captcha
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/internal/cache"
+)
+
+// AttemptsKeySuffix 验证码错误次数计数键的后缀，计数键与验证码同时过期
+const AttemptsKeySuffix = ":ATTEMPTS"
+
+// CheckCode 的比对结果，正数为当前错误次数
+const (
+	CodeMatched = 0  // 校验通过
+	CodeMissing = -1 // 验证码不存在或已过期
+	CodeLocked  = -2 // 错误次数达到上限，验证码已作废
+)
+
+// CodeCheck 验证码比对参数
+type CodeCheck struct {
+	Key         string // 验证码缓存键，缓存值为 BindNonce 生成的 "随机数|验证码"
+	Code        string // 用户提交的验证码，比对时不区分大小写
+	Nonce       string // 用户提交的客户端随机数，须与缓存值中的随机数一致，未绑定随机数的验证码不能通过校验
+	MaxAttempts int    // 允许的错误次数
+	Peek        bool   // 校验通过时是否保留验证码
+	Tolerance   int    // 大于 0 时按数值比对，与答案相差不超过该值即通过，用于滑动拼图
+}
+
+// checkCodeScript 比对验证码，要求随机数非空且一致，ARGV[4] 为 1 时校验通过后保留验证码，ARGV[5] 大于 0 时按数值在误差内比对
+// 校验通过时删除验证码与计数；错误时累加计数，达到上限后作废验证码
+// 读取、比对与删除在同一脚本中完成，同一验证码不会被并发请求重复使用
+var checkCodeScript = redis.NewScript(`
+local stored = redis.call('GET', KEYS[1])
+if not stored then
+	return -1
+end
+local nonce, code = '', stored
+local sep = string.find(stored, '|', 1, true)
+if sep then
+	nonce = string.sub(stored, 1, sep - 1)
+	code = string.sub(stored, sep + 1)
+end
+code = string.upper(string.match(code, '^%s*(.-)%s*$'))
+local matched
+local tolerance = tonumber(ARGV[5])
+if tolerance > 0 then
+	local answer, submitted = tonumber(code), tonumber(ARGV[1])
+	matched = answer ~= nil and submitted ~= nil and math.abs(answer - submitted) <= tolerance
+else
+	matched = code == ARGV[1]
+end
+if nonce ~= '' and nonce == ARGV[3] and matched then
+	if ARGV[4] ~= '1' then
+		redis.call('DEL', KEYS[1], KEYS[2])
+	end
+	return 0
+end
+local attempts = redis.call('INCR', KEYS[2])
+if attempts == 1 then
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl > 0 then
+		redis.call('PEXPIRE', KEYS[2], ttl)
+	end
+end
+if attempts >= tonumber(ARGV[2]) then
+	redis.call('DEL', KEYS[1], KEYS[2])
+	return -2
+end
+return attempts
+`)
+
+// CheckCode 原子地比对并作废缓存的验证码，返回 CodeMatched、CodeMissing、CodeLocked 或当前错误次数
+func CheckCode(ctx context.Context, check CodeCheck) (int, error) {
+	code := strings.ToUpper(strings.TrimSpace(check.Code))
+	attemptsKey := check.Key + AttemptsKeySuffix
+	result, err := cache.Eval(ctx, cache.Current(), checkCodeScript, func(tx cache.MemoryTx) (int64, error) {
+		return checkCodeInMemory(tx, check, code, attemptsKey)
+	}, []string{check.Key, attemptsKey}, code, check.MaxAttempts, check.Nonce, flag(check.Peek), check.Tolerance)
+	return int(result), err
+}
+
+// checkCodeInMemory 内存缓存中与 checkCodeScript 等价的比对逻辑
+func checkCodeInMemory(tx cache.MemoryTx, check CodeCheck, code, attemptsKey string) (int64, error) {
+	stored, err := tx.Get(check.Key)
+	if err != nil {
+		return CodeMissing, nil
+	}
+	nonce, answer := SplitNonce(stored)
+	if nonce != "" && nonce == check.Nonce && codeMatches(strings.ToUpper(strings.TrimSpace(answer)), code, check.Tolerance) {
+		if !check.Peek {
+			tx.Del(check.Key, attemptsKey)
+		}
+		return CodeMatched, nil
+	}
+
+	attempts, err := tx.Incr(attemptsKey, tx.TTL(check.Key))
+	if err != nil {
+		return 0, err
+	}
+	if attempts >= int64(check.MaxAttempts) {
+		tx.Del(check.Key, attemptsKey)
+		return CodeLocked, nil
+	}
+	return attempts, nil
+}
+
+// codeMatches 比对答案与用户提交的验证码，tolerance 大于 0 时按数值在误差内比对
+func codeMatches(answer, code string, tolerance int) bool {
+	if tolerance <= 0 {
+		return answer == code
+	}
+	want, err := strconv.ParseFloat(answer, 64)
+	if err != nil {
+		return false
+	}
+	got, err := strconv.ParseFloat(code, 64)
+	if err != nil {
+		return false
+	}
+	return want-got <= float64(tolerance) && got-want <= float64(tolerance)
+}
+
+// flag 将布尔值转换为脚本参数
+func flag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,55 @@
+package captcha
+
+import (
+	"context"
+)
+
+const (
+	SliderCacheKeyPrefix = "SLIDER:VERIFICATION:CODE:" // 滑动拼图答案的缓存键前缀，键为前缀加服务端下发的客户端随机数
+	defaultTolerance     = 5                           // 未配置时滑动拼图允许的横向误差（像素）
+)
+
+func init() {
+	Register(ProviderSlider, func(opts Options) (Provider, error) {
+		if opts.MaxAttempts <= 0 {
+			opts.MaxAttempts = defaultMaxAttempts
+		}
+		if opts.Tolerance <= 0 {
+			opts.Tolerance = defaultTolerance
+		}
+		return slider{maxAttempts: opts.MaxAttempts, tolerance: opts.Tolerance}, nil
+	})
+}
+
+// slider 内置滑动拼图，拼图块的横坐标由 /verification/sendSliderCaptcha 生成，以客户端随机数作为验证码 ID 缓存
+type slider struct {
+	maxAttempts int
+	tolerance   int
+}
+
+func (slider) Name() string {
+	return ProviderSlider
+}
+
+func (slider) SiteKey() string {
+	return ""
+}
+
+// Verify 将用户拖动的横坐标与缓存的答案比对，相差不超过误差即通过，其余规则与内置图形验证码一致
+func (s slider) Verify(ctx context.Context, req VerifyRequest) (bool, error) {
+	if req.Nonce == "" {
+		return false, nil
+	}
+	result, err := CheckCode(ctx, CodeCheck{
+		Key:         SliderCacheKeyPrefix + req.Nonce,
+		Code:        req.Token,
+		Nonce:       req.Nonce,
+		MaxAttempts: s.maxAttempts,
+		Peek:        req.Peek,
+		Tolerance:   s.tolerance,
+	})
+	if err != nil {
+		return false, err
+	}
+	return result == CodeMatched, nil
+}
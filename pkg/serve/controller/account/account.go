@@ -1,11 +1,14 @@
 package account
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 
 	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/password"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/account/dto"
 	"jank.com/jank_blog/pkg/serve/controller/verification"
@@ -26,13 +29,8 @@ import (
 // @Router       /account/getAccount [post]
 func GetAccount(c echo.Context) error {
 	req := new(dto.GetAccountRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	response, err := service.GetAccount(req, c)
@@ -45,7 +43,7 @@ func GetAccount(c echo.Context) error {
 
 // RegisterAcc godoc
 // @Summary      用户注册
-// @Description  注册新用户账号，支持图形验证码和邮箱验证码校验
+// @Description  注册新用户账号，支持图形验证码和邮箱验证码校验；站点开启邀请注册时还需填写有效的邀请码，开启注册审核时返回 pending_approval，审核通过前不能登录
 // @Tags         账户
 // @Accept       json
 // @Produce      json
@@ -53,30 +51,55 @@ func GetAccount(c echo.Context) error {
 // @Param        ImgVerificationCode  query   string  true  "图形验证码"
 // @Param        EmailVerificationCode  query   string  true  "邮箱验证码"
 // @Success      200     {object}   vo.Result{data=dto.RegisterRequest}  "注册成功"
-// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败、邮箱属于一次性邮箱、密码不符合安全要求或邀请码不可用"
+// @Failure      403     {object}   vo.Result         "站点已关闭注册，或开启了邀请注册而未填写邀请码"
 // @Failure      500     {object}   vo.Result         "服务器错误"
 // @Router       /account/registerAccount [post]
 func RegisterAcc(c echo.Context) error {
 	req := new(dto.RegisterRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if verification.IsDisposableEmail(req.Email, c) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.DisposableEmailBlocked), c))
 	}
 
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if err := password.Check(req.Password, req.Email); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasswordTooWeak, err.Error()), c))
 	}
 
-	if !verification.VerifyImgCode(req.ImgVerificationCode, req.Email, c) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendImgVerificationCodeFail, "图形验证码校验失败"), c))
+	if err := service.CheckRegistrationOpen(c); err != nil {
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.RegistrationClosed), c))
+	}
+
+	// 先校验邀请码，避免邀请码无效时邮箱验证码已被消耗
+	_, err := service.CheckRegisterInvite(req.InviteCode, c)
+	switch {
+	case errors.Is(err, service.ErrInviteRequired):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.InviteRequired), c))
+	case errors.Is(err, service.ErrInviteInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.InviteInvalid), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
 	}
 
-	if !verification.VerifyEmailCode(req.EmailVerificationCode, req.Email, c) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendEmailVerificationCodeFail, "邮箱验证码校验失败"), c))
+	if err := checkImgCode(req.ImgVerificationCode, req.ImgVerificationTicket, req.Email, req.VerificationNonce, c); err != nil {
+		return codeFailResponse(err, bizErr.SendImgVerificationCodeFail, "图形验证码校验失败", c)
+	}
+
+	if err := checkEmailCode(req.EmailVerificationCode, req.EmailVerificationTicket, req.Email, req.VerificationNonce, verification.ActionRegister, c); err != nil {
+		return codeFailResponse(err, bizErr.SendEmailVerificationCodeFail, "邮箱验证码校验失败", c)
 	}
 
 	user, err := service.RegisterUser(req, c)
-	if err != nil {
+	switch {
+	case errors.Is(err, service.ErrInviteInvalid):
+		// 校验后邀请码被其他注册用完或被撤销
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.InviteInvalid), c))
+	case errors.Is(err, service.ErrRegistrationClosed):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.RegistrationClosed), c))
+	case err != nil:
 		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
 	}
 
@@ -85,42 +108,56 @@ func RegisterAcc(c echo.Context) error {
 
 // LoginAccount godoc
 // @Summary      用户登录
-// @Description  用户登录并获取访问令牌，支持图形验证码校验
+// @Description  用户登录并获取访问令牌，需完成人机验证；LOGIN_CAPTCHA_THRESHOLD 大于 0 时，账户或当前 IP 登录失败次数达到阈值后才需要，可通过 /verification/getLoginCaptcha 查询；账户开启两步验证时返回 totp_ticket，需调用 /account/verifyTotpLogin 提交动态码后获取令牌；同一账户或同一 IP 连续登录失败达到阈值后暂停登录，账户被锁定时向邮箱发送解锁链接
 // @Tags         账户
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.LoginRequest  true  "登录信息"
-// @Param        ImgVerificationCode  query   string  true  "图形验证码"
-// @Success      200     {object}   vo.Result{data=account.LoginVo}  "登录成功，返回访问令牌"
-// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败"
+// @Success      200     {object}   vo.Result{data=account.LoginVo}  "登录成功，返回访问令牌或两步验证凭证"
+// @Failure      400     {object}   vo.Result         "参数错误，需要人机验证或验证码校验失败"
 // @Failure      401     {object}   vo.Result         "登录失败，凭证无效"
+// @Failure      403     {object}   vo.Result{data=account.AccountDisabledVo}  "账户已被停用、待审核或未通过注册审核"
+// @Failure      423     {object}   vo.Result{data=verification.RetryAfterVo}  "登录失败次数过多，账户或 IP 已被锁定"
 // @Router       /account/loginAccount [post]
 func LoginAccount(c echo.Context) error {
 	req := new(dto.LoginRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if verification.LoginCaptchaRequired(req.Email, c) {
+		if req.ImgVerificationCode == "" && req.ImgVerificationTicket == "" {
+			return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.LoginCaptchaRequired), c))
+		}
+		if err := checkImgCode(req.ImgVerificationCode, req.ImgVerificationTicket, req.Email, req.VerificationNonce, c); err != nil {
+			return codeFailResponse(err, bizErr.SendImgVerificationCodeFail, "图形验证码校验失败", c)
+		}
 	}
 
-	if !verification.VerifyImgCode(req.ImgVerificationCode, req.Email, c) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendImgVerificationCodeFail, "图形验证码校验失败"), c))
+	if err := verification.CheckLoginLock(req.Email, c); err != nil {
+		return verification.LoginLockFailResponse(err, c)
 	}
 
 	response, err := service.LoginUser(req, c)
+	var disabled *service.AccountDisabledError
+	if errors.As(err, &disabled) {
+		// 密码校验已通过，不计入登录失败次数
+		return accountDisabledResponse(disabled, c)
+	}
 	if err != nil {
+		if err := verification.RecordLoginFailure(req.Email, service.EmailRegistered(req.Email), c); err != nil {
+			return verification.LoginLockFailResponse(err, c)
+		}
 		return c.JSON(http.StatusUnauthorized, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
 	}
+	verification.ClearLoginFailures(req.Email)
 
 	return c.JSON(http.StatusOK, vo.Success(response, c))
 }
 
 // LogoutAccount godoc
 // @Summary      用户登出
-// @Description  退出当前用户登录状态
+// @Description  退出当前用户登录状态，请求头携带 Refresh_Token 时一并吊销该 refresh token
 // @Tags         账户
 // @Produce      json
 // @Success      200  {object}  vo.Result{data=string}  "登出成功"
@@ -136,40 +173,143 @@ func LogoutAccount(c echo.Context) error {
 	return c.JSON(http.StatusOK, vo.Success("用户注销成功", c))
 }
 
+// LogoutAllDevices godoc
+// @Summary      全部设备登出
+// @Description  退出当前用户在全部设备上的登录状态，此前签发的 refresh token 均被吊销
+// @Tags         账户
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=string}  "登出成功"
+// @Failure      401  {object}  vo.Result  "未授权"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Security     BearerAuth
+// @Router       /account/logoutAllDevices [post]
+func LogoutAllDevices(c echo.Context) error {
+	if err := service.LogoutAllDevices(c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("已在全部设备登出", c))
+}
+
+// RefreshToken godoc
+// @Summary      刷新令牌
+// @Description  使用 refresh token 换取新的 access token 与 refresh token，旧 refresh token 随即失效，重复使用时返回 401
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RefreshTokenRequest  true  "refresh token"
+// @Success      200  {object}  vo.Result{data=account.LoginVo}  "刷新成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      401  {object}  vo.Result  "refresh token 无效、已过期或已吊销"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /account/refreshToken [post]
+func RefreshToken(c echo.Context) error {
+	req := new(dto.RefreshTokenRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	response, err := service.RefreshToken(req, c)
+	if errors.Is(err, service.ErrRefreshTokenInvalid) {
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.RefreshTokenInvalid), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
+
 // ResetPassword godoc
 // @Summary      重置密码
-// @Description  重置用户账户密码，支持邮箱验证码校验
+// @Description  重置当前登录用户的密码，需校验账户邮箱收到的验证码，开启两步验证的账户可使用 TOTP 动态码代替
 // @Tags         账户
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.ResetPwdRequest  true  "重置密码信息"
 // @Success      200     {object}   vo.Result{data=string}  "密码重置成功"
-// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败"
+// @Failure      400     {object}   vo.Result         "参数错误，验证码校验失败或密码不符合安全要求"
 // @Failure      401     {object}   vo.Result         "未授权，用户未登录"
 // @Failure      500     {object}   vo.Result         "服务器错误"
 // @security     BearerAuth
 // @Router       /account/resetPassword [post]
 func ResetPassword(c echo.Context) error {
 	req := new(dto.ResetPwdRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	email, err := service.CurrentAccountEmail(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+	if req.Email != "" && !strings.EqualFold(req.Email, email) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "邮箱与当前用户不一致"), c))
 	}
 
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if err := password.Check(req.NewPassword, email); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasswordTooWeak, err.Error()), c))
+	}
+	if err := verification.VerifySecondFactor(email, verification.ActionResetPassword, verification.SecondFactor{
+		EmailCode:   req.EmailVerificationCode,
+		EmailTicket: req.EmailVerificationTicket,
+		TotpCode:    req.TotpCode,
+		Nonce:       req.VerificationNonce,
+	}, c); err != nil {
+		return codeFailResponse(err, bizErr.SendEmailVerificationCodeFail, "验证码校验失败", c)
 	}
 
-	if !verification.VerifyEmailCode(req.EmailVerificationCode, req.Email, c) {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendEmailVerificationCodeFail, "邮箱验证码校验失败"), c))
+	if err := service.ResetPassword(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
 	}
 
-	err := service.ResetPassword(req, c)
+	return c.JSON(http.StatusOK, vo.Success("密码重置成功", c))
+}
+
+// GetPreferences godoc
+// @Summary      获取偏好设置
+// @Description  获取当前用户的偏好设置
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.PreferencesVo}  "获取成功"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/getPreferences [get]
+func GetPreferences(c echo.Context) error {
+	prefs, err := service.GetPreferences(c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
 	}
 
-	return c.JSON(http.StatusOK, vo.Success("密码重置成功", c))
+	return c.JSON(http.StatusOK, vo.Success(prefs, c))
+}
+
+// UpdatePreferences godoc
+// @Summary      更新偏好设置
+// @Description  更新当前用户的偏好设置，未传的字段保持不变；关闭阅读历史后不再记录新的阅读进度；关闭新设备登录提醒后不再发送提醒邮件，修改密码、关闭两步验证等必要的安全提醒不受影响
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdatePreferencesRequest  true  "偏好设置"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.PreferencesVo}  "更新成功"
+// @Failure      400     {object}   vo.Result              "请求参数错误"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result              "服务器错误"
+// @Router       /account/updatePreferences [post]
+func UpdatePreferences(c echo.Context) error {
+	req := new(dto.UpdatePreferencesRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	prefs, err := service.UpdatePreferences(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(prefs, c))
 }
 
 // CreateRole 创建角色
@@ -185,13 +325,8 @@ func ResetPassword(c echo.Context) error {
 // @Router       /role/createOneRole [post]
 func CreateRole(c echo.Context) error {
 	req := new(dto.CreateRoleRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	role, err := service.CreateRole(req, c)
@@ -215,13 +350,8 @@ func CreateRole(c echo.Context) error {
 // @Router       /role/updateOneRole [post]
 func UpdateRole(c echo.Context) error {
 	req := new(dto.UpdateRoleRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	role, err := service.UpdateRole(req, c)
@@ -245,13 +375,8 @@ func UpdateRole(c echo.Context) error {
 // @Router       /role/deleteOneRole [post]
 func DeleteRole(c echo.Context) error {
 	req := new(dto.DeleteRoleRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.DeleteRole(req, c)
@@ -264,19 +389,22 @@ func DeleteRole(c echo.Context) error {
 
 // ListRoles 获取所有角色
 // @Summary      获取所有角色
-// @Description  获取系统中所有角色的信息
+// @Description  分页获取系统中角色的信息
 // @Tags         角色管理
 // @Accept       json
 // @Produce      json
-// @Success      200     {object}  vo.Result{data=[]account.RoleVo}  "获取成功"
+// @Param        page      query  int     false  "页码"
+// @Param        pageSize  query  int     false  "每页显示数量，最大 100"
+// @Param        cursor    query  string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200     {object}  vo.Result{data=[]account.RoleVo,page=vo.PageMeta}  "获取成功"
 // @Failure      500     {object}  vo.Result{message=string}     "服务器错误"
 // @Router       /role/listAllRoles [post]
 func ListRoles(c echo.Context) error {
-	roles, err := service.ListRoles(c)
+	roles, meta, err := service.ListRoles(vo.ParsePage(c), c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
 	}
-	return c.JSON(http.StatusOK, vo.Success(roles, c))
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(roles, meta, c))
 }
 
 // CreatePermission 创建权限
@@ -292,13 +420,8 @@ func ListRoles(c echo.Context) error {
 // @Router       /permission/createOnePermission [post]
 func CreatePermission(c echo.Context) error {
 	req := new(dto.CreatePermissionRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	permission, err := service.CreatePermission(req, c)
@@ -322,13 +445,8 @@ func CreatePermission(c echo.Context) error {
 // @Router       /permission/updateOnePermission [post]
 func UpdatePermission(c echo.Context) error {
 	req := new(dto.UpdatePermissionRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	permission, err := service.UpdatePermission(req, c)
@@ -352,13 +470,8 @@ func UpdatePermission(c echo.Context) error {
 // @Router       /permission/deleteOnePermission [post]
 func DeletePermission(c echo.Context) error {
 	req := new(dto.DeletePermissionRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.DeletePermission(req, c)
@@ -371,19 +484,22 @@ func DeletePermission(c echo.Context) error {
 
 // ListPermissions 获取所有权限
 // @Summary      获取所有权限
-// @Description  获取系统中所有权限的信息
+// @Description  分页获取系统中权限的信息
 // @Tags         权限管理
 // @Accept       json
 // @Produce      json
-// @Success      200     {object}  vo.Result{data=[]account.PermissionVo}  "获取成功"
+// @Param        page      query  int     false  "页码"
+// @Param        pageSize  query  int     false  "每页显示数量，最大 100"
+// @Param        cursor    query  string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200     {object}  vo.Result{data=[]account.PermissionVo,page=vo.PageMeta}  "获取成功"
 // @Failure      500     {object}  vo.Result{message=string}     "服务器错误"
 // @Router       /permission/listAllPermissions [post]
 func ListPermissions(c echo.Context) error {
-	permissions, err := service.ListPermissions(c)
+	permissions, meta, err := service.ListPermissions(vo.ParsePage(c), c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
 	}
-	return c.JSON(http.StatusOK, vo.Success(permissions, c))
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(permissions, meta, c))
 }
 
 // AssignRoleToAcc 为用户分配角色
@@ -399,13 +515,8 @@ func ListPermissions(c echo.Context) error {
 // @Router       /acc-role/assignRoleToAcc [post]
 func AssignRoleToAcc(c echo.Context) error {
 	req := new(dto.AssignRoleRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.AssignRoleToAcc(req, c)
@@ -429,13 +540,8 @@ func AssignRoleToAcc(c echo.Context) error {
 // @Router       /role-permission/assignPermissionToRole [post]
 func AssignPermissionToRole(c echo.Context) error {
 	req := new(dto.AssignPermissionRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.AssignPermissionToRole(req, c)
@@ -459,13 +565,8 @@ func AssignPermissionToRole(c echo.Context) error {
 // @Router       /acc-role/deleteRoleFromAcc [post]
 func DeleteRoleFromAcc(c echo.Context) error {
 	req := new(dto.AssignRoleRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.RemoveRoleFromAcc(req, c)
@@ -489,13 +590,8 @@ func DeleteRoleFromAcc(c echo.Context) error {
 // @Router       /role-permission/deletePermissionFromRole [post]
 func DeletePermissionFromRole(c echo.Context) error {
 	req := new(dto.AssignPermissionRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.RemovePermissionFromRole(req, c)
@@ -519,13 +615,8 @@ func DeletePermissionFromRole(c echo.Context) error {
 // @Router       /acc-role/updateRoleForAcc [post]
 func UpdateRoleForAcc(c echo.Context) error {
 	req := new(dto.AssignRoleRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.UpdateRoleForAcc(req, c)
@@ -549,13 +640,8 @@ func UpdateRoleForAcc(c echo.Context) error {
 // @Router       /role-permission/updatePermissionForRole [post]
 func UpdatePermissionForRole(c echo.Context) error {
 	req := new(dto.AssignPermissionRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.UpdatePermissionForRole(req, c)
@@ -579,13 +665,8 @@ func UpdatePermissionForRole(c echo.Context) error {
 // @Router       /acc-role/getRolesByAcc [POST]
 func GetRolesByAcc(c echo.Context) error {
 	req := new(dto.GetRolesByAccRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	roles, err := service.GetRolesByAcc(req, c)
@@ -609,13 +690,8 @@ func GetRolesByAcc(c echo.Context) error {
 // @Router       /role-permission/getPermissionsByRole [POST]
 func GetPermissionsByRole(c echo.Context) error {
 	req := new(dto.GetPermissionsByRoleRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, err.Error()), c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, "请求参数校验失败"), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	permissions, err := service.GetPermissionsByRole(req, c)
@@ -625,3 +701,35 @@ func GetPermissionsByRole(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, vo.Success(permissions, c))
 }
+
+// checkImgCode 校验图形验证码，提交了验证凭证时改为校验并作废凭证
+func checkImgCode(code, ticket, email, nonce string, c echo.Context) error {
+	if ticket != "" {
+		return verification.ConsumeTicket(ticket, verification.ChannelImg, email, "", c)
+	}
+	if !verification.VerifyImgCode(code, email, nonce, c) {
+		return verification.ErrVerificationCodeMismatch
+	}
+	return nil
+}
+
+// checkEmailCode 校验邮箱验证码，提交了验证凭证时改为校验并作废凭证，action 为验证码的用途
+func checkEmailCode(code, ticket, email, nonce, action string, c echo.Context) error {
+	if ticket != "" {
+		return verification.ConsumeTicket(ticket, verification.ChannelEmail, email, action, c)
+	}
+	return verification.VerifyEmailCode(code, email, nonce, action, c)
+}
+
+// codeFailResponse 验证码或验证凭证校验失败的响应，错误次数过多、凭证无效与未开启两步验证时返回专用错误码
+func codeFailResponse(err error, code int, msg string, c echo.Context) error {
+	switch {
+	case errors.Is(err, verification.ErrVerificationAttemptsExceeded):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.VerificationCodeLocked), c))
+	case errors.Is(err, verification.ErrTicketInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.VerificationTicketInvalid), c))
+	case errors.Is(err, verification.ErrTotpNotEnrolled):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.TotpNotEnrolled), c))
+	}
+	return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(code, msg), c))
+}
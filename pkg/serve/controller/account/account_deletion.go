@@ -0,0 +1,59 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// DeleteAccount godoc
+// @Summary      注销账户
+// @Description  提交 TOTP 动态码或邮箱验证码后申请注销账户，账户进入冷静期并在全部设备登出，冷静期内重新登录即撤销注销，到期后永久删除账户并匿名化评论
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DeleteAccountRequest  true  "注销账户信息"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.AccountDeletionVo}  "申请成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或验证码校验失败"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      409     {object}   vo.Result  "站点唯一的管理员不能注销账户"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/deleteAccount [post]
+func DeleteAccount(c echo.Context) error {
+	req := new(dto.DeleteAccountRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	email, err := service.CurrentAccountEmail(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+	if err := verification.VerifySecondFactor(email, verification.ActionDeleteAccount, verification.SecondFactor{
+		EmailCode:   req.EmailVerificationCode,
+		EmailTicket: req.EmailVerificationTicket,
+		TotpCode:    req.TotpCode,
+		Nonce:       req.VerificationNonce,
+	}, c); err != nil {
+		return codeFailResponse(err, bizErr.SendEmailVerificationCodeFail, "验证码校验失败", c)
+	}
+
+	result, err := service.ScheduleAccountDeletion(c)
+	if errors.Is(err, service.ErrLastAdminDeletion) {
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.AccountDeletionLastAdmin), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(result, c))
+}
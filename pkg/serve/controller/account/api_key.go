@@ -0,0 +1,94 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// CreateAPIKey godoc
+// @Summary      创建 API Key
+// @Description  创建个人 API Key，脚本与集成可在请求头 Authorization: ApiKey {key} 中携带，无需登录与人机验证；完整的 Key 仅返回一次
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateAPIKeyRequest  true  "API Key 信息"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.APIKeyCreatedVo}  "创建成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "非管理员创建 admin 权限范围的 Key，或使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/apiKey/createApiKey [post]
+func CreateAPIKey(c echo.Context) error {
+	req := new(dto.CreateAPIKeyRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	key, err := service.CreateAPIKey(req, c)
+	if errors.Is(err, service.ErrAPIKeyScopeDenied) {
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.APIKeyScopeDenied, err.Error()), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(key, c))
+}
+
+// ListAPIKeys godoc
+// @Summary      获取 API Key 列表
+// @Description  获取当前账户创建的 API Key 及最近使用记录
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]account.APIKeyVo}  "获取成功"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/apiKey/listApiKeys [get]
+func ListAPIKeys(c echo.Context) error {
+	keys, err := service.ListAPIKeys(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(keys, c))
+}
+
+// DeleteAPIKey godoc
+// @Summary      删除 API Key
+// @Description  删除当前账户创建的 API Key，删除后立即失效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DeleteAPIKeyRequest  true  "API Key ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "删除成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      404     {object}   vo.Result  "API Key 不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/apiKey/deleteApiKey [post]
+func DeleteAPIKey(c echo.Context) error {
+	req := new(dto.DeleteAPIKeyRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.DeleteAPIKey(req, c)
+	if errors.Is(err, service.ErrAPIKeyNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.APIKeyNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(nil, c))
+}
@@ -0,0 +1,57 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ExportData godoc
+// @Summary      导出个人数据
+// @Description  在后台将当前账户的资料、文章、评论与登录记录打包为 ZIP（JSON 与 Markdown），完成后将有时效的下载链接发送到账户邮箱
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      202  {object}  vo.Result{data=account.DataExportVo}  "已开始导出"
+// @Failure      403  {object}  vo.Result  "使用 API Key 调用"
+// @Failure      429  {object}  vo.Result  "申请过于频繁"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /account/exportData [post]
+func ExportData(c echo.Context) error {
+	result, err := service.RequestDataExport(c)
+	if errors.Is(err, service.ErrDataExportCooldown) {
+		return c.JSON(http.StatusTooManyRequests, vo.Fail(nil, bizErr.New(bizErr.DataExportCooldown), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusAccepted, vo.Success(result, c))
+}
+
+// DownloadExport godoc
+// @Summary      下载个人数据
+// @Description  使用导出完成邮件中的链接下载个人数据 ZIP 文件，链接在有效期内可重复使用
+// @Tags         账户
+// @Produce      application/zip
+// @Param        token  query  string  true  "下载链接中的令牌"
+// @Success      200  {file}    file       "导出文件"
+// @Failure      400  {object}  vo.Result  "下载链接无效或已过期"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /account/downloadExport [get]
+func DownloadExport(c echo.Context) error {
+	path, filename, err := service.DataExportFile(c.QueryParam("token"), c)
+	if errors.Is(err, service.ErrDataExportLinkInvalid) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.DataExportLinkInvalid), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.Attachment(path, filename)
+}
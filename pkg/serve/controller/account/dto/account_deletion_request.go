@@ -0,0 +1,14 @@
+package dto
+
+// DeleteAccountRequest    注销账户请求体
+// @Description	注销账户所需参数，需提交 TOTP 动态码或当前账户邮箱收到的验证码之一
+// @Param			totp_code					body	string	false	"TOTP 动态码"
+// @Param			email_verification_code		body	string	false	"邮箱验证码，用途为 delete_account"
+// @Param			email_verification_ticket	body	string	false	"邮箱验证码或 TOTP 动态码换取的验证凭证"
+// @Param			verification_nonce			body	string	false	"获取验证码时下发的客户端随机数"
+type DeleteAccountRequest struct {
+	TotpCode                string `json:"totp_code" xml:"totp_code" form:"totp_code" query:"totp_code" validate:"omitempty,len=6,numeric"`
+	EmailVerificationCode   string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required_without_all=EmailVerificationTicket TotpCode"`
+	EmailVerificationTicket string `json:"email_verification_ticket" xml:"email_verification_ticket" form:"email_verification_ticket" query:"email_verification_ticket"`
+	VerificationNonce       string `json:"verification_nonce" xml:"verification_nonce" form:"verification_nonce" query:"verification_nonce"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// CreateAPIKeyRequest    创建 API Key 请求体
+// @Description	创建个人 API Key 所需参数
+// @Param			name			body	string	true	"名称，便于区分用途"
+// @Param			scope			body	string	true	"权限范围，可选值: read, write, admin，admin 仅管理员可创建"
+// @Param			expires_in_days	body	int		false	"有效天数，留空或为 0 时永不过期"
+type CreateAPIKeyRequest struct {
+	Name          string `json:"name" xml:"name" form:"name" query:"name" validate:"required,max=64"`
+	Scope         string `json:"scope" xml:"scope" form:"scope" query:"scope" validate:"required,oneof=read write admin"`
+	ExpiresInDays int    `json:"expires_in_days" xml:"expires_in_days" form:"expires_in_days" query:"expires_in_days" validate:"omitempty,min=1,max=3650"`
+}
+
+// DeleteAPIKeyRequest    删除 API Key 请求体
+// @Description	删除当前账户创建的 API Key
+// @Param			id	body	int64	true	"API Key ID"
+type DeleteAPIKeyRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+// CreateInviteRequest    创建邀请码请求体
+// @Description	创建邀请码所需参数，普通用户创建的邀请码受使用次数与有效期上限限制
+// @Param			max_uses		body	int		false	"可使用的次数，留空时为 1"
+// @Param			expires_in_days	body	int		false	"有效天数，留空时使用 INVITE_DEFAULT_TTL"
+// @Param			note			body	string	false	"备注，便于区分用途"
+type CreateInviteRequest struct {
+	MaxUses       int    `json:"max_uses" xml:"max_uses" form:"max_uses" query:"max_uses" validate:"omitempty,min=1,max=1000"`
+	ExpiresInDays int    `json:"expires_in_days" xml:"expires_in_days" form:"expires_in_days" query:"expires_in_days" validate:"omitempty,min=1,max=365"`
+	Note          string `json:"note" xml:"note" form:"note" query:"note" validate:"max=64"`
+}
+
+// ListInvitesRequest    邀请码列表筛选参数，分页参数见 page、pageSize 与 cursor
+// @Param	creator_id	query	int64	false	"创建者的账户 ID，仅管理员可用，为空时获取全部邀请码；普通用户只能获取自己创建的邀请码"
+type ListInvitesRequest struct {
+	CreatorID int64 `json:"creator_id" xml:"creator_id" form:"creator_id" query:"creator_id" validate:"min=0"`
+}
+
+// RevokeInviteRequest    撤销邀请码请求体
+// @Description	撤销后邀请码不能再用于注册，已注册的账户不受影响
+// @Param			id	body	int64	true	"邀请码 ID"
+type RevokeInviteRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
+
+// CheckInviteRequest    校验邀请码请求参数
+// @Param	code	query	string	false	"邀请码，为空时仅返回是否需要邀请码"
+type CheckInviteRequest struct {
+	Code string `json:"code" xml:"code" form:"code" query:"code" validate:"max=32"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// LdapLoginRequest    LDAP 登录请求体
+// @Description	使用 Active Directory、OpenLDAP 等目录服务的账号登录
+// @Param			username	body	string	true	"目录用户名，如 AD 的 sAMAccountName"
+// @Param			password	body	string	true	"目录账号密码"
+type LdapLoginRequest struct {
+	Username string `json:"username" xml:"username" form:"username" query:"username" validate:"required,max=256"`
+	Password string `json:"password" xml:"password" form:"password" query:"password" validate:"required,max=256"`
+}
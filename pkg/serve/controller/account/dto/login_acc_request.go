@@ -4,9 +4,13 @@ package dto
 // @Description	用户登录请求所需参数
 // @Param			email		body	string	true	"用户邮箱"
 // @Param			password	body	string	true	"用户密码"
-// @Param			img_verification_code	body	string	true	"图片验证码"
+// @Param			img_verification_code	body	string	false	"图片验证码，使用托管人机验证时为验证令牌，提交 img_verification_ticket 时可省略；LOGIN_CAPTCHA_THRESHOLD 大于 0 时仅在 /verification/getLoginCaptcha 返回需要时必填"
+// @Param			img_verification_ticket	body	string	false	"图片验证码换取的验证凭证"
+// @Param			verification_nonce	body	string	false	"获取验证码时下发的客户端随机数"
 type LoginRequest struct {
-	Email               string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
-	Password            string `json:"password" xml:"password" form:"password" query:"password" validate:"required"`
-	ImgVerificationCode string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required"`
+	Email                 string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	Password              string `json:"password" xml:"password" form:"password" query:"password" validate:"required"`
+	ImgVerificationCode   string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code"`
+	ImgVerificationTicket string `json:"img_verification_ticket" xml:"img_verification_ticket" form:"img_verification_ticket" query:"img_verification_ticket"`
+	VerificationNonce     string `json:"verification_nonce" xml:"verification_nonce" form:"verification_nonce" query:"verification_nonce"`
 }
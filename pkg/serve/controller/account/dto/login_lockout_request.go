@@ -0,0 +1,17 @@
+package dto
+
+// UnlockAccountRequest    通过链接解锁账户请求体
+// @Description	使用账户锁定邮件中的解锁链接解除锁定，链接只能使用一次
+// @Param			token	body	string	true	"解锁链接中的 token 参数"
+type UnlockAccountRequest struct {
+	Token string `json:"token" xml:"token" form:"token" query:"token" validate:"required,len=64,hexadecimal"`
+}
+
+// UnlockLoginRequest    管理员解除登录锁定请求体
+// @Description	解除账户锁定，或解除某个 IP 的登录暂停，两者至少填写一项
+// @Param			email	body	string	false	"需要解锁的账户邮箱"
+// @Param			ip		body	string	false	"需要解除登录暂停的 IP"
+type UnlockLoginRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required_without=IP,omitempty,email"`
+	IP    string `json:"ip" xml:"ip" form:"ip" query:"ip" validate:"omitempty,ip"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+// SendMagicLinkRequest    发送免密登录链接请求体
+// @Description	向账户邮箱发送一次性登录链接，邮箱未注册时同样返回成功
+// @Param			email					body	string	true	"用户邮箱"
+// @Param			img_verification_code	body	string	false	"图形验证码，提交 img_verification_ticket 时可省略"
+// @Param			img_verification_ticket	body	string	false	"图形验证码换取的验证凭证"
+// @Param			verification_nonce		body	string	false	"获取验证码时下发的客户端随机数"
+type SendMagicLinkRequest struct {
+	Email                 string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	ImgVerificationCode   string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required_without=ImgVerificationTicket"`
+	ImgVerificationTicket string `json:"img_verification_ticket" xml:"img_verification_ticket" form:"img_verification_ticket" query:"img_verification_ticket"`
+	VerificationNonce     string `json:"verification_nonce" xml:"verification_nonce" form:"verification_nonce" query:"verification_nonce"`
+}
+
+// LoginByMagicLinkRequest    通过免密登录链接登录请求体
+// @Description	打开登录链接后提交链接中的 token，在发起请求的设备上打开时一并提交 request_id
+// @Param			token		body	string	true	"登录链接中的 token 参数"
+// @Param			request_id	body	string	false	"发送登录链接时返回的请求 ID"
+type LoginByMagicLinkRequest struct {
+	Token     string `json:"token" xml:"token" form:"token" query:"token" validate:"required,len=64,hexadecimal"`
+	RequestID string `json:"request_id" xml:"request_id" form:"request_id" query:"request_id" validate:"omitempty,len=64,hexadecimal"`
+}
+
+// ConfirmMagicLinkRequest    确认免密登录请求体
+// @Description	在其他设备上打开链接时确认登录，确认后发起请求的设备登录，确认的设备不会登录
+// @Param			token	body	string	true	"登录链接中的 token 参数"
+type ConfirmMagicLinkRequest struct {
+	Token string `json:"token" xml:"token" form:"token" query:"token" validate:"required,len=64,hexadecimal"`
+}
+
+// PollMagicLinkRequest    查询免密登录确认结果请求体
+// @Description	发起请求的设备轮询登录请求是否已在其他设备上确认
+// @Param			request_id	body	string	true	"发送登录链接时返回的请求 ID"
+type PollMagicLinkRequest struct {
+	RequestID string `json:"request_id" xml:"request_id" form:"request_id" query:"request_id" validate:"required,len=64,hexadecimal"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+// LinkMessengerRequest    绑定即时通讯账号请求体
+// @Description	向待绑定的账号发送验证码所需参数
+// @Param			provider	body	string	true	"即时通讯服务，可选值: telegram, whatsapp"
+// @Param			handle		body	string	true	"账号，Telegram 为与机器人对话的 chat ID，WhatsApp 为 E.164 格式的手机号"
+type LinkMessengerRequest struct {
+	Provider string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,oneof=telegram whatsapp"`
+	Handle   string `json:"handle" xml:"handle" form:"handle" query:"handle" validate:"required,max=32"`
+}
+
+// ConfirmMessengerRequest    确认绑定即时通讯账号请求体
+// @Description	提交待绑定账号收到的验证码以完成绑定
+// @Param			provider	body	string	true	"即时通讯服务，可选值: telegram, whatsapp"
+// @Param			handle		body	string	true	"待绑定的账号，需与发送验证码时一致"
+// @Param			code		body	string	true	"账号收到的验证码"
+type ConfirmMessengerRequest struct {
+	Provider string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,oneof=telegram whatsapp"`
+	Handle   string `json:"handle" xml:"handle" form:"handle" query:"handle" validate:"required,max=32"`
+	Code     string `json:"code" xml:"code" form:"code" query:"code" validate:"required"`
+}
+
+// UnlinkMessengerRequest    解除绑定即时通讯账号请求体
+// @Description	解除绑定所需参数
+// @Param			provider	body	string	true	"即时通讯服务，可选值: telegram, whatsapp"
+type UnlinkMessengerRequest struct {
+	Provider string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,oneof=telegram whatsapp"`
+}
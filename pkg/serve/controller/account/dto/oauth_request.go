@@ -0,0 +1,19 @@
+package dto
+
+// OAuthLoginRequest    发起第三方登录请求参数
+// @Description	跳转到第三方授权页所需参数
+// @Param			provider	path	string	true	"第三方登录服务，可选值: github, google, gitee, oidc"
+type OAuthLoginRequest struct {
+	Provider string `param:"provider" validate:"required,oneof=github google gitee oidc"`
+}
+
+// OAuthCallbackRequest    第三方登录回调请求参数
+// @Description	第三方授权完成后回调携带的参数
+// @Param			provider	path	string	true	"第三方登录服务，可选值: github, google, gitee, oidc"
+// @Param			code		query	string	true	"授权码"
+// @Param			state		query	string	true	"发起授权时生成的 state"
+type OAuthCallbackRequest struct {
+	Provider string `param:"provider" validate:"required,oneof=github google gitee oidc"`
+	Code     string `query:"code" validate:"required"`
+	State    string `query:"state" validate:"required"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+// FinishPasskeyRegistrationRequest    完成通行密钥注册请求体
+// @Description	浏览器创建凭证后返回的数据，二进制字段均为 Base64URL 编码
+// @Param			name				body	string	false	"通行密钥名称，便于区分设备，留空时使用默认名称"
+// @Param			credential_id		body	string	true	"凭证 ID"
+// @Param			client_data_json	body	string	true	"response.clientDataJSON"
+// @Param			attestation_object	body	string	true	"response.attestationObject"
+type FinishPasskeyRegistrationRequest struct {
+	Name              string `json:"name" xml:"name" form:"name" query:"name" validate:"omitempty,max=64"`
+	CredentialID      string `json:"credential_id" xml:"credential_id" form:"credential_id" query:"credential_id" validate:"required,base64rawurl,max=512"`
+	ClientDataJSON    string `json:"client_data_json" xml:"client_data_json" form:"client_data_json" query:"client_data_json" validate:"required,base64rawurl"`
+	AttestationObject string `json:"attestation_object" xml:"attestation_object" form:"attestation_object" query:"attestation_object" validate:"required,base64rawurl"`
+}
+
+// FinishPasskeyLoginRequest    完成通行密钥登录请求体
+// @Description	浏览器使用凭证签名后返回的数据，二进制字段均为 Base64URL 编码
+// @Param			credential_id		body	string	true	"凭证 ID"
+// @Param			client_data_json	body	string	true	"response.clientDataJSON"
+// @Param			authenticator_data	body	string	true	"response.authenticatorData"
+// @Param			signature			body	string	true	"response.signature"
+type FinishPasskeyLoginRequest struct {
+	CredentialID      string `json:"credential_id" xml:"credential_id" form:"credential_id" query:"credential_id" validate:"required,base64rawurl,max=512"`
+	ClientDataJSON    string `json:"client_data_json" xml:"client_data_json" form:"client_data_json" query:"client_data_json" validate:"required,base64rawurl"`
+	AuthenticatorData string `json:"authenticator_data" xml:"authenticator_data" form:"authenticator_data" query:"authenticator_data" validate:"required,base64rawurl"`
+	Signature         string `json:"signature" xml:"signature" form:"signature" query:"signature" validate:"required,base64rawurl"`
+}
+
+// DeletePasskeyRequest    删除通行密钥请求体
+// @Description	删除当前账户绑定的通行密钥
+// @Param			id	body	int64	true	"通行密钥 ID"
+type DeletePasskeyRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+// ForgotPasswordRequest    忘记密码请求体
+// @Description	向账户邮箱发送重置密码链接，邮箱未注册时同样返回成功
+// @Param			email					body	string	true	"用户邮箱"
+// @Param			img_verification_code	body	string	false	"图形验证码，提交 img_verification_ticket 时可省略"
+// @Param			img_verification_ticket	body	string	false	"图形验证码换取的验证凭证"
+// @Param			verification_nonce		body	string	false	"获取验证码时下发的客户端随机数"
+type ForgotPasswordRequest struct {
+	Email                 string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	ImgVerificationCode   string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required_without=ImgVerificationTicket"`
+	ImgVerificationTicket string `json:"img_verification_ticket" xml:"img_verification_ticket" form:"img_verification_ticket" query:"img_verification_ticket"`
+	VerificationNonce     string `json:"verification_nonce" xml:"verification_nonce" form:"verification_nonce" query:"verification_nonce"`
+}
+
+// CheckPasswordResetLinkRequest    校验重置密码链接请求体
+// @Description	前端打开重置密码页面时校验链接是否有效
+// @Param			token	body	string	true	"重置密码链接中的 token 参数"
+type CheckPasswordResetLinkRequest struct {
+	Token string `json:"token" xml:"token" form:"token" query:"token" validate:"required,len=64,hexadecimal"`
+}
+
+// ResetPasswordByLinkRequest    通过链接重置密码请求体
+// @Description	使用重置密码链接设置新密码，链接只能使用一次
+// @Param			token				body	string	true	"重置密码链接中的 token 参数"
+// @Param			new_password		body	string	true	"新密码"
+// @Param			again_new_password	body	string	true	"再次输入新密码"
+type ResetPasswordByLinkRequest struct {
+	Token            string `json:"token" xml:"token" form:"token" query:"token" validate:"required,len=64,hexadecimal"`
+	NewPassword      string `json:"new_password" xml:"new_password" form:"new_password" query:"new_password" validate:"required"`
+	AgainNewPassword string `json:"again_new_password" xml:"again_new_password" form:"again_new_password" query:"again_new_password" validate:"required,eqfield=NewPassword"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+// UpdateProfileRequest    更新个人资料请求体，未传的字段保持不变，传空值时清除
+// @Description	更新当前用户公开资料所需参数
+// @Param			nickname		body	string				false	"昵称"
+// @Param			display_name	body	string				false	"公开资料中展示的名称"
+// @Param			bio				body	string				false	"个人简介"
+// @Param			website			body	string				false	"个人网站"
+// @Param			social_links	body	map[string]string	false	"社交链接，键可选值: github, gitee, twitter, weibo, zhihu, bilibili, linkedin, mastodon，传入时替换全部社交链接"
+type UpdateProfileRequest struct {
+	Nickname    *string           `json:"nickname" xml:"nickname" form:"nickname" query:"nickname" validate:"omitempty,min=1,max=64"`
+	DisplayName *string           `json:"display_name" xml:"display_name" form:"display_name" query:"display_name" validate:"omitempty,max=64"`
+	Bio         *string           `json:"bio" xml:"bio" form:"bio" query:"bio" validate:"omitempty,max=512"`
+	Website     *string           `json:"website" xml:"website" form:"website" query:"website" validate:"omitempty,max=255,len=0|http_url"`
+	SocialLinks map[string]string `json:"social_links" xml:"social_links" form:"social_links" query:"social_links" validate:"omitempty,max=8,dive,keys,oneof=github gitee twitter weibo zhihu bilibili linkedin mastodon,endkeys,omitempty,max=255,http_url"`
+}
+
+// UploadAvatarRequest    上传头像请求体，图片以 multipart 表单的 avatar 字段上传
+// @Description	上传头像时的裁剪区域，未传 crop_size 时从图片中心裁剪最大的正方形
+// @Param			crop_x		formData	int	false	"裁剪区域左上角的横坐标"
+// @Param			crop_y		formData	int	false	"裁剪区域左上角的纵坐标"
+// @Param			crop_size	formData	int	false	"裁剪区域的边长"
+type UploadAvatarRequest struct {
+	CropX    int `json:"crop_x" xml:"crop_x" form:"crop_x" query:"crop_x" validate:"min=0"`
+	CropY    int `json:"crop_y" xml:"crop_y" form:"crop_y" query:"crop_y" validate:"min=0"`
+	CropSize int `json:"crop_size" xml:"crop_size" form:"crop_size" query:"crop_size" validate:"min=0"`
+}
+
+// GetProfileRequest    获取公开资料请求参数
+// @Param	id	path	int	true	"账户 ID"
+type GetProfileRequest struct {
+	ID int64 `param:"id" validate:"required,min=1"`
+}
+
+// FallbackAvatarRequest    获取默认头像请求参数
+// @Param	hash	path	string	true	"邮箱的 MD5 或 SHA-256 哈希"
+// @Param	size	query	int		false	"头像边长，取值 32 至 512，默认 80"
+// @Param	s		query	int		false	"头像边长，与 Gravatar 兼容的写法，同时传入时以 size 为准"
+type FallbackAvatarRequest struct {
+	Hash string `param:"hash" validate:"required"`
+	Size int    `query:"size" validate:"min=0"`
+	S    int    `query:"s" validate:"min=0"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// RefreshTokenRequest    刷新令牌请求体
+// @Description	使用 refresh token 换取新的令牌，每个 refresh token 只能使用一次
+// @Param			refresh_token	body	string	true	"登录或上次刷新时返回的 refresh token"
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" xml:"refresh_token" form:"refresh_token" query:"refresh_token" validate:"required"`
+}
@@ -6,13 +6,21 @@ package dto
 // @Param			phone		body	string	true	"用户手机号"
 // @Param			nickname	body	string	true	"用户昵称"
 // @Param			password	body	string	true	"用户密码"
-// @Param			email_verification_code	body	string	true	"用户邮箱验证码"
-// @Param			img_verification_code	body	string	true	"用户图片验证码"
+// @Param			email_verification_code	body	string	false	"用户邮箱验证码，提交 email_verification_ticket 时可省略"
+// @Param			img_verification_code	body	string	false	"用户图片验证码，使用托管人机验证时为验证令牌，提交 img_verification_ticket 时可省略"
+// @Param			email_verification_ticket	body	string	false	"邮箱验证码换取的验证凭证"
+// @Param			img_verification_ticket	body	string	false	"图片验证码换取的验证凭证"
+// @Param			verification_nonce	body	string	false	"获取验证码时下发的客户端随机数"
+// @Param			invite_code	body	string	false	"邀请码，站点开启邀请注册时必填"
 type RegisterRequest struct {
-	Email                 string `json:"email" xml:"email" form:"email" query:"email" validate:"required"`
-	Phone                 string `json:"phone" xml:"phone" form:"phone" query:"phone" default:""`
-	Nickname              string `json:"nickname" xml:"nickname" form:"nickname" query:"nickname" validate:"required,min=1,max=20"`
-	Password              string `json:"password" xml:"password" form:"password" query:"password" validate:"required,min=6,max=20"`
-	EmailVerificationCode string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required"`
-	ImgVerificationCode   string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required"`
+	Email                   string `json:"email" xml:"email" form:"email" query:"email" validate:"required"`
+	Phone                   string `json:"phone" xml:"phone" form:"phone" query:"phone" default:""`
+	Nickname                string `json:"nickname" xml:"nickname" form:"nickname" query:"nickname" validate:"required,min=1,max=20"`
+	Password                string `json:"password" xml:"password" form:"password" query:"password" validate:"required"`
+	EmailVerificationCode   string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required_without=EmailVerificationTicket"`
+	ImgVerificationCode     string `json:"img_verification_code" xml:"img_verification_code" form:"img_verification_code" query:"img_verification_code" validate:"required_without=ImgVerificationTicket"`
+	EmailVerificationTicket string `json:"email_verification_ticket" xml:"email_verification_ticket" form:"email_verification_ticket" query:"email_verification_ticket"`
+	ImgVerificationTicket   string `json:"img_verification_ticket" xml:"img_verification_ticket" form:"img_verification_ticket" query:"img_verification_ticket"`
+	VerificationNonce       string `json:"verification_nonce" xml:"verification_nonce" form:"verification_nonce" query:"verification_nonce"`
+	InviteCode              string `json:"invite_code" xml:"invite_code" form:"invite_code" query:"invite_code" validate:"max=32"`
 }
@@ -0,0 +1,24 @@
+package dto
+
+// RememberDeviceRequest    开启记住我请求体
+// @Description	为当前会话所在的设备签发设备令牌
+// @Param			device_id	body	string	true	"客户端生成并持久保存的设备标识，建议为随机值，换取 token 时需提交相同的值"
+type RememberDeviceRequest struct {
+	DeviceID string `json:"device_id" xml:"device_id" form:"device_id" query:"device_id" validate:"required,min=16,max=128"`
+}
+
+// ExchangeDeviceTokenRequest    使用设备令牌换取 token 请求体
+// @Description	会话结束后使用设备令牌免登录换取新的 token
+// @Param			device_token	body	string	true	"开启记住我或上次换取 token 时返回的设备令牌"
+// @Param			device_id		body	string	true	"开启记住我时提交的设备标识"
+type ExchangeDeviceTokenRequest struct {
+	DeviceToken string `json:"device_token" xml:"device_token" form:"device_token" query:"device_token" validate:"required,max=128"`
+	DeviceID    string `json:"device_id" xml:"device_id" form:"device_id" query:"device_id" validate:"required,min=16,max=128"`
+}
+
+// RevokeDeviceTokenRequest    删除设备令牌请求体
+// @Description	删除当前账户的设备令牌
+// @Param			id	body	int64	true	"设备令牌 ID"
+type RevokeDeviceTokenRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
@@ -2,13 +2,19 @@ package dto
 
 // ResetPwdRequest  重置密码请求体
 // @Description	用户重置密码所需参数
-// @Param			email					body	string	true	"用户邮箱"
+// @Param			email					body	string	false	"用户邮箱，以当前登录用户的邮箱为准，传入时须与之一致"
 // @Param			new_password			body	string	true	"新密码"
 // @Param			again_new_password		body	string	true	"再次输入新密码"
-// @Param			email_verification_code	body	string	true	"邮箱验证码"
+// @Param			email_verification_code	body	string	false	"邮箱验证码，提交 email_verification_ticket 或 totp_code 时可省略"
+// @Param			email_verification_ticket	body	string	false	"邮箱验证码或 TOTP 动态码换取的验证凭证"
+// @Param			totp_code	body	string	false	"TOTP 动态码，开启两步验证的账户可代替邮箱验证码"
+// @Param			verification_nonce	body	string	false	"获取验证码时下发的客户端随机数"
 type ResetPwdRequest struct {
-	Email                 string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
-	NewPassword           string `json:"new_password" xml:"new_password" form:"new_password" query:"new_password" validate:"required,min=6,max=20"`
-	AgainNewPassword      string `json:"again_new_password" xml:"again_new_password" form:"again_new_password" query:"again_new_password" validate:"required,min=6,max=20"`
-	EmailVerificationCode string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required"`
+	Email                   string `json:"email" xml:"email" form:"email" query:"email" validate:"omitempty,email"`
+	NewPassword             string `json:"new_password" xml:"new_password" form:"new_password" query:"new_password" validate:"required"`
+	AgainNewPassword        string `json:"again_new_password" xml:"again_new_password" form:"again_new_password" query:"again_new_password" validate:"required"`
+	EmailVerificationCode   string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required_without_all=EmailVerificationTicket TotpCode"`
+	EmailVerificationTicket string `json:"email_verification_ticket" xml:"email_verification_ticket" form:"email_verification_ticket" query:"email_verification_ticket"`
+	TotpCode                string `json:"totp_code" xml:"totp_code" form:"totp_code" query:"totp_code" validate:"omitempty,len=6,numeric"`
+	VerificationNonce       string `json:"verification_nonce" xml:"verification_nonce" form:"verification_nonce" query:"verification_nonce"`
 }
@@ -0,0 +1,8 @@
+package dto
+
+// RevokeSessionRequest    移除会话请求体
+// @Description	移除当前账户的单个登录会话，被移除的设备需重新登录
+// @Param			session_id	body	string	true	"会话 ID"
+type RevokeSessionRequest struct {
+	SessionID string `json:"session_id" xml:"session_id" form:"session_id" query:"session_id" validate:"required,len=32,hexadecimal"`
+}
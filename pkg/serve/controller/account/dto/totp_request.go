@@ -0,0 +1,41 @@
+package dto
+
+// EnableTotpRequest    开启两步验证请求体
+// @Description	绑定验证器后提交动态码以开启两步验证
+// @Param			totp_code	body	string	true	"验证器显示的 6 位动态码"
+type EnableTotpRequest struct {
+	TotpCode string `json:"totp_code" xml:"totp_code" form:"totp_code" query:"totp_code" validate:"required,len=6,numeric"`
+}
+
+// DisableTotpRequest    关闭两步验证请求体
+// @Description	关闭两步验证所需参数，需提交 TOTP 动态码或邮箱验证码之一
+// @Param			email						body	string	true	"用户邮箱"
+// @Param			totp_code					body	string	false	"TOTP 动态码"
+// @Param			email_verification_code		body	string	false	"邮箱验证码，验证器丢失时使用"
+// @Param			email_verification_ticket	body	string	false	"邮箱验证码或 TOTP 动态码换取的验证凭证"
+// @Param			verification_nonce			body	string	false	"获取验证码时下发的客户端随机数"
+type DisableTotpRequest struct {
+	Email                   string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	TotpCode                string `json:"totp_code" xml:"totp_code" form:"totp_code" query:"totp_code" validate:"omitempty,len=6,numeric"`
+	EmailVerificationCode   string `json:"email_verification_code" xml:"email_verification_code" form:"email_verification_code" query:"email_verification_code" validate:"required_without_all=EmailVerificationTicket TotpCode"`
+	EmailVerificationTicket string `json:"email_verification_ticket" xml:"email_verification_ticket" form:"email_verification_ticket" query:"email_verification_ticket"`
+	VerificationNonce       string `json:"verification_nonce" xml:"verification_nonce" form:"verification_nonce" query:"verification_nonce"`
+}
+
+// VerifyTotpLoginRequest    提交两步验证完成登录请求体
+// @Description	密码或第三方登录返回 totp_ticket 后提交动态码或恢复码之一
+// @Param			totp_ticket		body	string	true	"登录时返回的两步验证凭证"
+// @Param			totp_code		body	string	false	"验证器显示的 6 位动态码"
+// @Param			recovery_code	body	string	false	"恢复码，验证器丢失时使用"
+type VerifyTotpLoginRequest struct {
+	TotpTicket   string `json:"totp_ticket" xml:"totp_ticket" form:"totp_ticket" query:"totp_ticket" validate:"required,hexadecimal,len=32"`
+	TotpCode     string `json:"totp_code" xml:"totp_code" form:"totp_code" query:"totp_code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" xml:"recovery_code" form:"recovery_code" query:"recovery_code" validate:"omitempty,max=32"`
+}
+
+// RegenerateRecoveryCodesRequest    重新生成恢复码请求体
+// @Description	重新生成恢复码需提交验证器显示的动态码
+// @Param			totp_code	body	string	true	"验证器显示的 6 位动态码"
+type RegenerateRecoveryCodesRequest struct {
+	TotpCode string `json:"totp_code" xml:"totp_code" form:"totp_code" query:"totp_code" validate:"required,len=6,numeric"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// UpdatePreferencesRequest    更新偏好设置请求参数结构体，未传的字段保持不变
+// @Param	reading_history	body	bool	false	"是否记录阅读历史"
+// @Param	login_alert		body	bool	false	"是否接收新设备登录提醒邮件"
+type UpdatePreferencesRequest struct {
+	ReadingHistory *bool `json:"reading_history" xml:"reading_history" form:"reading_history" query:"reading_history"`
+	LoginAlert     *bool `json:"login_alert" xml:"login_alert" form:"login_alert" query:"login_alert"`
+}
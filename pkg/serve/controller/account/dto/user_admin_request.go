@@ -0,0 +1,86 @@
+package dto
+
+// ListUsersRequest    用户列表筛选参数，分页参数见 page、pageSize 与 cursor
+// @Param	keyword	query	string	false	"模糊匹配邮箱、手机号、昵称与展示名称"
+// @Param	role	query	string	false	"角色编码"
+// @Param	status	query	string	false	"账户状态，可选值: active, disabled, pending_deletion, pending_approval, rejected"
+type ListUsersRequest struct {
+	Keyword string `json:"keyword" xml:"keyword" form:"keyword" query:"keyword" validate:"max=64"`
+	Role    string `json:"role" xml:"role" form:"role" query:"role" validate:"max=32"`
+	Status  string `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=active disabled pending_deletion pending_approval rejected"`
+}
+
+// AdminUserRequest    指定用户的请求参数
+// @Param	id	body	int64	true	"账户 ID"
+type AdminUserRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,min=1"`
+}
+
+// AdminResetPasswordRequest    管理员重置用户密码请求体
+// @Description	为用户设置新密码，账户的全部设备随即下线
+// @Param	id				body	int64	true	"账户 ID"
+// @Param	new_password	body	string	true	"新密码，需满足密码强度策略"
+type AdminResetPasswordRequest struct {
+	ID          int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,min=1"`
+	NewPassword string `json:"new_password" xml:"new_password" form:"new_password" query:"new_password" validate:"required"`
+}
+
+// ChangeUserRoleRequest    变更用户角色请求体
+// @Param	id			body	int64	true	"账户 ID"
+// @Param	role_code	body	string	true	"新角色编码"
+type ChangeUserRoleRequest struct {
+	ID       int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,min=1"`
+	RoleCode string `json:"role_code" xml:"role_code" form:"role_code" query:"role_code" validate:"required,max=32"`
+}
+
+// DisableUserRequest    停用用户请求体
+// @Param	id				body	int64	true	"账户 ID"
+// @Param	reason			body	string	false	"停用原因，用户登录时可见"
+// @Param	duration_hours	body	int		false	"停用时长（小时），到期后自动解除，不传或为 0 时永久停用"
+type DisableUserRequest struct {
+	ID            int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,min=1"`
+	Reason        string `json:"reason" xml:"reason" form:"reason" query:"reason" validate:"max=255"`
+	DurationHours int    `json:"duration_hours" xml:"duration_hours" form:"duration_hours" query:"duration_hours" validate:"min=0,max=87600"`
+}
+
+// EnableUserRequest    启用用户请求体
+// @Param	id		body	int64	true	"账户 ID"
+// @Param	reason	body	string	false	"解除停用的原因，记入停用记录"
+type EnableUserRequest struct {
+	ID     int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,min=1"`
+	Reason string `json:"reason" xml:"reason" form:"reason" query:"reason" validate:"max=255"`
+}
+
+// UpdateBanAppealRequest    记录停用申诉请求体
+// @Param	id			body	int64	true	"停用记录 ID"
+// @Param	appeal_note	body	string	true	"用户的申诉内容与处理情况，覆盖原有备注"
+type UpdateBanAppealRequest struct {
+	ID         int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,min=1"`
+	AppealNote string `json:"appeal_note" xml:"appeal_note" form:"appeal_note" query:"appeal_note" validate:"required,max=2000"`
+}
+
+// RejectUserRequest    拒绝注册请求体
+// @Param	id		body	int64	true	"账户 ID"
+// @Param	reason	body	string	false	"拒绝原因，用户登录时可见"
+type RejectUserRequest struct {
+	ID     int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,min=1"`
+	Reason string `json:"reason" xml:"reason" form:"reason" query:"reason" validate:"max=255"`
+}
+
+// UpdateRegistrationRequest    修改注册设置请求体
+// @Param	mode			body	string	true	"注册方式，可选值: open, approval, closed"
+// @Param	notify_admins	body	bool	false	"有新注册待审核时是否邮件通知管理员，不传时保持不变"
+type UpdateRegistrationRequest struct {
+	Mode         string `json:"mode" xml:"mode" form:"mode" query:"mode" validate:"required,oneof=open approval closed"`
+	NotifyAdmins *bool  `json:"notify_admins" xml:"notify_admins" form:"notify_admins" query:"notify_admins"`
+}
+
+// ListAdminAuditLogsRequest    管理员操作审计日志筛选参数，分页参数见 page、pageSize 与 cursor
+// @Param	target_id	query	int64	false	"被操作的账户 ID"
+// @Param	operator_id	query	int64	false	"执行操作的管理员账户 ID"
+// @Param	action		query	string	false	"操作，可选值: reset_password, force_logout, change_role, disable, enable, ban_appeal, approve, reject, registration"
+type ListAdminAuditLogsRequest struct {
+	TargetID   int64  `json:"target_id" xml:"target_id" form:"target_id" query:"target_id" validate:"min=0"`
+	OperatorID int64  `json:"operator_id" xml:"operator_id" form:"operator_id" query:"operator_id" validate:"min=0"`
+	Action     string `json:"action" xml:"action" form:"action" query:"action" validate:"omitempty,oneof=reset_password force_logout change_role disable enable ban_appeal approve reject registration"`
+}
@@ -0,0 +1,130 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// CreateInvite godoc
+// @Summary      创建邀请码
+// @Description  创建邀请码，可指定可使用的次数与有效天数；管理员不受限制，普通用户需站点开启 INVITE_USER_ENABLED，且使用次数、有效天数与可用邀请码数量受配置限制
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateInviteRequest  true  "邀请码信息"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.InviteCodeVo}  "创建成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有权限创建该邀请码，或使用 API Key 调用"
+// @Failure      409     {object}   vo.Result  "可用的邀请码数量已达上限"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/invite/createInvite [post]
+func CreateInvite(c echo.Context) error {
+	req := new(dto.CreateInviteRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	invite, err := service.CreateInvite(req, c)
+	switch {
+	case errors.Is(err, service.ErrInviteCreateDenied):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.InviteCreateDenied), c))
+	case errors.Is(err, service.ErrInviteLimitExceeded):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.InviteLimitExceeded), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(invite, c))
+}
+
+// ListInvites godoc
+// @Summary      获取邀请码列表
+// @Description  分页获取邀请码及使用情况，按创建时间倒序排列；普通用户获取自己创建的邀请码，管理员获取全部邀请码，可按创建者筛选
+// @Tags         账户
+// @Produce      json
+// @Param        creator_id  query    int     false  "创建者的账户 ID，仅管理员可用"
+// @Param        page        query    int     false  "页码"
+// @Param        pageSize    query    int     false  "每页显示数量，最大 100"
+// @Param        cursor      query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]account.InviteCodeVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /account/invite/listInvites [get]
+func ListInvites(c echo.Context) error {
+	req := new(dto.ListInvitesRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	invites, meta, err := service.ListInvites(req, vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(invites, meta, c))
+}
+
+// RevokeInvite godoc
+// @Summary      撤销邀请码
+// @Description  撤销邀请码，撤销后不能再用于注册，已注册的账户不受影响；普通用户只能撤销自己创建的邀请码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RevokeInviteRequest  true  "邀请码 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=string}  "撤销成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      404     {object}   vo.Result  "邀请码不存在"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/invite/revokeInvite [post]
+func RevokeInvite(c echo.Context) error {
+	req := new(dto.RevokeInviteRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.RevokeInvite(req, c)
+	if errors.Is(err, service.ErrInviteNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.InviteNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("邀请码已撤销", c))
+}
+
+// CheckInvite godoc
+// @Summary      校验邀请码
+// @Description  返回注册是否需要邀请码，以及传入的邀请码是否可用于注册，供注册页面展示邀请码输入框并提前校验，无需登录
+// @Tags         账户
+// @Produce      json
+// @Param        code  query  string  false  "邀请码"
+// @Success      200  {object}  vo.Result{data=account.InviteCheckVo}  "校验完成"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /account/invite/checkInvite [get]
+func CheckInvite(c echo.Context) error {
+	req := new(dto.CheckInviteRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	result, err := service.CheckInvite(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(result, c))
+}
@@ -0,0 +1,67 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// LdapLogin godoc
+// @Summary      LDAP 登录
+// @Description  使用目录服务的账号登录，首次登录时按目录中的邮箱关联已有账户，邮箱未注册时自动创建账户，并按所属组同步角色；返回与密码登录相同的 token，账户开启两步验证时返回 totp_ticket；同一用户名或同一 IP 连续登录失败达到阈值后暂停登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.LdapLoginRequest  true  "目录账号"
+// @Success      200     {object}   vo.Result{data=account.LoginVo}  "登录成功，返回访问令牌或两步验证凭证"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      401     {object}   vo.Result  "用户名或密码错误"
+// @Failure      403     {object}   vo.Result{data=account.AccountDisabledVo}  "目录中未填写邮箱，邮箱未注册且未开启自动创建账户，或账户已被停用、待审核或未通过注册审核"
+// @Failure      423     {object}   vo.Result{data=verification.RetryAfterVo}  "登录失败次数过多，用户名或 IP 已被锁定"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Failure      502     {object}   vo.Result  "请求目录服务失败"
+// @Failure      503     {object}   vo.Result  "未开启 LDAP 登录"
+// @Router       /account/ldapLogin [post]
+func LdapLogin(c echo.Context) error {
+	req := new(dto.LdapLoginRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	// 目录用户名与本站邮箱分开计数，锁定时不发送解锁邮件
+	lockKey := "ldap:" + req.Username
+	if err := verification.CheckLoginLock(lockKey, c); err != nil {
+		return verification.LoginLockFailResponse(err, c)
+	}
+
+	response, err := service.LdapLogin(req, c)
+	var disabled *service.AccountDisabledError
+	switch {
+	case errors.As(err, &disabled):
+		return accountDisabledResponse(disabled, c)
+	case errors.Is(err, service.ErrLdapDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.LdapDisabled), c))
+	case errors.Is(err, service.ErrLdapInvalidCredentials):
+		if err := verification.RecordLoginFailure(lockKey, false, c); err != nil {
+			return verification.LoginLockFailResponse(err, c)
+		}
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.LdapInvalidCredentials), c))
+	case errors.Is(err, service.ErrLdapAccountUnavailable):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.LdapAccountUnavailable), c))
+	case errors.Is(err, service.ErrLdapLoginFail):
+		return c.JSON(http.StatusBadGateway, vo.Fail(err.Error(), bizErr.New(bizErr.LdapLoginFail), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+	verification.ClearLoginFailures(lockKey)
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
@@ -0,0 +1,33 @@
+package account
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetLoginHistory godoc
+// @Summary      获取登录记录
+// @Description  分页获取当前账户成功与失败的登录记录，包含时间、IP、地理位置、设备与登录方式，按时间倒序排列
+// @Tags         账户
+// @Produce      json
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]account.LoginRecordVo,page=vo.PageMeta}  "获取成功"
+// @Failure      403  {object}  vo.Result                 "使用 API Key 调用"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /account/loginHistory [get]
+func GetLoginHistory(c echo.Context) error {
+	records, meta, err := service.LoginHistory(vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(records, meta, c))
+}
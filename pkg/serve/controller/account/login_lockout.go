@@ -0,0 +1,63 @@
+package account
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// UnlockAccount godoc
+// @Summary      通过链接解锁账户
+// @Description  账户因登录失败次数过多被锁定时，使用锁定通知邮件中的链接立即解除锁定，链接随即失效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlockAccountRequest  true  "链接中的 token"
+// @Success      200     {object}   vo.Result  "账户已解锁"
+// @Failure      400     {object}   vo.Result  "请求参数错误，或链接无效、已过期、已使用"
+// @Router       /account/unlockAccount [post]
+func UnlockAccount(c echo.Context) error {
+	req := new(dto.UnlockAccountRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	email, err := verification.UnlockAccountByLink(req.Token)
+	if err != nil {
+		return verification.LoginLockFailResponse(err, c)
+	}
+	utils.BizLogger(c).Infof("「%s」通过邮件链接解除登录锁定", email)
+
+	return c.JSON(http.StatusOK, vo.Success("账户已解锁，请重新登录！", c))
+}
+
+// UnlockLogin godoc
+// @Summary      解除登录锁定
+// @Description  管理员解除账户的登录锁定并清零失败次数，或解除某个 IP 的登录暂停
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlockLoginRequest  true  "账户邮箱或 IP"
+// @Success      200     {object}   vo.Result  "已解除锁定"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      401     {object}   vo.Result  "未登录"
+// @Failure      403     {object}   vo.Result  "无管理员权限"
+// @Security     BearerAuth
+// @Router       /account/unlockLogin [post]
+func UnlockLogin(c echo.Context) error {
+	req := new(dto.UnlockLoginRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	verification.UnlockLogin(req.Email, req.IP)
+	utils.BizLogger(c).Infof("管理员解除登录锁定，邮箱: %s, IP: %s", req.Email, req.IP)
+
+	return c.JSON(http.StatusOK, vo.Success("已解除登录锁定", c))
+}
@@ -0,0 +1,154 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// SendMagicLink godoc
+// @Summary      发送免密登录链接
+// @Description  校验图形验证码后向账户邮箱发送一次性登录链接，返回发起请求的设备需保存的请求 ID；邮箱未注册时同样返回成功
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SendMagicLinkRequest  true  "邮箱与图形验证码"
+// @Success      200     {object}   vo.Result{data=account.MagicLinkSentVo}  "已发送登录链接"
+// @Failure      400     {object}   vo.Result  "请求参数错误或图形验证码校验失败"
+// @Failure      429     {object}   vo.Result{data=verification.RetryAfterVo}  "发送过于频繁"
+// @Failure      500     {object}   vo.Result  "登录链接邮件发送失败"
+// @Failure      503     {object}   vo.Result  "免密登录未开启或邮件服务熔断中"
+// @Router       /account/magicLink/sendMagicLink [post]
+func SendMagicLink(c echo.Context) error {
+	req := new(dto.SendMagicLinkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+	if !verification.MagicLinkEnabled() {
+		return verification.MagicLinkFailResponse(verification.ErrMagicLinkDisabled, c)
+	}
+
+	if err := checkImgCode(req.ImgVerificationCode, req.ImgVerificationTicket, req.Email, req.VerificationNonce, c); err != nil {
+		return codeFailResponse(err, bizErr.SendImgVerificationCodeFail, "图形验证码校验失败", c)
+	}
+
+	requestID, err := verification.SendMagicLink(req.Email, service.EmailRegistered(req.Email), c)
+	if err != nil {
+		return verification.MagicLinkFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(service.MagicLinkSent(requestID), c))
+}
+
+// LoginByMagicLink godoc
+// @Summary      通过免密登录链接登录
+// @Description  在发起请求的设备上打开链接时提交 token 与 request_id 直接登录；在其他设备上打开时返回 409 与发起请求的设备信息，需调用确认接口
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.LoginByMagicLinkRequest  true  "链接中的 token 与请求 ID"
+// @Success      200     {object}   vo.Result{data=account.LoginVo}  "登录成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误，或链接无效、已过期、已使用"
+// @Failure      403     {object}   vo.Result{data=account.AccountDisabledVo}  "账户已被停用、待审核或未通过注册审核"
+// @Failure      409     {object}   vo.Result{data=account.MagicLinkRequestVo}  "不是在发起请求的设备上打开链接，需确认登录"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Failure      503     {object}   vo.Result  "免密登录未开启"
+// @Router       /account/magicLink/loginByMagicLink [post]
+func LoginByMagicLink(c echo.Context) error {
+	req := new(dto.LoginByMagicLinkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	email, err := verification.ConsumeMagicLink(req.Token, req.RequestID)
+	if errors.Is(err, verification.ErrMagicLinkDeviceMismatch) {
+		info, checkErr := verification.CheckMagicLink(req.Token)
+		if checkErr != nil {
+			return verification.MagicLinkFailResponse(checkErr, c)
+		}
+		request := service.MagicLinkRequest(info.Email, info.Device, info.IP, info.ExpiresAt.Unix())
+		return c.JSON(http.StatusConflict, vo.Fail(request, bizErr.New(bizErr.MagicLinkDeviceMismatch), c))
+	}
+	if err != nil {
+		return verification.MagicLinkFailResponse(err, c)
+	}
+
+	response, err := service.MagicLinkLogin(email, c)
+	var disabled *service.AccountDisabledError
+	if errors.As(err, &disabled) {
+		return accountDisabledResponse(disabled, c)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
+
+// ConfirmMagicLink godoc
+// @Summary      确认免密登录
+// @Description  在其他设备上打开链接时确认登录，链接随即失效，发起请求的设备可通过轮询接口登录，确认的设备不会登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ConfirmMagicLinkRequest  true  "链接中的 token"
+// @Success      200     {object}   vo.Result{data=account.MagicLinkRequestVo}  "已确认"
+// @Failure      400     {object}   vo.Result  "请求参数错误，或链接无效、已过期、已使用"
+// @Failure      503     {object}   vo.Result  "免密登录未开启"
+// @Router       /account/magicLink/confirmMagicLink [post]
+func ConfirmMagicLink(c echo.Context) error {
+	req := new(dto.ConfirmMagicLinkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	info, err := verification.ApproveMagicLink(req.Token)
+	if err != nil {
+		return verification.MagicLinkFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(service.MagicLinkRequest(info.Email, info.Device, info.IP, info.ExpiresAt.Unix()), c))
+}
+
+// PollMagicLink godoc
+// @Summary      查询免密登录确认结果
+// @Description  发起请求的设备轮询登录请求是否已在其他设备上确认，确认后返回登录信息，结果只能领取一次
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.PollMagicLinkRequest  true  "请求 ID"
+// @Success      200     {object}   vo.Result{data=account.MagicLinkPollVo}  "查询成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result{data=account.AccountDisabledVo}  "账户已被停用、待审核或未通过注册审核"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Failure      503     {object}   vo.Result  "免密登录未开启"
+// @Router       /account/magicLink/pollMagicLink [post]
+func PollMagicLink(c echo.Context) error {
+	req := new(dto.PollMagicLinkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	email, approved, err := verification.ClaimMagicLinkApproval(req.RequestID)
+	if err != nil {
+		return verification.MagicLinkFailResponse(err, c)
+	}
+	response, err := service.MagicLinkPoll(email, approved, c)
+	var disabled *service.AccountDisabledError
+	if errors.As(err, &disabled) {
+		return accountDisabledResponse(disabled, c)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
@@ -0,0 +1,106 @@
+package account
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// LinkMessenger godoc
+// @Summary      绑定即时通讯账号
+// @Description  向待绑定的 Telegram 或 WhatsApp 账号发送验证码，3 分钟内提交验证码确认后完成绑定；Telegram 需先与机器人对话
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.LinkMessengerRequest  true  "即时通讯服务与账号"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "验证码发送成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或账号格式无效"
+// @Failure      429     {object}   vo.Result  "发送过于频繁"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误，验证码发送失败"
+// @Failure      503     {object}   vo.Result  "未开启该即时通讯服务"
+// @Router       /account/linkMessenger [post]
+func LinkMessenger(c echo.Context) error {
+	req := new(dto.LinkMessengerRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	accountID, err := service.CurrentAccountID(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+	if err := verification.SendMessengerLinkCode(accountID, req.Provider, req.Handle, c); err != nil {
+		return verification.MessengerFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("验证码发送成功, 请注意查收！", c))
+}
+
+// ConfirmMessenger godoc
+// @Summary      确认绑定即时通讯账号
+// @Description  提交待绑定账号收到的验证码，校验通过后完成绑定，之后可通过该账号接收验证码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ConfirmMessengerRequest  true  "待绑定账号与验证码"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "绑定成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或验证码校验失败"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/confirmMessenger [post]
+func ConfirmMessenger(c echo.Context) error {
+	req := new(dto.ConfirmMessengerRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	accountID, err := service.CurrentAccountID(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+	if err := verification.VerifyMessengerLinkCode(accountID, req.Provider, req.Handle, req.Code, c); err != nil {
+		return codeFailResponse(err, bizErr.BadRequest, "验证码校验失败", c)
+	}
+
+	if err := service.ConfirmMessenger(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("即时通讯账号绑定成功", c))
+}
+
+// UnlinkMessenger godoc
+// @Summary      解除绑定即时通讯账号
+// @Description  解除绑定 Telegram 或 WhatsApp 账号，解除后不再通过该账号接收验证码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlinkMessengerRequest  true  "即时通讯服务"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "解除绑定成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/unlinkMessenger [post]
+func UnlinkMessenger(c echo.Context) error {
+	req := new(dto.UnlinkMessengerRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.UnlinkMessenger(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("即时通讯账号已解除绑定", c))
+}
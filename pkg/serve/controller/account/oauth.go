@@ -0,0 +1,107 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/oauth"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// oauthStateCookie 保存 state 的 Cookie，回调时需与地址中的 state 一致，确保授权由当前浏览器发起
+const oauthStateCookie = "oauth_state"
+
+// OAuthLogin godoc
+// @Summary      第三方登录
+// @Description  跳转到 GitHub、Google、Gitee 或配置的 OpenID Connect 服务的授权页，授权完成后回调 /account/oauth/{provider}/callback
+// @Tags         账户
+// @Param        provider  path  string  true  "第三方登录服务，可选值: github, google, gitee, oidc"
+// @Success      302  "跳转到第三方授权页"
+// @Failure      400  {object}  vo.Result  "不支持的第三方登录服务"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Failure      503  {object}  vo.Result  "未开启该第三方登录"
+// @Router       /account/oauth/{provider}/login [get]
+func OAuthLogin(c echo.Context) error {
+	req := new(dto.OAuthLoginRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	authURL, state, err := service.OAuthLoginURL(req.Provider, c)
+	if errors.Is(err, oauth.ErrDisabled) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.OAuthDisabled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		Path:     "/",
+		Secure:   c.Scheme() == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Now().Add(service.OAuthStateExpiration),
+	})
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// OAuthCallback godoc
+// @Summary      第三方登录回调
+// @Description  校验 state 并使用授权码获取第三方账号，已关联的账号直接登录；未关联时按已验证的邮箱关联已有账户，邮箱未注册时自动注册，返回与密码登录相同的 token
+// @Tags         账户
+// @Produce      json
+// @Param        provider  path   string  true  "第三方登录服务，可选值: github, google, gitee, oidc"
+// @Param        code      query  string  true  "授权码"
+// @Param        state     query  string  true  "发起授权时生成的 state"
+// @Success      200  {object}  vo.Result{data=account.LoginVo}  "登录成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误、state 无效或第三方账号未提供已验证的邮箱"
+// @Failure      403  {object}  vo.Result{data=account.AccountDisabledVo}  "账户已被停用、待审核或未通过注册审核，站点已关闭注册，或开启了邀请注册且邮箱未注册"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Failure      502  {object}  vo.Result  "请求第三方登录服务失败"
+// @Failure      503  {object}  vo.Result  "未开启该第三方登录"
+// @Router       /account/oauth/{provider}/callback [get]
+func OAuthCallback(c echo.Context) error {
+	req := new(dto.OAuthCallbackRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	cookie, err := c.Cookie(oauthStateCookie)
+	if err != nil || cookie.Value != req.State {
+		utils.BizLogger(c).Error("第三方登录回调的 state 与 Cookie 不一致")
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.OAuthStateInvalid), c))
+	}
+	c.SetCookie(&http.Cookie{Name: oauthStateCookie, Path: "/", MaxAge: -1, HttpOnly: true})
+
+	response, err := service.OAuthCallback(req.Provider, req.Code, req.State, c)
+	var disabled *service.AccountDisabledError
+	switch {
+	case errors.As(err, &disabled):
+		return accountDisabledResponse(disabled, c)
+	case errors.Is(err, service.ErrInviteRequired):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.InviteRequired), c))
+	case errors.Is(err, service.ErrRegistrationClosed):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.RegistrationClosed), c))
+	case errors.Is(err, service.ErrOAuthStateInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.OAuthStateInvalid), c))
+	case errors.Is(err, service.ErrOAuthEmailUnavailable):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.OAuthEmailUnavailable), c))
+	case errors.Is(err, oauth.ErrDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.OAuthDisabled), c))
+	case errors.Is(err, service.ErrOAuthLoginFail):
+		return c.JSON(http.StatusBadGateway, vo.Fail(nil, bizErr.New(bizErr.OAuthLoginFail), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
@@ -0,0 +1,165 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// BeginPasskeyRegistration godoc
+// @Summary      发起通行密钥注册
+// @Description  生成注册通行密钥的参数，前端调用 navigator.credentials.create 创建凭证后提交到 /account/passkey/finishRegistration
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.PasskeyCreationVo}  "获取成功"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/passkey/beginRegistration [post]
+func BeginPasskeyRegistration(c echo.Context) error {
+	options, err := service.BeginPasskeyRegistration(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(options, c))
+}
+
+// FinishPasskeyRegistration godoc
+// @Summary      完成通行密钥注册
+// @Description  校验浏览器创建的凭证并绑定到当前账户，之后可使用通行密钥登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.FinishPasskeyRegistrationRequest  true  "创建的凭证"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.PasskeyVo}  "绑定成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或通行密钥验证失败"
+// @Failure      409     {object}   vo.Result  "通行密钥已绑定"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/passkey/finishRegistration [post]
+func FinishPasskeyRegistration(c echo.Context) error {
+	req := new(dto.FinishPasskeyRegistrationRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	passkey, err := service.FinishPasskeyRegistration(req, c)
+	switch {
+	case errors.Is(err, service.ErrPasskeyVerifyFail):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasskeyVerifyFail), c))
+	case errors.Is(err, service.ErrPasskeyDuplicate):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PasskeyDuplicate), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(passkey, c))
+}
+
+// BeginPasskeyLogin godoc
+// @Summary      发起通行密钥登录
+// @Description  生成通行密钥登录参数，前端调用 navigator.credentials.get 由用户选择通行密钥签名后提交到 /account/passkey/finishLogin
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=account.PasskeyRequestVo}  "获取成功"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/passkey/beginLogin [post]
+func BeginPasskeyLogin(c echo.Context) error {
+	options, err := service.BeginPasskeyLogin(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(options, c))
+}
+
+// FinishPasskeyLogin godoc
+// @Summary      完成通行密钥登录
+// @Description  校验通行密钥的签名与用户验证标志，登录通行密钥所属的账户，返回与密码登录相同的 token；认证器未验证用户身份时登录失败
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.FinishPasskeyLoginRequest  true  "通行密钥签名"
+// @Success      200     {object}   vo.Result{data=account.LoginVo}  "登录成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或通行密钥验证失败"
+// @Failure      403     {object}   vo.Result{data=account.AccountDisabledVo}  "账户已被停用、待审核或未通过注册审核"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/passkey/finishLogin [post]
+func FinishPasskeyLogin(c echo.Context) error {
+	req := new(dto.FinishPasskeyLoginRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	response, err := service.FinishPasskeyLogin(req, c)
+	var disabled *service.AccountDisabledError
+	if errors.As(err, &disabled) {
+		return accountDisabledResponse(disabled, c)
+	}
+	if errors.Is(err, service.ErrPasskeyVerifyFail) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasskeyVerifyFail), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
+
+// ListPasskeys godoc
+// @Summary      获取通行密钥列表
+// @Description  获取当前账户绑定的通行密钥
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]account.PasskeyVo}  "获取成功"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/passkey/listPasskeys [get]
+func ListPasskeys(c echo.Context) error {
+	passkeys, err := service.ListPasskeys(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(passkeys, c))
+}
+
+// DeletePasskey godoc
+// @Summary      删除通行密钥
+// @Description  删除当前账户绑定的通行密钥，删除后无法再使用该通行密钥登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DeletePasskeyRequest  true  "通行密钥 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "删除成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      404     {object}   vo.Result  "通行密钥不存在"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/passkey/deletePasskey [post]
+func DeletePasskey(c echo.Context) error {
+	req := new(dto.DeletePasskeyRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.DeletePasskey(req, c)
+	if errors.Is(err, service.ErrPasskeyNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PasskeyNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(nil, c))
+}
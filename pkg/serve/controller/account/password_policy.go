@@ -0,0 +1,21 @@
+package account
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetPasswordPolicy godoc
+// @Summary      获取密码强度策略
+// @Description  返回注册、修改密码与重置密码时服务端校验的密码规则，前端可据此提示用户并预先校验
+// @Tags         账户
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=account.PasswordPolicyVo}  "密码强度策略"
+// @Router       /account/passwordPolicy [get]
+func GetPasswordPolicy(c echo.Context) error {
+	return c.JSON(http.StatusOK, vo.Success(service.PasswordPolicy(), c))
+}
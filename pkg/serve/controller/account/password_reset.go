@@ -0,0 +1,109 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ForgotPassword godoc
+// @Summary      发送重置密码链接
+// @Description  校验图形验证码后向账户邮箱发送重置密码链接，链接只能使用一次；邮箱未注册时同样返回成功
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ForgotPasswordRequest  true  "邮箱与图形验证码"
+// @Success      200     {object}   vo.Result  "已发送重置密码邮件"
+// @Failure      400     {object}   vo.Result  "请求参数错误或图形验证码校验失败"
+// @Failure      429     {object}   vo.Result{data=verification.RetryAfterVo}  "发送过于频繁"
+// @Failure      500     {object}   vo.Result  "重置密码邮件发送失败"
+// @Failure      503     {object}   vo.Result  "邮件服务熔断中，暂不可用"
+// @Router       /account/forgotPassword [post]
+func ForgotPassword(c echo.Context) error {
+	req := new(dto.ForgotPasswordRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := checkImgCode(req.ImgVerificationCode, req.ImgVerificationTicket, req.Email, req.VerificationNonce, c); err != nil {
+		return codeFailResponse(err, bizErr.SendImgVerificationCodeFail, "图形验证码校验失败", c)
+	}
+
+	if err := verification.SendPasswordResetLink(req.Email, service.EmailRegistered(req.Email), c); err != nil {
+		return verification.PasswordResetFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("如果该邮箱已注册，重置密码邮件已发送，请注意查收！", c))
+}
+
+// CheckPasswordResetLink godoc
+// @Summary      校验重置密码链接
+// @Description  前端打开重置密码页面时校验链接是否有效，校验不会作废链接
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CheckPasswordResetLinkRequest  true  "链接中的 token"
+// @Success      200     {object}   vo.Result{data=account.PasswordResetLinkVo}  "链接有效"
+// @Failure      400     {object}   vo.Result  "请求参数错误，或链接无效、已过期、已使用"
+// @Router       /account/checkPasswordResetLink [post]
+func CheckPasswordResetLink(c echo.Context) error {
+	req := new(dto.CheckPasswordResetLinkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	email, expiresAt, err := verification.CheckPasswordResetLink(req.Token)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasswordResetLinkInvalid), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(service.PasswordResetLink(email, expiresAt.Unix()), c))
+}
+
+// ResetPasswordByLink godoc
+// @Summary      通过链接重置密码
+// @Description  使用重置密码链接设置新密码，链接随即失效，账户在全部设备上的登录状态同时失效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ResetPasswordByLinkRequest  true  "链接中的 token 与新密码"
+// @Success      200     {object}   vo.Result  "密码重置成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误，密码不符合安全要求，或链接无效、已过期、已使用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/resetPasswordByLink [post]
+func ResetPasswordByLink(c echo.Context) error {
+	req := new(dto.ResetPasswordByLinkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	// 先校验新密码再作废链接，密码不符合要求时用户可用同一链接重试
+	if email, _, err := verification.CheckPasswordResetLink(req.Token); err == nil {
+		if err := password.Check(req.NewPassword, email); err != nil {
+			return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasswordTooWeak, err.Error()), c))
+		}
+	}
+
+	email, err := verification.ConsumePasswordResetLink(req.Token)
+	if errors.Is(err, verification.ErrPasswordResetLinkInvalid) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasswordResetLinkInvalid), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	if err := service.ResetPasswordByLink(email, req.NewPassword, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("密码重置成功", c))
+}
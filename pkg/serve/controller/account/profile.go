@@ -0,0 +1,174 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetProfile godoc
+// @Summary      获取公开资料
+// @Description  获取账户的昵称、展示名称、头像、简介、个人网站与社交链接，不包含邮箱、手机号等私密信息，无需登录
+// @Tags         账户
+// @Produce      json
+// @Param        id  path  int  true  "账户 ID"
+// @Success      200  {object}  vo.Result{data=account.ProfileVo}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      404  {object}  vo.Result  "用户不存在"
+// @Router       /account/profile/{id} [get]
+func GetProfile(c echo.Context) error {
+	req := new(dto.GetProfileRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	profile, err := service.GetProfile(req, c)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.ProfileNotFound), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(profile, c))
+}
+
+// UpdateProfile godoc
+// @Summary      更新个人资料
+// @Description  更新当前用户的昵称、展示名称、简介、个人网站与社交链接，未传的字段保持不变
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateProfileRequest  true  "个人资料"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.ProfileVo}  "更新成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/updateProfile [post]
+func UpdateProfile(c echo.Context) error {
+	req := new(dto.UpdateProfileRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	profile, err := service.UpdateProfile(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(profile, c))
+}
+
+// UploadAvatar godoc
+// @Summary      上传头像
+// @Description  上传 JPEG、PNG、GIF 或 WebP 图片作为头像，服务端按裁剪区域裁剪为正方形并缩放为 AVATAR_SIZE 边长的 PNG，未传裁剪区域时从图片中心裁剪
+// @Tags         账户
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        avatar     formData  file  true   "头像图片"
+// @Param        crop_x     formData  int   false  "裁剪区域左上角的横坐标"
+// @Param        crop_y     formData  int   false  "裁剪区域左上角的纵坐标"
+// @Param        crop_size  formData  int   false  "裁剪区域的边长"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.AvatarVo}  "上传成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或图片无效"
+// @Failure      413     {object}   vo.Result  "图片过大"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/uploadAvatar [post]
+func UploadAvatar(c echo.Context) error {
+	req := new(dto.UploadAvatarRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, "请上传头像图片"), c))
+	}
+	src, err := header.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, "读取头像图片失败"), c))
+	}
+	defer src.Close()
+
+	var crop *image.Rectangle
+	if req.CropSize > 0 {
+		rect := image.Rect(req.CropX, req.CropY, req.CropX+req.CropSize, req.CropY+req.CropSize)
+		crop = &rect
+	}
+
+	result, err := service.UploadAvatar(src, header.Size, crop, c)
+	switch {
+	case errors.Is(err, service.ErrAvatarTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, vo.Fail(nil, bizErr.New(bizErr.AvatarTooLarge), c))
+	case errors.Is(err, service.ErrAvatarInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.AvatarInvalid), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(result, c))
+}
+
+// GetAvatar godoc
+// @Summary      获取头像
+// @Description  获取上传的头像图片，头像地址由上传头像接口返回，无需登录
+// @Tags         账户
+// @Produce      image/png
+// @Param        file  path  string  true  "头像文件名"
+// @Success      200  {file}    file       "头像图片"
+// @Failure      404  {object}  vo.Result  "头像不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /account/avatar/{file} [get]
+func GetAvatar(c echo.Context) error {
+	path, err := service.AvatarFile(c.Param("file"), c)
+	if errors.Is(err, service.ErrAvatarNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.AvatarNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	// 每次上传生成新文件名，同一地址的内容不会变化
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=31536000, immutable")
+	return c.File(path)
+}
+
+// GetFallbackAvatar godoc
+// @Summary      获取默认头像
+// @Description  获取未上传头像的账户使用的默认头像，根据配置生成对称像素图案，或由服务端代理并缓存 Gravatar 头像，访客不直接访问 Gravatar；无需登录
+// @Tags         账户
+// @Produce      image/png
+// @Param        hash  path   string  true   "邮箱的 MD5 或 SHA-256 哈希"
+// @Param        size  query  int     false  "头像边长，取值 32 至 512，默认 80"
+// @Param        s     query  int     false  "头像边长，与 Gravatar 兼容的写法"
+// @Success      200  {file}    file       "头像图片"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      404  {object}  vo.Result  "哈希无效"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /avatar/{hash} [get]
+func GetFallbackAvatar(c echo.Context) error {
+	req := new(dto.FallbackAvatarRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	data, maxAge, err := service.FallbackAvatar(req, c)
+	if errors.Is(err, service.ErrAvatarNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.AvatarNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	return c.Blob(http.StatusOK, "image/png", data)
+}
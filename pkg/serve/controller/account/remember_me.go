@@ -0,0 +1,131 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// RememberDevice godoc
+// @Summary      开启记住我
+// @Description  为当前会话所在的受信任设备签发长期有效的设备令牌，会话结束后可调用 /account/device/exchangeDeviceToken 免登录换取新的 token，无需重新输入密码或邮箱验证码；设备令牌与客户端提交的设备标识绑定，完整的令牌仅返回一次，需站点开启 REMEMBER_ME_ENABLED
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RememberDeviceRequest  true  "设备标识"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.DeviceTokenCreatedVo}  "签发成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Failure      503     {object}   vo.Result  "站点未开启记住我"
+// @Router       /account/device/rememberDevice [post]
+func RememberDevice(c echo.Context) error {
+	req := new(dto.RememberDeviceRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	token, err := service.RememberDevice(req, c)
+	if errors.Is(err, service.ErrRememberMeDisabled) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.RememberMeDisabled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(token, c))
+}
+
+// ExchangeDeviceToken godoc
+// @Summary      使用设备令牌换取 token
+// @Description  使用开启记住我时签发的设备令牌与设备标识换取新的访问令牌和刷新令牌，创建新的会话并记入登录记录，无需登录；设备令牌随即轮换，响应中的 device_token 为新令牌，旧令牌不能再使用，有效期随之顺延；设备标识不一致时令牌随即删除，需重新登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ExchangeDeviceTokenRequest  true  "设备令牌与设备标识"
+// @Success      200     {object}   vo.Result{data=account.LoginVo}  "换取成功，返回访问令牌与新的设备令牌"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      401     {object}   vo.Result  "设备令牌无效、已过期或设备标识不一致"
+// @Failure      403     {object}   vo.Result{data=account.AccountDisabledVo}  "账户已被停用、待审核或未通过注册审核"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Failure      503     {object}   vo.Result  "站点未开启记住我"
+// @Router       /account/device/exchangeDeviceToken [post]
+func ExchangeDeviceToken(c echo.Context) error {
+	req := new(dto.ExchangeDeviceTokenRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	response, err := service.ExchangeDeviceToken(req, c)
+	var disabled *service.AccountDisabledError
+	switch {
+	case errors.As(err, &disabled):
+		return accountDisabledResponse(disabled, c)
+	case errors.Is(err, service.ErrRememberMeDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.RememberMeDisabled), c))
+	case errors.Is(err, service.ErrDeviceTokenInvalid):
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.DeviceTokenInvalid), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
+
+// ListDeviceTokens godoc
+// @Summary      获取记住我的设备列表
+// @Description  获取当前账户开启记住我且未过期的设备及最近使用记录，不包含完整的设备令牌
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]account.DeviceTokenVo}  "获取成功"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/device/listDevices [get]
+func ListDeviceTokens(c echo.Context) error {
+	tokens, err := service.ListDeviceTokens(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(tokens, c))
+}
+
+// RevokeDeviceToken godoc
+// @Summary      取消记住设备
+// @Description  删除当前账户的设备令牌，该设备的会话结束后需重新登录，已登录的会话不受影响，需同时下线时调用 /account/session/revokeSession
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RevokeDeviceTokenRequest  true  "设备令牌 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "删除成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      404     {object}   vo.Result  "设备令牌不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/device/revokeDevice [post]
+func RevokeDeviceToken(c echo.Context) error {
+	req := new(dto.RevokeDeviceTokenRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.RevokeDeviceToken(req, c)
+	if errors.Is(err, service.ErrDeviceTokenNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.DeviceTokenNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("设备已取消记住", c))
+}
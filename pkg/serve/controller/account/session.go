@@ -0,0 +1,87 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ListSessions godoc
+// @Summary      获取登录会话列表
+// @Description  获取当前账户在各设备上未结束的登录会话，包含设备、IP、User-Agent 与最近访问时间
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]account.SessionVo}  "获取成功"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/session/listSessions [get]
+func ListSessions(c echo.Context) error {
+	sessions, err := service.ListSessions(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(sessions, c))
+}
+
+// RevokeSession godoc
+// @Summary      移除登录会话
+// @Description  移除当前账户的单个登录会话，被移除的设备需重新登录
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RevokeSessionRequest  true  "会话 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "移除成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      404     {object}   vo.Result  "会话不存在或已结束"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/session/revokeSession [post]
+func RevokeSession(c echo.Context) error {
+	req := new(dto.RevokeSessionRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.RevokeSession(req, c)
+	if errors.Is(err, service.ErrSessionNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.SessionNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(nil, c))
+}
+
+// RevokeOtherSessions godoc
+// @Summary      移除其他登录会话
+// @Description  移除当前账户除发起请求的会话外的全部登录会话
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.RevokeSessionsVo}  "移除成功"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      404     {object}   vo.Result  "发起请求的会话不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/session/revokeOtherSessions [post]
+func RevokeOtherSessions(c echo.Context) error {
+	result, err := service.RevokeOtherSessions(c)
+	if errors.Is(err, service.ErrSessionNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.SessionNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(result, c))
+}
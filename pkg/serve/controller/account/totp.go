@@ -0,0 +1,197 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/controller/verification"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// SetupTotp godoc
+// @Summary      获取两步验证绑定信息
+// @Description  生成 TOTP 密钥与 otpauth 地址供验证器扫码绑定，10 分钟内提交动态码确认后开启两步验证
+// @Tags         账户
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.TotpSetupVo}  "获取成功"
+// @Failure      409     {object}   vo.Result  "账户已开启两步验证"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/setupTotp [post]
+func SetupTotp(c echo.Context) error {
+	setup, err := service.SetupTotp(c)
+	if errors.Is(err, service.ErrTotpAlreadyEnabled) {
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.TotpAlreadyEnabled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(setup, c))
+}
+
+// EnableTotp godoc
+// @Summary      开启两步验证
+// @Description  提交验证器显示的动态码，校验通过后开启两步验证并返回恢复码，之后登录需再提交动态码，修改密码等敏感操作可使用动态码代替邮箱验证码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.EnableTotpRequest  true  "动态码"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.TotpRecoveryCodesVo}  "开启成功，恢复码仅返回一次"
+// @Failure      400     {object}   vo.Result  "请求参数错误、动态码错误或绑定信息已过期"
+// @Failure      409     {object}   vo.Result  "账户已开启两步验证"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/enableTotp [post]
+func EnableTotp(c echo.Context) error {
+	req := new(dto.EnableTotpRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	codes, err := service.EnableTotp(req, c)
+	switch {
+	case errors.Is(err, service.ErrTotpAlreadyEnabled):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.TotpAlreadyEnabled), c))
+	case errors.Is(err, service.ErrTotpSetupExpired), errors.Is(err, service.ErrTotpCodeInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(codes, c))
+}
+
+// DisableTotp godoc
+// @Summary      关闭两步验证
+// @Description  提交 TOTP 动态码或邮箱验证码后关闭两步验证，验证器丢失时可使用邮箱验证码
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DisableTotpRequest  true  "关闭两步验证信息"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "关闭成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或验证码校验失败"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/disableTotp [post]
+func DisableTotp(c echo.Context) error {
+	req := new(dto.DisableTotpRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := verification.VerifySecondFactor(req.Email, verification.ActionDisableTotp, verification.SecondFactor{
+		EmailCode:   req.EmailVerificationCode,
+		EmailTicket: req.EmailVerificationTicket,
+		TotpCode:    req.TotpCode,
+		Nonce:       req.VerificationNonce,
+	}, c); err != nil {
+		return codeFailResponse(err, bizErr.SendEmailVerificationCodeFail, "验证码校验失败", c)
+	}
+
+	if err := service.DisableTotp(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("两步验证已关闭", c))
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      重新生成恢复码
+// @Description  提交动态码后重新生成恢复码，旧恢复码全部失效
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RegenerateRecoveryCodesRequest  true  "动态码"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.TotpRecoveryCodesVo}  "生成成功，恢复码仅返回一次"
+// @Failure      400     {object}   vo.Result  "请求参数错误、动态码错误或账户未开启两步验证"
+// @Failure      403     {object}   vo.Result  "使用 API Key 调用"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/regenerateRecoveryCodes [post]
+func RegenerateRecoveryCodes(c echo.Context) error {
+	req := new(dto.RegenerateRecoveryCodesRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	email, err := service.CurrentAccountEmail(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+	if err := verification.VerifyTotpCode(email, req.TotpCode, c); err != nil {
+		return codeFailResponse(err, bizErr.BadRequest, "动态码错误", c)
+	}
+
+	codes, err := service.RegenerateRecoveryCodes(c)
+	if errors.Is(err, service.ErrTotpNotEnabled) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.TotpNotEnrolled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(codes, c))
+}
+
+// VerifyTotpLogin godoc
+// @Summary      两步验证登录
+// @Description  密码或第三方登录返回 totp_ticket 后，提交验证器的动态码或恢复码完成登录，返回访问令牌
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.VerifyTotpLoginRequest  true  "两步验证信息"
+// @Success      200     {object}   vo.Result{data=account.LoginVo}  "登录成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误、动态码或恢复码错误、登录凭证无效"
+// @Failure      403     {object}   vo.Result{data=account.AccountDisabledVo}  "账户已被停用、待审核或未通过注册审核"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /account/verifyTotpLogin [post]
+func VerifyTotpLogin(c echo.Context) error {
+	req := new(dto.VerifyTotpLoginRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	acc, err := service.TotpLoginAccount(req.TotpTicket, c)
+	if errors.Is(err, service.ErrTotpLoginTicketInvalid) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.TotpLoginTicketInvalid), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	if req.TotpCode != "" {
+		if err := verification.VerifyTotpCode(acc.Email, req.TotpCode, c); err != nil {
+			service.RecordTotpLoginFailure(acc, req.TotpTicket, c)
+			return codeFailResponse(err, bizErr.BadRequest, "动态码错误", c)
+		}
+	} else {
+		err := service.UseRecoveryCode(acc, req.TotpTicket, req.RecoveryCode, c)
+		if errors.Is(err, service.ErrRecoveryCodeInvalid) {
+			return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.RecoveryCodeInvalid), c))
+		}
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+		}
+	}
+
+	response, err := service.FinishTotpLogin(acc, req.TotpTicket, req.TotpCode == "", c)
+	var disabled *service.AccountDisabledError
+	if errors.As(err, &disabled) {
+		return accountDisabledResponse(disabled, c)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(response, c))
+}
@@ -0,0 +1,433 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// ListUsers godoc
+// @Summary      获取用户列表
+// @Description  分页获取全部用户，可按关键字、角色与账户状态筛选，按注册时间倒序排列，仅管理员可用
+// @Tags         用户管理
+// @Produce      json
+// @Param        keyword  query    string  false  "模糊匹配邮箱、手机号、昵称与展示名称"
+// @Param        role     query    string  false  "角色编码"
+// @Param        status   query    string  false  "账户状态，可选值: active, disabled, pending_deletion, pending_approval, rejected"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]account.AdminUserVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      403  {object}  vo.Result  "没有管理员权限"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /admin/user/listUsers [get]
+func ListUsers(c echo.Context) error {
+	req := new(dto.ListUsersRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	users, meta, err := service.ListUsers(req, vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(users, meta, c))
+}
+
+// GetUser godoc
+// @Summary      获取用户详情
+// @Description  获取用户的资料、账户状态、已关联的登录方式、会话数量与最近的登录记录，仅管理员可用
+// @Tags         用户管理
+// @Produce      json
+// @Param        id  query  int  true  "账户 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=account.AdminUserDetailVo}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      403  {object}  vo.Result  "没有管理员权限"
+// @Failure      404  {object}  vo.Result  "用户不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /admin/user/getUser [get]
+func GetUser(c echo.Context) error {
+	req := new(dto.AdminUserRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	user, err := service.GetUser(req, c)
+	if err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(user, c))
+}
+
+// AdminResetPassword godoc
+// @Summary      重置用户密码
+// @Description  为用户设置新密码，新密码需满足密码强度策略，用户的全部设备随即下线，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AdminResetPasswordRequest  true  "账户 ID 与新密码"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=string}  "重置成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或密码不符合安全要求"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "用户不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/resetPassword [post]
+func AdminResetPassword(c echo.Context) error {
+	req := new(dto.AdminResetPasswordRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.AdminResetPassword(req, c); err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("密码已重置", c))
+}
+
+// ForceLogout godoc
+// @Summary      强制用户下线
+// @Description  结束用户在全部设备上的会话并吊销 refresh token，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AdminUserRequest  true  "账户 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.ForceLogoutVo}  "已下线"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "用户不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/forceLogout [post]
+func ForceLogout(c echo.Context) error {
+	req := new(dto.AdminUserRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	result, err := service.ForceLogout(req, c)
+	if err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(result, c))
+}
+
+// ChangeUserRole godoc
+// @Summary      变更用户角色
+// @Description  将用户的角色变更为指定角色，不能变更自己的角色，也不能降级站点唯一的管理员，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ChangeUserRoleRequest  true  "账户 ID 与新角色编码"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=string}  "变更成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或角色不存在"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "用户不存在"
+// @Failure      409     {object}   vo.Result  "不能变更自己的角色，或用户是站点唯一的管理员"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/changeRole [post]
+func ChangeUserRole(c echo.Context) error {
+	req := new(dto.ChangeUserRoleRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.ChangeUserRole(req, c); err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("角色已变更", c))
+}
+
+// DisableUser godoc
+// @Summary      停用用户
+// @Description  停用用户，停用期间不能通过任何方式登录，也不能使用 API Key，已登录的设备随即下线；指定 duration_hours 时到期后自动解除，否则永久停用，已停用的用户再次停用时按本次的原因与时长重新计算；每次停用写入停用记录，不能停用自己，也不能停用站点唯一的管理员，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DisableUserRequest  true  "账户 ID、停用原因与停用时长"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=string}  "已停用"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "用户不存在"
+// @Failure      409     {object}   vo.Result  "不能停用自己，或用户是站点唯一的管理员"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/disableUser [post]
+func DisableUser(c echo.Context) error {
+	req := new(dto.DisableUserRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.DisableUser(req, c); err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("用户已停用", c))
+}
+
+// EnableUser godoc
+// @Summary      启用用户
+// @Description  重新启用已停用的用户，未解除的停用记录标记为已解除并记录解除原因，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.EnableUserRequest  true  "账户 ID 与解除原因"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=string}  "已启用"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "用户不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/enableUser [post]
+func EnableUser(c echo.Context) error {
+	req := new(dto.EnableUserRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.EnableUser(req, c); err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("用户已启用", c))
+}
+
+// ListUserBans godoc
+// @Summary      获取用户的停用记录
+// @Description  获取用户的全部停用记录，包括停用原因、到期时间、解除情况与申诉备注，按停用时间倒序排列，仅管理员可用
+// @Tags         用户管理
+// @Produce      json
+// @Param        id  query  int  true  "账户 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]account.AccountBanVo}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      403  {object}  vo.Result  "没有管理员权限"
+// @Failure      404  {object}  vo.Result  "用户不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /admin/user/listBans [get]
+func ListUserBans(c echo.Context) error {
+	req := new(dto.AdminUserRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	bans, err := service.ListUserBans(req, c)
+	if err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(bans, c))
+}
+
+// UpdateBanAppeal godoc
+// @Summary      记录停用申诉
+// @Description  在停用记录上记录用户的申诉内容与处理情况，覆盖原有备注，不影响停用状态，需解除停用时调用 enableUser，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateBanAppealRequest  true  "停用记录 ID 与申诉备注"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.AccountBanVo}  "已记录"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "停用记录不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/updateBanAppeal [post]
+func UpdateBanAppeal(c echo.Context) error {
+	req := new(dto.UpdateBanAppealRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	ban, err := service.UpdateBanAppeal(req, c)
+	if err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(ban, c))
+}
+
+// ApproveUser godoc
+// @Summary      通过注册审核
+// @Description  通过待审核或已拒绝用户的注册申请，用户随即可以登录，并收到审核结果邮件；无需审核的用户不做处理，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.AdminUserRequest  true  "账户 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=string}  "已通过"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "用户不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/approveUser [post]
+func ApproveUser(c echo.Context) error {
+	req := new(dto.AdminUserRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.ApproveUser(req, c); err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("已通过注册审核", c))
+}
+
+// RejectUser godoc
+// @Summary      拒绝注册
+// @Description  拒绝待审核用户的注册申请，账户保留但不能登录，用户登录时可见拒绝原因，并收到审核结果邮件；已在正常使用的账户应改为停用，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RejectUserRequest  true  "账户 ID 与拒绝原因"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=string}  "已拒绝"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      404     {object}   vo.Result  "用户不存在"
+// @Failure      409     {object}   vo.Result  "用户的注册无需审核"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/rejectUser [post]
+func RejectUser(c echo.Context) error {
+	req := new(dto.RejectUserRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.RejectUser(req, c); err != nil {
+		return userAdminFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("已拒绝注册申请", c))
+}
+
+// GetRegistrationSettings godoc
+// @Summary      获取注册设置
+// @Description  获取站点的注册方式、是否邮件通知管理员审核，以及待审核的用户数量，仅管理员可用
+// @Tags         用户管理
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=account.RegistrationSettingsVo}  "获取成功"
+// @Failure      403  {object}  vo.Result  "没有管理员权限"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /admin/user/getRegistration [get]
+func GetRegistrationSettings(c echo.Context) error {
+	settings, err := service.GetRegistrationSettings(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(settings, c))
+}
+
+// UpdateRegistration godoc
+// @Summary      修改注册设置
+// @Description  修改站点的注册方式：open 为开放注册，approval 为注册后需管理员审核，closed 为关闭注册；修改保存到数据库并立即生效，操作记入审计日志，仅管理员可用
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateRegistrationRequest  true  "注册设置"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=account.RegistrationSettingsVo}  "修改成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      403     {object}   vo.Result  "没有管理员权限"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/user/updateRegistration [post]
+func UpdateRegistration(c echo.Context) error {
+	req := new(dto.UpdateRegistrationRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	settings, err := service.UpdateRegistration(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(settings, c))
+}
+
+// ListAdminAuditLogs godoc
+// @Summary      获取管理员操作审计日志
+// @Description  分页获取管理员在用户管理中执行的操作，可按被操作的账户、管理员与操作筛选，按时间倒序排列，仅管理员可用
+// @Tags         用户管理
+// @Produce      json
+// @Param        target_id    query    int     false  "被操作的账户 ID"
+// @Param        operator_id  query    int     false  "执行操作的管理员账户 ID"
+// @Param        action       query    string  false  "操作，可选值: reset_password, force_logout, change_role, disable, enable, ban_appeal, approve, reject, registration"
+// @Param        page         query    int     false  "页码"
+// @Param        pageSize     query    int     false  "每页显示数量，最大 100"
+// @Param        cursor       query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]account.AdminAuditLogVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      403  {object}  vo.Result  "没有管理员权限"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /admin/user/listAuditLogs [get]
+func ListAdminAuditLogs(c echo.Context) error {
+	req := new(dto.ListAdminAuditLogsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	logs, meta, err := service.ListAdminAuditLogs(req, vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(logs, meta, c))
+}
+
+// userAdminFailResponse 将用户管理的错误映射为对应的状态码与错误码
+func userAdminFailResponse(err error, c echo.Context) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.AdminUserNotFound), c))
+	case errors.Is(err, service.ErrBanNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.AccountBanNotFound), c))
+	case errors.Is(err, service.ErrAdminSelfOperation):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.AdminSelfOperation), c))
+	case errors.Is(err, service.ErrLastAdmin):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.AdminLastAdmin), c))
+	case errors.Is(err, service.ErrPasswordTooWeak):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasswordTooWeak, err.Error()), c))
+	case errors.Is(err, service.ErrRoleNotFound):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	case errors.Is(err, service.ErrNotPendingApproval):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.RegistrationNotPending), c))
+	default:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+}
+
+// accountDisabledResponse 账户已停用、待审核或未通过注册审核时拒绝登录，返回管理员填写的原因
+func accountDisabledResponse(err *service.AccountDisabledError, c echo.Context) error {
+	code := bizErr.AccountDisabled
+	switch err.Status {
+	case model.ApprovalStatusPending:
+		code = bizErr.AccountPendingApproval
+	case model.ApprovalStatusRejected:
+		code = bizErr.AccountRejected
+	}
+	return c.JSON(http.StatusForbidden, vo.Fail(&account.AccountDisabledVo{Reason: err.Reason, Until: err.Until}, bizErr.New(code), c))
+}
@@ -0,0 +1,86 @@
+package bookmark
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/bookmark/dto"
+	"jank.com/jank_blog/pkg/serve/service/bookmark"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// AddBookmark godoc
+// @Summary      收藏文章
+// @Description  收藏指定文章，重复收藏不会报错，返回文章最新的收藏数与收藏状态
+// @Tags         收藏
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.BookmarkRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=bookmark.BookmarkStateVo}  "收藏成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /bookmark/addBookmark [post]
+func AddBookmark(c echo.Context) error {
+	req := new(dto.BookmarkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	state, err := service.AddBookmark(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(state, c))
+}
+
+// RemoveBookmark godoc
+// @Summary      取消收藏文章
+// @Description  取消收藏指定文章，未收藏时不会报错，返回文章最新的收藏数与收藏状态
+// @Tags         收藏
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.BookmarkRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=bookmark.BookmarkStateVo}  "取消成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /bookmark/removeBookmark [post]
+func RemoveBookmark(c echo.Context) error {
+	req := new(dto.BookmarkRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	state, err := service.RemoveBookmark(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(state, c))
+}
+
+// ListBookmarks godoc
+// @Summary      获取收藏列表
+// @Description  分页获取当前用户收藏的文章，按最近收藏时间倒序排序
+// @Tags         收藏
+// @Produce      json
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]bookmark.BookmarkVo,page=vo.PageMeta}  "获取成功"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /bookmark/listBookmarks [get]
+func ListBookmarks(c echo.Context) error {
+	bookmarks, meta, err := service.ListBookmarks(vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(bookmarks, meta, c))
+}
@@ -0,0 +1,7 @@
+package dto
+
+// BookmarkRequest           收藏或取消收藏文章请求参数结构体
+// @Param	post_id	body	int64	true	"文章 ID"
+type BookmarkRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+}
@@ -25,13 +25,8 @@ import (
 // @Router       /category/getOneCategory [get]
 func GetOneCategory(c echo.Context) error {
 	req := new(dto.GetOneCategoryRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	category, err := service.GetCategoryByID(req, c)
@@ -74,13 +69,8 @@ func GetCategoryTree(c echo.Context) error {
 // @Router       /category/getCategoryChildrenTree [post]
 func GetCategoryChildrenTree(c echo.Context) error {
 	req := new(dto.GetOneCategoryRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	childrenCategories, err := service.GetCategoryChildrenByID(req, c)
@@ -104,13 +94,8 @@ func GetCategoryChildrenTree(c echo.Context) error {
 // @Router       /category/createOneCategory [post]
 func CreateOneCategory(c echo.Context) error {
 	req := new(dto.CreateOneCategoryRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	createdCategory, err := service.CreateCategory(req, c)
@@ -137,13 +122,8 @@ func CreateOneCategory(c echo.Context) error {
 // @Router       /category/updateOneCategory [post]
 func UpdateOneCategory(c echo.Context) error {
 	req := new(dto.UpdateOneCategoryRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	updatedCategory, err := service.UpdateCategory(req, c)
@@ -169,13 +149,8 @@ func UpdateOneCategory(c echo.Context) error {
 // @Router       /category/deleteOneCategory [post]
 func DeleteOneCategory(c echo.Context) error {
 	req := new(dto.DeleteOneCategoryRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	category, err := service.DeleteCategory(req, c)
@@ -25,13 +25,8 @@ import (
 // @Router       /comment/getOneComment [get]
 func GetOneComment(c echo.Context) error {
 	req := new(dto.GetOneCommentRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	comment, err := service.GetCommentWithReplies(req, c)
@@ -54,13 +49,8 @@ func GetOneComment(c echo.Context) error {
 // @Router       /comment/getOneComment [get]
 func GetCommentGraph(c echo.Context) error {
 	req := new(dto.GetCommentGraphRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	comments, err := service.GetCommentGraphByPostID(req, c)
@@ -83,13 +73,8 @@ func GetCommentGraph(c echo.Context) error {
 // @Router       /comment/createOneComment [post]
 func CreateOneComment(c echo.Context) error {
 	req := new(dto.CreateCommentRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	comment, err := service.CreateComment(req, c)
@@ -113,13 +98,8 @@ func CreateOneComment(c echo.Context) error {
 // @Router       /comment/deleteOneComment [post]
 func DeleteOneComment(c echo.Context) error {
 	req := new(dto.DeleteCommentRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	comment, err := service.DeleteComment(req, c)
@@ -0,0 +1,7 @@
+package dto
+
+// RestoreCommentRequest 从回收站恢复评论请求
+// @Param id body int64 true "评论ID"
+type RestoreCommentRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
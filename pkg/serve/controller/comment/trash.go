@@ -0,0 +1,69 @@
+package comment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/comment/dto"
+	"jank.com/jank_blog/pkg/serve/service/comment"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetTrashedComments godoc
+// @Summary      获取回收站评论
+// @Description  分页获取已删除的评论，按删除时间倒序排序；超过 trash.TRASH_COMMENT_RETENTION_DAYS 天的评论由定时任务永久删除，仅管理员可用
+// @Tags         评论
+// @Produce      json
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200   {object}  vo.Result{data=[]comment.TrashCommentVo,page=vo.PageMeta}  "获取成功"
+// @Failure      401   {object}  vo.Result  "未授权"
+// @Failure      403   {object}  vo.Result  "权限不足"
+// @Failure      500   {object}  vo.Result  "服务器错误"
+// @Router       /comment/trash/getTrashedComments [get]
+func GetTrashedComments(c echo.Context) error {
+	list, meta, err := service.GetTrashedComments(vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(list, meta, c))
+}
+
+// RestoreComment godoc
+// @Summary      恢复评论
+// @Description  从回收站恢复评论，仅管理员可用
+// @Tags         评论
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RestoreCommentRequest  true  "评论 ID"
+// @Security     BearerAuth
+// @Success      200   {object}  vo.Result  "恢复成功"
+// @Failure      400   {object}  vo.Result  "请求参数错误"
+// @Failure      401   {object}  vo.Result  "未授权"
+// @Failure      403   {object}  vo.Result  "权限不足"
+// @Failure      404   {object}  vo.Result  "回收站中不存在该评论"
+// @Failure      500   {object}  vo.Result  "服务器错误"
+// @Router       /comment/trash/restoreComment [post]
+func RestoreComment(c echo.Context) error {
+	req := new(dto.RestoreCommentRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.RestoreComment(req, c)
+	switch {
+	case errors.Is(err, service.ErrCommentNotInTrash):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.TrashCommentNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("评论恢复成功", c))
+}
@@ -0,0 +1,101 @@
+package disposable
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/disposable/dto"
+	"jank.com/jank_blog/pkg/serve/service/disposable"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ListDomains godoc
+// @Summary      获取一次性邮箱域名黑名单
+// @Description  获取黑名单的来源域名数、管理员添加与移除的域名，仅管理员可用
+// @Tags         一次性邮箱
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=disposable.DisposableEmailVo}  "获取成功"
+// @Failure      401  {object}  vo.Result  "未授权"
+// @Failure      403  {object}  vo.Result  "权限不足"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /disposableEmail/listDomains [get]
+func ListDomains(c echo.Context) error {
+	status, err := service.GetDisposableEmailStatus(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(status, c))
+}
+
+// AddDomain godoc
+// @Summary      添加一次性邮箱域名
+// @Description  将域名加入黑名单，子域名同样拒绝，仅管理员可用
+// @Tags         一次性邮箱
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DomainRequest  true  "邮箱域名"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "添加成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /disposableEmail/addDomain [post]
+func AddDomain(c echo.Context) error {
+	req := new(dto.DomainRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.AddDisposableDomain(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("一次性邮箱域名已添加", c))
+}
+
+// RemoveDomain godoc
+// @Summary      移除一次性邮箱域名
+// @Description  将域名移出黑名单，内置、文件或远程列表中的域名同样放行，仅管理员可用
+// @Tags         一次性邮箱
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DomainRequest  true  "邮箱域名"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "移除成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /disposableEmail/removeDomain [post]
+func RemoveDomain(c echo.Context) error {
+	req := new(dto.DomainRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.RemoveDisposableDomain(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("一次性邮箱域名已移除", c))
+}
+
+// RefreshDomains godoc
+// @Summary      刷新一次性邮箱域名列表
+// @Description  立即重新加载配置的本地文件与远程列表，返回加载的域名数，仅管理员可用
+// @Tags         一次性邮箱
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=int}  "刷新成功"
+// @Failure      500  {object}  vo.Result  "服务器错误，读取文件或下载远程列表失败"
+// @Router       /disposableEmail/refreshDomains [post]
+func RefreshDomains(c echo.Context) error {
+	count, err := service.RefreshDisposableDomains(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(count, c))
+}
@@ -0,0 +1,8 @@
+package dto
+
+// DomainRequest     一次性邮箱域名操作请求体
+// @Description	添加或移除一次性邮箱域名所需参数
+// @Param			domain	body	string	true	"邮箱域名，如 mailinator.com"
+type DomainRequest struct {
+	Domain string `json:"domain" xml:"domain" form:"domain" query:"domain" validate:"required,fqdn"`
+}
@@ -0,0 +1,21 @@
+package errcode
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/pkg/serve/service/errcode"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ListErrorCodes godoc
+// @Summary      获取错误码目录
+// @Description  返回全部业务错误码及其 HTTP 状态码、默认错误信息与国际化资源键，供客户端按错误码映射提示文案
+// @Tags         错误码
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=[]errcode.ErrorCodeVo}  "获取成功"
+// @Router       /api/errors [get]
+func ListErrorCodes(c echo.Context) error {
+	return c.JSON(http.StatusOK, vo.Success(service.ListErrorCodes(c), c))
+}
@@ -0,0 +1,32 @@
+package feed
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/feed"
+	"jank.com/jank_blog/pkg/serve/service/feed"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetJSONFeed godoc
+// @Summary      获取 JSON Feed 订阅源
+// @Description  按 JSON Feed 1.1 规范输出已发布文章，按创建时间倒序分页，存在下一页时通过 next_url 给出下一页地址；条目包含正文、摘要、封面、分类标签、作者与正文中的音视频及文档附件，与静态导出的 RSS、Atom 订阅源共用同一构建逻辑
+// @Tags         订阅源
+// @Produce      json
+// @Param        lang     query    string  false  "语言，传入时只输出该语言的文章；未传入时有翻译的文章只输出默认语言的版本，没有默认语言时输出原文"
+// @Param        pageSize query    int     false  "每页条目数，默认 20，最大 100"
+// @Param        cursor   query    string  false  "上一页 next_url 中的游标"
+// @Success      200  {string}  string     "JSON Feed 订阅源"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /feed.json [get]
+func GetJSONFeed(c echo.Context) error {
+	body, err := service.GetJSONFeed(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.Blob(http.StatusOK, feed.JSONFeedContentType+"; charset=utf-8", body)
+}
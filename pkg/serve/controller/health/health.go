@@ -0,0 +1,27 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/pkg/serve/service/health"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetHealth godoc
+// @Summary      健康检查
+// @Description  检测数据库与 Redis 连通性，返回当前缓存驱动与邮件发送熔断器状态；数据库不可用时返回 503，Redis 不可用或邮件发送熔断中时整体状态为 degraded
+// @Tags         健康检查
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=health.HealthVo}  "服务可用"
+// @Failure      503  {object}  vo.Result{data=health.HealthVo}  "数据库不可用"
+// @Router       /health [get]
+func GetHealth(c echo.Context) error {
+	result := service.GetHealth(c)
+	if result.Status == service.StatusDown {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(result, bizErr.New(bizErr.ServerError, "数据库不可用"), c))
+	}
+	return c.JSON(http.StatusOK, vo.Success(result, c))
+}
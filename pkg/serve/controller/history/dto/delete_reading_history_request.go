@@ -0,0 +1,7 @@
+package dto
+
+// DeleteReadingHistoryRequest    删除阅读历史请求参数结构体
+// @Param	post_id	body	int64	false	"文章 ID，不传时清空全部阅读历史"
+type DeleteReadingHistoryRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"min=0"`
+}
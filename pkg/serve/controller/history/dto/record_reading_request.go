@@ -0,0 +1,9 @@
+package dto
+
+// RecordReadingRequest      记录阅读进度请求参数结构体
+// @Param	post_id		body	int64	true	"文章 ID"
+// @Param	position	body	int64	false	"阅读位置，由客户端定义，如滚动百分比或段落序号"
+type RecordReadingRequest struct {
+	PostID   int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Position int64 `json:"position" xml:"position" form:"position" query:"position" validate:"min=0"`
+}
@@ -0,0 +1,88 @@
+package history
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/history/dto"
+	"jank.com/jank_blog/pkg/serve/service/history"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// RecordReading godoc
+// @Summary      记录阅读进度
+// @Description  记录当前用户读过的文章及阅读位置，重复记录时更新位置与时间；用户在偏好设置中关闭阅读历史时不记录
+// @Tags         阅读历史
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RecordReadingRequest  true  "阅读进度"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "记录成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /history/recordReading [post]
+func RecordReading(c echo.Context) error {
+	req := new(dto.RecordReadingRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	recorded, err := service.RecordReading(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+	if !recorded {
+		return c.JSON(http.StatusOK, vo.Success("阅读历史已关闭，未记录", c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("阅读进度已记录", c))
+}
+
+// GetReadingHistory godoc
+// @Summary      获取阅读历史
+// @Description  分页获取当前用户的阅读历史及上次阅读位置，按最近阅读时间倒序排序
+// @Tags         阅读历史
+// @Produce      json
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]history.ReadingHistoryVo,page=vo.PageMeta}  "获取成功"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /history/getReadingHistory [get]
+func GetReadingHistory(c echo.Context) error {
+	records, meta, err := service.GetReadingHistory(vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(records, meta, c))
+}
+
+// DeleteReadingHistory godoc
+// @Summary      删除阅读历史
+// @Description  删除当前用户指定文章的阅读历史，不传文章 ID 时清空全部阅读历史
+// @Tags         阅读历史
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DeleteReadingHistoryRequest  false  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "删除成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /history/deleteReadingHistory [post]
+func DeleteReadingHistory(c echo.Context) error {
+	req := new(dto.DeleteReadingHistoryRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.DeleteReadingHistory(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("阅读历史已删除", c))
+}
@@ -0,0 +1,7 @@
+package dto
+
+// GetImportTaskRequest     查询后台导入任务请求
+// @Param	id	query	string	true	"任务 ID"
+type GetImportTaskRequest struct {
+	ID string `json:"id" xml:"id" form:"id" query:"id" validate:"required,len=32,hexadecimal"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// ImportContentRequest     内容导入请求体
+// @Description	以 multipart/form-data 提交，文件类导入源需上传导出文件，数据库类导入源需提供连接串
+// @Param			format		formData	string	true	"导入格式，可选值: ghost, markdown, typecho, wordpress"
+// @Param			file		formData	file	false	"导出文件，文件类导入源使用"
+// @Param			driver		formData	string	false	"数据库驱动，可选值: mysql, sqlite"
+// @Param			dsn			formData	string	false	"数据库连接串，数据库类导入源使用"
+// @Param			prefix		formData	string	false	"数据表前缀"
+// @Param			base_url	formData	string	false	"原站点地址，用于补全图片等相对地址"
+// @Param			dry_run		formData	bool	false	"试运行，只生成导入报告，不写入数据"
+type ImportContentRequest struct {
+	Format  string `json:"format" xml:"format" form:"format" query:"format" validate:"required"`
+	Driver  string `json:"driver" xml:"driver" form:"driver" query:"driver" validate:"omitempty,oneof=mysql sqlite"`
+	DSN     string `json:"dsn" xml:"dsn" form:"dsn" query:"dsn" default:""`
+	Prefix  string `json:"prefix" xml:"prefix" form:"prefix" query:"prefix" validate:"omitempty,max=32"`
+	BaseURL string `json:"base_url" xml:"base_url" form:"base_url" query:"base_url" validate:"omitempty,url"`
+	DryRun  bool   `json:"dry_run" xml:"dry_run" form:"dry_run" query:"dry_run"`
+}
@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/importer/dto"
+	"jank.com/jank_blog/pkg/serve/service/importer"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ImportContent godoc
+// @Summary      导入内容
+// @Description  从 Ghost JSON 导出文件、Typecho 数据库、WordPress WXR 导出文件或 Markdown 文件的 ZIP 压缩包导入文章、标签、图片与评论，标签以类目形式导入，仅管理员可用
+// @Tags         内容导入
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        format    formData  string  true   "导入格式，可选值: ghost, markdown, typecho, wordpress"
+// @Param        file      formData  file    false  "导出文件"
+// @Param        driver    formData  string  false  "数据库驱动"
+// @Param        dsn       formData  string  false  "数据库连接串"
+// @Param        prefix    formData  string  false  "数据表前缀"
+// @Param        base_url  formData  string  false  "原站点地址"
+// @Param        dry_run   formData  bool    false  "试运行，只生成导入报告，不写入数据"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=importer.ImportReportVo}  "导入成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      401     {object}   vo.Result  "未授权"
+// @Failure      403     {object}   vo.Result  "权限不足"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /import/importContent [post]
+func ImportContent(c echo.Context) error {
+	req := new(dto.ImportContentRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	file, closeFile, err := openImportFile(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, "读取导出文件失败"), c))
+	}
+	defer closeFile()
+
+	report, err := service.ImportContent(req, file, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(report, c))
+}
+
+// StartImport godoc
+// @Summary      后台导入内容
+// @Description  参数与导入内容一致，导入在后台进行，立即返回任务，通过查询导入任务获取进度与导入报告；同一时间只能运行一个导入任务，仅管理员可用
+// @Tags         内容导入
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        format    formData  string  true   "导入格式，可选值: ghost, markdown, typecho, wordpress"
+// @Param        file      formData  file    false  "导出文件"
+// @Param        driver    formData  string  false  "数据库驱动"
+// @Param        dsn       formData  string  false  "数据库连接串"
+// @Param        prefix    formData  string  false  "数据表前缀"
+// @Param        base_url  formData  string  false  "原站点地址"
+// @Param        dry_run   formData  bool    false  "试运行，只生成导入报告，不写入数据"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=importer.ImportTaskVo}  "任务已开始"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      401     {object}   vo.Result  "未授权"
+// @Failure      403     {object}   vo.Result  "权限不足"
+// @Failure      409     {object}   vo.Result  "已有导入任务正在进行"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /import/startImport [post]
+func StartImport(c echo.Context) error {
+	req := new(dto.ImportContentRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	file, closeFile, err := openImportFile(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(err, bizErr.New(bizErr.BadRequest, "读取导出文件失败"), c))
+	}
+	defer closeFile()
+
+	task, err := service.StartImport(req, file, c)
+	if err != nil {
+		return importFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(task, c))
+}
+
+// GetImportTask godoc
+// @Summary      查询导入任务
+// @Description  获取后台导入任务的状态、进度与导入报告，任务结束后保留 24 小时，仅管理员可用
+// @Tags         内容导入
+// @Produce      json
+// @Param        id   query     string  true  "任务 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=importer.ImportTaskVo}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      401  {object}  vo.Result  "未授权"
+// @Failure      403  {object}  vo.Result  "权限不足"
+// @Failure      404  {object}  vo.Result  "导入任务不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /import/getImportTask [get]
+func GetImportTask(c echo.Context) error {
+	req := new(dto.GetImportTaskRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	task, err := service.GetImportTask(req, c)
+	if err != nil {
+		return importFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(task, c))
+}
+
+// openImportFile 打开上传的导出文件，未上传文件时返回 nil，数据库类导入源不需要文件
+func openImportFile(c echo.Context) (io.Reader, func(), error) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, func() {}, nil
+	}
+	src, err := header.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	return src, func() { _ = src.Close() }, nil
+}
+
+// importFailResponse 将导入任务的错误转换为响应
+func importFailResponse(err error, c echo.Context) error {
+	switch {
+	case errors.Is(err, service.ErrImportRunning):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.ImportRunning), c))
+	case errors.Is(err, service.ErrImportTaskNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.ImportTaskNotFound), c))
+	default:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// contentType Prometheus 文本格式的响应类型
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// GetMetrics godoc
+// @Summary      获取运行指标
+// @Description  按 Prometheus 文本格式输出验证码发送、校验与邮件投递等指标；配置了 METRICS_TOKEN 时需通过 Bearer 令牌访问
+// @Tags         运行指标
+// @Produce      plain
+// @Success      200  {string}  string  "Prometheus 文本格式的指标"
+// @Failure      401  {object}  vo.Result  "令牌无效"
+// @Failure      404  {object}  vo.Result  "未开启运行指标"
+// @Router       /metrics [get]
+func GetMetrics(c echo.Context) error {
+	config, err := configs.LoadConfig()
+	if err != nil || !config.MetricsConfig.MetricsEnabled {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "未开启运行指标"), c))
+	}
+
+	if token := config.MetricsConfig.MetricsToken; token != "" {
+		supplied := strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(supplied), []byte(token)) != 1 {
+			return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "运行指标令牌无效"), c))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := global.Metrics.WriteText(&buf); err != nil {
+		utils.BizLogger(c).Errorf("输出运行指标失败: %v", err)
+		return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+	}
+	return c.Blob(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,126 @@
+package post
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/service/post"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// SetPostAuthors godoc
+// @Summary      设置文章作者
+// @Description  以请求中的作者列表替换文章的全部作者，署名顺序与列表顺序一致；作者可以是 author 或 editor 角色，至少包含一位 author，仅管理员与文章角色为 author 的作者可用
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SetPostAuthorsRequest  true  "文章 ID 与作者列表"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]post.PostAuthorVo}  "设置成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误或作者列表无效"
+// @Failure      401     {object}   vo.Result          "未授权"
+// @Failure      403     {object}   vo.Result          "无权修改文章作者"
+// @Failure      404     {object}   vo.Result          "文章不存在"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/author/setAuthors [post]
+func SetPostAuthors(c echo.Context) error {
+	req := new(dto.SetPostAuthorsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	authors, err := service.SetPostAuthors(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostAuthorsInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PostAuthorsInvalid, err.Error()), c))
+	case errors.Is(err, service.ErrPostAuthorForbidden):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.PostAuthorForbidden), c))
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(authors, c))
+}
+
+// GetAuthors godoc
+// @Summary      获取作者列表
+// @Description  获取署名过已发布文章的作者及其文章数量，按文章数量降序排序，资料不公开的账户不返回
+// @Tags         文章
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=[]post.AuthorVo}  "获取成功"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/author/getAuthors [get]
+func GetAuthors(c echo.Context) error {
+	authors, err := service.GetAuthors(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(authors, c))
+}
+
+// GetAuthor godoc
+// @Summary      获取作者资料
+// @Description  获取作者页的公开资料与署名的已发布文章数量
+// @Tags         文章
+// @Produce      json
+// @Param        id   query     int64  true  "作者账户 ID"
+// @Success      200  {object}  vo.Result{data=post.AuthorVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      404  {object}  vo.Result                 "作者不存在"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/author/getAuthor [get]
+func GetAuthor(c echo.Context) error {
+	req := new(dto.GetAuthorRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	author, err := service.GetAuthor(req, c)
+	switch {
+	case errors.Is(err, service.ErrAuthorNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.AuthorNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(author, c))
+}
+
+// GetAuthorPosts godoc
+// @Summary      获取作者文章
+// @Description  分页获取作者署名的已发布文章，以编辑署名的文章也包含在内，按创建时间倒序排序
+// @Tags         文章
+// @Produce      json
+// @Param        id       query    int64   true   "作者账户 ID"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200  {object}  vo.Result{data=[]post.PostsVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      404  {object}  vo.Result                 "作者不存在"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/author/getAuthorPosts [get]
+func GetAuthorPosts(c echo.Context) error {
+	req := new(dto.GetAuthorPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	posts, meta, err := service.GetAuthorPosts(req, vo.ParsePage(c), c)
+	switch {
+	case errors.Is(err, service.ErrAuthorNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.AuthorNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(posts, meta, c))
+}
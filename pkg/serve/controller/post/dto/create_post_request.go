@@ -6,10 +6,18 @@ package dto
 // @Param	visibility			body	string	true	"文章可见性(可选,默认 private)"
 // @Param	content_html	    body	string	true	"文章内容(markdown格式)"
 // @Param	category_ids		body	[]int64	true	"文章分类ID列表"
+// @Param	slug				body	string	false	"文章别名(可选,默认由标题生成)"
+// @Param	access				body	string	false	"访问权限(可选,默认 public)，可选值: public, login, password"
+// @Param	access_password		body	string	false	"访问密码，access 为 password 时必填"
+// @Param	language			body	string	false	"文章语言(可选,默认 zh-CN)，BCP 47 语言标签"
 type CreateOnePostRequest struct {
 	Title           string `json:"title" xml:"title" form:"title" query:"title" validate:"required,min=1,max=225"`
 	Image           string `json:"image" xml:"image" form:"image" query:"image" default:""`
 	Visibility      bool   `json:"visibility" xml:"visibility" form:"visibility" query:"visibility" default:"false"`
 	ContentMarkdown string `json:"content_markdown" xml:"content_markdown" form:"content_markdown" query:"content_markdown" default:""`
 	CategoryIDs     string `json:"category_ids" xml:"category_ids" form:"category_ids" query:"category_ids"`
+	Slug            string `json:"slug" xml:"slug" form:"slug" query:"slug" validate:"max=255" default:""`
+	Access          string `json:"access" xml:"access" form:"access" query:"access" validate:"omitempty,oneof=public login password"`
+	AccessPassword  string `json:"access_password" xml:"access_password" form:"access_password" query:"access_password" validate:"omitempty,min=4,max=64"`
+	Language        string `json:"language" xml:"language" form:"language" query:"language" validate:"omitempty,max=16,bcp47_language_tag"`
 }
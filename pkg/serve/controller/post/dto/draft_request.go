@@ -0,0 +1,31 @@
+package dto
+
+// SaveDraftRequest          自动保存草稿请求参数结构体
+// @Param	post_id				body	int64	false	"正在编辑的文章 ID，新文章传 0"
+// @Param	title				body	string	false	"草稿标题"
+// @Param	image				body	string	false	"草稿图片"
+// @Param	content_markdown	body	string	false	"草稿内容(markdown格式)"
+// @Param	category_ids		body	[]int64	false	"草稿分类ID列表"
+// @Param	version				body	int64	false	"上次保存返回的版本号，首次保存传 0"
+// @Param	force				body	bool	false	"版本号冲突时是否强制覆盖"
+type SaveDraftRequest struct {
+	PostID          int64   `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"min=0"`
+	Title           string  `json:"title" xml:"title" form:"title" query:"title" validate:"max=225"`
+	Image           string  `json:"image" xml:"image" form:"image" query:"image" validate:"max=255"`
+	ContentMarkdown string  `json:"content_markdown" xml:"content_markdown" form:"content_markdown" query:"content_markdown"`
+	CategoryIDs     []int64 `json:"category_ids" xml:"category_ids" form:"category_ids" query:"category_ids"`
+	Version         int64   `json:"version" xml:"version" form:"version" query:"version" validate:"min=0"`
+	Force           bool    `json:"force" xml:"force" form:"force" query:"force"`
+}
+
+// GetDraftRequest           获取草稿请求参数结构体
+// @Param	post_id	query	int64	false	"文章 ID，新文章传 0"
+type GetDraftRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"min=0"`
+}
+
+// DiscardDraftRequest       丢弃草稿请求参数结构体
+// @Param	post_id	body	int64	false	"文章 ID，新文章传 0"
+type DiscardDraftRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"min=0"`
+}
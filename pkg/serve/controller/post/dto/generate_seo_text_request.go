@@ -0,0 +1,11 @@
+package dto
+
+// GenerateSeoTextRequest    生成文章摘要与 SEO 文本请求参数结构体
+// @Param	post_id	body	int64		true	"文章 ID"
+// @Param	fields	body	[]string	false	"需要生成的字段，可选值: summary, meta_description, social_blurb，不传时全部生成"
+// @Param	apply	body	bool		false	"是否写入文章，仅写入尚未填写的字段，不会覆盖已有文本"
+type GenerateSeoTextRequest struct {
+	PostID int64    `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Fields []string `json:"fields" xml:"fields" form:"fields" query:"fields" validate:"omitempty,dive,oneof=summary meta_description social_blurb"`
+	Apply  bool     `json:"apply" xml:"apply" form:"apply" query:"apply"`
+}
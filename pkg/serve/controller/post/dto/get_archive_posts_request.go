@@ -0,0 +1,9 @@
+package dto
+
+// GetArchivePostsRequest    按归档时间获取文章的请求参数结构体
+// @Param	year	query	int	true	"年份"
+// @Param	month	query	int	false	"月份，不传时返回全年的文章"
+type GetArchivePostsRequest struct {
+	Year  int `json:"year" xml:"year" form:"year" query:"year" validate:"required,min=1970,max=9999"`
+	Month int `json:"month" xml:"month" form:"month" query:"month" validate:"omitempty,min=1,max=12"`
+}
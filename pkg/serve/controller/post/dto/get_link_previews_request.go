@@ -0,0 +1,7 @@
+package dto
+
+// GetLinkPreviewsRequest    获取文章外部链接预览请求参数结构体
+// @Param	post_id	query	int64	true	"文章 ID"
+type GetLinkPreviewsRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+}
@@ -3,7 +3,17 @@ package dto
 // GetOnePostRequest        获取文章的请求结构体
 // @Param	id		path	string	true	"文章 ID"
 // @Param	title	query	string	false	"文章标题"
+// @Param	lang	query	string	false	"语言，文章有该语言的翻译时返回翻译"
 type GetOnePostRequest struct {
 	ID    int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0" default:"0"`
 	Title string `json:"title" xml:"title" form:"title" query:"title" validate:"max=225" default:""`
+	Lang  string `json:"lang" xml:"lang" form:"lang" query:"lang" validate:"max=16" default:""`
+}
+
+// GetPostBySlugRequest     根据别名获取文章的请求结构体
+// @Param	slug	path	string	true	"文章别名"
+// @Param	lang	query	string	false	"语言，文章有该语言的翻译时重定向到翻译的地址"
+type GetPostBySlugRequest struct {
+	Slug string `param:"slug" validate:"required,max=255"`
+	Lang string `query:"lang" validate:"max=16"`
 }
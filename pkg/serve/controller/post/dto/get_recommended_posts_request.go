@@ -0,0 +1,9 @@
+package dto
+
+// GetRecommendedPostsRequest    获取推荐文章请求参数结构体
+// @Param	post_id	query	int64	false	"当前阅读的文章 ID，传入时优先推荐同类目的文章"
+// @Param	limit	query	int		false	"返回数量，默认 5，最大 20"
+type GetRecommendedPostsRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"min=0"`
+	Limit  int   `json:"limit" xml:"limit" form:"limit" query:"limit" validate:"omitempty,min=1,max=20"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// GetRelatedPostsRequest    获取相关文章请求参数结构体
+// @Param	id		path	int64	true	"文章 ID"
+// @Param	limit	query	int		false	"返回数量，默认 5，最大 20"
+type GetRelatedPostsRequest struct {
+	ID    int64 `param:"id" validate:"required,gt=0"`
+	Limit int   `json:"limit" xml:"limit" form:"limit" query:"limit" validate:"omitempty,min=1,max=20"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// GetTrendingPostsRequest    获取热门文章请求参数结构体
+// @Param	days	query	int		false	"统计最近几天的浏览量，默认为配置的 TRENDING_DAYS，最大 90"
+// @Param	limit	query	int		false	"返回数量，默认 10，最大 50"
+type GetTrendingPostsRequest struct {
+	Days  int `json:"days" xml:"days" form:"days" query:"days" validate:"omitempty,min=1,max=90"`
+	Limit int `json:"limit" xml:"limit" form:"limit" query:"limit" validate:"omitempty,min=1,max=50"`
+}
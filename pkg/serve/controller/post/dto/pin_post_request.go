@@ -0,0 +1,27 @@
+package dto
+
+// SetPostPinnedRequest    设置文章置顶请求参数结构体
+// @Param	id		body	int64	true	"文章 ID"
+// @Param	pinned	body	bool	false	"是否置顶，false 表示取消置顶"
+// @Param	order	body	int		false	"置顶顺序，数值小的在前，默认 0"
+type SetPostPinnedRequest struct {
+	ID     int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+	Pinned bool  `json:"pinned" xml:"pinned" form:"pinned" query:"pinned"`
+	Order  int   `json:"order" xml:"order" form:"order" query:"order" validate:"min=0,max=9999"`
+}
+
+// SetPostFeaturedRequest    设置文章精选请求参数结构体
+// @Param	id			body	int64	true	"文章 ID"
+// @Param	featured	body	bool	false	"是否精选，false 表示取消精选"
+// @Param	order		body	int		false	"精选顺序，数值小的在前，默认 0"
+type SetPostFeaturedRequest struct {
+	ID       int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+	Featured bool  `json:"featured" xml:"featured" form:"featured" query:"featured"`
+	Order    int   `json:"order" xml:"order" form:"order" query:"order" validate:"min=0,max=9999"`
+}
+
+// GetFeaturedPostsRequest    获取精选文章请求参数结构体
+// @Param	limit	query	int	false	"返回数量，默认 5，最大 20"
+type GetFeaturedPostsRequest struct {
+	Limit int `json:"limit" xml:"limit" form:"limit" query:"limit" validate:"omitempty,min=1,max=20"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// SetPostAuthorsRequest    设置文章作者请求参数结构体
+// @Param	post_id	body	int64				true	"文章 ID"
+// @Param	authors	body	[]PostAuthorItem	true	"作者列表，按署名顺序排列，至少包含一位角色为 author 的作者"
+type SetPostAuthorsRequest struct {
+	PostID  int64             `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Authors []*PostAuthorItem `json:"authors" xml:"authors" form:"authors" query:"authors" validate:"required,min=1,max=20,dive,required"`
+}
+
+// PostAuthorItem    文章作者
+// @Param	account_id	body	int64	true	"作者账户 ID"
+// @Param	role		body	string	false	"署名角色，可选值: author, editor，默认 author"
+type PostAuthorItem struct {
+	AccountID int64  `json:"account_id" xml:"account_id" form:"account_id" query:"account_id" validate:"required,gt=0"`
+	Role      string `json:"role" xml:"role" form:"role" query:"role" validate:"omitempty,oneof=author editor"`
+}
+
+// GetAuthorRequest    获取作者资料请求参数结构体
+// @Param	id	query	int64	true	"作者账户 ID"
+type GetAuthorRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
+
+// GetAuthorPostsRequest    获取作者文章请求参数结构体
+// @Param	id	query	int64	true	"作者账户 ID"
+type GetAuthorPostsRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+// GetAllPostsRequest    文章列表筛选参数，分页参数见 page、pageSize 与 cursor
+// @Param	status	query	string	false	"发布状态，可选值: draft, published, archived，仅管理员可筛选，其他请求只返回已发布的文章"
+// @Param	lang	query	string	false	"语言，有翻译的文章只返回该语言的版本；未传入时访客按 Accept-Language 协商，登录用户返回全部语言"
+type GetAllPostsRequest struct {
+	Status string `json:"status" xml:"status" form:"status" query:"status" validate:"omitempty,oneof=draft published archived"`
+	Lang   string `json:"lang" xml:"lang" form:"lang" query:"lang" validate:"max=16"`
+}
+
+// UpdatePostStatusRequest    变更文章发布状态请求参数结构体
+// @Param	id		body	int64	true	"文章 ID"
+// @Param	status	body	string	true	"目标状态，可选值: draft, published, archived"
+type UpdatePostStatusRequest struct {
+	ID     int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+	Status string `json:"status" xml:"status" form:"status" query:"status" validate:"required,oneof=draft published archived"`
+}
+
+// SchedulePostRequest    设置定时发布请求参数结构体
+// @Param	id			body	int64	true	"文章 ID"
+// @Param	publish_at	body	int64	true	"定时发布时间（Unix 秒），需晚于当前时间，0 表示取消定时发布"
+type SchedulePostRequest struct {
+	ID        int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+	PublishAt int64 `json:"publish_at" xml:"publish_at" form:"publish_at" query:"publish_at" validate:"min=0"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// LinkPostTranslationRequest    关联文章翻译请求参数结构体
+// @Param	post_id		body	int64	true	"作为翻译的文章 ID"
+// @Param	source_id	body	int64	true	"原文或翻译组内任意一篇文章的 ID"
+type LinkPostTranslationRequest struct {
+	PostID   int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	SourceID int64 `json:"source_id" xml:"source_id" form:"source_id" query:"source_id" validate:"required,gt=0"`
+}
+
+// UnlinkPostTranslationRequest    解除文章翻译关联请求参数结构体
+// @Param	post_id		body	int64	true	"文章 ID"
+type UnlinkPostTranslationRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+}
+
+// GetPostTranslationsRequest    获取文章翻译请求参数结构体
+// @Param	id	query	int64	true	"文章 ID"
+type GetPostTranslationsRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// RestorePostRequest    从回收站恢复文章请求
+// @Param id body int true "文章 ID"
+type RestorePostRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
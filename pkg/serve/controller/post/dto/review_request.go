@@ -0,0 +1,33 @@
+package dto
+
+// SubmitForReviewRequest    提交文章审核请求参数结构体
+// @Param	post_id	body	int64	true	"文章 ID"
+// @Param	comment	body	string	false	"提交说明"
+type SubmitForReviewRequest struct {
+	PostID  int64  `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Comment string `json:"comment" xml:"comment" form:"comment" query:"comment" validate:"max=1024"`
+}
+
+// ApproveReviewRequest      审核通过请求参数结构体
+// @Param	post_id		body	int64	true	"文章 ID"
+// @Param	comment		body	string	false	"审核意见"
+// @Param	publish_at	body	int64	false	"定时发布时间（Unix 秒），不传或早于当前时间时立即发布"
+type ApproveReviewRequest struct {
+	PostID    int64  `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Comment   string `json:"comment" xml:"comment" form:"comment" query:"comment" validate:"max=1024"`
+	PublishAt int64  `json:"publish_at" xml:"publish_at" form:"publish_at" query:"publish_at" validate:"min=0"`
+}
+
+// RequestReviewChangesRequest    审核退回请求参数结构体
+// @Param	post_id	body	int64	true	"文章 ID"
+// @Param	comment	body	string	true	"修改意见"
+type RequestReviewChangesRequest struct {
+	PostID  int64  `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Comment string `json:"comment" xml:"comment" form:"comment" query:"comment" validate:"required,max=1024"`
+}
+
+// GetReviewHistoryRequest   获取审核记录请求参数结构体
+// @Param	post_id	query	int64	true	"文章 ID"
+type GetReviewHistoryRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+}
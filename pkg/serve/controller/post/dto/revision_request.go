@@ -0,0 +1,25 @@
+package dto
+
+// GetPostRevisionsRequest    获取文章修订列表请求参数结构体，分页参数见 page、pageSize 与 cursor
+// @Param	post_id	query	int64	true	"文章 ID"
+type GetPostRevisionsRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+}
+
+// GetRevisionDiffRequest    对比文章修订请求参数结构体
+// @Param	post_id	query	int64	true	"文章 ID"
+// @Param	from	query	int		true	"旧修订的版本号"
+// @Param	to		query	int		false	"新修订的版本号，不传或为 0 时与文章当前内容对比"
+type GetRevisionDiffRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	From   int   `json:"from" xml:"from" form:"from" query:"from" validate:"required,gt=0"`
+	To     int   `json:"to" xml:"to" form:"to" query:"to" validate:"min=0"`
+}
+
+// RestoreRevisionRequest    恢复文章修订请求参数结构体
+// @Param	post_id	body	int64	true	"文章 ID"
+// @Param	version	body	int		true	"要恢复的修订版本号"
+type RestoreRevisionRequest struct {
+	PostID  int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Version int   `json:"version" xml:"version" form:"version" query:"version" validate:"required,gt=0"`
+}
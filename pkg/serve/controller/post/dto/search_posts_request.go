@@ -0,0 +1,7 @@
+package dto
+
+// SearchPostsRequest        搜索文章请求参数结构体
+// @Param	keyword	query	string	true	"搜索关键词，多个关键词以空格分隔"
+type SearchPostsRequest struct {
+	Keyword string `json:"keyword" xml:"keyword" form:"keyword" query:"keyword" validate:"required,min=1,max=100"`
+}
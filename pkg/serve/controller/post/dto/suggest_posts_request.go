@@ -0,0 +1,9 @@
+package dto
+
+// SuggestPostsRequest       搜索建议请求参数结构体
+// @Param	prefix	query	string	true	"搜索前缀"
+// @Param	limit	query	int		false	"返回数量，默认 10，最大 20"
+type SuggestPostsRequest struct {
+	Prefix string `json:"prefix" xml:"prefix" form:"prefix" query:"prefix" validate:"required,min=1,max=100"`
+	Limit  int    `json:"limit" xml:"limit" form:"limit" query:"limit" validate:"omitempty,min=1,max=20"`
+}
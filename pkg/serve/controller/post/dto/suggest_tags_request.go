@@ -0,0 +1,15 @@
+package dto
+
+// SuggestTagsRequest        标签推荐请求参数结构体
+// @Param	post_id				body	int64	false	"正在编辑的文章 ID，传入时排除该文章自身"
+// @Param	title				body	string	false	"草稿标题"
+// @Param	content_markdown	body	string	false	"草稿正文"
+// @Param	source				body	string	false	"推荐方式，可选值: tfidf, llm，默认 tfidf"
+// @Param	limit				body	int		false	"返回数量，默认 5，最大 20"
+type SuggestTagsRequest struct {
+	PostID          int64  `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"min=0"`
+	Title           string `json:"title" xml:"title" form:"title" query:"title" validate:"required_without=ContentMarkdown,max=225"`
+	ContentMarkdown string `json:"content_markdown" xml:"content_markdown" form:"content_markdown" query:"content_markdown" validate:"required_without=Title"`
+	Source          string `json:"source" xml:"source" form:"source" query:"source" validate:"omitempty,oneof=tfidf llm"`
+	Limit           int    `json:"limit" xml:"limit" form:"limit" query:"limit" validate:"omitempty,min=1,max=20"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// UnlockPostRequest    解锁密码保护文章请求参数结构体
+// @Param	id			body	int64	true	"文章 ID"
+// @Param	password	body	string	true	"访问密码"
+type UnlockPostRequest struct {
+	ID       int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+	Password string `json:"password" xml:"password" form:"password" query:"password" validate:"required,max=64"`
+}
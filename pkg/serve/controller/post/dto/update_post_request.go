@@ -7,6 +7,10 @@ package dto
 // @Param   visibility 	      body 	  string        false     "文章可见性(可选)"
 // @Param   content_markdown  body    string 		false     "文章内容(markdown格式)"
 // @Param   category_ids 	  body    interface{}       false     "文章分类ID列表(可选)"
+// @Param   slug 	          body    string        false     "文章别名(可选)，修改后旧别名重定向到新别名"
+// @Param   access 	          body    string        false     "访问权限(可选)，可选值: public, login, password"
+// @Param   access_password   body    string        false     "访问密码(可选)，改为 password 且文章尚未设置密码时必填，传入时替换原密码"
+// @Param   language          body    string        false     "文章语言(可选)，BCP 47 语言标签，不能与翻译组内其他文章的语言相同"
 type UpdateOnePostRequest struct {
 	ID              int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
 	Title           string `json:"title" xml:"title" form:"title" query:"title" validate:"min=0,max=255" default:""`
@@ -14,4 +18,8 @@ type UpdateOnePostRequest struct {
 	Visibility      bool   `json:"visibility" xml:"visibility" form:"visibility" query:"visibility" default:"false"`
 	ContentMarkdown string `json:"content_markdown" xml:"content_markdown" form:"content_markdown" query:"content_markdown" default:""`
 	CategoryIDs     string `json:"category_ids" xml:"category_ids" form:"category_ids" query:"category_ids" default:""`
+	Slug            string `json:"slug" xml:"slug" form:"slug" query:"slug" validate:"max=255" default:""`
+	Access          string `json:"access" xml:"access" form:"access" query:"access" validate:"omitempty,oneof=public login password"`
+	AccessPassword  string `json:"access_password" xml:"access_password" form:"access_password" query:"access_password" validate:"omitempty,min=4,max=64"`
+	Language        string `json:"language" xml:"language" form:"language" query:"language" validate:"omitempty,max=16,bcp47_language_tag"`
 }
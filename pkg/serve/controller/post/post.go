@@ -1,12 +1,15 @@
 package post
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
+	"net/url"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 
 	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/llm"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/post/dto"
 	"jank.com/jank_blog/pkg/serve/service/post"
@@ -15,28 +18,29 @@ import (
 
 // GetOnePost    godoc
 // @Summary      获取文章详情
-// @Description  根据文章 ID 或标题获取文章的详细信息，至少需要提供其中一个参数
+// @Description  根据文章 ID 或标题获取文章的详细信息，至少需要提供其中一个参数；草稿与归档的文章仅对其作者与管理员可见，其他请求视为不存在；
+// @Description  密码保护的文章未解锁时不返回正文并标记为 locked，解锁后在请求头 X-Post-Access-Token 中携带访问令牌
 // @Tags         文章
 // @Accept       json
 // @Produce      json
-// @Param        request  body      dto.GetOnePostRequest  true  "获取文章请求参数"
+// @Param        request  body      dto.GetOnePostRequest  true  "获取文章请求参数，传入 lang 且文章有该语言的翻译时返回翻译"
+// @Param        X-Post-Access-Token  header  string  false  "文章访问令牌，多个以逗号分隔"
 // @Success      200      {object}  vo.Result{data=post.PostsVo}  "获取成功"
 // @Failure      400      {object}  vo.Result          "请求参数错误"
+// @Failure      401      {object}  vo.Result          "文章仅登录用户可见"
 // @Failure      404      {object}  vo.Result          "文章不存在"
 // @Failure      500      {object}  vo.Result          "服务器错误"
 // @Router       /post/getOnePost [get]
 func GetOnePost(c echo.Context) error {
 	req := new(dto.GetOnePostRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	pos, err := service.GetOnePostByIDOrTitle(req, c)
+	if errors.Is(err, service.ErrPostLoginRequired) {
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.PostLoginRequired), c))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(bizErr.New(bizErr.ServerError, err.Error()), nil, c))
 	}
@@ -44,27 +48,750 @@ func GetOnePost(c echo.Context) error {
 	return c.JSON(http.StatusOK, vo.Success(pos, c))
 }
 
+// GetPostBySlug godoc
+// @Summary      根据别名获取文章
+// @Description  根据文章别名获取文章详情；别名是文章修改前的旧别名或格式不规范时以 301 重定向到当前别名的地址；草稿与归档的文章仅对其作者与管理员可见，其他请求视为不存在
+// @Tags         文章
+// @Produce      json
+// @Param        slug  path      string  true  "文章别名"
+// @Param        lang  query     string  false "语言，文章有该语言的翻译时重定向到翻译的地址"
+// @Param        X-Post-Access-Token  header  string  false  "文章访问令牌，多个以逗号分隔"
+// @Success      200   {object}  vo.Result{data=post.PostsVo}  "获取成功"
+// @Success      301   "重定向到文章当前别名的地址"
+// @Failure      400   {object}  vo.Result          "请求参数错误"
+// @Failure      401   {object}  vo.Result          "文章仅登录用户可见"
+// @Failure      404   {object}  vo.Result          "文章不存在"
+// @Failure      500   {object}  vo.Result          "服务器错误"
+// @Router       /post/slug/{slug} [get]
+func GetPostBySlug(c echo.Context) error {
+	req := new(dto.GetPostBySlugRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	pos, current, err := service.GetPostBySlug(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	case errors.Is(err, service.ErrPostLoginRequired):
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.PostLoginRequired), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(bizErr.New(bizErr.ServerError, err.Error()), nil, c))
+	case current != "":
+		target := strings.Replace(c.Path(), ":slug", url.PathEscape(current), 1)
+		if query := c.QueryString(); query != "" {
+			target += "?" + query
+		}
+		return c.Redirect(http.StatusMovedPermanently, target)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(pos, c))
+}
+
+// UnlockPost    godoc
+// @Summary      解锁密码保护的文章
+// @Description  校验文章的访问密码，通过后返回仅对该文章有效的访问令牌，获取文章时在请求头 X-Post-Access-Token 中携带；修改访问密码后此前的令牌失效，同一 IP 失败次数过多时暂停解锁
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlockPostRequest  true  "文章 ID 与访问密码"
+// @Success      200      {object}  vo.Result{data=post.PostAccessTokenVo}  "解锁成功"
+// @Failure      400      {object}  vo.Result          "请求参数错误或文章未设置密码保护"
+// @Failure      403      {object}  vo.Result          "访问密码错误"
+// @Failure      404      {object}  vo.Result          "文章不存在"
+// @Failure      429      {object}  vo.Result          "解锁尝试次数过多"
+// @Failure      500      {object}  vo.Result          "服务器错误"
+// @Router       /post/unlockPost [post]
+func UnlockPost(c echo.Context) error {
+	req := new(dto.UnlockPostRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	token, err := service.UnlockPost(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	case errors.Is(err, service.ErrPostNotProtected):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PostNotProtected), c))
+	case errors.Is(err, service.ErrPostPasswordIncorrect):
+		return c.JSON(http.StatusForbidden, vo.Fail(nil, bizErr.New(bizErr.PostPasswordIncorrect), c))
+	case errors.Is(err, service.ErrPostUnlockLimited):
+		return c.JSON(http.StatusTooManyRequests, vo.Fail(nil, bizErr.New(bizErr.PostUnlockLimited), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(token, c))
+}
+
 // GetAllPosts   godoc
 // @Summary      获取文章列表
-// @Description  获取所有的文章列表，按创建时间倒序排序
+// @Description  获取文章列表，置顶的文章按置顶顺序排在最前，其余按创建时间倒序排序；非管理员只返回已发布的文章，管理员可按发布状态筛选，不传时返回全部
 // @Tags         文章
 // @Accept       json
 // @Produce      json
+// @Param        status   query    string  false  "发布状态，可选值: draft, published, archived"
+// @Param        lang     query    string  false  "语言，有翻译的文章只返回该语言的版本，没有该语言时返回原文；未传入时访客按 Accept-Language 协商，登录用户返回全部语言"
 // @Param        page     query    int     false  "页码"
-// @Param        pageSize query    int     false  "每页显示数量"
-// @Success      200  {object}  vo.Result{data=[]post.PostsVo}  "获取成功"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200  {object}  vo.Result{data=[]post.PostsVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
 // @Failure      500  {object}  vo.Result                 "服务器错误"
 // @Router       /post/getAllPosts [get]
 func GetAllPosts(c echo.Context) error {
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	pageSize, _ := strconv.Atoi(c.QueryParam("pageSize"))
+	req := new(dto.GetAllPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
 
-	response, err := service.GetAllPostsWithPagingAndFormat(page, pageSize, c)
+	posts, meta, err := service.GetAllPostsWithPagingAndFormat(req, vo.ParsePage(c, 5), c)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(bizErr.New(bizErr.ServerError, err.Error()), nil, c))
 	}
 
-	return c.JSON(http.StatusOK, vo.Success(response, c))
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(posts, meta, c))
+}
+
+// SearchPosts   godoc
+// @Summary      搜索文章
+// @Description  按关键词搜索已发布文章的标题与正文，结果按相关度排序；开启模糊匹配时拼写相近的关键词也能命中，但相关度会打折扣
+// @Tags         文章
+// @Produce      json
+// @Param        keyword  query    string  true   "搜索关键词，多个关键词以空格分隔"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200  {object}  vo.Result{data=[]post.SearchResultVo,page=vo.PageMeta}  "搜索成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/search [get]
+func SearchPosts(c echo.Context) error {
+	req := new(dto.SearchPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	results, meta, err := service.SearchPosts(req, vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(results, meta, c))
+}
+
+// SuggestPosts  godoc
+// @Summary      获取搜索建议
+// @Description  根据输入前缀返回已发布文章的标题与标签补全，不区分大小写
+// @Tags         文章
+// @Produce      json
+// @Param        prefix  query    string  true   "搜索前缀"
+// @Param        limit   query    int     false  "返回数量，默认 10，最大 20"
+// @Success      200  {object}  vo.Result{data=[]post.SuggestionVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/search/suggest [get]
+func SuggestPosts(c echo.Context) error {
+	req := new(dto.SuggestPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	suggestions, err := service.SuggestPosts(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(suggestions, c))
+}
+
+// GetArchives   godoc
+// @Summary      获取文章归档
+// @Description  按年月统计已发布文章的数量，按时间倒序排列，用于渲染归档侧边栏
+// @Tags         文章
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=[]post.ArchiveVo}  "获取成功"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/archive/getArchives [get]
+func GetArchives(c echo.Context) error {
+	archives, err := service.GetArchives(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(archives, c))
+}
+
+// GetArchive   godoc
+// @Summary      获取按年月分组的文章归档
+// @Description  按年、月分组返回已发布文章的标题与数量，年、月与文章均按时间倒序排列，用于渲染归档页；结果缓存 30 分钟，文章变更时失效
+// @Tags         文章
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=[]post.ArchiveYearVo}  "获取成功"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/archive [get]
+func GetArchive(c echo.Context) error {
+	archive, err := service.GetArchive(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(archive, c))
+}
+
+// GetArchivePosts godoc
+// @Summary      获取归档文章
+// @Description  分页获取指定年份或年月内已发布的文章，按创建时间倒序排序
+// @Tags         文章
+// @Produce      json
+// @Param        year     query    int     true   "年份"
+// @Param        month    query    int     false  "月份，不传时返回全年的文章"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200  {object}  vo.Result{data=[]post.PostsVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/archive/getArchivePosts [get]
+func GetArchivePosts(c echo.Context) error {
+	req := new(dto.GetArchivePostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	posts, meta, err := service.GetArchivePosts(req, vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(posts, meta, c))
+}
+
+// GetRecommendedPosts godoc
+// @Summary      获取推荐文章
+// @Description  获取推荐的已发布文章，传入当前文章 ID 时优先推荐同类目的文章；携带登录凭证时排除已读过的文章
+// @Tags         文章
+// @Produce      json
+// @Param        post_id  query    int64   false  "当前阅读的文章 ID"
+// @Param        limit    query    int     false  "返回数量，默认 5，最大 20"
+// @Success      200  {object}  vo.Result{data=[]post.PostsVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/getRecommendedPosts [get]
+func GetRecommendedPosts(c echo.Context) error {
+	req := new(dto.GetRecommendedPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	posts, err := service.GetRecommendedPosts(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(posts, c))
+}
+
+// GetTrendingPosts godoc
+// @Summary      获取热门文章
+// @Description  获取最近若干天浏览量最高的已发布文章，浏览量定期由缓冲写入数据库，最近一个写入间隔内的浏览不计入
+// @Tags         文章
+// @Produce      json
+// @Param        days     query    int     false  "统计最近几天的浏览量，默认为配置的 TRENDING_DAYS，最大 90"
+// @Param        limit    query    int     false  "返回数量，默认 10，最大 50"
+// @Success      200  {object}  vo.Result{data=[]post.TrendingPostVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/getTrendingPosts [get]
+func GetTrendingPosts(c echo.Context) error {
+	req := new(dto.GetTrendingPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	posts, err := service.GetTrendingPosts(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(posts, c))
+}
+
+// GetRelatedPosts godoc
+// @Summary      获取相关文章
+// @Description  获取与指定文章内容相近或类目相同的已发布文章，按相关度倒序排序；草稿与归档的文章仅对其作者与管理员可见，其他请求视为不存在
+// @Tags         文章
+// @Produce      json
+// @Param        id       path     int64   true   "文章 ID"
+// @Param        limit    query    int     false  "返回数量，默认 5，最大 20"
+// @Success      200  {object}  vo.Result{data=[]post.RelatedPostVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      401  {object}  vo.Result                 "文章仅登录用户可见"
+// @Failure      404  {object}  vo.Result                 "文章不存在"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/{id}/related [get]
+func GetRelatedPosts(c echo.Context) error {
+	req := new(dto.GetRelatedPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	posts, err := service.GetRelatedPosts(req, c)
+	if errors.Is(err, service.ErrPostNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	}
+	if errors.Is(err, service.ErrPostLoginRequired) {
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.PostLoginRequired), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(posts, c))
+}
+
+// GetLinkPreviews godoc
+// @Summary      获取文章外部链接预览
+// @Description  抓取文章中外部链接的标题、描述与图片，用于渲染链接卡片；结果会缓存，仅访问公网地址；密码保护的文章未解锁时返回空列表
+// @Tags         文章
+// @Produce      json
+// @Param        post_id  query    int64  true  "文章 ID"
+// @Success      200  {object}  vo.Result{data=[]post.LinkPreviewVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      401  {object}  vo.Result                 "文章仅登录用户可见"
+// @Failure      404  {object}  vo.Result                 "文章不存在"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/getLinkPreviews [get]
+func GetLinkPreviews(c echo.Context) error {
+	req := new(dto.GetLinkPreviewsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	previews, err := service.GetLinkPreviews(req, c)
+	if errors.Is(err, service.ErrPostNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	}
+	if errors.Is(err, service.ErrPostLoginRequired) {
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.PostLoginRequired), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(previews, c))
+}
+
+// GenerateSeoText godoc
+// @Summary      生成文章摘要与 SEO 文本
+// @Description  使用配置的大模型为文章生成摘要、meta 描述与分享文案；apply 为 true 时仅写入尚未填写的字段，不会覆盖已有文本
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.GenerateSeoTextRequest  true  "生成参数"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.SeoTextVo}  "生成成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      503     {object}   vo.Result          "未开启大模型服务"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/generateSeoText [post]
+func GenerateSeoText(c echo.Context) error {
+	req := new(dto.GenerateSeoTextRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	text, err := service.GenerateSeoText(req, c)
+	if errors.Is(err, llm.ErrDisabled) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.LLMDisabled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(text, c))
+}
+
+// SuggestTags godoc
+// @Summary      推荐文章标签
+// @Description  根据草稿标题与正文推荐已有的类目，默认按 TF-IDF 汇总相似文章的类目，也可使用配置的大模型挑选
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SuggestTagsRequest  true  "草稿内容"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]post.TagSuggestionVo}  "推荐成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      503     {object}   vo.Result          "未开启大模型服务"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/suggestTags [post]
+func SuggestTags(c echo.Context) error {
+	req := new(dto.SuggestTagsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	tags, err := service.SuggestTags(req, c)
+	if errors.Is(err, llm.ErrDisabled) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.LLMDisabled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(tags, c))
+}
+
+// SaveDraft godoc
+// @Summary      自动保存草稿
+// @Description  保存编辑中的草稿，需携带上次保存返回的版本号；版本号过期时返回 409 与最新草稿，可传 force 强制覆盖
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SaveDraftRequest  true  "草稿内容"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.DraftVo}  "保存成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      409     {object}   vo.Result{data=post.DraftVo}  "草稿版本冲突"
+// @Failure      503     {object}   vo.Result          "草稿存储不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/draft/saveDraft [post]
+func SaveDraft(c echo.Context) error {
+	req := new(dto.SaveDraftRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	draft, err := service.SaveDraft(req, c)
+	switch {
+	case errors.Is(err, service.ErrDraftConflict):
+		return c.JSON(http.StatusConflict, vo.Fail(draft, bizErr.New(bizErr.DraftConflict), c))
+	case errors.Is(err, service.ErrDraftUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.DraftUnavailable), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(draft, c))
+}
+
+// GetDraft godoc
+// @Summary      获取草稿
+// @Description  获取当前账户自动保存的草稿，没有草稿时返回空；stale 为 true 表示开始编辑后文章已被更新
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        post_id  query     int64  false  "文章 ID，新文章传 0"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.DraftVo}  "获取成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      503     {object}   vo.Result          "草稿存储不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/draft/getDraft [get]
+func GetDraft(c echo.Context) error {
+	req := new(dto.GetDraftRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	draft, err := service.GetDraft(req, c)
+	if errors.Is(err, service.ErrDraftUnavailable) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.DraftUnavailable), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(draft, c))
+}
+
+// DiscardDraft godoc
+// @Summary      丢弃草稿
+// @Description  丢弃当前账户自动保存的草稿
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DiscardDraftRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result          "丢弃成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      503     {object}   vo.Result          "草稿存储不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/draft/discardDraft [post]
+func DiscardDraft(c echo.Context) error {
+	req := new(dto.DiscardDraftRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.DiscardDraft(req, c)
+	if errors.Is(err, service.ErrDraftUnavailable) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.DraftUnavailable), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("草稿已丢弃", c))
+}
+
+// AcquireEditLock godoc
+// @Summary      获取文章编辑锁
+// @Description  打开文章编辑时加锁，文章正在被其他账户编辑时返回 409 与当前持有者，可选择等待或接管
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.EditLockRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.EditLockVo}  "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      409     {object}   vo.Result{data=post.EditLockVo}  "文章正在被其他账户编辑"
+// @Failure      503     {object}   vo.Result          "编辑锁不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/lock/acquireEditLock [post]
+func AcquireEditLock(c echo.Context) error {
+	req := new(dto.EditLockRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	lock, err := service.AcquireEditLock(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostEditLocked):
+		return c.JSON(http.StatusConflict, vo.Fail(lock, bizErr.New(bizErr.PostEditLocked), c))
+	case errors.Is(err, service.ErrEditLockUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.EditLockUnavailable), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(lock, c))
+}
+
+// RenewEditLock godoc
+// @Summary      续期文章编辑锁
+// @Description  编辑器按心跳间隔续期编辑锁，锁已过期或被其他账户接管时返回 409 与当前持有者
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.EditLockRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.EditLockVo}  "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      409     {object}   vo.Result{data=post.EditLockVo}  "编辑锁已失效"
+// @Failure      503     {object}   vo.Result          "编辑锁不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/lock/renewEditLock [post]
+func RenewEditLock(c echo.Context) error {
+	req := new(dto.EditLockRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	lock, err := service.RenewEditLock(req, c)
+	switch {
+	case errors.Is(err, service.ErrEditLockLost):
+		return c.JSON(http.StatusConflict, vo.Fail(lock, bizErr.New(bizErr.EditLockLost), c))
+	case errors.Is(err, service.ErrEditLockUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.EditLockUnavailable), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(lock, c))
+}
+
+// TakeOverEditLock godoc
+// @Summary      接管文章编辑锁
+// @Description  接管被遗弃的编辑锁，原持有者下次续期时将收到锁已失效的提示
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.EditLockRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.EditLockVo}  "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      503     {object}   vo.Result          "编辑锁不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/lock/takeOverEditLock [post]
+func TakeOverEditLock(c echo.Context) error {
+	req := new(dto.EditLockRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	lock, err := service.TakeOverEditLock(req, c)
+	switch {
+	case errors.Is(err, service.ErrEditLockUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.EditLockUnavailable), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(lock, c))
+}
+
+// ReleaseEditLock godoc
+// @Summary      释放文章编辑锁
+// @Description  关闭编辑器时释放编辑锁，锁不由当前账户持有时不做处理
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.EditLockRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      503     {object}   vo.Result          "编辑锁不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/lock/releaseEditLock [post]
+func ReleaseEditLock(c echo.Context) error {
+	req := new(dto.EditLockRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.ReleaseEditLock(req, c)
+	switch {
+	case errors.Is(err, service.ErrEditLockUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.EditLockUnavailable), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("编辑锁已释放", c))
+}
+
+// GetEditLock godoc
+// @Summary      获取文章编辑状态
+// @Description  获取文章当前的编辑锁，用于提示文章正在被谁编辑，无人编辑时返回空
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        post_id  query     int64  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.EditLockVo}  "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      503     {object}   vo.Result          "编辑锁不可用"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/lock/getEditLock [get]
+func GetEditLock(c echo.Context) error {
+	req := new(dto.EditLockRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	lock, err := service.GetEditLock(req, c)
+	switch {
+	case errors.Is(err, service.ErrEditLockUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.EditLockUnavailable), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(lock, c))
+}
+
+// GetDuplicatePosts godoc
+// @Summary      获取疑似重复的文章
+// @Description  按内容指纹列出内容几乎相同的文章对，按距离升序排列，常见于重复提交或重复导入
+// @Tags         文章
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]post.DuplicatePairVo}  "获取成功"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/getDuplicatePosts [get]
+func GetDuplicatePosts(c echo.Context) error {
+	pairs, err := service.GetDuplicatePosts(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(pairs, c))
+}
+
+// GetFeaturedPosts godoc
+// @Summary      获取精选文章
+// @Description  获取已发布的精选文章，按精选顺序排列，用于首页轮播
+// @Tags         文章
+// @Produce      json
+// @Param        limit    query    int     false  "返回数量，默认 5，最大 20"
+// @Success      200  {object}  vo.Result{data=[]post.PostsVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/getFeaturedPosts [get]
+func GetFeaturedPosts(c echo.Context) error {
+	req := new(dto.GetFeaturedPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	posts, err := service.GetFeaturedPosts(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(posts, c))
+}
+
+// SetPostPinned godoc
+// @Summary      设置文章置顶
+// @Description  设置或取消文章置顶，置顶的文章在文章列表中按置顶顺序排在最前，仅管理员可操作
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SetPostPinnedRequest  true  "文章 ID、是否置顶与置顶顺序"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.PostsVo}  "设置成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      404     {object}   vo.Result          "文章不存在"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/setPinned [post]
+func SetPostPinned(c echo.Context) error {
+	req := new(dto.SetPostPinnedRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	pos, err := service.SetPostPinned(req, c)
+	if errors.Is(err, service.ErrPostNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(pos, c))
+}
+
+// SetPostFeatured godoc
+// @Summary      设置文章精选
+// @Description  设置或取消文章精选，精选的已发布文章按精选顺序出现在精选列表中，仅管理员可操作
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SetPostFeaturedRequest  true  "文章 ID、是否精选与精选顺序"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.PostsVo}  "设置成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      404     {object}   vo.Result          "文章不存在"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/setFeatured [post]
+func SetPostFeatured(c echo.Context) error {
+	req := new(dto.SetPostFeaturedRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	pos, err := service.SetPostFeatured(req, c)
+	if errors.Is(err, service.ErrPostNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(pos, c))
 }
 
 // CreateOnePost godoc
@@ -76,21 +803,26 @@ func GetAllPosts(c echo.Context) error {
 // @Param        request  body      dto.CreateOnePostRequest  true  "创建文章请求参数"
 // @Success      200     {object}   vo.Result{data=post.PostsVo}  "创建成功"
 // @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      409     {object}   vo.Result          "文章内容与已有文章几乎相同"
 // @Failure      500     {object}   vo.Result          "服务器错误"
 // @Security     BearerAuth
 // @Router       /post/createOnePost [post]
 func CreateOnePost(c echo.Context) error {
 	req := new(dto.CreateOnePostRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	createdPost, err := service.CreateOnePost(req, c)
+	if errors.Is(err, service.ErrPostDuplicate) {
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PostDuplicate, err.Error()), c))
+	}
+	if errors.Is(err, service.ErrSlugInvalid) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+	if errors.Is(err, service.ErrPostPasswordMissing) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PostPasswordMissing), c))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(bizErr.New(bizErr.ServerError, err.Error()), nil, c))
 	}
@@ -108,21 +840,26 @@ func CreateOnePost(c echo.Context) error {
 // @Success      200     {object}   vo.Result{data=post.PostsVo}  "更新成功"
 // @Failure      400     {object}   vo.Result          "请求参数错误"
 // @Failure      404     {object}   vo.Result          "文章不存在"
+// @Failure      409     {object}   vo.Result          "翻译组中已有该语言的文章"
 // @Failure      500     {object}   vo.Result          "服务器错误"
 // @Security     BearerAuth
 // @Router       /post/updateOnePost [post]
 func UpdateOnePost(c echo.Context) error {
 	req := new(dto.UpdateOnePostRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	updatedPost, err := service.UpdateOnePost(req, c)
+	if errors.Is(err, service.ErrSlugInvalid) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+	if errors.Is(err, service.ErrPostPasswordMissing) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PostPasswordMissing), c))
+	}
+	if errors.Is(err, service.ErrTranslationConflict) {
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PostTranslationConflict, err.Error()), c))
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, vo.Fail(bizErr.New(bizErr.ServerError, err.Error()), nil, c))
 	}
@@ -145,13 +882,8 @@ func UpdateOnePost(c echo.Context) error {
 // @Router       /post/deleteOnePost [post]
 func DeleteOnePost(c echo.Context) error {
 	req := new(dto.DeleteOnePostRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(bizErr.New(bizErr.BadRequest, err.Error()), nil, c))
-	}
-
-	errors := utils.Validator(*req)
-	if errors != nil {
-		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest), c))
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
 
 	err := service.DeleteOnePost(req, c)
@@ -0,0 +1,134 @@
+package post
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/service/post"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// SubmitForReview godoc
+// @Summary      提交文章审核
+// @Description  作者将未发布的文章提交审核，被退回的文章修改后可重新提交
+// @Tags         文章审核
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SubmitForReviewRequest  true  "审核参数"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result          "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/review/submitForReview [post]
+func SubmitForReview(c echo.Context) error {
+	req := new(dto.SubmitForReviewRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.SubmitForReview(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("文章已提交审核", c))
+}
+
+// ApproveReview godoc
+// @Summary      审核通过
+// @Description  编辑审核通过文章，未指定发布时间时立即发布，否则在指定时间定时发布
+// @Tags         文章审核
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ApproveReviewRequest  true  "审核参数"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result          "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/review/approveReview [post]
+func ApproveReview(c echo.Context) error {
+	req := new(dto.ApproveReviewRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.ApproveReview(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("文章审核已通过", c))
+}
+
+// RequestReviewChanges godoc
+// @Summary      审核退回
+// @Description  编辑退回文章并附上修改意见
+// @Tags         文章审核
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RequestReviewChangesRequest  true  "审核参数"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result          "操作成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/review/requestChanges [post]
+func RequestReviewChanges(c echo.Context) error {
+	req := new(dto.RequestReviewChangesRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.RequestReviewChanges(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("文章已退回修改", c))
+}
+
+// GetReviewQueue godoc
+// @Summary      获取审核队列
+// @Description  获取等待审核的文章列表，等待最久的排在前面
+// @Tags         文章审核
+// @Produce      json
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]post.PostsVo,page=vo.PageMeta}  "获取成功"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/review/getReviewQueue [get]
+func GetReviewQueue(c echo.Context) error {
+	posts, meta, err := service.GetReviewQueue(vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(posts, meta, c))
+}
+
+// GetReviewHistory godoc
+// @Summary      获取文章审核记录
+// @Description  获取文章在审核流程中的全部状态变更记录，按时间正序排列
+// @Tags         文章审核
+// @Produce      json
+// @Param        post_id  query    int64   true   "文章 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]post.ReviewRecordVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/review/getReviewHistory [get]
+func GetReviewHistory(c echo.Context) error {
+	req := new(dto.GetReviewHistoryRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	records, err := service.GetReviewHistory(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(records, c))
+}
@@ -0,0 +1,103 @@
+package post
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/service/post"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetPostRevisions godoc
+// @Summary      获取文章修订列表
+// @Description  获取文章每次创建、更新或恢复时保存的修订，按版本号倒序排列，不含正文；内容未变化的保存不产生修订，超出 REVISION_MAX_PER_POST 或 REVISION_RETENTION_DAYS 的修订会被清理
+// @Tags         文章
+// @Produce      json
+// @Param        post_id  query    int64   true   "文章 ID"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]post.PostRevisionVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/revision/getRevisions [get]
+func GetPostRevisions(c echo.Context) error {
+	req := new(dto.GetPostRevisionsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	revisions, meta, err := service.GetPostRevisions(req, vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(revisions, meta, c))
+}
+
+// GetRevisionDiff godoc
+// @Summary      对比文章修订
+// @Description  对比文章的两个修订，返回标题、封面与分类的变化以及 Markdown 正文的统一格式逐行差异；不传 to 时与文章当前内容对比
+// @Tags         文章
+// @Produce      json
+// @Param        post_id  query    int64   true   "文章 ID"
+// @Param        from     query    int     true   "旧修订的版本号"
+// @Param        to       query    int     false  "新修订的版本号，不传时与文章当前内容对比"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=post.RevisionDiffVo}  "对比成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      404  {object}  vo.Result                 "修订不存在"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/revision/getRevisionDiff [get]
+func GetRevisionDiff(c echo.Context) error {
+	req := new(dto.GetRevisionDiffRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	diff, err := service.GetRevisionDiff(req, c)
+	switch {
+	case errors.Is(err, service.ErrRevisionNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.RevisionNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(diff, c))
+}
+
+// RestoreRevision godoc
+// @Summary      恢复文章修订
+// @Description  将文章的标题、封面、正文与分类恢复为指定修订的内容，发布状态与审核状态不变；恢复记为一次新的修订，此前的修订均保留
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RestoreRevisionRequest  true  "文章 ID 与修订版本号"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.PostsVo}  "恢复成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      404     {object}   vo.Result          "修订不存在"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/revision/restoreRevision [post]
+func RestoreRevision(c echo.Context) error {
+	req := new(dto.RestoreRevisionRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	pos, err := service.RestorePostRevision(req, c)
+	switch {
+	case errors.Is(err, service.ErrRevisionNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.RevisionNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(pos, c))
+}
@@ -0,0 +1,80 @@
+package post
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/service/post"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// UpdatePostStatus godoc
+// @Summary      变更文章发布状态
+// @Description  变更文章的发布状态，草稿可发布，已发布的文章可撤回为草稿或归档，归档的文章可重新发布或转为草稿；只有已发布的文章对访客可见，正在审核或等待定时发布的文章需先完成审核
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdatePostStatusRequest  true  "文章 ID 与目标状态"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.PostsVo}  "变更成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      409     {object}   vo.Result          "不允许的状态变更或文章正在审核"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/updatePostStatus [post]
+func UpdatePostStatus(c echo.Context) error {
+	req := new(dto.UpdatePostStatusRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	pos, err := service.UpdatePostStatus(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostStatusTransition):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PostStatusConflict, err.Error()), c))
+	case errors.Is(err, service.ErrPostInReview):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PostInReview), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(pos, c))
+}
+
+// SchedulePost godoc
+// @Summary      定时发布文章
+// @Description  为草稿或归档的文章设置定时发布时间，到期后自动发布，并清除缓存、重新导出静态站点的订阅源与站点地图、发送 post.published Webhook；publish_at 为 0 时取消定时发布，手动变更发布状态或提交审核后定时发布随即取消
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SchedulePostRequest  true  "文章 ID 与定时发布时间"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=post.PostsVo}  "设置成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误或定时发布时间已过"
+// @Failure      409     {object}   vo.Result          "文章已发布或正在审核"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/schedulePost [post]
+func SchedulePost(c echo.Context) error {
+	req := new(dto.SchedulePostRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	pos, err := service.SchedulePost(req, c)
+	switch {
+	case errors.Is(err, service.ErrPublishAtPassed):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PublishAtPassed), c))
+	case errors.Is(err, service.ErrPostStatusTransition):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PostStatusConflict, err.Error()), c))
+	case errors.Is(err, service.ErrPostInReview):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PostInReview), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(pos, c))
+}
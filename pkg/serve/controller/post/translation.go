@@ -0,0 +1,112 @@
+package post
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/service/post"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// LinkPostTranslation godoc
+// @Summary      关联文章翻译
+// @Description  将文章关联为另一篇文章的翻译，两篇文章加入同一翻译组，组 ID 取原文的文章 ID；翻译组中每种语言只能有一篇文章
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.LinkPostTranslationRequest  true  "翻译与原文的文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]post.PostTranslationVo}  "关联成功，返回翻译组内的文章"
+// @Failure      400     {object}   vo.Result          "请求参数错误或关联无效"
+// @Failure      401     {object}   vo.Result          "未授权"
+// @Failure      404     {object}   vo.Result          "文章不存在"
+// @Failure      409     {object}   vo.Result          "翻译组中已有该语言的文章"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/translation/linkTranslation [post]
+func LinkPostTranslation(c echo.Context) error {
+	req := new(dto.LinkPostTranslationRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	translations, err := service.LinkPostTranslation(req, c)
+	switch {
+	case errors.Is(err, service.ErrTranslationInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PostTranslationInvalid, err.Error()), c))
+	case errors.Is(err, service.ErrTranslationConflict):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.PostTranslationConflict, err.Error()), c))
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(translations, c))
+}
+
+// UnlinkPostTranslation godoc
+// @Summary      解除文章翻译关联
+// @Description  将文章移出所在的翻译组，组内只剩一篇文章时解散翻译组，移出的是原文时由组内最早创建的文章作为新的原文
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UnlinkPostTranslationRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result          "解除成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      401     {object}   vo.Result          "未授权"
+// @Failure      404     {object}   vo.Result          "文章不存在"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/translation/unlinkTranslation [post]
+func UnlinkPostTranslation(c echo.Context) error {
+	req := new(dto.UnlinkPostTranslationRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.UnlinkPostTranslation(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(nil, c))
+}
+
+// GetPostTranslations godoc
+// @Summary      获取文章翻译
+// @Description  获取文章所在翻译组中可见的文章，包含文章自身，用于语言切换与 hreflang 链接；文章没有关联翻译时返回空列表
+// @Tags         文章
+// @Produce      json
+// @Param        id   query     int64  true  "文章 ID"
+// @Success      200  {object}  vo.Result{data=[]post.PostTranslationVo}  "获取成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      401  {object}  vo.Result                 "文章仅登录用户可见"
+// @Failure      404  {object}  vo.Result                 "文章不存在"
+// @Failure      500  {object}  vo.Result                 "服务器错误"
+// @Router       /post/translation/getTranslations [get]
+func GetPostTranslations(c echo.Context) error {
+	req := new(dto.GetPostTranslationsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	translations, err := service.GetPostTranslations(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	case errors.Is(err, service.ErrPostLoginRequired):
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.PostLoginRequired), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(translations, c))
+}
@@ -0,0 +1,69 @@
+package post
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/service/post"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetTrashedPosts godoc
+// @Summary      获取回收站文章
+// @Description  分页获取已删除的文章，按删除时间倒序排序；超过 trash.TRASH_POST_RETENTION_DAYS 天的文章由定时任务永久删除，仅管理员可用
+// @Tags         文章
+// @Produce      json
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]post.TrashPostVo,page=vo.PageMeta}  "获取成功"
+// @Failure      401     {object}   vo.Result          "未授权"
+// @Failure      403     {object}   vo.Result          "权限不足"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/trash/getTrashedPosts [get]
+func GetTrashedPosts(c echo.Context) error {
+	list, meta, err := service.GetTrashedPosts(vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(list, meta, c))
+}
+
+// RestorePost godoc
+// @Summary      恢复文章
+// @Description  从回收站恢复文章，恢复后的发布状态与删除前一致；删除期间别名被其他文章使用时重新生成别名，仅管理员可用
+// @Tags         文章
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RestorePostRequest  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result          "恢复成功"
+// @Failure      400     {object}   vo.Result          "请求参数错误"
+// @Failure      401     {object}   vo.Result          "未授权"
+// @Failure      403     {object}   vo.Result          "权限不足"
+// @Failure      404     {object}   vo.Result          "回收站中不存在该文章"
+// @Failure      500     {object}   vo.Result          "服务器错误"
+// @Router       /post/trash/restorePost [post]
+func RestorePost(c echo.Context) error {
+	req := new(dto.RestorePostRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	err := service.RestorePost(req, c)
+	switch {
+	case errors.Is(err, service.ErrPostNotInTrash):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.TrashPostNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("文章恢复成功", c))
+}
@@ -0,0 +1,15 @@
+package dto
+
+// ReactionRequest           表态或取消表态请求参数结构体
+// @Param	post_id		body	int64	true	"文章 ID"
+// @Param	reaction	body	string	true	"表态类型，需为 REACTION_TYPES 中配置的类型"
+type ReactionRequest struct {
+	PostID   int64  `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Reaction string `json:"reaction" xml:"reaction" form:"reaction" query:"reaction" validate:"required,max=16"`
+}
+
+// GetReactionsRequest       获取文章表态请求参数结构体
+// @Param	post_id	query	int64	true	"文章 ID"
+type GetReactionsRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+}
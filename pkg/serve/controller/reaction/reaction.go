@@ -0,0 +1,105 @@
+package reaction
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/reaction/dto"
+	"jank.com/jank_blog/pkg/serve/service/reaction"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// AddReaction godoc
+// @Summary      对文章表态
+// @Description  对指定文章点赞或添加表情表态，登录用户按账户去重，未登录时按访客 ID 去重并在缺少时签发访客 ID，重复表态不会报错
+// @Tags         表态
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ReactionRequest  true  "文章 ID 与表态类型"
+// @Success      200     {object}   vo.Result{data=reaction.ReactionStateVo}  "表态成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或不支持的表态类型"
+// @Failure      401     {object}   vo.Result  "未登录且站点未开启访客表态"
+// @Failure      404     {object}   vo.Result  "文章不存在"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /reaction/addReaction [post]
+func AddReaction(c echo.Context) error {
+	req := new(dto.ReactionRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	state, err := service.AddReaction(req, c)
+	if err != nil {
+		return reactionFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(state, c))
+}
+
+// RemoveReaction godoc
+// @Summary      取消文章表态
+// @Description  取消当前用户或访客对指定文章的表态，未表态时不会报错
+// @Tags         表态
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ReactionRequest  true  "文章 ID 与表态类型"
+// @Success      200     {object}   vo.Result{data=reaction.ReactionStateVo}  "取消成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      401     {object}   vo.Result  "未登录且没有有效的访客 ID"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /reaction/removeReaction [post]
+func RemoveReaction(c echo.Context) error {
+	req := new(dto.ReactionRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	state, err := service.RemoveReaction(req, c)
+	if err != nil {
+		return reactionFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(state, c))
+}
+
+// GetReactions godoc
+// @Summary      获取文章表态
+// @Description  获取指定文章每种表态的数量，以及当前用户或访客已表态的类型
+// @Tags         表态
+// @Produce      json
+// @Param        post_id  query     int64  true  "文章 ID"
+// @Success      200     {object}   vo.Result{data=reaction.ReactionStateVo}  "获取成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /reaction/getReactions [get]
+func GetReactions(c echo.Context) error {
+	req := new(dto.GetReactionsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	state, err := service.GetReactions(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(state, c))
+}
+
+// reactionFailResponse 将表态服务的错误转换为响应
+func reactionFailResponse(err error, c echo.Context) error {
+	switch {
+	case errors.Is(err, service.ErrReactionTypeInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.ReactionTypeInvalid), c))
+	case errors.Is(err, service.ErrIdentityRequired):
+		return c.JSON(http.StatusUnauthorized, vo.Fail(nil, bizErr.New(bizErr.ReactionIdentityRequired), c))
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	default:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+}
@@ -0,0 +1,7 @@
+package dto
+
+// SearchRequest        全文检索请求参数结构体，分页参数见 page、pageSize 与 cursor
+// @Param	keyword	query	string	true	"检索关键词，多个关键词以空格分隔，中文无需分隔"
+type SearchRequest struct {
+	Keyword string `json:"keyword" xml:"keyword" form:"keyword" query:"keyword" validate:"required,min=1,max=100"`
+}
@@ -0,0 +1,100 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/search/dto"
+	"jank.com/jank_blog/pkg/serve/service/search"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// Search godoc
+// @Summary      全文检索
+// @Description  在 SEARCH_BACKEND 配置的全文索引中检索已发布文章的标题、正文与标签，按相关度排序，内置索引基于 Bleve，命中关键词越多的文章排序越靠前，中文按相邻两字切分；开启 SEARCH_FUZZY_ENABLED 时容忍关键词的拼写错误；返回的标题与正文片段中关键词以 <mark> 标记；文章创建、更新、发布或删除后索引随即在后台更新，需站点开启 SEARCH_INDEX_ENABLED
+// @Tags         搜索
+// @Produce      json
+// @Param        keyword  query    string  true   "检索关键词"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200  {object}  vo.Result{data=[]search.SearchHitVo,page=vo.PageMeta}  "检索成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      503  {object}  vo.Result                 "站点未开启全文检索"
+// @Router       /search [get]
+func Search(c echo.Context) error {
+	req := new(dto.SearchRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	hits, meta, err := service.Search(req, vo.ParsePage(c), c)
+	switch {
+	case errors.Is(err, service.ErrSearchIndexDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.SearchIndexDisabled), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(hits, meta, c))
+}
+
+// SearchComments godoc
+// @Summary      检索评论
+// @Description  在全文索引中检索已发布文章下的评论，按相关度排序并返回所属文章，开启 SEARCH_FUZZY_ENABLED 时容忍关键词的拼写错误；评论片段中关键词以 <mark> 标记，所属文章已不再发布的评论不返回，需站点开启 SEARCH_INDEX_ENABLED
+// @Tags         搜索
+// @Produce      json
+// @Param        keyword  query    string  true   "检索关键词"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200  {object}  vo.Result{data=[]search.CommentSearchHitVo,page=vo.PageMeta}  "检索成功"
+// @Failure      400  {object}  vo.Result                 "请求参数错误"
+// @Failure      503  {object}  vo.Result                 "站点未开启全文检索"
+// @Router       /search/comments [get]
+func SearchComments(c echo.Context) error {
+	req := new(dto.SearchRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	hits, meta, err := service.SearchComments(req, vo.ParsePage(c), c)
+	switch {
+	case errors.Is(err, service.ErrSearchIndexDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.SearchIndexDisabled), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(hits, meta, c))
+}
+
+// Reindex godoc
+// @Summary      重建全文索引
+// @Description  立即在后台运行 search_index_rebuild 任务，清空文章与评论的全文索引后按数据库中已发布的文章及其评论批量写入，切换 SEARCH_BACKEND 或外部搜索服务数据丢失后调用；运行结果可通过 /task/getTaskLogs 查看
+// @Tags         搜索
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result  "已开始重建"
+// @Failure      403  {object}  vo.Result  "非管理员"
+// @Failure      409  {object}  vo.Result  "全文索引正在重建"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Failure      503  {object}  vo.Result  "站点未开启全文检索"
+// @Router       /search/reindex [post]
+func Reindex(c echo.Context) error {
+	err := service.TriggerReindex(c)
+	switch {
+	case errors.Is(err, service.ErrSearchIndexDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.SearchIndexDisabled), c))
+	case errors.Is(err, service.ErrSearchReindexRunning):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.SearchReindexRunning), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("全文索引已开始重建", c))
+}
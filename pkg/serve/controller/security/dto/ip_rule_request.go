@@ -0,0 +1,10 @@
+package dto
+
+// IPRuleRequest     管理接口 IP 访问规则请求体
+// @Description	添加或移除管理接口的 IP 访问规则
+// @Param			list	body	string	true	"规则列表，可选值: allow（白名单）, deny（黑名单）"
+// @Param			rule	body	string	true	"IP 或 CIDR，如 203.0.113.7 或 10.0.0.0/8"
+type IPRuleRequest struct {
+	List string `json:"list" xml:"list" form:"list" query:"list" validate:"required,oneof=allow deny"`
+	Rule string `json:"rule" xml:"rule" form:"rule" query:"rule" validate:"required,max=64"`
+}
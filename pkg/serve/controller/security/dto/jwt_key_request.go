@@ -0,0 +1,8 @@
+package dto
+
+// RotateJWTKeyRequest     轮换 JWT 签名密钥请求体
+// @Description	立即轮换签名密钥，可选择使旧密钥立即失效
+// @Param			revoke_previous	body	bool	false	"旧密钥是否立即失效，为 true 时已签发的 token 全部作废，全部用户需重新登录"
+type RotateJWTKeyRequest struct {
+	RevokePrevious bool `json:"revoke_previous" xml:"revoke_previous" form:"revoke_previous" query:"revoke_previous"`
+}
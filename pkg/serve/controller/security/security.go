@@ -0,0 +1,150 @@
+package security
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/security/dto"
+	"jank.com/jank_blog/pkg/serve/service/security"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ListJWTKeys godoc
+// @Summary      获取 JWT 签名密钥列表
+// @Description  获取仍用于签发或校验 token 的签名密钥，按创建时间倒序排列，不返回密钥本身，仅管理员可用
+// @Tags         安全
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]security.JWTKeyVo}  "获取成功"
+// @Failure      401  {object}  vo.Result  "未授权"
+// @Failure      403  {object}  vo.Result  "权限不足"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /admin/security/listJwtKeys [get]
+func ListJWTKeys(c echo.Context) error {
+	keys, err := service.ListJWTKeys(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(keys, c))
+}
+
+// RotateJWTKey godoc
+// @Summary      轮换 JWT 签名密钥
+// @Description  立即为 access token 与 refresh token 生成新的签名密钥，旧密钥在 JWT_KEY_GRACE_HOURS 内继续用于校验；revoke_previous 为 true 时旧密钥立即失效，全部用户需重新登录，用于密钥疑似泄露时，仅管理员可用
+// @Tags         安全
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RotateJWTKeyRequest  true  "轮换方式"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]security.JWTKeyVo}  "轮换成功，返回新密钥"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      401     {object}   vo.Result  "未授权"
+// @Failure      403     {object}   vo.Result  "权限不足"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/security/rotateJwtKey [post]
+func RotateJWTKey(c echo.Context) error {
+	req := new(dto.RotateJWTKeyRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	keys, err := service.RotateJWTKey(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(keys, c))
+}
+
+// ListIPRules godoc
+// @Summary      获取管理接口 IP 访问规则
+// @Description  获取管理接口的白名单与黑名单，包括配置文件中的规则与管理员添加的规则，以及当前请求的来源 IP，仅管理员可用
+// @Tags         安全
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=security.IPRulesVo}  "获取成功"
+// @Failure      401  {object}  vo.Result  "未授权"
+// @Failure      403  {object}  vo.Result  "权限不足或当前 IP 不允许访问管理接口"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /admin/security/listIpRules [get]
+func ListIPRules(c echo.Context) error {
+	rules, err := service.ListIPRules(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(rules, c))
+}
+
+// AddIPRule godoc
+// @Summary      添加管理接口 IP 访问规则
+// @Description  将 IP 或 CIDR 加入管理接口的白名单或黑名单，立即对全部实例生效；白名单非空时仅允许其中的 IP 访问管理接口，黑名单优先于白名单；添加后当前 IP 将无法访问时拒绝添加，仅管理员可用
+// @Tags         安全
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.IPRuleRequest  true  "规则信息"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=security.IPRulesVo}  "添加成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或 IP 格式无效"
+// @Failure      401     {object}   vo.Result  "未授权"
+// @Failure      403     {object}   vo.Result  "权限不足或当前 IP 不允许访问管理接口"
+// @Failure      409     {object}   vo.Result  "添加后当前 IP 将无法访问管理接口"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/security/addIpRule [post]
+func AddIPRule(c echo.Context) error {
+	req := new(dto.IPRuleRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	rules, err := service.AddIPRule(req, c)
+	switch {
+	case errors.Is(err, service.ErrIPRuleInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.IPRuleInvalid), c))
+	case errors.Is(err, service.ErrIPRuleSelfLockout):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.IPRuleSelfLockout), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(rules, c))
+}
+
+// RemoveIPRule godoc
+// @Summary      移除管理接口 IP 访问规则
+// @Description  将管理员添加的 IP 或 CIDR 移出白名单或黑名单，配置文件中的规则需修改配置文件；移除后当前 IP 将无法访问时拒绝移除，仅管理员可用
+// @Tags         安全
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.IPRuleRequest  true  "规则信息"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=security.IPRulesVo}  "移除成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或 IP 格式无效"
+// @Failure      401     {object}   vo.Result  "未授权"
+// @Failure      403     {object}   vo.Result  "权限不足或当前 IP 不允许访问管理接口"
+// @Failure      409     {object}   vo.Result  "移除后当前 IP 将无法访问管理接口"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /admin/security/removeIpRule [post]
+func RemoveIPRule(c echo.Context) error {
+	req := new(dto.IPRuleRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	rules, err := service.RemoveIPRule(req, c)
+	switch {
+	case errors.Is(err, service.ErrIPRuleInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.IPRuleInvalid), c))
+	case errors.Is(err, service.ErrIPRuleSelfLockout):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.IPRuleSelfLockout), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(rules, c))
+}
@@ -0,0 +1,43 @@
+package dto
+
+// CreateSeriesRequest       创建系列请求参数结构体
+// @Param	title		body	string	true	"系列标题"
+// @Param	description	body	string	false	"系列简介"
+type CreateSeriesRequest struct {
+	Title       string `json:"title" xml:"title" form:"title" query:"title" validate:"required,min=1,max=255"`
+	Description string `json:"description" xml:"description" form:"description" query:"description" validate:"max=2000"`
+}
+
+// UpdateSeriesRequest       更新系列请求参数结构体
+// @Param	id			body	int64	true	"系列 ID"
+// @Param	title		body	string	true	"系列标题"
+// @Param	description	body	string	false	"系列简介"
+type UpdateSeriesRequest struct {
+	ID          int64  `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+	Title       string `json:"title" xml:"title" form:"title" query:"title" validate:"required,min=1,max=255"`
+	Description string `json:"description" xml:"description" form:"description" query:"description" validate:"max=2000"`
+}
+
+// SeriesIDRequest           指定系列的请求参数结构体，用于获取与删除系列
+// @Param	id	query	int64	true	"系列 ID"
+type SeriesIDRequest struct {
+	ID int64 `json:"id" xml:"id" form:"id" query:"id" validate:"required,gt=0"`
+}
+
+// SeriesPostRequest         将文章加入或移出系列请求参数结构体
+// @Param	series_id	body	int64	true	"系列 ID"
+// @Param	post_id		body	int64	true	"文章 ID"
+// @Param	position	body	int		false	"加入时的序号，从 1 开始，默认加到末尾；移出时忽略"
+type SeriesPostRequest struct {
+	SeriesID int64 `json:"series_id" xml:"series_id" form:"series_id" query:"series_id" validate:"required,gt=0"`
+	PostID   int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+	Position int   `json:"position" xml:"position" form:"position" query:"position" validate:"gte=0"`
+}
+
+// ReorderSeriesPostsRequest 重新排序系列文章请求参数结构体
+// @Param	series_id	body	int64	true	"系列 ID"
+// @Param	post_ids	body	[]int64	true	"按新顺序排列的文章 ID，需包含系列中全部未删除的文章"
+type ReorderSeriesPostsRequest struct {
+	SeriesID int64   `json:"series_id" xml:"series_id" form:"series_id" query:"series_id" validate:"required,gt=0"`
+	PostIDs  []int64 `json:"post_ids" xml:"post_ids" form:"post_ids" query:"post_ids" validate:"required,min=1,max=500,dive,gt=0"`
+}
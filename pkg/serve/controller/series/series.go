@@ -0,0 +1,236 @@
+package series
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/series/dto"
+	"jank.com/jank_blog/pkg/serve/service/series"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetSeries godoc
+// @Summary      获取系列详情
+// @Description  获取系列信息及按顺序排列的文章，未发布的文章仅对其作者与管理员可见
+// @Tags         系列
+// @Produce      json
+// @Param        id   query     int64  true  "系列 ID"
+// @Success      200  {object}  vo.Result{data=series.SeriesVo}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      404  {object}  vo.Result  "系列不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/getSeries [get]
+func GetSeries(c echo.Context) error {
+	req := new(dto.SeriesIDRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	s, err := service.GetSeries(req, c)
+	if err != nil {
+		return seriesFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(s, c))
+}
+
+// ListSeries godoc
+// @Summary      获取系列列表
+// @Description  分页获取系列列表，按创建时间倒序排序，不包含系列中的文章
+// @Tags         系列
+// @Produce      json
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Success      200  {object}  vo.Result{data=[]series.SeriesVo,page=vo.PageMeta}  "获取成功"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/listSeries [get]
+func ListSeries(c echo.Context) error {
+	list, meta, err := service.ListSeries(vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(list, meta, c))
+}
+
+// CreateSeries godoc
+// @Summary      创建系列
+// @Description  创建文章系列，创建后通过 addPost 加入文章
+// @Tags         系列
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateSeriesRequest  true  "系列信息"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=series.SeriesVo}  "创建成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/createSeries [post]
+func CreateSeries(c echo.Context) error {
+	req := new(dto.CreateSeriesRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	s, err := service.CreateSeries(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(s, c))
+}
+
+// UpdateSeries godoc
+// @Summary      更新系列
+// @Description  更新系列的标题与简介
+// @Tags         系列
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.UpdateSeriesRequest  true  "系列信息"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=series.SeriesVo}  "更新成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      404  {object}  vo.Result  "系列不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/updateSeries [post]
+func UpdateSeries(c echo.Context) error {
+	req := new(dto.UpdateSeriesRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	s, err := service.UpdateSeries(req, c)
+	if err != nil {
+		return seriesFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(s, c))
+}
+
+// DeleteSeries godoc
+// @Summary      删除系列
+// @Description  删除系列并解除与文章的关联，文章本身保留
+// @Tags         系列
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SeriesIDRequest  true  "系列 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result  "删除成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      404  {object}  vo.Result  "系列不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/deleteSeries [post]
+func DeleteSeries(c echo.Context) error {
+	req := new(dto.SeriesIDRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.DeleteSeries(req, c); err != nil {
+		return seriesFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(nil, c))
+}
+
+// AddSeriesPost godoc
+// @Summary      将文章加入系列
+// @Description  将文章加入系列的指定位置，默认加到末尾；每篇文章最多属于一个系列，已在该系列中时不做处理
+// @Tags         系列
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SeriesPostRequest  true  "系列 ID、文章 ID 与位置"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=series.SeriesVo}  "加入成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      404  {object}  vo.Result  "系列或文章不存在"
+// @Failure      409  {object}  vo.Result  "文章已属于其他系列"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/addPost [post]
+func AddSeriesPost(c echo.Context) error {
+	req := new(dto.SeriesPostRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	s, err := service.AddSeriesPost(req, c)
+	if err != nil {
+		return seriesFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(s, c))
+}
+
+// RemoveSeriesPost godoc
+// @Summary      将文章移出系列
+// @Description  将文章移出系列，其后的文章依次前移，文章不在系列中时不会报错
+// @Tags         系列
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SeriesPostRequest  true  "系列 ID 与文章 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=series.SeriesVo}  "移出成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      404  {object}  vo.Result  "系列不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/removePost [post]
+func RemoveSeriesPost(c echo.Context) error {
+	req := new(dto.SeriesPostRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	s, err := service.RemoveSeriesPost(req, c)
+	if err != nil {
+		return seriesFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(s, c))
+}
+
+// ReorderSeriesPosts godoc
+// @Summary      重新排序系列文章
+// @Description  按提交的文章 ID 顺序重新排列系列中的文章，需提交系列中全部未删除的文章
+// @Tags         系列
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ReorderSeriesPostsRequest  true  "系列 ID 与排序后的文章 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=series.SeriesVo}  "排序成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误或文章与系列不一致"
+// @Failure      404  {object}  vo.Result  "系列不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /series/reorderPosts [post]
+func ReorderSeriesPosts(c echo.Context) error {
+	req := new(dto.ReorderSeriesPostsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	s, err := service.ReorderSeriesPosts(req, c)
+	if err != nil {
+		return seriesFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(s, c))
+}
+
+// seriesFailResponse 将系列服务的错误转换为响应
+func seriesFailResponse(err error, c echo.Context) error {
+	switch {
+	case errors.Is(err, service.ErrSeriesNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.SeriesNotFound), c))
+	case errors.Is(err, service.ErrPostNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.PostNotFound), c))
+	case errors.Is(err, service.ErrSeriesPostConflict):
+		return c.JSON(http.StatusConflict, vo.Fail(nil, bizErr.New(bizErr.SeriesPostConflict), c))
+	case errors.Is(err, service.ErrSeriesOrderMismatch):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.SeriesOrderMismatch), c))
+	default:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+// InitSetupRequest         首次启动初始化请求体
+// @Description	创建管理员并写入站点与邮件配置所需参数
+// @Param			email		body	string	true	"管理员邮箱"
+// @Param			nickname	body	string	true	"管理员昵称"
+// @Param			password	body	string	true	"管理员密码"
+// @Param			site_title	body	string	true	"站点标题"
+// @Param			site_url	body	string	true	"站点地址"
+// @Param			email_type	body	string	false	"邮箱类型，可选值: qq, gmail, outlook"
+// @Param			from_email	body	string	false	"发件邮箱"
+// @Param			email_smtp	body	string	false	"邮箱 SMTP 授权码"
+type InitSetupRequest struct {
+	Email     string `json:"email" xml:"email" form:"email" query:"email" validate:"required,email"`
+	Nickname  string `json:"nickname" xml:"nickname" form:"nickname" query:"nickname" validate:"required,min=1,max=20"`
+	Password  string `json:"password" xml:"password" form:"password" query:"password" validate:"required"`
+	SiteTitle string `json:"site_title" xml:"site_title" form:"site_title" query:"site_title" validate:"required,max=64"`
+	SiteURL   string `json:"site_url" xml:"site_url" form:"site_url" query:"site_url" validate:"required,url"`
+	EmailType string `json:"email_type" xml:"email_type" form:"email_type" query:"email_type" validate:"omitempty,oneof=qq gmail outlook"`
+	FromEmail string `json:"from_email" xml:"from_email" form:"from_email" query:"from_email" validate:"omitempty,email"`
+	EmailSmtp string `json:"email_smtp" xml:"email_smtp" form:"email_smtp" query:"email_smtp" default:""`
+}
@@ -0,0 +1,79 @@
+package setup
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/setup/dto"
+	"jank.com/jank_blog/pkg/serve/service/setup"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetSetupStatus godoc
+// @Summary      获取安装状态
+// @Description  查询系统是否已完成初始化，前端据此决定是否展示首次启动安装向导
+// @Tags         安装向导
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=setup.SetupStatusVo}  "获取成功"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /setup/status [get]
+func GetSetupStatus(c echo.Context) error {
+	status, err := service.GetSetupStatus(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(status, c))
+}
+
+// TestConnection godoc
+// @Summary      检测依赖组件
+// @Description  检测数据库与 Redis 连通性，仅在系统未初始化时可用
+// @Tags         安装向导
+// @Produce      json
+// @Success      200  {object}  vo.Result{data=setup.ConnectionVo}  "检测完成"
+// @Failure      403  {object}  vo.Result  "系统已完成初始化"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /setup/testConnection [post]
+func TestConnection(c echo.Context) error {
+	result, err := service.TestConnection(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(result, c))
+}
+
+// InitSetup godoc
+// @Summary      执行首次启动初始化
+// @Description  创建管理员账号并保存站点标题、站点地址与邮件配置，配置保存在数据库中并覆盖配置文件，仅在系统未初始化时可用
+// @Tags         安装向导
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.InitSetupRequest  true  "初始化参数"
+// @Success      200     {object}   vo.Result{data=account.RegisterAccountVo}  "初始化成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误或密码不符合安全要求"
+// @Failure      403     {object}   vo.Result  "系统已完成初始化"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /setup/init [post]
+func InitSetup(c echo.Context) error {
+	req := new(dto.InitSetupRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := password.Check(req.Password, req.Email); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.PasswordTooWeak, err.Error()), c))
+	}
+
+	admin, err := service.InitSetup(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(admin, c))
+}
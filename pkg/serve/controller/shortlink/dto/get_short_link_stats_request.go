@@ -0,0 +1,7 @@
+package dto
+
+// GetShortLinkStatsRequest  获取短链接点击统计请求参数结构体
+// @Param	post_id	query	int64	true	"文章 ID"
+type GetShortLinkStatsRequest struct {
+	PostID int64 `json:"post_id" xml:"post_id" form:"post_id" query:"post_id" validate:"required,gt=0"`
+}
@@ -0,0 +1,60 @@
+package shortlink
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/shortlink/dto"
+	"jank.com/jank_blog/pkg/serve/service/shortlink"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// RedirectShortLink godoc
+// @Summary      短链接跳转
+// @Description  根据短码跳转到对应的文章页面，并记录点击来源
+// @Tags         短链接
+// @Param        code  path  string  true  "短码"
+// @Success      302  "跳转到文章页面"
+// @Failure      404  {object}  vo.Result  "短链接不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /s/{code} [get]
+func RedirectShortLink(c echo.Context) error {
+	target, err := service.ResolveShortLink(c.Param("code"), c)
+	if errors.Is(err, service.ErrShortLinkNotFound) {
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.ShortLinkNotFound), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.Redirect(http.StatusFound, target)
+}
+
+// GetShortLinkStats godoc
+// @Summary      获取短链接点击统计
+// @Description  获取文章短链接的累计点击次数与点击量最高的来源页面，文章尚无短链接时自动生成，仅管理员可用
+// @Tags         短链接
+// @Produce      json
+// @Param        post_id  query    int64  true  "文章 ID"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=shortlink.ShortLinkStatsVo}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /shortlink/getShortLinkStats [get]
+func GetShortLinkStats(c echo.Context) error {
+	req := new(dto.GetShortLinkStatsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	stats, err := service.GetShortLinkStats(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(stats, c))
+}
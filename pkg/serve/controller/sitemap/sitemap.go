@@ -0,0 +1,65 @@
+package sitemap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/pkg/serve/service/sitemap"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// GetSitemap godoc
+// @Summary      获取站点地图
+// @Description  输出包含静态页面、年月归档、分类与已发布文章的站点地图，附带 lastmod；地址数超过 SITEMAP_MAX_URLS 时输出站点地图索引，引用 /sitemap-1.xml 等分页文件；文章发布、更新、删除或分类变更后重新生成，响应携带 Cache-Control、ETag 与 Last-Modified，支持条件请求
+// @Tags         站点地图
+// @Produce      xml
+// @Success      200  {string}  string     "站点地图或站点地图索引"
+// @Success      304  "未修改"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Failure      503  {object}  vo.Result  "站点未开启站点地图"
+// @Router       /sitemap.xml [get]
+func GetSitemap(c echo.Context) error {
+	return serveSitemap(service.IndexFile, c)
+}
+
+// GetSitemapPart godoc
+// @Summary      获取站点地图分页
+// @Description  地址数超过 SITEMAP_MAX_URLS 时拆分出的站点地图文件，由 /sitemap.xml 索引引用
+// @Tags         站点地图
+// @Produce      xml
+// @Param        part  path      int  true  "分页序号，从 1 开始"
+// @Success      200  {string}  string     "站点地图"
+// @Success      304  "未修改"
+// @Failure      404  {object}  vo.Result  "站点地图不存在"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Failure      503  {object}  vo.Result  "站点未开启站点地图"
+// @Router       /sitemap-{part}.xml [get]
+func GetSitemapPart(c echo.Context) error {
+	return serveSitemap(fmt.Sprintf("sitemap-%s.xml", strings.TrimSuffix(c.Param("part"), ".xml")), c)
+}
+
+// serveSitemap 输出站点地图文件，并设置缓存相关的响应头
+func serveSitemap(file string, c echo.Context) error {
+	doc, err := service.GetSitemap(file, c)
+	switch {
+	case errors.Is(err, service.ErrSitemapDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.SitemapDisabled), c))
+	case errors.Is(err, service.ErrSitemapNotFound):
+		return c.JSON(http.StatusNotFound, vo.Fail(nil, bizErr.New(bizErr.SitemapNotFound), c))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
+	header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(doc.MaxAge.Seconds())))
+	header.Set("ETag", doc.ETag)
+	http.ServeContent(c.Response(), c.Request(), file, doc.ModTime, bytes.NewReader(doc.Content))
+	return nil
+}
@@ -0,0 +1,8 @@
+package dto
+
+// TaskNameRequest     定时任务操作请求体
+// @Description	触发、暂停、恢复定时任务或查看运行记录所需参数
+// @Param			name	body	string	true	"任务名称"
+type TaskNameRequest struct {
+	Name string `json:"name" xml:"name" form:"name" query:"name" validate:"required"`
+}
@@ -0,0 +1,133 @@
+package task
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/task/dto"
+	"jank.com/jank_blog/pkg/serve/service/task"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ListTasks godoc
+// @Summary      获取定时任务列表
+// @Description  列出所有定时任务及其下次运行时间、上次运行时间与耗时，仅管理员可用
+// @Tags         定时任务
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]task.TaskVo}  "获取成功"
+// @Failure      401  {object}  vo.Result  "未授权"
+// @Failure      403  {object}  vo.Result  "权限不足"
+// @Router       /task/listTasks [get]
+func ListTasks(c echo.Context) error {
+	tasks, err := service.ListTasks(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(tasks, c))
+}
+
+// TriggerTask godoc
+// @Summary      立即运行定时任务
+// @Description  在后台立即运行指定任务，不影响其周期调度，仅管理员可用
+// @Tags         定时任务
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TaskNameRequest  true  "任务名称"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "触发成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /task/triggerTask [post]
+func TriggerTask(c echo.Context) error {
+	req := new(dto.TaskNameRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.TriggerTask(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("定时任务已触发", c))
+}
+
+// PauseTask godoc
+// @Summary      暂停定时任务
+// @Description  暂停指定任务的周期调度，正在运行的任务不受影响，仅管理员可用
+// @Tags         定时任务
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TaskNameRequest  true  "任务名称"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "暂停成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /task/pauseTask [post]
+func PauseTask(c echo.Context) error {
+	req := new(dto.TaskNameRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.PauseTask(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("定时任务已暂停", c))
+}
+
+// ResumeTask godoc
+// @Summary      恢复定时任务
+// @Description  恢复指定任务的周期调度，从恢复时刻起重新计算下次运行时间，仅管理员可用
+// @Tags         定时任务
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TaskNameRequest  true  "任务名称"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result  "恢复成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /task/resumeTask [post]
+func ResumeTask(c echo.Context) error {
+	req := new(dto.TaskNameRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	if err := service.ResumeTask(req, c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("定时任务已恢复", c))
+}
+
+// GetTaskLogs godoc
+// @Summary      获取定时任务运行记录
+// @Description  获取指定任务最近的运行记录，按时间倒序排列，仅管理员可用
+// @Tags         定时任务
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TaskNameRequest  true  "任务名称"
+// @Security     BearerAuth
+// @Success      200     {object}   vo.Result{data=[]task.TaskLogVo}  "获取成功"
+// @Failure      400     {object}   vo.Result  "请求参数错误"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Router       /task/getTaskLogs [post]
+func GetTaskLogs(c echo.Context) error {
+	req := new(dto.TaskNameRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	logs, err := service.GetTaskLogs(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(logs, c))
+}
@@ -0,0 +1,28 @@
+package verification
+
+import (
+	"errors"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/pkg/serve/captcha"
+)
+
+// verificationAttemptsKeySuffix 验证码错误次数计数键的后缀，计数键与验证码同时过期
+const verificationAttemptsKeySuffix = captcha.AttemptsKeySuffix
+
+// defaultMaxVerifyAttempts 未配置时同一验证码允许的错误次数
+const defaultMaxVerifyAttempts = 5
+
+var (
+	ErrVerificationCodeExpired      = errors.New("验证码不存在或已过期")
+	ErrVerificationCodeMismatch     = errors.New("验证码错误")
+	ErrVerificationAttemptsExceeded = errors.New("验证码错误次数过多，请重新获取验证码")
+)
+
+// maxVerifyAttempts 读取同一验证码允许的错误次数
+func maxVerifyAttempts() int {
+	if config, err := configs.LoadConfig(); err == nil && config.VerificationConfig.VerificationMaxAttempts > 0 {
+		return config.VerificationConfig.VerificationMaxAttempts
+	}
+	return defaultMaxVerifyAttempts
+}
@@ -0,0 +1,33 @@
+package verification
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/verification"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// maxTargetLength 审计日志中接收方字段的最大长度，与表结构一致
+const maxTargetLength = 128
+
+// recordLog 开启审计日志时在后台写入一条验证码审计日志，写入失败不影响验证码流程
+func recordLog(event, channel, target, result string, c echo.Context) {
+	config, err := configs.LoadConfig()
+	if err != nil || !config.VerificationConfig.VerificationAuditEnabled || global.DB == nil {
+		return
+	}
+	if len(target) > maxTargetLength {
+		target = target[:maxTargetLength]
+	}
+
+	log := &model.VerificationLog{Event: event, Channel: channel, Target: target, IP: c.RealIP(), Result: result}
+	logger := utils.BizLogger(c)
+	go func() {
+		if err := mapper.CreateVerificationLog(log); err != nil {
+			logger.Errorf("写入验证码审计日志失败: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,29 @@
+package verification
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// DeprecatedGET 包装仍以 GET 方式提供的发送验证码接口
+// 开启 VERIFICATION_ALLOW_GET 时照常处理并通过 Deprecation 响应头提示改用 POST，关闭后返回 405
+func DeprecatedGET(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		config, err := configs.LoadConfig()
+		if err == nil && !config.VerificationConfig.VerificationAllowGet {
+			c.Response().Header().Set(echo.HeaderAllow, http.MethodPost)
+			return c.JSON(http.StatusMethodNotAllowed, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "GET 方式已停用，请使用 POST 请求并在请求体中提交参数"), c))
+		}
+
+		utils.BizLogger(c).Warnf("调用已废弃的 GET 发送验证码接口: %s", c.Path())
+		c.Response().Header().Set("Deprecation", "true")
+		c.Response().Header().Set("Link", "<"+c.Path()+">; rel=\"successor-version\"; method=\"POST\"")
+		return next(c)
+	}
+}
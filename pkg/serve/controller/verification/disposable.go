@@ -0,0 +1,22 @@
+package verification
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/disposable"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// IsDisposableEmail 开启一次性邮箱黑名单时判断邮箱是否属于一次性邮箱
+func IsDisposableEmail(email string, c echo.Context) bool {
+	config, err := configs.LoadConfig()
+	if err != nil || !config.DisposableEmailConfig.DisposableEmailEnabled {
+		return false
+	}
+	if disposable.IsDisposable(c.Request().Context(), email) {
+		utils.BizLogger(c).Warnf("拒绝一次性邮箱: %s", email)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,12 @@
+package dto
+
+// CheckImgCodeRequest    预校验图形验证码请求参数
+// @Description	表单即时校验图形验证码所需参数
+// @Param			email	body	string	true	"获取图形验证码时使用的邮箱地址"
+// @Param			code	body	string	true	"图形验证码"
+// @Param			nonce	body	string	false	"获取图形验证码时下发的客户端随机数"
+type CheckImgCodeRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,valid_email"`
+	Code  string `json:"code" xml:"code" form:"code" query:"code" validate:"required"`
+	Nonce string `json:"nonce" xml:"nonce" form:"nonce" query:"nonce" validate:"omitempty,alphanum,max=64"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// GetLoginCaptchaRequest    查询登录是否需要人机验证请求参数
+// @Description	登录页面输入邮箱后查询是否需要展示人机验证
+// @Param			email	query	string	true	"登录邮箱"
+type GetLoginCaptchaRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,valid_email"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// ListVerificationLogsRequest    查询验证码审计日志请求参数
+// @Description	按条件查询验证码审计日志，留空的条件不参与过滤，时间为秒级时间戳
+// @Param			event	query	string	false	"事件，可选值: send, verify"
+// @Param			channel	query	string	false	"渠道，可选值: img, email, sms, totp, messenger"
+// @Param			target	query	string	false	"接收方，邮箱或手机号"
+// @Param			ip		query	string	false	"客户端 IP"
+// @Param			result	query	string	false	"结果，如 success, failure, rate_limited, mismatch, expired, locked"
+// @Param			since	query	int64	false	"起始时间（含）"
+// @Param			until	query	int64	false	"截止时间（不含）"
+type ListVerificationLogsRequest struct {
+	Event   string `json:"event" xml:"event" form:"event" query:"event" validate:"omitempty,oneof=send verify"`
+	Channel string `json:"channel" xml:"channel" form:"channel" query:"channel" validate:"omitempty,oneof=img email sms totp messenger IMG EMAIL SMS TOTP MESSENGER"`
+	Target  string `json:"target" xml:"target" form:"target" query:"target" validate:"omitempty,max=128"`
+	IP      string `json:"ip" xml:"ip" form:"ip" query:"ip" validate:"omitempty,ip"`
+	Result  string `json:"result" xml:"result" form:"result" query:"result" validate:"omitempty,max=32"`
+	Since   int64  `json:"since" xml:"since" form:"since" query:"since" validate:"omitempty,min=0"`
+	Until   int64  `json:"until" xml:"until" form:"until" query:"until" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// SendEmailVerificationCodeRequest    发送邮箱验证码请求参数
+// @Description	获取邮箱验证码所需参数，验证码只能用于声明的用途
+// @Param			email	body	string	true	"邮箱地址"
+// @Param			action	body	string	true	"验证码用途，可选值: register, reset_password, disable_totp, delete_account"
+// @Param			nonce	body	string	false	"服务端此前下发的客户端随机数，留空或已过期时由服务端生成并通过响应头 X-Verification-Nonce 返回"
+type SendEmailVerificationCodeRequest struct {
+	Email  string `json:"email" xml:"email" form:"email" query:"email" validate:"required,valid_email"`
+	Action string `json:"action" xml:"action" form:"action" query:"action" validate:"required,oneof=register reset_password disable_totp delete_account"`
+	Nonce  string `json:"nonce" xml:"nonce" form:"nonce" query:"nonce" validate:"omitempty,alphanum,max=64"`
+}
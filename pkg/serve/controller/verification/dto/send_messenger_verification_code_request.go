@@ -0,0 +1,14 @@
+package dto
+
+// SendMessengerVerificationCodeRequest    发送即时通讯验证码请求参数
+// @Description	向账户绑定的 Telegram 或 WhatsApp 账号发送验证码所需参数
+// @Param			email		body	string	true	"账户邮箱"
+// @Param			provider	body	string	true	"即时通讯服务，可选值: telegram, whatsapp"
+// @Param			action		body	string	true	"验证码用途，可选值: register, reset_password, disable_totp, delete_account"
+// @Param			nonce		body	string	false	"服务端此前下发的客户端随机数，留空或已过期时由服务端生成并通过响应头 X-Verification-Nonce 返回"
+type SendMessengerVerificationCodeRequest struct {
+	Email    string `json:"email" xml:"email" form:"email" query:"email" validate:"required,valid_email"`
+	Provider string `json:"provider" xml:"provider" form:"provider" query:"provider" validate:"required,oneof=telegram whatsapp"`
+	Action   string `json:"action" xml:"action" form:"action" query:"action" validate:"required,oneof=register reset_password disable_totp delete_account"`
+	Nonce    string `json:"nonce" xml:"nonce" form:"nonce" query:"nonce" validate:"omitempty,alphanum,max=64"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// SendSmsVerificationCodeRequest    发送短信验证码请求参数
+// @Description	获取短信验证码所需参数
+// @Param			phone	body	string	true	"E.164 格式的手机号，如 +8613800000000"
+// @Param			action	body	string	true	"验证码用途，可选值: register, reset_password, disable_totp, delete_account"
+// @Param			nonce	body	string	false	"服务端此前下发的客户端随机数，留空或已过期时由服务端生成并通过响应头 X-Verification-Nonce 返回"
+type SendSmsVerificationCodeRequest struct {
+	Phone  string `json:"phone" xml:"phone" form:"phone" query:"phone" validate:"required,e164"`
+	Action string `json:"action" xml:"action" form:"action" query:"action" validate:"required,oneof=register reset_password disable_totp delete_account"`
+	Nonce  string `json:"nonce" xml:"nonce" form:"nonce" query:"nonce" validate:"omitempty,alphanum,max=64"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// SendVerificationCodeRequest    发送验证码请求参数
+// @Description	获取图形验证码或滑动拼图所需参数
+// @Param			email	body	string	true	"邮箱地址"
+// @Param			nonce	body	string	false	"服务端此前下发的客户端随机数，留空或已过期时由服务端生成并通过响应头 X-Verification-Nonce 返回"
+type SendVerificationCodeRequest struct {
+	Email string `json:"email" xml:"email" form:"email" query:"email" validate:"required,valid_email"`
+	Nonce string `json:"nonce" xml:"nonce" form:"nonce" query:"nonce" validate:"omitempty,alphanum,max=64"`
+}
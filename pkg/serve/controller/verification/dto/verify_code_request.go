@@ -0,0 +1,16 @@
+package dto
+
+// VerifyCodeRequest    校验验证码请求体
+// @Description	校验验证码并换取验证凭证所需参数
+// @Param			channel	body	string	true	"验证码渠道，可选值: email, img, sms, totp, messenger"
+// @Param			target	body	string	true	"验证码接收方，图形验证码、邮箱验证码与 TOTP 动态码为邮箱地址，短信验证码为手机号，即时通讯验证码为账户邮箱"
+// @Param			action	body	string	false	"验证码用途，需与发送验证码时一致，邮箱、短信与即时通讯验证码必填，可选值: register, reset_password, disable_totp, delete_account"
+// @Param			code	body	string	true	"验证码，使用托管人机验证时为验证令牌"
+// @Param			nonce	body	string	false	"获取验证码时下发的客户端随机数"
+type VerifyCodeRequest struct {
+	Channel string `json:"channel" xml:"channel" form:"channel" query:"channel" validate:"required,oneof=email img sms totp messenger"`
+	Target  string `json:"target" xml:"target" form:"target" query:"target" validate:"required"`
+	Action  string `json:"action" xml:"action" form:"action" query:"action" validate:"omitempty,oneof=register reset_password disable_totp delete_account"`
+	Code    string `json:"code" xml:"code" form:"code" query:"code" validate:"required"`
+	Nonce   string `json:"nonce" xml:"nonce" form:"nonce" query:"nonce"`
+}
@@ -0,0 +1,82 @@
+package verification
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/mail"
+	"jank.com/jank_blog/internal/utils"
+)
+
+// newLinkToken 生成邮件链接中的一次性令牌，缓存中只保存令牌的摘要
+func newLinkToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// linkTokenDigest 计算令牌的 SHA-256 摘要
+func linkTokenDigest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// linkURL 生成邮件链接，page 为前端页面地址，未配置时使用站点地址加 defaultPath
+func linkURL(config *configs.Config, page, defaultPath, token string) (string, error) {
+	if page == "" {
+		if config.SiteConfig.SiteURL == "" {
+			return "", fmt.Errorf("未配置前端页面地址与 SITE_URL，无法生成 %s 链接", defaultPath)
+		}
+		page = strings.TrimSuffix(config.SiteConfig.SiteURL, "/") + defaultPath
+	}
+	u, err := url.Parse(page)
+	if err != nil {
+		return "", fmt.Errorf("前端页面地址无效: %v", err)
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// siteName 站点名称，未配置时为 Jank Blog
+func siteName(config *configs.Config) string {
+	if config.SiteConfig.SiteTitle == "" {
+		return "Jank Blog"
+	}
+	return config.SiteConfig.SiteTitle
+}
+
+// deliverLinkEmail 发送链接邮件，开启邮件队列时写入队列后立即返回，队列不可用时同步发送
+func deliverLinkEmail(msg *mail.Message, email string, c echo.Context) error {
+	if msg.Subject == "" {
+		msg.Subject = utils.SUBJECT
+	}
+	if mailQueueEnabled() {
+		err := mail.Enqueue(context.Background(), msg, []string{email})
+		if err == nil {
+			return observeSend(ChannelEmail, email, nil, c)
+		}
+		utils.BizLogger(c).Errorf("链接邮件写入发送队列失败，改为同步发送: %v", err)
+	}
+
+	start := time.Now()
+	success, err := utils.SendHTMLEmail(msg.Subject, msg.Text, msg.HTML, []string{email})
+	mail.ObserveDelivery(mail.DeliverySync, time.Since(start), err)
+	if !success {
+		utils.BizLogger(c).Errorf("链接邮件发送失败，邮箱地址: %s, 错误: %v", email, err)
+		return observeSend(ChannelEmail, email, fmt.Errorf("链接邮件发送失败: %w", err), c)
+	}
+	return observeSend(ChannelEmail, email, nil, c)
+}
@@ -0,0 +1,66 @@
+package verification
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/serve/service/verification"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ListVerificationLogs godoc
+// @Summary      查询验证码审计日志
+// @Description  按事件、渠道、接收方、IP、结果与时间范围分页查询验证码发送与校验记录，最新的排在前面，仅管理员可用
+// @Tags         验证码审计
+// @Produce      json
+// @Param        request  query    dto.ListVerificationLogsRequest  false  "查询条件"
+// @Param        page     query    int     false  "页码"
+// @Param        pageSize query    int     false  "每页显示数量，最大 100"
+// @Param        cursor   query    string  false  "上一页返回的游标，传入时忽略页码"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]verification.VerificationLogVo,page=vo.PageMeta}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /verificationLog/listLogs [get]
+func ListVerificationLogs(c echo.Context) error {
+	req := new(dto.ListVerificationLogsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	logs, meta, err := service.ListVerificationLogs(req, vo.ParsePage(c), c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.SuccessWithPage(logs, meta, c))
+}
+
+// GetVerificationLogStats godoc
+// @Summary      统计验证码审计日志
+// @Description  按事件、渠道与结果分组统计符合条件的验证码记录条数，可用于发现同一 IP 或接收方的异常请求，仅管理员可用
+// @Tags         验证码审计
+// @Produce      json
+// @Param        request  query    dto.ListVerificationLogsRequest  false  "查询条件"
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=[]verification.VerificationLogStatVo}  "获取成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Failure      500  {object}  vo.Result  "服务器错误"
+// @Router       /verificationLog/getLogStats [get]
+func GetVerificationLogStats(c echo.Context) error {
+	req := new(dto.ListVerificationLogsRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	stats, err := service.GetVerificationLogStats(req, c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(stats, c))
+}
@@ -0,0 +1,164 @@
+package verification
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/verification"
+)
+
+const (
+	LoginCaptchaAccountCacheKeyPrefix = "LOGIN_CAPTCHA:FAIL:ACCOUNT:" // 账户的登录失败计数，键为前缀加邮箱，值为 "次数:计数起点毫秒时间戳"
+	LoginCaptchaIPCacheKeyPrefix      = "LOGIN_CAPTCHA:FAIL:IP:"      // IP 的登录失败计数
+	defaultLoginCaptchaDecay          = 10 * time.Minute
+)
+
+// decayCounterScript 读取随时间衰减的计数，每经过 ARGV[2] 毫秒计数减一，ARGV[3] 为 1 时在衰减后加一并写回
+// 计数归零后键随即过期，返回衰减后的计数
+var decayCounterScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local count, since = 0, now
+local stored = redis.call('GET', KEYS[1])
+if stored then
+	local sep = string.find(stored, ':', 1, true)
+	if sep then
+		count = tonumber(string.sub(stored, 1, sep - 1)) or 0
+		since = tonumber(string.sub(stored, sep + 1)) or now
+	end
+	local decayed = math.floor((now - since) / interval)
+	if decayed >= count then
+		count, since = 0, now
+	else
+		count, since = count - decayed, since + decayed * interval
+	end
+end
+if ARGV[3] == '1' then
+	count = count + 1
+	redis.call('SET', KEYS[1], count .. ':' .. since, 'PX', since + count * interval - now)
+end
+return count
+`)
+
+// LoginCaptchaRequired 密码登录是否需要人机验证，LOGIN_CAPTCHA_THRESHOLD 为 0 时始终需要；
+// 账户或当前 IP 的失败计数达到阈值后需要，计数随时间衰减；读取配置或缓存失败时按需要处理
+func LoginCaptchaRequired(email string, c echo.Context) bool {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载登录锁定配置失败: %v", err)
+		return true
+	}
+	cfg := config.LoginLockoutConfig
+	if cfg.LoginCaptchaThreshold <= 0 {
+		return true
+	}
+
+	for _, key := range loginCaptchaKeys(email, c) {
+		count, err := decayCount(key, loginCaptchaDecay(cfg), false)
+		if err != nil {
+			utils.BizLogger(c).Errorf("读取登录失败计数失败: %v", err)
+			return true
+		}
+		if count >= int64(cfg.LoginCaptchaThreshold) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordLoginCaptchaFailure 累加账户与当前 IP 的失败计数，未开启按失败次数要求人机验证时不计数
+func recordLoginCaptchaFailure(cfg configs.LoginLockoutConfig, email string, c echo.Context) {
+	if cfg.LoginCaptchaThreshold <= 0 {
+		return
+	}
+	for _, key := range loginCaptchaKeys(email, c) {
+		if _, err := decayCount(key, loginCaptchaDecay(cfg), true); err != nil {
+			utils.BizLogger(c).Errorf("记录登录失败计数失败: %v", err)
+		}
+	}
+}
+
+// GetLoginCaptcha godoc
+// @Summary      查询登录是否需要人机验证
+// @Description  查询使用该邮箱登录时是否需要完成人机验证；LOGIN_CAPTCHA_THRESHOLD 大于 0 时，账户或当前 IP 登录失败次数达到阈值后才需要，失败次数每隔 LOGIN_CAPTCHA_DECAY 分钟减一
+// @Tags         账户
+// @Produce      json
+// @Param        email  query  string  true  "登录邮箱"
+// @Success      200  {object}  vo.Result{data=verification.LoginCaptchaVo}  "查询成功"
+// @Failure      400  {object}  vo.Result  "请求参数错误"
+// @Router       /verification/getLoginCaptcha [get]
+func GetLoginCaptcha(c echo.Context) error {
+	req := new(dto.GetLoginCaptchaRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(verification.LoginCaptchaVo{Required: LoginCaptchaRequired(req.Email, c)}, c))
+}
+
+// loginCaptchaKeys 账户与当前 IP 的失败计数键
+func loginCaptchaKeys(email string, c echo.Context) []string {
+	return []string{LoginCaptchaAccountCacheKeyPrefix + strings.ToLower(email), LoginCaptchaIPCacheKeyPrefix + c.RealIP()}
+}
+
+// decayCount 原子地读取衰减后的计数，incr 为 true 时加一并写回
+func decayCount(key string, interval time.Duration, incr bool) (int64, error) {
+	now, step := time.Now().UnixMilli(), interval.Milliseconds()
+	incrArg := "0"
+	if incr {
+		incrArg = "1"
+	}
+	return cache.Eval(context.Background(), cache.Current(), decayCounterScript, func(tx cache.MemoryTx) (int64, error) {
+		count, since := int64(0), now
+		if stored, err := tx.Get(key); err == nil {
+			count, since = parseDecayCounter(stored, now)
+			if decayed := (now - since) / step; decayed >= count {
+				count, since = 0, now
+			} else {
+				count, since = count-decayed, since+decayed*step
+			}
+		}
+		if incr {
+			count++
+			tx.Set(key, strconv.FormatInt(count, 10)+":"+strconv.FormatInt(since, 10), time.Duration(since+count*step-now)*time.Millisecond)
+		}
+		return count, nil
+	}, []string{key}, now, step, incrArg)
+}
+
+// parseDecayCounter 解析 "次数:计数起点" 形式的计数值，格式无效时视为无计数
+func parseDecayCounter(stored string, now int64) (int64, int64) {
+	countPart, sincePart, ok := strings.Cut(stored, ":")
+	if !ok {
+		return 0, now
+	}
+	count, err := strconv.ParseInt(countPart, 10, 64)
+	if err != nil {
+		return 0, now
+	}
+	since, err := strconv.ParseInt(sincePart, 10, 64)
+	if err != nil {
+		return 0, now
+	}
+	return count, since
+}
+
+// loginCaptchaDecay 失败计数减一的间隔，未配置时为 10 分钟
+func loginCaptchaDecay(cfg configs.LoginLockoutConfig) time.Duration {
+	if cfg.LoginCaptchaDecay > 0 {
+		return time.Duration(cfg.LoginCaptchaDecay) * time.Minute
+	}
+	return defaultLoginCaptchaDecay
+}
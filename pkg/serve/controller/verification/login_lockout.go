@@ -0,0 +1,219 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+const (
+	LoginFailAccountCacheKeyPrefix = "LOGIN_LOCKOUT:FAIL:ACCOUNT:" // 账户在统计窗口内的登录失败次数，键为前缀加邮箱
+	LoginFailIPCacheKeyPrefix      = "LOGIN_LOCKOUT:FAIL:IP:"      // IP 在统计窗口内的登录失败次数
+	LoginLockAccountCacheKeyPrefix = "LOGIN_LOCKOUT:LOCK:ACCOUNT:" // 已锁定的账户，值为解锁令牌摘要
+	LoginLockIPCacheKeyPrefix      = "LOGIN_LOCKOUT:LOCK:IP:"      // 已暂停登录的 IP
+	AccountUnlockCacheKeyPrefix    = "LOGIN_LOCKOUT:UNLOCK:"       // 解锁链接，键为前缀加令牌摘要，值为邮箱
+	defaultLoginLockoutWindow      = 15 * time.Minute
+	defaultLoginLockoutDuration    = 30 * time.Minute
+)
+
+// ErrAccountUnlockLinkInvalid 解锁链接无效、已过期、已使用或账户已被解锁
+var ErrAccountUnlockLinkInvalid = errors.New("解锁链接无效或已过期")
+
+// loginLockedError 账户或 IP 处于锁定期
+type loginLockedError struct {
+	retryAfter time.Duration
+}
+
+func (e *loginLockedError) Error() string {
+	return fmt.Sprintf("登录失败次数过多，请 %d 秒后再试", int(e.retryAfter.Seconds()))
+}
+
+// CheckLoginLock 密码登录前检查账户与当前 IP 是否处于锁定期，锁定时返回剩余锁定时长；缓存异常时放行
+func CheckLoginLock(email string, c echo.Context) error {
+	ctx := context.Background()
+	keys := []string{LoginLockAccountCacheKeyPrefix + strings.ToLower(email), LoginLockIPCacheKeyPrefix + c.RealIP()}
+	for _, key := range keys {
+		ttl, err := cache.Current().TTL(ctx, key)
+		if err != nil {
+			utils.BizLogger(c).Errorf("读取登录锁定状态失败: %v", err)
+			continue
+		}
+		if ttl > 0 {
+			return &loginLockedError{retryAfter: ttl}
+		}
+	}
+	return nil
+}
+
+// RecordLoginFailure 记录一次密码登录失败，账户或 IP 的失败次数达到阈值时锁定并返回锁定错误；
+// 账户被锁定且邮箱已注册时向邮箱发送解锁链接，未注册的邮箱同样计数与锁定，避免通过锁定行为探测邮箱是否已注册
+func RecordLoginFailure(email string, registered bool, c echo.Context) error {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载登录锁定配置失败: %v", err)
+		return nil
+	}
+	cfg := config.LoginLockoutConfig
+	window, duration := loginLockoutWindow(cfg), loginLockoutDuration(cfg)
+	email = strings.ToLower(email)
+	ctx := context.Background()
+
+	recordLoginCaptchaFailure(cfg, email, c)
+
+	var locked error
+	if cfg.LoginLockoutIPThreshold > 0 {
+		ip := c.RealIP()
+		count, err := cache.Current().Incr(ctx, LoginFailIPCacheKeyPrefix+ip, window)
+		if err != nil {
+			utils.BizLogger(c).Errorf("记录 IP 登录失败次数失败: %v", err)
+		} else if count >= int64(cfg.LoginLockoutIPThreshold) {
+			if err := cache.Current().Set(ctx, LoginLockIPCacheKeyPrefix+ip, 1, duration); err != nil {
+				utils.BizLogger(c).Errorf("写入 IP 登录锁定状态失败: %v", err)
+			} else {
+				cache.Current().Del(ctx, LoginFailIPCacheKeyPrefix+ip)
+				utils.BizLogger(c).Warnf("IP %s 登录失败次数过多，暂停登录 %s", ip, duration)
+				locked = &loginLockedError{retryAfter: duration}
+			}
+		}
+	}
+
+	if cfg.LoginLockoutAccountThreshold > 0 {
+		count, err := cache.Current().Incr(ctx, LoginFailAccountCacheKeyPrefix+email, window)
+		if err != nil {
+			utils.BizLogger(c).Errorf("记录账户登录失败次数失败: %v", err)
+		} else if count >= int64(cfg.LoginLockoutAccountThreshold) {
+			if err := lockAccount(config, email, registered, duration, c); err != nil {
+				utils.BizLogger(c).Errorf("锁定账户失败: %v", err)
+			} else {
+				locked = &loginLockedError{retryAfter: duration}
+			}
+		}
+	}
+	return locked
+}
+
+// ClearLoginFailures 登录成功后清零账户的失败次数与人机验证的失败计数，IP 的失败次数不受影响
+func ClearLoginFailures(email string) {
+	email = strings.ToLower(email)
+	cache.Current().Del(context.Background(), LoginFailAccountCacheKeyPrefix+email, LoginCaptchaAccountCacheKeyPrefix+email)
+}
+
+// UnlockAccountByLink 使用邮件中的解锁链接解除账户锁定，链接随即失效，返回被解锁的邮箱
+func UnlockAccountByLink(token string) (string, error) {
+	digest := linkTokenDigest(token)
+	ctx := context.Background()
+	email, err := cache.Current().Get(ctx, AccountUnlockCacheKeyPrefix+digest)
+	if err != nil {
+		return "", ErrAccountUnlockLinkInvalid
+	}
+	// 账户在链接发出后已被解锁或重新锁定时，此前的链接失效
+	current, err := cache.Current().Get(ctx, LoginLockAccountCacheKeyPrefix+email)
+	if err != nil || current != digest {
+		cache.Current().Del(ctx, AccountUnlockCacheKeyPrefix+digest)
+		return "", ErrAccountUnlockLinkInvalid
+	}
+	UnlockLogin(email, "")
+	return email, nil
+}
+
+// UnlockLogin 解除账户锁定并清零失败次数，ip 不为空时同时解除该 IP 的登录暂停，供管理员在后台解锁
+func UnlockLogin(email, ip string) {
+	ctx := context.Background()
+	if email != "" {
+		email = strings.ToLower(email)
+		if digest, err := cache.Current().Get(ctx, LoginLockAccountCacheKeyPrefix+email); err == nil {
+			cache.Current().Del(ctx, AccountUnlockCacheKeyPrefix+digest)
+		}
+		cache.Current().Del(ctx, LoginLockAccountCacheKeyPrefix+email, LoginFailAccountCacheKeyPrefix+email, LoginCaptchaAccountCacheKeyPrefix+email)
+	}
+	if ip != "" {
+		cache.Current().Del(ctx, LoginLockIPCacheKeyPrefix+ip, LoginFailIPCacheKeyPrefix+ip, LoginCaptchaIPCacheKeyPrefix+ip)
+	}
+}
+
+// LoginLockFailResponse 根据登录锁定相关错误返回对应的失败响应
+func LoginLockFailResponse(err error, c echo.Context) error {
+	var locked *loginLockedError
+	switch {
+	case errors.As(err, &locked):
+		return retryAfterResponse(locked.retryAfter, bizErr.New(bizErr.LoginLocked), c)
+	case errors.Is(err, ErrAccountUnlockLinkInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.AccountUnlockLinkInvalid), c))
+	}
+	return c.JSON(http.StatusInternalServerError, vo.Fail(err.Error(), bizErr.New(bizErr.ServerError), c))
+}
+
+// lockAccount 锁定账户并清零失败次数，邮箱已注册时生成解锁链接并发送锁定通知邮件；
+// 邮件发送失败不影响锁定，用户可等待锁定期结束或联系管理员解锁
+func lockAccount(config *configs.Config, email string, registered bool, duration time.Duration, c echo.Context) error {
+	token, err := newLinkToken()
+	if err != nil {
+		return fmt.Errorf("生成解锁链接令牌失败: %v", err)
+	}
+	digest := linkTokenDigest(token)
+	ctx := context.Background()
+	if err := cache.Current().Set(ctx, LoginLockAccountCacheKeyPrefix+email, digest, duration); err != nil {
+		return fmt.Errorf("写入账户锁定状态失败: %v", err)
+	}
+	cache.Current().Del(ctx, LoginFailAccountCacheKeyPrefix+email)
+	utils.BizLogger(c).Warnf("「%s」登录失败次数过多，账户锁定 %s", email, duration)
+	if !registered {
+		return nil
+	}
+
+	if err := cache.Current().Set(ctx, AccountUnlockCacheKeyPrefix+digest, email, duration); err != nil {
+		utils.BizLogger(c).Errorf("解锁链接写入缓存失败: %v", err)
+		return nil
+	}
+	unlockURL, err := linkURL(config, config.LoginLockoutConfig.LoginLockoutUnlockURL, "/unlock-account", token)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil
+	}
+	locale := i18n.FromRequest(c.Request())
+	msg, err := mail.Render(mail.TemplateAccountUnlock, locale, mail.AccountUnlockData{
+		SiteName:    siteName(config),
+		SiteURL:     config.SiteConfig.SiteURL,
+		UnlockURL:   unlockURL,
+		LockMinutes: int(duration.Round(time.Minute).Minutes()),
+		IP:          c.RealIP(),
+		Locale:      locale,
+	}, config.AppConfig.EmailTemplateDir)
+	if err != nil {
+		utils.BizLogger(c).Errorf("渲染账户锁定邮件失败: %v", err)
+		return nil
+	}
+	if err := deliverLinkEmail(msg, email, c); err != nil {
+		utils.BizLogger(c).Errorf("账户锁定邮件发送失败: %v", err)
+	}
+	return nil
+}
+
+// loginLockoutWindow 登录失败次数的统计窗口，未配置时为 15 分钟
+func loginLockoutWindow(cfg configs.LoginLockoutConfig) time.Duration {
+	if cfg.LoginLockoutWindow > 0 {
+		return time.Duration(cfg.LoginLockoutWindow) * time.Minute
+	}
+	return defaultLoginLockoutWindow
+}
+
+// loginLockoutDuration 锁定时长，未配置时为 30 分钟
+func loginLockoutDuration(cfg configs.LoginLockoutConfig) time.Duration {
+	if cfg.LoginLockoutDuration > 0 {
+		return time.Duration(cfg.LoginLockoutDuration) * time.Minute
+	}
+	return defaultLoginLockoutDuration
+}
@@ -0,0 +1,264 @@
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+const (
+	MagicLinkCacheKeyPrefix         = "MAGIC_LINK:LINK:"     // 免密登录链接，键为前缀加令牌摘要
+	MagicLinkLatestCacheKeyPrefix   = "MAGIC_LINK:LATEST:"   // 邮箱最近一次发送的令牌摘要，此前的链接失效
+	MagicLinkUsedCacheKeyPrefix     = "MAGIC_LINK:USED:"     // 已使用的令牌或请求摘要，保证链接与确认结果只能使用一次
+	MagicLinkApprovedCacheKeyPrefix = "MAGIC_LINK:APPROVED:" // 已在其他设备上确认的登录请求，键为前缀加请求 ID 摘要，值为邮箱
+	defaultMagicLinkExpire          = 15 * time.Minute
+)
+
+var (
+	ErrMagicLinkDisabled       = errors.New("免密登录未开启")
+	ErrMagicLinkInvalid        = errors.New("登录链接无效或已过期")
+	ErrMagicLinkDeviceMismatch = errors.New("登录链接不是在发起请求的设备上打开的，需确认后登录")
+)
+
+// MagicLinkInfo 免密登录链接对应的登录请求
+type MagicLinkInfo struct {
+	Email     string    // 登录的邮箱
+	Device    string    // 发起请求的设备
+	IP        string    // 发起请求的 IP
+	ExpiresAt time.Time // 链接过期时间
+}
+
+// magicLinkRecord 缓存中的登录链接
+type magicLinkRecord struct {
+	Email   string `json:"email"`
+	Request string `json:"request"` // 发起请求的设备持有的请求 ID 的摘要
+	Device  string `json:"device"`
+	IP      string `json:"ip"`
+}
+
+// MagicLinkEnabled 是否开启免密登录
+func MagicLinkEnabled() bool {
+	config, err := configs.LoadConfig()
+	return err == nil && config.MagicLinkConfig.MagicLinkEnabled
+}
+
+// SendMagicLink 向邮箱发送免密登录链接，返回由发起请求的设备保存的请求 ID；
+// 在同一设备上打开链接时提交请求 ID 直接登录，在其他设备上打开时确认后，发起请求的设备凭请求 ID 登录。
+// registered 为 false 时仅计入限流不发送邮件，同样返回请求 ID，避免通过接口探测邮箱是否已注册
+func SendMagicLink(email string, registered bool, c echo.Context) (string, error) {
+	if !MagicLinkEnabled() {
+		return "", ErrMagicLinkDisabled
+	}
+	email = strings.ToLower(email)
+	if retryAfter := checkSendRate(ChannelEmail, email, c); retryAfter > 0 {
+		return "", &rateLimitedError{retryAfter: retryAfter}
+	}
+	requestID, err := newLinkToken()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成登录请求 ID 失败: %v", err)
+		return "", err
+	}
+	if !registered {
+		return requestID, nil
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	token, err := newLinkToken()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成登录链接令牌失败: %v", err)
+		return "", err
+	}
+	expire := defaultMagicLinkExpire
+	if minutes := config.MagicLinkConfig.MagicLinkExpire; minutes > 0 {
+		expire = time.Duration(minutes) * time.Minute
+	}
+	loginURL, err := linkURL(config, config.MagicLinkConfig.MagicLinkURL, "/magic-link", token)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return "", err
+	}
+
+	record := magicLinkRecord{
+		Email:   email,
+		Request: linkTokenDigest(requestID),
+		Device:  session.DeviceName(c.Request().UserAgent()),
+		IP:      c.RealIP(),
+	}
+	locale := i18n.FromRequest(c.Request())
+	msg, err := mail.Render(mail.TemplateMagicLink, locale, mail.MagicLinkData{
+		SiteName:      siteName(config),
+		SiteURL:       config.SiteConfig.SiteURL,
+		LoginURL:      loginURL,
+		ExpireMinutes: int(expire.Round(time.Minute).Minutes()),
+		Device:        record.Device,
+		IP:            record.IP,
+		Locale:        locale,
+	}, config.AppConfig.EmailTemplateDir)
+	if err != nil {
+		utils.BizLogger(c).Errorf("渲染登录链接邮件失败: %v", err)
+		return "", err
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	digest := linkTokenDigest(token)
+	ctx := context.Background()
+	if err := cache.Current().Set(ctx, MagicLinkCacheKeyPrefix+digest, string(value), expire); err != nil {
+		utils.BizLogger(c).Errorf("登录链接写入缓存失败: %v", err)
+		return "", err
+	}
+	if err := cache.Current().Set(ctx, MagicLinkLatestCacheKeyPrefix+email, digest, expire); err != nil {
+		utils.BizLogger(c).Errorf("登录链接写入缓存失败: %v", err)
+		return "", err
+	}
+
+	if err := deliverLinkEmail(msg, email, c); err != nil {
+		cache.Current().Del(ctx, MagicLinkCacheKeyPrefix+digest, MagicLinkLatestCacheKeyPrefix+email)
+		return "", err
+	}
+	return requestID, nil
+}
+
+// CheckMagicLink 校验免密登录链接中的令牌，返回对应的登录请求，不作废令牌
+func CheckMagicLink(token string) (*MagicLinkInfo, error) {
+	record, expiresAt, err := lookupMagicLink(token)
+	if err != nil {
+		return nil, err
+	}
+	return &MagicLinkInfo{Email: record.Email, Device: record.Device, IP: record.IP, ExpiresAt: expiresAt}, nil
+}
+
+// ConsumeMagicLink 在发起请求的设备上使用登录链接，请求 ID 与链接匹配时作废令牌并返回邮箱，
+// 不匹配时返回 ErrMagicLinkDeviceMismatch，令牌保持有效，可改为确认登录
+func ConsumeMagicLink(token, requestID string) (string, error) {
+	record, expiresAt, err := lookupMagicLink(token)
+	if err != nil {
+		return "", err
+	}
+	if requestID == "" || linkTokenDigest(requestID) != record.Request {
+		return "", ErrMagicLinkDeviceMismatch
+	}
+	if err := claimMagicLink(linkTokenDigest(token), expiresAt); err != nil {
+		return "", err
+	}
+	cache.Current().Del(context.Background(), MagicLinkCacheKeyPrefix+linkTokenDigest(token), MagicLinkLatestCacheKeyPrefix+record.Email)
+	return record.Email, nil
+}
+
+// ApproveMagicLink 在打开链接的设备上确认登录，作废令牌，之后发起请求的设备可凭请求 ID 登录
+func ApproveMagicLink(token string) (*MagicLinkInfo, error) {
+	record, expiresAt, err := lookupMagicLink(token)
+	if err != nil {
+		return nil, err
+	}
+	digest := linkTokenDigest(token)
+	if err := claimMagicLink(digest, expiresAt); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if err := cache.Current().Set(ctx, MagicLinkApprovedCacheKeyPrefix+record.Request, record.Email, time.Until(expiresAt)); err != nil {
+		return nil, fmt.Errorf("写入登录确认结果失败: %v", err)
+	}
+	cache.Current().Del(ctx, MagicLinkCacheKeyPrefix+digest, MagicLinkLatestCacheKeyPrefix+record.Email)
+	return &MagicLinkInfo{Email: record.Email, Device: record.Device, IP: record.IP, ExpiresAt: expiresAt}, nil
+}
+
+// ClaimMagicLinkApproval 发起请求的设备查询登录请求是否已在其他设备上确认，已确认时返回邮箱，确认结果只能领取一次
+func ClaimMagicLinkApproval(requestID string) (string, bool, error) {
+	if !MagicLinkEnabled() {
+		return "", false, ErrMagicLinkDisabled
+	}
+	request := linkTokenDigest(requestID)
+	ctx := context.Background()
+	key := MagicLinkApprovedCacheKeyPrefix + request
+	email, err := cache.Current().Get(ctx, key)
+	if errors.Is(err, cache.ErrMiss) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("读取登录确认结果失败: %v", err)
+	}
+	ttl, err := cache.Current().TTL(ctx, key)
+	if err != nil || ttl <= 0 {
+		return "", false, nil
+	}
+	if err := claimMagicLink(request, time.Now().Add(ttl)); err != nil {
+		return "", false, err
+	}
+	cache.Current().Del(ctx, key)
+	return email, true, nil
+}
+
+// MagicLinkFailResponse 免密登录链接相关请求失败的响应
+func MagicLinkFailResponse(err error, c echo.Context) error {
+	var limited *rateLimitedError
+	switch {
+	case errors.As(err, &limited):
+		return retryAfterResponse(limited.retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	case errors.Is(err, ErrMagicLinkDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.MagicLinkDisabled), c))
+	case errors.Is(err, ErrMagicLinkInvalid):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.MagicLinkInvalid), c))
+	case errors.Is(err, utils.ErrMailUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.MailServiceUnavailable), c))
+	}
+	return c.JSON(http.StatusInternalServerError, vo.Fail("登录链接邮件发送失败", bizErr.New(bizErr.SendMagicLinkFail), c))
+}
+
+// lookupMagicLink 校验令牌并返回缓存中的登录链接与过期时间
+func lookupMagicLink(token string) (*magicLinkRecord, time.Time, error) {
+	if !MagicLinkEnabled() {
+		return nil, time.Time{}, ErrMagicLinkDisabled
+	}
+	digest := linkTokenDigest(token)
+	ctx := context.Background()
+	value, err := cache.Current().Get(ctx, MagicLinkCacheKeyPrefix+digest)
+	if err != nil {
+		return nil, time.Time{}, ErrMagicLinkInvalid
+	}
+	var record magicLinkRecord
+	if err := json.Unmarshal([]byte(value), &record); err != nil {
+		return nil, time.Time{}, ErrMagicLinkInvalid
+	}
+	if latest, err := cache.Current().Get(ctx, MagicLinkLatestCacheKeyPrefix+record.Email); err != nil || latest != digest {
+		return nil, time.Time{}, ErrMagicLinkInvalid
+	}
+	ttl, err := cache.Current().TTL(ctx, MagicLinkCacheKeyPrefix+digest)
+	if err != nil || ttl <= 0 {
+		return nil, time.Time{}, ErrMagicLinkInvalid
+	}
+	return &record, time.Now().Add(ttl), nil
+}
+
+// claimMagicLink 使用 SetNX 标记令牌或请求已使用，并发使用时只有一个请求成功
+func claimMagicLink(digest string, expiresAt time.Time) error {
+	fresh, err := cache.Current().SetNX(context.Background(), MagicLinkUsedCacheKeyPrefix+digest, 1, time.Until(expiresAt))
+	if err != nil {
+		return fmt.Errorf("作废登录链接失败: %v", err)
+	}
+	if !fresh {
+		return ErrMagicLinkInvalid
+	}
+	return nil
+}
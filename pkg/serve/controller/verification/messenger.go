@@ -0,0 +1,195 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/messenger"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/captcha"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// ErrMessengerNotLinked 账户未绑定请求的即时通讯账号
+var ErrMessengerNotLinked = errors.New("账户未绑定该即时通讯账号")
+
+// rateLimitedError 发送过于频繁，retryAfter 为需要等待的时长
+type rateLimitedError struct {
+	retryAfter time.Duration
+}
+
+func (e *rateLimitedError) Error() string {
+	return "发送过于频繁，请稍后再试"
+}
+
+// SendMessengerVerificationCode godoc
+// @Summary 发送即时通讯验证码
+// @Description 向账户绑定的 Telegram 或 WhatsApp 账号发送验证码，验证码有效期为3分钟；冷却期结束后再次调用会作废原验证码并发送新的验证码
+// @Tags 账户
+// @Accept json
+// @Produce json
+// @Param request body dto.SendMessengerVerificationCodeRequest true "账户邮箱、即时通讯服务与验证码用途"
+// @Success 200 {object} vo.Result "验证码发送成功, 请注意查收"
+// @Failure 400 {object} vo.Result{data=[]utils.ValidErrRes} "请求参数错误或账户未绑定该即时通讯账号"
+// @Failure 429 {object} vo.Result{data=verification.CooldownVo} "仍在冷却期或发送过于频繁"
+// @Failure 500 {object} vo.Result "服务器错误，验证码发送失败"
+// @Failure 503 {object} vo.Result "未开启该即时通讯服务"
+// @Router /verification/sendMessengerVerificationCode [post]
+func SendMessengerVerificationCode(c echo.Context) error {
+	req := new(dto.SendMessengerVerificationCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendMessengerCodeFail, err.Error()), c))
+	}
+	email := req.Email
+	codeKey := scopedKey(MessengerVerificationCodeCacheKeyPrefix, req.Action, email)
+	cooldownKey := scopedKey(MessengerVerificationCooldownCacheKeyPrefix, req.Action, email)
+
+	sender, err := messengerSender(req.Provider, c)
+	if err != nil {
+		return MessengerFailResponse(err, c)
+	}
+
+	// 账户不存在时同样返回未绑定，避免探测已注册的邮箱
+	acc, err := mapper.GetAccountByEmail(email)
+	if err != nil || acc.MessengerHandle(req.Provider) == "" {
+		return MessengerFailResponse(ErrMessengerNotLinked, c)
+	}
+
+	cooldown, err := cache.Current().TTL(context.Background(), cooldownKey)
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询即时通讯验证码冷却时间失败: %v", err)
+		return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+	}
+	if cooldown > 0 {
+		ttl, err := cache.Current().TTL(context.Background(), codeKey)
+		if err != nil {
+			utils.BizLogger(c).Errorf("检查即时通讯验证码是否有效失败: %v", err)
+			return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+		}
+		return cooldownResponse(ttl, cooldown, c)
+	}
+
+	if retryAfter := checkSendRate(ChannelMessenger, email, c); retryAfter > 0 {
+		return retryAfterResponse(retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	}
+
+	nonce, err := flowNonce(req.Nonce, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成客户端随机数失败: %v", err)
+		return MessengerFailResponse(err, c)
+	}
+	err = sendMessengerCode(sender, acc.MessengerHandle(req.Provider), codeKey, nonce, c)
+	if err := observeSend(ChannelMessenger, email, err, c); err != nil {
+		return MessengerFailResponse(err, c)
+	}
+
+	if cooldown := resendCooldown(); cooldown > 0 {
+		if err := cache.Current().Set(context.Background(), cooldownKey, 1, cooldown); err != nil {
+			utils.BizLogger(c).Errorf("即时通讯验证码冷却时间写入缓存失败: %v", err)
+		}
+	}
+	return c.JSON(http.StatusOK, vo.Success("验证码发送成功, 请注意查收！", c))
+}
+
+// VerifyMessengerCode 校验即时通讯验证码，email 为账户邮箱，nonce 为发送验证码时下发的客户端随机数，action 为发送验证码时声明的用途
+func VerifyMessengerCode(code, email, nonce, action string, c echo.Context) error {
+	return observeVerify(ChannelMessenger, email, verifyCode(code, email, nonce, action, MessengerVerificationCodeCacheKeyPrefix, c), c)
+}
+
+// SendMessengerLinkCode 向待绑定的即时通讯账号发送验证码，确认绑定时需提交相同的账号
+func SendMessengerLinkCode(accountID int64, provider, handle string, c echo.Context) error {
+	if err := messenger.ValidHandle(provider, handle); err != nil {
+		return err
+	}
+	sender, err := messengerSender(provider, c)
+	if err != nil {
+		return err
+	}
+
+	target := messengerLinkTarget(accountID, provider)
+	if retryAfter := checkSendRate(ChannelMessenger, target, c); retryAfter > 0 {
+		return &rateLimitedError{retryAfter: retryAfter}
+	}
+	// 待绑定的账号写入随机数位置，确认时账号不一致视为验证码错误
+	err = sendMessengerCode(sender, handle, MessengerLinkCacheKeyPrefix+target, handle, c)
+	return observeSend(ChannelMessenger, target, err, c)
+}
+
+// VerifyMessengerLinkCode 校验待绑定账号收到的验证码，通过后验证码作废
+func VerifyMessengerLinkCode(accountID int64, provider, handle, code string, c echo.Context) error {
+	target := messengerLinkTarget(accountID, provider)
+	return observeVerify(ChannelMessenger, target, checkCode(captcha.CodeCheck{
+		Key:         MessengerLinkCacheKeyPrefix + target,
+		Code:        code,
+		Nonce:       handle,
+		MaxAttempts: maxVerifyAttempts(),
+	}, c), c)
+}
+
+// sendMessengerCode 生成验证码并发送到即时通讯账号，验证码与 nonce 绑定，覆盖未过期的旧验证码
+func sendMessengerCode(sender messenger.Sender, handle, key, nonce string, c echo.Context) error {
+	code, err := newCode()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成即时通讯验证码失败: %v", err)
+		return err
+	}
+	err = cache.Current().Set(context.Background(), key, captcha.BindNonce(nonce, code), EmailVerificationCodeCacheExpiration)
+	if err != nil {
+		utils.BizLogger(c).Errorf("即时通讯验证码写入缓存失败: %v", err)
+		return err
+	}
+	cache.Current().Del(context.Background(), key+verificationAttemptsKeySuffix)
+
+	if err := sender.SendCode(c.Request().Context(), handle, code, EmailVerificationCodeCacheExpiration); err != nil {
+		utils.BizLogger(c).Errorf("即时通讯验证码发送失败，服务: %s, 错误: %v", sender.Name(), err)
+		cache.Current().Del(context.Background(), key)
+		return err
+	}
+	return nil
+}
+
+// messengerSender 根据配置创建即时通讯服务
+func messengerSender(provider string, c echo.Context) (messenger.Sender, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载即时通讯服务配置失败: %v", err)
+		return nil, err
+	}
+	sender, err := messenger.New(config, provider)
+	if err != nil && !errors.Is(err, messenger.ErrDisabled) {
+		utils.BizLogger(c).Errorf("创建即时通讯服务失败: %v", err)
+	}
+	return sender, err
+}
+
+// messengerLinkTarget 绑定验证码的接收方，由账户 ID 与服务名称组成
+func messengerLinkTarget(accountID int64, provider string) string {
+	return fmt.Sprintf("%d:%s", accountID, provider)
+}
+
+// MessengerFailResponse 即时通讯验证码发送失败的响应，发送过于频繁时返回 429 与等待秒数
+func MessengerFailResponse(err error, c echo.Context) error {
+	var limited *rateLimitedError
+	switch {
+	case errors.As(err, &limited):
+		return retryAfterResponse(limited.retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	case errors.Is(err, messenger.ErrInvalidHandle):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	case errors.Is(err, messenger.ErrDisabled):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.MessengerDisabled), c))
+	case errors.Is(err, ErrMessengerNotLinked):
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.MessengerNotLinked), c))
+	}
+	return c.JSON(http.StatusInternalServerError, vo.Fail("验证码发送失败", bizErr.New(bizErr.SendMessengerCodeFail), c))
+}
@@ -0,0 +1,68 @@
+package verification
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/verification"
+)
+
+// 验证码相关指标，渠道取值同 ChannelImg、ChannelEmail、ChannelSms
+var (
+	codesSent = global.Metrics.Counter("jank_verification_codes_sent_total",
+		"发送成功的验证码数", "channel")
+	sendFailures = global.Metrics.Counter("jank_verification_send_failures_total",
+		"生成、缓存或发送失败的验证码数", "channel")
+	rateLimited = global.Metrics.Counter("jank_verification_rate_limited_total",
+		"因限流被拒绝的发送验证码请求数", "channel")
+	verifyResults = global.Metrics.Counter("jank_verification_verify_total",
+		"验证码校验次数，result 取值 success、mismatch、expired、locked、not_enrolled、error", "channel", "result")
+)
+
+// 发送结果
+const (
+	resultSuccess     = "success"
+	resultFailure     = "failure"
+	resultRateLimited = "rate_limited"
+)
+
+// observeSend 记录一次验证码发送结果的指标与审计日志，并原样返回错误
+func observeSend(channel, target string, err error, c echo.Context) error {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+		sendFailures.Inc(channel)
+	} else {
+		codesSent.Inc(channel)
+	}
+	recordLog(model.EventSend, channel, target, result, c)
+	return err
+}
+
+// observeRateLimited 记录一次因限流被拒绝的发送请求
+func observeRateLimited(channel, target string, c echo.Context) {
+	rateLimited.Inc(channel)
+	recordLog(model.EventSend, channel, target, resultRateLimited, c)
+}
+
+// observeVerify 记录一次验证码校验结果的指标与审计日志，并原样返回错误
+func observeVerify(channel, target string, err error, c echo.Context) error {
+	result := "error"
+	switch {
+	case err == nil:
+		result = resultSuccess
+	case errors.Is(err, ErrVerificationCodeMismatch):
+		result = "mismatch"
+	case errors.Is(err, ErrVerificationCodeExpired):
+		result = "expired"
+	case errors.Is(err, ErrVerificationAttemptsExceeded):
+		result = "locked"
+	case errors.Is(err, ErrTotpNotEnrolled):
+		result = "not_enrolled"
+	}
+	verifyResults.Inc(channel, result)
+	recordLog(model.EventVerify, channel, target, result, c)
+	return err
+}
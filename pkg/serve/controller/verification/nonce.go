@@ -0,0 +1,48 @@
+package verification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/cache"
+)
+
+const (
+	NonceHeader         = "X-Verification-Nonce" // 下发客户端随机数的响应头
+	NonceCacheKeyPrefix = "VERIFICATION:NONCE:"  // 服务端下发的客户端随机数，键为前缀加随机数
+	NonceExpiration     = 30 * time.Minute       // 客户端随机数的有效期，覆盖一次注册或登录流程
+)
+
+// flowNonce 获取验证码时使用的客户端随机数，随机数只能由服务端生成：
+// 请求中提交了仍有效的随机数时沿用并延长有效期，否则生成新的随机数，并通过响应头返回给客户端
+// 客户端在同一次注册或登录流程中获取各类验证码与提交表单时应携带同一个随机数，内置图形验证码与滑动拼图以随机数作为验证码 ID
+func flowNonce(supplied string, c echo.Context) (string, error) {
+	ctx := context.Background()
+	nonce := supplied
+	if nonce != "" {
+		if _, err := cache.Current().Get(ctx, NonceCacheKeyPrefix+nonce); err != nil {
+			if !errors.Is(err, cache.ErrMiss) {
+				return "", fmt.Errorf("读取客户端随机数失败: %v", err)
+			}
+			nonce = ""
+		}
+	}
+	if nonce == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		nonce = hex.EncodeToString(b)
+	}
+	if err := cache.Current().Set(ctx, NonceCacheKeyPrefix+nonce, 1, NonceExpiration); err != nil {
+		return "", fmt.Errorf("记录客户端随机数失败: %v", err)
+	}
+	c.Response().Header().Set(NonceHeader, nonce)
+	return nonce, nil
+}
@@ -0,0 +1,145 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+const (
+	PasswordResetLinkCacheKeyPrefix   = "PASSWORD_RESET:LINK:"   // 重置密码链接，键为前缀加令牌摘要，值为邮箱
+	PasswordResetLatestCacheKeyPrefix = "PASSWORD_RESET:LATEST:" // 邮箱最近一次发送的令牌摘要，此前的链接失效
+	PasswordResetUsedCacheKeyPrefix   = "PASSWORD_RESET:USED:"   // 已使用的令牌摘要，保证并发提交时只有一次成功
+	defaultPasswordResetExpire        = 30 * time.Minute
+)
+
+// ErrPasswordResetLinkInvalid 重置密码链接无效、已过期、已使用或已被新链接替代
+var ErrPasswordResetLinkInvalid = errors.New("重置密码链接无效或已过期")
+
+// SendPasswordResetLink 向邮箱发送重置密码链接，registered 为 false 时仅计入限流不发送邮件，
+// 使已注册与未注册邮箱的响应一致，避免通过接口探测邮箱是否已注册
+func SendPasswordResetLink(email string, registered bool, c echo.Context) error {
+	email = strings.ToLower(email)
+	if retryAfter := checkSendRate(ChannelEmail, email, c); retryAfter > 0 {
+		return &rateLimitedError{retryAfter: retryAfter}
+	}
+	if !registered {
+		return nil
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return err
+	}
+	token, err := newLinkToken()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成重置密码令牌失败: %v", err)
+		return err
+	}
+	expire := passwordResetExpire(config)
+	resetURL, err := linkURL(config, config.PasswordResetConfig.PasswordResetURL, "/reset-password", token)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+
+	locale := i18n.FromRequest(c.Request())
+	msg, err := mail.Render(mail.TemplatePasswordReset, locale, mail.PasswordResetData{
+		SiteName:      siteName(config),
+		SiteURL:       config.SiteConfig.SiteURL,
+		ResetURL:      resetURL,
+		ExpireMinutes: int(expire.Round(time.Minute).Minutes()),
+		Locale:        locale,
+	}, config.AppConfig.EmailTemplateDir)
+	if err != nil {
+		utils.BizLogger(c).Errorf("渲染重置密码邮件失败: %v", err)
+		return err
+	}
+
+	digest := linkTokenDigest(token)
+	ctx := context.Background()
+	if err := cache.Current().Set(ctx, PasswordResetLinkCacheKeyPrefix+digest, email, expire); err != nil {
+		utils.BizLogger(c).Errorf("重置密码令牌写入缓存失败: %v", err)
+		return err
+	}
+	if err := cache.Current().Set(ctx, PasswordResetLatestCacheKeyPrefix+email, digest, expire); err != nil {
+		utils.BizLogger(c).Errorf("重置密码令牌写入缓存失败: %v", err)
+		return err
+	}
+
+	if err := deliverLinkEmail(msg, email, c); err != nil {
+		cache.Current().Del(ctx, PasswordResetLinkCacheKeyPrefix+digest, PasswordResetLatestCacheKeyPrefix+email)
+		return err
+	}
+	return nil
+}
+
+// CheckPasswordResetLink 校验重置密码链接中的令牌，返回对应的邮箱与链接过期时间，不作废令牌
+func CheckPasswordResetLink(token string) (string, time.Time, error) {
+	digest := linkTokenDigest(token)
+	ctx := context.Background()
+	email, err := cache.Current().Get(ctx, PasswordResetLinkCacheKeyPrefix+digest)
+	if err != nil {
+		return "", time.Time{}, ErrPasswordResetLinkInvalid
+	}
+	if latest, err := cache.Current().Get(ctx, PasswordResetLatestCacheKeyPrefix+email); err != nil || latest != digest {
+		return "", time.Time{}, ErrPasswordResetLinkInvalid
+	}
+	ttl, err := cache.Current().TTL(ctx, PasswordResetLinkCacheKeyPrefix+digest)
+	if err != nil || ttl <= 0 {
+		return "", time.Time{}, ErrPasswordResetLinkInvalid
+	}
+	return email, time.Now().Add(ttl), nil
+}
+
+// ConsumePasswordResetLink 校验并作废重置密码链接中的令牌，返回对应的邮箱
+func ConsumePasswordResetLink(token string) (string, error) {
+	email, expiresAt, err := CheckPasswordResetLink(token)
+	if err != nil {
+		return "", err
+	}
+	digest := linkTokenDigest(token)
+	ctx := context.Background()
+	fresh, err := cache.Current().SetNX(ctx, PasswordResetUsedCacheKeyPrefix+digest, 1, time.Until(expiresAt))
+	if err != nil {
+		return "", fmt.Errorf("作废重置密码令牌失败: %v", err)
+	}
+	if !fresh {
+		return "", ErrPasswordResetLinkInvalid
+	}
+	cache.Current().Del(ctx, PasswordResetLinkCacheKeyPrefix+digest, PasswordResetLatestCacheKeyPrefix+email)
+	return email, nil
+}
+
+// PasswordResetFailResponse 发送重置密码链接失败的响应，发送过于频繁时返回 429 与等待秒数，邮件发送熔断时返回 503
+func PasswordResetFailResponse(err error, c echo.Context) error {
+	var limited *rateLimitedError
+	switch {
+	case errors.As(err, &limited):
+		return retryAfterResponse(limited.retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	case errors.Is(err, utils.ErrMailUnavailable):
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.MailServiceUnavailable), c))
+	}
+	return c.JSON(http.StatusInternalServerError, vo.Fail("重置密码邮件发送失败", bizErr.New(bizErr.SendPasswordResetLinkFail), c))
+}
+
+// passwordResetExpire 重置密码链接有效期，未配置时为 30 分钟
+func passwordResetExpire(config *configs.Config) time.Duration {
+	if minutes := config.PasswordResetConfig.PasswordResetExpire; minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return defaultPasswordResetExpire
+}
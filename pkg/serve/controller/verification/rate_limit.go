@@ -0,0 +1,104 @@
+package verification
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/ratelimit"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/verification"
+)
+
+// 验证码发送渠道，各渠道分别限流
+const (
+	ChannelImg       = "IMG"
+	ChannelEmail     = "EMAIL"
+	ChannelSms       = "SMS"
+	ChannelTotp      = "TOTP"
+	ChannelMessenger = "MESSENGER"
+)
+
+// rateCheck 一项限流检查
+type rateCheck struct {
+	key  string
+	rule ratelimit.Rule
+}
+
+// checkSendRate 按 IP 与接收方限制验证码发送频率，超限时返回需要等待的时长
+// target 为空时仅按 IP 限流；限流组件异常时放行，避免影响正常发送
+func checkSendRate(channel, target string, c echo.Context) time.Duration {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载验证码配置失败: %v", err)
+		return 0
+	}
+	cfg := config.VerificationConfig
+
+	checks := []rateCheck{
+		{"VERIFICATION:" + channel + ":IP:" + c.RealIP(), ratelimit.Rule{
+			Limit:  cfg.VerificationIPRateLimit,
+			Window: time.Duration(cfg.VerificationIPRateWindow) * time.Second,
+		}},
+	}
+	if target != "" {
+		checks = append(checks, rateCheck{"VERIFICATION:" + channel + ":TARGET:" + target, ratelimit.Rule{
+			Limit:  cfg.VerificationTargetRateLimit,
+			Window: time.Duration(cfg.VerificationTargetRateWindow) * time.Second,
+		}})
+	}
+
+	for _, check := range checks {
+		allowed, retryAfter, err := ratelimit.Allow(c.Request().Context(), check.key, check.rule)
+		if err != nil {
+			utils.BizLogger(c).Errorf("验证码限流检查失败: %v", err)
+			continue
+		}
+		if !allowed {
+			observeRateLimited(channel, target, c)
+			return retryAfter
+		}
+	}
+	return 0
+}
+
+// retryAfterResponse 按错误码对应的状态码（限流为 429）返回响应，并通过 Retry-After 响应头与响应体告知等待秒数
+func retryAfterResponse(retryAfter time.Duration, err *bizErr.Err, c echo.Context) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	return c.JSON(bizErr.HTTPStatus(err.Code), vo.Fail(verification.RetryAfterVo{RetryAfter: seconds}, err, c))
+}
+
+// defaultResendCooldown 未配置时重新发送验证码的冷却时间
+const defaultResendCooldown = time.Minute
+
+// resendCooldown 读取重新发送验证码的冷却时间，不超过验证码有效期
+func resendCooldown() time.Duration {
+	cooldown := defaultResendCooldown
+	if config, err := configs.LoadConfig(); err == nil && config.VerificationConfig.VerificationResendCooldown > 0 {
+		cooldown = time.Duration(config.VerificationConfig.VerificationResendCooldown) * time.Second
+	}
+	return min(cooldown, EmailVerificationCodeCacheExpiration)
+}
+
+// cooldownResponse 验证码未过期时返回 429，告知剩余有效期与可重新发送的等待时间
+func cooldownResponse(ttl, cooldown time.Duration, c echo.Context) error {
+	resendAfter := max(int(math.Ceil(cooldown.Seconds())), 0)
+	expiresIn := max(int(math.Ceil(ttl.Seconds())), 0)
+	if resendAfter > 0 {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(resendAfter))
+	}
+	return c.JSON(http.StatusTooManyRequests, vo.Fail(verification.CooldownVo{
+		ResendAfter: resendAfter,
+		ExpiresIn:   expiresIn,
+	}, bizErr.New(bizErr.VerificationCodeCooldown), c))
+}
@@ -0,0 +1,25 @@
+package verification
+
+import "strings"
+
+// 验证码的用途，发送与校验时声明的用途需一致，为某一用途获取的验证码与验证凭证不能用于其他用途
+const (
+	ActionRegister      = "register"       // 注册
+	ActionResetPassword = "reset_password" // 重置密码
+	ActionDisableTotp   = "disable_totp"   // 关闭两步验证
+	ActionDeleteAccount = "delete_account" // 注销账户
+)
+
+// scopedKey 按用途隔离的缓存键，由前缀、大写的用途与接收方组成
+func scopedKey(prefix, action, target string) string {
+	return prefix + strings.ToUpper(action) + ":" + target
+}
+
+// scopedChannel 渠道的验证码是否按用途隔离，图形验证码与 TOTP 动态码不区分用途
+func scopedChannel(channel string) bool {
+	switch channel {
+	case ChannelEmail, ChannelSms, ChannelMessenger:
+		return true
+	}
+	return false
+}
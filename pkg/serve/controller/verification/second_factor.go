@@ -0,0 +1,97 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+const (
+	TotpAttemptsCacheKeyPrefix = "TOTP:ATTEMPTS:" // TOTP 动态码错误次数，键为前缀加账户 ID
+	TotpUsedCacheKeyPrefix     = "TOTP:USED:"     // 已使用的 TOTP 时间步，键为前缀加账户 ID 与时间步序号
+	totpAttemptsWindow         = 5 * time.Minute  // 错误次数的统计窗口，达到上限后在窗口内拒绝校验
+	totpUsedExpiration         = 2 * time.Minute  // 覆盖允许的时间偏差，期间同一动态码不能重复使用
+)
+
+// ErrTotpNotEnrolled 账户未开启两步验证，不能使用 TOTP 动态码
+var ErrTotpNotEnrolled = errors.New("账户未开启两步验证")
+
+// SecondFactor 敏感操作的验证方式，提交 TOTP 动态码时优先使用动态码，否则校验邮箱验证码或验证凭证
+type SecondFactor struct {
+	EmailCode   string // 邮箱验证码
+	EmailTicket string // 邮箱验证码、即时通讯验证码或 TOTP 动态码换取的验证凭证
+	TotpCode    string // TOTP 动态码，仅开启两步验证的账户可用
+	Nonce       string // 获取邮箱验证码时下发的客户端随机数
+}
+
+// VerifySecondFactor 校验修改密码等敏感操作的验证方式，action 为敏感操作对应的验证码用途
+// 开启两步验证的账户可提交 TOTP 动态码代替邮箱验证码，验证凭证可由邮箱验证码、即时通讯验证码或动态码换取
+func VerifySecondFactor(email, action string, factor SecondFactor, c echo.Context) error {
+	switch {
+	case factor.TotpCode != "":
+		return VerifyTotpCode(email, factor.TotpCode, c)
+	case factor.EmailTicket != "":
+		if claims, err := utils.ParseVerificationTicket(factor.EmailTicket); err == nil {
+			switch claims.Channel {
+			case ChannelTotp, ChannelMessenger:
+				return ConsumeTicket(factor.EmailTicket, claims.Channel, email, action, c)
+			}
+		}
+		return ConsumeTicket(factor.EmailTicket, ChannelEmail, email, action, c)
+	default:
+		return VerifyEmailCode(factor.EmailCode, email, factor.Nonce, action, c)
+	}
+}
+
+// VerifyTotpCode 校验账户的 TOTP 动态码，同一动态码只能使用一次，错误次数达到上限后在统计窗口内拒绝校验
+func VerifyTotpCode(email, code string, c echo.Context) error {
+	return observeVerify(ChannelTotp, email, verifyTotpCode(email, code, c), c)
+}
+
+func verifyTotpCode(email, code string, c echo.Context) error {
+	acc, err := mapper.GetAccountByEmail(email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", email, err)
+		return ErrVerificationCodeMismatch
+	}
+	if !acc.TotpEnabled || acc.TotpSecret == "" {
+		return ErrTotpNotEnrolled
+	}
+
+	ctx := context.Background()
+	attemptsKey := fmt.Sprintf("%s%d", TotpAttemptsCacheKeyPrefix, acc.ID)
+	value, _ := cache.Current().Get(ctx, attemptsKey)
+	if attempts, _ := strconv.Atoi(value); attempts >= maxVerifyAttempts() {
+		utils.BizLogger(c).Errorf("TOTP 动态码错误次数过多，账户 ID: %d", acc.ID)
+		return ErrVerificationAttemptsExceeded
+	}
+
+	counter, ok := utils.ValidateTotp(acc.TotpSecret, code, time.Now())
+	if !ok {
+		if _, err := cache.Current().Incr(ctx, attemptsKey, totpAttemptsWindow); err != nil {
+			utils.BizLogger(c).Errorf("记录 TOTP 动态码错误次数失败: %v", err)
+		}
+		utils.BizLogger(c).Errorf("TOTP 动态码错误，账户 ID: %d", acc.ID)
+		return ErrVerificationCodeMismatch
+	}
+
+	fresh, err := cache.Current().SetNX(ctx, fmt.Sprintf("%s%d:%d", TotpUsedCacheKeyPrefix, acc.ID, counter), 1, totpUsedExpiration)
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录 TOTP 动态码使用状态失败: %v", err)
+		return err
+	}
+	if !fresh {
+		utils.BizLogger(c).Errorf("TOTP 动态码已使用，账户 ID: %d", acc.ID)
+		return ErrVerificationCodeMismatch
+	}
+	cache.Current().Del(ctx, attemptsKey)
+	return nil
+}
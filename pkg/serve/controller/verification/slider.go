@@ -0,0 +1,100 @@
+package verification
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/captcha"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/verification"
+)
+
+// SendSliderCaptcha godoc
+// @Summary      获取滑动拼图
+// @Description  生成滑动拼图的背景图与拼图块，有效期为3分钟；用户拖动拼图块对齐缺口后，将拼图块的横坐标作为图形验证码提交，与答案相差不超过 CAPTCHA_SLIDER_TOLERANCE 像素即通过
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.SendVerificationCodeRequest  true  "邮箱地址，用于生成滑动拼图"
+// @Success      200   {object} vo.Result{data=verification.SliderCaptchaVo} "获取成功"
+// @Failure      400   {object} vo.Result{data=[]utils.ValidErrRes} "请求参数错误或当前未使用滑动拼图"
+// @Failure      429   {object} vo.Result{data=verification.RetryAfterVo} "请求过于频繁"
+// @Failure      500   {object} vo.Result{data=string} "服务器错误，生成滑动拼图失败"
+// @Router       /verification/sendSliderCaptcha [post]
+func SendSliderCaptcha(c echo.Context) error {
+	req := new(dto.SendVerificationCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+	email := req.Email
+
+	provider, err := captchaProvider(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成滑动拼图失败", bizErr.New(bizErr.ServerError), c))
+	}
+	if provider.Name() != captcha.ProviderSlider {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "当前使用 "+provider.Name()+" 人机验证，无需获取滑动拼图"), c))
+	}
+
+	if retryAfter := checkSendRate(ChannelImg, "", c); retryAfter > 0 {
+		return retryAfterResponse(retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	}
+
+	nonce, err := flowNonce(req.Nonce, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成客户端随机数失败: %v", err)
+		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成滑动拼图失败", bizErr.New(bizErr.ServerError), c))
+	}
+	key := captcha.SliderCacheKeyPrefix + nonce
+
+	opts := sliderCaptchaOptions()
+	puzzle, err := utils.GenSliderCaptcha(opts)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成滑动拼图失败: %v", err)
+		observeSend(ChannelImg, email, err, c)
+		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成滑动拼图失败", bizErr.New(bizErr.ServerError), c))
+	}
+
+	err = cache.Current().Set(context.Background(), key, captcha.BindNonce(nonce, strconv.Itoa(puzzle.X)), ImgVerificationCodeCacheExpiration)
+	if err != nil {
+		utils.BizLogger(c).Errorf("滑动拼图写入缓存失败，key: %v, 错误: %v", key, err)
+		observeSend(ChannelImg, email, err, c)
+		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成滑动拼图失败", bizErr.New(bizErr.ServerError), c))
+	}
+	cache.Current().Del(context.Background(), key+verificationAttemptsKeySuffix)
+	observeSend(ChannelImg, email, nil, c)
+
+	return c.JSON(http.StatusOK, vo.Success(verification.SliderCaptchaVo{
+		Background: puzzle.Background,
+		Piece:      puzzle.Piece,
+		Y:          puzzle.Y,
+		Width:      opts.Width,
+		Height:     opts.Height,
+		Nonce:      nonce,
+	}, c))
+}
+
+// sliderCaptchaOptions 读取滑动拼图的生成配置，读取失败时使用默认配置
+func sliderCaptchaOptions() utils.SliderCaptchaOptions {
+	opts := utils.SliderCaptchaOptions{Width: utils.SliderWidth, Height: utils.SliderHeight}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return opts
+	}
+	if w := config.CaptchaConfig.CaptchaSliderWidth; w > 0 {
+		opts.Width = w
+	}
+	if h := config.CaptchaConfig.CaptchaSliderHeight; h > 0 {
+		opts.Height = h
+	}
+	return opts
+}
@@ -0,0 +1,127 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/sms"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/captcha"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// SendSmsVerificationCode godoc
+// @Summary 发送短信验证码
+// @Description 向指定手机号发送验证码，验证码有效期为3分钟；冷却期结束后再次调用会作废原验证码并发送新的验证码，冷却期内返回剩余有效期与等待时间
+// @Tags 账户
+// @Accept json
+// @Produce json
+// @Param request body dto.SendSmsVerificationCodeRequest true "E.164 格式的手机号与验证码用途"
+// @Success 200 {object} vo.Result "短信验证码发送成功, 请注意查收"
+// @Failure 400 {object} vo.Result{data=[]utils.ValidErrRes} "请求参数错误，手机号为空或格式无效"
+// @Failure 429 {object} vo.Result{data=verification.CooldownVo} "仍在冷却期或发送过于频繁"
+// @Failure 500 {object} vo.Result "服务器错误，短信验证码发送失败"
+// @Failure 503 {object} vo.Result "未开启短信服务"
+// @Router /verification/sendSmsVerificationCode [post]
+func SendSmsVerificationCode(c echo.Context) error {
+	req := new(dto.SendSmsVerificationCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendSmsVerificationCodeFail, err.Error()), c))
+	}
+	phone := req.Phone
+
+	sender, err := smsSender(c)
+	if err != nil {
+		if errors.Is(err, sms.ErrDisabled) {
+			return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.SmsDisabled), c))
+		}
+		return c.JSON(http.StatusInternalServerError, vo.Fail("短信验证码发送失败", bizErr.New(bizErr.SendSmsVerificationCodeFail), c))
+	}
+
+	cooldown, err := cache.Current().TTL(context.Background(), scopedKey(SmsVerificationCooldownCacheKeyPrefix, req.Action, phone))
+	if err != nil {
+		utils.BizLogger(c).Errorf("查询短信验证码冷却时间失败: %v", err)
+		return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+	}
+	if cooldown > 0 {
+		ttl, err := cache.Current().TTL(context.Background(), scopedKey(SmsVerificationCodeCacheKeyPrefix, req.Action, phone))
+		if err != nil {
+			utils.BizLogger(c).Errorf("检查短信验证码是否有效失败: %v", err)
+			return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+		}
+		return cooldownResponse(ttl, cooldown, c)
+	}
+
+	if retryAfter := checkSendRate(ChannelSms, phone, c); retryAfter > 0 {
+		return retryAfterResponse(retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	}
+
+	if err := observeSend(ChannelSms, phone, sendSmsCode(sender, phone, req.Action, req.Nonce, c), c); err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail("短信验证码发送失败", bizErr.New(bizErr.SendSmsVerificationCodeFail), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("短信验证码发送成功, 请注意查收！", c))
+}
+
+// sendSmsCode 生成短信验证码并发送，验证码与客户端随机数绑定，覆盖未过期的旧验证码，并开始重新发送的冷却期
+func sendSmsCode(sender sms.Sender, phone, action, nonce string, c echo.Context) error {
+	key := scopedKey(SmsVerificationCodeCacheKeyPrefix, action, phone)
+	nonce, err := flowNonce(nonce, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成客户端随机数失败: %v", err)
+		return err
+	}
+
+	// 生成并缓存验证码，与邮箱验证码使用相同的有效期，同时清空旧验证码的错误次数
+	code, err := newCode()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成短信验证码失败: %v", err)
+		return err
+	}
+	err = cache.Current().Set(context.Background(), key, captcha.BindNonce(nonce, code), EmailVerificationCodeCacheExpiration)
+	if err != nil {
+		utils.BizLogger(c).Errorf("短信验证码写入缓存失败: %v", err)
+		return err
+	}
+	cache.Current().Del(context.Background(), key+verificationAttemptsKeySuffix)
+
+	if err := sender.SendCode(c.Request().Context(), phone, code, EmailVerificationCodeCacheExpiration); err != nil {
+		utils.BizLogger(c).Errorf("短信验证码发送失败，短信服务: %s, 错误: %v", sender.Name(), err)
+		cache.Current().Del(context.Background(), key)
+		return err
+	}
+
+	if cooldown := resendCooldown(); cooldown > 0 {
+		if err := cache.Current().Set(context.Background(), scopedKey(SmsVerificationCooldownCacheKeyPrefix, action, phone), 1, cooldown); err != nil {
+			utils.BizLogger(c).Errorf("短信验证码冷却时间写入缓存失败: %v", err)
+		}
+	}
+	return nil
+}
+
+// VerifySmsCode 校验短信验证码，供手机号注册与登录使用，nonce 为发送验证码时下发的客户端随机数，action 为发送验证码时声明的用途
+func VerifySmsCode(code, phone, nonce, action string, c echo.Context) error {
+	return observeVerify(ChannelSms, phone, verifyCode(code, phone, nonce, action, SmsVerificationCodeCacheKeyPrefix, c), c)
+}
+
+// smsSender 根据配置创建短信服务
+func smsSender(c echo.Context) (sms.Sender, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载短信服务配置失败: %v", err)
+		return nil, err
+	}
+	sender, err := sms.New(config)
+	if err != nil && !errors.Is(err, sms.ErrDisabled) {
+		utils.BizLogger(c).Errorf("创建短信服务失败: %v", err)
+	}
+	return sender, err
+}
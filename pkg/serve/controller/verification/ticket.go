@@ -0,0 +1,152 @@
+package verification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/cache"
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/verification"
+)
+
+const (
+	TicketExpiration           = 5 * time.Minute               // 验证凭证有效期
+	TicketIssuedCacheKeyPrefix = "VERIFICATION:TICKET:ISSUED:" // 已签发的验证凭证，键为前缀加凭证 ID，凭证使用后删除
+	TicketUsedCacheKeyPrefix   = "VERIFICATION:TICKET:USED:"   // 已使用的验证凭证，键为前缀加凭证 ID
+)
+
+// ErrTicketInvalid 验证凭证无效、已过期、已使用或与接收方不匹配
+var ErrTicketInvalid = errors.New("验证凭证无效或已使用")
+
+// VerifyCode godoc
+// @Summary 校验验证码并换取验证凭证
+// @Description 校验邮箱、图形、短信、即时通讯验证码或 TOTP 动态码，通过后验证码作废并返回有效期 5 分钟的一次性验证凭证，注册、重置密码等接口可提交凭证代替验证码
+// @Tags 账户
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyCodeRequest true "校验验证码请求参数"
+// @Success 200 {object} vo.Result{data=verification.TicketVo} "校验通过"
+// @Failure 400 {object} vo.Result "请求参数错误或验证码校验失败"
+// @Failure 500 {object} vo.Result "服务器错误"
+// @Router /verification/verify [post]
+func VerifyCode(c echo.Context) error {
+	req := new(dto.VerifyCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+	channel := strings.ToUpper(req.Channel)
+	action := req.Action
+	if !scopedChannel(channel) {
+		action = ""
+	} else if action == "" {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "请声明验证码用途"), c))
+	}
+
+	var err error
+	switch channel {
+	case ChannelImg:
+		if !VerifyImgCode(req.Code, req.Target, req.Nonce, c) {
+			return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.SendImgVerificationCodeFail, "图形验证码校验失败"), c))
+		}
+	case ChannelEmail:
+		err = VerifyEmailCode(req.Code, req.Target, req.Nonce, action, c)
+	case ChannelSms:
+		err = VerifySmsCode(req.Code, req.Target, req.Nonce, action, c)
+	case ChannelTotp:
+		err = VerifyTotpCode(req.Target, req.Code, c)
+	case ChannelMessenger:
+		err = VerifyMessengerCode(req.Code, req.Target, req.Nonce, action, c)
+	}
+	if errors.Is(err, ErrVerificationAttemptsExceeded) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.VerificationCodeLocked), c))
+	}
+	if errors.Is(err, ErrTotpNotEnrolled) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.TotpNotEnrolled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "验证码校验失败"), c))
+	}
+
+	ticket, err := issueTicket(channel, req.Target, action)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成验证凭证失败: %v", err)
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(verification.TicketVo{
+		Ticket:    ticket,
+		ExpiresIn: int(TicketExpiration.Seconds()),
+	}, c))
+}
+
+// ConsumeTicket 校验验证凭证的渠道、接收方与用途并将其作废，每个凭证只能使用一次，
+// 凭证 ID 须由服务端签发且尚未使用
+// 图形验证码与 TOTP 动态码换取的凭证不区分用途，action 仅对按用途隔离的渠道生效
+func ConsumeTicket(ticket, channel, target, action string, c echo.Context) error {
+	claims, err := utils.ParseVerificationTicket(ticket)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return ErrTicketInvalid
+	}
+	if claims.Channel != channel || !strings.EqualFold(claims.Target, target) {
+		utils.BizLogger(c).Errorf("验证凭证与请求不匹配，凭证渠道: %s, 请求渠道: %s", claims.Channel, channel)
+		return ErrTicketInvalid
+	}
+	if scopedChannel(channel) && claims.Action != action {
+		utils.BizLogger(c).Errorf("验证凭证用途不匹配，凭证用途: %s, 请求用途: %s", claims.Action, action)
+		return ErrTicketInvalid
+	}
+
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return ErrTicketInvalid
+	}
+	ctx := context.Background()
+	if _, err := cache.Current().Get(ctx, TicketIssuedCacheKeyPrefix+claims.ID); err != nil {
+		if !errors.Is(err, cache.ErrMiss) {
+			utils.BizLogger(c).Errorf("读取验证凭证签发记录失败: %v", err)
+			return err
+		}
+		utils.BizLogger(c).Errorf("验证凭证未签发或已使用，凭证 ID: %s", claims.ID)
+		return ErrTicketInvalid
+	}
+	ok, err := cache.Current().SetNX(ctx, TicketUsedCacheKeyPrefix+claims.ID, 1, ttl)
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录验证凭证使用状态失败: %v", err)
+		return err
+	}
+	if !ok {
+		utils.BizLogger(c).Errorf("验证凭证已使用，凭证 ID: %s", claims.ID)
+		return ErrTicketInvalid
+	}
+	cache.Current().Del(ctx, TicketIssuedCacheKeyPrefix+claims.ID)
+	return nil
+}
+
+// issueTicket 生成带随机 ID 的验证凭证并记录凭证 ID，action 为验证码的用途
+func issueTicket(channel, target, action string) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(raw)
+	ticket, err := utils.GenerateVerificationTicket(id, channel, target, action, TicketExpiration)
+	if err != nil {
+		return "", err
+	}
+	if err := cache.Current().Set(context.Background(), TicketIssuedCacheKeyPrefix+id, 1, TicketExpiration); err != nil {
+		return "", fmt.Errorf("记录验证凭证失败: %v", err)
+	}
+	return ticket, nil
+}
@@ -2,26 +2,37 @@ package verification
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
 	bizErr "jank.com/jank_blog/internal/error"
-	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
 	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/captcha"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
 	"jank.com/jank_blog/pkg/vo"
 	"jank.com/jank_blog/pkg/vo/verification"
 )
 
 const (
-	EmailVerificationCodeCacheKeyPrefix  = "Email:VERIFICATION:CODE:"
-	EmailVerificationCodeCacheExpiration = 3 * time.Minute
-	ImgVerificationCodeCachePrefix       = "IMG:VERIFICATION:CODE:CACHE:"
-	ImgVerificationCodeCacheExpiration   = 3 * time.Minute
+	EmailVerificationCodeCacheKeyPrefix         = "Email:VERIFICATION:CODE:"
+	EmailVerificationCodeCacheExpiration        = 3 * time.Minute
+	EmailVerificationCooldownCacheKeyPrefix     = "Email:VERIFICATION:COOLDOWN:"
+	ImgVerificationCodeCachePrefix              = "IMG:VERIFICATION:CODE:CACHE:"
+	ImgVerificationCodeCacheExpiration          = 3 * time.Minute
+	SmsVerificationCodeCacheKeyPrefix           = "Sms:VERIFICATION:CODE:"
+	SmsVerificationCooldownCacheKeyPrefix       = "Sms:VERIFICATION:COOLDOWN:"
+	MessengerVerificationCodeCacheKeyPrefix     = "Messenger:VERIFICATION:CODE:"
+	MessengerVerificationCooldownCacheKeyPrefix = "Messenger:VERIFICATION:COOLDOWN:"
+	MessengerLinkCacheKeyPrefix                 = "Messenger:VERIFICATION:LINK:"
 )
 
 // SendImgVerificationCode godoc
@@ -30,128 +41,415 @@ const (
 // @Tags         账户
 // @Accept       json
 // @Produce      json
-// @Param        email  query   string  true  "邮箱地址，用于生成验证码"
+// @Param        request  body  dto.SendVerificationCodeRequest  true  "邮箱地址，用于生成验证码"
 // @Success      200   {object} vo.Result{data=map[string]string} "成功返回验证码的Base64编码"
-// @Failure      400   {object} vo.Result{data=string} "请求参数错误，邮箱地址为空"
+// @Failure      400   {object} vo.Result{data=[]utils.ValidErrRes} "请求参数错误，邮箱地址为空或格式无效"
+// @Failure      429   {object} vo.Result{data=verification.RetryAfterVo} "请求过于频繁"
 // @Failure      500   {object} vo.Result{data=string} "服务器错误，生成验证码失败"
-// @Router       /verification/sendImgVerificationCode [get]
+// @Router       /verification/sendImgVerificationCode [post]
 func SendImgVerificationCode(c echo.Context) error {
-	email := c.QueryParam("email")
-	if email == "" {
-		utils.BizLogger(c).Errorf("请求参数错误，邮箱地址为空")
-		return c.JSON(http.StatusBadRequest, vo.Fail("请求参数错误，邮箱地址为空", bizErr.New(bizErr.UnKnowErr), c))
+	req := new(dto.SendVerificationCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
 	}
+	email := req.Email
 
-	key := ImgVerificationCodeCachePrefix + email
+	provider, err := captchaProvider(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成图形验证码失败", bizErr.New(bizErr.ServerError), c))
+	}
+	if provider.Name() != captcha.ProviderBuiltin {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, "当前使用 "+provider.Name()+" 人机验证，无需获取图形验证码"), c))
+	}
 
-	// 生成单个图形验证码
-	imgBase64, answer, err := utils.GenImgVerificationCode()
+	if retryAfter := checkSendRate(ChannelImg, "", c); retryAfter > 0 {
+		return retryAfterResponse(retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	}
+
+	nonce, err := flowNonce(req.Nonce, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成客户端随机数失败: %v", err)
+		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成图形验证码失败", bizErr.New(bizErr.ServerError), c))
+	}
+	// 图形验证码以服务端下发的随机数作为验证码 ID，他人凭邮箱无法覆盖或使用
+	key := ImgVerificationCodeCachePrefix + nonce
+
+	// 按配置生成单个图形验证码
+	imgBase64, answer, err := utils.GenImgVerificationCode(imgCaptchaOptions())
 	if err != nil {
 		utils.BizLogger(c).Errorf("生成图片验证码失败: %v", err)
+		observeSend(ChannelImg, email, err, c)
 		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成图形验证码失败", bizErr.New(bizErr.ServerError), c))
 	}
 
-	err = global.RedisClient.Set(context.Background(), key, answer, ImgVerificationCodeCacheExpiration).Err()
+	err = cache.Current().Set(context.Background(), key, captcha.BindNonce(nonce, answer), ImgVerificationCodeCacheExpiration)
 	if err != nil {
 		utils.BizLogger(c).Errorf("图形验证码写入缓存失败，key: %v, 错误: %v", key, err)
+		observeSend(ChannelImg, email, err, c)
 		return c.JSON(http.StatusInternalServerError, vo.Fail("服务器错误，生成图形验证码失败", bizErr.New(bizErr.ServerError), c))
 	}
+	cache.Current().Del(context.Background(), key+verificationAttemptsKeySuffix)
+	observeSend(ChannelImg, email, nil, c)
 
-	return c.JSON(http.StatusOK, vo.Success(verification.ImgVerificationVo{ImgBase64: imgBase64}, c))
+	return c.JSON(http.StatusOK, vo.Success(verification.ImgVerificationVo{ImgBase64: imgBase64, Nonce: nonce}, c))
 }
 
 // SendEmailVerificationCode godoc
 // @Summary 发送邮箱验证码
-// @Description 向指定邮箱发送验证码，验证码有效期为3分钟
+// @Description 向指定邮箱发送验证码，验证码有效期为3分钟；验证码未过期时返回剩余有效期与可重新发送的等待时间
 // @Tags 账户
 // @Accept json
 // @Produce json
-// @Param email query string true "邮箱地址，用于发送验证码"
+// @Param request body dto.SendEmailVerificationCodeRequest true "邮箱地址与验证码用途"
 // @Success 200 {object} vo.Result "邮箱验证码发送成功, 请注意查收邮件"
-// @Failure 400 {object} vo.Result "请求参数错误，邮箱地址为空"
+// @Failure 400 {object} vo.Result{data=[]utils.ValidErrRes} "请求参数错误，邮箱地址为空或格式无效"
+// @Failure 400 {object} vo.Result "邮箱属于一次性邮箱"
+// @Failure 429 {object} vo.Result{data=verification.CooldownVo} "验证码未过期或发送过于频繁"
 // @Failure 500 {object} vo.Result "服务器错误，邮箱验证码发送失败"
-// @Router /verification/sendEmailVerificationCode [get]
+// @Failure 503 {object} vo.Result "邮件服务熔断中，暂不可用"
+// @Router /verification/sendEmailVerificationCode [post]
 func SendEmailVerificationCode(c echo.Context) error {
-	email := c.QueryParam("email")
-	if email == "" {
-		utils.BizLogger(c).Errorf("请求参数错误，邮箱地址为空")
-		return c.JSON(http.StatusBadRequest, vo.Fail("请求参数错误，邮箱地址为空", bizErr.New(bizErr.SendEmailVerificationCodeFail), c))
+	req := new(dto.SendEmailVerificationCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendEmailVerificationCodeFail, err.Error()), c))
 	}
+	email := req.Email
 
-	if !utils.ValidEmail(email) {
-		utils.BizLogger(c).Errorf("邮箱格式无效: %s", email)
-		return c.JSON(http.StatusBadRequest, vo.Fail("邮箱格式无效", bizErr.New(bizErr.SendEmailVerificationCodeFail), c))
+	if IsDisposableEmail(email, c) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.DisposableEmailBlocked), c))
 	}
 
-	key := EmailVerificationCodeCacheKeyPrefix + email
+	key := scopedKey(EmailVerificationCodeCacheKeyPrefix, req.Action, email)
 
-	// 检查验证码是否存在
-	exists, err := global.RedisClient.Exists(context.Background(), key).Result()
+	// 验证码未过期时返回剩余有效期，冷却期结束后可调用重新发送接口
+	ttl, err := cache.Current().TTL(context.Background(), key)
 	if err != nil {
 		utils.BizLogger(c).Errorf("检查邮箱验证码是否有效失败: %v", err)
 		return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
 	}
+	if ttl > 0 {
+		cooldown, err := cache.Current().TTL(context.Background(), scopedKey(EmailVerificationCooldownCacheKeyPrefix, req.Action, email))
+		if err != nil {
+			utils.BizLogger(c).Errorf("查询邮箱验证码冷却时间失败: %v", err)
+			return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+		}
+		return cooldownResponse(ttl, cooldown, c)
+	}
 
-	if exists > 0 {
-		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.SendEmailVerificationCodeFail), c))
+	if retryAfter := checkSendRate(ChannelEmail, strings.ToLower(email), c); retryAfter > 0 {
+		return retryAfterResponse(retryAfter, bizErr.New(bizErr.TooManyRequests), c)
 	}
 
-	// 生成并缓存验证码
-	code := utils.NewRand()
-	err = global.RedisClient.Set(context.Background(), key, strconv.Itoa(code), EmailVerificationCodeCacheExpiration).Err()
+	if err := observeSend(ChannelEmail, email, sendEmailCode(email, req.Action, req.Nonce, c), c); err != nil {
+		return emailSendFailResponse(err, c)
+	}
+
+	return c.JSON(http.StatusOK, vo.Success("邮箱验证码发送成功, 请注意查收！", c))
+}
+
+// ResendEmailVerificationCode godoc
+// @Summary 重新发送邮箱验证码
+// @Description 冷却期结束后作废原验证码并发送新的验证码，冷却期内返回剩余等待时间
+// @Tags 账户
+// @Accept json
+// @Produce json
+// @Param request body dto.SendEmailVerificationCodeRequest true "邮箱地址与验证码用途"
+// @Success 200 {object} vo.Result "邮箱验证码发送成功, 请注意查收邮件"
+// @Failure 400 {object} vo.Result{data=[]utils.ValidErrRes} "请求参数错误，邮箱地址为空或格式无效"
+// @Failure 400 {object} vo.Result "邮箱属于一次性邮箱"
+// @Failure 429 {object} vo.Result{data=verification.CooldownVo} "仍在冷却期或发送过于频繁"
+// @Failure 500 {object} vo.Result "服务器错误，邮箱验证码发送失败"
+// @Failure 503 {object} vo.Result "邮件服务熔断中，暂不可用"
+// @Router /verification/resend [post]
+func ResendEmailVerificationCode(c echo.Context) error {
+	req := new(dto.SendEmailVerificationCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.SendEmailVerificationCodeFail, err.Error()), c))
+	}
+	email := req.Email
+
+	if IsDisposableEmail(email, c) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.DisposableEmailBlocked), c))
+	}
+
+	cooldown, err := cache.Current().TTL(context.Background(), scopedKey(EmailVerificationCooldownCacheKeyPrefix, req.Action, email))
 	if err != nil {
-		utils.BizLogger(c).Errorf("邮箱验证码写入缓存失败: %v", err)
+		utils.BizLogger(c).Errorf("查询邮箱验证码冷却时间失败: %v", err)
 		return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
 	}
+	if cooldown > 0 {
+		ttl, err := cache.Current().TTL(context.Background(), scopedKey(EmailVerificationCodeCacheKeyPrefix, req.Action, email))
+		if err != nil {
+			utils.BizLogger(c).Errorf("检查邮箱验证码是否有效失败: %v", err)
+			return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+		}
+		return cooldownResponse(ttl, cooldown, c)
+	}
 
-	// 发送验证码邮件
-	expirationInMinutes := int(EmailVerificationCodeCacheExpiration.Round(time.Minute).Minutes())
-	emailContent := fmt.Sprintf("您的注册验证码是: %d , 有效期为 %d 分钟。", code, expirationInMinutes)
-	success, err := utils.SendEmail(emailContent, []string{email})
-	if !success {
-		utils.BizLogger(c).Errorf("邮箱验证码发送失败，邮箱地址: %s, 错误: %v", email, err)
-		global.RedisClient.Del(context.Background(), key)
-		return c.JSON(http.StatusInternalServerError, vo.Fail("邮箱验证码发送失败", bizErr.New(bizErr.SendEmailVerificationCodeFail), c))
+	if retryAfter := checkSendRate(ChannelEmail, strings.ToLower(email), c); retryAfter > 0 {
+		return retryAfterResponse(retryAfter, bizErr.New(bizErr.TooManyRequests), c)
+	}
+
+	if err := observeSend(ChannelEmail, email, sendEmailCode(email, req.Action, req.Nonce, c), c); err != nil {
+		return emailSendFailResponse(err, c)
 	}
 
 	return c.JSON(http.StatusOK, vo.Success("邮箱验证码发送成功, 请注意查收！", c))
 }
 
-// VerifyEmailCode 校验邮箱验证码
-func VerifyEmailCode(code, email string, c echo.Context) bool {
-	return verifyCode(code, email, EmailVerificationCodeCacheKeyPrefix, c)
+// sendEmailCode 生成邮箱验证码并发送邮件或写入发送队列，验证码与客户端随机数绑定，覆盖未过期的旧验证码，并开始重新发送的冷却期
+func sendEmailCode(email, action, nonce string, c echo.Context) error {
+	key := scopedKey(EmailVerificationCodeCacheKeyPrefix, action, email)
+	nonce, err := flowNonce(nonce, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成客户端随机数失败: %v", err)
+		return err
+	}
+
+	// 生成验证码并渲染验证码邮件
+	code, err := newCode()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成邮箱验证码失败: %v", err)
+		return err
+	}
+	msg, err := renderCodeEmail(code, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("渲染验证码邮件失败: %v", err)
+		return err
+	}
+
+	// 缓存验证码，同时清空旧验证码的错误次数
+	err = cache.Current().Set(context.Background(), key, captcha.BindNonce(nonce, code), EmailVerificationCodeCacheExpiration)
+	if err != nil {
+		utils.BizLogger(c).Errorf("邮箱验证码写入缓存失败: %v", err)
+		return err
+	}
+	cache.Current().Del(context.Background(), key+verificationAttemptsKeySuffix)
+
+	// 开启邮件队列时写入队列后立即返回，由后台任务发送并重试；队列不可用时同步发送
+	queued := false
+	if mailQueueEnabled() {
+		if err := mail.Enqueue(context.Background(), msg, []string{email}); err != nil {
+			utils.BizLogger(c).Errorf("验证码邮件写入发送队列失败，改为同步发送: %v", err)
+		} else {
+			queued = true
+		}
+	}
+	if !queued {
+		start := time.Now()
+		success, err := utils.SendHTMLEmail(msg.Subject, msg.Text, msg.HTML, []string{email})
+		mail.ObserveDelivery(mail.DeliverySync, time.Since(start), err)
+		if !success {
+			utils.BizLogger(c).Errorf("邮箱验证码发送失败，邮箱地址: %s, 错误: %v", email, err)
+			cache.Current().Del(context.Background(), key)
+			return fmt.Errorf("邮箱验证码发送失败: %w", err)
+		}
+	}
+
+	if cooldown := resendCooldown(); cooldown > 0 {
+		if err := cache.Current().Set(context.Background(), scopedKey(EmailVerificationCooldownCacheKeyPrefix, action, email), 1, cooldown); err != nil {
+			utils.BizLogger(c).Errorf("邮箱验证码冷却时间写入缓存失败: %v", err)
+		}
+	}
+	return nil
 }
 
-// VerifyImgCode 校验图形验证码
-func VerifyImgCode(code, email string, c echo.Context) bool {
-	return verifyCode(code, email, ImgVerificationCodeCachePrefix, c)
+// emailSendFailResponse 邮箱验证码发送失败的响应，邮件发送熔断时返回 503
+func emailSendFailResponse(err error, c echo.Context) error {
+	if errors.Is(err, utils.ErrMailUnavailable) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.MailServiceUnavailable), c))
+	}
+	return c.JSON(http.StatusInternalServerError, vo.Fail("邮箱验证码发送失败", bizErr.New(bizErr.SendEmailVerificationCodeFail), c))
 }
 
-// verifyCode 通用验证码校验
-func verifyCode(code, email, prefix string, c echo.Context) bool {
-	key := prefix + email
+// renderCodeEmail 按请求语言使用邮件模板渲染验证码邮件，模板未定义主题时使用默认主题
+func renderCodeEmail(code string, c echo.Context) (*mail.Message, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
 
-	storedCode, err := global.RedisClient.Get(c.Request().Context(), key).Result()
+	siteName := config.SiteConfig.SiteTitle
+	if siteName == "" {
+		siteName = "Jank Blog"
+	}
+	locale := i18n.FromRequest(c.Request())
+	msg, err := mail.Render(mail.TemplateVerificationCode, locale, mail.VerificationCodeData{
+		SiteName:      siteName,
+		SiteURL:       config.SiteConfig.SiteURL,
+		Code:          code,
+		ExpireMinutes: int(EmailVerificationCodeCacheExpiration.Round(time.Minute).Minutes()),
+		Locale:        locale,
+	}, config.AppConfig.EmailTemplateDir)
 	if err != nil {
-		if err.Error() == "redis: nil" {
-			utils.BizLogger(c).Error("验证码不存在或已过期")
-		} else {
-			utils.BizLogger(c).Errorf("验证码校验失败: %v", err)
-		}
-		return false
+		return nil, err
+	}
+	if msg.Subject == "" {
+		msg.Subject = utils.SUBJECT
 	}
+	return msg, nil
+}
 
-	storedCode = strings.ToUpper(strings.TrimSpace(storedCode))
-	code = strings.ToUpper(strings.TrimSpace(code))
+// mailQueueEnabled 是否开启邮件发送队列
+func mailQueueEnabled() bool {
+	config, err := configs.LoadConfig()
+	return err == nil && config.MailQueueConfig.MailQueueEnabled
+}
 
-	if storedCode != code {
-		utils.BizLogger(c).Error("用户验证码错误")
-		return false
+// newCode 按配置的长度与格式生成邮箱与短信验证码
+func newCode() (string, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return utils.NewRand(config.VerificationConfig.VerificationCodeLength, config.VerificationConfig.VerificationCodeFormat)
+}
+
+// imgCaptchaOptions 读取内置图形验证码的生成配置，读取失败时使用默认配置
+func imgCaptchaOptions() utils.ImgCaptchaOptions {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return utils.ImgCaptchaOptions{}
+	}
+	cfg := config.CaptchaConfig
+	return utils.ImgCaptchaOptions{
+		Length:     cfg.CaptchaImgLength,
+		Charset:    cfg.CaptchaImgCharset,
+		NoiseCount: cfg.CaptchaImgNoiseCount,
+		Width:      cfg.CaptchaImgWidth,
+		Height:     cfg.CaptchaImgHeight,
+	}
+}
+
+// VerifyEmailCode 校验邮箱验证码，nonce 为发送验证码时下发的客户端随机数，action 为发送验证码时声明的用途
+func VerifyEmailCode(code, email, nonce, action string, c echo.Context) error {
+	return observeVerify(ChannelEmail, email, verifyCode(code, email, nonce, action, EmailVerificationCodeCacheKeyPrefix, c), c)
+}
+
+// CheckImgCode godoc
+// @Summary      预校验图形验证码
+// @Description  仅校验图形验证码或滑动拼图是否正确，不作废验证码，供表单即时提示；最终提交表单时仍会校验并作废验证码，错误次数与最终提交共同计数
+// @Tags         账户
+// @Accept       json
+// @Produce      json
+// @Param        request  body  dto.CheckImgCodeRequest  true  "预校验图形验证码请求参数"
+// @Success      200   {object} vo.Result "图形验证码正确"
+// @Failure      400   {object} vo.Result{data=[]utils.ValidErrRes} "请求参数错误、图形验证码错误或当前人机验证服务不支持预校验"
+// @Router       /verification/checkImgCode [post]
+func CheckImgCode(c echo.Context) error {
+	req := new(dto.CheckImgCodeRequest)
+	if errors, err := utils.BindAndValidate(c, req); err != nil {
+		return c.JSON(http.StatusBadRequest, vo.Fail(errors, bizErr.New(bizErr.BadRequest, err.Error()), c))
+	}
+
+	provider, err := captchaProvider(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(nil, bizErr.New(bizErr.ServerError), c))
+	}
+	if name := provider.Name(); name != captcha.ProviderBuiltin && name != captcha.ProviderSlider {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.BadRequest, captcha.ErrPeekUnsupported.Error()), c))
 	}
 
-	if err := global.RedisClient.Del(context.Background(), key).Err(); err != nil {
-		utils.BizLogger(c).Errorf("删除验证码缓存失败: %v", err)
+	if !verifyImgCode(provider, captcha.VerifyRequest{Token: req.Code, Subject: req.Email, Nonce: req.Nonce, Peek: true}, c) {
+		return c.JSON(http.StatusBadRequest, vo.Fail(nil, bizErr.New(bizErr.SendImgVerificationCodeFail, "图形验证码错误"), c))
+	}
+	return c.JSON(http.StatusOK, vo.Success("图形验证码正确", c))
+}
+
+// VerifyImgCode 校验人机验证，code 为内置图形验证码的答案、滑动拼图的横坐标或托管服务返回的令牌，nonce 为获取图形验证码时下发的客户端随机数，即验证码 ID
+// 校验通过后验证码作废，用于最终提交表单
+func VerifyImgCode(code, email, nonce string, c echo.Context) bool {
+	provider, err := captchaProvider(c)
+	if err != nil {
+		return false
 	}
+	return verifyImgCode(provider, captcha.VerifyRequest{Token: code, Subject: email, Nonce: nonce}, c)
+}
 
+// verifyImgCode 调用人机验证服务校验，补充客户端 IP
+func verifyImgCode(provider captcha.Provider, req captcha.VerifyRequest, c echo.Context) bool {
+	req.RemoteIP = c.RealIP()
+	ok, err := provider.Verify(c.Request().Context(), req)
+	if err != nil {
+		utils.BizLogger(c).Errorf("人机验证校验失败: %v", err)
+		observeVerify(ChannelImg, req.Subject, err, c)
+		return false
+	}
+	if !ok {
+		utils.BizLogger(c).Error("用户人机验证未通过")
+		observeVerify(ChannelImg, req.Subject, ErrVerificationCodeMismatch, c)
+		return false
+	}
+	observeVerify(ChannelImg, req.Subject, nil, c)
 	return true
 }
+
+// GetCaptchaConfig godoc
+// @Summary      获取人机验证配置
+// @Description  获取当前使用的人机验证服务与站点公钥，前端据此渲染内置图形验证码或托管验证组件
+// @Tags         账户
+// @Produce      json
+// @Success      200   {object} vo.Result{data=verification.CaptchaConfigVo} "获取成功"
+// @Failure      500   {object} vo.Result "服务器错误"
+// @Router       /verification/getCaptchaConfig [get]
+func GetCaptchaConfig(c echo.Context) error {
+	provider, err := captchaProvider(c)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(verification.CaptchaConfigVo{Provider: provider.Name(), SiteKey: provider.SiteKey()}, c))
+}
+
+// captchaProvider 根据配置创建人机验证服务
+func captchaProvider(c echo.Context) (captcha.Provider, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载人机验证配置失败: %v", err)
+		return nil, err
+	}
+	provider, err := captcha.New(config)
+	if err != nil {
+		utils.BizLogger(c).Errorf("创建人机验证服务失败: %v", err)
+		return nil, err
+	}
+	return provider, nil
+}
+
+// verifyCode 通用验证码校验，比对与作废在同一 Lua 脚本中原子完成，要求 nonce 与发送验证码时一致
+// 验证码按用途存储，用途不一致时视为验证码不存在；错误次数达到上限时作废验证码并返回 ErrVerificationAttemptsExceeded
+func verifyCode(code, target, nonce, action, prefix string, c echo.Context) error {
+	return checkCode(captcha.CodeCheck{
+		Key:         scopedKey(prefix, action, target),
+		Code:        code,
+		Nonce:       nonce,
+		MaxAttempts: maxVerifyAttempts(),
+	}, c)
+}
+
+// checkCode 比对验证码并将比对结果转换为校验错误
+func checkCode(check captcha.CodeCheck, c echo.Context) error {
+	key := check.Key
+	result, err := captcha.CheckCode(c.Request().Context(), check)
+	if err != nil {
+		utils.BizLogger(c).Errorf("验证码校验失败: %v", err)
+		return err
+	}
+
+	switch {
+	case result == captcha.CodeMatched:
+		return nil
+	case result == captcha.CodeMissing:
+		utils.BizLogger(c).Error("验证码不存在或已过期")
+		return ErrVerificationCodeExpired
+	case result == captcha.CodeLocked:
+		utils.BizLogger(c).Errorf("验证码错误次数过多，已作废，key: %s", key)
+		return ErrVerificationAttemptsExceeded
+	default:
+		utils.BizLogger(c).Errorf("用户验证码错误，已错误 %d 次", result)
+		return ErrVerificationCodeMismatch
+	}
+}
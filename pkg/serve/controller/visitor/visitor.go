@@ -0,0 +1,34 @@
+package visitor
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/internal/visitor"
+	"jank.com/jank_blog/pkg/serve/service/visitor"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// Identify godoc
+// @Summary      领取访客 ID
+// @Description  未注册的访客领取带签名的访客 ID，写入名为 jank_visitor 的 HttpOnly Cookie，用于点赞、浏览量去重与访客评论的归属，无需登录；已持有有效的访客 ID 时返回原 ID 并顺延 Cookie 有效期，需站点开启 VISITOR_ENABLED
+// @Tags         访客
+// @Produce      json
+// @Success      200     {object}   vo.Result{data=visitor.VisitorVo}  "领取成功"
+// @Failure      500     {object}   vo.Result  "服务器错误"
+// @Failure      503     {object}   vo.Result  "站点未开启匿名访客身份"
+// @Router       /visitor/identify [post]
+func Identify(c echo.Context) error {
+	identity, err := service.Identify(c)
+	if errors.Is(err, visitor.ErrDisabled) {
+		return c.JSON(http.StatusServiceUnavailable, vo.Fail(nil, bizErr.New(bizErr.VisitorDisabled), c))
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, vo.Fail(err, bizErr.New(bizErr.ServerError, err.Error()), c))
+	}
+
+	return c.JSON(http.StatusOK, vo.Success(identity, c))
+}
@@ -34,6 +34,18 @@ func GetAccountByAccountID(accountID int64) (*account.Account, error) {
 	return &user, nil
 }
 
+// GetAccountsByIDs 根据 ID 列表获取未删除的用户
+func GetAccountsByIDs(ids []int64) ([]*account.Account, error) {
+	var users []*account.Account
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := global.DB.Where("id IN ? AND deleted = ?", ids, false).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("获取用户失败: %v", err)
+	}
+	return users, nil
+}
+
 // CreateAccount 创建新用户
 func CreateAccount(acc *account.Account) error {
 	if err := global.DB.Create(acc).Error; err != nil {
@@ -91,13 +103,17 @@ func GetRoleByID(roleID int64) (*account.Role, error) {
 	return &role, nil
 }
 
-// GetAllRoles 获取所有角色
-func GetAllRoles() ([]*account.Role, error) {
+// GetRolesWithPaging 获取分页后的角色列表和角色总数
+func GetRolesWithPaging(offset, limit int) ([]*account.Role, int64, error) {
 	var roles []*account.Role
-	if err := global.DB.Where("deleted = ?", false).Find(&roles).Error; err != nil {
-		return nil, fmt.Errorf("获取角色列表失败: %v", err)
+	var total int64
+	if err := global.DB.Model(&account.Role{}).Where("deleted = ?", false).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取角色总数失败: %v", err)
 	}
-	return roles, nil
+	if err := global.DB.Where("deleted = ?", false).Order("id").Offset(offset).Limit(limit).Find(&roles).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取角色列表失败: %v", err)
+	}
+	return roles, total, nil
 }
 
 // CreatePermission 创建权限
@@ -132,13 +148,17 @@ func GetPermissionByID(permissionID int64) (*account.Permission, error) {
 	return &permission, nil
 }
 
-// GetAllPermissions 获取所有权限
-func GetAllPermissions() ([]*account.Permission, error) {
+// GetPermissionsWithPaging 获取分页后的权限列表和权限总数
+func GetPermissionsWithPaging(offset, limit int) ([]*account.Permission, int64, error) {
 	var permissions []*account.Permission
-	if err := global.DB.Where("deleted = ?", false).Find(&permissions).Error; err != nil {
-		return nil, fmt.Errorf("获取权限列表失败: %v", err)
+	var total int64
+	if err := global.DB.Model(&account.Permission{}).Where("deleted = ?", false).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取权限总数失败: %v", err)
 	}
-	return permissions, nil
+	if err := global.DB.Where("deleted = ?", false).Order("id").Offset(offset).Limit(limit).Find(&permissions).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取权限列表失败: %v", err)
+	}
+	return permissions, total, nil
 }
 
 // AssignRoleToAcc 为用户分配角色
@@ -216,3 +236,25 @@ func GetPermissionsByRoleID(roleID string) ([]*account.RolePermission, error) {
 	}
 	return permissions, nil
 }
+
+// CountAccounts 统计未删除的用户数量
+func CountAccounts() (int64, error) {
+	var count int64
+	if err := global.DB.Model(&account.Account{}).Where("deleted = ?", false).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计用户数量失败: %v", err)
+	}
+	return count, nil
+}
+
+// CountAccountsByRoleCode 统计拥有指定角色编码的用户数量
+func CountAccountsByRoleCode(code string) (int64, error) {
+	var count int64
+	err := global.DB.Model(&account.AccountRole{}).
+		Joins("JOIN roles ON roles.id = account_roles.role_id").
+		Where("roles.code = ? AND roles.deleted = ? AND account_roles.deleted = ?", code, false, false).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计角色用户数量失败: %v", err)
+	}
+	return count, nil
+}
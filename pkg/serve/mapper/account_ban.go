@@ -0,0 +1,110 @@
+package mapper
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// BanAccount 停用账户并写入停用记录，账户此前未解除的停用记录一并标记为已被本次停用替换
+func BanAccount(acc *account.Account, ban *account.AccountBan, now int64) error {
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := liftActiveBans(tx, acc.ID, ban.OperatorID, "被新的停用替换", now); err != nil {
+			return err
+		}
+		if err := tx.Save(acc).Error; err != nil {
+			return err
+		}
+		ban.AccountID = acc.ID
+		return tx.Create(ban).Error
+	})
+	if err != nil {
+		return fmt.Errorf("停用账户 %d 失败: %v", acc.ID, err)
+	}
+	return nil
+}
+
+// LiftAccountBan 解除账户的停用，并将未解除的停用记录标记为已解除，liftedBy 为 0 时表示到期自动解除
+func LiftAccountBan(acc *account.Account, liftedBy int64, reason string, now int64) error {
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		acc.DisabledAt, acc.DisabledUntil, acc.DisabledReason = 0, 0, ""
+		if err := tx.Save(acc).Error; err != nil {
+			return err
+		}
+		return liftActiveBans(tx, acc.ID, liftedBy, reason, now)
+	})
+	if err != nil {
+		return fmt.Errorf("解除账户 %d 的停用失败: %v", acc.ID, err)
+	}
+	return nil
+}
+
+// LiftExpiredAccountBan 限时停用在 now 时已到期时解除账户的停用，返回是否已解除；
+// 账户已被重新停用或已解除停用时不做处理，避免覆盖管理员在此期间的操作
+func LiftExpiredAccountBan(accountID, now int64) (bool, error) {
+	lifted := false
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&account.Account{}).
+			Where("id = ? AND disabled_at > ? AND disabled_until > ? AND disabled_until <= ?", accountID, 0, 0, now).
+			Updates(map[string]interface{}{"disabled_at": 0, "disabled_until": 0, "disabled_reason": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		lifted = true
+		return liftActiveBans(tx, accountID, 0, "停用到期", now)
+	})
+	if err != nil {
+		return false, fmt.Errorf("解除账户 %d 的到期停用失败: %v", accountID, err)
+	}
+	return lifted, nil
+}
+
+// GetAccountIDsWithExpiredBan 获取限时停用在 now 之前到期但尚未解除的账户 ID
+func GetAccountIDsWithExpiredBan(now int64) ([]int64, error) {
+	var ids []int64
+	if err := global.DB.Model(&account.Account{}).
+		Where("disabled_at > ? AND disabled_until > ? AND disabled_until <= ?", 0, 0, now).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("获取停用到期的账户失败: %v", err)
+	}
+	return ids, nil
+}
+
+// GetAccountBansByAccountID 获取账户的全部停用记录，按停用时间倒序排列
+func GetAccountBansByAccountID(accountID int64) ([]*account.AccountBan, error) {
+	var bans []*account.AccountBan
+	if err := global.DB.Where("account_id = ? AND deleted = ?", accountID, false).Order("id DESC").Find(&bans).Error; err != nil {
+		return nil, fmt.Errorf("获取停用记录失败: %v", err)
+	}
+	return bans, nil
+}
+
+// GetAccountBanByID 根据 ID 获取停用记录
+func GetAccountBanByID(id int64) (*account.AccountBan, error) {
+	var ban account.AccountBan
+	if err := global.DB.Where("id = ? AND deleted = ?", id, false).First(&ban).Error; err != nil {
+		return nil, fmt.Errorf("获取停用记录失败: %v", err)
+	}
+	return &ban, nil
+}
+
+// UpdateAccountBanAppeal 更新停用记录的申诉备注
+func UpdateAccountBanAppeal(id int64, note string) error {
+	if err := global.DB.Model(&account.AccountBan{}).Where("id = ?", id).Update("appeal_note", note).Error; err != nil {
+		return fmt.Errorf("更新停用申诉备注失败: %v", err)
+	}
+	return nil
+}
+
+// liftActiveBans 将账户未解除的停用记录标记为已解除
+func liftActiveBans(tx *gorm.DB, accountID, liftedBy int64, reason string, now int64) error {
+	return tx.Model(&account.AccountBan{}).
+		Where("account_id = ? AND lifted_at = ?", accountID, 0).
+		Updates(map[string]interface{}{"lifted_at": now, "lifted_by": liftedBy, "lift_reason": reason}).Error
+}
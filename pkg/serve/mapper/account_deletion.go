@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+	bookmark "jank.com/jank_blog/internal/model/bookmark"
+	comment "jank.com/jank_blog/internal/model/comment"
+	history "jank.com/jank_blog/internal/model/history"
+	post "jank.com/jank_blog/internal/model/post"
+	reaction "jank.com/jank_blog/internal/model/reaction"
+	series "jank.com/jank_blog/internal/model/series"
+)
+
+// GetAccountIDsDueForDeletion 获取注销冷静期在 before 之前到期的账户 ID
+func GetAccountIDsDueForDeletion(before int64) ([]int64, error) {
+	var ids []int64
+	if err := global.DB.Model(&account.Account{}).
+		Where("deletion_scheduled_at > ? AND deletion_scheduled_at <= ?", 0, before).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("获取待注销账户失败: %v", err)
+	}
+	return ids, nil
+}
+
+// PurgeAccount 永久删除账户及其角色、第三方账号关联、会话、API Key、设备令牌、通行密钥、登录记录、创建的邀请码、收藏、表态、阅读记录与文章署名，
+// 账户发表的评论与创建的系列保留并将所属用户置为 0；账户已撤销注销或冷静期未在 before 之前到期时不删除，返回 false
+func PurgeAccount(accountID, before int64) (bool, error) {
+	purged := false
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		var acc account.Account
+		if err := tx.Where("id = ? AND deletion_scheduled_at > ? AND deletion_scheduled_at <= ?", accountID, 0, before).First(&acc).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		if err := tx.Model(&comment.Comment{}).Where("user_id = ?", accountID).Update("user_id", 0).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&series.Series{}).Where("account_id = ?", accountID).Update("account_id", 0).Error; err != nil {
+			return err
+		}
+		for _, m := range []interface{}{
+			&account.AccountRole{}, &account.OAuthIdentity{}, &account.AccountSession{}, &account.APIKey{},
+			&account.DeviceToken{}, &account.WebAuthnCredential{}, &bookmark.Bookmark{}, &history.ReadingHistory{},
+			&reaction.PostReaction{}, &post.PostAuthor{},
+		} {
+			if err := tx.Where("account_id = ?", accountID).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("account_id = ? OR email = ?", accountID, acc.Email).Delete(&account.LoginRecord{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("creator_id = ?", accountID).Delete(&account.InviteCode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&acc).Error; err != nil {
+			return err
+		}
+		purged = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("永久删除账户 %d 失败: %v", accountID, err)
+	}
+	return purged, nil
+}
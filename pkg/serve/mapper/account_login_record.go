@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"fmt"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// CreateLoginRecord 创建登录记录
+func CreateLoginRecord(record *account.LoginRecord) error {
+	if err := global.DB.Create(record).Error; err != nil {
+		return fmt.Errorf("创建登录记录失败: %v", err)
+	}
+	return nil
+}
+
+// GetLoginRecordsWithPaging 分页获取账户的登录记录，按时间倒序排列
+func GetLoginRecordsWithPaging(accountID int64, offset, limit int) ([]*account.LoginRecord, int64, error) {
+	var records []*account.LoginRecord
+	var total int64
+
+	query := global.DB.Model(&account.LoginRecord{}).Where("account_id = ? AND deleted = ?", accountID, false)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取登录记录数量失败: %v", err)
+	}
+
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&records).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取登录记录失败: %v", err)
+	}
+	return records, total, nil
+}
+
+// CountSuccessfulLogins 统计账户成功登录的次数，device 不为空时只统计该设备
+func CountSuccessfulLogins(accountID int64, device string) (int64, error) {
+	var count int64
+	query := global.DB.Model(&account.LoginRecord{}).Where("account_id = ? AND success = ? AND deleted = ?", accountID, true, false)
+	if device != "" {
+		query = query.Where("device = ?", device)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计登录记录失败: %v", err)
+	}
+	return count, nil
+}
+
+// GetLoginRecordsByAccountID 获取账户的全部登录记录，按时间顺序排列
+func GetLoginRecordsByAccountID(accountID int64) ([]*account.LoginRecord, error) {
+	var records []*account.LoginRecord
+	if err := global.DB.Where("account_id = ? AND deleted = ?", accountID, false).Order("id ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("获取登录记录失败: %v", err)
+	}
+	return records, nil
+}
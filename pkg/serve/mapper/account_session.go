@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// CreateAccountSession 创建会话记录
+func CreateAccountSession(session *account.AccountSession) error {
+	if err := global.DB.Create(session).Error; err != nil {
+		return fmt.Errorf("创建会话记录失败: %v", err)
+	}
+	return nil
+}
+
+// GetActiveAccountSessions 获取账户未结束且未过期的会话记录
+func GetActiveAccountSessions(accountID int64) ([]*account.AccountSession, error) {
+	var sessions []*account.AccountSession
+	err := global.DB.Where("account_id = ? AND (ended_at IS NULL OR ended_at = 0) AND expires_at > ? AND deleted = ?", accountID, time.Now().Unix(), false).
+		Order("id DESC").Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("获取会话列表失败: %v", err)
+	}
+	return sessions, nil
+}
+
+// UpdateAccountSession 更新会话记录的指定字段
+func UpdateAccountSession(sessionID string, fields map[string]interface{}) error {
+	if err := global.DB.Model(&account.AccountSession{}).Where("session_id = ?", sessionID).Updates(fields).Error; err != nil {
+		return fmt.Errorf("更新会话记录失败: %v", err)
+	}
+	return nil
+}
+
+// EndAccountSession 将账户的单个会话记录标记为已结束，返回是否结束了记录
+func EndAccountSession(accountID int64, sessionID string, endedAt int64, reason string) (bool, error) {
+	result := global.DB.Model(&account.AccountSession{}).
+		Where("account_id = ? AND session_id = ? AND (ended_at IS NULL OR ended_at = 0) AND deleted = ?", accountID, sessionID, false).
+		Updates(map[string]interface{}{"ended_at": endedAt, "end_reason": reason})
+	if result.Error != nil {
+		return false, fmt.Errorf("结束会话失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// EndAccountSessions 将账户除 exceptSessionID 外未结束的会话记录标记为已结束，exceptSessionID 为空时结束全部会话，返回被结束的会话 ID
+func EndAccountSessions(accountID int64, exceptSessionID string, endedAt int64, reason string) ([]string, error) {
+	var sessionIDs []string
+	if err := global.DB.Model(&account.AccountSession{}).
+		Where("account_id = ? AND session_id <> ? AND (ended_at IS NULL OR ended_at = 0) AND deleted = ?", accountID, exceptSessionID, false).
+		Pluck("session_id", &sessionIDs).Error; err != nil {
+		return nil, fmt.Errorf("获取会话记录失败: %v", err)
+	}
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+	if err := global.DB.Model(&account.AccountSession{}).Where("session_id IN ?", sessionIDs).
+		Updates(map[string]interface{}{"ended_at": endedAt, "end_reason": reason}).Error; err != nil {
+		return nil, fmt.Errorf("结束会话失败: %v", err)
+	}
+	return sessionIDs, nil
+}
@@ -0,0 +1,157 @@
+package mapper
+
+import (
+	"fmt"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// 用户列表的账户状态筛选
+const (
+	AccountStatusActive          = "active"           // 正常
+	AccountStatusDisabled        = "disabled"         // 已停用
+	AccountStatusPendingDeletion = "pending_deletion" // 处于注销冷静期
+	AccountStatusPendingApproval = "pending_approval" // 注册待审核
+	AccountStatusRejected        = "rejected"         // 注册未通过审核
+)
+
+// GetAccountsWithPaging 分页获取用户列表，按注册时间倒序排列；keyword 模糊匹配邮箱、手机号、昵称与展示名称，
+// roleCode 与 status 为空时不筛选
+func GetAccountsWithPaging(keyword, roleCode, status string, offset, limit int) ([]*account.Account, int64, error) {
+	var accounts []*account.Account
+	var total int64
+
+	query := global.DB.Model(&account.Account{}).Where("accounts.deleted = ?", false)
+	if keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where("accounts.email LIKE ? OR accounts.phone LIKE ? OR accounts.nickname LIKE ? OR accounts.display_name LIKE ?", like, like, like, like)
+	}
+	if roleCode != "" {
+		query = query.Joins("JOIN account_roles ON account_roles.account_id = accounts.id AND account_roles.deleted = ?", false).
+			Joins("JOIN roles ON roles.id = account_roles.role_id AND roles.deleted = ?", false).
+			Where("roles.code = ?", roleCode)
+	}
+	switch status {
+	case AccountStatusActive:
+		query = query.Where("accounts.disabled_at = ? AND accounts.deletion_scheduled_at = ?", 0, 0).
+			Where("accounts.approval_status IS NULL OR accounts.approval_status = ?", "")
+	case AccountStatusDisabled:
+		query = query.Where("accounts.disabled_at > ?", 0)
+	case AccountStatusPendingDeletion:
+		query = query.Where("accounts.deletion_scheduled_at > ?", 0)
+	case AccountStatusPendingApproval:
+		query = query.Where("accounts.approval_status = ?", account.ApprovalStatusPending)
+	case AccountStatusRejected:
+		query = query.Where("accounts.approval_status = ?", account.ApprovalStatusRejected)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取用户总数失败: %v", err)
+	}
+	if err := query.Select("accounts.*").Order("accounts.id DESC").Offset(offset).Limit(limit).Find(&accounts).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取用户列表失败: %v", err)
+	}
+	return accounts, total, nil
+}
+
+// GetRoleCodesByAccountIDs 批量获取账户的角色编码，键为账户 ID，未分配角色的账户不在结果中
+func GetRoleCodesByAccountIDs(accountIDs []int64) (map[int64]string, error) {
+	codes := make(map[int64]string, len(accountIDs))
+	if len(accountIDs) == 0 {
+		return codes, nil
+	}
+
+	var rows []struct {
+		AccountID int64
+		Code      string
+	}
+	err := global.DB.Model(&account.AccountRole{}).
+		Select("account_roles.account_id, roles.code").
+		Joins("JOIN roles ON roles.id = account_roles.role_id").
+		Where("account_roles.account_id IN ? AND account_roles.deleted = ? AND roles.deleted = ?", accountIDs, false, false).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("获取用户角色失败: %v", err)
+	}
+	for _, row := range rows {
+		codes[row.AccountID] = row.Code
+	}
+	return codes, nil
+}
+
+// CountEnabledAccountsByRoleCode 统计拥有指定角色编码、未停用且不在注销冷静期的用户数量
+func CountEnabledAccountsByRoleCode(code string) (int64, error) {
+	var count int64
+	err := global.DB.Model(&account.AccountRole{}).
+		Joins("JOIN roles ON roles.id = account_roles.role_id").
+		Joins("JOIN accounts ON accounts.id = account_roles.account_id").
+		Where("roles.code = ? AND roles.deleted = ? AND account_roles.deleted = ?", code, false, false).
+		Where("accounts.deleted = ? AND accounts.disabled_at = ? AND accounts.deletion_scheduled_at = ?", false, 0, 0).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计角色用户数量失败: %v", err)
+	}
+	return count, nil
+}
+
+// CountAccountsByApprovalStatus 统计处于指定注册审核状态的用户数量
+func CountAccountsByApprovalStatus(status string) (int64, error) {
+	var count int64
+	err := global.DB.Model(&account.Account{}).
+		Where("deleted = ? AND approval_status = ?", false, status).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计待审核用户数量失败: %v", err)
+	}
+	return count, nil
+}
+
+// GetEnabledEmailsByRoleCode 获取拥有指定角色编码、未停用且不在注销冷静期的用户邮箱
+func GetEnabledEmailsByRoleCode(code string) ([]string, error) {
+	var emails []string
+	err := global.DB.Model(&account.AccountRole{}).
+		Select("accounts.email").
+		Joins("JOIN roles ON roles.id = account_roles.role_id").
+		Joins("JOIN accounts ON accounts.id = account_roles.account_id").
+		Where("roles.code = ? AND roles.deleted = ? AND account_roles.deleted = ?", code, false, false).
+		Where("accounts.deleted = ? AND accounts.disabled_at = ? AND accounts.deletion_scheduled_at = ?", false, 0, 0).
+		Pluck("accounts.email", &emails).Error
+	if err != nil {
+		return nil, fmt.Errorf("获取角色用户邮箱失败: %v", err)
+	}
+	return emails, nil
+}
+
+// CreateAdminAuditLog 创建管理员操作审计日志
+func CreateAdminAuditLog(log *account.AdminAuditLog) error {
+	if err := global.DB.Create(log).Error; err != nil {
+		return fmt.Errorf("创建管理员操作审计日志失败: %v", err)
+	}
+	return nil
+}
+
+// GetAdminAuditLogsWithPaging 分页获取管理员操作审计日志，按时间倒序排列，筛选条件为零值时不筛选
+func GetAdminAuditLogsWithPaging(targetID, operatorID int64, action string, offset, limit int) ([]*account.AdminAuditLog, int64, error) {
+	var logs []*account.AdminAuditLog
+	var total int64
+
+	query := global.DB.Model(&account.AdminAuditLog{}).Where("deleted = ?", false)
+	if targetID > 0 {
+		query = query.Where("target_id = ?", targetID)
+	}
+	if operatorID > 0 {
+		query = query.Where("operator_id = ?", operatorID)
+	}
+	if action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取管理员操作审计日志数量失败: %v", err)
+	}
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&logs).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取管理员操作审计日志失败: %v", err)
+	}
+	return logs, total, nil
+}
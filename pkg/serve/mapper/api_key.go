@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"fmt"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// GetAPIKeyByHash 根据 Key 的摘要获取 API Key
+func GetAPIKeyByHash(keyHash string) (*account.APIKey, error) {
+	var key account.APIKey
+	if err := global.DB.Where("key_hash = ? AND deleted = ?", keyHash, false).First(&key).Error; err != nil {
+		return nil, fmt.Errorf("获取 API Key 失败: %v", err)
+	}
+	return &key, nil
+}
+
+// GetAPIKeysByAccountID 获取账户创建的全部 API Key
+func GetAPIKeysByAccountID(accountID int64) ([]*account.APIKey, error) {
+	var keys []*account.APIKey
+	if err := global.DB.Where("account_id = ? AND deleted = ?", accountID, false).Order("id ASC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("获取 API Key 列表失败: %v", err)
+	}
+	return keys, nil
+}
+
+// CreateAPIKey 创建 API Key
+func CreateAPIKey(key *account.APIKey) error {
+	if err := global.DB.Create(key).Error; err != nil {
+		return fmt.Errorf("创建 API Key 失败: %v", err)
+	}
+	return nil
+}
+
+// TouchAPIKey 更新 API Key 的最近使用时间与 IP
+func TouchAPIKey(id, usedAt int64, ip string) error {
+	if err := global.DB.Model(&account.APIKey{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"last_used_at": usedAt, "last_used_ip": ip}).Error; err != nil {
+		return fmt.Errorf("更新 API Key 使用记录失败: %v", err)
+	}
+	return nil
+}
+
+// DeleteAPIKeySoftly 删除账户的 API Key，返回是否删除了记录
+func DeleteAPIKeySoftly(accountID, id int64) (bool, error) {
+	result := global.DB.Model(&account.APIKey{}).
+		Where("id = ? AND account_id = ? AND deleted = ?", id, accountID, false).
+		Update("deleted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("删除 API Key 失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
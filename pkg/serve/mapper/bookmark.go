@@ -0,0 +1,77 @@
+package mapper
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	bookmark "jank.com/jank_blog/internal/model/bookmark"
+)
+
+// SaveBookmark 收藏文章，已收藏时不做处理
+func SaveBookmark(accountID, postID int64) error {
+	var record bookmark.Bookmark
+	err := global.DB.Where("account_id = ? AND post_id = ?", accountID, postID).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return global.DB.Create(&bookmark.Bookmark{AccountID: accountID, PostID: postID}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	// 唯一索引包含已取消的收藏，重新收藏时恢复该记录
+	if record.Deleted {
+		return global.DB.Model(&record).Updates(map[string]interface{}{
+			"deleted":      false,
+			"gmt_modified": time.Now().Unix(),
+		}).Error
+	}
+	return nil
+}
+
+// DeleteBookmarkSoftly 取消收藏
+func DeleteBookmarkSoftly(accountID, postID int64) error {
+	return global.DB.Model(&bookmark.Bookmark{}).
+		Where("account_id = ? AND post_id = ? AND deleted = ?", accountID, postID, false).
+		Update("deleted", true).Error
+}
+
+// GetBookmarksWithPaging 获取用户的收藏分页列表和收藏总数，按最近收藏时间倒序排序
+func GetBookmarksWithPaging(accountID int64, offset, limit int) ([]*bookmark.Bookmark, int64, error) {
+	var records []*bookmark.Bookmark
+	var total int64
+
+	query := global.DB.Model(&bookmark.Bookmark{}).Where("account_id = ? AND deleted = ?", accountID, false)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("gmt_modified DESC").
+		Offset(offset).Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// CountBookmarksByPostID 统计文章的收藏数
+func CountBookmarksByPostID(postID int64) (int64, error) {
+	var count int64
+	err := global.DB.Model(&bookmark.Bookmark{}).
+		Where("post_id = ? AND deleted = ?", postID, false).
+		Count(&count).Error
+	return count, err
+}
+
+// IsPostBookmarked 判断用户是否已收藏文章
+func IsPostBookmarked(accountID, postID int64) (bool, error) {
+	var count int64
+	err := global.DB.Model(&bookmark.Bookmark{}).
+		Where("account_id = ? AND post_id = ? AND deleted = ?", accountID, postID, false).
+		Count(&count).Error
+	return count > 0, err
+}
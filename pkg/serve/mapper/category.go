@@ -53,6 +53,19 @@ func GetAllActivatedCategories() ([]*category.Category, error) {
 	return categories, nil
 }
 
+// GetCategoriesByIDs 根据 ID 列表查找未删除的类目
+func GetCategoriesByIDs(ids []int64) ([]*category.Category, error) {
+	var categories []*category.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	err := global.DB.Where("id IN ? AND deleted = ?", ids, false).Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
 // GetParentCategoryPathByID 根据父类目 ID 查找父类目的路径
 func GetParentCategoryPathByID(parentID int64) (string, error) {
 	if parentID == 0 {
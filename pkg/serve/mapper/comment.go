@@ -3,6 +3,7 @@ package mapper
 import (
 	"jank.com/jank_blog/internal/global"
 	model "jank.com/jank_blog/internal/model/comment"
+	post "jank.com/jank_blog/internal/model/post"
 )
 
 // CreateComment 保存评论到数据库
@@ -44,3 +45,44 @@ func GetCommentsByPostID(postID int64) ([]*model.Comment, error) {
 func UpdateComment(comment *model.Comment) error {
 	return global.DB.Save(comment).Error
 }
+
+// GetCommentsByUserID 根据用户 ID 查询用户发表的所有评论，按 ID 升序排列
+func GetCommentsByUserID(userID int64) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	err := global.DB.Where("user_id = ? AND deleted = ?", userID, false).Order("id ASC").Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetCommentsOfPublishedPosts 获取已发布的公开文章下所有未删除的评论，用于重建全文索引
+func GetCommentsOfPublishedPosts() ([]*model.Comment, error) {
+	var comments []*model.Comment
+	err := global.DB.Where("deleted = ? AND post_id IN (?)", false,
+		global.DB.Model(&post.Post{}).Select("id").Where("status = ? AND visibility = ? AND deleted = ? AND access = ?", post.StatusPublished, true, false, post.AccessPublic)).
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetCommentsWithoutHTML 获取尚未渲染 HTML 的未删除评论，仅查询 ID 与内容
+func GetCommentsWithoutHTML() ([]*model.Comment, error) {
+	var comments []*model.Comment
+	err := global.DB.Select("id", "content").
+		Where("(content_html = ? OR content_html IS NULL) AND deleted = ?", "", false).
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// UpdateCommentHTML 更新评论渲染后的 HTML，不改变评论的修改时间
+func UpdateCommentHTML(commentID int64, contentHTML string) error {
+	return global.DB.Model(&model.Comment{}).
+		Where("id = ?", commentID).
+		UpdateColumn("content_html", contentHTML).Error
+}
@@ -0,0 +1,99 @@
+package mapper
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// GetDeviceTokenByHash 根据令牌的摘要获取设备令牌
+func GetDeviceTokenByHash(tokenHash string) (*account.DeviceToken, error) {
+	var token account.DeviceToken
+	if err := global.DB.Where("token_hash = ? AND deleted = ?", tokenHash, false).First(&token).Error; err != nil {
+		return nil, fmt.Errorf("获取设备令牌失败: %v", err)
+	}
+	return &token, nil
+}
+
+// GetDeviceTokensByAccountID 获取账户在 now 时未过期的设备令牌，按最近使用时间倒序排列
+func GetDeviceTokensByAccountID(accountID, now int64) ([]*account.DeviceToken, error) {
+	var tokens []*account.DeviceToken
+	if err := global.DB.Where("account_id = ? AND deleted = ? AND expires_at > ?", accountID, false, now).
+		Order("last_used_at DESC, id DESC").Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("获取设备令牌列表失败: %v", err)
+	}
+	return tokens, nil
+}
+
+// CreateDeviceToken 创建设备令牌，同一账户在同一设备上已有的令牌一并删除
+func CreateDeviceToken(token *account.DeviceToken) error {
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&account.DeviceToken{}).
+			Where("account_id = ? AND device_hash = ? AND deleted = ?", token.AccountID, token.DeviceHash, false).
+			Update("deleted", true).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+	if err != nil {
+		return fmt.Errorf("创建设备令牌失败: %v", err)
+	}
+	return nil
+}
+
+// RotateDeviceToken 将摘要仍为 oldHash 的设备令牌轮换为新的摘要并记录本次使用，返回是否轮换成功；
+// 令牌已被并发的请求轮换或已删除时返回 false
+func RotateDeviceToken(id int64, oldHash string, values map[string]interface{}) (bool, error) {
+	result := global.DB.Model(&account.DeviceToken{}).
+		Where("id = ? AND token_hash = ? AND deleted = ?", id, oldHash, false).
+		Updates(values)
+	if result.Error != nil {
+		return false, fmt.Errorf("轮换设备令牌失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteDeviceTokenSoftly 删除账户的设备令牌，返回是否删除了记录
+func DeleteDeviceTokenSoftly(accountID, id int64) (bool, error) {
+	result := global.DB.Model(&account.DeviceToken{}).
+		Where("id = ? AND account_id = ? AND deleted = ?", id, accountID, false).
+		Update("deleted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("删除设备令牌失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteDeviceTokensByAccountID 删除账户的设备令牌，exceptSessionID 不为空时保留由该会话开启记住我或创建该会话的令牌，返回删除的数量
+func DeleteDeviceTokensByAccountID(accountID int64, exceptSessionID string) (int64, error) {
+	query := global.DB.Model(&account.DeviceToken{}).Where("account_id = ? AND deleted = ?", accountID, false)
+	if exceptSessionID != "" {
+		query = query.Where("session_id IS NULL OR session_id <> ?", exceptSessionID)
+	}
+	result := query.Update("deleted", true)
+	if result.Error != nil {
+		return 0, fmt.Errorf("删除设备令牌失败: %v", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteDeviceTokensBySessionID 删除与会话关联的设备令牌，用户移除会话时该设备不能再免登录
+func DeleteDeviceTokensBySessionID(accountID int64, sessionID string) error {
+	if err := global.DB.Model(&account.DeviceToken{}).
+		Where("account_id = ? AND session_id = ? AND deleted = ?", accountID, sessionID, false).
+		Update("deleted", true).Error; err != nil {
+		return fmt.Errorf("删除设备令牌失败: %v", err)
+	}
+	return nil
+}
+
+// UpdateDeviceTokenSession 记录由设备令牌创建的会话 ID
+func UpdateDeviceTokenSession(id int64, sessionID string) error {
+	if err := global.DB.Model(&account.DeviceToken{}).Where("id = ?", id).Update("session_id", sessionID).Error; err != nil {
+		return fmt.Errorf("更新设备令牌的会话失败: %v", err)
+	}
+	return nil
+}
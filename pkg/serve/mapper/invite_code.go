@@ -0,0 +1,108 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// ErrInviteCodeUnavailable 注册时邀请码已被撤销、已过期或已用完
+var ErrInviteCodeUnavailable = errors.New("邀请码已被撤销、已过期或已用完")
+
+// GetInviteCodeByCode 根据邀请码获取未撤销的邀请码
+func GetInviteCodeByCode(code string) (*account.InviteCode, error) {
+	var invite account.InviteCode
+	if err := global.DB.Where("code = ? AND deleted = ?", code, false).First(&invite).Error; err != nil {
+		return nil, fmt.Errorf("获取邀请码失败: %v", err)
+	}
+	return &invite, nil
+}
+
+// CountInviteCodesByCode 统计使用该邀请码的记录数量，包含已撤销的记录
+func CountInviteCodesByCode(code string) (int64, error) {
+	var count int64
+	if err := global.DB.Model(&account.InviteCode{}).Where("code = ?", code).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("统计邀请码失败: %v", err)
+	}
+	return count, nil
+}
+
+// CountUsableInviteCodesByCreator 统计账户创建的在 now 时未用完且未过期的邀请码数量
+func CountUsableInviteCodesByCreator(creatorID, now int64) (int64, error) {
+	var count int64
+	err := global.DB.Model(&account.InviteCode{}).
+		Where("creator_id = ? AND deleted = ? AND used_count < max_uses AND (expires_at = ? OR expires_at > ?)", creatorID, false, 0, now).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计邀请码失败: %v", err)
+	}
+	return count, nil
+}
+
+// GetInviteCodesWithPaging 分页获取未撤销的邀请码，按创建时间倒序排列，creatorID 为 0 时获取全部账户创建的邀请码
+func GetInviteCodesWithPaging(creatorID int64, offset, limit int) ([]*account.InviteCode, int64, error) {
+	var invites []*account.InviteCode
+	var total int64
+
+	query := global.DB.Model(&account.InviteCode{}).Where("deleted = ?", false)
+	if creatorID > 0 {
+		query = query.Where("creator_id = ?", creatorID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取邀请码总数失败: %v", err)
+	}
+	if err := query.Order("id DESC").Offset(offset).Limit(limit).Find(&invites).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取邀请码列表失败: %v", err)
+	}
+	return invites, total, nil
+}
+
+// CreateInviteCode 创建邀请码
+func CreateInviteCode(invite *account.InviteCode) error {
+	if err := global.DB.Create(invite).Error; err != nil {
+		return fmt.Errorf("创建邀请码失败: %v", err)
+	}
+	return nil
+}
+
+// DeleteInviteCodeSoftly 撤销邀请码，creatorID 为 0 时不限创建者，返回是否撤销了记录
+func DeleteInviteCodeSoftly(creatorID, id int64) (bool, error) {
+	query := global.DB.Model(&account.InviteCode{}).Where("id = ? AND deleted = ?", id, false)
+	if creatorID > 0 {
+		query = query.Where("creator_id = ?", creatorID)
+	}
+	result := query.Update("deleted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("撤销邀请码失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateAccountWithInvite 累加邀请码的使用次数并创建账户，邀请码在 now 时已不可用时返回 ErrInviteCodeUnavailable，
+// 并发注册时同一邀请码的使用次数不会超过上限
+func CreateAccountWithInvite(acc *account.Account, inviteID, now int64) error {
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&account.InviteCode{}).
+			Where("id = ? AND deleted = ? AND used_count < max_uses AND (expires_at = ? OR expires_at > ?)", inviteID, false, 0, now).
+			UpdateColumn("used_count", gorm.Expr("used_count + ?", 1))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInviteCodeUnavailable
+		}
+		acc.InviteCodeID = inviteID
+		return tx.Create(acc).Error
+	})
+	if errors.Is(err, ErrInviteCodeUnavailable) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("创建账户失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// GetValidJWTSigningKeys 获取尚未停止校验的 JWT 签名密钥，按创建时间倒序排列
+func GetValidJWTSigningKeys(now int64) ([]*account.JWTSigningKey, error) {
+	var keys []*account.JWTSigningKey
+	err := global.DB.Where("deleted = ? AND (expires_at = ? OR expires_at > ?)", false, 0, now).
+		Order("id DESC").Find(&keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("获取 JWT 签名密钥失败: %v", err)
+	}
+	return keys, nil
+}
+
+// CreateJWTSigningKey 创建 JWT 签名密钥
+func CreateJWTSigningKey(key *account.JWTSigningKey) error {
+	if err := global.DB.Create(key).Error; err != nil {
+		return fmt.Errorf("创建 JWT 签名密钥失败: %v", err)
+	}
+	return nil
+}
+
+// RotateJWTSigningKey 在同一事务中创建新密钥，将同一用途的其他密钥标记为已替换，并将其停止校验的时间提前到 expiresAt
+func RotateJWTSigningKey(key *account.JWTSigningKey, now, expiresAt int64) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(key).Error; err != nil {
+			return fmt.Errorf("创建 JWT 签名密钥失败: %v", err)
+		}
+		others := tx.Model(&account.JWTSigningKey{}).Where("purpose = ? AND kid <> ? AND deleted = ?", key.Purpose, key.Kid, false)
+		if err := others.Session(&gorm.Session{}).Where("retired_at = ?", 0).Update("retired_at", now).Error; err != nil {
+			return fmt.Errorf("替换 JWT 签名密钥失败: %v", err)
+		}
+		if err := others.Session(&gorm.Session{}).Where("expires_at = ? OR expires_at > ?", 0, expiresAt).Update("expires_at", expiresAt).Error; err != nil {
+			return fmt.Errorf("替换 JWT 签名密钥失败: %v", err)
+		}
+		return nil
+	})
+}
+
+// PurgeExpiredJWTSigningKeys 永久删除在 before 之前停止校验的 JWT 签名密钥
+func PurgeExpiredJWTSigningKeys(before int64) (int64, error) {
+	result := global.DB.Where("expires_at > ? AND expires_at < ?", 0, before).Delete(&account.JWTSigningKey{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("删除过期的 JWT 签名密钥失败: %v", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"fmt"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// GetOAuthIdentity 根据第三方登录服务与第三方账号 ID 获取关联
+func GetOAuthIdentity(provider, subject string) (*account.OAuthIdentity, error) {
+	var identity account.OAuthIdentity
+	if err := global.DB.Where("provider = ? AND subject = ? AND deleted = ?", provider, subject, false).First(&identity).Error; err != nil {
+		return nil, fmt.Errorf("获取第三方登录关联失败: %v", err)
+	}
+	return &identity, nil
+}
+
+// CreateOAuthIdentity 创建第三方登录关联
+func CreateOAuthIdentity(identity *account.OAuthIdentity) error {
+	if err := global.DB.Create(identity).Error; err != nil {
+		return fmt.Errorf("创建第三方登录关联失败: %v", err)
+	}
+	return nil
+}
+
+// GetOAuthIdentitiesByAccountID 获取账户关联的全部第三方账号
+func GetOAuthIdentitiesByAccountID(accountID int64) ([]*account.OAuthIdentity, error) {
+	var identities []*account.OAuthIdentity
+	if err := global.DB.Where("account_id = ? AND deleted = ?", accountID, false).Order("id ASC").Find(&identities).Error; err != nil {
+		return nil, fmt.Errorf("获取第三方登录关联失败: %v", err)
+	}
+	return identities, nil
+}
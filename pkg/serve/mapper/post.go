@@ -2,6 +2,9 @@ package mapper
 
 import (
 	"fmt"
+	"time"
+
+	"gorm.io/gorm"
 
 	"jank.com/jank_blog/internal/global"
 	category "jank.com/jank_blog/internal/model/category"
@@ -73,23 +76,31 @@ func GetPostsByTitle(title string) ([]post.Post, error) {
 	return posts, nil
 }
 
-// GetAllPostsWithPaging 获取分页后的文章列表和文章总数
-func GetAllPostsWithPaging(page, pageSize int) ([]*post.Post, int64, error) {
+// GetAllPostsWithPaging 获取分页后的文章列表和文章总数，按创建时间倒序排序，status 为空时不按发布状态筛选，access 为空时不按访问权限筛选；
+// language 不为空时每个翻译组只返回该语言的版本，见 withLanguage；pinnedFirst 为 true 时置顶的文章按置顶顺序排在最前
+func GetAllPostsWithPaging(status string, access []string, language string, pinnedFirst bool, offset, limit int) ([]*post.Post, int64, error) {
 	var posts []*post.Post
 	var total int64
 
-	offset := (page - 1) * pageSize
+	query := global.DB.Model(&post.Post{}).Where("deleted = ?", false)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query = withAccess(query, "access", access)
+	query = withLanguage(query, language, access)
 
 	// 查询文章总数
-	err := global.DB.Model(&post.Post{}).Where("deleted = ?", false).Count(&total).Error
+	err := query.Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// 查询分页数据
-	err = global.DB.Where("deleted = ?", false).
-		Order("gmt_create DESC").
-		Offset(offset).Limit(pageSize).
+	if pinnedFirst {
+		query = query.Order("pinned DESC, pin_order ASC")
+	}
+	err = query.Order("gmt_create DESC").
+		Offset(offset).Limit(limit).
 		Find(&posts).Error
 	if err != nil {
 		return nil, 0, err
@@ -113,6 +124,30 @@ func GetAllPostsWithPaging(page, pageSize int) ([]*post.Post, int64, error) {
 	return posts, total, nil
 }
 
+// GetAllPublishedPosts 获取所有已发布的文章，按创建时间倒序排序，access 为空时不按访问权限筛选
+func GetAllPublishedPosts(access []string) ([]*post.Post, error) {
+	var posts []*post.Post
+	err := withAccess(global.DB.Where("visibility = ? AND deleted = ?", true, false), "access", access).
+		Order("gmt_create DESC").
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetAllPosts 获取所有未删除的文章，包括草稿与已归档的文章，按创建时间正序排序
+func GetAllPosts() ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Where("deleted = ?", false).
+		Order("gmt_create ASC").
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
 // UpdateOnePostByID 更新文章
 func UpdateOnePostByID(postID int64, newPost *post.Post) error {
 	if postID <= 0 || newPost == nil {
@@ -138,6 +173,52 @@ func UpdateOnePostByID(postID int64, newPost *post.Post) error {
 	return nil
 }
 
+// UpdatePostStatus 文章的发布状态仍为 from 时变更为 to，并同步更新可见性、取消定时发布，返回是否已变更
+func UpdatePostStatus(postID int64, from, to string) (bool, error) {
+	result := global.DB.Model(&post.Post{}).
+		Where("id = ? AND status = ? AND deleted = ?", postID, from, false).
+		Updates(map[string]interface{}{"status": to, "visibility": to == post.StatusPublished, "publish_at": 0})
+	if result.Error != nil {
+		return false, fmt.Errorf("变更文章 %d 的发布状态失败: %v", postID, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SchedulePost 文章的发布状态仍为 status 且未在审核流程中时设置定时发布时间，publishAt 为 0 时取消定时发布，返回是否已设置
+func SchedulePost(postID int64, status string, publishAt int64) (bool, error) {
+	result := global.DB.Model(&post.Post{}).
+		Where("id = ? AND status = ? AND review_status NOT IN ? AND deleted = ?",
+			postID, status, []string{post.ReviewStatusPending, post.ReviewStatusScheduled}, false).
+		Update("publish_at", publishAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("设置文章 %d 的定时发布时间失败: %v", postID, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetDueDirectScheduledPosts 获取作者直接定时发布且发布时间已到的文章，审核通过后等待定时发布的文章见 GetDueScheduledPosts
+func GetDueDirectScheduledPosts(now int64) ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Where("status <> ? AND review_status NOT IN ? AND publish_at > ? AND publish_at <= ? AND deleted = ?",
+		post.StatusPublished, []string{post.ReviewStatusPending, post.ReviewStatusScheduled}, 0, now, false).
+		Find(&posts).Error
+	if err != nil {
+		return nil, fmt.Errorf("获取到期的定时发布文章失败: %v", err)
+	}
+	return posts, nil
+}
+
+// PublishDirectScheduledPost 文章的定时发布时间仍为 publishAt 且尚未发布时发布文章，返回是否已发布
+func PublishDirectScheduledPost(postID, publishAt int64) (bool, error) {
+	result := global.DB.Model(&post.Post{}).
+		Where("id = ? AND publish_at = ? AND status <> ? AND deleted = ?", postID, publishAt, post.StatusPublished, false).
+		Updates(map[string]interface{}{"status": post.StatusPublished, "visibility": true, "publish_at": 0})
+	if result.Error != nil {
+		return false, fmt.Errorf("定时发布文章 %d 失败: %v", postID, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // DeleteOnePostByID 根据 ID 进行软删除操作
 func DeleteOnePostByID(postID int64) error {
 	if postID <= 0 {
@@ -161,9 +242,10 @@ func DeleteOnePostByID(postID int64) error {
 		}
 	}
 
+	// 显式更新修改时间，回收站按删除时间计算保留期限
 	result := global.DB.Model(&post.Post{}).
 		Where("id = ? AND deleted = ?", postID, false).
-		Update("deleted", true)
+		Updates(map[string]interface{}{"deleted": true, "gmt_modified": time.Now().Unix()})
 
 	if result.Error != nil {
 		return result.Error
@@ -204,3 +286,167 @@ func getValidCategoryIDs(postID int64, categoryIDs []int64) ([]int64, bool, erro
 
 	return validIDs, updated, nil
 }
+
+// GetPostsByIDs 根据 ID 列表获取未删除的文章
+func GetPostsByIDs(ids []int64) ([]*post.Post, error) {
+	var posts []*post.Post
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	err := global.DB.Where("id IN ? AND deleted = ?", ids, false).Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetPublishedPostsForSitemap 获取访客可见的已发布文章的 ID、分类与创建、更新时间，不含正文，按创建时间倒序排列
+func GetPublishedPostsForSitemap() ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Select("id", "category_ids", "gmt_create", "gmt_modified").
+		Where("visibility = ? AND deleted = ? AND access <> ?", true, false, post.AccessLogin).
+		Order("gmt_create DESC").
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetPublishedPostCreateTimes 获取所有已发布文章的创建时间，access 为空时不按访问权限筛选
+func GetPublishedPostCreateTimes(access []string) ([]int64, error) {
+	var times []int64
+	err := withAccess(global.DB.Model(&post.Post{}).Where("visibility = ? AND deleted = ?", true, false), "access", access).
+		Pluck("gmt_create", &times).Error
+	if err != nil {
+		return nil, err
+	}
+	return times, nil
+}
+
+// GetPublishedPostTitles 获取所有已发布文章的 ID、标题、别名与创建时间，不含正文，按创建时间倒序排列，access 为空时不按访问权限筛选
+func GetPublishedPostTitles(access []string) ([]*post.Post, error) {
+	var posts []*post.Post
+	err := withAccess(global.DB.Select("id", "title", "slug", "gmt_create").Where("visibility = ? AND deleted = ?", true, false), "access", access).
+		Order("gmt_create DESC, id DESC").
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetPublishedPostsByPeriodWithPaging 获取创建时间在 [start, end) 内的已发布文章分页列表和文章总数，access 为空时不按访问权限筛选
+func GetPublishedPostsByPeriodWithPaging(start, end int64, access []string, offset, limit int) ([]*post.Post, int64, error) {
+	var posts []*post.Post
+	var total int64
+
+	query := global.DB.Model(&post.Post{}).
+		Where("visibility = ? AND deleted = ? AND gmt_create >= ? AND gmt_create < ?", true, false, start, end)
+	query = withAccess(query, "access", access)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("gmt_create DESC").
+		Offset(offset).Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+// GetPostFingerprints 获取已计算内容指纹的未删除文章，仅查询 ID、标题与指纹
+func GetPostFingerprints() ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Select("id", "title", "fingerprint").
+		Where("fingerprint <> ? AND deleted = ?", 0, false).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetPostsWithoutFingerprint 获取尚未计算内容指纹的未删除文章
+func GetPostsWithoutFingerprint() ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Select("id", "title", "content_markdown").
+		Where("fingerprint = ? AND deleted = ?", 0, false).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// UpdatePostFingerprint 更新文章的内容指纹，不改变文章的修改时间
+func UpdatePostFingerprint(postID, fingerprint int64) error {
+	return global.DB.Model(&post.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn("fingerprint", fingerprint).Error
+}
+
+// GetPostsWithoutReadingStats 获取尚未计算字数的未删除文章，正文为空的文章不返回
+func GetPostsWithoutReadingStats() ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Select("id", "content_html").
+		Where("word_count = ? AND content_html <> ? AND deleted = ?", 0, "", false).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// UpdatePostReadingStats 更新文章的字数与预计阅读时间，不改变文章的修改时间
+func UpdatePostReadingStats(postID int64, wordCount, readingTime int) error {
+	return global.DB.Model(&post.Post{}).
+		Where("id = ?", postID).
+		UpdateColumns(map[string]interface{}{"word_count": wordCount, "reading_time": readingTime}).Error
+}
+
+// UpdatePostContent 更新文章的标题、封面、正文、分类、指纹与字数，零值字段同样写入，用于恢复历史修订
+func UpdatePostContent(pos *post.Post) error {
+	if err := global.DB.Model(pos).
+		Select("title", "image", "content_markdown", "content_html", "category_ids", "fingerprint", "word_count", "reading_time", "gmt_modified").
+		Updates(pos).Error; err != nil {
+		return fmt.Errorf("更新文章 %d 的内容失败: %v", pos.ID, err)
+	}
+	return nil
+}
+
+// GetPostAccessPasswords 获取密码保护文章的访问密码哈希，返回文章 ID 到密码哈希的映射
+func GetPostAccessPasswords(postIDs []int64) (map[int64]string, error) {
+	passwords := make(map[int64]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return passwords, nil
+	}
+	var posts []*post.Post
+	if err := global.DB.Select("id", "access_password").
+		Where("id IN ? AND access = ?", postIDs, post.AccessPassword).
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	for _, pos := range posts {
+		passwords[pos.ID] = pos.AccessPassword
+	}
+	return passwords, nil
+}
+
+// UpdatePostAccess 更新文章的访问权限与访问密码哈希，按结构体更新时会忽略空密码，需单独写入
+func UpdatePostAccess(postID int64, access, passwordHash string) error {
+	return global.DB.Model(&post.Post{}).
+		Where("id = ? AND deleted = ?", postID, false).
+		Updates(map[string]interface{}{"access": access, "access_password": passwordHash}).Error
+}
+
+// withAccess 按访问权限筛选文章，access 为空时不筛选；column 为访问权限的列名，联表查询时需带表名
+func withAccess(query *gorm.DB, column string, access []string) *gorm.DB {
+	if len(access) == 0 {
+		return query
+	}
+	return query.Where(column+" IN ?", access)
+}
@@ -0,0 +1,122 @@
+package mapper
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"jank.com/jank_blog/internal/global"
+	post "jank.com/jank_blog/internal/model/post"
+)
+
+// AuthorPostCount 作者署名的已发布文章数量
+type AuthorPostCount struct {
+	AccountID int64
+	Posts     int64
+}
+
+// GetPostAuthors 获取文章的署名作者，按文章 ID 与署名顺序排列
+func GetPostAuthors(postIDs []int64) ([]*post.PostAuthor, error) {
+	var authors []*post.PostAuthor
+	if len(postIDs) == 0 {
+		return authors, nil
+	}
+	err := global.DB.Where("post_id IN ?", postIDs).
+		Order("post_id ASC, position ASC, id ASC").
+		Find(&authors).Error
+	if err != nil {
+		return nil, fmt.Errorf("获取文章作者失败: %v", err)
+	}
+	return authors, nil
+}
+
+// GetLeadAuthorIDs 获取文章署名顺序最靠前的作者（不含编辑），返回文章 ID 到账户 ID 的映射，没有作者的文章不在其中
+func GetLeadAuthorIDs(postIDs []int64) (map[int64]int64, error) {
+	authors, err := GetPostAuthors(postIDs)
+	if err != nil {
+		return nil, err
+	}
+	leads := make(map[int64]int64, len(postIDs))
+	for _, author := range authors {
+		if _, ok := leads[author.PostID]; !ok && author.Role == post.AuthorRoleAuthor {
+			leads[author.PostID] = author.AccountID
+		}
+	}
+	return leads, nil
+}
+
+// AddPostAuthors 添加文章作者，账户已是该文章的作者时跳过
+func AddPostAuthors(authors []*post.PostAuthor) error {
+	if len(authors) == 0 {
+		return nil
+	}
+	return global.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}, {Name: "account_id"}},
+		DoNothing: true,
+	}).Create(&authors).Error
+}
+
+// ReplacePostAuthors 在同一事务中以 authors 替换文章的全部作者
+func ReplacePostAuthors(postID int64, authors []*post.PostAuthor) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("post_id = ?", postID).Delete(&post.PostAuthor{}).Error; err != nil {
+			return err
+		}
+		if len(authors) == 0 {
+			return nil
+		}
+		return tx.Create(&authors).Error
+	})
+}
+
+// GetPostIDsWithoutAuthors 获取没有署名作者的未删除文章 ID
+func GetPostIDsWithoutAuthors() ([]int64, error) {
+	var ids []int64
+	err := global.DB.Model(&post.Post{}).
+		Where("deleted = ? AND NOT EXISTS (?)", false,
+			global.DB.Model(&post.PostAuthor{}).Select("1").Where("post_authors.post_id = posts.id")).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetAuthorPublishedPostsWithPaging 获取账户署名的已发布文章分页列表和文章总数，署名为作者或编辑均包含在内，按创建时间倒序排列，
+// access 为空时不按访问权限筛选
+func GetAuthorPublishedPostsWithPaging(accountID int64, access []string, offset, limit int) ([]*post.Post, int64, error) {
+	var posts []*post.Post
+	var total int64
+
+	query := global.DB.Model(&post.Post{}).
+		Where("status = ? AND deleted = ? AND id IN (?)", post.StatusPublished, false,
+			global.DB.Model(&post.PostAuthor{}).Select("post_id").Where("account_id = ?", accountID))
+	query = withAccess(query, "access", access)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("gmt_create DESC").
+		Offset(offset).Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+// GetAuthorPostCounts 获取署名过已发布文章的账户及其文章数量，accountIDs 为空时返回全部作者，按文章数量降序排列
+func GetAuthorPostCounts(accountIDs []int64) ([]AuthorPostCount, error) {
+	var counts []AuthorPostCount
+	query := global.DB.Model(&post.PostAuthor{}).
+		Select("post_authors.account_id AS account_id, COUNT(*) AS posts").
+		Joins("JOIN posts ON posts.id = post_authors.post_id").
+		Where("posts.status = ? AND posts.deleted = ?", post.StatusPublished, false)
+	if len(accountIDs) > 0 {
+		query = query.Where("post_authors.account_id IN ?", accountIDs)
+	}
+	err := query.Group("post_authors.account_id").
+		Order("posts DESC, post_authors.account_id ASC").
+		Scan(&counts).Error
+	return counts, err
+}
@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"jank.com/jank_blog/internal/global"
+	post "jank.com/jank_blog/internal/model/post"
+)
+
+// SetPostPinned 设置文章的置顶状态与置顶顺序，取消置顶时顺序清零，返回文章是否存在
+func SetPostPinned(postID int64, pinned bool, order int) (bool, error) {
+	if !pinned {
+		order = 0
+	}
+	result := global.DB.Model(&post.Post{}).
+		Where("id = ? AND deleted = ?", postID, false).
+		Updates(map[string]interface{}{"pinned": pinned, "pin_order": order})
+	return result.RowsAffected > 0, result.Error
+}
+
+// SetPostFeatured 设置文章的精选状态与精选顺序，取消精选时顺序清零，返回文章是否存在
+func SetPostFeatured(postID int64, featured bool, order int) (bool, error) {
+	if !featured {
+		order = 0
+	}
+	result := global.DB.Model(&post.Post{}).
+		Where("id = ? AND deleted = ?", postID, false).
+		Updates(map[string]interface{}{"featured": featured, "feature_order": order})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetFeaturedPosts 获取已发布的精选文章，按精选顺序排列，顺序相同时新文章在前，access 为空时不按访问权限筛选
+func GetFeaturedPosts(limit int, access []string) ([]*post.Post, error) {
+	var posts []*post.Post
+	err := withAccess(global.DB.Where("featured = ? AND status = ? AND deleted = ?", true, post.StatusPublished, false), "access", access).
+		Order("feature_order ASC, gmt_create DESC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
@@ -0,0 +1,95 @@
+package mapper
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	post "jank.com/jank_blog/internal/model/post"
+	review "jank.com/jank_blog/internal/model/review"
+)
+
+// ErrReviewStatusChanged 文章不存在或审核状态已被其他操作变更
+var ErrReviewStatusChanged = errors.New("文章不存在或审核状态已变更")
+
+// TransitPostReviewStatus 在同一事务中变更文章审核状态并写入审核记录
+// 仅当文章当前状态为 record.FromStatus 时才会变更，否则返回 ErrReviewStatusChanged
+func TransitPostReviewStatus(record *review.PostReview, updates map[string]interface{}) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		values := map[string]interface{}{
+			"review_status": record.ToStatus,
+			"gmt_modified":  time.Now().Unix(),
+		}
+		for key, value := range updates {
+			values[key] = value
+		}
+
+		result := tx.Model(&post.Post{}).
+			Where("id = ? AND deleted = ? AND review_status = ?", record.PostID, false, record.FromStatus).
+			Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReviewStatusChanged
+		}
+		return tx.Create(record).Error
+	})
+}
+
+// GetPostsByReviewStatusWithPaging 获取指定审核状态的文章分页列表，按最近变更时间正序排序，等待最久的排在前面
+func GetPostsByReviewStatusWithPaging(status string, offset, limit int) ([]*post.Post, int64, error) {
+	var posts []*post.Post
+	var total int64
+
+	query := global.DB.Model(&post.Post{}).Where("review_status = ? AND deleted = ?", status, false)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("gmt_modified ASC").
+		Offset(offset).Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+// GetDueScheduledPosts 获取定时发布时间已到的文章
+func GetDueScheduledPosts(now int64) ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Where("review_status = ? AND publish_at <= ? AND deleted = ?", post.ReviewStatusScheduled, now, false).
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// GetPostReviewsByPostID 获取文章的审核记录，按时间正序排序
+func GetPostReviewsByPostID(postID int64) ([]*review.PostReview, error) {
+	var records []*review.PostReview
+	err := global.DB.Where("post_id = ? AND deleted = ?", postID, false).
+		Order("id ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// GetPostsSubmittedByAccount 获取账户提交过审核的文章，按 ID 升序排列
+func GetPostsSubmittedByAccount(accountID int64) ([]*post.Post, error) {
+	var posts []*post.Post
+	submitted := global.DB.Model(&review.PostReview{}).
+		Select("post_id").
+		Where("account_id = ? AND action = ? AND deleted = ?", accountID, review.ActionSubmit, false)
+	if err := global.DB.Where("id IN (?) AND deleted = ?", submitted, false).Order("id ASC").Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
@@ -0,0 +1,134 @@
+package mapper
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	revision "jank.com/jank_blog/internal/model/revision"
+)
+
+// revisionSummaryColumns 修订列表查询的字段，不含正文
+var revisionSummaryColumns = []string{"id", "post_id", "version", "account_id", "action", "restored_from", "title", "gmt_create"}
+
+// CreatePostRevision 写入文章修订记录，版本号为该文章已有的最大版本号加一
+func CreatePostRevision(rev *revision.PostRevision) error {
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		var latest int
+		if err := tx.Model(&revision.PostRevision{}).
+			Where("post_id = ?", rev.PostID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+		rev.Version = latest + 1
+		return tx.Create(rev).Error
+	})
+	if err != nil {
+		return fmt.Errorf("写入文章 %d 的修订记录失败: %v", rev.PostID, err)
+	}
+	return nil
+}
+
+// GetLatestPostRevision 获取文章的最新修订，没有修订时返回 nil
+func GetLatestPostRevision(postID int64) (*revision.PostRevision, error) {
+	var revs []*revision.PostRevision
+	if err := global.DB.Where("post_id = ? AND deleted = ?", postID, false).
+		Order("version DESC").Limit(1).
+		Find(&revs).Error; err != nil {
+		return nil, fmt.Errorf("获取文章 %d 的最新修订失败: %v", postID, err)
+	}
+	if len(revs) == 0 {
+		return nil, nil
+	}
+	return revs[0], nil
+}
+
+// GetPostRevisionsWithPaging 获取文章的修订分页列表，按版本号倒序排列，不含正文
+func GetPostRevisionsWithPaging(postID int64, offset, limit int) ([]*revision.PostRevision, int64, error) {
+	var revs []*revision.PostRevision
+	var total int64
+
+	query := global.DB.Model(&revision.PostRevision{}).Where("post_id = ? AND deleted = ?", postID, false)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取文章 %d 的修订数失败: %v", postID, err)
+	}
+	if err := query.Select(revisionSummaryColumns).
+		Order("version DESC").
+		Offset(offset).Limit(limit).
+		Find(&revs).Error; err != nil {
+		return nil, 0, fmt.Errorf("获取文章 %d 的修订列表失败: %v", postID, err)
+	}
+	return revs, total, nil
+}
+
+// GetPostRevisionByVersion 根据版本号获取文章的修订
+func GetPostRevisionByVersion(postID int64, version int) (*revision.PostRevision, error) {
+	var rev revision.PostRevision
+	if err := global.DB.Where("post_id = ? AND version = ? AND deleted = ?", postID, version, false).
+		First(&rev).Error; err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// PrunePostRevisions 永久删除文章最新 keep 个修订之外的修订，返回删除的数量
+func PrunePostRevisions(postID int64, keep int) (int64, error) {
+	var versions []int
+	if err := global.DB.Model(&revision.PostRevision{}).
+		Where("post_id = ?", postID).
+		Order("version DESC").Offset(keep).Limit(1).
+		Pluck("version", &versions).Error; err != nil {
+		return 0, fmt.Errorf("获取文章 %d 需清理的修订失败: %v", postID, err)
+	}
+	if len(versions) == 0 {
+		return 0, nil
+	}
+
+	result := global.DB.Where("post_id = ? AND version <= ?", postID, versions[0]).Delete(&revision.PostRevision{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("清理文章 %d 的修订失败: %v", postID, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// PurgePostRevisions 永久删除在 before 之前保存的修订，每篇文章的最新修订始终保留，返回删除的数量
+func PurgePostRevisions(before int64) (int64, error) {
+	// MySQL 不允许在删除语句的子查询中直接查询同一张表，需再包一层派生表
+	latest := global.DB.Table("(?) AS latest",
+		global.DB.Model(&revision.PostRevision{}).Select("MAX(id) AS id").Group("post_id")).
+		Select("id")
+	result := global.DB.Where("gmt_create < ? AND id NOT IN (?)", before, latest).Delete(&revision.PostRevision{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("清理超期的文章修订失败: %v", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetPostAuthorIDs 获取文章最早一条修订的保存人 ID 作为作者，返回文章 ID 到账户 ID 的映射，没有修订或保存人未知的文章不在其中
+func GetPostAuthorIDs(postIDs []int64) (map[int64]int64, error) {
+	authors := make(map[int64]int64, len(postIDs))
+	if len(postIDs) == 0 {
+		return authors, nil
+	}
+	var rows []struct {
+		PostID    int64
+		AccountID int64
+	}
+	first := global.DB.Model(&revision.PostRevision{}).
+		Select("post_id, MIN(version) AS version").
+		Where("post_id IN ? AND deleted = ?", postIDs, false).
+		Group("post_id")
+	if err := global.DB.Table("(?) AS f", first).
+		Select("r.post_id, r.account_id").
+		Joins("JOIN post_revisions AS r ON r.post_id = f.post_id AND r.version = f.version").
+		Where("r.account_id > 0").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("获取文章作者失败: %v", err)
+	}
+	for _, row := range rows {
+		authors[row.PostID] = row.AccountID
+	}
+	return authors, nil
+}
@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"jank.com/jank_blog/internal/global"
+	post "jank.com/jank_blog/internal/model/post"
+)
+
+// GetPostBySlug 根据别名获取未删除的文章
+func GetPostBySlug(slug string) (*post.Post, error) {
+	var pos post.Post
+	err := global.DB.Where("slug = ? AND deleted = ?", slug, false).First(&pos).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pos, nil
+}
+
+// GetPostSlugHistory 根据历史别名获取别名记录
+func GetPostSlugHistory(slug string) (*post.PostSlugHistory, error) {
+	var history post.PostSlugHistory
+	err := global.DB.Where("slug = ? AND deleted = ?", slug, false).First(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// IsPostSlugTaken 判断别名是否已被其他未删除的文章使用，或是其他文章的历史别名
+func IsPostSlugTaken(slug string, postID int64) (bool, error) {
+	var count int64
+	if err := global.DB.Model(&post.Post{}).
+		Where("slug = ? AND id <> ? AND deleted = ?", slug, postID, false).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err := global.DB.Model(&post.PostSlugHistory{}).
+		Where("slug = ? AND post_id <> ?", slug, postID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdatePostSlug 将文章的别名由 oldSlug 修改为 newSlug，oldSlug 记为历史别名；
+// newSlug 是文章自己的历史别名时移除该历史记录
+func UpdatePostSlug(postID int64, oldSlug, newSlug string) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&post.Post{}).Where("id = ?", postID).Update("slug", newSlug).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("post_id = ? AND slug = ?", postID, newSlug).Delete(&post.PostSlugHistory{}).Error; err != nil {
+			return err
+		}
+		if oldSlug == "" {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&post.PostSlugHistory{PostID: postID, Slug: oldSlug}).Error
+	})
+}
+
+// GetPostsWithoutSlug 获取尚未生成别名的未删除文章
+func GetPostsWithoutSlug() ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Select("id", "title", "ext").
+		Where("slug = ? AND deleted = ?", "", false).
+		Order("id ASC").
+		Find(&posts).Error
+	return posts, err
+}
@@ -0,0 +1,110 @@
+package mapper
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	post "jank.com/jank_blog/internal/model/post"
+)
+
+// GetPostTranslations 获取翻译组内未删除的文章，按文章 ID 升序排列，不含正文
+func GetPostTranslations(groupID int64) ([]*post.Post, error) {
+	var posts []*post.Post
+	err := global.DB.Model(&post.Post{}).
+		Select("id", "title", "slug", "status", "access", "language", "translation_group_id").
+		Where("(translation_group_id = ? OR id = ?) AND deleted = ?", groupID, groupID, false).
+		Order("id ASC").
+		Find(&posts).Error
+	if err != nil {
+		return nil, fmt.Errorf("获取文章翻译失败: %v", err)
+	}
+	return posts, nil
+}
+
+// LinkPostTranslation 将文章加入 groupID 所在的翻译组，组内原文的翻译组 ID 同时写入
+func LinkPostTranslation(postID, groupID int64) error {
+	return global.DB.Model(&post.Post{}).
+		Where("id IN ?", []int64{postID, groupID}).
+		Update("translation_group_id", groupID).Error
+}
+
+// UnlinkPostTranslation 在同一事务中将文章移出翻译组；组内只剩一篇文章时解散翻译组，
+// 移出的是原文时以组内 ID 最小的文章作为新的原文
+func UnlinkPostTranslation(postID, groupID int64) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&post.Post{}).Where("id = ?", postID).Update("translation_group_id", 0).Error; err != nil {
+			return err
+		}
+
+		var remaining []int64
+		err := tx.Model(&post.Post{}).
+			Where("(translation_group_id = ? OR id = ?) AND id <> ? AND deleted = ?", groupID, groupID, postID, false).
+			Order("id ASC").
+			Pluck("id", &remaining).Error
+		if err != nil {
+			return err
+		}
+
+		newGroupID := groupID
+		switch {
+		case len(remaining) <= 1:
+			newGroupID = 0
+		case postID == groupID:
+			newGroupID = remaining[0]
+		}
+		if len(remaining) == 0 || newGroupID == groupID {
+			return nil
+		}
+		return tx.Model(&post.Post{}).Where("id IN ?", remaining).Update("translation_group_id", newGroupID).Error
+	})
+}
+
+// GetPublishedPostLanguages 获取已发布文章使用的语言，access 为空时不按访问权限筛选
+func GetPublishedPostLanguages(access []string) ([]string, error) {
+	var languages []string
+	query := global.DB.Model(&post.Post{}).
+		Where("status = ? AND deleted = ?", post.StatusPublished, false)
+	err := withAccess(query, "access", access).
+		Distinct("language").
+		Order("language ASC").
+		Pluck("language", &languages).Error
+	return languages, err
+}
+
+// GetPublishedPostsByLanguageWithPaging 获取指定语言的已发布文章分页列表和文章总数，按创建时间倒序排列，用于分语言的订阅源
+func GetPublishedPostsByLanguageWithPaging(language string, access []string, offset, limit int) ([]*post.Post, int64, error) {
+	var posts []*post.Post
+	var total int64
+
+	query := global.DB.Model(&post.Post{}).
+		Where("status = ? AND deleted = ? AND language = ?", post.StatusPublished, false, language)
+	query = withAccess(query, "access", access)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("gmt_create DESC").
+		Offset(offset).Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return posts, total, nil
+}
+
+// withLanguage 按语言合并翻译组，每个翻译组只保留 language 对应的版本，组内没有该语言的可见版本时保留原文；
+// 没有关联翻译的文章不受影响，language 为空时不筛选，access 为组内其他版本可见的访问权限
+func withLanguage(query *gorm.DB, language string, access []string) *gorm.DB {
+	if language == "" {
+		return query
+	}
+	sibling := global.DB.Table("posts AS siblings").Select("1").
+		Where("siblings.translation_group_id = posts.translation_group_id AND siblings.language = ? AND siblings.status = ? AND siblings.deleted = ?",
+			language, post.StatusPublished, false)
+	sibling = withAccess(sibling, "siblings.access", access)
+	return query.Where("posts.translation_group_id = 0 OR posts.language = ? OR (posts.id = posts.translation_group_id AND NOT EXISTS (?))",
+		language, sibling)
+}
@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"jank.com/jank_blog/internal/global"
+	post "jank.com/jank_blog/internal/model/post"
+)
+
+// PostViewCount 文章在统计区间内的浏览量
+type PostViewCount struct {
+	PostID int64
+	Views  int64
+}
+
+// AddPostViews 在同一事务中累加文章的累计浏览量与每日浏览量，views 为日期（格式为 20060102）到各文章浏览量的映射
+func AddPostViews(views map[int]map[int64]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		for day, counts := range views {
+			for postID, n := range counts {
+				if err := tx.Model(&post.Post{}).Where("id = ?", postID).
+					UpdateColumn("view_count", gorm.Expr("view_count + ?", n)).Error; err != nil {
+					return err
+				}
+				if err := tx.Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "post_id"}, {Name: "day"}},
+					DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("post_daily_views.views + ?", n)}),
+				}).Create(&post.PostDailyView{PostID: postID, Day: day, Views: n}).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// GetTrendingPostViews 获取自 since 当日起浏览量最高的已发布文章，since 格式为 20060102，按浏览量降序排列，access 为空时不按访问权限筛选
+func GetTrendingPostViews(since, limit int, access []string) ([]PostViewCount, error) {
+	var counts []PostViewCount
+	query := global.DB.Model(&post.PostDailyView{}).
+		Select("post_daily_views.post_id AS post_id, SUM(post_daily_views.views) AS views").
+		Joins("JOIN posts ON posts.id = post_daily_views.post_id").
+		Where("post_daily_views.day >= ? AND posts.status = ? AND posts.deleted = ?", since, post.StatusPublished, false)
+	err := withAccess(query, "posts.access", access).
+		Group("post_daily_views.post_id").
+		Order("views DESC, post_daily_views.post_id DESC").
+		Limit(limit).
+		Scan(&counts).Error
+	return counts, err
+}
@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	reaction "jank.com/jank_blog/internal/model/reaction"
+)
+
+// ReactionCount 文章每种表态的数量
+type ReactionCount struct {
+	Reaction string
+	Count    int64
+}
+
+// SaveReaction 记录用户或访客对文章的表态，返回是否新增了表态，已表态时不做处理
+func SaveReaction(postID, accountID int64, visitorID, kind string) (bool, error) {
+	var record reaction.PostReaction
+	err := global.DB.Where("post_id = ? AND reaction = ? AND account_id = ? AND visitor_id = ?", postID, kind, accountID, visitorID).
+		First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		record = reaction.PostReaction{PostID: postID, Reaction: kind, AccountID: accountID, VisitorID: visitorID}
+		if err := global.DB.Create(&record).Error; err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !record.Deleted {
+		return false, nil
+	}
+
+	// 唯一索引包含已取消的表态，重新表态时恢复该记录；并发恢复时只有一个请求计数
+	result := global.DB.Model(&reaction.PostReaction{}).
+		Where("id = ? AND deleted = ?", record.ID, true).
+		Updates(map[string]interface{}{
+			"deleted":      false,
+			"gmt_modified": time.Now().Unix(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteReactionSoftly 取消用户或访客对文章的表态，返回是否取消了表态
+func DeleteReactionSoftly(postID, accountID int64, visitorID, kind string) (bool, error) {
+	result := global.DB.Model(&reaction.PostReaction{}).
+		Where("post_id = ? AND reaction = ? AND account_id = ? AND visitor_id = ? AND deleted = ?", postID, kind, accountID, visitorID, false).
+		Update("deleted", true)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountReactionsByPostID 按表态类型统计文章的表态数
+func CountReactionsByPostID(postID int64) ([]ReactionCount, error) {
+	var counts []ReactionCount
+	err := global.DB.Model(&reaction.PostReaction{}).
+		Select("reaction, COUNT(*) AS count").
+		Where("post_id = ? AND deleted = ?", postID, false).
+		Group("reaction").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// GetReactionsByReactor 获取用户或访客对文章的表态类型
+func GetReactionsByReactor(postID, accountID int64, visitorID string) ([]string, error) {
+	var kinds []string
+	err := global.DB.Model(&reaction.PostReaction{}).
+		Where("post_id = ? AND account_id = ? AND visitor_id = ? AND deleted = ?", postID, accountID, visitorID, false).
+		Order("id ASC").
+		Pluck("reaction", &kinds).Error
+	return kinds, err
+}
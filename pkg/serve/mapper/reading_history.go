@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	history "jank.com/jank_blog/internal/model/history"
+)
+
+// SaveReadingHistory 记录阅读历史，已存在时更新阅读位置与阅读时间
+func SaveReadingHistory(accountID, postID, position, readAt int64) error {
+	var record history.ReadingHistory
+	err := global.DB.Where("account_id = ? AND post_id = ?", accountID, postID).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return global.DB.Create(&history.ReadingHistory{
+			AccountID: accountID,
+			PostID:    postID,
+			Position:  position,
+			ReadAt:    readAt,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	// 唯一索引包含已删除的记录，重新阅读时恢复该记录
+	return global.DB.Model(&record).Updates(map[string]interface{}{
+		"position": position,
+		"read_at":  readAt,
+		"deleted":  false,
+	}).Error
+}
+
+// GetReadingHistoryWithPaging 获取用户的阅读历史分页列表和记录总数，按最近阅读时间倒序排序
+func GetReadingHistoryWithPaging(accountID int64, offset, limit int) ([]*history.ReadingHistory, int64, error) {
+	var records []*history.ReadingHistory
+	var total int64
+
+	query := global.DB.Model(&history.ReadingHistory{}).Where("account_id = ? AND deleted = ?", accountID, false)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("read_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// GetReadPostIDs 获取用户读过的文章 ID
+func GetReadPostIDs(accountID int64) ([]int64, error) {
+	var ids []int64
+	err := global.DB.Model(&history.ReadingHistory{}).
+		Where("account_id = ? AND deleted = ?", accountID, false).
+		Pluck("post_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteReadingHistorySoftly 软删除用户的阅读历史，postID 为 0 时清空全部记录
+func DeleteReadingHistorySoftly(accountID, postID int64) error {
+	query := global.DB.Model(&history.ReadingHistory{}).Where("account_id = ? AND deleted = ?", accountID, false)
+	if postID > 0 {
+		query = query.Where("post_id = ?", postID)
+	}
+	return query.Update("deleted", true).Error
+}
@@ -0,0 +1,139 @@
+package mapper
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	series "jank.com/jank_blog/internal/model/series"
+)
+
+// CreateSeries 创建系列
+func CreateSeries(s *series.Series) error {
+	return global.DB.Create(s).Error
+}
+
+// GetSeriesByID 根据 ID 获取系列
+func GetSeriesByID(id int64) (*series.Series, error) {
+	var s series.Series
+	err := global.DB.Where("id = ? AND deleted = ?", id, false).First(&s).Error
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateSeries 更新系列的标题与简介
+func UpdateSeries(s *series.Series) error {
+	return global.DB.Model(s).Updates(map[string]interface{}{
+		"title":        s.Title,
+		"description":  s.Description,
+		"gmt_modified": time.Now().Unix(),
+	}).Error
+}
+
+// DeleteSeriesSoftly 删除系列，并解除系列与文章的关联，文章本身保留
+func DeleteSeriesSoftly(id int64) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&series.Series{}).Where("id = ? AND deleted = ?", id, false).Update("deleted", true).Error; err != nil {
+			return err
+		}
+		return tx.Where("series_id = ?", id).Delete(&series.SeriesPost{}).Error
+	})
+}
+
+// GetSeriesWithPaging 获取系列分页列表和系列总数，按创建时间倒序排序
+func GetSeriesWithPaging(offset, limit int) ([]*series.Series, int64, error) {
+	var records []*series.Series
+	var total int64
+
+	query := global.DB.Model(&series.Series{}).Where("deleted = ?", false)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("gmt_create DESC").
+		Offset(offset).Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+// GetSeriesPosts 获取系列中的文章关联，按顺序排列，包含已删除文章的关联
+func GetSeriesPosts(seriesID int64) ([]*series.SeriesPost, error) {
+	var records []*series.SeriesPost
+	err := global.DB.Where("series_id = ?", seriesID).Order("position ASC").Find(&records).Error
+	return records, err
+}
+
+// GetSeriesPostByPostID 获取文章所属系列的关联，文章不属于任何系列时返回 gorm.ErrRecordNotFound
+func GetSeriesPostByPostID(postID int64) (*series.SeriesPost, error) {
+	var record series.SeriesPost
+	err := global.DB.Where("post_id = ?", postID).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// AddSeriesPost 将文章加入系列的 position 位置，其后的文章依次后移；position 小于 1 或超出末尾时加到末尾
+func AddSeriesPost(seriesID, postID int64, position int) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		// 永久删除文章时不会重新编号，编号可能不连续，以最大编号作为末尾
+		var last int
+		if err := tx.Model(&series.SeriesPost{}).Where("series_id = ?", seriesID).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		if position < 1 || position > last {
+			position = last + 1
+		}
+
+		if err := tx.Model(&series.SeriesPost{}).
+			Where("series_id = ? AND position >= ?", seriesID, position).
+			Update("position", gorm.Expr("position + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Create(&series.SeriesPost{SeriesID: seriesID, PostID: postID, Position: position}).Error
+	})
+}
+
+// RemoveSeriesPost 将文章移出系列，其后的文章依次前移，返回文章是否在系列中
+func RemoveSeriesPost(seriesID, postID int64) (bool, error) {
+	removed := false
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		var record series.SeriesPost
+		result := tx.Where("series_id = ? AND post_id = ?", seriesID, postID).Limit(1).Find(&record)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if err := tx.Delete(&record).Error; err != nil {
+			return err
+		}
+		removed = true
+		return tx.Model(&series.SeriesPost{}).
+			Where("series_id = ? AND position > ?", seriesID, record.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+	return removed, err
+}
+
+// ReorderSeriesPosts 按 postIDs 的顺序重新编号系列中的文章，postIDs 需包含系列中的全部文章
+func ReorderSeriesPosts(seriesID int64, postIDs []int64) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Unix()
+		for i, postID := range postIDs {
+			if err := tx.Model(&series.SeriesPost{}).
+				Where("series_id = ? AND post_id = ?", seriesID, postID).
+				Updates(map[string]interface{}{"position": i + 1, "gmt_modified": now}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
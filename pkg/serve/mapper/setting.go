@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"jank.com/jank_blog/internal/global"
+	setting "jank.com/jank_blog/internal/model/setting"
+)
+
+// GetAllSettings 获取全部运行时配置项
+func GetAllSettings() ([]*setting.Setting, error) {
+	var settings []*setting.Setting
+	if err := global.DB.Where("deleted = ?", false).Find(&settings).Error; err != nil {
+		return nil, fmt.Errorf("获取运行时配置失败: %v", err)
+	}
+	return settings, nil
+}
+
+// UpsertSettings 在同一事务中写入运行时配置项，配置项已存在时更新配置值
+func UpsertSettings(values map[string]string) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		for key, value := range values {
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "key"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"value":        value,
+					"deleted":      false,
+					"gmt_modified": time.Now().Unix(),
+				}),
+			}).Create(&setting.Setting{Key: key, Value: value}).Error
+			if err != nil {
+				return fmt.Errorf("写入运行时配置失败: %v", err)
+			}
+		}
+		return nil
+	})
+}
+
+// SettingExists 运行时配置项是否存在，key 是部分数据库的保留字，以 map 条件查询使列名被转义
+func SettingExists(key string) (bool, error) {
+	var count int64
+	if err := global.DB.Model(&setting.Setting{}).Where(map[string]interface{}{"key": key, "deleted": false}).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("获取运行时配置失败: %v", err)
+	}
+	return count > 0, nil
+}
+
+// CreateSettingIfAbsent 配置项不存在时写入，返回是否写入；依赖唯一索引，多个实例并发写入时只有一个成功
+func CreateSettingIfAbsent(key, value string) (bool, error) {
+	result := global.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&setting.Setting{Key: key, Value: value})
+	if result.Error != nil {
+		return false, fmt.Errorf("写入运行时配置失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteSetting 永久删除运行时配置项
+func DeleteSetting(key string) error {
+	if err := global.DB.Where(map[string]interface{}{"key": key}).Delete(&setting.Setting{}).Error; err != nil {
+		return fmt.Errorf("删除运行时配置失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package mapper
+
+import (
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	shortlink "jank.com/jank_blog/internal/model/shortlink"
+)
+
+// GetShortLinkByPostID 根据文章 ID 获取短链接
+func GetShortLinkByPostID(postID int64) (*shortlink.ShortLink, error) {
+	var link shortlink.ShortLink
+	err := global.DB.Where("post_id = ? AND deleted = ?", postID, false).First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetShortLinkByCode 根据短码获取短链接
+func GetShortLinkByCode(code string) (*shortlink.ShortLink, error) {
+	var link shortlink.ShortLink
+	err := global.DB.Where("code = ? AND deleted = ?", code, false).First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// CountShortLinksByCode 统计使用该短码的短链接数量，包含已删除的记录
+func CountShortLinksByCode(code string) (int64, error) {
+	var count int64
+	err := global.DB.Model(&shortlink.ShortLink{}).Where("code = ?", code).Count(&count).Error
+	return count, err
+}
+
+// CreateShortLink 创建短链接
+func CreateShortLink(link *shortlink.ShortLink) error {
+	return global.DB.Create(link).Error
+}
+
+// RecordShortLinkClick 累加短链接点击次数并写入点击记录
+func RecordShortLinkClick(click *shortlink.ShortLinkClick) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&shortlink.ShortLink{}).Where("id = ?", click.ShortLinkID).
+			UpdateColumn("clicks", gorm.Expr("clicks + ?", 1)).Error; err != nil {
+			return err
+		}
+		return tx.Create(click).Error
+	})
+}
+
+// ShortLinkRefererCount 短链接来源统计
+type ShortLinkRefererCount struct {
+	Referer string
+	Count   int64
+}
+
+// GetShortLinkTopReferers 获取短链接点击量最高的来源页面
+func GetShortLinkTopReferers(shortLinkID int64, limit int) ([]*ShortLinkRefererCount, error) {
+	var referers []*ShortLinkRefererCount
+	err := global.DB.Model(&shortlink.ShortLinkClick{}).
+		Select("referer, COUNT(*) AS count").
+		Where("short_link_id = ? AND deleted = ?", shortLinkID, false).
+		Group("referer").
+		Order("count DESC").
+		Limit(limit).
+		Scan(&referers).Error
+	if err != nil {
+		return nil, err
+	}
+	return referers, nil
+}
@@ -0,0 +1,147 @@
+package mapper
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	bookmark "jank.com/jank_blog/internal/model/bookmark"
+	comment "jank.com/jank_blog/internal/model/comment"
+	history "jank.com/jank_blog/internal/model/history"
+	post "jank.com/jank_blog/internal/model/post"
+	reaction "jank.com/jank_blog/internal/model/reaction"
+	review "jank.com/jank_blog/internal/model/review"
+	revision "jank.com/jank_blog/internal/model/revision"
+	series "jank.com/jank_blog/internal/model/series"
+	shortlink "jank.com/jank_blog/internal/model/shortlink"
+)
+
+// PurgeDeletedPosts 永久删除在 before 之前删除的文章，以及文章的评论、收藏、表态、阅读记录、审核记录、修订记录、历史别名、每日浏览量、作者署名、系列关联与短链接，返回被删除的文章 ID
+func PurgeDeletedPosts(before int64) ([]int64, error) {
+	var ids []int64
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&post.Post{}).
+			Where("deleted = ? AND gmt_modified < ?", true, before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		var linkIDs []int64
+		if err := tx.Model(&shortlink.ShortLink{}).Where("post_id IN ?", ids).Pluck("id", &linkIDs).Error; err != nil {
+			return err
+		}
+		if len(linkIDs) > 0 {
+			if err := tx.Where("short_link_id IN ?", linkIDs).Delete(&shortlink.ShortLinkClick{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, m := range []interface{}{&shortlink.ShortLink{}, &comment.Comment{}, &bookmark.Bookmark{}, &reaction.PostReaction{}, &history.ReadingHistory{}, &review.PostReview{}, &revision.PostRevision{}, &post.PostSlugHistory{}, &post.PostDailyView{}, &post.PostAuthor{}, &series.SeriesPost{}} {
+			if err := tx.Where("post_id IN ?", ids).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id IN ?", ids).Delete(&post.Post{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// PurgeDeletedComments 永久删除在 before 之前删除的评论，返回被删除的评论 ID
+func PurgeDeletedComments(before int64) ([]int64, error) {
+	var ids []int64
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&comment.Comment{}).
+			Where("deleted = ? AND gmt_modified < ?", true, before).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Where("id IN ?", ids).Delete(&comment.Comment{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetDeletedPostsWithPaging 分页获取回收站中的文章，按删除时间倒序排序，不查询正文
+func GetDeletedPostsWithPaging(offset, limit int) ([]*post.Post, int64, error) {
+	var posts []*post.Post
+	var total int64
+
+	query := global.DB.Model(&post.Post{}).Where("deleted = ?", true)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Select("id", "title", "slug", "image", "status", "gmt_create", "gmt_modified").
+		Order("gmt_modified DESC").
+		Offset(offset).Limit(limit).
+		Find(&posts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+// GetDeletedPostByID 获取回收站中的文章
+func GetDeletedPostByID(postID int64) (*post.Post, error) {
+	var pos post.Post
+	if err := global.DB.Where("id = ? AND deleted = ?", postID, true).First(&pos).Error; err != nil {
+		return nil, err
+	}
+	return &pos, nil
+}
+
+// RestorePost 从回收站恢复文章，返回是否恢复；修改时间更新为恢复时间
+func RestorePost(postID int64) (bool, error) {
+	result := global.DB.Model(&post.Post{}).
+		Where("id = ? AND deleted = ?", postID, true).
+		Updates(map[string]interface{}{"deleted": false, "gmt_modified": time.Now().Unix()})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetDeletedCommentsWithPaging 分页获取回收站中的评论，按删除时间倒序排序
+func GetDeletedCommentsWithPaging(offset, limit int) ([]*comment.Comment, int64, error) {
+	var comments []*comment.Comment
+	var total int64
+
+	query := global.DB.Model(&comment.Comment{}).Where("deleted = ?", true)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("gmt_modified DESC").
+		Offset(offset).Limit(limit).
+		Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// GetDeletedCommentByID 获取回收站中的评论
+func GetDeletedCommentByID(commentID int64) (*comment.Comment, error) {
+	var com comment.Comment
+	if err := global.DB.Where("id = ? AND deleted = ?", commentID, true).First(&com).Error; err != nil {
+		return nil, err
+	}
+	return &com, nil
+}
+
+// RestoreComment 从回收站恢复评论，返回是否恢复；修改时间更新为恢复时间
+func RestoreComment(commentID int64) (bool, error) {
+	result := global.DB.Model(&comment.Comment{}).
+		Where("id = ? AND deleted = ?", commentID, true).
+		Updates(map[string]interface{}{"deleted": false, "gmt_modified": time.Now().Unix()})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,86 @@
+package mapper
+
+import (
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	verification "jank.com/jank_blog/internal/model/verification"
+)
+
+// VerificationLogFilter 验证码审计日志查询条件，零值字段不参与过滤
+type VerificationLogFilter struct {
+	Event   string
+	Channel string
+	Target  string
+	IP      string
+	Result  string
+	Since   int64 // 起始时间（秒级时间戳，含）
+	Until   int64 // 截止时间（秒级时间戳，不含）
+}
+
+// VerificationLogCount 按事件、渠道与结果分组的验证码审计日志条数
+type VerificationLogCount struct {
+	Event   string
+	Channel string
+	Result  string
+	Count   int64
+}
+
+// CreateVerificationLog 写入验证码审计日志
+func CreateVerificationLog(log *verification.VerificationLog) error {
+	return global.DB.Create(log).Error
+}
+
+// GetVerificationLogsWithPaging 按条件获取验证码审计日志分页列表，按时间倒序排序
+func GetVerificationLogsWithPaging(filter VerificationLogFilter, offset, limit int) ([]*verification.VerificationLog, int64, error) {
+	var logs []*verification.VerificationLog
+	var total int64
+
+	query := filterVerificationLogs(global.DB.Model(&verification.VerificationLog{}), filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("gmt_create DESC, id DESC").Offset(offset).Limit(limit).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// CountVerificationLogs 按事件、渠道与结果分组统计验证码审计日志
+func CountVerificationLogs(filter VerificationLogFilter) ([]*VerificationLogCount, error) {
+	var counts []*VerificationLogCount
+	err := filterVerificationLogs(global.DB.Model(&verification.VerificationLog{}), filter).
+		Select("event, channel, result, COUNT(*) AS count").
+		Group("event, channel, result").
+		Order("event, channel, result").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// PurgeVerificationLogs 永久删除指定时间之前的验证码审计日志，返回删除条数
+func PurgeVerificationLogs(before int64) (int64, error) {
+	result := global.DB.Where("gmt_create < ?", before).Delete(&verification.VerificationLog{})
+	return result.RowsAffected, result.Error
+}
+
+// filterVerificationLogs 拼接验证码审计日志的查询条件
+func filterVerificationLogs(query *gorm.DB, filter VerificationLogFilter) *gorm.DB {
+	for column, value := range map[string]string{
+		"event":   filter.Event,
+		"channel": filter.Channel,
+		"target":  filter.Target,
+		"ip":      filter.IP,
+		"result":  filter.Result,
+	} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+	if filter.Since > 0 {
+		query = query.Where("gmt_create >= ?", filter.Since)
+	}
+	if filter.Until > 0 {
+		query = query.Where("gmt_create < ?", filter.Until)
+	}
+	return query
+}
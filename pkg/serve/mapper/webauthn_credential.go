@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"fmt"
+
+	"jank.com/jank_blog/internal/global"
+	account "jank.com/jank_blog/internal/model/account"
+)
+
+// GetWebAuthnCredential 根据凭证 ID 获取通行密钥
+func GetWebAuthnCredential(credentialID string) (*account.WebAuthnCredential, error) {
+	var credential account.WebAuthnCredential
+	if err := global.DB.Where("credential_id = ? AND deleted = ?", credentialID, false).First(&credential).Error; err != nil {
+		return nil, fmt.Errorf("获取通行密钥失败: %v", err)
+	}
+	return &credential, nil
+}
+
+// GetWebAuthnCredentialsByAccountID 获取账户绑定的全部通行密钥
+func GetWebAuthnCredentialsByAccountID(accountID int64) ([]*account.WebAuthnCredential, error) {
+	var credentials []*account.WebAuthnCredential
+	if err := global.DB.Where("account_id = ? AND deleted = ?", accountID, false).Order("id ASC").Find(&credentials).Error; err != nil {
+		return nil, fmt.Errorf("获取通行密钥列表失败: %v", err)
+	}
+	return credentials, nil
+}
+
+// CreateWebAuthnCredential 创建通行密钥
+func CreateWebAuthnCredential(credential *account.WebAuthnCredential) error {
+	if err := global.DB.Create(credential).Error; err != nil {
+		return fmt.Errorf("创建通行密钥失败: %v", err)
+	}
+	return nil
+}
+
+// UpdateWebAuthnCredential 更新通行密钥
+func UpdateWebAuthnCredential(credential *account.WebAuthnCredential) error {
+	if err := global.DB.Save(credential).Error; err != nil {
+		return fmt.Errorf("更新通行密钥失败: %v", err)
+	}
+	return nil
+}
+
+// DeleteWebAuthnCredentialSoftly 删除账户的通行密钥，返回是否删除了记录
+func DeleteWebAuthnCredentialSoftly(accountID, id int64) (bool, error) {
+	result := global.DB.Model(&account.WebAuthnCredential{}).
+		Where("id = ? AND account_id = ? AND deleted = ?", id, accountID, false).
+		Update("deleted", true)
+	if result.Error != nil {
+		return false, fmt.Errorf("删除通行密钥失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -1,16 +1,16 @@
 package service
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/labstack/echo/v4"
-	"golang.org/x/crypto/bcrypt"
 
-	"jank.com/jank_blog/internal/global"
 	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/session"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/account/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
@@ -23,10 +23,8 @@ var (
 	logoutLock        sync.Mutex // 用户登出锁，保护并发用户登出操作
 )
 
-const (
-	UserCache           = "User_Cache"
-	UserCacheExpireTime = time.Hour * 2 // Access Token 有效期
-)
+// ErrRefreshTokenInvalid refresh token 无效、已过期、已使用或已吊销
+var ErrRefreshTokenInvalid = errors.New("refresh token 无效，请重新登录")
 
 // GetAccount 获取用户信息逻辑
 func GetAccount(req *dto.GetAccountRequest, c echo.Context) (*account.GetAccountVo, error) {
@@ -42,55 +40,69 @@ func GetAccount(req *dto.GetAccountRequest, c echo.Context) (*account.GetAccount
 		return nil, fmt.Errorf("获取用户信息时映射 vo 失败: %v", err)
 	}
 
-	return vo.(*account.GetAccountVo), nil
+	result := vo.(*account.GetAccountVo)
+	result.Messengers = userInfo.MessengerProviders()
+	result.Avatar = AvatarURL(userInfo)
+	result.SocialLinks = userInfo.SocialLinks()
+	return result, nil
 }
 
-// RegisterUser 用户注册逻辑
+// RegisterUser 用户注册逻辑，填写邀请码时累加邀请码的使用次数，站点开启邀请注册时必须填写有效的邀请码；
+// 站点关闭注册时返回 ErrRegistrationClosed，开启注册审核时新账户需管理员审核通过后才能登录
 func RegisterUser(req *dto.RegisterRequest, c echo.Context) (*account.RegisterAccountVo, error) {
 	registerLock.Lock()
 	defer registerLock.Unlock()
 
+	if err := CheckRegistrationOpen(c); err != nil {
+		return nil, err
+	}
 	existingUser, _ := mapper.GetAccountByEmail(req.Email)
 	if existingUser != nil {
 		utils.BizLogger(c).Errorf("「%s」邮箱已被注册", req.Email)
 		return nil, fmt.Errorf("「%s」邮箱已被注册", req.Email)
 	}
+	invite, err := CheckRegisterInvite(req.InviteCode, c)
+	if err != nil {
+		return nil, err
+	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := password.Hash(req.Password)
 	if err != nil {
-		utils.BizLogger(c).Errorf("哈希加密失败: %v", err)
-		return nil, fmt.Errorf("哈希加密失败: %v", err)
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
 	}
 
 	acc := &model.Account{
-		Email:    req.Email,
-		Password: string(hashedPassword),
-		Nickname: req.Nickname,
-		Phone:    req.Phone,
+		Email:             req.Email,
+		Password:          hashedPassword,
+		PasswordAlgorithm: password.Identify(hashedPassword),
+		Nickname:          req.Nickname,
+		Phone:             req.Phone,
 	}
+	pending := requireApproval(acc)
 
-	if err := mapper.CreateAccount(acc); err != nil {
+	if invite != nil {
+		err = mapper.CreateAccountWithInvite(acc, invite.ID, time.Now().Unix())
+	} else {
+		err = mapper.CreateAccount(acc)
+	}
+	if errors.Is(err, mapper.ErrInviteCodeUnavailable) {
+		utils.BizLogger(c).Errorf("「%s」用户注册失败，邀请码 %d 已不可用", req.Email, invite.ID)
+		return nil, ErrInviteInvalid
+	}
+	if err != nil {
 		utils.BizLogger(c).Errorf("「%s」用户注册失败: %v", req.Email, err)
 		return nil, fmt.Errorf("「%s」用户注册失败: %v", req.Email, err)
 	}
 
-	// 获取并分配默认角色，如果没有则自动创建
-	role, err := mapper.GetRoleByCode("user")
-	if err != nil {
-		defaultRole := &model.Role{
-			Code:        "user",
-			Description: "普通用户",
-		}
-		if err := mapper.CreateRole(defaultRole); err != nil {
-			utils.BizLogger(c).Errorf("创建默认角色失败: %v", err)
-			return nil, fmt.Errorf("创建默认角色失败: %v", err)
-		}
-		role = defaultRole
+	if err := assignDefaultRole(acc.ID, c); err != nil {
+		return nil, err
 	}
-
-	if err := mapper.AssignRoleToAcc(acc.ID, role.ID); err != nil {
-		utils.BizLogger(c).Errorf("给用户分配角色失败: %v", err)
-		return nil, fmt.Errorf("给用户分配角色失败: %v", err)
+	if invite != nil {
+		utils.BizLogger(c).Infof("账户 %d 使用账户 %d 创建的邀请码 %d 注册", acc.ID, invite.CreatorID, invite.ID)
+	}
+	if pending {
+		notifyPendingRegistration(acc, c)
 	}
 
 	vo, err := utils.MapModelToVO(acc, &account.RegisterAccountVo{})
@@ -99,7 +111,9 @@ func RegisterUser(req *dto.RegisterRequest, c echo.Context) (*account.RegisterAc
 		return nil, fmt.Errorf("用户注册时映射 vo 失败: %v", err)
 	}
 
-	return vo.(*account.RegisterAccountVo), nil
+	result := vo.(*account.RegisterAccountVo)
+	result.PendingApproval = pending
+	return result, nil
 }
 
 // LoginUser 登录用户逻辑
@@ -107,38 +121,80 @@ func LoginUser(req *dto.LoginRequest, c echo.Context) (*account.LoginVo, error)
 	acc, err := mapper.GetAccountByEmail(req.Email)
 	if err != nil {
 		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
+		recordLoginFailure(0, req.Email, model.LoginMethodPassword, "", "账户不存在", c)
 		return nil, fmt.Errorf("「%s」用户不存在: %v", req.Email, err)
 	}
 
+	ok, needsRehash, err := password.Verify(req.Password, acc.Password)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户密码校验失败: %v", req.Email, err)
+		return nil, fmt.Errorf("密码输入错误: %v", err)
+	}
+	if !ok {
+		utils.BizLogger(c).Errorf("「%s」用户密码输入错误", req.Email)
+		recordLoginFailure(acc.ID, acc.Email, model.LoginMethodPassword, "", "密码错误", c)
+		return nil, fmt.Errorf("密码输入错误")
+	}
+	if needsRehash {
+		rehashPassword(acc, req.Password, c)
+	}
+
+	return completeLogin(acc, model.LoginMethodPassword, c)
+}
+
+// rehashPassword 密码校验通过后按当前配置的算法与参数重新哈希并保存，失败时不影响本次登录
+func rehashPassword(acc *model.Account, plain string, c echo.Context) {
+	hashed, err := password.Hash(plain)
+	if err != nil {
+		utils.BizLogger(c).Errorf("账户 %d 重新哈希密码失败: %v", acc.ID, err)
+		return
+	}
+	from := password.Identify(acc.Password)
+	acc.Password = hashed
+	acc.PasswordAlgorithm = password.Identify(hashed)
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("账户 %d 保存重新哈希的密码失败: %v", acc.ID, err)
+		return
+	}
+	utils.BizLogger(c).Infof("账户 %d 的密码哈希已由 %s 升级为 %s", acc.ID, from, acc.PasswordAlgorithm)
+}
+
+// issueLoginTokens 为已通过身份校验的用户签发 access token 与 refresh token，并记录本次登录
+// 账户角色要求开启两步验证但尚未开启时，在返回值中标记，提示前端引导用户绑定验证器；账户处于注销冷静期时撤销注销；
+// 账户已停用时返回 AccountDisabledError，覆盖提交动态码期间被停用的情况
+func issueLoginTokens(acc *model.Account, method, secondFactor string, c echo.Context) (*account.LoginVo, error) {
+	if err := checkAccountEnabled(acc, method, c); err != nil {
+		return nil, err
+	}
 	role, err := mapper.GetRoleByAccountID(acc.ID)
 	if err != nil {
 		utils.BizLogger(c).Errorf("获取「%s」用户角色失败: %v", acc.Nickname, err)
 		return nil, fmt.Errorf("获取「%s」用户角色失败: %v", acc.Nickname, err)
 	}
+	setupRequired := false
+	if !acc.TotpEnabled {
+		if r, err := mapper.GetRoleByID(role.RoleID); err == nil {
+			setupRequired = utils.TotpRequiredForRole(r.Code)
+		}
+	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(acc.Password), []byte(req.Password))
+	state, err := session.Create(acc.ID, c.RealIP(), c.Request().UserAgent())
 	if err != nil {
-		utils.BizLogger(c).Errorf("密码输入错误: %v", err)
-		return nil, fmt.Errorf("密码输入错误: %v", err)
+		utils.BizLogger(c).Errorf("登录时创建会话失败: %v", err)
+		return nil, fmt.Errorf("登录时创建会话失败: %v", err)
 	}
 
-	accessTokenString, refreshTokenString, err := utils.GenerateJWT(acc.ID, role.ID)
+	accessTokenString, refreshTokenString, err := utils.GenerateJWT(acc.ID, role.RoleID, state.ID)
 	if err != nil {
 		utils.BizLogger(c).Errorf("token 生成失败: %v", err)
 		return nil, fmt.Errorf("token 生成失败: %v", err)
 	}
 
-	cacheKey := fmt.Sprintf("%s:%d:%d", UserCache, acc.ID, role.ID)
-
-	err = global.RedisClient.Set(context.Background(), cacheKey, accessTokenString, UserCacheExpireTime).Err()
-	if err != nil {
-		utils.BizLogger(c).Errorf("登录时设置缓存失败: %v", err)
-		return nil, fmt.Errorf("登录时设置缓存失败: %v", err)
-	}
-
 	token := &account.LoginVo{
-		AccessToken:  accessTokenString,
-		RefreshToken: refreshTokenString,
+		AccessToken:       accessTokenString,
+		RefreshToken:      refreshTokenString,
+		TotpSetupRequired: setupRequired,
+		DeletionCancelled: cancelAccountDeletion(acc, c),
 	}
 
 	vo, err := utils.MapModelToVO(token, &account.LoginVo{})
@@ -147,30 +203,146 @@ func LoginUser(req *dto.LoginRequest, c echo.Context) (*account.LoginVo, error)
 		return nil, fmt.Errorf("用户登陆时映射 vo 失败: %v", err)
 	}
 
+	recordLoginSuccess(acc, method, secondFactor, c)
+
 	return vo.(*account.LoginVo), nil
 }
 
+// assignDefaultRole 为新注册的用户分配默认角色，默认角色不存在时自动创建
+func assignDefaultRole(accountID int64, c echo.Context) error {
+	role, err := mapper.GetRoleByCode("user")
+	if err != nil {
+		defaultRole := &model.Role{
+			Code:        "user",
+			Description: "普通用户",
+		}
+		if err := mapper.CreateRole(defaultRole); err != nil {
+			utils.BizLogger(c).Errorf("创建默认角色失败: %v", err)
+			return fmt.Errorf("创建默认角色失败: %v", err)
+		}
+		role = defaultRole
+	}
+
+	if err := mapper.AssignRoleToAcc(accountID, role.ID); err != nil {
+		utils.BizLogger(c).Errorf("给用户分配角色失败: %v", err)
+		return fmt.Errorf("给用户分配角色失败: %v", err)
+	}
+	return nil
+}
+
+// setAccountRole 将用户角色设为指定的角色编码，code 为空时使用普通用户角色；
+// 角色不存在时记录日志并保留用户当前的角色，供 LDAP 与 OIDC 按目录组或声明同步角色
+func setAccountRole(acc *model.Account, code string, c echo.Context) error {
+	if code == "" {
+		code = model.RoleCodeUser
+	}
+	role, err := mapper.GetRoleByCode(code)
+	if err != nil {
+		utils.BizLogger(c).Errorf("同步角色时角色「%s」不存在: %v", code, err)
+		return nil
+	}
+
+	current, err := mapper.GetRoleByAccountID(acc.ID)
+	if err == nil {
+		if current.RoleID == role.ID {
+			return nil
+		}
+		if err := mapper.DeleteRoleFromAccSoftly(acc.ID, current.RoleID); err != nil {
+			utils.BizLogger(c).Errorf("移除账户 %d 的角色失败: %v", acc.ID, err)
+			return fmt.Errorf("移除账户 %d 的角色失败: %v", acc.ID, err)
+		}
+	}
+	if err := mapper.AssignRoleToAcc(acc.ID, role.ID); err != nil {
+		utils.BizLogger(c).Errorf("给用户分配角色失败: %v", err)
+		return fmt.Errorf("给用户分配角色失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("账户 %d 的角色同步为「%s」", acc.ID, code)
+	return nil
+}
+
 // LogoutUser 处理用户登出逻辑
 func LogoutUser(c echo.Context) error {
 	logoutLock.Lock()
 	defer logoutLock.Unlock()
 
-	accountID, roleID, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	authHeader := c.Request().Header.Get("Authorization")
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(authHeader)
 	if err != nil {
 		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
 		return fmt.Errorf("解析 access token 失败: %v", err)
 	}
 
-	cacheKey := fmt.Sprintf("%s:%d:%d", UserCache, accountID, roleID)
-	err = global.RedisClient.Del(c.Request().Context(), cacheKey).Err()
+	if _, err := session.End(accountID, utils.ParseSessionIDFromJWT(authHeader), model.SessionEndLogout); err != nil {
+		utils.BizLogger(c).Errorf("结束会话失败: %v", err)
+		return fmt.Errorf("结束会话失败: %v", err)
+	}
+
+	// 携带 refresh token 时一并吊销，避免登出后仍能刷新
+	if refreshToken := c.Request().Header.Get("Refresh_Token"); refreshToken != "" {
+		if err := utils.RevokeRefreshToken(refreshToken); err != nil {
+			utils.BizLogger(c).Errorf("%v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LogoutAllDevices 吊销当前用户在全部设备上的登录状态，此前签发的 refresh token 均不能再刷新，记住我的设备也需重新登录
+func LogoutAllDevices(c echo.Context) error {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
 	if err != nil {
-		utils.BizLogger(c).Errorf("删除 Redis 缓存失败: %v", err)
-		return fmt.Errorf("删除 Redis 缓存失败: %v", err)
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	if err := utils.RevokeAllRefreshTokens(accountID); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if _, err := session.EndAll(accountID, "", model.SessionEndLogoutAll); err != nil {
+		utils.BizLogger(c).Errorf("结束会话失败: %v", err)
+		return fmt.Errorf("结束会话失败: %v", err)
 	}
+	revokeDeviceTokens(accountID, "", c)
 
+	utils.BizLogger(c).Infof("账户 %d 已在全部设备登出", accountID)
 	return nil
 }
 
+// RefreshToken 使用 refresh token 换取新的 access token 与 refresh token，旧 refresh token 随即失效，所属会话的有效期随之顺延
+func RefreshToken(req *dto.RefreshTokenRequest, c echo.Context) (*account.LoginVo, error) {
+	tokens, err := utils.RefreshTokenLogic(req.RefreshToken)
+	if err != nil {
+		utils.BizLogger(c).Errorf("刷新 token 失败: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrRefreshTokenInvalid, err)
+	}
+
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(tokens["accessToken"])
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+	state, err := session.Get(tokens["sessionID"])
+	if err != nil || state.AccountID != accountID {
+		utils.BizLogger(c).Errorf("刷新 token 失败，会话已结束: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrRefreshTokenInvalid, session.ErrNotFound)
+	}
+	if err := session.CheckSingleActive(state); err != nil {
+		utils.BizLogger(c).Errorf("刷新 token 失败: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrRefreshTokenInvalid, err)
+	}
+	if err := session.Renew(state); err != nil {
+		utils.BizLogger(c).Errorf("刷新 token 时顺延会话失败: %v", err)
+		return nil, fmt.Errorf("刷新 token 时顺延会话失败: %v", err)
+	}
+
+	return &account.LoginVo{
+		AccessToken:  tokens["accessToken"],
+		RefreshToken: tokens["refreshToken"],
+	}, nil
+}
+
 // ResetPassword 重置密码逻辑
 func ResetPassword(req *dto.ResetPwdRequest, c echo.Context) error {
 	passwordResetLock.Lock()
@@ -189,21 +361,23 @@ func ResetPassword(req *dto.ResetPwdRequest, c echo.Context) error {
 
 	acc, err := mapper.GetAccountByAccountID(accountID)
 	if err != nil {
-		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", req.Email, err)
-		return fmt.Errorf("「%s」用户不存在: %v", req.Email, err)
+		utils.BizLogger(c).Errorf("账户 %d 不存在: %v", accountID, err)
+		return fmt.Errorf("账户 %d 不存在: %v", accountID, err)
 	}
 
-	newPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	newPassword, err := password.Hash(req.NewPassword)
 	if err != nil {
 		utils.BizLogger(c).Errorf("密码加密失败: %v", err)
 		return fmt.Errorf("密码加密失败: %v", err)
 	}
-	acc.Password = string(newPassword)
+	acc.Password = newPassword
+	acc.PasswordAlgorithm = password.Identify(newPassword)
 
 	if err := mapper.UpdateAccount(acc); err != nil {
 		utils.BizLogger(c).Errorf("密码修改失败: %v", err)
 		return fmt.Errorf("密码修改失败: %v", err)
 	}
 
+	sendSecurityNotice(acc.Email, SecurityEventPasswordChanged, "", c)
 	return nil
 }
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// defaultDeletionGraceDays 未配置时注销账户的冷静期天数
+const defaultDeletionGraceDays = 14
+
+// ErrLastAdminDeletion 站点唯一的管理员申请注销账户
+var ErrLastAdminDeletion = errors.New("站点唯一的管理员不能注销账户")
+
+// ScheduleAccountDeletion 为当前用户申请注销账户，冷静期到期后由定时任务永久删除；
+// 申请后吊销全部设备的登录状态并发送确认邮件，冷静期内重新登录即撤销注销
+func ScheduleAccountDeletion(c echo.Context) (*account.AccountDeletionVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLastAdmin(acc.ID); err != nil {
+		utils.BizLogger(c).Errorf("账户 %d 申请注销失败: %v", acc.ID, err)
+		return nil, err
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载注销账户配置失败: %v", err)
+		return nil, err
+	}
+	graceDays := config.AccountDeletionConfig.AccountDeletionGraceDays
+	if graceDays < 1 {
+		graceDays = defaultDeletionGraceDays
+	}
+
+	scheduledAt := time.Now().AddDate(0, 0, graceDays)
+	acc.DeletionScheduledAt = scheduledAt.Unix()
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("账户 %d 申请注销失败: %v", acc.ID, err)
+		return nil, err
+	}
+
+	if err := utils.RevokeAllRefreshTokens(acc.ID); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	if _, err := session.EndAll(acc.ID, "", model.SessionEndDeletion); err != nil {
+		utils.BizLogger(c).Errorf("结束会话失败: %v", err)
+		return nil, fmt.Errorf("结束会话失败: %v", err)
+	}
+	revokeDeviceTokens(acc.ID, "", c)
+	utils.BizLogger(c).Infof("账户 %d 申请注销，将于 %s 永久删除", acc.ID, scheduledAt.Format("2006-01-02 15:04:05"))
+
+	locale := i18n.FromRequest(c.Request())
+	go func() {
+		if err := sendAccountDeletionEmail(config, acc.Email, scheduledAt, graceDays, locale); err != nil {
+			global.BizLog.Errorf("账户 %d 注销确认邮件发送失败: %v", acc.ID, err)
+		}
+	}()
+
+	return &account.AccountDeletionVo{
+		ScheduledAt: acc.DeletionScheduledAt,
+		GraceDays:   graceDays,
+	}, nil
+}
+
+// checkLastAdmin 账户是站点唯一的管理员时返回 ErrLastAdminDeletion，避免注销后无人管理站点
+func checkLastAdmin(accountID int64) error {
+	accountRole, err := mapper.GetRoleByAccountID(accountID)
+	if err != nil {
+		return nil
+	}
+	role, err := mapper.GetRoleByID(accountRole.RoleID)
+	if err != nil || role.Code != model.RoleCodeAdmin {
+		return nil
+	}
+	count, err := mapper.CountAccountsByRoleCode(model.RoleCodeAdmin)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return ErrLastAdminDeletion
+	}
+	return nil
+}
+
+// cancelAccountDeletion 账户处于注销冷静期时撤销注销，返回是否撤销，失败时仅记录日志，不影响本次登录
+func cancelAccountDeletion(acc *model.Account, c echo.Context) bool {
+	if acc.DeletionScheduledAt == 0 {
+		return false
+	}
+	acc.DeletionScheduledAt = 0
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("账户 %d 撤销注销失败: %v", acc.ID, err)
+		return false
+	}
+	utils.BizLogger(c).Infof("账户 %d 在注销冷静期内重新登录，已撤销注销", acc.ID)
+	return true
+}
+
+// sendAccountDeletionEmail 发送注销账户确认邮件
+func sendAccountDeletionEmail(config *configs.Config, email string, scheduledAt time.Time, graceDays int, locale string) error {
+	msg, err := mail.Render(mail.TemplateAccountDeletion, locale, mail.AccountDeletionData{
+		SiteName:   siteName(config),
+		SiteURL:    config.SiteConfig.SiteURL,
+		DeleteDate: scheduledAt.Format("2006-01-02 15:04:05 MST"),
+		GraceDays:  graceDays,
+		Locale:     locale,
+	}, config.AppConfig.EmailTemplateDir)
+	if err != nil {
+		return fmt.Errorf("渲染注销账户确认邮件失败: %v", err)
+	}
+	return deliverEmail(config, msg, email)
+}
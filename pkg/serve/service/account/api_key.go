@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+var (
+	ErrAPIKeyScopeDenied = errors.New("仅管理员可创建 admin 权限范围的 API Key")
+	ErrAPIKeyNotFound    = errors.New("API Key 不存在")
+)
+
+// CreateAPIKey 为当前用户创建 API Key，完整的 Key 仅在返回值中出现一次
+func CreateAPIKey(req *dto.CreateAPIKeyRequest, c echo.Context) (*account.APIKeyCreatedVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	if req.Scope == model.APIKeyScopeAdmin {
+		accountRole, err := mapper.GetRoleByAccountID(acc.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("%v", err)
+			return nil, err
+		}
+		role, err := mapper.GetRoleByID(accountRole.RoleID)
+		if err != nil || role.Code != model.RoleCodeAdmin {
+			return nil, ErrAPIKeyScopeDenied
+		}
+	}
+
+	key, prefix, hash, err := utils.GenerateAPIKey()
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	apiKey := &model.APIKey{
+		AccountID: acc.ID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scope:     req.Scope,
+	}
+	if req.ExpiresInDays > 0 {
+		apiKey.ExpiresAt = time.Now().AddDate(0, 0, req.ExpiresInDays).Unix()
+	}
+	if err := mapper.CreateAPIKey(apiKey); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	utils.BizLogger(c).Infof("账户 %d 创建 %s 权限范围的 API Key %d", acc.ID, apiKey.Scope, apiKey.ID)
+	return &account.APIKeyCreatedVo{APIKeyVo: *apiKeyVo(apiKey), Key: key}, nil
+}
+
+// ListAPIKeys 获取当前用户创建的 API Key
+func ListAPIKeys(c echo.Context) ([]*account.APIKeyVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := mapper.GetAPIKeysByAccountID(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	result := make([]*account.APIKeyVo, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, apiKeyVo(key))
+	}
+	return result, nil
+}
+
+// DeleteAPIKey 删除当前用户创建的 API Key，删除后立即失效
+func DeleteAPIKey(req *dto.DeleteAPIKeyRequest, c echo.Context) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+	deleted, err := mapper.DeleteAPIKeySoftly(acc.ID, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if !deleted {
+		return ErrAPIKeyNotFound
+	}
+	utils.BizLogger(c).Infof("账户 %d 删除 API Key %d", acc.ID, req.ID)
+	return nil
+}
+
+// apiKeyVo 将 API Key 映射为 vo
+func apiKeyVo(key *model.APIKey) *account.APIKeyVo {
+	return &account.APIKeyVo{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scope:      key.Scope,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		LastUsedIP: key.LastUsedIP,
+		GmtCreate:  key.GmtCreate,
+	}
+}
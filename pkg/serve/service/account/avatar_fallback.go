@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/avatar"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+)
+
+// FallbackAvatarPath 默认头像的访问路径前缀，后接邮箱哈希
+const FallbackAvatarPath = "/avatar/"
+
+// FallbackAvatar 获取未上传头像时的默认头像，返回 PNG 编码的头像与浏览器可缓存的时长，哈希无效时返回 ErrAvatarNotFound
+func FallbackAvatar(req *dto.FallbackAvatarRequest, c echo.Context) ([]byte, time.Duration, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载头像配置失败: %v", err)
+		return nil, 0, err
+	}
+	cfg := config.AvatarConfig
+	opts := avatar.FallbackOptions{
+		Source:      cfg.AvatarFallback,
+		GravatarURL: cfg.AvatarGravatarURL,
+		CacheDir:    cfg.AvatarCacheDir,
+		CacheTTL:    time.Duration(cfg.AvatarCacheTTL) * time.Hour,
+	}
+
+	size := req.Size
+	if size == 0 {
+		size = req.S
+	}
+	data, err := avatar.Fallback(c.Request().Context(), req.Hash, size, opts)
+	if errors.Is(err, avatar.ErrInvalidHash) {
+		return nil, 0, ErrAvatarNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成默认头像失败: %v", err)
+		return nil, 0, err
+	}
+
+	maxAge := opts.CacheTTL
+	if opts.Source != avatar.FallbackGravatar || maxAge <= 0 {
+		maxAge = avatar.DefaultCacheTTL
+	}
+	return data, maxAge, nil
+}
+
+// AvatarURL 账户的头像地址，未上传头像时使用根据邮箱哈希生成的默认头像
+func AvatarURL(acc *model.Account) string {
+	if acc.Avatar != "" {
+		return acc.Avatar
+	}
+	return FallbackAvatarPath + avatar.EmailHash(acc.Email)
+}
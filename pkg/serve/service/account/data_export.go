@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/export"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// DataExportCooldownCacheKeyPrefix 申请导出个人数据的冷却期，键为前缀加账户 ID
+const DataExportCooldownCacheKeyPrefix = "DATA_EXPORT:COOLDOWN:"
+
+// defaultDataExportCooldown 未配置时同一账户两次申请导出的最小间隔
+const defaultDataExportCooldown = time.Hour
+
+var (
+	ErrDataExportCooldown    = errors.New("数据导出申请过于频繁，请稍后再试")
+	ErrDataExportLinkInvalid = errors.New("下载链接无效或已过期")
+)
+
+// dataExportOptions 导出目录、下载链接有效期与冷却期，未配置的项使用默认值
+type dataExportOptions struct {
+	dir      string
+	ttl      time.Duration
+	cooldown time.Duration
+}
+
+// currentDataExportOptions 读取配置中的导出参数
+func currentDataExportOptions(config *configs.Config) dataExportOptions {
+	cfg := config.DataExportConfig
+	opts := dataExportOptions{dir: cfg.DataExportDir, ttl: export.DefaultAccountExportTTL, cooldown: defaultDataExportCooldown}
+	if opts.dir == "" {
+		opts.dir = export.DefaultAccountExportDir
+	}
+	if cfg.DataExportLinkTTL > 0 {
+		opts.ttl = time.Duration(cfg.DataExportLinkTTL) * time.Hour
+	}
+	if cfg.DataExportCooldown > 0 {
+		opts.cooldown = time.Duration(cfg.DataExportCooldown) * time.Minute
+	}
+	return opts
+}
+
+// RequestDataExport 为当前用户申请导出个人数据，导出在后台进行，完成后将下载链接发送到账户邮箱
+func RequestDataExport(c echo.Context) (*account.DataExportVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载数据导出配置失败: %v", err)
+		return nil, err
+	}
+	opts := currentDataExportOptions(config)
+
+	cooldownKey := DataExportCooldownCacheKeyPrefix + fmt.Sprint(acc.ID)
+	fresh, err := cache.Current().SetNX(c.Request().Context(), cooldownKey, "1", opts.cooldown)
+	if err != nil {
+		utils.BizLogger(c).Errorf("写入数据导出冷却期失败: %v", err)
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrDataExportCooldown
+	}
+
+	// 下载链接指向接收本次请求的服务地址
+	downloadURL := c.Scheme() + "://" + c.Request().Host + "/api/v1/account/downloadExport"
+	locale := i18n.FromRequest(c.Request())
+	go func() {
+		if err := buildDataExport(config, opts, acc, downloadURL, locale); err != nil {
+			global.BizLog.Errorf("账户 %d 导出个人数据失败: %v", acc.ID, err)
+			// 导出失败时允许立即重新申请
+			_ = cache.Current().Del(context.Background(), cooldownKey)
+		}
+	}()
+
+	utils.BizLogger(c).Infof("账户 %d 申请导出个人数据", acc.ID)
+	return &account.DataExportVo{
+		Email:       acc.Email,
+		ExpireHours: int(opts.ttl / time.Hour),
+	}, nil
+}
+
+// buildDataExport 生成导出文件并发送下载链接邮件
+func buildDataExport(config *configs.Config, opts dataExportOptions, acc *model.Account, downloadURL, locale string) error {
+	exportID, err := randomHex()
+	if err != nil {
+		return err
+	}
+	path, err := export.AccountExportPath(opts.dir, acc.ID, exportID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(opts.dir, 0o700); err != nil {
+		return fmt.Errorf("创建导出目录失败: %v", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("创建导出文件失败: %v", err)
+	}
+	report, err := export.ExportAccount(acc.ID, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	global.BizLog.Infof("账户 %d 个人数据导出完成: %s", acc.ID, report)
+
+	token, err := utils.GenerateDownloadToken(acc.ID, exportID, opts.ttl)
+	if err != nil {
+		return fmt.Errorf("生成下载链接失败: %v", err)
+	}
+	msg, err := mail.Render(mail.TemplateDataExport, locale, mail.DataExportData{
+		SiteName:    siteName(config),
+		SiteURL:     config.SiteConfig.SiteURL,
+		DownloadURL: downloadURL + "?token=" + url.QueryEscape(token),
+		ExpireHours: int(opts.ttl / time.Hour),
+		Locale:      locale,
+	}, config.AppConfig.EmailTemplateDir)
+	if err != nil {
+		return fmt.Errorf("渲染数据导出邮件失败: %v", err)
+	}
+	return deliverEmail(config, msg, acc.Email)
+}
+
+// DataExportFile 校验下载链接并返回导出文件的路径与下载时使用的文件名
+func DataExportFile(token string, c echo.Context) (string, string, error) {
+	claims, err := utils.ParseDownloadToken(token)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return "", "", ErrDataExportLinkInvalid
+	}
+	if _, err := mapper.GetAccountByAccountID(claims.AccountID); err != nil {
+		utils.BizLogger(c).Errorf("导出文件所属的账户 %d 不存在: %v", claims.AccountID, err)
+		return "", "", ErrDataExportLinkInvalid
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载数据导出配置失败: %v", err)
+		return "", "", err
+	}
+
+	path, err := export.AccountExportPath(currentDataExportOptions(config).dir, claims.AccountID, claims.Subject)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return "", "", ErrDataExportLinkInvalid
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		utils.BizLogger(c).Errorf("账户 %d 的导出文件不存在: %v", claims.AccountID, err)
+		return "", "", ErrDataExportLinkInvalid
+	}
+	return path, "data-export-" + info.ModTime().Format("20060102") + ".zip", nil
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// 邀请码未配置时的默认值
+const (
+	defaultInviteTTLDays   = 7 // 邀请码的有效天数
+	defaultInviteUserLimit = 5 // 普通用户同时持有的可用邀请码数量上限
+	defaultInviteUserUses  = 1 // 普通用户创建的邀请码可使用的次数上限
+	inviteCodeLength       = 10
+	inviteCodeAlphabet     = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 去除易混淆的 I、O、0、1，便于口头或手动输入
+	maxInviteCodeRetries   = 5
+)
+
+var (
+	ErrInviteRequired      = errors.New("注册需要邀请码")
+	ErrInviteInvalid       = errors.New("邀请码无效、已过期或已用完")
+	ErrInviteNotFound      = errors.New("邀请码不存在")
+	ErrInviteCreateDenied  = errors.New("没有权限创建该邀请码")
+	ErrInviteLimitExceeded = errors.New("可用的邀请码数量已达上限")
+)
+
+// inviteOptions 邀请注册的配置，未配置的项使用默认值
+type inviteOptions struct {
+	required    bool
+	userEnabled bool
+	userLimit   int64
+	userMaxUses int
+	ttlDays     int
+}
+
+// currentInviteOptions 读取配置中的邀请注册参数
+func currentInviteOptions() (inviteOptions, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return inviteOptions{}, err
+	}
+	cfg := config.InviteConfig
+	opts := inviteOptions{
+		required:    cfg.InviteRequired,
+		userEnabled: cfg.InviteUserEnabled,
+		userLimit:   int64(cfg.InviteUserLimit),
+		userMaxUses: cfg.InviteUserMaxUses,
+		ttlDays:     cfg.InviteDefaultTTL,
+	}
+	if opts.userLimit < 1 {
+		opts.userLimit = defaultInviteUserLimit
+	}
+	if opts.userMaxUses < 1 {
+		opts.userMaxUses = defaultInviteUserUses
+	}
+	if opts.ttlDays < 1 {
+		opts.ttlDays = defaultInviteTTLDays
+	}
+	return opts, nil
+}
+
+// CreateInvite 为当前用户创建邀请码；管理员不受限制，普通用户需站点允许用户邀请，
+// 且使用次数与有效天数不能超过配置的上限，同时持有的可用邀请码数量也有上限
+func CreateInvite(req *dto.CreateInviteRequest, c echo.Context) (*account.InviteCodeVo, error) {
+	accountID, err := CurrentAccountID(c)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := currentInviteOptions()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载邀请注册配置失败: %v", err)
+		return nil, err
+	}
+
+	maxUses, ttlDays := req.MaxUses, req.ExpiresInDays
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if ttlDays == 0 {
+		ttlDays = opts.ttlDays
+	}
+	now := time.Now()
+	if !isAdminAccount(accountID) {
+		if !opts.userEnabled || maxUses > opts.userMaxUses || ttlDays > opts.ttlDays {
+			return nil, ErrInviteCreateDenied
+		}
+		count, err := mapper.CountUsableInviteCodesByCreator(accountID, now.Unix())
+		if err != nil {
+			utils.BizLogger(c).Errorf("%v", err)
+			return nil, err
+		}
+		if count >= opts.userLimit {
+			return nil, ErrInviteLimitExceeded
+		}
+	}
+
+	invite := &model.InviteCode{
+		CreatorID: accountID,
+		MaxUses:   maxUses,
+		ExpiresAt: now.AddDate(0, 0, ttlDays).Unix(),
+		Note:      strings.TrimSpace(req.Note),
+	}
+	for i := 0; i < maxInviteCodeRetries; i++ {
+		code, err := randomInviteCode()
+		if err != nil {
+			return nil, err
+		}
+		if count, err := mapper.CountInviteCodesByCode(code); err != nil {
+			utils.BizLogger(c).Errorf("%v", err)
+			return nil, err
+		} else if count > 0 {
+			continue
+		}
+		invite.Code = code
+		break
+	}
+	if invite.Code == "" {
+		utils.BizLogger(c).Errorf("生成邀请码失败，重试 %d 次仍与已有邀请码重复", maxInviteCodeRetries)
+		return nil, errors.New("生成邀请码失败，请重试")
+	}
+	if err := mapper.CreateInviteCode(invite); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	utils.BizLogger(c).Infof("账户 %d 创建邀请码 %d，可使用 %d 次", accountID, invite.ID, invite.MaxUses)
+	return inviteVo(invite, now.Unix()), nil
+}
+
+// ListInvites 分页获取邀请码，按创建时间倒序排列；管理员可获取全部或指定账户创建的邀请码，普通用户只能获取自己创建的邀请码
+func ListInvites(req *dto.ListInvitesRequest, page vo.PageRequest, c echo.Context) ([]*account.InviteCodeVo, *vo.PageMeta, error) {
+	accountID, err := CurrentAccountID(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	creatorID := accountID
+	if isAdminAccount(accountID) {
+		creatorID = req.CreatorID
+	}
+
+	invites, total, err := mapper.GetInviteCodesWithPaging(creatorID, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, nil, err
+	}
+
+	now := time.Now().Unix()
+	result := make([]*account.InviteCodeVo, len(invites))
+	for i, invite := range invites {
+		result[i] = inviteVo(invite, now)
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// RevokeInvite 撤销邀请码，普通用户只能撤销自己创建的邀请码，管理员可撤销任意邀请码
+func RevokeInvite(req *dto.RevokeInviteRequest, c echo.Context) error {
+	accountID, err := CurrentAccountID(c)
+	if err != nil {
+		return err
+	}
+	creatorID := accountID
+	if isAdminAccount(accountID) {
+		creatorID = 0
+	}
+
+	revoked, err := mapper.DeleteInviteCodeSoftly(creatorID, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if !revoked {
+		return ErrInviteNotFound
+	}
+	utils.BizLogger(c).Infof("账户 %d 撤销邀请码 %d", accountID, req.ID)
+	return nil
+}
+
+// CheckInvite 返回注册是否需要邀请码，以及传入的邀请码是否可用，供注册页面提前校验
+func CheckInvite(req *dto.CheckInviteRequest, c echo.Context) (*account.InviteCheckVo, error) {
+	opts, err := currentInviteOptions()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载邀请注册配置失败: %v", err)
+		return nil, err
+	}
+
+	result := &account.InviteCheckVo{Required: opts.required}
+	if code := normalizeInviteCode(req.Code); code != "" {
+		if invite, err := mapper.GetInviteCodeByCode(code); err == nil && invite.Usable(time.Now().Unix()) {
+			result.Valid = true
+			result.ExpiresAt = invite.ExpiresAt
+		}
+	}
+	return result, nil
+}
+
+// CheckRegisterInvite 校验注册时填写的邀请码，返回可用的邀请码；站点开启邀请注册且未填写时返回 ErrInviteRequired，
+// 未开启邀请注册且未填写时返回空，填写的邀请码不可用时返回 ErrInviteInvalid
+func CheckRegisterInvite(code string, c echo.Context) (*model.InviteCode, error) {
+	code = normalizeInviteCode(code)
+	if code == "" {
+		opts, err := currentInviteOptions()
+		if err != nil {
+			utils.BizLogger(c).Errorf("加载邀请注册配置失败: %v", err)
+			return nil, err
+		}
+		if opts.required {
+			return nil, ErrInviteRequired
+		}
+		return nil, nil
+	}
+
+	invite, err := mapper.GetInviteCodeByCode(code)
+	if err != nil || !invite.Usable(time.Now().Unix()) {
+		utils.BizLogger(c).Errorf("邀请码 %s 无效、已过期或已用完: %v", code, err)
+		return nil, ErrInviteInvalid
+	}
+	return invite, nil
+}
+
+// inviteRequired 站点是否开启了邀请注册，读取配置失败时按未开启处理
+func inviteRequired() bool {
+	opts, err := currentInviteOptions()
+	return err == nil && opts.required
+}
+
+// isAdminAccount 账户是否为管理员
+func isAdminAccount(accountID int64) bool {
+	codes, err := mapper.GetRoleCodesByAccountIDs([]int64{accountID})
+	return err == nil && codes[accountID] == model.RoleCodeAdmin
+}
+
+// normalizeInviteCode 去除首尾空白并转为大写，邀请码不区分大小写
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// randomInviteCode 生成随机邀请码
+func randomInviteCode() (string, error) {
+	var b strings.Builder
+	size := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for i := 0; i < inviteCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(inviteCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// inviteVo 将邀请码映射为 vo
+func inviteVo(invite *model.InviteCode, now int64) *account.InviteCodeVo {
+	return &account.InviteCodeVo{
+		ID:        invite.ID,
+		Code:      invite.Code,
+		CreatorID: invite.CreatorID,
+		MaxUses:   invite.MaxUses,
+		UsedCount: invite.UsedCount,
+		ExpiresAt: invite.ExpiresAt,
+		Note:      invite.Note,
+		Usable:    invite.Usable(now),
+		GmtCreate: invite.GmtCreate,
+	}
+}
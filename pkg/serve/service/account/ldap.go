@@ -0,0 +1,201 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/ldap"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// ldapIdentityProvider LDAP 账号在第三方账号关联表中的服务名称
+const ldapIdentityProvider = "ldap"
+
+// maxIdentitySubject 关联表中第三方账号 ID 的最大长度，超出时保存其 SHA-256 摘要
+const maxIdentitySubject = 128
+
+var (
+	ErrLdapDisabled           = errors.New("LDAP 登录未开启")
+	ErrLdapInvalidCredentials = errors.New("用户名或密码错误")
+	ErrLdapAccountUnavailable = errors.New("目录账号无法登录本站")
+	ErrLdapLoginFail          = errors.New("LDAP 登录失败")
+)
+
+// LdapLogin 使用目录账号登录，首次登录时按目录中的邮箱关联或创建本地账户，每次登录时同步昵称与角色
+func LdapLogin(req *dto.LdapLoginRequest, c echo.Context) (*account.LoginVo, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载 LDAP 配置失败: %v", err)
+		return nil, err
+	}
+	cfg := config.LdapConfig
+	if !cfg.LdapEnabled || cfg.LdapURL == "" {
+		return nil, ErrLdapDisabled
+	}
+
+	user, err := ldap.Authenticate(c.Request().Context(), ldapOptions(cfg), req.Username, req.Password)
+	if errors.Is(err, ldap.ErrInvalidCredentials) {
+		utils.BizLogger(c).Errorf("LDAP 用户「%s」校验失败", req.Username)
+		recordLoginFailure(0, req.Username, model.LoginMethodLdap, "", "用户名或密码错误", c)
+		return nil, ErrLdapInvalidCredentials
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("LDAP 用户「%s」登录失败: %v", req.Username, err)
+		return nil, fmt.Errorf("%w: %v", ErrLdapLoginFail, err)
+	}
+
+	acc, created, err := ldapAccount(user, cfg, c)
+	if err != nil {
+		return nil, err
+	}
+	if !created && cfg.LdapSyncProfile && user.Nickname != "" && user.Nickname != acc.Nickname {
+		acc.Nickname = user.Nickname
+		if err := mapper.UpdateAccount(acc); err != nil {
+			utils.BizLogger(c).Errorf("同步账户 %d 的目录昵称失败: %v", acc.ID, err)
+		}
+	}
+	if err := syncLdapRole(acc, user, cfg, created, c); err != nil {
+		return nil, err
+	}
+
+	return completeLogin(acc, model.LoginMethodLdap, c)
+}
+
+// ldapAccount 获取目录账号关联的用户，未关联时按目录中的邮箱关联已有用户，邮箱未注册且开启自动创建时创建用户，created 表示本次新建了用户
+func ldapAccount(user *ldap.User, cfg configs.LdapConfig, c echo.Context) (*model.Account, bool, error) {
+	subject := ldapIdentitySubject(user.UID)
+	if link, err := mapper.GetOAuthIdentity(ldapIdentityProvider, subject); err == nil {
+		acc, err := mapper.GetAccountByAccountID(link.AccountID)
+		return acc, false, err
+	}
+
+	if user.Email == "" {
+		utils.BizLogger(c).Errorf("LDAP 用户 %s 未填写邮箱", user.DN)
+		return nil, false, ErrLdapAccountUnavailable
+	}
+
+	created := false
+	acc, err := mapper.GetAccountByEmail(user.Email)
+	if err != nil {
+		if !cfg.LdapAutoProvision {
+			utils.BizLogger(c).Errorf("LDAP 用户 %s 的邮箱「%s」未注册，且未开启自动创建账户", user.DN, user.Email)
+			return nil, false, ErrLdapAccountUnavailable
+		}
+		if acc, err = createLdapAccount(user, c); err != nil {
+			return nil, false, err
+		}
+		created = true
+	}
+
+	if err := mapper.CreateOAuthIdentity(&model.OAuthIdentity{
+		AccountID: acc.ID,
+		Provider:  ldapIdentityProvider,
+		Subject:   subject,
+		Email:     user.Email,
+	}); err != nil {
+		utils.BizLogger(c).Errorf("关联 LDAP 账号失败: %v", err)
+		return nil, false, err
+	}
+	utils.BizLogger(c).Infof("账户 %d 关联 LDAP 账号 %s", acc.ID, user.DN)
+	return acc, created, nil
+}
+
+// createLdapAccount 使用目录账号信息创建用户，密码为随机值，登录时始终由目录校验；角色由 syncLdapRole 分配
+func createLdapAccount(user *ldap.User, c echo.Context) (*model.Account, error) {
+	registerLock.Lock()
+	defer registerLock.Unlock()
+
+	secret, err := randomHex()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := password.Hash(secret)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	nickname := user.Nickname
+	if nickname == "" {
+		nickname = user.Username
+	}
+	acc := &model.Account{
+		Email:             user.Email,
+		Password:          hashedPassword,
+		PasswordAlgorithm: password.Identify(hashedPassword),
+		Nickname:          nickname,
+	}
+	if err := mapper.CreateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户注册失败: %v", user.Email, err)
+		return nil, fmt.Errorf("「%s」用户注册失败: %v", user.Email, err)
+	}
+	return acc, nil
+}
+
+// syncLdapRole 按组与角色的对应关系更新用户角色，未配置对应关系时只为新建的用户分配默认角色
+func syncLdapRole(acc *model.Account, user *ldap.User, cfg configs.LdapConfig, created bool, c echo.Context) error {
+	if len(cfg.LdapGroupRoles) == 0 {
+		if created {
+			return assignDefaultRole(acc.ID, c)
+		}
+		return nil
+	}
+
+	code := cfg.LdapDefaultRole
+	for _, mapping := range cfg.LdapGroupRoles {
+		if user.InGroup(mapping.Group) {
+			code = mapping.Role
+			break
+		}
+	}
+	if err := setAccountRole(acc, code, c); err != nil {
+		return err
+	}
+	// 对应的角色不存在时新建的用户仍需有角色
+	if _, err := mapper.GetRoleByAccountID(acc.ID); err != nil && created {
+		return assignDefaultRole(acc.ID, c)
+	}
+	return nil
+}
+
+// ldapOptions 将配置转为目录服务的连接与搜索参数
+func ldapOptions(cfg configs.LdapConfig) ldap.Options {
+	return ldap.Options{
+		URL:                cfg.LdapURL,
+		StartTLS:           cfg.LdapStartTLS,
+		InsecureSkipVerify: cfg.LdapInsecureSkipVerify,
+		BindDN:             cfg.LdapBindDN,
+		BindPassword:       cfg.LdapBindPassword,
+		BaseDN:             cfg.LdapBaseDN,
+		UserFilter:         cfg.LdapUserFilter,
+		UIDAttribute:       cfg.LdapUIDAttribute,
+		EmailAttribute:     cfg.LdapEmailAttribute,
+		NicknameAttribute:  cfg.LdapNicknameAttribute,
+		GroupAttribute:     cfg.LdapGroupAttribute,
+		GroupBaseDN:        cfg.LdapGroupBaseDN,
+		GroupFilter:        cfg.LdapGroupFilter,
+		Timeout:            time.Duration(cfg.LdapTimeout) * time.Second,
+	}
+}
+
+// ldapIdentitySubject 目录账号在关联表中的 ID，DN 等超长的值保存其摘要
+func ldapIdentitySubject(uid string) string {
+	uid = strings.ToLower(uid)
+	if len(uid) <= maxIdentitySubject {
+		return uid
+	}
+	sum := sha256.Sum256([]byte(uid))
+	return hex.EncodeToString(sum[:])
+}
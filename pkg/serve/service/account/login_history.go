@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/geoip"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// maxLoginUserAgent 登录记录中 User-Agent 的最大长度
+const maxLoginUserAgent = 512
+
+// loginAttempt 一次登录尝试，请求信息在处理请求时采集，登录记录在请求结束后异步写入
+type loginAttempt struct {
+	record    model.LoginRecord
+	geoHeader string // 反向代理或 CDN 写入的地理位置
+	locale    string // 提醒邮件的语言
+	alert     bool   // 账户是否接收新设备登录提醒
+}
+
+// newLoginAttempt 采集登录请求的 IP、User-Agent 与地理位置请求头
+func newLoginAttempt(accountID int64, email, method, secondFactor string, c echo.Context) *loginAttempt {
+	userAgent := c.Request().UserAgent()
+	if len(userAgent) > maxLoginUserAgent {
+		userAgent = userAgent[:maxLoginUserAgent]
+	}
+	attempt := &loginAttempt{
+		record: model.LoginRecord{
+			AccountID:    accountID,
+			Email:        email,
+			Method:       method,
+			SecondFactor: secondFactor,
+			IP:           c.RealIP(),
+			UserAgent:    userAgent,
+			Device:       session.DeviceName(userAgent),
+		},
+		locale: i18n.FromRequest(c.Request()),
+	}
+	if config, err := configs.LoadConfig(); err == nil && config.LoginHistoryConfig.LoginHistoryGeoHeader != "" {
+		attempt.geoHeader = c.Request().Header.Get(config.LoginHistoryConfig.LoginHistoryGeoHeader)
+	}
+	return attempt
+}
+
+// recordLoginSuccess 记录一次成功的登录
+func recordLoginSuccess(acc *model.Account, method, secondFactor string, c echo.Context) {
+	attempt := newLoginAttempt(acc.ID, acc.Email, method, secondFactor, c)
+	attempt.record.Success = true
+	attempt.alert = acc.Preference(model.PreferenceLoginAlert, true)
+	attempt.save()
+}
+
+// recordLoginFailure 记录一次失败的登录，accountID 为 0 表示邮箱未注册
+func recordLoginFailure(accountID int64, email, method, secondFactor, reason string, c echo.Context) {
+	attempt := newLoginAttempt(accountID, email, method, secondFactor, c)
+	attempt.record.FailReason = reason
+	attempt.save()
+}
+
+// RecordTotpLoginFailure 记录一次动态码错误导致的登录失败
+func RecordTotpLoginFailure(acc *model.Account, ticket string, c echo.Context) {
+	recordLoginFailure(acc.ID, acc.Email, totpLoginMethod(ticket), model.SecondFactorTotp, "动态码错误", c)
+}
+
+// save 异步查询地理位置并写入登录记录，登录成功且为新设备时按账户的偏好设置发送提醒邮件，失败时仅记录日志
+func (a *loginAttempt) save() {
+	config, err := configs.LoadConfig()
+	if err != nil || !config.LoginHistoryConfig.LoginHistoryEnabled {
+		return
+	}
+	cfg := config.LoginHistoryConfig
+
+	go func() {
+		ctx := context.Background()
+		record := &a.record
+		record.Location = a.geoHeader
+		if record.Location == "" {
+			location, err := geoip.Lookup(ctx, record.IP, geoip.Options{
+				API:     cfg.LoginHistoryGeoAPI,
+				Timeout: time.Duration(cfg.LoginHistoryGeoTimeout) * time.Second,
+			})
+			if err != nil {
+				global.BizLog.Warnf("查询登录 IP %s 的地理位置失败: %v", record.IP, err)
+			}
+			record.Location = location
+		}
+
+		// 账户的第一次登录不视为新设备，避免注册后立即收到提醒
+		if record.Success {
+			total, err := mapper.CountSuccessfulLogins(record.AccountID, "")
+			if err == nil && total > 0 {
+				seen, err := mapper.CountSuccessfulLogins(record.AccountID, record.Device)
+				record.NewDevice = err == nil && seen == 0
+			}
+		}
+
+		if err := mapper.CreateLoginRecord(record); err != nil {
+			global.BizLog.Errorf("%v", err)
+			return
+		}
+		if record.NewDevice && cfg.LoginHistoryNewDeviceAlert && a.alert {
+			if err := sendLoginAlert(config, record, a.locale); err != nil {
+				global.BizLog.Errorf("账户 %d 新设备登录提醒邮件发送失败: %v", record.AccountID, err)
+			}
+		}
+	}()
+}
+
+// sendLoginAlert 发送新设备登录提醒邮件
+func sendLoginAlert(config *configs.Config, record *model.LoginRecord, locale string) error {
+	msg, err := mail.Render(mail.TemplateLoginAlert, locale, mail.LoginAlertData{
+		SiteName: siteName(config),
+		SiteURL:  config.SiteConfig.SiteURL,
+		Time:     time.Unix(record.GmtCreate, 0).Format("2006-01-02 15:04:05 MST"),
+		Device:   record.Device,
+		IP:       record.IP,
+		Location: record.Location,
+		Locale:   locale,
+	}, config.AppConfig.EmailTemplateDir)
+	if err != nil {
+		return fmt.Errorf("渲染新设备登录提醒邮件失败: %v", err)
+	}
+	return deliverEmail(config, msg, record.Email)
+}
+
+// siteName 邮件中显示的站点名称，未配置站点标题时为 Jank Blog
+func siteName(config *configs.Config) string {
+	if config.SiteConfig.SiteTitle == "" {
+		return "Jank Blog"
+	}
+	return config.SiteConfig.SiteTitle
+}
+
+// deliverEmail 发送通知邮件，开启邮件队列时写入队列，队列不可用时同步发送
+func deliverEmail(config *configs.Config, msg *mail.Message, email string) error {
+	if msg.Subject == "" {
+		msg.Subject = utils.SUBJECT
+	}
+
+	to := []string{email}
+	if config.MailQueueConfig.MailQueueEnabled {
+		if err := mail.Enqueue(context.Background(), msg, to); err == nil {
+			return nil
+		}
+	}
+	start := time.Now()
+	_, err := utils.SendHTMLEmail(msg.Subject, msg.Text, msg.HTML, to)
+	mail.ObserveDelivery(mail.DeliverySync, time.Since(start), err)
+	return err
+}
+
+// LoginHistory 分页获取当前用户的登录记录，按时间倒序排列
+func LoginHistory(page vo.PageRequest, c echo.Context) ([]*account.LoginRecordVo, *vo.PageMeta, error) {
+	accountID, err := CurrentAccountID(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records, total, err := mapper.GetLoginRecordsWithPaging(accountID, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, nil, err
+	}
+
+	result := make([]*account.LoginRecordVo, len(records))
+	for i, record := range records {
+		result[i] = loginRecordVo(record)
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// loginRecordVo 将登录记录映射为 vo
+func loginRecordVo(record *model.LoginRecord) *account.LoginRecordVo {
+	return &account.LoginRecordVo{
+		ID:           record.ID,
+		Time:         record.GmtCreate,
+		Method:       record.Method,
+		SecondFactor: record.SecondFactor,
+		Success:      record.Success,
+		FailReason:   record.FailReason,
+		IP:           record.IP,
+		Location:     record.Location,
+		Device:       record.Device,
+		UserAgent:    record.UserAgent,
+		NewDevice:    record.NewDevice,
+	}
+}
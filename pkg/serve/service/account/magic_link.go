@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// MagicLinkLogin 为通过免密登录链接校验的邮箱完成登录，账户开启两步验证时仍需提交动态码
+func MagicLinkLogin(email string, c echo.Context) (*account.LoginVo, error) {
+	acc, err := mapper.GetAccountByEmail(email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", email, err)
+		return nil, fmt.Errorf("「%s」用户不存在: %v", email, err)
+	}
+
+	utils.BizLogger(c).Infof("账户 %d 通过邮件链接登录", acc.ID)
+	return completeLogin(acc, model.LoginMethodMagicLink, c)
+}
+
+// MagicLinkRequest 生成登录链接对应的登录请求的展示信息，邮箱脱敏后返回
+func MagicLinkRequest(email, device, ip string, expiresAt int64) *account.MagicLinkRequestVo {
+	return &account.MagicLinkRequestVo{Email: maskEmail(email), Device: device, IP: ip, ExpiresAt: expiresAt}
+}
+
+// MagicLinkSent 生成已发送登录链接的返回值
+func MagicLinkSent(requestID string) *account.MagicLinkSentVo {
+	return &account.MagicLinkSentVo{RequestID: requestID}
+}
+
+// MagicLinkPoll 生成登录请求的确认结果，已确认时为对应的邮箱完成登录
+func MagicLinkPoll(email string, approved bool, c echo.Context) (*account.MagicLinkPollVo, error) {
+	if !approved {
+		return &account.MagicLinkPollVo{}, nil
+	}
+	login, err := MagicLinkLogin(email, c)
+	if err != nil {
+		return nil, err
+	}
+	return &account.MagicLinkPollVo{Approved: true, Login: login}, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// CurrentAccountID 获取当前登录用户的账户 ID
+func CurrentAccountID(c echo.Context) (int64, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return 0, err
+	}
+	return acc.ID, nil
+}
+
+// CurrentAccountEmail 获取当前登录用户的邮箱
+func CurrentAccountEmail(c echo.Context) (string, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return "", err
+	}
+	return acc.Email, nil
+}
+
+// ConfirmMessenger 为当前用户绑定即时通讯账号，调用前需已校验待绑定账号收到的验证码
+func ConfirmMessenger(req *dto.ConfirmMessengerRequest, c echo.Context) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+
+	acc.SetMessengerHandle(req.Provider, req.Handle)
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("绑定即时通讯账号失败: %v", err)
+		return fmt.Errorf("绑定即时通讯账号失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("账户 %d 绑定 %s 账号", acc.ID, req.Provider)
+	return nil
+}
+
+// UnlinkMessenger 解除当前用户绑定的即时通讯账号，未绑定时不做处理
+func UnlinkMessenger(req *dto.UnlinkMessengerRequest, c echo.Context) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+	if acc.MessengerHandle(req.Provider) == "" {
+		return nil
+	}
+
+	acc.SetMessengerHandle(req.Provider, "")
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("解除绑定即时通讯账号失败: %v", err)
+		return fmt.Errorf("解除绑定即时通讯账号失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("账户 %d 解除绑定 %s 账号", acc.ID, req.Provider)
+	return nil
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/oauth"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	OAuthStateCacheKeyPrefix = "OAUTH:STATE:"   // 发起授权时生成的 state，键为前缀加 state，值为服务名称、nonce 与 PKCE code_verifier
+	OAuthStateExpiration     = 10 * time.Minute // 完成第三方授权的有效期
+)
+
+var (
+	ErrOAuthStateInvalid     = errors.New("第三方登录状态无效，请重新登录")
+	ErrOAuthEmailUnavailable = errors.New("第三方账号未提供已验证的邮箱")
+	ErrOAuthLoginFail        = errors.New("第三方登录失败")
+)
+
+// OAuthLoginURL 生成第三方授权页地址，返回地址与 state，调用方需将 state 写入浏览器以便回调时比对
+func OAuthLoginURL(provider string, c echo.Context) (string, string, error) {
+	p, err := oauthProvider(provider, c)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomHex()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomHex()
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	key := OAuthStateCacheKeyPrefix + state
+	if err := cache.Current().Set(context.Background(), key, provider+"|"+nonce+"|"+codeVerifier, OAuthStateExpiration); err != nil {
+		utils.BizLogger(c).Errorf("第三方登录 state 写入缓存失败: %v", err)
+		return "", "", fmt.Errorf("第三方登录 state 写入缓存失败: %v", err)
+	}
+
+	return p.AuthCodeURL(state, nonce, codeVerifier, oauthRedirectURL(provider, c)), state, nil
+}
+
+// OAuthCallback 校验 state 并使用授权码获取第三方账号，登录关联的用户或按邮箱关联、创建用户后签发 token，开启两步验证的用户需再提交动态码
+func OAuthCallback(provider, code, state string, c echo.Context) (*account.LoginVo, error) {
+	key := OAuthStateCacheKeyPrefix + state
+	stored, err := cache.Current().Get(context.Background(), key)
+	if err != nil {
+		utils.BizLogger(c).Errorf("第三方登录 state 不存在或已过期: %v", err)
+		return nil, ErrOAuthStateInvalid
+	}
+	// state 只能使用一次
+	cache.Current().Del(context.Background(), key)
+	parts := strings.SplitN(stored, "|", 3)
+	storedProvider, nonce, codeVerifier := parts[0], "", ""
+	if len(parts) == 3 {
+		nonce, codeVerifier = parts[1], parts[2]
+	}
+	if storedProvider != provider {
+		utils.BizLogger(c).Errorf("第三方登录 state 与服务不匹配，发起服务: %s, 回调服务: %s", storedProvider, provider)
+		return nil, ErrOAuthStateInvalid
+	}
+
+	p, err := oauthProvider(provider, c)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := p.Exchange(c.Request().Context(), code, nonce, codeVerifier, oauthRedirectURL(provider, c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取 %s 账号信息失败: %v", provider, err)
+		return nil, fmt.Errorf("%w: %v", ErrOAuthLoginFail, err)
+	}
+
+	acc, err := oauthAccount(identity, c)
+	if err != nil {
+		return nil, err
+	}
+	if provider == oauth.ProviderOIDC {
+		if err := syncOIDCRole(acc, identity, c); err != nil {
+			return nil, err
+		}
+	}
+	return completeLogin(acc, model.LoginMethodOAuthPrefix+provider, c)
+}
+
+// oauthAccount 获取第三方账号关联的用户，未关联时按已验证的邮箱关联已有用户，邮箱未注册时创建用户；
+// GitHub、Google、Gitee 账号的邮箱未注册时，站点关闭注册返回 ErrRegistrationClosed，开启邀请注册返回 ErrInviteRequired，
+// 开启注册审核时创建的用户需管理员审核通过后才能登录
+func oauthAccount(identity *oauth.Identity, c echo.Context) (*model.Account, error) {
+	if link, err := mapper.GetOAuthIdentity(identity.Provider, identity.Subject); err == nil {
+		return mapper.GetAccountByAccountID(link.AccountID)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		utils.BizLogger(c).Errorf("%s 账号 %s 未提供已验证的邮箱", identity.Provider, identity.Subject)
+		return nil, ErrOAuthEmailUnavailable
+	}
+
+	acc, err := mapper.GetAccountByEmail(identity.Email)
+	if err != nil {
+		// 关闭注册或开启邀请注册时不通过社交账号自动创建用户，OIDC 由站点自行配置的身份提供方认证，不受限制
+		if identity.Provider != oauth.ProviderOIDC {
+			if err := CheckRegistrationOpen(c); err != nil {
+				return nil, err
+			}
+			if inviteRequired() {
+				utils.BizLogger(c).Errorf("%s 账号 %s 的邮箱未注册，站点开启了邀请注册", identity.Provider, identity.Subject)
+				return nil, ErrInviteRequired
+			}
+		}
+		if acc, err = createOAuthAccount(identity, c); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mapper.CreateOAuthIdentity(&model.OAuthIdentity{
+		AccountID: acc.ID,
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+	}); err != nil {
+		utils.BizLogger(c).Errorf("关联 %s 账号失败: %v", identity.Provider, err)
+		return nil, err
+	}
+	utils.BizLogger(c).Infof("账户 %d 关联 %s 账号 %s", acc.ID, identity.Provider, identity.Subject)
+	return acc, nil
+}
+
+// createOAuthAccount 使用第三方账号信息创建用户，密码为随机值，用户可通过重置密码设置密码；
+// 站点开启注册审核时，除 OIDC 外创建的用户标记为待审核
+func createOAuthAccount(identity *oauth.Identity, c echo.Context) (*model.Account, error) {
+	registerLock.Lock()
+	defer registerLock.Unlock()
+
+	secret, err := randomHex()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := password.Hash(secret)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	nickname := identity.Nickname
+	if nickname == "" {
+		nickname, _, _ = strings.Cut(identity.Email, "@")
+	}
+	acc := &model.Account{
+		Email:             identity.Email,
+		Password:          hashedPassword,
+		PasswordAlgorithm: password.Identify(hashedPassword),
+		Nickname:          nickname,
+		Avatar:            identity.Avatar,
+	}
+	pending := identity.Provider != oauth.ProviderOIDC && requireApproval(acc)
+	if err := mapper.CreateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户注册失败: %v", identity.Email, err)
+		return nil, fmt.Errorf("「%s」用户注册失败: %v", identity.Email, err)
+	}
+	if err := assignDefaultRole(acc.ID, c); err != nil {
+		return nil, err
+	}
+	if pending {
+		notifyPendingRegistration(acc, c)
+	}
+	return acc, nil
+}
+
+// oauthProvider 根据配置创建第三方登录服务
+func oauthProvider(provider string, c echo.Context) (oauth.Provider, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载第三方登录配置失败: %v", err)
+		return nil, err
+	}
+	p, err := oauth.New(config, provider)
+	if err != nil && !errors.Is(err, oauth.ErrDisabled) {
+		utils.BizLogger(c).Errorf("创建第三方登录服务失败: %v", err)
+	}
+	return p, err
+}
+
+// oauthRedirectURL 第三方授权后的回调地址，未配置前缀时按当前请求的地址生成
+func oauthRedirectURL(provider string, c echo.Context) string {
+	base := ""
+	if config, err := configs.LoadConfig(); err == nil {
+		base = strings.TrimRight(config.OAuthConfig.OAuthRedirectBaseURL, "/")
+	}
+	if base == "" {
+		base = c.Scheme() + "://" + c.Request().Host + "/api/v1/account/oauth"
+	}
+	return base + "/" + provider + "/callback"
+}
+
+// syncOIDCRole 按角色声明与角色的对应关系更新用户角色，未配置角色声明或对应关系时不修改
+func syncOIDCRole(acc *model.Account, identity *oauth.Identity, c echo.Context) error {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载 OIDC 配置失败: %v", err)
+		return err
+	}
+	cfg := config.OIDCConfig
+	if cfg.OIDCRolesClaim == "" || len(cfg.OIDCRoleMappings) == 0 {
+		return nil
+	}
+
+	code := cfg.OIDCDefaultRole
+	for _, mapping := range cfg.OIDCRoleMappings {
+		if slices.Contains(identity.Roles, mapping.Value) {
+			code = mapping.Role
+			break
+		}
+	}
+	return setAccountRole(acc, code, c)
+}
+
+// randomToken 生成 32 字节的随机 base64url 字符串，用作 PKCE code_verifier
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// randomHex 生成 16 字节的随机十六进制字符串
+func randomHex() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/internal/webauthn"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	PasskeyRegisterCacheKeyPrefix = "PASSKEY:REGISTER:" // 注册通行密钥的挑战值，键为前缀加账户 ID
+	PasskeyLoginCacheKeyPrefix    = "PASSKEY:LOGIN:"    // 通行密钥登录的挑战值，键为前缀加 Base64URL 编码的挑战值
+	PasskeyUsedCacheKeyPrefix     = "PASSKEY:USED:"     // 已使用的挑战值，键为前缀加 Base64URL 编码的挑战值
+	defaultPasskeyTimeout         = 5 * time.Minute     // 未配置时注册与登录的超时时间
+	defaultPasskeyName            = "通行密钥"              // 未填写名称时的默认名称
+)
+
+var (
+	ErrPasskeyVerifyFail = errors.New("通行密钥验证失败")
+	ErrPasskeyDuplicate  = errors.New("通行密钥已绑定")
+	ErrPasskeyNotFound   = errors.New("通行密钥不存在")
+)
+
+// BeginPasskeyRegistration 为当前用户生成注册通行密钥的参数，挑战值在超时时间内有效
+func BeginPasskeyRegistration(c echo.Context) (*account.PasskeyCreationVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, timeout, err := passkeyRelyingParty(c)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := mapper.GetWebAuthnCredentialsByAccountID(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	challenge, err := newPasskeyChallenge(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := storePasskeyChallenge(fmt.Sprintf("%s%d", PasskeyRegisterCacheKeyPrefix, acc.ID), challenge, timeout, c); err != nil {
+		return nil, err
+	}
+
+	options := &account.PasskeyCreationVo{
+		Challenge: challenge,
+		RP:        account.PasskeyRPVo{ID: rp.ID, Name: rp.Name},
+		User: account.PasskeyUserVo{
+			ID:          base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(acc.ID, 10))),
+			Name:        acc.Email,
+			DisplayName: acc.Nickname,
+		},
+		Timeout:            int(timeout.Milliseconds()),
+		ExcludeCredentials: make([]account.PasskeyDescriptorVo, 0, len(credentials)),
+		AuthenticatorSelection: account.PasskeyAuthenticatorSelectionVo{
+			ResidentKey:      "required",
+			UserVerification: "required",
+		},
+		Attestation: "none",
+	}
+	for _, alg := range webauthn.SupportedAlgorithms {
+		options.PubKeyCredParams = append(options.PubKeyCredParams, account.PasskeyCredParamVo{Type: "public-key", Alg: alg})
+	}
+	for _, credential := range credentials {
+		options.ExcludeCredentials = append(options.ExcludeCredentials, account.PasskeyDescriptorVo{Type: "public-key", ID: credential.CredentialID})
+	}
+	return options, nil
+}
+
+// FinishPasskeyRegistration 校验浏览器创建凭证后返回的数据，为当前用户绑定通行密钥
+func FinishPasskeyRegistration(req *dto.FinishPasskeyRegistrationRequest, c echo.Context) (*account.PasskeyVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, timeout, err := passkeyRelyingParty(c)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := consumePasskeyChallenge(fmt.Sprintf("%s%d", PasskeyRegisterCacheKeyPrefix, acc.ID), timeout)
+	if err != nil {
+		utils.BizLogger(c).Errorf("注册通行密钥的挑战值无效，账户 ID: %d, 错误: %v", acc.ID, err)
+		return nil, fmt.Errorf("%w: 挑战值已过期，请重新发起注册", ErrPasskeyVerifyFail)
+	}
+
+	challenge, _ := base64.RawURLEncoding.DecodeString(stored)
+	clientDataJSON, _ := base64.RawURLEncoding.DecodeString(req.ClientDataJSON)
+	attestationObject, _ := base64.RawURLEncoding.DecodeString(req.AttestationObject)
+	credential, err := rp.VerifyRegistration(challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		utils.BizLogger(c).Errorf("注册通行密钥失败，账户 ID: %d, 错误: %v", acc.ID, err)
+		return nil, fmt.Errorf("%w: %v", ErrPasskeyVerifyFail, err)
+	}
+	credentialID := base64.RawURLEncoding.EncodeToString(credential.ID)
+	if credentialID != req.CredentialID {
+		utils.BizLogger(c).Errorf("注册通行密钥失败，凭证 ID 与认证器数据不一致，账户 ID: %d", acc.ID)
+		return nil, fmt.Errorf("%w: 凭证 ID 不一致", ErrPasskeyVerifyFail)
+	}
+	if _, err := mapper.GetWebAuthnCredential(credentialID); err == nil {
+		return nil, ErrPasskeyDuplicate
+	}
+
+	name := req.Name
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	passkey := &model.WebAuthnCredential{
+		AccountID:    acc.ID,
+		CredentialID: credentialID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    credential.SignCount,
+		Name:         name,
+	}
+	if err := mapper.CreateWebAuthnCredential(passkey); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	utils.BizLogger(c).Infof("账户 %d 绑定通行密钥 %d", acc.ID, passkey.ID)
+	return passkeyVo(passkey), nil
+}
+
+// BeginPasskeyLogin 生成通行密钥登录参数，不指定凭证，由浏览器列出当前站点的可发现凭证供用户选择
+func BeginPasskeyLogin(c echo.Context) (*account.PasskeyRequestVo, error) {
+	rp, timeout, err := passkeyRelyingParty(c)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := newPasskeyChallenge(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := storePasskeyChallenge(PasskeyLoginCacheKeyPrefix+challenge, challenge, timeout, c); err != nil {
+		return nil, err
+	}
+
+	return &account.PasskeyRequestVo{
+		Challenge:        challenge,
+		Timeout:          int(timeout.Milliseconds()),
+		RPID:             rp.ID,
+		UserVerification: "required",
+	}, nil
+}
+
+// FinishPasskeyLogin 校验通行密钥签名并更新签名计数器，登录凭证所属的用户并签发 token
+// 断言须带有用户验证标志，通行密钥同时证明持有设备与用户身份，开启两步验证的用户无需再提交动态码
+func FinishPasskeyLogin(req *dto.FinishPasskeyLoginRequest, c echo.Context) (*account.LoginVo, error) {
+	rp, timeout, err := passkeyRelyingParty(c)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataJSON, _ := base64.RawURLEncoding.DecodeString(req.ClientDataJSON)
+	authData, _ := base64.RawURLEncoding.DecodeString(req.AuthenticatorData)
+	signature, _ := base64.RawURLEncoding.DecodeString(req.Signature)
+	challenge, err := webauthn.ClientChallenge(clientDataJSON)
+	if err != nil {
+		utils.BizLogger(c).Errorf("通行密钥登录失败: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrPasskeyVerifyFail, err)
+	}
+
+	if _, err := consumePasskeyChallenge(PasskeyLoginCacheKeyPrefix+base64.RawURLEncoding.EncodeToString(challenge), timeout); err != nil {
+		utils.BizLogger(c).Errorf("通行密钥登录的挑战值无效: %v", err)
+		return nil, fmt.Errorf("%w: 挑战值已过期，请重新发起登录", ErrPasskeyVerifyFail)
+	}
+
+	passkey, err := mapper.GetWebAuthnCredential(req.CredentialID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("通行密钥登录失败: %v", err)
+		return nil, fmt.Errorf("%w: 通行密钥未绑定", ErrPasskeyVerifyFail)
+	}
+	signCount, err := rp.VerifyAssertion(challenge, clientDataJSON, authData, signature, passkey.PublicKey, passkey.SignCount)
+	if err != nil {
+		utils.BizLogger(c).Errorf("通行密钥 %d 登录失败: %v", passkey.ID, err)
+		return nil, fmt.Errorf("%w: %v", ErrPasskeyVerifyFail, err)
+	}
+
+	passkey.SignCount, passkey.LastUsedAt = signCount, time.Now().Unix()
+	if err := mapper.UpdateWebAuthnCredential(passkey); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	acc, err := mapper.GetAccountByAccountID(passkey.AccountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取用户失败: %v", err)
+		return nil, fmt.Errorf("获取用户失败: %v", err)
+	}
+	return issueLoginTokens(acc, model.LoginMethodPasskey, "", c)
+}
+
+// ListPasskeys 获取当前用户绑定的通行密钥
+func ListPasskeys(c echo.Context) ([]*account.PasskeyVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := mapper.GetWebAuthnCredentialsByAccountID(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	passkeys := make([]*account.PasskeyVo, 0, len(credentials))
+	for _, credential := range credentials {
+		passkeys = append(passkeys, passkeyVo(credential))
+	}
+	return passkeys, nil
+}
+
+// DeletePasskey 删除当前用户绑定的通行密钥
+func DeletePasskey(req *dto.DeletePasskeyRequest, c echo.Context) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+	deleted, err := mapper.DeleteWebAuthnCredentialSoftly(acc.ID, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if !deleted {
+		return ErrPasskeyNotFound
+	}
+	utils.BizLogger(c).Infof("账户 %d 删除通行密钥 %d", acc.ID, req.ID)
+	return nil
+}
+
+// passkeyRelyingParty 根据配置生成依赖方，未配置的字段按站点地址与名称生成
+func passkeyRelyingParty(c echo.Context) (*webauthn.RelyingParty, time.Duration, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载通行密钥配置失败: %v", err)
+		return nil, 0, err
+	}
+
+	rp := &webauthn.RelyingParty{
+		ID:      config.WebAuthnConfig.WebAuthnRPID,
+		Name:    config.WebAuthnConfig.WebAuthnRPName,
+		Origins: config.WebAuthnConfig.WebAuthnOrigins,
+	}
+	site, err := url.Parse(config.SiteConfig.SiteURL)
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析站点地址失败: %v", err)
+		return nil, 0, fmt.Errorf("解析站点地址失败: %v", err)
+	}
+	if rp.ID == "" {
+		rp.ID = site.Hostname()
+	}
+	if rp.Name == "" {
+		rp.Name = config.SiteConfig.SiteTitle
+	}
+	if len(rp.Origins) == 0 {
+		rp.Origins = []string{site.Scheme + "://" + site.Host}
+	}
+
+	timeout := defaultPasskeyTimeout
+	if config.WebAuthnConfig.WebAuthnTimeout > 0 {
+		timeout = time.Duration(config.WebAuthnConfig.WebAuthnTimeout) * time.Second
+	}
+	return rp, timeout, nil
+}
+
+// newPasskeyChallenge 生成 Base64URL 编码的挑战值
+func newPasskeyChallenge(c echo.Context) (string, error) {
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(challenge), nil
+}
+
+// storePasskeyChallenge 将挑战值写入缓存，超时后失效
+func storePasskeyChallenge(key, challenge string, timeout time.Duration, c echo.Context) error {
+	if err := cache.Current().Set(context.Background(), key, challenge, timeout); err != nil {
+		utils.BizLogger(c).Errorf("通行密钥挑战值写入缓存失败: %v", err)
+		return fmt.Errorf("通行密钥挑战值写入缓存失败: %v", err)
+	}
+	return nil
+}
+
+// consumePasskeyChallenge 读取并作废挑战值，每个挑战值只能使用一次；
+// 使用 SetNX 标记挑战值已使用，并发提交同一挑战值时只有一个请求成功
+func consumePasskeyChallenge(key string, timeout time.Duration) (string, error) {
+	ctx := context.Background()
+	challenge, err := cache.Current().Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("挑战值不存在或已过期: %v", err)
+	}
+	fresh, err := cache.Current().SetNX(ctx, PasskeyUsedCacheKeyPrefix+challenge, 1, timeout)
+	if err != nil {
+		return "", fmt.Errorf("记录挑战值使用状态失败: %v", err)
+	}
+	if !fresh {
+		return "", errors.New("挑战值已使用")
+	}
+	cache.Current().Del(ctx, key)
+	return challenge, nil
+}
+
+// passkeyVo 将通行密钥映射为 vo
+func passkeyVo(credential *model.WebAuthnCredential) *account.PasskeyVo {
+	return &account.PasskeyVo{
+		ID:         credential.ID,
+		Name:       credential.Name,
+		GmtCreate:  credential.GmtCreate,
+		LastUsedAt: credential.LastUsedAt,
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// PasswordPolicy 返回当前配置的密码强度策略
+func PasswordPolicy() *account.PasswordPolicyVo {
+	policy := password.Current()
+	classes := policy.RequireClasses
+	if classes == nil {
+		classes = []string{}
+	}
+	return &account.PasswordPolicyVo{
+		MinLength:      policy.MinLength,
+		MaxLength:      policy.MaxLength,
+		MinCharClasses: policy.MinCharClasses,
+		RequireClasses: classes,
+		BanCommon:      policy.BanCommon,
+		DisallowEmail:  policy.DisallowEmail,
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// EmailRegistered 邮箱是否已注册
+func EmailRegistered(email string) bool {
+	acc, err := mapper.GetAccountByEmail(email)
+	return err == nil && acc != nil
+}
+
+// PasswordResetLink 生成重置密码链接的展示信息，邮箱脱敏后返回
+func PasswordResetLink(email string, expiresAt int64) *account.PasswordResetLinkVo {
+	return &account.PasswordResetLinkVo{Email: maskEmail(email), ExpiresAt: expiresAt}
+}
+
+// ResetPasswordByLink 为通过重置密码链接校验的邮箱设置新密码，并结束账户的全部会话
+func ResetPasswordByLink(email, newPassword string, c echo.Context) error {
+	passwordResetLock.Lock()
+	defer passwordResetLock.Unlock()
+
+	acc, err := mapper.GetAccountByEmail(email)
+	if err != nil {
+		utils.BizLogger(c).Errorf("「%s」用户不存在: %v", email, err)
+		return fmt.Errorf("「%s」用户不存在: %v", email, err)
+	}
+
+	hashed, err := password.Hash(newPassword)
+	if err != nil {
+		utils.BizLogger(c).Errorf("密码加密失败: %v", err)
+		return fmt.Errorf("密码加密失败: %v", err)
+	}
+	acc.Password = hashed
+	acc.PasswordAlgorithm = password.Identify(hashed)
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("密码修改失败: %v", err)
+		return fmt.Errorf("密码修改失败: %v", err)
+	}
+
+	// 忘记密码通常意味着密码可能已泄露，重置后其他设备需重新登录
+	if err := utils.RevokeAllRefreshTokens(acc.ID); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+	}
+	if _, err := session.EndAll(acc.ID, "", model.SessionEndPasswordReset); err != nil {
+		utils.BizLogger(c).Errorf("结束会话失败: %v", err)
+	}
+	revokeDeviceTokens(acc.ID, "", c)
+	sendSecurityNotice(acc.Email, SecurityEventPasswordChanged, "", c)
+
+	utils.BizLogger(c).Infof("账户 %d 通过邮件链接重置密码", acc.ID)
+	return nil
+}
+
+// maskEmail 邮箱脱敏，保留用户名首字符与域名，如 a***@example.com
+func maskEmail(email string) string {
+	name, domain, ok := strings.Cut(email, "@")
+	if !ok || name == "" {
+		return email
+	}
+	return name[:1] + "***@" + domain
+}
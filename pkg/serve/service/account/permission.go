@@ -9,6 +9,7 @@ import (
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/account/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
 	"jank.com/jank_blog/pkg/vo/account"
 )
 
@@ -68,23 +69,23 @@ func DeletePermission(req *dto.DeletePermissionRequest, c echo.Context) error {
 	return nil
 }
 
-// ListPermissions 获取所有权限
-func ListPermissions(c echo.Context) ([]*account.PermissionVo, error) {
-	permissions, err := mapper.GetAllPermissions()
+// ListPermissions 分页获取权限列表
+func ListPermissions(page vo.PageRequest, c echo.Context) ([]*account.PermissionVo, *vo.PageMeta, error) {
+	permissions, total, err := mapper.GetPermissionsWithPaging(page.Offset(), page.Limit())
 	if err != nil {
 		utils.BizLogger(c).Errorf("获取所有权限失败: %v", err)
-		return nil, fmt.Errorf("获取所有权限失败: %v", err)
+		return nil, nil, fmt.Errorf("获取所有权限失败: %v", err)
 	}
 
-	var permissionVos []*account.PermissionVo
+	permissionVos := make([]*account.PermissionVo, 0, len(permissions))
 	for _, permission := range permissions {
 		permissionVo, err := utils.MapModelToVO(permission, &account.PermissionVo{})
 		if err != nil {
 			utils.BizLogger(c).Errorf("获取所有权限时映射 vo 失败: %v", err)
-			return nil, fmt.Errorf("获取所有权限时映射 vo 失败: %v", err)
+			return nil, nil, fmt.Errorf("获取所有权限时映射 vo 失败: %v", err)
 		}
 		permissionVos = append(permissionVos, permissionVo.(*account.PermissionVo))
 	}
 
-	return permissionVos, nil
+	return permissionVos, vo.NewPageMeta(page, total), nil
 }
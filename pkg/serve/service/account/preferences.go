@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// GetPreferences 获取当前用户的偏好设置
+func GetPreferences(c echo.Context) (*account.PreferencesVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	return preferencesVo(acc), nil
+}
+
+// UpdatePreferences 更新当前用户的偏好设置
+func UpdatePreferences(req *dto.UpdatePreferencesRequest, c echo.Context) (*account.PreferencesVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ReadingHistory != nil {
+		acc.SetPreference(model.PreferenceReadingHistory, *req.ReadingHistory)
+	}
+	if req.LoginAlert != nil {
+		acc.SetPreference(model.PreferenceLoginAlert, *req.LoginAlert)
+	}
+
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("更新偏好设置失败: %v", err)
+		return nil, fmt.Errorf("更新偏好设置失败: %v", err)
+	}
+	return preferencesVo(acc), nil
+}
+
+// currentAccount 根据 access token 获取当前用户
+func currentAccount(c echo.Context) (*model.Account, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取用户失败: %v", err)
+		return nil, fmt.Errorf("获取用户失败: %v", err)
+	}
+	return acc, nil
+}
+
+// preferencesVo 将用户偏好设置映射为 vo
+func preferencesVo(acc *model.Account) *account.PreferencesVo {
+	return &account.PreferencesVo{
+		ReadingHistory: acc.Preference(model.PreferenceReadingHistory, true),
+		LoginAlert:     acc.Preference(model.PreferenceLoginAlert, true),
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/avatar"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// 头像未配置时的默认值
+const (
+	defaultAvatarMaxSize   = 5 << 20 // 上传图片的大小上限
+	defaultAvatarURLPrefix = "/api/v1/account/avatar/"
+)
+
+var (
+	ErrAvatarInvalid   = errors.New("头像图片无效")
+	ErrAvatarTooLarge  = errors.New("头像图片过大")
+	ErrProfileNotFound = errors.New("用户不存在")
+	ErrAvatarNotFound  = errors.New("头像不存在")
+)
+
+// avatarOptions 头像存放目录、边长、上传大小上限与地址前缀，未配置的项使用默认值
+type avatarOptions struct {
+	dir       string
+	size      int
+	maxSize   int64
+	urlPrefix string
+}
+
+// currentAvatarOptions 读取配置中的头像参数
+func currentAvatarOptions(config *configs.Config) avatarOptions {
+	cfg := config.AvatarConfig
+	opts := avatarOptions{dir: cfg.AvatarDir, size: cfg.AvatarSize, maxSize: defaultAvatarMaxSize, urlPrefix: cfg.AvatarURLPrefix}
+	if opts.dir == "" {
+		opts.dir = avatar.DefaultDir
+	}
+	if cfg.AvatarMaxSize > 0 {
+		opts.maxSize = int64(cfg.AvatarMaxSize) << 10
+	}
+	if opts.urlPrefix == "" {
+		opts.urlPrefix = defaultAvatarURLPrefix
+	}
+	return opts
+}
+
+// GetProfile 获取账户的公开资料，账户不存在、已停用、未通过注册审核或处于注销冷静期时返回 ErrProfileNotFound
+func GetProfile(req *dto.GetProfileRequest, c echo.Context) (*account.ProfileVo, error) {
+	acc, err := mapper.GetAccountByAccountID(req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取账户 %d 的公开资料失败: %v", req.ID, err)
+		return nil, ErrProfileNotFound
+	}
+	profile := PublicProfile(acc)
+	if profile == nil {
+		return nil, ErrProfileNotFound
+	}
+	return profile, nil
+}
+
+// PublicProfile 账户的公开资料，账户正在注销、已停用或尚未通过注册审核时返回 nil
+func PublicProfile(acc *model.Account) *account.ProfileVo {
+	if acc.DeletionScheduledAt > 0 || acc.Disabled(time.Now().Unix()) || acc.ApprovalStatus != "" {
+		return nil
+	}
+	return profileVo(acc)
+}
+
+// UpdateProfile 更新当前用户的个人资料，未传的字段保持不变
+func UpdateProfile(req *dto.UpdateProfileRequest, c echo.Context) (*account.ProfileVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// 昵称不能清空，仅含空白字符时保持不变
+	if req.Nickname != nil {
+		if nickname := strings.TrimSpace(*req.Nickname); nickname != "" {
+			acc.Nickname = nickname
+		}
+	}
+	if req.DisplayName != nil {
+		acc.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+	if req.Bio != nil {
+		acc.Bio = strings.TrimSpace(*req.Bio)
+	}
+	if req.Website != nil {
+		acc.Website = strings.TrimSpace(*req.Website)
+	}
+	if req.SocialLinks != nil {
+		acc.SetSocialLinks(req.SocialLinks)
+	}
+
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("更新个人资料失败: %v", err)
+		return nil, fmt.Errorf("更新个人资料失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("账户 %d 更新个人资料", acc.ID)
+	return profileVo(acc), nil
+}
+
+// UploadAvatar 为当前用户上传头像，图片裁剪缩放后保存为新文件，并删除本站保存的旧头像；
+// size 为上传文件的大小，crop 为空时从图片中心裁剪
+func UploadAvatar(file io.Reader, size int64, crop *image.Rectangle, c echo.Context) (*account.AvatarVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载头像配置失败: %v", err)
+		return nil, err
+	}
+	opts := currentAvatarOptions(config)
+	if size > opts.maxSize {
+		return nil, ErrAvatarTooLarge
+	}
+
+	data, err := avatar.Process(io.LimitReader(file, opts.maxSize), avatar.Options{Size: opts.size, Crop: crop})
+	if err != nil {
+		utils.BizLogger(c).Errorf("账户 %d 上传的头像无效: %v", acc.ID, err)
+		return nil, ErrAvatarInvalid
+	}
+
+	id, err := randomHex()
+	if err != nil {
+		return nil, err
+	}
+	name := avatar.FileName(acc.ID, id)
+	path, err := avatar.Path(opts.dir, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.dir, 0o755); err != nil {
+		utils.BizLogger(c).Errorf("创建头像目录失败: %v", err)
+		return nil, fmt.Errorf("创建头像目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		utils.BizLogger(c).Errorf("保存头像失败: %v", err)
+		return nil, fmt.Errorf("保存头像失败: %v", err)
+	}
+
+	oldAvatar := acc.Avatar
+	acc.Avatar = opts.urlPrefix + name
+	if err := mapper.UpdateAccount(acc); err != nil {
+		_ = os.Remove(path)
+		utils.BizLogger(c).Errorf("更新头像失败: %v", err)
+		return nil, fmt.Errorf("更新头像失败: %v", err)
+	}
+	removeOldAvatar(opts, oldAvatar, c)
+
+	utils.BizLogger(c).Infof("账户 %d 上传头像 %s", acc.ID, name)
+	return &account.AvatarVo{Avatar: acc.Avatar}, nil
+}
+
+// AvatarFile 校验头像文件名并返回头像文件的路径，文件不存在时返回 ErrAvatarNotFound
+func AvatarFile(name string, c echo.Context) (string, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载头像配置失败: %v", err)
+		return "", err
+	}
+	path, err := avatar.Path(currentAvatarOptions(config).dir, name)
+	if err != nil {
+		return "", ErrAvatarNotFound
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", ErrAvatarNotFound
+	}
+	return path, nil
+}
+
+// removeOldAvatar 删除本站保存的旧头像，外部地址的头像不做处理，失败时仅记录日志
+func removeOldAvatar(opts avatarOptions, oldAvatar string, c echo.Context) {
+	name, ok := strings.CutPrefix(oldAvatar, opts.urlPrefix)
+	if !ok {
+		return
+	}
+	path, err := avatar.Path(opts.dir, name)
+	if err != nil {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		utils.BizLogger(c).Errorf("删除旧头像失败: %v", err)
+	}
+}
+
+// profileVo 将账户映射为公开资料
+func profileVo(acc *model.Account) *account.ProfileVo {
+	return &account.ProfileVo{
+		ID:          acc.ID,
+		Nickname:    acc.Nickname,
+		DisplayName: acc.DisplayName,
+		Avatar:      AvatarURL(acc),
+		Bio:         acc.Bio,
+		Website:     acc.Website,
+		SocialLinks: acc.SocialLinks(),
+		JoinedAt:    acc.GmtCreate,
+	}
+}
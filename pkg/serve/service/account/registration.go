@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/setting"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// 注册方式
+const (
+	RegistrationModeOpen     = "open"     // 开放注册
+	RegistrationModeApproval = "approval" // 注册后需管理员审核通过才能登录
+	RegistrationModeClosed   = "closed"   // 关闭注册
+)
+
+var (
+	ErrRegistrationClosed = errors.New("站点已关闭注册")
+	ErrNotPendingApproval = errors.New("用户的注册无需审核")
+)
+
+// registrationMode 读取配置中的注册方式，读取失败或未配置时按开放注册处理
+func registrationMode() string {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return RegistrationModeOpen
+	}
+	switch mode := config.RegistrationConfig.RegistrationMode; mode {
+	case RegistrationModeApproval, RegistrationModeClosed:
+		return mode
+	default:
+		return RegistrationModeOpen
+	}
+}
+
+// CheckRegistrationOpen 站点已关闭注册时返回 ErrRegistrationClosed
+func CheckRegistrationOpen(c echo.Context) error {
+	if registrationMode() == RegistrationModeClosed {
+		utils.BizLogger(c).Errorf("站点已关闭注册，拒绝创建账户")
+		return ErrRegistrationClosed
+	}
+	return nil
+}
+
+// requireApproval 站点开启注册审核时将新账户标记为待审核，返回是否需要审核，在创建账户前调用
+func requireApproval(acc *model.Account) bool {
+	if registrationMode() != RegistrationModeApproval {
+		return false
+	}
+	acc.ApprovalStatus = model.ApprovalStatusPending
+	return true
+}
+
+// notifyPendingRegistration 异步发送新注册待审核通知邮件给全部管理员，未开启通知时不发送，发送失败时仅记录日志
+func notifyPendingRegistration(acc *model.Account, c echo.Context) {
+	utils.BizLogger(c).Infof("账户 %d 注册后等待管理员审核", acc.ID)
+	go func() {
+		config, err := configs.LoadConfig()
+		if err != nil || !config.RegistrationConfig.RegistrationNotifyAdmins {
+			return
+		}
+		emails, err := mapper.GetEnabledEmailsByRoleCode(model.RoleCodeAdmin)
+		if err != nil {
+			global.BizLog.Errorf("%v", err)
+			return
+		}
+		pending, err := mapper.CountAccountsByApprovalStatus(model.ApprovalStatusPending)
+		if err != nil {
+			global.BizLog.Errorf("%v", err)
+			return
+		}
+
+		// 管理员的语言未知，使用站点默认语言
+		msg, err := mail.Render(mail.TemplateRegistrationPending, i18n.DefaultLocale, mail.RegistrationPendingData{
+			SiteName: siteName(config),
+			SiteURL:  config.SiteConfig.SiteURL,
+			Email:    acc.Email,
+			Nickname: acc.Nickname,
+			Time:     time.Unix(acc.GmtCreate, 0).Format("2006-01-02 15:04:05 MST"),
+			Pending:  pending,
+			Locale:   i18n.DefaultLocale,
+		}, config.AppConfig.EmailTemplateDir)
+		if err != nil {
+			global.BizLog.Errorf("渲染新注册待审核通知邮件失败: %v", err)
+			return
+		}
+		for _, email := range emails {
+			// 每次发送前复制邮件，避免未设置主题时 deliverEmail 修改共享的邮件
+			msg := *msg
+			if err := deliverEmail(config, &msg, email); err != nil {
+				global.BizLog.Errorf("新注册待审核通知邮件发送给 %s 失败: %v", email, err)
+			}
+		}
+	}()
+}
+
+// GetRegistrationSettings 获取注册方式与待审核的用户数量
+func GetRegistrationSettings(c echo.Context) (*account.RegistrationSettingsVo, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载注册配置失败: %v", err)
+		return nil, err
+	}
+	pending, err := mapper.CountAccountsByApprovalStatus(model.ApprovalStatusPending)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	return &account.RegistrationSettingsVo{
+		Mode:         registrationMode(),
+		NotifyAdmins: config.RegistrationConfig.RegistrationNotifyAdmins,
+		Pending:      pending,
+	}, nil
+}
+
+// UpdateRegistration 修改注册方式并保存到数据库，立即生效，其他实例最迟一分钟后生效；由审核改为开放或关闭注册时，已在等待审核的用户仍需审核
+func UpdateRegistration(req *dto.UpdateRegistrationRequest, c echo.Context) (*account.RegistrationSettingsVo, error) {
+	previous := registrationMode()
+	values := map[string]string{"registration.REGISTRATION_MODE": req.Mode}
+	if req.NotifyAdmins != nil {
+		values["registration.REGISTRATION_NOTIFY_ADMINS"] = strconv.FormatBool(*req.NotifyAdmins)
+	}
+	if err := setting.Update(values); err != nil {
+		utils.BizLogger(c).Errorf("写入注册配置失败: %v", err)
+		return nil, fmt.Errorf("写入注册配置失败: %v", err)
+	}
+
+	recordAdminAction(0, model.AdminActionRegistration, fmt.Sprintf("%s -> %s", previous, req.Mode), c)
+	return GetRegistrationSettings(c)
+}
+
+// ApproveUser 通过用户的注册审核，已拒绝的用户也可重新通过，无需审核的用户不做处理；审核结果以邮件通知用户
+func ApproveUser(req *dto.AdminUserRequest, c echo.Context) error {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return err
+	}
+	if acc.ApprovalStatus == "" {
+		return nil
+	}
+
+	acc.ApprovalStatus = ""
+	acc.ApprovalReason = ""
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("通过账户 %d 的注册审核失败: %v", acc.ID, err)
+		return fmt.Errorf("通过账户 %d 的注册审核失败: %v", acc.ID, err)
+	}
+
+	recordAdminAction(acc.ID, model.AdminActionApprove, "", c)
+	sendRegistrationReview(acc, true, c)
+	return nil
+}
+
+// RejectUser 拒绝用户的注册申请，账户保留但不能登录，用户登录时可见拒绝原因；无需审核的用户不能拒绝，应改为停用
+func RejectUser(req *dto.RejectUserRequest, c echo.Context) error {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return err
+	}
+	if acc.ApprovalStatus == "" {
+		return ErrNotPendingApproval
+	}
+
+	notify := acc.ApprovalStatus == model.ApprovalStatusPending
+	acc.ApprovalStatus = model.ApprovalStatusRejected
+	acc.ApprovalReason = req.Reason
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("拒绝账户 %d 的注册申请失败: %v", acc.ID, err)
+		return fmt.Errorf("拒绝账户 %d 的注册申请失败: %v", acc.ID, err)
+	}
+
+	recordAdminAction(acc.ID, model.AdminActionReject, req.Reason, c)
+	if notify {
+		sendRegistrationReview(acc, false, c)
+	}
+	return nil
+}
+
+// sendRegistrationReview 异步发送注册审核结果邮件，发送失败时仅记录日志
+func sendRegistrationReview(acc *model.Account, approved bool, c echo.Context) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return
+	}
+	go func() {
+		// 请求来自管理员，用户的语言未知，使用站点默认语言
+		locale := i18n.DefaultLocale
+		msg, err := mail.Render(mail.TemplateRegistrationReview, locale, mail.RegistrationReviewData{
+			SiteName: siteName(config),
+			SiteURL:  config.SiteConfig.SiteURL,
+			Approved: approved,
+			Reason:   acc.ApprovalReason,
+			Locale:   locale,
+		}, config.AppConfig.EmailTemplateDir)
+		if err != nil {
+			global.BizLog.Errorf("渲染注册审核结果邮件失败: %v", err)
+			return
+		}
+		if err := deliverEmail(config, msg, acc.Email); err != nil {
+			global.BizLog.Errorf("账户 %d 注册审核结果邮件发送失败: %v", acc.ID, err)
+		}
+	}()
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// defaultRememberMeDays 未配置时设备令牌的有效天数
+const defaultRememberMeDays = 30
+
+var (
+	ErrRememberMeDisabled  = errors.New("站点未开启记住我")
+	ErrDeviceTokenInvalid  = errors.New("设备令牌无效或已过期，请重新登录")
+	ErrDeviceTokenNotFound = errors.New("设备令牌不存在")
+)
+
+// rememberMeLifetime 读取配置中的设备令牌有效期，未开启记住我时返回 ErrRememberMeDisabled
+func rememberMeLifetime() (time.Duration, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+	if !config.SessionConfig.RememberMeEnabled {
+		return 0, ErrRememberMeDisabled
+	}
+	days := config.SessionConfig.RememberMeDays
+	if days < 1 {
+		days = defaultRememberMeDays
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// RememberDevice 为当前会话所在的设备签发设备令牌，完整的令牌仅在返回值中出现一次；
+// 同一设备标识已有的令牌随即失效，客户端需妥善保存设备标识，换取 token 时一并提交
+func RememberDevice(req *dto.RememberDeviceRequest, c echo.Context) (*account.DeviceTokenCreatedVo, error) {
+	lifetime, err := rememberMeLifetime()
+	if err != nil {
+		return nil, err
+	}
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+
+	token, hash, err := utils.GenerateDeviceToken()
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	now := time.Now()
+	deviceToken := &model.DeviceToken{
+		AccountID:  acc.ID,
+		TokenHash:  hash,
+		DeviceHash: utils.HashDeviceToken(req.DeviceID),
+		SessionID:  utils.ParseSessionIDFromJWT(c.Request().Header.Get("Authorization")),
+		Device:     session.DeviceName(c.Request().UserAgent()),
+		LastUsedAt: now.Unix(),
+		LastUsedIP: c.RealIP(),
+		ExpiresAt:  now.Add(lifetime).Unix(),
+	}
+	if err := mapper.CreateDeviceToken(deviceToken); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	utils.BizLogger(c).Infof("账户 %d 在设备 %s 上开启记住我，设备令牌 %d", acc.ID, deviceToken.Device, deviceToken.ID)
+	return &account.DeviceTokenCreatedVo{DeviceTokenVo: *deviceTokenVo(deviceToken, ""), DeviceToken: token}, nil
+}
+
+// ExchangeDeviceToken 使用设备令牌换取新的 access token 与 refresh token，并创建新的会话；
+// 设备令牌随即轮换，新令牌在返回值中，旧令牌不能再使用；提交的设备标识与签发时不一致时视为令牌被盗用，删除该令牌。
+// 签发设备令牌时已完成登录，换取 token 时不再要求两步验证
+func ExchangeDeviceToken(req *dto.ExchangeDeviceTokenRequest, c echo.Context) (*account.LoginVo, error) {
+	lifetime, err := rememberMeLifetime()
+	if err != nil {
+		return nil, err
+	}
+	oldHash := utils.HashDeviceToken(req.DeviceToken)
+	deviceToken, err := mapper.GetDeviceTokenByHash(oldHash)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, ErrDeviceTokenInvalid
+	}
+	now := time.Now()
+	if now.Unix() >= deviceToken.ExpiresAt {
+		return nil, ErrDeviceTokenInvalid
+	}
+	if subtle.ConstantTimeCompare([]byte(deviceToken.DeviceHash), []byte(utils.HashDeviceToken(req.DeviceID))) != 1 {
+		utils.BizLogger(c).Errorf("设备令牌 %d 的设备标识不一致，疑似被盗用，已删除", deviceToken.ID)
+		if _, err := mapper.DeleteDeviceTokenSoftly(deviceToken.AccountID, deviceToken.ID); err != nil {
+			utils.BizLogger(c).Errorf("%v", err)
+		}
+		return nil, ErrDeviceTokenInvalid
+	}
+
+	acc, err := mapper.GetAccountByAccountID(deviceToken.AccountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取账户 %d 失败: %v", deviceToken.AccountID, err)
+		return nil, ErrDeviceTokenInvalid
+	}
+	if acc.DeletionScheduledAt > 0 {
+		// 申请注销时已删除设备令牌，此处兜底处理，撤销注销需重新登录
+		return nil, ErrDeviceTokenInvalid
+	}
+
+	token, hash, err := utils.GenerateDeviceToken()
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	rotated, err := mapper.RotateDeviceToken(deviceToken.ID, oldHash, map[string]interface{}{
+		"token_hash":   hash,
+		"last_used_at": now.Unix(),
+		"last_used_ip": c.RealIP(),
+		"expires_at":   now.Add(lifetime).Unix(),
+	})
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	if !rotated {
+		return nil, ErrDeviceTokenInvalid
+	}
+
+	vo, err := issueLoginTokens(acc, model.LoginMethodDeviceToken, "", c)
+	if err != nil {
+		return nil, err
+	}
+	if err := mapper.UpdateDeviceTokenSession(deviceToken.ID, utils.ParseSessionIDFromJWT(vo.AccessToken)); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+	}
+	vo.DeviceToken = token
+	return vo, nil
+}
+
+// ListDeviceTokens 获取当前用户未过期的设备令牌，不包含完整的令牌
+func ListDeviceTokens(c echo.Context) ([]*account.DeviceTokenVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := mapper.GetDeviceTokensByAccountID(acc.ID, time.Now().Unix())
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	current := utils.ParseSessionIDFromJWT(c.Request().Header.Get("Authorization"))
+	result := make([]*account.DeviceTokenVo, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, deviceTokenVo(token, current))
+	}
+	return result, nil
+}
+
+// RevokeDeviceToken 删除当前用户的设备令牌，该设备在会话结束后需重新登录，已登录的会话不受影响
+func RevokeDeviceToken(req *dto.RevokeDeviceTokenRequest, c echo.Context) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+	deleted, err := mapper.DeleteDeviceTokenSoftly(acc.ID, req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if !deleted {
+		return ErrDeviceTokenNotFound
+	}
+	utils.BizLogger(c).Infof("账户 %d 删除设备令牌 %d", acc.ID, req.ID)
+	return nil
+}
+
+// revokeDeviceTokens 删除账户的设备令牌，exceptSessionID 不为空时保留与该会话关联的令牌；
+// 结束账户全部会话时调用，避免被移除的设备凭设备令牌重新登录，删除失败时仅记录日志
+func revokeDeviceTokens(accountID int64, exceptSessionID string, c echo.Context) {
+	count, err := mapper.DeleteDeviceTokensByAccountID(accountID, exceptSessionID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return
+	}
+	if count > 0 {
+		utils.BizLogger(c).Infof("账户 %d 的 %d 个设备令牌已删除", accountID, count)
+	}
+}
+
+// deviceTokenVo 将设备令牌映射为 vo，current 为发起请求的会话 ID
+func deviceTokenVo(token *model.DeviceToken, current string) *account.DeviceTokenVo {
+	return &account.DeviceTokenVo{
+		ID:         token.ID,
+		Device:     token.Device,
+		LastUsedAt: token.LastUsedAt,
+		LastUsedIP: token.LastUsedIP,
+		ExpiresAt:  token.ExpiresAt,
+		Current:    current != "" && token.SessionID == current,
+		GmtCreate:  token.GmtCreate,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/account/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
 	"jank.com/jank_blog/pkg/vo/account"
 )
 
@@ -68,23 +69,23 @@ func DeleteRole(req *dto.DeleteRoleRequest, c echo.Context) error {
 	return nil
 }
 
-// ListRoles 获取所有角色
-func ListRoles(c echo.Context) ([]*account.RoleVo, error) {
-	roles, err := mapper.GetAllRoles()
+// ListRoles 分页获取角色列表
+func ListRoles(page vo.PageRequest, c echo.Context) ([]*account.RoleVo, *vo.PageMeta, error) {
+	roles, total, err := mapper.GetRolesWithPaging(page.Offset(), page.Limit())
 	if err != nil {
 		utils.BizLogger(c).Errorf("获取所有角色失败: %v", err)
-		return nil, fmt.Errorf("获取所有角色失败: %v", err)
+		return nil, nil, fmt.Errorf("获取所有角色失败: %v", err)
 	}
 
-	var roleVos []*account.RoleVo
+	roleVos := make([]*account.RoleVo, 0, len(roles))
 	for _, role := range roles {
 		roleVo, err := utils.MapModelToVO(role, &account.RoleVo{})
 		if err != nil {
 			utils.BizLogger(c).Errorf("获取所有角色时映射 vo 失败: %v", err)
-			return nil, fmt.Errorf("获取所有时映射 vo 失败: %v", err)
+			return nil, nil, fmt.Errorf("获取所有时映射 vo 失败: %v", err)
 		}
 		roleVos = append(roleVos, roleVo.(*account.RoleVo))
 	}
 
-	return roleVos, nil
+	return roleVos, vo.NewPageMeta(page, total), nil
 }
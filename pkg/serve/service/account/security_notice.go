@@ -0,0 +1,56 @@
+package service
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/i18n"
+	"jank.com/jank_blog/internal/mail"
+	"jank.com/jank_blog/internal/session"
+)
+
+// 账户安全提醒的事件，均为必要通知，不受用户偏好设置影响
+const (
+	SecurityEventPasswordChanged    = "password_changed"     // 用户修改或通过邮件链接重置密码
+	SecurityEventPasswordAdminReset = "password_admin_reset" // 管理员重置密码
+	SecurityEventEmailChanged       = "email_changed"        // 修改登录邮箱，提醒发送到修改前的邮箱
+	SecurityEventTotpDisabled       = "totp_disabled"        // 关闭两步验证
+)
+
+// sendSecurityNotice 异步向 email 发送账户安全提醒邮件，未开启 SECURITY_NOTICE_ENABLED 时不发送，发送失败时仅记录日志；
+// 管理员重置密码时不显示管理员的设备与 IP，newEmail 仅在修改邮箱时传入
+func sendSecurityNotice(email, event, newEmail string, c echo.Context) {
+	config, err := configs.LoadConfig()
+	if err != nil || !config.SecurityNoticeConfig.SecurityNoticeEnabled || email == "" {
+		return
+	}
+
+	data := mail.SecurityNoticeData{
+		SiteName: siteName(config),
+		SiteURL:  config.SiteConfig.SiteURL,
+		Event:    event,
+		Time:     time.Now().Format("2006-01-02 15:04:05 MST"),
+		Locale:   i18n.FromRequest(c.Request()),
+	}
+	if newEmail != "" {
+		data.Email = maskEmail(newEmail)
+	}
+	if event != SecurityEventPasswordAdminReset {
+		data.Device = session.DeviceName(c.Request().UserAgent())
+		data.IP = c.RealIP()
+	}
+
+	go func() {
+		msg, err := mail.Render(mail.TemplateSecurityNotice, data.Locale, data, config.AppConfig.EmailTemplateDir)
+		if err != nil {
+			global.BizLog.Errorf("渲染账户安全提醒邮件失败: %v", err)
+			return
+		}
+		if err := deliverEmail(config, msg, email); err != nil {
+			global.BizLog.Errorf("向 %s 发送 %s 账户安全提醒邮件失败: %v", maskEmail(email), event, err)
+		}
+	}()
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// ErrSessionNotFound 会话不存在、已结束或不属于当前账户
+var ErrSessionNotFound = errors.New("会话不存在或已结束")
+
+// ListSessions 获取当前用户未结束的登录会话，并标记发起请求的会话
+func ListSessions(c echo.Context) ([]*account.SessionVo, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, err
+	}
+	states, err := session.List(accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	current := utils.ParseSessionIDFromJWT(c.Request().Header.Get("Authorization"))
+	result := make([]*account.SessionVo, 0, len(states))
+	for _, state := range states {
+		result = append(result, &account.SessionVo{
+			SessionID:  state.ID,
+			Device:     state.Device,
+			UserAgent:  state.UserAgent,
+			IP:         state.IP,
+			CreatedAt:  state.CreatedAt,
+			LastSeenAt: state.LastSeenAt,
+			Current:    state.ID == current,
+		})
+	}
+	return result, nil
+}
+
+// RevokeSession 移除当前用户的单个会话，该会话的 access token 与 refresh token 随即失效，该设备开启的记住我一并失效
+func RevokeSession(req *dto.RevokeSessionRequest, c echo.Context) error {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return err
+	}
+	found, err := session.End(accountID, req.SessionID, model.SessionEndRevoked)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if !found {
+		return ErrSessionNotFound
+	}
+	if err := mapper.DeleteDeviceTokensBySessionID(accountID, req.SessionID); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+	}
+
+	utils.BizLogger(c).Infof("账户 %d 移除会话 %s", accountID, req.SessionID)
+	return nil
+}
+
+// RevokeOtherSessions 移除当前用户除发起请求的会话外的全部会话，其他设备开启的记住我一并失效
+func RevokeOtherSessions(c echo.Context) (*account.RevokeSessionsVo, error) {
+	authHeader := c.Request().Header.Get("Authorization")
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(authHeader)
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, err
+	}
+	current := utils.ParseSessionIDFromJWT(authHeader)
+	if current == "" {
+		return nil, ErrSessionNotFound
+	}
+	count, err := session.EndAll(accountID, current, model.SessionEndLogoutAll)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	revokeDeviceTokens(accountID, current, c)
+	return &account.RevokeSessionsVo{Revoked: count}, nil
+}
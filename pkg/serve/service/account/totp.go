@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	TotpPendingCacheKeyPrefix = "TOTP:PENDING:"  // 待确认的 TOTP 密钥，键为前缀加账户 ID
+	TotpPendingExpiration     = 10 * time.Minute // 绑定验证器的有效期
+	defaultRecoveryCodeCount  = 10               // 未配置时每次生成的恢复码数量
+)
+
+var (
+	ErrTotpAlreadyEnabled = errors.New("账户已开启两步验证")
+	ErrTotpSetupExpired   = errors.New("绑定信息已过期，请重新获取")
+	ErrTotpCodeInvalid    = errors.New("动态码错误")
+	ErrTotpNotEnabled     = errors.New("账户未开启两步验证")
+)
+
+// SetupTotp 为当前用户生成待确认的 TOTP 密钥，提交动态码确认后才会开启两步验证
+func SetupTotp(c echo.Context) (*account.TotpSetupVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	if acc.TotpEnabled {
+		return nil, ErrTotpAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTotpSecret()
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	key := fmt.Sprintf("%s%d", TotpPendingCacheKeyPrefix, acc.ID)
+	if err := cache.Current().Set(context.Background(), key, secret, TotpPendingExpiration); err != nil {
+		utils.BizLogger(c).Errorf("TOTP 密钥写入缓存失败: %v", err)
+		return nil, fmt.Errorf("TOTP 密钥写入缓存失败: %v", err)
+	}
+
+	return &account.TotpSetupVo{
+		Secret:     secret,
+		OtpauthURL: utils.TotpURI(totpIssuer(), acc.Email, secret),
+		ExpiresIn:  int(TotpPendingExpiration.Seconds()),
+	}, nil
+}
+
+// EnableTotp 校验验证器的动态码并开启两步验证，同时生成恢复码
+func EnableTotp(req *dto.EnableTotpRequest, c echo.Context) (*account.TotpRecoveryCodesVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	if acc.TotpEnabled {
+		return nil, ErrTotpAlreadyEnabled
+	}
+
+	key := fmt.Sprintf("%s%d", TotpPendingCacheKeyPrefix, acc.ID)
+	secret, err := cache.Current().Get(context.Background(), key)
+	if err != nil {
+		utils.BizLogger(c).Errorf("读取待确认的 TOTP 密钥失败: %v", err)
+		return nil, ErrTotpSetupExpired
+	}
+	if _, ok := utils.ValidateTotp(secret, req.TotpCode, time.Now()); !ok {
+		utils.BizLogger(c).Errorf("开启两步验证时动态码错误，账户 ID: %d", acc.ID)
+		return nil, ErrTotpCodeInvalid
+	}
+
+	codes, err := resetRecoveryCodes(acc, c)
+	if err != nil {
+		return nil, err
+	}
+	acc.TotpSecret, acc.TotpEnabled = secret, true
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("开启两步验证失败: %v", err)
+		return nil, fmt.Errorf("开启两步验证失败: %v", err)
+	}
+	cache.Current().Del(context.Background(), key)
+	utils.BizLogger(c).Infof("账户 %d 开启两步验证", acc.ID)
+	return &account.TotpRecoveryCodesVo{RecoveryCodes: codes}, nil
+}
+
+// RegenerateRecoveryCodes 为当前用户重新生成恢复码，旧恢复码全部失效，调用前需已校验动态码
+func RegenerateRecoveryCodes(c echo.Context) (*account.TotpRecoveryCodesVo, error) {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return nil, err
+	}
+	if !acc.TotpEnabled {
+		return nil, ErrTotpNotEnabled
+	}
+
+	codes, err := resetRecoveryCodes(acc, c)
+	if err != nil {
+		return nil, err
+	}
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("保存恢复码失败: %v", err)
+		return nil, fmt.Errorf("保存恢复码失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("账户 %d 重新生成恢复码", acc.ID)
+	return &account.TotpRecoveryCodesVo{RecoveryCodes: codes}, nil
+}
+
+// DisableTotp 关闭当前用户的两步验证，调用前需已校验动态码或邮箱验证码
+func DisableTotp(req *dto.DisableTotpRequest, c echo.Context) error {
+	acc, err := currentAccount(c)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(acc.Email, req.Email) {
+		utils.BizLogger(c).Errorf("关闭两步验证的邮箱与当前用户不一致，账户 ID: %d", acc.ID)
+		return fmt.Errorf("邮箱与当前用户不一致")
+	}
+
+	acc.TotpSecret, acc.TotpEnabled, acc.TotpRecoveryCodes = "", false, ""
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("关闭两步验证失败: %v", err)
+		return fmt.Errorf("关闭两步验证失败: %v", err)
+	}
+	sendSecurityNotice(acc.Email, SecurityEventTotpDisabled, "", c)
+	utils.BizLogger(c).Infof("账户 %d 关闭两步验证", acc.ID)
+	return nil
+}
+
+// totpIssuer 验证器中显示的服务名称，使用站点标题
+func totpIssuer() string {
+	if config, err := configs.LoadConfig(); err == nil && config.SiteConfig.SiteTitle != "" {
+		return config.SiteConfig.SiteTitle
+	}
+	return "Jank Blog"
+}
+
+// resetRecoveryCodes 为用户生成新的恢复码并写入摘要，调用方负责保存账户
+func resetRecoveryCodes(acc *model.Account, c echo.Context) ([]string, error) {
+	count := defaultRecoveryCodeCount
+	if config, err := configs.LoadConfig(); err == nil && config.TotpConfig.TotpRecoveryCodeCount > 0 {
+		count = config.TotpConfig.TotpRecoveryCodeCount
+	}
+	codes, hashes, err := utils.GenerateRecoveryCodes(count)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	acc.TotpRecoveryCodes = strings.Join(hashes, ",")
+	return codes, nil
+}
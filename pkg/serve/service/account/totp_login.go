@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/cache"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+const (
+	TotpLoginCacheKeyPrefix         = "TOTP:LOGIN:"          // 待提交动态码的登录凭证，键为前缀加凭证，值为账户 ID
+	TotpLoginAttemptsCacheKeyPrefix = "TOTP:LOGIN:ATTEMPTS:" // 登录凭证下恢复码的错误次数，键为前缀加凭证
+	defaultTotpLoginTimeout         = 5 * time.Minute        // 未配置时提交动态码的有效期
+	maxRecoveryCodeAttempts         = 5                      // 同一登录凭证允许输错恢复码的次数
+)
+
+var (
+	ErrTotpLoginTicketInvalid = errors.New("登录凭证无效或已过期，请重新登录")
+	ErrRecoveryCodeInvalid    = errors.New("恢复码错误或已使用")
+)
+
+// completeLogin 用户通过密码或第三方登录校验后调用，开启两步验证的账户返回登录凭证，否则直接签发 token
+// method 为登录方式，随登录凭证保存，提交动态码后记入登录记录；账户已停用时返回 AccountDisabledError
+func completeLogin(acc *model.Account, method string, c echo.Context) (*account.LoginVo, error) {
+	if err := checkAccountEnabled(acc, method, c); err != nil {
+		return nil, err
+	}
+	if !acc.TotpEnabled {
+		return issueLoginTokens(acc, method, "", c)
+	}
+
+	ticket, err := randomHex()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成两步验证登录凭证失败: %v", err)
+		return nil, fmt.Errorf("生成两步验证登录凭证失败: %v", err)
+	}
+	if err := cache.Current().Set(context.Background(), TotpLoginCacheKeyPrefix+ticket, fmt.Sprintf("%d:%s", acc.ID, method), totpLoginTimeout()); err != nil {
+		utils.BizLogger(c).Errorf("两步验证登录凭证写入缓存失败: %v", err)
+		return nil, fmt.Errorf("两步验证登录凭证写入缓存失败: %v", err)
+	}
+	return &account.LoginVo{TotpRequired: true, TotpTicket: ticket}, nil
+}
+
+// TotpLoginAccount 获取登录凭证对应的用户，凭证在登录完成前保持有效
+func TotpLoginAccount(ticket string, c echo.Context) (*model.Account, error) {
+	value, err := cache.Current().Get(context.Background(), TotpLoginCacheKeyPrefix+ticket)
+	if err != nil {
+		utils.BizLogger(c).Errorf("两步验证登录凭证不存在或已过期: %v", err)
+		return nil, ErrTotpLoginTicketInvalid
+	}
+	id, _, _ := strings.Cut(value, ":")
+	accountID, _ := strconv.ParseInt(id, 10, 64)
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取用户失败: %v", err)
+		return nil, fmt.Errorf("获取用户失败: %v", err)
+	}
+	if !acc.TotpEnabled {
+		// 签发凭证后两步验证已被关闭，需重新登录
+		return nil, ErrTotpLoginTicketInvalid
+	}
+	return acc, nil
+}
+
+// UseRecoveryCode 校验并作废恢复码，同一登录凭证输错次数达到上限后凭证失效
+func UseRecoveryCode(acc *model.Account, ticket, code string, c echo.Context) error {
+	ctx := context.Background()
+	attemptsKey := TotpLoginAttemptsCacheKeyPrefix + ticket
+
+	hash := utils.HashRecoveryCode(code)
+	hashes := strings.Split(acc.TotpRecoveryCodes, ",")
+	for i, stored := range hashes {
+		if stored == "" || subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) != 1 {
+			continue
+		}
+		acc.TotpRecoveryCodes = strings.Join(append(hashes[:i:i], hashes[i+1:]...), ",")
+		if err := mapper.UpdateAccount(acc); err != nil {
+			utils.BizLogger(c).Errorf("作废恢复码失败: %v", err)
+			return fmt.Errorf("作废恢复码失败: %v", err)
+		}
+		cache.Current().Del(ctx, attemptsKey)
+		utils.BizLogger(c).Infof("账户 %d 使用恢复码登录，剩余 %d 个", acc.ID, len(hashes)-1)
+		return nil
+	}
+
+	recordLoginFailure(acc.ID, acc.Email, totpLoginMethod(ticket), model.SecondFactorRecoveryCode, "恢复码错误", c)
+	attempts, err := cache.Current().Incr(ctx, attemptsKey, totpLoginTimeout())
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录恢复码错误次数失败: %v", err)
+	}
+	if attempts >= maxRecoveryCodeAttempts {
+		cache.Current().Del(ctx, TotpLoginCacheKeyPrefix+ticket, attemptsKey)
+	}
+	utils.BizLogger(c).Errorf("恢复码错误，账户 ID: %d", acc.ID)
+	return ErrRecoveryCodeInvalid
+}
+
+// FinishTotpLogin 作废登录凭证并签发 token，调用前需已校验动态码或恢复码，usedRecoveryCode 表示使用恢复码完成验证
+func FinishTotpLogin(acc *model.Account, ticket string, usedRecoveryCode bool, c echo.Context) (*account.LoginVo, error) {
+	method := totpLoginMethod(ticket)
+	cache.Current().Del(context.Background(), TotpLoginCacheKeyPrefix+ticket, TotpLoginAttemptsCacheKeyPrefix+ticket)
+	secondFactor := model.SecondFactorTotp
+	if usedRecoveryCode {
+		secondFactor = model.SecondFactorRecoveryCode
+	}
+	return issueLoginTokens(acc, method, secondFactor, c)
+}
+
+// totpLoginMethod 读取登录凭证中保存的登录方式，凭证不存在或未保存登录方式时视为密码登录
+func totpLoginMethod(ticket string) string {
+	value, err := cache.Current().Get(context.Background(), TotpLoginCacheKeyPrefix+ticket)
+	if err != nil {
+		return model.LoginMethodPassword
+	}
+	if _, method, ok := strings.Cut(value, ":"); ok && method != "" {
+		return method
+	}
+	return model.LoginMethodPassword
+}
+
+// totpLoginTimeout 读取提交动态码的有效期
+func totpLoginTimeout() time.Duration {
+	if config, err := configs.LoadConfig(); err == nil && config.TotpConfig.TotpLoginTimeout > 0 {
+		return time.Duration(config.TotpConfig.TotpLoginTimeout) * time.Second
+	}
+	return defaultTotpLoginTimeout
+}
@@ -0,0 +1,509 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/session"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/account/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/account"
+)
+
+// adminRecentLogins 用户详情中返回的最近登录记录数量
+const adminRecentLogins = 10
+
+var (
+	ErrUserNotFound       = errors.New("用户不存在")
+	ErrAdminSelfOperation = errors.New("不能对自己的账户执行该操作")
+	ErrLastAdmin          = errors.New("不能停用或降级站点唯一的管理员")
+	ErrPasswordTooWeak    = errors.New("密码不符合安全要求")
+	ErrRoleNotFound       = errors.New("角色不存在")
+	ErrBanNotFound        = errors.New("停用记录不存在")
+)
+
+// AccountDisabledError 账户已被管理员停用或未通过注册审核，不能登录；
+// Status 为空时表示已停用，Reason 为停用原因，Until 为限时停用的到期时间，否则为注册审核状态，Reason 为拒绝原因
+type AccountDisabledError struct {
+	Status string
+	Reason string
+	Until  int64
+}
+
+func (e *AccountDisabledError) Error() string {
+	switch e.Status {
+	case model.ApprovalStatusPending:
+		return "账户正在等待管理员审核"
+	case model.ApprovalStatusRejected:
+		return "账户注册申请未通过审核"
+	default:
+		return "账户已被停用"
+	}
+}
+
+// ListUsers 分页获取用户列表，按注册时间倒序排列
+func ListUsers(req *dto.ListUsersRequest, page vo.PageRequest, c echo.Context) ([]*account.AdminUserVo, *vo.PageMeta, error) {
+	accounts, total, err := mapper.GetAccountsWithPaging(req.Keyword, req.Role, req.Status, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, nil, err
+	}
+
+	ids := make([]int64, len(accounts))
+	for i, acc := range accounts {
+		ids[i] = acc.ID
+	}
+	roleCodes, err := mapper.GetRoleCodesByAccountIDs(ids)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, nil, err
+	}
+
+	result := make([]*account.AdminUserVo, len(accounts))
+	for i, acc := range accounts {
+		result[i] = adminUserVo(acc, roleCodes[acc.ID])
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// GetUser 获取用户详情，包括登录方式、会话数量与最近的登录记录
+func GetUser(req *dto.AdminUserRequest, c echo.Context) (*account.AdminUserDetailVo, error) {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return nil, err
+	}
+	roleCodes, err := mapper.GetRoleCodesByAccountIDs([]int64{acc.ID})
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	detail := &account.AdminUserDetailVo{
+		AdminUserVo:    *adminUserVo(acc, roleCodes[acc.ID]),
+		Bio:            acc.Bio,
+		Website:        acc.Website,
+		SocialLinks:    acc.SocialLinks(),
+		Messengers:     acc.MessengerProviders(),
+		LinkedAccounts: []string{},
+	}
+	if detail.Messengers == nil {
+		detail.Messengers = []string{}
+	}
+
+	identities, err := mapper.GetOAuthIdentitiesByAccountID(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	for _, identity := range identities {
+		detail.LinkedAccounts = append(detail.LinkedAccounts, identity.Provider)
+	}
+	credentials, err := mapper.GetWebAuthnCredentialsByAccountID(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	detail.Passkeys = len(credentials)
+	keys, err := mapper.GetAPIKeysByAccountID(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	detail.APIKeys = len(keys)
+	states, err := session.List(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	detail.ActiveSessions = len(states)
+
+	records, _, err := mapper.GetLoginRecordsWithPaging(acc.ID, 0, adminRecentLogins)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	detail.RecentLogins = make([]*account.LoginRecordVo, len(records))
+	for i, record := range records {
+		detail.RecentLogins[i] = loginRecordVo(record)
+	}
+	return detail, nil
+}
+
+// AdminResetPassword 为用户设置新密码，新密码需满足密码强度策略，设置后用户的全部设备随即下线
+func AdminResetPassword(req *dto.AdminResetPasswordRequest, c echo.Context) error {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return err
+	}
+	if err := password.Check(req.NewPassword, acc.Email); err != nil {
+		return fmt.Errorf("%w: %v", ErrPasswordTooWeak, err)
+	}
+
+	hashed, err := password.Hash(req.NewPassword)
+	if err != nil {
+		utils.BizLogger(c).Errorf("密码加密失败: %v", err)
+		return fmt.Errorf("密码加密失败: %v", err)
+	}
+	acc.Password = hashed
+	acc.PasswordAlgorithm = password.Identify(hashed)
+	if err := mapper.UpdateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("重置账户 %d 的密码失败: %v", acc.ID, err)
+		return fmt.Errorf("重置账户 %d 的密码失败: %v", acc.ID, err)
+	}
+	if _, err := endAllSessions(acc.ID, model.SessionEndForceLogout, c); err != nil {
+		return err
+	}
+	sendSecurityNotice(acc.Email, SecurityEventPasswordAdminReset, "", c)
+
+	recordAdminAction(acc.ID, model.AdminActionResetPassword, "", c)
+	return nil
+}
+
+// ForceLogout 强制用户的全部设备下线，此前签发的 refresh token 均不能再刷新
+func ForceLogout(req *dto.AdminUserRequest, c echo.Context) (*account.ForceLogoutVo, error) {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return nil, err
+	}
+	count, err := endAllSessions(acc.ID, model.SessionEndForceLogout, c)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAdminAction(acc.ID, model.AdminActionForceLogout, fmt.Sprintf("结束 %d 个会话", count), c)
+	return &account.ForceLogoutVo{Sessions: count}, nil
+}
+
+// ChangeUserRole 变更用户角色，不能变更自己的角色，也不能降级站点唯一的管理员
+func ChangeUserRole(req *dto.ChangeUserRoleRequest, c echo.Context) error {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return err
+	}
+	if err := checkNotSelf(acc.ID, c); err != nil {
+		return err
+	}
+	role, err := mapper.GetRoleByCode(req.RoleCode)
+	if err != nil {
+		return ErrRoleNotFound
+	}
+
+	previous := ""
+	if codes, err := mapper.GetRoleCodesByAccountIDs([]int64{acc.ID}); err == nil {
+		previous = codes[acc.ID]
+	}
+	if previous == role.Code {
+		return nil
+	}
+	if previous == model.RoleCodeAdmin {
+		if err := checkOtherAdmins(acc, c); err != nil {
+			return err
+		}
+	}
+
+	if err := setAccountRole(acc, role.Code, c); err != nil {
+		return err
+	}
+	recordAdminAction(acc.ID, model.AdminActionChangeRole, fmt.Sprintf("%s -> %s", previous, role.Code), c)
+	return nil
+}
+
+// DisableUser 停用用户，停用期间不能登录，已签发的登录状态随即失效；指定时长时到期后自动解除，否则永久停用；
+// 不能停用自己，也不能停用站点唯一的管理员；已停用的用户再次停用时按本次的原因与时长重新计算
+func DisableUser(req *dto.DisableUserRequest, c echo.Context) error {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return err
+	}
+	if err := checkNotSelf(acc.ID, c); err != nil {
+		return err
+	}
+	now := time.Now()
+	if !acc.Disabled(now.Unix()) {
+		if err := checkOtherAdmins(acc, c); err != nil {
+			return err
+		}
+		acc.DisabledAt = now.Unix()
+	}
+	acc.DisabledUntil = 0
+	if req.DurationHours > 0 {
+		acc.DisabledUntil = now.Add(time.Duration(req.DurationHours) * time.Hour).Unix()
+	}
+	acc.DisabledReason = req.Reason
+
+	operatorID, _ := CurrentAccountID(c)
+	ban := &model.AccountBan{
+		OperatorID: operatorID,
+		Reason:     req.Reason,
+		ExpiresAt:  acc.DisabledUntil,
+	}
+	if err := mapper.BanAccount(acc, ban, now.Unix()); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if _, err := endAllSessions(acc.ID, model.SessionEndDisabled, c); err != nil {
+		return err
+	}
+
+	detail := req.Reason
+	if acc.DisabledUntil > 0 {
+		detail = fmt.Sprintf("停用至 %s: %s", time.Unix(acc.DisabledUntil, 0).Format("2006-01-02 15:04"), req.Reason)
+	}
+	recordAdminAction(acc.ID, model.AdminActionDisable, detail, c)
+	return nil
+}
+
+// EnableUser 重新启用已停用的用户并解除停用记录，未停用时不做处理
+func EnableUser(req *dto.EnableUserRequest, c echo.Context) error {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return err
+	}
+	if acc.DisabledAt == 0 {
+		return nil
+	}
+
+	operatorID, _ := CurrentAccountID(c)
+	if err := mapper.LiftAccountBan(acc, operatorID, req.Reason, time.Now().Unix()); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+
+	recordAdminAction(acc.ID, model.AdminActionEnable, req.Reason, c)
+	return nil
+}
+
+// ListUserBans 获取用户的全部停用记录，按停用时间倒序排列
+func ListUserBans(req *dto.AdminUserRequest, c echo.Context) ([]*account.AccountBanVo, error) {
+	acc, err := adminTarget(req.ID, c)
+	if err != nil {
+		return nil, err
+	}
+	bans, err := mapper.GetAccountBansByAccountID(acc.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	result := make([]*account.AccountBanVo, len(bans))
+	for i, ban := range bans {
+		result[i] = accountBanVo(ban)
+	}
+	return result, nil
+}
+
+// UpdateBanAppeal 记录用户对停用的申诉内容与处理情况，覆盖原有的申诉备注，不影响停用状态
+func UpdateBanAppeal(req *dto.UpdateBanAppealRequest, c echo.Context) (*account.AccountBanVo, error) {
+	ban, err := mapper.GetAccountBanByID(req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, ErrBanNotFound
+	}
+	if err := mapper.UpdateAccountBanAppeal(ban.ID, req.AppealNote); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	ban.AppealNote = req.AppealNote
+
+	recordAdminAction(ban.AccountID, model.AdminActionBanAppeal, fmt.Sprintf("停用记录 %d: %s", ban.ID, req.AppealNote), c)
+	return accountBanVo(ban), nil
+}
+
+// ListAdminAuditLogs 分页获取管理员操作审计日志，按时间倒序排列
+func ListAdminAuditLogs(req *dto.ListAdminAuditLogsRequest, page vo.PageRequest, c echo.Context) ([]*account.AdminAuditLogVo, *vo.PageMeta, error) {
+	logs, total, err := mapper.GetAdminAuditLogsWithPaging(req.TargetID, req.OperatorID, req.Action, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, nil, err
+	}
+
+	result := make([]*account.AdminAuditLogVo, len(logs))
+	for i, log := range logs {
+		result[i] = &account.AdminAuditLogVo{
+			ID:         log.ID,
+			Time:       log.GmtCreate,
+			OperatorID: log.OperatorID,
+			TargetID:   log.TargetID,
+			Action:     log.Action,
+			Detail:     log.Detail,
+			IP:         log.IP,
+		}
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// checkAccountEnabled 账户已停用、待审核或未通过注册审核时记录登录失败并返回 AccountDisabledError，在身份校验通过后、签发 token 前调用
+func checkAccountEnabled(acc *model.Account, method string, c echo.Context) error {
+	liftExpiredBan(acc, c)
+	var err *AccountDisabledError
+	switch {
+	case acc.Disabled(time.Now().Unix()):
+		err = &AccountDisabledError{Reason: acc.DisabledReason, Until: acc.DisabledUntil}
+	case acc.ApprovalStatus == model.ApprovalStatusPending:
+		err = &AccountDisabledError{Status: acc.ApprovalStatus}
+	case acc.ApprovalStatus == model.ApprovalStatusRejected:
+		err = &AccountDisabledError{Status: acc.ApprovalStatus, Reason: acc.ApprovalReason}
+	default:
+		return nil
+	}
+	utils.BizLogger(c).Errorf("账户 %d %s，拒绝登录", acc.ID, err.Error())
+	recordLoginFailure(acc.ID, acc.Email, method, "", err.Error(), c)
+	return err
+}
+
+// liftExpiredBan 限时停用已到期但定时任务尚未处理时立即解除，用户无需等待定时任务即可登录
+func liftExpiredBan(acc *model.Account, c echo.Context) {
+	now := time.Now().Unix()
+	if acc.DisabledAt == 0 || acc.Disabled(now) {
+		return
+	}
+	lifted, err := mapper.LiftExpiredAccountBan(acc.ID, now)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return
+	}
+	if lifted {
+		acc.DisabledAt, acc.DisabledUntil, acc.DisabledReason = 0, 0, ""
+		utils.BizLogger(c).Infof("账户 %d 的限时停用已到期，自动解除", acc.ID)
+	}
+}
+
+// adminTarget 获取被操作的用户，账户不存在时返回 ErrUserNotFound
+func adminTarget(accountID int64, c echo.Context) (*model.Account, error) {
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取账户 %d 失败: %v", accountID, err)
+		return nil, ErrUserNotFound
+	}
+	return acc, nil
+}
+
+// checkNotSelf 被操作的账户是当前管理员自己时返回 ErrAdminSelfOperation，避免管理员误将自己锁在站点外
+func checkNotSelf(accountID int64, c echo.Context) error {
+	operatorID, err := CurrentAccountID(c)
+	if err != nil {
+		return err
+	}
+	if operatorID == accountID {
+		return ErrAdminSelfOperation
+	}
+	return nil
+}
+
+// checkOtherAdmins 账户是站点唯一可用的管理员时返回 ErrLastAdmin
+func checkOtherAdmins(acc *model.Account, c echo.Context) error {
+	codes, err := mapper.GetRoleCodesByAccountIDs([]int64{acc.ID})
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if codes[acc.ID] != model.RoleCodeAdmin {
+		return nil
+	}
+	count, err := mapper.CountEnabledAccountsByRoleCode(model.RoleCodeAdmin)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return err
+	}
+	if count <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// endAllSessions 吊销账户全部的 refresh token 与设备令牌并结束全部会话，返回结束的会话数量
+func endAllSessions(accountID int64, reason string, c echo.Context) (int, error) {
+	if err := utils.RevokeAllRefreshTokens(accountID); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return 0, err
+	}
+	count, err := session.EndAll(accountID, "", reason)
+	if err != nil {
+		utils.BizLogger(c).Errorf("结束会话失败: %v", err)
+		return 0, fmt.Errorf("结束会话失败: %v", err)
+	}
+	revokeDeviceTokens(accountID, "", c)
+	return count, nil
+}
+
+// recordAdminAction 写入管理员操作审计日志，并同步输出到系统日志，写入失败时仅记录日志，不影响已完成的操作
+func recordAdminAction(targetID int64, action, detail string, c echo.Context) {
+	operatorID, _ := CurrentAccountID(c)
+	if len(detail) > 255 {
+		detail = detail[:255]
+	}
+	log := &model.AdminAuditLog{
+		OperatorID: operatorID,
+		TargetID:   targetID,
+		Action:     action,
+		Detail:     detail,
+		IP:         c.RealIP(),
+	}
+	if err := mapper.CreateAdminAuditLog(log); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+	}
+	global.SysLog.WithFields(logrus.Fields{
+		"audit":    "admin_user",
+		"operator": operatorID,
+		"target":   targetID,
+		"action":   action,
+		"ip":       log.IP,
+	}).Infof("管理员 %d 对账户 %d 执行 %s", operatorID, targetID, action)
+}
+
+// adminUserVo 将账户映射为用户列表中的用户
+func adminUserVo(acc *model.Account, roleCode string) *account.AdminUserVo {
+	status := mapper.AccountStatusActive
+	switch {
+	case acc.Disabled(time.Now().Unix()):
+		status = mapper.AccountStatusDisabled
+	case acc.ApprovalStatus == model.ApprovalStatusPending:
+		status = mapper.AccountStatusPendingApproval
+	case acc.ApprovalStatus == model.ApprovalStatusRejected:
+		status = mapper.AccountStatusRejected
+	case acc.DeletionScheduledAt > 0:
+		status = mapper.AccountStatusPendingDeletion
+	}
+	return &account.AdminUserVo{
+		ID:                  acc.ID,
+		Email:               acc.Email,
+		Phone:               acc.Phone,
+		Nickname:            acc.Nickname,
+		DisplayName:         acc.DisplayName,
+		Avatar:              AvatarURL(acc),
+		RoleCode:            roleCode,
+		TotpEnabled:         acc.TotpEnabled,
+		Status:              status,
+		DisabledAt:          acc.DisabledAt,
+		DisabledUntil:       acc.DisabledUntil,
+		DisabledReason:      acc.DisabledReason,
+		DeletionScheduledAt: acc.DeletionScheduledAt,
+		ApprovalReason:      acc.ApprovalReason,
+		CreatedAt:           acc.GmtCreate,
+	}
+}
+
+// accountBanVo 将停用记录映射为 vo
+func accountBanVo(ban *model.AccountBan) *account.AccountBanVo {
+	return &account.AccountBanVo{
+		ID:         ban.ID,
+		AccountID:  ban.AccountID,
+		OperatorID: ban.OperatorID,
+		Reason:     ban.Reason,
+		CreatedAt:  ban.GmtCreate,
+		ExpiresAt:  ban.ExpiresAt,
+		LiftedAt:   ban.LiftedAt,
+		LiftedBy:   ban.LiftedBy,
+		LiftReason: ban.LiftReason,
+		AppealNote: ban.AppealNote,
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/bookmark/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/bookmark"
+)
+
+const (
+	BookmarkCountCache           = "BOOKMARK:COUNT" // 文章收藏数缓存
+	BookmarkCountCacheExpireTime = time.Hour        // 文章收藏数缓存有效期，收藏变更时主动失效
+)
+
+// AddBookmark 收藏文章
+func AddBookmark(req *dto.BookmarkRequest, c echo.Context) (*bookmark.BookmarkStateVo, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	if _, err := mapper.GetPostByID(req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+
+	if err := mapper.SaveBookmark(accountID, req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("收藏文章失败: %v", err)
+		return nil, fmt.Errorf("收藏文章失败: %v", err)
+	}
+	invalidateBookmarkCount(req.PostID, c)
+
+	return bookmarkStateVo(req.PostID, accountID, c)
+}
+
+// RemoveBookmark 取消收藏文章
+func RemoveBookmark(req *dto.BookmarkRequest, c echo.Context) (*bookmark.BookmarkStateVo, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	if err := mapper.DeleteBookmarkSoftly(accountID, req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("取消收藏失败: %v", err)
+		return nil, fmt.Errorf("取消收藏失败: %v", err)
+	}
+	invalidateBookmarkCount(req.PostID, c)
+
+	return bookmarkStateVo(req.PostID, accountID, c)
+}
+
+// ListBookmarks 获取当前用户的收藏分页列表，已删除的文章不展示
+func ListBookmarks(page vo.PageRequest, c echo.Context) ([]*bookmark.BookmarkVo, *vo.PageMeta, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, nil, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	records, total, err := mapper.GetBookmarksWithPaging(accountID, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取收藏列表失败: %v", err)
+		return nil, nil, fmt.Errorf("获取收藏列表失败: %v", err)
+	}
+
+	postIDs := make([]int64, len(records))
+	for i, record := range records {
+		postIDs[i] = record.PostID
+	}
+	posts, err := mapper.GetPostsByIDs(postIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取收藏的文章失败: %v", err)
+		return nil, nil, fmt.Errorf("获取收藏的文章失败: %v", err)
+	}
+
+	result := make([]*bookmark.BookmarkVo, 0, len(records))
+	for _, record := range records {
+		for _, pos := range posts {
+			if pos.ID == record.PostID {
+				result = append(result, &bookmark.BookmarkVo{
+					PostID:       pos.ID,
+					Title:        pos.Title,
+					Image:        pos.Image,
+					BookmarkedAt: record.GmtModified,
+				})
+				break
+			}
+		}
+	}
+
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// BookmarkState 获取文章的收藏数与用户的收藏状态，收藏数优先读取缓存，accountID 为 0 时收藏状态为 false
+func BookmarkState(postID, accountID int64, c echo.Context) (int64, bool, error) {
+	count, err := bookmarkCount(postID, c)
+	if err != nil {
+		return 0, false, err
+	}
+	if accountID <= 0 {
+		return count, false, nil
+	}
+
+	bookmarked, err := mapper.IsPostBookmarked(accountID, postID)
+	if err != nil {
+		return 0, false, err
+	}
+	return count, bookmarked, nil
+}
+
+// bookmarkStateVo 生成文章收藏状态 vo
+func bookmarkStateVo(postID, accountID int64, c echo.Context) (*bookmark.BookmarkStateVo, error) {
+	count, bookmarked, err := BookmarkState(postID, accountID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取收藏状态失败: %v", err)
+		return nil, fmt.Errorf("获取收藏状态失败: %v", err)
+	}
+	return &bookmark.BookmarkStateVo{PostID: postID, BookmarkCount: count, Bookmarked: bookmarked}, nil
+}
+
+// bookmarkCount 获取文章收藏数，Redis 不可用时直接查询数据库
+func bookmarkCount(postID int64, c echo.Context) (int64, error) {
+	ctx := c.Request().Context()
+	cacheKey := fmt.Sprintf("%s:%d", BookmarkCountCache, postID)
+	if global.RedisClient != nil {
+		if cached, err := global.RedisClient.Get(ctx, cacheKey).Result(); err == nil {
+			if count, err := strconv.ParseInt(cached, 10, 64); err == nil {
+				return count, nil
+			}
+		}
+	}
+
+	count, err := mapper.CountBookmarksByPostID(postID)
+	if err != nil {
+		return 0, err
+	}
+
+	if global.RedisClient != nil {
+		if err := global.RedisClient.Set(ctx, cacheKey, count, BookmarkCountCacheExpireTime).Err(); err != nil {
+			utils.BizLogger(c).Errorf("缓存文章收藏数失败: %v", err)
+		}
+	}
+	return count, nil
+}
+
+// invalidateBookmarkCount 收藏变更后清除文章收藏数缓存，失败时仅记录日志
+func invalidateBookmarkCount(postID int64, c echo.Context) {
+	if global.RedisClient == nil {
+		return
+	}
+	cacheKey := fmt.Sprintf("%s:%d", BookmarkCountCache, postID)
+	if err := global.RedisClient.Del(c.Request().Context(), cacheKey).Err(); err != nil {
+		utils.BizLogger(c).Errorf("清除文章收藏数缓存失败: %v", err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/category/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	sitemapService "jank.com/jank_blog/pkg/serve/service/sitemap"
 	"jank.com/jank_blog/pkg/vo/category"
 )
 
@@ -163,6 +164,7 @@ func CreateCategory(req *dto.CreateOneCategoryRequest, c echo.Context) (*categor
 			utils.BizLogger(c).Errorf("创建根类目失败：%v", err)
 			return nil, fmt.Errorf("创建根类目失败: %v", err)
 		}
+		sitemapService.Invalidate()
 
 		categoryVo, err := utils.MapModelToVO(newCategory, &category.CategoriesVo{})
 		if err != nil {
@@ -189,6 +191,7 @@ func CreateCategory(req *dto.CreateOneCategoryRequest, c echo.Context) (*categor
 		utils.BizLogger(c).Errorf("创建子类目失败：%v", err)
 		return nil, fmt.Errorf("创建子类目失败: %v", err)
 	}
+	sitemapService.Invalidate()
 
 	categoryVo, err := utils.MapModelToVO(newCategory, &category.CategoriesVo{})
 	if err != nil {
@@ -226,6 +229,7 @@ func UpdateCategory(req *dto.UpdateOneCategoryRequest, c echo.Context) (*categor
 		utils.BizLogger(c).Errorf("递归更新「%v」类目失败: %v", existingCategory.Name, err)
 		return nil, fmt.Errorf("递归更新「%v」类目失败: %v", existingCategory.Name, err)
 	}
+	sitemapService.Invalidate()
 
 	var convert func(cat *model.Category) (*category.CategoriesVo, error)
 	convert = func(cat *model.Category) (*category.CategoriesVo, error) {
@@ -273,6 +277,7 @@ func DeleteCategory(req *dto.DeleteOneCategoryRequest, c echo.Context) ([]*categ
 		utils.BizLogger(c).Errorf("软删除「%v」下所有子类目失败：%v", cat.Name, err)
 		return nil, fmt.Errorf("软删除「%v」下所有子类目失败：%v", cat.Name, err)
 	}
+	sitemapService.Invalidate()
 
 	var convert func(cat *model.Category) (*category.CategoriesVo, error)
 	convert = func(cat *model.Category) (*category.CategoriesVo, error) {
@@ -5,17 +5,26 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"jank.com/jank_blog/internal/global"
 	model "jank.com/jank_blog/internal/model/comment"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/comment/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
 	"jank.com/jank_blog/pkg/vo/comment"
 )
 
-// CreateComment 创建评论
+// CreateComment 创建评论，评论内容按 Markdown 渲染并过滤后保存
 func CreateComment(req *dto.CreateCommentRequest, c echo.Context) (*comment.CommentsVo, error) {
+	contentHTML, err := utils.RenderCommentMarkdown([]byte(req.Content))
+	if err != nil {
+		utils.BizLogger(c).Errorf("渲染评论失败：%v", err)
+		return nil, fmt.Errorf("渲染评论失败：%v", err)
+	}
+
 	com := &model.Comment{
 		Content:          req.Content,
+		ContentHTML:      contentHTML,
 		UserId:           req.UserId,
 		PostId:           req.PostId,
 		ReplyToCommentId: req.ReplyToCommentId,
@@ -25,6 +34,7 @@ func CreateComment(req *dto.CreateCommentRequest, c echo.Context) (*comment.Comm
 		utils.BizLogger(c).Errorf("创建评论失败：%v", err)
 		return nil, fmt.Errorf("创建评论失败：%v", err)
 	}
+	searchService.IndexComment(com)
 
 	commentVo, err := utils.MapModelToVO(com, &comment.CommentsVo{})
 	if err != nil {
@@ -130,6 +140,7 @@ func DeleteComment(req *dto.DeleteCommentRequest, c echo.Context) (*comment.Comm
 		utils.BizLogger(c).Errorf("软删除评论失败：%v", err)
 		return nil, fmt.Errorf("软删除评论失败：%v", err)
 	}
+	searchService.RemoveComment(com.ID)
 
 	commentVo, err := utils.MapModelToVO(com, &comment.CommentsVo{})
 	if err != nil {
@@ -139,3 +150,25 @@ func DeleteComment(req *dto.DeleteCommentRequest, c echo.Context) (*comment.Comm
 
 	return commentVo.(*comment.CommentsVo), nil
 }
+
+// BackfillCommentHTML 为升级前创建、尚未渲染 HTML 的评论渲染并过滤 HTML；启动时调用
+func BackfillCommentHTML() error {
+	comments, err := mapper.GetCommentsWithoutHTML()
+	if err != nil {
+		return fmt.Errorf("获取待渲染的评论失败: %v", err)
+	}
+
+	for _, com := range comments {
+		contentHTML, err := utils.RenderCommentMarkdown([]byte(com.Content))
+		if err != nil {
+			return fmt.Errorf("渲染评论 %d 失败: %v", com.ID, err)
+		}
+		if err := mapper.UpdateCommentHTML(com.ID, contentHTML); err != nil {
+			return fmt.Errorf("写入评论 %d 的 HTML 失败: %v", com.ID, err)
+		}
+	}
+	if len(comments) > 0 {
+		global.SysLog.Infof("已为 %d 条评论渲染 HTML", len(comments))
+	}
+	return nil
+}
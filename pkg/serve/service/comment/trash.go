@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/comment/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/comment"
+)
+
+// ErrCommentNotInTrash 回收站中不存在该评论
+var ErrCommentNotInTrash = errors.New("回收站中不存在该评论")
+
+// GetTrashedComments 分页获取回收站中的评论，按删除时间倒序排序，并计算预计永久删除的时间
+func GetTrashedComments(page vo.PageRequest, c echo.Context) ([]*comment.TrashCommentVo, *vo.PageMeta, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载回收站配置失败：%v", err)
+		return nil, nil, fmt.Errorf("加载回收站配置失败：%v", err)
+	}
+	comments, total, err := mapper.GetDeletedCommentsWithPaging(page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取回收站评论失败：%v", err)
+		return nil, nil, fmt.Errorf("获取回收站评论失败：%v", err)
+	}
+
+	days := config.TrashConfig.TrashCommentRetentionDays
+	result := make([]*comment.TrashCommentVo, len(comments))
+	for i, com := range comments {
+		item := &comment.TrashCommentVo{
+			ID:               com.ID,
+			Content:          com.Content,
+			UserId:           com.UserId,
+			PostId:           com.PostId,
+			ReplyToCommentId: com.ReplyToCommentId,
+			GmtCreate:        com.GmtCreate,
+			DeletedAt:        com.GmtModified,
+		}
+		if days > 0 {
+			item.PurgeAt = time.Unix(com.GmtModified, 0).AddDate(0, 0, days).Unix()
+		}
+		result[i] = item
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// RestoreComment 从回收站恢复评论，恢复后重新加入搜索索引
+func RestoreComment(req *dto.RestoreCommentRequest, c echo.Context) error {
+	com, err := mapper.GetDeletedCommentByID(req.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrCommentNotInTrash
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取回收站评论失败：%v", err)
+		return fmt.Errorf("获取回收站评论失败：%v", err)
+	}
+
+	restored, err := mapper.RestoreComment(com.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("恢复评论失败：%v", err)
+		return fmt.Errorf("恢复评论失败：%v", err)
+	}
+	if !restored {
+		return ErrCommentNotInTrash
+	}
+	com.Deleted = false
+
+	searchService.IndexComment(com)
+	utils.BizLogger(c).Infof("评论 %d 已从回收站恢复", com.ID)
+	return nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/disposable"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/disposable/dto"
+	vo "jank.com/jank_blog/pkg/vo/disposable"
+)
+
+// GetDisposableEmailStatus 获取一次性邮箱域名黑名单状态
+func GetDisposableEmailStatus(c echo.Context) (*vo.DisposableEmailVo, error) {
+	stats, err := disposable.Status(c.Request().Context())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取一次性邮箱域名失败: %v", err)
+		return nil, fmt.Errorf("获取一次性邮箱域名失败: %v", err)
+	}
+
+	result := &vo.DisposableEmailVo{
+		Loaded:  stats.Loaded,
+		Blocked: append([]string{}, stats.Blocked...),
+		Allowed: append([]string{}, stats.Allowed...),
+	}
+	if !stats.RefreshedAt.IsZero() {
+		result.RefreshedAt = stats.RefreshedAt.Unix()
+	}
+	return result, nil
+}
+
+// AddDisposableDomain 将域名加入一次性邮箱黑名单
+func AddDisposableDomain(req *dto.DomainRequest, c echo.Context) error {
+	domain, err := disposable.Add(c.Request().Context(), req.Domain)
+	if err != nil {
+		utils.BizLogger(c).Errorf("添加一次性邮箱域名失败: %v", err)
+		return fmt.Errorf("添加一次性邮箱域名失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("添加一次性邮箱域名: %s", domain)
+	return nil
+}
+
+// RemoveDisposableDomain 将域名移出一次性邮箱黑名单
+func RemoveDisposableDomain(req *dto.DomainRequest, c echo.Context) error {
+	domain, err := disposable.Remove(c.Request().Context(), req.Domain)
+	if err != nil {
+		utils.BizLogger(c).Errorf("移除一次性邮箱域名失败: %v", err)
+		return fmt.Errorf("移除一次性邮箱域名失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("移除一次性邮箱域名: %s", domain)
+	return nil
+}
+
+// RefreshDisposableDomains 立即重新加载一次性邮箱域名的文件与远程列表
+func RefreshDisposableDomains(c echo.Context) (int, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+	cfg := config.DisposableEmailConfig
+
+	count, err := disposable.Refresh(c.Request().Context(), disposable.Sources{File: cfg.DisposableEmailFile, URL: cfg.DisposableEmailURL})
+	if err != nil {
+		utils.BizLogger(c).Errorf("刷新一次性邮箱域名失败: %v", err)
+		return 0, fmt.Errorf("刷新一次性邮箱域名失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("刷新一次性邮箱域名，共 %d 个", count)
+	return count, nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+
+	bizErr "jank.com/jank_blog/internal/error"
+	"jank.com/jank_blog/pkg/vo/errcode"
+)
+
+// ListErrorCodes 获取错误码目录
+func ListErrorCodes(c echo.Context) []*errcode.ErrorCodeVo {
+	catalog := bizErr.Catalog()
+
+	codes := make([]*errcode.ErrorCodeVo, len(catalog))
+	for i, def := range catalog {
+		codes[i] = &errcode.ErrorCodeVo{
+			Code:        def.Code,
+			HTTPStatus:  def.HTTPStatus,
+			Message:     def.Message,
+			I18nKey:     def.I18nKey,
+			Description: def.Description,
+		}
+	}
+	return codes
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/export"
+	"jank.com/jank_blog/internal/feed"
+	"jank.com/jank_blog/internal/i18n"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+)
+
+// feedPageSize JSON Feed 每页的默认条目数
+const feedPageSize = 20
+
+// GetJSONFeed 按分页生成已发布文章的 JSON Feed 1.1 订阅源，存在下一页时通过 next_url 给出下一页的地址；
+// 传入 lang 时只包含该语言的文章，否则按默认语言合并翻译组
+func GetJSONFeed(c echo.Context) ([]byte, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载站点配置失败: %v", err)
+		return nil, fmt.Errorf("加载站点配置失败: %v", err)
+	}
+
+	access := []string{model.AccessPublic}
+	language, err := feedLanguage(c.QueryParam("lang"), access)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章语言失败: %v", err)
+		return nil, fmt.Errorf("获取文章语言失败: %v", err)
+	}
+
+	page := vo.ParsePage(c, feedPageSize)
+	var posts []*model.Post
+	var total int64
+	if c.QueryParam("lang") != "" {
+		posts, total, err = mapper.GetPublishedPostsByLanguageWithPaging(language, access, page.Offset(), page.Limit())
+	} else {
+		posts, total, err = mapper.GetAllPostsWithPaging(model.StatusPublished, access, language, false, page.Offset(), page.Limit())
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取已发布文章失败: %v", err)
+		return nil, fmt.Errorf("获取已发布文章失败: %v", err)
+	}
+
+	f, err := export.NewPostFeed(export.FeedOptions{
+		SiteTitle:  config.SiteConfig.SiteTitle,
+		SiteURL:    config.SiteConfig.SiteURL,
+		SiteAuthor: config.SiteConfig.SiteAuthor,
+		Language:   language,
+	}, posts)
+	if err != nil {
+		utils.BizLogger(c).Errorf("构建订阅源失败: %v", err)
+		return nil, fmt.Errorf("构建订阅源失败: %v", err)
+	}
+	f.FeedLink = strings.TrimRight(config.SiteConfig.SiteURL, "/") + "/feed.json"
+	if c.QueryParam("lang") != "" {
+		f.FeedLink += "?" + url.Values{"lang": {language}}.Encode()
+	}
+
+	if meta := vo.NewPageMeta(page, total); meta.HasNext {
+		query := url.Values{"cursor": {meta.NextCursor}}
+		if page.PageSize != feedPageSize {
+			query.Set("pageSize", strconv.Itoa(page.PageSize))
+		}
+		if c.QueryParam("lang") != "" {
+			query.Set("lang", language)
+		}
+		f.NextLink = strings.TrimRight(config.SiteConfig.SiteURL, "/") + "/feed.json?" + query.Encode()
+	}
+
+	body, err := feed.BuildJSON(f)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	return body, nil
+}
+
+// feedLanguage 订阅源的语言，传入 lang 时在公开文章使用的语言中匹配，未匹配时按规范化后的 lang 返回空的订阅源；
+// 未传入时使用默认语言
+func feedLanguage(lang string, access []string) (string, error) {
+	if lang == "" {
+		return model.DefaultLanguage, nil
+	}
+	languages, err := mapper.GetPublishedPostLanguages(access)
+	if err != nil {
+		return "", err
+	}
+	if tag, ok := i18n.MatchAmong(lang, languages); ok {
+		return tag, nil
+	}
+	return i18n.Normalize(lang), nil
+}
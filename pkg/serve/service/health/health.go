@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/breaker"
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo/health"
+)
+
+// 服务整体状态
+const (
+	StatusOK       = "ok"       // 各组件均正常
+	StatusDegraded = "degraded" // Redis 不可用或邮件发送熔断中，核心功能仍可用
+	StatusDown     = "down"     // 数据库不可用
+)
+
+const pingTimeout = 2 * time.Second // 检测依赖组件的超时时间
+
+// GetHealth 检测数据库与 Redis 连通性，并汇总缓存驱动与邮件发送熔断器状态
+func GetHealth(c echo.Context) *health.HealthVo {
+	result := &health.HealthVo{Errors: make(map[string]string)}
+	ctx, cancel := context.WithTimeout(c.Request().Context(), pingTimeout)
+	defer cancel()
+
+	if global.DB == nil {
+		result.Errors["database"] = "数据库未连接"
+	} else if sqlDB, err := global.DB.DB(); err != nil {
+		result.Errors["database"] = err.Error()
+	} else if err := sqlDB.PingContext(ctx); err != nil {
+		result.Errors["database"] = err.Error()
+	} else {
+		result.Database = true
+	}
+
+	if global.RedisClient == nil {
+		result.Errors["redis"] = "Redis 未连接"
+	} else if err := global.RedisClient.Ping(ctx).Err(); err != nil {
+		result.Errors["redis"] = err.Error()
+	} else {
+		result.Redis = true
+	}
+	result.Cache = cache.Current().Name()
+
+	st := utils.MailBreakerStatus()
+	result.MailBreaker = health.MailBreakerVo{State: st.State, Failures: st.Failures}
+	if !st.OpenedAt.IsZero() {
+		result.MailBreaker.OpenedAt = st.OpenedAt.Unix()
+	}
+	if !st.RetryAt.IsZero() {
+		result.MailBreaker.RetryAt = st.RetryAt.Unix()
+	}
+	if st.State != breaker.StateClosed {
+		result.Errors["mail"] = utils.ErrMailUnavailable.Error()
+	}
+
+	switch {
+	case !result.Database:
+		result.Status = StatusDown
+	case len(result.Errors) > 0:
+		result.Status = StatusDegraded
+	default:
+		result.Status = StatusOK
+	}
+	return result
+}
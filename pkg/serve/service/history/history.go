@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	account "jank.com/jank_blog/internal/model/account"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/history/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/history"
+)
+
+// RecordReading 记录阅读进度，用户关闭阅读历史时不记录，返回是否已记录
+func RecordReading(req *dto.RecordReadingRequest, c echo.Context) (bool, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return false, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取用户失败: %v", err)
+		return false, fmt.Errorf("获取用户失败: %v", err)
+	}
+	if !acc.Preference(account.PreferenceReadingHistory, true) {
+		return false, nil
+	}
+
+	if _, err := mapper.GetPostByID(req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return false, fmt.Errorf("文章不存在: %v", err)
+	}
+
+	if err := mapper.SaveReadingHistory(accountID, req.PostID, req.Position, time.Now().Unix()); err != nil {
+		utils.BizLogger(c).Errorf("记录阅读历史失败: %v", err)
+		return false, fmt.Errorf("记录阅读历史失败: %v", err)
+	}
+	return true, nil
+}
+
+// GetReadingHistory 获取当前用户的阅读历史分页列表，已删除的文章不展示
+func GetReadingHistory(page vo.PageRequest, c echo.Context) ([]*history.ReadingHistoryVo, *vo.PageMeta, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, nil, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	records, total, err := mapper.GetReadingHistoryWithPaging(accountID, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取阅读历史失败: %v", err)
+		return nil, nil, fmt.Errorf("获取阅读历史失败: %v", err)
+	}
+
+	postIDs := make([]int64, len(records))
+	for i, record := range records {
+		postIDs[i] = record.PostID
+	}
+	posts, err := mapper.GetPostsByIDs(postIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取阅读历史中的文章失败: %v", err)
+		return nil, nil, fmt.Errorf("获取阅读历史中的文章失败: %v", err)
+	}
+
+	result := make([]*history.ReadingHistoryVo, 0, len(records))
+	for _, record := range records {
+		for _, pos := range posts {
+			if pos.ID == record.PostID {
+				result = append(result, &history.ReadingHistoryVo{
+					PostID:   pos.ID,
+					Title:    pos.Title,
+					Image:    pos.Image,
+					Position: record.Position,
+					ReadAt:   record.ReadAt,
+				})
+				break
+			}
+		}
+	}
+
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// DeleteReadingHistory 删除当前用户的单条阅读历史或清空全部阅读历史
+func DeleteReadingHistory(req *dto.DeleteReadingHistoryRequest, c echo.Context) error {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	if err := mapper.DeleteReadingHistorySoftly(accountID, req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("删除阅读历史失败: %v", err)
+		return fmt.Errorf("删除阅读历史失败: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/cache"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/importer"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/importer/dto"
+	postService "jank.com/jank_blog/pkg/serve/service/post"
+	vo "jank.com/jank_blog/pkg/vo/importer"
+)
+
+const (
+	ImportTaskCacheKeyPrefix = "IMPORT:TASK:"   // 后台导入任务状态，键为前缀加任务 ID
+	ImportRunningCacheKey    = "IMPORT:RUNNING" // 后台导入任务锁，值为正在运行的任务 ID
+	ImportTaskExpiration     = 24 * time.Hour   // 任务结束后保留状态的时间
+	importLockExpiration     = 2 * time.Hour    // 任务锁的最长持有时间，避免进程异常退出后无法再次导入
+	importProgressInterval   = time.Second      // 写入任务进度的最小间隔
+)
+
+// 后台导入任务状态
+const (
+	ImportTaskRunning   = "running"
+	ImportTaskSucceeded = "succeeded"
+	ImportTaskFailed    = "failed"
+)
+
+var (
+	ErrImportRunning      = errors.New("已有导入任务正在进行")
+	ErrImportTaskNotFound = errors.New("导入任务不存在")
+)
+
+// ImportContent 从其他博客系统导入内容，无法匹配评论者时评论归属到当前用户
+func ImportContent(req *dto.ImportContentRequest, file io.Reader, c echo.Context) (*vo.ImportReportVo, error) {
+	opts, err := importOptions(req, file, c)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := runImport(opts)
+	if err != nil {
+		utils.BizLogger(c).Errorf("导入内容失败: %v", err)
+		return nil, fmt.Errorf("导入内容失败: %v", err)
+	}
+	return reportVo(report), nil
+}
+
+// StartImport 在后台导入内容并立即返回任务，通过 GetImportTask 查询进度与导入报告；同一时间只能运行一个导入任务
+func StartImport(req *dto.ImportContentRequest, file io.Reader, c echo.Context) (*vo.ImportTaskVo, error) {
+	// 请求结束后上传的文件会被清理，先读入内存
+	if file != nil {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			utils.BizLogger(c).Errorf("读取导出文件失败: %v", err)
+			return nil, fmt.Errorf("读取导出文件失败: %v", err)
+		}
+		file = bytes.NewReader(data)
+	}
+	opts, err := importOptions(req, file, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := importer.Get(opts.Format); err != nil {
+		return nil, err
+	}
+
+	id, err := randomHex()
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成导入任务 ID 失败: %v", err)
+		return nil, fmt.Errorf("生成导入任务 ID 失败: %v", err)
+	}
+	locked, err := cache.Current().SetNX(c.Request().Context(), ImportRunningCacheKey, id, importLockExpiration)
+	if err != nil {
+		utils.BizLogger(c).Errorf("写入导入任务锁失败: %v", err)
+		return nil, fmt.Errorf("写入导入任务锁失败: %v", err)
+	}
+	if !locked {
+		return nil, ErrImportRunning
+	}
+
+	task := &vo.ImportTaskVo{
+		ID:        id,
+		Format:    opts.Format,
+		DryRun:    opts.DryRun,
+		Status:    ImportTaskRunning,
+		StartedAt: time.Now().Unix(),
+	}
+	if err := saveImportTask(task); err != nil {
+		_ = cache.Current().Del(context.Background(), ImportRunningCacheKey)
+		utils.BizLogger(c).Errorf("写入导入任务失败: %v", err)
+		return nil, fmt.Errorf("写入导入任务失败: %v", err)
+	}
+
+	go runImportTask(*task, opts)
+
+	utils.BizLogger(c).Infof("开始后台导入任务 %s，格式 %s", id, opts.Format)
+	return task, nil
+}
+
+// GetImportTask 获取后台导入任务的状态，任务不存在或已过期时返回 ErrImportTaskNotFound
+func GetImportTask(req *dto.GetImportTaskRequest, c echo.Context) (*vo.ImportTaskVo, error) {
+	value, err := cache.Current().Get(c.Request().Context(), ImportTaskCacheKeyPrefix+req.ID)
+	if errors.Is(err, cache.ErrMiss) {
+		return nil, ErrImportTaskNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("读取导入任务失败: %v", err)
+		return nil, fmt.Errorf("读取导入任务失败: %v", err)
+	}
+
+	task := new(vo.ImportTaskVo)
+	if err := json.Unmarshal([]byte(value), task); err != nil {
+		utils.BizLogger(c).Errorf("解析导入任务失败: %v", err)
+		return nil, fmt.Errorf("解析导入任务失败: %v", err)
+	}
+	return task, nil
+}
+
+// runImportTask 执行后台导入任务，按间隔写入进度，结束后写入导入报告或失败原因并释放任务锁
+func runImportTask(task vo.ImportTaskVo, opts importer.Options) {
+	defer func() {
+		_ = cache.Current().Del(context.Background(), ImportRunningCacheKey)
+	}()
+
+	var mu sync.Mutex
+	var saved time.Time
+	opts.Progress = func(done, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		task.Done, task.Total = done, total
+		if done > 0 && done < total && time.Since(saved) < importProgressInterval {
+			return
+		}
+		saved = time.Now()
+		if err := saveImportTask(&task); err != nil {
+			global.BizLog.Warnf("写入导入任务 %s 的进度失败: %v", task.ID, err)
+		}
+	}
+
+	report, err := runImport(opts)
+
+	mu.Lock()
+	defer mu.Unlock()
+	task.FinishedAt = time.Now().Unix()
+	if err != nil {
+		task.Status, task.Error = ImportTaskFailed, err.Error()
+		global.BizLog.Errorf("后台导入任务 %s 失败: %v", task.ID, err)
+	} else {
+		task.Status, task.Report = ImportTaskSucceeded, reportVo(report)
+		global.BizLog.Infof("后台导入任务 %s 完成: %s", task.ID, report)
+	}
+	if err := saveImportTask(&task); err != nil {
+		global.BizLog.Errorf("写入导入任务 %s 的结果失败: %v", task.ID, err)
+	}
+}
+
+// runImport 执行导入，实际写入数据时为导入的文章生成别名
+func runImport(opts importer.Options) (*importer.Report, error) {
+	report, err := importer.Run(opts)
+	if err != nil {
+		return nil, err
+	}
+	if !report.DryRun {
+		// 导入的文章沿用源站点的别名，别名冲突时追加数字后缀
+		if err := postService.BackfillSlugs(); err != nil {
+			global.BizLog.Errorf("生成导入文章的别名失败: %v", err)
+		}
+	}
+	return report, nil
+}
+
+// importOptions 根据请求生成导入配置，执行导入的用户为当前登录用户
+func importOptions(req *dto.ImportContentRequest, file io.Reader, c echo.Context) (importer.Options, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return importer.Options{}, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+	return importer.Options{
+		Format: req.Format,
+		Source: importer.Source{
+			Reader:  file,
+			Driver:  req.Driver,
+			DSN:     req.DSN,
+			Prefix:  req.Prefix,
+			BaseURL: req.BaseURL,
+		},
+		AccountID: accountID,
+		DryRun:    req.DryRun,
+	}, nil
+}
+
+// saveImportTask 写入任务状态
+func saveImportTask(task *vo.ImportTaskVo) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	return cache.Current().Set(context.Background(), ImportTaskCacheKeyPrefix+task.ID, string(data), ImportTaskExpiration)
+}
+
+// reportVo 生成导入报告 vo
+func reportVo(report *importer.Report) *vo.ImportReportVo {
+	return &vo.ImportReportVo{
+		Format:     report.Format,
+		Posts:      report.Posts,
+		Categories: report.Categories,
+		Comments:   report.Comments,
+		Media:      report.Media,
+		Skipped:    report.Skipped,
+		Warnings:   report.Warnings,
+		DryRun:     report.DryRun,
+		ElapsedMs:  report.Elapsed.Milliseconds(),
+	}
+}
+
+// randomHex 生成 32 位十六进制随机字符串
+func randomHex() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
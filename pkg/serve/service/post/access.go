@@ -0,0 +1,204 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/cache"
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const (
+	PostAccessTokenHeader        = "X-Post-Access-Token" // 携带文章访问令牌的请求头，多个令牌以逗号分隔
+	PostAccessTokenExpireTime    = time.Hour * 24        // 文章访问令牌有效期
+	PostUnlockFailCacheKeyPrefix = "POST:UNLOCK:FAIL:"   // 解锁失败次数，键为前缀加文章 ID 与客户端 IP
+	PostUnlockFailWindow         = time.Minute * 15      // 解锁失败次数的统计窗口
+	PostUnlockMaxAttempts        = 10                    // 统计窗口内允许的解锁失败次数
+)
+
+var (
+	// ErrPostLoginRequired 文章仅登录用户可见
+	ErrPostLoginRequired = errors.New("文章仅登录用户可见")
+	// ErrPostPasswordIncorrect 文章访问密码错误
+	ErrPostPasswordIncorrect = errors.New("文章访问密码错误")
+	// ErrPostPasswordMissing 设置密码保护时没有填写访问密码
+	ErrPostPasswordMissing = errors.New("密码保护的文章需要设置访问密码")
+	// ErrPostNotProtected 文章未设置密码保护，无需解锁
+	ErrPostNotProtected = errors.New("文章未设置密码保护")
+	// ErrPostUnlockLimited 解锁失败次数过多，统计窗口结束前拒绝解锁
+	ErrPostUnlockLimited = errors.New("解锁尝试次数过多，请稍后再试")
+)
+
+// UnlockPost 校验密码保护文章的访问密码，通过后签发仅对该文章有效的访问令牌；
+// 同一客户端 IP 在统计窗口内失败次数过多时拒绝解锁
+func UnlockPost(req *dto.UnlockPostRequest, c echo.Context) (*post.PostAccessTokenVo, error) {
+	pos, err := mapper.GetPostByID(req.ID)
+	if err != nil || pos == nil {
+		return nil, ErrPostNotFound
+	}
+	if pos.Status != model.StatusPublished && !canViewUnpublished(pos, c) {
+		return nil, ErrPostNotFound
+	}
+	if pos.Access != model.AccessPassword {
+		return nil, ErrPostNotProtected
+	}
+
+	ctx := c.Request().Context()
+	failKey := PostUnlockFailCacheKeyPrefix + strconv.FormatInt(pos.ID, 10) + ":" + c.RealIP()
+	if value, err := cache.Current().Get(ctx, failKey); err == nil {
+		if fails, _ := strconv.Atoi(value); fails >= PostUnlockMaxAttempts {
+			return nil, ErrPostUnlockLimited
+		}
+	}
+
+	ok, _, err := password.Verify(req.Password, pos.AccessPassword)
+	if err != nil {
+		utils.BizLogger(c).Errorf("校验文章 %d 的访问密码失败: %v", pos.ID, err)
+		return nil, fmt.Errorf("校验访问密码失败: %v", err)
+	}
+	if !ok {
+		if _, err := cache.Current().Incr(ctx, failKey, PostUnlockFailWindow); err != nil {
+			utils.BizLogger(c).Errorf("记录文章 %d 的解锁失败次数失败: %v", pos.ID, err)
+		}
+		return nil, ErrPostPasswordIncorrect
+	}
+	if err := cache.Current().Del(ctx, failKey); err != nil {
+		utils.BizLogger(c).Errorf("清除文章 %d 的解锁失败次数失败: %v", pos.ID, err)
+	}
+
+	token, expiresAt, err := utils.GeneratePostAccessToken(pos.ID, passwordStamp(pos.AccessPassword), PostAccessTokenExpireTime)
+	if err != nil {
+		utils.BizLogger(c).Errorf("签发文章 %d 的访问令牌失败: %v", pos.ID, err)
+		return nil, fmt.Errorf("签发访问令牌失败: %v", err)
+	}
+	return &post.PostAccessTokenVo{PostID: pos.ID, AccessToken: token, ExpiresAt: expiresAt.Unix()}, nil
+}
+
+// applyAccess 按请求设置文章的访问权限与访问密码，access 为空时保留文章原有的访问权限；
+// 设置为密码保护时未传入密码则沿用原密码，文章尚未设置密码时返回 ErrPostPasswordMissing
+func applyAccess(pos *model.Post, access, accessPassword string) error {
+	if access == "" {
+		access = pos.Access
+	}
+	if access == "" {
+		access = model.AccessPublic
+	}
+	if access != model.AccessPassword {
+		pos.Access, pos.AccessPassword = access, ""
+		return nil
+	}
+
+	if accessPassword == "" {
+		if pos.AccessPassword == "" {
+			return ErrPostPasswordMissing
+		}
+		pos.Access = access
+		return nil
+	}
+	hash, err := password.Hash(accessPassword)
+	if err != nil {
+		return fmt.Errorf("生成访问密码哈希失败: %v", err)
+	}
+	pos.Access, pos.AccessPassword = access, hash
+	return nil
+}
+
+// checkPostAccess 校验当前请求能否访问文章，仅登录可见的文章在未登录时返回 ErrPostLoginRequired；
+// 密码保护的文章可以访问，正文由 lockPostContent 隐藏
+func checkPostAccess(pos *model.Post, c echo.Context) error {
+	if pos.Access == model.AccessLogin && !isLoggedIn(c) {
+		return ErrPostLoginRequired
+	}
+	return nil
+}
+
+// visibleAccess 当前请求在文章列表中可见的访问权限，未登录时不包含仅登录可见的文章
+func visibleAccess(c echo.Context) []string {
+	return model.VisibleAccess(isLoggedIn(c))
+}
+
+// lockPostContent 对当前请求尚未解锁的密码保护文章隐藏正文与目录并标记为已锁定；
+// 管理员、文章的作者与携带该文章有效访问令牌的请求可以阅读正文
+func lockPostContent(postVos []*post.PostsVo, c echo.Context) {
+	var postIDs []int64
+	for _, postVo := range postVos {
+		if postVo.Access == model.AccessPassword {
+			postIDs = append(postIDs, postVo.ID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+
+	unlocked := unlockedPosts(postIDs, c)
+	for _, postVo := range postVos {
+		if postVo.Access == model.AccessPassword && !unlocked[postVo.ID] {
+			postVo.Locked = true
+			postVo.ContentHTML = ""
+			postVo.ContentMarkdown = ""
+			postVo.TOC = nil
+		}
+	}
+}
+
+// unlockedPosts 返回 postIDs 中当前请求可以阅读正文的文章，查询失败时按未解锁处理
+func unlockedPosts(postIDs []int64, c echo.Context) map[int64]bool {
+	unlocked := make(map[int64]bool, len(postIDs))
+	accountID := authMiddleware.OptionalAccountID(c)
+	if accountID > 0 {
+		if isAdminAccount(accountID) {
+			for _, id := range postIDs {
+				unlocked[id] = true
+			}
+			return unlocked
+		}
+		authors, err := mapper.GetPostAuthors(postIDs)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取文章作者失败: %v", err)
+		}
+		for _, author := range authors {
+			if author.AccountID == accountID {
+				unlocked[author.PostID] = true
+			}
+		}
+	}
+
+	header := c.Request().Header.Get(PostAccessTokenHeader)
+	if header == "" {
+		return unlocked
+	}
+	passwords, err := mapper.GetPostAccessPasswords(postIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章访问密码失败: %v", err)
+		return unlocked
+	}
+	for _, token := range strings.Split(header, ",") {
+		claims, err := utils.ParsePostAccessToken(strings.TrimSpace(token))
+		if err != nil {
+			continue
+		}
+		if hash, ok := passwords[claims.PostID()]; ok && claims.Stamp == passwordStamp(hash) {
+			unlocked[claims.PostID()] = true
+		}
+	}
+	return unlocked
+}
+
+// passwordStamp 访问密码哈希的印记，写入访问令牌，修改密码后印记变化，此前签发的令牌失效
+func passwordStamp(hash string) string {
+	sum := sha256.Sum256([]byte(hash))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	sitemapService "jank.com/jank_blog/pkg/serve/service/sitemap"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const (
+	ArchiveCacheKey        = "POST:ARCHIVE"      // 文章归档统计缓存
+	ArchiveTreeCacheKey    = "POST:ARCHIVE:TREE" // 按年月分组的文章归档缓存
+	ArchiveCacheExpireTime = time.Minute * 30    // 文章归档缓存有效期，文章变更时主动失效
+	ArchiveMemberSuffix    = ":MEMBER"           // 登录用户可见仅登录文章，归档缓存键追加该后缀单独缓存
+)
+
+// GetArchives 获取按年月统计的已发布文章数量，按时间倒序排列
+func GetArchives(c echo.Context) ([]*post.ArchiveVo, error) {
+	ctx := c.Request().Context()
+	cacheKey := archiveCacheKey(ArchiveCacheKey, c)
+	if global.RedisClient != nil {
+		if cached, err := global.RedisClient.Get(ctx, cacheKey).Result(); err == nil {
+			var archives []*post.ArchiveVo
+			if err := json.Unmarshal([]byte(cached), &archives); err == nil {
+				return archives, nil
+			}
+		}
+	}
+
+	times, err := mapper.GetPublishedPostCreateTimes(visibleAccess(c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章归档失败: %v", err)
+		return nil, fmt.Errorf("获取文章归档失败: %v", err)
+	}
+
+	counts := make(map[[2]int]int64)
+	for _, ts := range times {
+		t := time.Unix(ts, 0)
+		counts[[2]int{t.Year(), int(t.Month())}]++
+	}
+
+	archives := make([]*post.ArchiveVo, 0, len(counts))
+	for period, count := range counts {
+		archives = append(archives, &post.ArchiveVo{Year: period[0], Month: period[1], Count: count})
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		if archives[i].Year != archives[j].Year {
+			return archives[i].Year > archives[j].Year
+		}
+		return archives[i].Month > archives[j].Month
+	})
+
+	if global.RedisClient != nil {
+		if data, err := json.Marshal(archives); err == nil {
+			if err := global.RedisClient.Set(ctx, cacheKey, data, ArchiveCacheExpireTime).Err(); err != nil {
+				utils.BizLogger(c).Errorf("缓存文章归档失败: %v", err)
+			}
+		}
+	}
+	return archives, nil
+}
+
+// GetArchive 获取按年月分组的已发布文章标题与数量，年、月与文章均按时间倒序排列，用于渲染归档页；
+// 只查询一次不含正文的文章列表并在内存中分组
+func GetArchive(c echo.Context) ([]*post.ArchiveYearVo, error) {
+	ctx := c.Request().Context()
+	cacheKey := archiveCacheKey(ArchiveTreeCacheKey, c)
+	if global.RedisClient != nil {
+		if cached, err := global.RedisClient.Get(ctx, cacheKey).Result(); err == nil {
+			var years []*post.ArchiveYearVo
+			if err := json.Unmarshal([]byte(cached), &years); err == nil {
+				return years, nil
+			}
+		}
+	}
+
+	posts, err := mapper.GetPublishedPostTitles(visibleAccess(c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章归档失败: %v", err)
+		return nil, fmt.Errorf("获取文章归档失败: %v", err)
+	}
+
+	// 文章已按创建时间倒序排列，依次追加即可保持年、月的倒序
+	years := make([]*post.ArchiveYearVo, 0)
+	for _, pos := range posts {
+		t := time.Unix(pos.GmtCreate, 0)
+		if len(years) == 0 || years[len(years)-1].Year != t.Year() {
+			years = append(years, &post.ArchiveYearVo{Year: t.Year()})
+		}
+		year := years[len(years)-1]
+		if len(year.Months) == 0 || year.Months[len(year.Months)-1].Month != int(t.Month()) {
+			year.Months = append(year.Months, &post.ArchiveMonthVo{Month: int(t.Month())})
+		}
+		month := year.Months[len(year.Months)-1]
+		month.Posts = append(month.Posts, &post.ArchivePostVo{ID: pos.ID, Title: pos.Title, Slug: pos.Slug, GmtCreate: pos.GmtCreate})
+		month.Count++
+		year.Count++
+	}
+
+	if global.RedisClient != nil {
+		if data, err := json.Marshal(years); err == nil {
+			if err := global.RedisClient.Set(ctx, cacheKey, data, ArchiveCacheExpireTime).Err(); err != nil {
+				utils.BizLogger(c).Errorf("缓存文章归档失败: %v", err)
+			}
+		}
+	}
+	return years, nil
+}
+
+// GetArchivePosts 获取指定年份或年月内的已发布文章分页列表
+func GetArchivePosts(req *dto.GetArchivePostsRequest, page vo.PageRequest, c echo.Context) ([]*post.PostsVo, *vo.PageMeta, error) {
+	var start, end time.Time
+	if req.Month > 0 {
+		start = time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.Local)
+		end = start.AddDate(0, 1, 0)
+	} else {
+		start = time.Date(req.Year, time.January, 1, 0, 0, 0, 0, time.Local)
+		end = start.AddDate(1, 0, 0)
+	}
+
+	posts, total, err := mapper.GetPublishedPostsByPeriodWithPaging(start.Unix(), end.Unix(), visibleAccess(c), page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取归档文章失败: %v", err)
+		return nil, nil, fmt.Errorf("获取归档文章失败: %v", err)
+	}
+
+	postResponse := make([]*post.PostsVo, len(posts))
+	for i, pos := range posts {
+		mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取归档文章时映射 vo 失败: %v", err)
+			return nil, nil, fmt.Errorf("获取归档文章时映射 vo 失败: %v", err)
+		}
+
+		postVo := mapped.(*post.PostsVo)
+		if len(postVo.ContentHTML) > 150 {
+			postVo.ContentHTML = postVo.ContentHTML[:150]
+		}
+		postResponse[i] = postVo
+	}
+	fillAuthors(postResponse, c)
+	lockPostContent(postResponse, c)
+
+	return postResponse, vo.NewPageMeta(page, total), nil
+}
+
+// archiveCacheKey 按当前请求可见的文章范围返回归档缓存键，登录用户追加 ArchiveMemberSuffix
+func archiveCacheKey(key string, c echo.Context) string {
+	if isLoggedIn(c) {
+		return key + ArchiveMemberSuffix
+	}
+	return key
+}
+
+// invalidateArchiveCache 文章变更后清除归档缓存、站点地图缓存与相关文章缓存，失败时仅记录日志
+func invalidateArchiveCache(c echo.Context) {
+	sitemapService.Invalidate()
+	invalidateRelatedPosts()
+	if err := clearArchiveCache(c.Request().Context()); err != nil {
+		utils.BizLogger(c).Errorf("清除文章归档缓存失败: %v", err)
+	}
+}
+
+// clearArchiveCache 清除归档统计与按年月分组的归档缓存
+func clearArchiveCache(ctx context.Context) error {
+	if global.RedisClient == nil {
+		return nil
+	}
+	return global.RedisClient.Del(ctx, ArchiveCacheKey, ArchiveTreeCacheKey,
+		ArchiveCacheKey+ArchiveMemberSuffix, ArchiveTreeCacheKey+ArchiveMemberSuffix).Err()
+}
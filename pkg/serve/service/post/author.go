@@ -0,0 +1,307 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/global"
+	accountModel "jank.com/jank_blog/internal/model/account"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	accountService "jank.com/jank_blog/pkg/serve/service/account"
+	"jank.com/jank_blog/pkg/vo"
+	accountVo "jank.com/jank_blog/pkg/vo/account"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+var (
+	// ErrPostAuthorsInvalid 作者列表重复、缺少角色为 author 的作者或包含不存在的账户
+	ErrPostAuthorsInvalid = errors.New("文章作者列表无效")
+	// ErrPostAuthorForbidden 当前账户既不是管理员也不是文章的作者
+	ErrPostAuthorForbidden = errors.New("无权修改文章作者")
+	// ErrAuthorNotFound 作者不存在或资料不公开
+	ErrAuthorNotFound = errors.New("作者不存在")
+)
+
+// SetPostAuthors 以请求中的作者列表替换文章的全部作者，署名顺序与列表顺序一致；仅管理员与文章角色为 author 的作者可以修改
+func SetPostAuthors(req *dto.SetPostAuthorsRequest, c echo.Context) ([]*post.PostAuthorVo, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		return nil, fmt.Errorf("解析 token 失败: %v", err)
+	}
+	pos, err := mapper.GetPostByID(req.PostID)
+	if err != nil || pos == nil {
+		return nil, ErrPostNotFound
+	}
+
+	current, err := mapper.GetPostAuthors([]int64{pos.ID})
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的作者失败: %v", pos.ID, err)
+		return nil, err
+	}
+	if !canManagePostAuthors(accountID, current) {
+		return nil, ErrPostAuthorForbidden
+	}
+
+	authors := make([]*model.PostAuthor, 0, len(req.Authors))
+	accountIDs := make([]int64, 0, len(req.Authors))
+	seen := make(map[int64]bool, len(req.Authors))
+	hasAuthor := false
+	for i, item := range req.Authors {
+		if seen[item.AccountID] {
+			return nil, fmt.Errorf("%w: 账户 %d 重复", ErrPostAuthorsInvalid, item.AccountID)
+		}
+		seen[item.AccountID] = true
+		role := item.Role
+		if role == "" {
+			role = model.AuthorRoleAuthor
+		}
+		hasAuthor = hasAuthor || role == model.AuthorRoleAuthor
+		authors = append(authors, &model.PostAuthor{PostID: pos.ID, AccountID: item.AccountID, Role: role, Position: i + 1})
+		accountIDs = append(accountIDs, item.AccountID)
+	}
+	if !hasAuthor {
+		return nil, fmt.Errorf("%w: 至少需要一位角色为 author 的作者", ErrPostAuthorsInvalid)
+	}
+	accounts, err := mapper.GetAccountsByIDs(accountIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取作者账户失败: %v", err)
+		return nil, err
+	}
+	if len(accounts) != len(accountIDs) {
+		return nil, fmt.Errorf("%w: 包含不存在的账户", ErrPostAuthorsInvalid)
+	}
+
+	if err := mapper.ReplacePostAuthors(pos.ID, authors); err != nil {
+		utils.BizLogger(c).Errorf("设置文章 %d 的作者失败: %v", pos.ID, err)
+		return nil, fmt.Errorf("设置文章作者失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("账户 %d 将文章 %d 的作者设置为 %v", accountID, pos.ID, accountIDs)
+	return authorVos(authors, accounts), nil
+}
+
+// GetAuthors 获取署名过已发布文章的作者，按文章数量降序排列，资料不公开的账户不返回
+func GetAuthors(c echo.Context) ([]*post.AuthorVo, error) {
+	counts, err := mapper.GetAuthorPostCounts(nil)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取作者列表失败: %v", err)
+		return nil, fmt.Errorf("获取作者列表失败: %v", err)
+	}
+	accountIDs := make([]int64, len(counts))
+	for i, count := range counts {
+		accountIDs[i] = count.AccountID
+	}
+	accounts, err := mapper.GetAccountsByIDs(accountIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取作者账户失败: %v", err)
+		return nil, err
+	}
+	byID := make(map[int64]*accountModel.Account, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+	}
+
+	result := make([]*post.AuthorVo, 0, len(counts))
+	for _, count := range counts {
+		acc, ok := byID[count.AccountID]
+		if !ok {
+			continue
+		}
+		if profile := accountService.PublicProfile(acc); profile != nil {
+			result = append(result, &post.AuthorVo{ProfileVo: *profile, PostCount: count.Posts})
+		}
+	}
+	return result, nil
+}
+
+// GetAuthor 获取作者页的公开资料与署名的已发布文章数量
+func GetAuthor(req *dto.GetAuthorRequest, c echo.Context) (*post.AuthorVo, error) {
+	profile, err := authorProfile(req.ID, c)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := mapper.GetAuthorPostCounts([]int64{req.ID})
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取作者 %d 的文章数量失败: %v", req.ID, err)
+		return nil, fmt.Errorf("获取作者文章数量失败: %v", err)
+	}
+
+	author := &post.AuthorVo{ProfileVo: *profile}
+	if len(counts) > 0 {
+		author.PostCount = counts[0].Posts
+	}
+	return author, nil
+}
+
+// GetAuthorPosts 获取作者署名的已发布文章分页列表，以编辑署名的文章也包含在内，按创建时间倒序排列
+func GetAuthorPosts(req *dto.GetAuthorPostsRequest, page vo.PageRequest, c echo.Context) ([]*post.PostsVo, *vo.PageMeta, error) {
+	if _, err := authorProfile(req.ID, c); err != nil {
+		return nil, nil, err
+	}
+	posts, total, err := mapper.GetAuthorPublishedPostsWithPaging(req.ID, visibleAccess(c), page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取作者 %d 的文章失败: %v", req.ID, err)
+		return nil, nil, fmt.Errorf("获取作者文章失败: %v", err)
+	}
+
+	postResponse := make([]*post.PostsVo, len(posts))
+	for i, pos := range posts {
+		mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取作者文章时映射 vo 失败: %v", err)
+			return nil, nil, fmt.Errorf("获取作者文章时映射 vo 失败: %v", err)
+		}
+
+		postVo := mapped.(*post.PostsVo)
+		if len(postVo.ContentHTML) > 150 {
+			postVo.ContentHTML = postVo.ContentHTML[:150]
+		}
+		postResponse[i] = postVo
+	}
+	fillAuthors(postResponse, c)
+	lockPostContent(postResponse, c)
+
+	return postResponse, vo.NewPageMeta(page, total), nil
+}
+
+// BackfillPostAuthors 为升级前创建、没有署名作者的文章补充作者，以最早一条修订的保存人作为作者；启动时调用
+func BackfillPostAuthors() error {
+	postIDs, err := mapper.GetPostIDsWithoutAuthors()
+	if err != nil {
+		return fmt.Errorf("获取没有作者的文章失败: %v", err)
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+	authorIDs, err := mapper.GetPostAuthorIDs(postIDs)
+	if err != nil {
+		return err
+	}
+	accountIDs := make([]int64, 0, len(authorIDs))
+	for _, id := range authorIDs {
+		accountIDs = append(accountIDs, id)
+	}
+	// 保存人的账户已永久删除时不补充
+	accounts, err := mapper.GetAccountsByIDs(accountIDs)
+	if err != nil {
+		return err
+	}
+	exists := make(map[int64]bool, len(accounts))
+	for _, acc := range accounts {
+		exists[acc.ID] = true
+	}
+
+	var authors []*model.PostAuthor
+	for _, postID := range postIDs {
+		if accountID, ok := authorIDs[postID]; ok && exists[accountID] {
+			authors = append(authors, &model.PostAuthor{PostID: postID, AccountID: accountID, Role: model.AuthorRoleAuthor, Position: 1})
+		}
+	}
+	if err := mapper.AddPostAuthors(authors); err != nil {
+		return fmt.Errorf("写入文章作者失败: %v", err)
+	}
+	if len(authors) > 0 {
+		global.SysLog.Infof("已为 %d 篇文章补充作者", len(authors))
+	}
+	return nil
+}
+
+// addPostCreator 将创建文章的账户设为文章的第一作者，失败时仅记录日志
+func addPostCreator(pos *model.Post, c echo.Context) {
+	accountID := utils.OptionalAccountIDFromJWT(c.Request().Header.Get("Authorization"))
+	if accountID == 0 {
+		return
+	}
+	author := &model.PostAuthor{PostID: pos.ID, AccountID: accountID, Role: model.AuthorRoleAuthor, Position: 1}
+	if err := mapper.AddPostAuthors([]*model.PostAuthor{author}); err != nil {
+		utils.BizLogger(c).Errorf("记录文章 %d 的作者失败: %v", pos.ID, err)
+	}
+}
+
+// fillAuthors 批量填充文章的署名作者，失败时仅记录日志
+func fillAuthors(postVos []*post.PostsVo, c echo.Context) {
+	postIDs := make([]int64, len(postVos))
+	for i, postVo := range postVos {
+		postIDs[i] = postVo.ID
+		postVo.Authors = []*post.PostAuthorVo{}
+	}
+	authors, err := mapper.GetPostAuthors(postIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章作者失败: %v", err)
+		return
+	}
+	accountIDs := make([]int64, 0, len(authors))
+	for _, author := range authors {
+		accountIDs = append(accountIDs, author.AccountID)
+	}
+	accounts, err := mapper.GetAccountsByIDs(accountIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章作者失败: %v", err)
+		return
+	}
+
+	byPost := make(map[int64][]*model.PostAuthor, len(postVos))
+	for _, author := range authors {
+		byPost[author.PostID] = append(byPost[author.PostID], author)
+	}
+	for _, postVo := range postVos {
+		postVo.Authors = authorVos(byPost[postVo.ID], accounts)
+	}
+}
+
+// authorVos 按署名顺序生成作者 vo，账户已删除的作者不返回
+func authorVos(authors []*model.PostAuthor, accounts []*accountModel.Account) []*post.PostAuthorVo {
+	byID := make(map[int64]*accountModel.Account, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+	}
+	result := make([]*post.PostAuthorVo, 0, len(authors))
+	for _, author := range authors {
+		acc, ok := byID[author.AccountID]
+		if !ok {
+			continue
+		}
+		result = append(result, &post.PostAuthorVo{
+			ID:          acc.ID,
+			Nickname:    acc.Nickname,
+			DisplayName: acc.DisplayName,
+			Avatar:      accountService.AvatarURL(acc),
+			Role:        author.Role,
+		})
+	}
+	return result
+}
+
+// canManagePostAuthors 账户是否为管理员或文章角色为 author 的作者
+func canManagePostAuthors(accountID int64, current []*model.PostAuthor) bool {
+	for _, author := range current {
+		if author.AccountID == accountID && author.Role == model.AuthorRoleAuthor {
+			return true
+		}
+	}
+	return isAdminAccount(accountID)
+}
+
+// isAdminAccount 账户是否为管理员
+func isAdminAccount(accountID int64) bool {
+	codes, err := mapper.GetRoleCodesByAccountIDs([]int64{accountID})
+	return err == nil && codes[accountID] == accountModel.RoleCodeAdmin
+}
+
+// authorProfile 获取作者的公开资料，账户不存在或资料不公开时返回 ErrAuthorNotFound
+func authorProfile(accountID int64, c echo.Context) (*accountVo.ProfileVo, error) {
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取作者 %d 失败: %v", accountID, err)
+		return nil, ErrAuthorNotFound
+	}
+	profile := accountService.PublicProfile(acc)
+	if profile == nil {
+		return nil, ErrAuthorNotFound
+	}
+	return profile, nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	bookmarkService "jank.com/jank_blog/pkg/serve/service/bookmark"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// fillBookmarkState 填充文章的收藏数与当前用户的收藏状态，未登录时收藏状态为 false，失败时仅记录日志
+func fillBookmarkState(postVo *post.PostsVo, c echo.Context) {
+	accountID := utils.OptionalAccountIDFromJWT(c.Request().Header.Get("Authorization"))
+	count, bookmarked, err := bookmarkService.BookmarkState(postVo.ID, accountID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的收藏状态失败: %v", postVo.ID, err)
+		return
+	}
+	postVo.BookmarkCount = count
+	postVo.Bookmarked = bookmarked
+}
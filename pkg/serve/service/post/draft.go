@@ -0,0 +1,198 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const (
+	DraftCacheKeyPrefix  = "POST:DRAFT:"      // 自动保存的草稿，键为 POST:DRAFT:<账户 ID>:<文章 ID>，新文章的文章 ID 为 0
+	DraftCacheExpireTime = time.Hour * 24 * 7 // 草稿保留时间，每次保存后重新计算
+)
+
+var (
+	// ErrDraftConflict 草稿已被其他窗口或设备更新，客户端提交的版本号已过期
+	ErrDraftConflict = errors.New("草稿已在其他窗口或设备中更新")
+	// ErrDraftUnavailable 草稿依赖 Redis 存储，Redis 不可用时无法自动保存
+	ErrDraftUnavailable = errors.New("草稿存储不可用")
+)
+
+// saveDraftScript 校验版本号后写入草稿，版本号不一致时返回 -1，否则返回新的版本号
+// base_modified 仅在首次保存时写入，记录开始编辑时文章的更新时间
+var saveDraftScript = redis.NewScript(`
+local current = tonumber(redis.call('HGET', KEYS[1], 'version') or '0')
+if ARGV[4] ~= '1' and current ~= tonumber(ARGV[1]) then
+	return -1
+end
+local version = current + 1
+redis.call('HSET', KEYS[1], 'version', version, 'data', ARGV[2])
+redis.call('HSETNX', KEYS[1], 'base_modified', ARGV[3])
+redis.call('EXPIRE', KEYS[1], ARGV[5])
+return version
+`)
+
+// draftContent 草稿中由编辑器提交的内容
+type draftContent struct {
+	Title           string  `json:"title"`
+	Image           string  `json:"image"`
+	ContentMarkdown string  `json:"content_markdown"`
+	CategoryIDs     []int64 `json:"category_ids"`
+	SavedAt         int64   `json:"saved_at"`
+}
+
+// SaveDraft 自动保存草稿，客户端需携带上次保存返回的版本号，版本号过期时返回 ErrDraftConflict 与最新草稿
+func SaveDraft(req *dto.SaveDraftRequest, c echo.Context) (*post.DraftVo, error) {
+	if global.RedisClient == nil {
+		return nil, ErrDraftUnavailable
+	}
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析访问令牌失败: %v", err)
+		return nil, fmt.Errorf("解析访问令牌失败: %v", err)
+	}
+
+	var baseModified int64
+	if req.PostID > 0 {
+		pos, err := mapper.GetPostByID(req.PostID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("文章不存在: %v", err)
+			return nil, fmt.Errorf("文章不存在: %v", err)
+		}
+		baseModified = pos.GmtModified
+	}
+
+	data, err := json.Marshal(draftContent{
+		Title:           req.Title,
+		Image:           req.Image,
+		ContentMarkdown: req.ContentMarkdown,
+		CategoryIDs:     req.CategoryIDs,
+		SavedAt:         time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("序列化草稿失败: %v", err)
+	}
+
+	force := "0"
+	if req.Force {
+		force = "1"
+	}
+	key := draftKey(accountID, req.PostID)
+	version, err := saveDraftScript.Run(c.Request().Context(), global.RedisClient, []string{key},
+		req.Version, data, baseModified, force, int(DraftCacheExpireTime.Seconds())).Int64()
+	if err != nil {
+		utils.BizLogger(c).Errorf("保存草稿失败: %v", err)
+		return nil, fmt.Errorf("保存草稿失败: %v", err)
+	}
+
+	draft, err := loadDraft(key, req.PostID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取草稿失败: %v", err)
+		return nil, fmt.Errorf("获取草稿失败: %v", err)
+	}
+	if version < 0 {
+		return draft, ErrDraftConflict
+	}
+	return draft, nil
+}
+
+// GetDraft 获取当前账户自动保存的草稿，没有草稿时返回 nil
+func GetDraft(req *dto.GetDraftRequest, c echo.Context) (*post.DraftVo, error) {
+	if global.RedisClient == nil {
+		return nil, ErrDraftUnavailable
+	}
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析访问令牌失败: %v", err)
+		return nil, fmt.Errorf("解析访问令牌失败: %v", err)
+	}
+
+	draft, err := loadDraft(draftKey(accountID, req.PostID), req.PostID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取草稿失败: %v", err)
+		return nil, fmt.Errorf("获取草稿失败: %v", err)
+	}
+	return draft, nil
+}
+
+// DiscardDraft 丢弃当前账户自动保存的草稿
+func DiscardDraft(req *dto.DiscardDraftRequest, c echo.Context) error {
+	if global.RedisClient == nil {
+		return ErrDraftUnavailable
+	}
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析访问令牌失败: %v", err)
+		return fmt.Errorf("解析访问令牌失败: %v", err)
+	}
+
+	if err := global.RedisClient.Del(c.Request().Context(), draftKey(accountID, req.PostID)).Err(); err != nil {
+		utils.BizLogger(c).Errorf("丢弃草稿失败: %v", err)
+		return fmt.Errorf("丢弃草稿失败: %v", err)
+	}
+	return nil
+}
+
+// clearDraft 文章保存成功后清理当前账户的草稿，失败时仅记录日志
+func clearDraft(postID int64, c echo.Context) {
+	if global.RedisClient == nil {
+		return
+	}
+	accountID := utils.OptionalAccountIDFromJWT(c.Request().Header.Get("Authorization"))
+	if accountID == 0 {
+		return
+	}
+	if err := global.RedisClient.Del(c.Request().Context(), draftKey(accountID, postID)).Err(); err != nil {
+		utils.BizLogger(c).Errorf("清理文章 %d 的草稿失败: %v", postID, err)
+	}
+}
+
+// loadDraft 读取草稿，并判断开始编辑后文章是否已被更新
+func loadDraft(key string, postID int64, c echo.Context) (*post.DraftVo, error) {
+	fields, err := global.RedisClient.HGetAll(c.Request().Context(), key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if fields["data"] == "" {
+		return nil, nil
+	}
+
+	var content draftContent
+	if err := json.Unmarshal([]byte(fields["data"]), &content); err != nil {
+		return nil, err
+	}
+	version, _ := strconv.ParseInt(fields["version"], 10, 64)
+	baseModified, _ := strconv.ParseInt(fields["base_modified"], 10, 64)
+
+	draft := &post.DraftVo{
+		PostID:          postID,
+		Title:           content.Title,
+		Image:           content.Image,
+		ContentMarkdown: content.ContentMarkdown,
+		CategoryIDs:     content.CategoryIDs,
+		Version:         version,
+		SavedAt:         content.SavedAt,
+	}
+	if postID > 0 {
+		if pos, err := mapper.GetPostByID(postID); err == nil {
+			draft.Stale = pos.GmtModified > baseModified
+		}
+	}
+	return draft, nil
+}
+
+// draftKey 草稿的缓存键
+func draftKey(accountID, postID int64) string {
+	return fmt.Sprintf("%s%d:%d", DraftCacheKeyPrefix, accountID, postID)
+}
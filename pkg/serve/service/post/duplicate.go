@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// 重复内容检测方式
+const (
+	DuplicateCheckOff   = "off"   // 不检测
+	DuplicateCheckWarn  = "warn"  // 仅在响应中提示疑似重复的文章
+	DuplicateCheckBlock = "block" // 拒绝创建与已有文章几乎相同的文章
+)
+
+const defaultDuplicateMaxDistance = 3 // 未配置时判定为重复的最大汉明距离
+
+// ErrPostDuplicate 新文章与已有文章内容几乎相同
+var ErrPostDuplicate = errors.New("文章内容与已有文章几乎相同")
+
+// fingerprint 计算文章的内容指纹
+func fingerprint(pos *model.Post) int64 {
+	return int64(search.SimHash(search.Document{Title: pos.Title, Content: pos.ContentMarkdown}))
+}
+
+// duplicateOptions 读取重复内容检测配置
+func duplicateOptions() (string, int) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return DuplicateCheckWarn, defaultDuplicateMaxDistance
+	}
+
+	mode, distance := config.DuplicateConfig.DuplicateCheckMode, config.DuplicateConfig.DuplicateMaxDistance
+	if mode == "" {
+		mode = DuplicateCheckWarn
+	}
+	if distance <= 0 {
+		distance = defaultDuplicateMaxDistance
+	}
+	return mode, distance
+}
+
+// checkDuplicates 查找与文章内容几乎相同的已有文章，block 模式下发现重复时返回 ErrPostDuplicate
+func checkDuplicates(pos *model.Post, c echo.Context) ([]int64, error) {
+	mode, maxDistance := duplicateOptions()
+	if mode == DuplicateCheckOff || pos.Fingerprint == 0 {
+		return nil, nil
+	}
+
+	posts, err := mapper.GetPostFingerprints()
+	if err != nil {
+		// 重复检测失败不影响保存文章
+		utils.BizLogger(c).Errorf("获取文章指纹失败: %v", err)
+		return nil, nil
+	}
+
+	var duplicates []int64
+	for _, other := range posts {
+		if other.ID != pos.ID && search.HammingDistance(uint64(other.Fingerprint), uint64(pos.Fingerprint)) <= maxDistance {
+			duplicates = append(duplicates, other.ID)
+		}
+	}
+	if len(duplicates) > 0 && mode == DuplicateCheckBlock {
+		return duplicates, fmt.Errorf("%w: %v", ErrPostDuplicate, duplicates)
+	}
+	return duplicates, nil
+}
+
+// GetDuplicatePosts 列出内容疑似重复的文章对，按指纹距离升序排列，首次调用时为尚未计算指纹的文章补算
+func GetDuplicatePosts(c echo.Context) ([]*post.DuplicatePairVo, error) {
+	missing, err := mapper.GetPostsWithoutFingerprint()
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取待计算指纹的文章失败: %v", err)
+		return nil, fmt.Errorf("获取待计算指纹的文章失败: %v", err)
+	}
+	for _, pos := range missing {
+		if fp := fingerprint(pos); fp != 0 {
+			if err := mapper.UpdatePostFingerprint(pos.ID, fp); err != nil {
+				utils.BizLogger(c).Errorf("更新文章 %d 的指纹失败: %v", pos.ID, err)
+				return nil, fmt.Errorf("更新文章指纹失败: %v", err)
+			}
+		}
+	}
+
+	posts, err := mapper.GetPostFingerprints()
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章指纹失败: %v", err)
+		return nil, fmt.Errorf("获取文章指纹失败: %v", err)
+	}
+
+	_, maxDistance := duplicateOptions()
+	pairs := make([]*post.DuplicatePairVo, 0)
+	for i, a := range posts {
+		for _, b := range posts[i+1:] {
+			if distance := search.HammingDistance(uint64(a.Fingerprint), uint64(b.Fingerprint)); distance <= maxDistance {
+				pairs = append(pairs, &post.DuplicatePairVo{
+					PostID:         a.ID,
+					PostTitle:      a.Title,
+					DuplicateID:    b.ID,
+					DuplicateTitle: b.Title,
+					Distance:       distance,
+				})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Distance < pairs[j].Distance })
+	return pairs, nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const (
+	EditLockKeyPrefix         = "POST:EDIT_LOCK:" // 文章编辑锁，键为 POST:EDIT_LOCK:<文章 ID>
+	EditLockExpireTime        = time.Second * 90  // 编辑锁有效期，未按时续期的锁自动释放
+	EditLockHeartbeatInterval = time.Second * 30  // 建议的续期间隔
+)
+
+var (
+	// ErrPostEditLocked 文章正在被其他账户编辑
+	ErrPostEditLocked = errors.New("文章正在被其他账户编辑")
+	// ErrEditLockLost 编辑锁已过期或已被其他账户接管
+	ErrEditLockLost = errors.New("编辑锁已失效")
+	// ErrEditLockUnavailable 编辑锁依赖 Redis，Redis 不可用时无法加锁
+	ErrEditLockUnavailable = errors.New("编辑锁不可用")
+)
+
+// 编辑锁脚本，ARGV 依次为账户 ID、昵称、当前时间、有效期（秒）
+var (
+	// acquireEditLockScript 无锁或已由当前账户持有时加锁，被其他账户持有时返回 0
+	acquireEditLockScript = redis.NewScript(`
+local holder = redis.call('HGET', KEYS[1], 'account_id')
+if holder and holder ~= ARGV[1] then
+	return 0
+end
+if not holder then
+	redis.call('HSET', KEYS[1], 'acquired_at', ARGV[3])
+end
+redis.call('HSET', KEYS[1], 'account_id', ARGV[1], 'nickname', ARGV[2], 'heartbeat_at', ARGV[3])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+	// renewEditLockScript 当前账户持有锁时续期，否则返回 0
+	renewEditLockScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'account_id') ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'heartbeat_at', ARGV[3])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+	// releaseEditLockScript 当前账户持有锁时释放，否则返回 0
+	releaseEditLockScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'account_id') ~= ARGV[1] then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+return 1
+`)
+	// takeOverEditLockScript 接管编辑锁，并记录原持有者
+	takeOverEditLockScript = redis.NewScript(`
+local holder = redis.call('HGET', KEYS[1], 'account_id') or ''
+if holder == ARGV[1] then
+	holder = redis.call('HGET', KEYS[1], 'taken_over_from') or ''
+end
+redis.call('DEL', KEYS[1])
+redis.call('HSET', KEYS[1], 'account_id', ARGV[1], 'nickname', ARGV[2], 'acquired_at', ARGV[3], 'heartbeat_at', ARGV[3], 'taken_over_from', holder)
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+)
+
+// AcquireEditLock 打开文章编辑时加锁，文章被其他账户编辑时返回 ErrPostEditLocked 与当前持有者
+func AcquireEditLock(req *dto.EditLockRequest, c echo.Context) (*post.EditLockVo, error) {
+	return runEditLockScript(acquireEditLockScript, req, ErrPostEditLocked, c)
+}
+
+// RenewEditLock 编辑器心跳续期，锁已过期或被接管时返回 ErrEditLockLost 与当前持有者
+func RenewEditLock(req *dto.EditLockRequest, c echo.Context) (*post.EditLockVo, error) {
+	return runEditLockScript(renewEditLockScript, req, ErrEditLockLost, c)
+}
+
+// TakeOverEditLock 接管被遗弃的编辑锁，原持有者下次续期时得知锁已被接管
+func TakeOverEditLock(req *dto.EditLockRequest, c echo.Context) (*post.EditLockVo, error) {
+	return runEditLockScript(takeOverEditLockScript, req, nil, c)
+}
+
+// ReleaseEditLock 关闭编辑器时释放编辑锁，锁不由当前账户持有时不做处理
+func ReleaseEditLock(req *dto.EditLockRequest, c echo.Context) error {
+	if global.RedisClient == nil {
+		return ErrEditLockUnavailable
+	}
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析访问令牌失败: %v", err)
+		return fmt.Errorf("解析访问令牌失败: %v", err)
+	}
+
+	if err := releaseEditLockScript.Run(c.Request().Context(), global.RedisClient, []string{editLockKey(req.PostID)}, accountID).Err(); err != nil {
+		utils.BizLogger(c).Errorf("释放编辑锁失败: %v", err)
+		return fmt.Errorf("释放编辑锁失败: %v", err)
+	}
+	return nil
+}
+
+// GetEditLock 获取文章当前的编辑锁，无人编辑时返回 nil
+func GetEditLock(req *dto.EditLockRequest, c echo.Context) (*post.EditLockVo, error) {
+	if global.RedisClient == nil {
+		return nil, ErrEditLockUnavailable
+	}
+	accountID := utils.OptionalAccountIDFromJWT(c.Request().Header.Get("Authorization"))
+
+	lock, err := loadEditLock(req.PostID, accountID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取编辑锁失败: %v", err)
+		return nil, fmt.Errorf("获取编辑锁失败: %v", err)
+	}
+	return lock, nil
+}
+
+// runEditLockScript 以当前账户执行编辑锁脚本，脚本返回 0 时返回 failErr 与当前持有者
+func runEditLockScript(script *redis.Script, req *dto.EditLockRequest, failErr error, c echo.Context) (*post.EditLockVo, error) {
+	if global.RedisClient == nil {
+		return nil, ErrEditLockUnavailable
+	}
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析访问令牌失败: %v", err)
+		return nil, fmt.Errorf("解析访问令牌失败: %v", err)
+	}
+	if _, err := mapper.GetPostByID(req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+	acc, err := mapper.GetAccountByAccountID(accountID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取账户信息失败: %v", err)
+		return nil, fmt.Errorf("获取账户信息失败: %v", err)
+	}
+
+	ok, err := script.Run(c.Request().Context(), global.RedisClient, []string{editLockKey(req.PostID)},
+		accountID, acc.Nickname, time.Now().Unix(), int(EditLockExpireTime.Seconds())).Int()
+	if err != nil {
+		utils.BizLogger(c).Errorf("更新编辑锁失败: %v", err)
+		return nil, fmt.Errorf("更新编辑锁失败: %v", err)
+	}
+
+	lock, err := loadEditLock(req.PostID, accountID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取编辑锁失败: %v", err)
+		return nil, fmt.Errorf("获取编辑锁失败: %v", err)
+	}
+	if ok == 0 {
+		return lock, failErr
+	}
+	return lock, nil
+}
+
+// clearEditLock 文章删除后移除编辑锁，失败时仅记录日志
+func clearEditLock(postID int64, c echo.Context) {
+	if global.RedisClient == nil {
+		return
+	}
+	if err := global.RedisClient.Del(c.Request().Context(), editLockKey(postID)).Err(); err != nil {
+		utils.BizLogger(c).Errorf("移除文章 %d 的编辑锁失败: %v", postID, err)
+	}
+}
+
+// loadEditLock 读取编辑锁，accountID 用于判断锁是否由当前账户持有
+func loadEditLock(postID, accountID int64, c echo.Context) (*post.EditLockVo, error) {
+	key := editLockKey(postID)
+	fields, err := global.RedisClient.HGetAll(c.Request().Context(), key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if fields["account_id"] == "" {
+		return nil, nil
+	}
+	ttl, err := global.RedisClient.TTL(c.Request().Context(), key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	holder, _ := strconv.ParseInt(fields["account_id"], 10, 64)
+	acquiredAt, _ := strconv.ParseInt(fields["acquired_at"], 10, 64)
+	heartbeatAt, _ := strconv.ParseInt(fields["heartbeat_at"], 10, 64)
+	takenOverFrom, _ := strconv.ParseInt(fields["taken_over_from"], 10, 64)
+	return &post.EditLockVo{
+		PostID:            postID,
+		AccountID:         holder,
+		Nickname:          fields["nickname"],
+		AcquiredAt:        acquiredAt,
+		HeartbeatAt:       heartbeatAt,
+		ExpiresAt:         time.Now().Add(ttl).Unix(),
+		HeartbeatInterval: int(EditLockHeartbeatInterval.Seconds()),
+		TakenOverFrom:     takenOverFrom,
+		Mine:              accountID != 0 && holder == accountID,
+	}, nil
+}
+
+// editLockKey 编辑锁的缓存键
+func editLockKey(postID int64) string {
+	return fmt.Sprintf("%s%d", EditLockKeyPrefix, postID)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/linkpreview"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const (
+	maxPreviewLinks      = 20 // 单篇文章最多抓取的链接数
+	previewFetchParallel = 4  // 同时抓取的链接数
+)
+
+var linkHrefPattern = regexp.MustCompile(`<a[^>]+href="([^"]+)"`)
+
+// GetLinkPreviews 获取文章中外部链接的预览信息，按链接在文中出现的顺序返回；
+// 与文章详情一致校验发布状态与访问权限，密码保护的文章未解锁时不返回预览
+func GetLinkPreviews(req *dto.GetLinkPreviewsRequest, c echo.Context) ([]*post.LinkPreviewVo, error) {
+	pos, err := mapper.GetPostByID(req.PostID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章失败: %v", err)
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+	if pos.Status != model.StatusPublished && !canViewUnpublished(pos, c) {
+		return nil, ErrPostNotFound
+	}
+	if err := checkPostAccess(pos, c); err != nil {
+		return nil, err
+	}
+	if pos.Access == model.AccessPassword && !unlockedPosts([]int64{pos.ID}, c)[pos.ID] {
+		return []*post.LinkPreviewVo{}, nil
+	}
+
+	links := externalLinks(pos.ContentHTML)
+	previews := make([]*post.LinkPreviewVo, len(links))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, previewFetchParallel)
+	for i, link := range links {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, link string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			p := linkpreview.Fetch(c.Request().Context(), link)
+			previews[i] = &post.LinkPreviewVo{
+				URL:         p.URL,
+				Title:       p.Title,
+				Description: p.Description,
+				Image:       p.Image,
+				SiteName:    p.SiteName,
+				Error:       p.Error,
+			}
+		}(i, link)
+	}
+	wg.Wait()
+
+	return previews, nil
+}
+
+// externalLinks 提取 HTML 中指向站外的 http 与 https 链接，去重并限制数量
+func externalLinks(contentHTML string) []string {
+	siteHost := ""
+	if config, err := configs.LoadConfig(); err == nil {
+		if u, err := url.Parse(config.SiteConfig.SiteURL); err == nil {
+			siteHost = u.Hostname()
+		}
+	}
+
+	var links []string
+	seen := make(map[string]bool)
+	for _, match := range linkHrefPattern.FindAllStringSubmatch(contentHTML, -1) {
+		href := match[1]
+		u, err := url.Parse(href)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == siteHost || seen[href] {
+			continue
+		}
+		seen[href] = true
+		links = append(links, href)
+		if len(links) == maxPreviewLinks {
+			break
+		}
+	}
+	return links
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// defaultFeaturedLimit 未指定时返回的精选文章数量
+const defaultFeaturedLimit = 5
+
+// SetPostPinned 设置或取消文章置顶，置顶的文章在文章列表中按置顶顺序排在最前
+func SetPostPinned(req *dto.SetPostPinnedRequest, c echo.Context) (*post.PostsVo, error) {
+	found, err := mapper.SetPostPinned(req.ID, req.Pinned, req.Order)
+	if err != nil {
+		utils.BizLogger(c).Errorf("设置文章置顶失败: %v", err)
+		return nil, fmt.Errorf("设置文章置顶失败: %v", err)
+	}
+	if !found {
+		return nil, ErrPostNotFound
+	}
+	utils.BizLogger(c).Infof("文章 %d 的置顶状态设置为 %t，顺序 %d", req.ID, req.Pinned, req.Order)
+	return postVoByID(req.ID, c)
+}
+
+// SetPostFeatured 设置或取消文章精选，精选的已发布文章按精选顺序出现在精选列表中
+func SetPostFeatured(req *dto.SetPostFeaturedRequest, c echo.Context) (*post.PostsVo, error) {
+	found, err := mapper.SetPostFeatured(req.ID, req.Featured, req.Order)
+	if err != nil {
+		utils.BizLogger(c).Errorf("设置文章精选失败: %v", err)
+		return nil, fmt.Errorf("设置文章精选失败: %v", err)
+	}
+	if !found {
+		return nil, ErrPostNotFound
+	}
+	utils.BizLogger(c).Infof("文章 %d 的精选状态设置为 %t，顺序 %d", req.ID, req.Featured, req.Order)
+	return postVoByID(req.ID, c)
+}
+
+// GetFeaturedPosts 获取已发布的精选文章，按精选顺序排列，用于首页轮播
+func GetFeaturedPosts(req *dto.GetFeaturedPostsRequest, c echo.Context) ([]*post.PostsVo, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultFeaturedLimit
+	}
+
+	posts, err := mapper.GetFeaturedPosts(limit, visibleAccess(c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取精选文章失败: %v", err)
+		return nil, fmt.Errorf("获取精选文章失败: %v", err)
+	}
+
+	result := make([]*post.PostsVo, len(posts))
+	for i, pos := range posts {
+		mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取精选文章时映射 vo 失败: %v", err)
+			return nil, fmt.Errorf("获取精选文章时映射 vo 失败: %v", err)
+		}
+
+		postVo := mapped.(*post.PostsVo)
+		if len(postVo.ContentHTML) > 150 {
+			postVo.ContentHTML = postVo.ContentHTML[:150]
+		}
+		result[i] = postVo
+	}
+	fillAuthors(result, c)
+	lockPostContent(result, c)
+	return result, nil
+}
+
+// postVoByID 重新读取文章并映射为 vo
+func postVoByID(postID int64, c echo.Context) (*post.PostsVo, error) {
+	pos, err := mapper.GetPostByID(postID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章失败: %v", err)
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+	mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("映射文章 vo 失败: %v", err)
+		return nil, fmt.Errorf("映射文章 vo 失败: %v", err)
+	}
+	return mapped.(*post.PostsVo), nil
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"mime/multipart"
 	"strconv"
 	"strings"
@@ -12,9 +11,12 @@ import (
 	"github.com/labstack/echo/v4"
 
 	model "jank.com/jank_blog/internal/model/post"
+	revision "jank.com/jank_blog/internal/model/revision"
 	"jank.com/jank_blog/internal/utils"
 	"jank.com/jank_blog/pkg/serve/controller/post/dto"
 	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	"jank.com/jank_blog/pkg/vo"
 	"jank.com/jank_blog/pkg/vo/post"
 )
 
@@ -78,15 +80,45 @@ func CreateOnePost(req *dto.CreateOnePostRequest, c echo.Context) (*post.PostsVo
 		Title:           req.Title,
 		Image:           req.Image,
 		Visibility:      req.Visibility,
+		Status:          model.StatusFromVisibility(req.Visibility),
 		ContentMarkdown: ContentMarkdown,
 		ContentHTML:     ContentHTML,
 		CategoryIDs:     CategoryIDs,
 	}
+	if err := applyAccess(newPost, req.Access, req.AccessPassword); err != nil {
+		utils.BizLogger(c).Errorf("设置文章访问权限失败: %v", err)
+		return nil, err
+	}
+	if err := applyLanguage(newPost, req.Language); err != nil {
+		utils.BizLogger(c).Errorf("设置文章语言失败: %v", err)
+		return nil, err
+	}
+	newPost.Fingerprint = fingerprint(newPost)
+	fillReadingStats(newPost)
+	duplicates, err := checkDuplicates(newPost, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("创建文章失败: %v", err)
+		return nil, err
+	}
+
+	if err := assignSlug(newPost, req.Slug); err != nil {
+		utils.BizLogger(c).Errorf("生成文章别名失败: %v", err)
+		return nil, err
+	}
 
 	if err := mapper.CreatePost(newPost); err != nil {
 		utils.BizLogger(c).Errorf("创建文章失败: %v", err)
 		return nil, fmt.Errorf("创建文章失败: %v", err)
 	}
+	recordPostRevision(newPost, revision.ActionCreate, 0, c)
+	addPostCreator(newPost, c)
+	indexPostSuggestions(newPost, c)
+	searchService.IndexPost(newPost)
+	invalidateArchiveCache(c)
+	clearDraft(0, c)
+	if newPost.Visibility {
+		notifyPostsPublished(newPost)
+	}
 
 	vo, err := utils.MapModelToVO(newPost, &post.PostsVo{})
 	if err != nil {
@@ -94,10 +126,15 @@ func CreateOnePost(req *dto.CreateOnePostRequest, c echo.Context) (*post.PostsVo
 		return nil, fmt.Errorf("创建文章时映射 vo 失败: %v", err)
 	}
 
-	return vo.(*post.PostsVo), nil
+	postVo := vo.(*post.PostsVo)
+	postVo.Duplicates = duplicates
+	fillAuthors([]*post.PostsVo{postVo}, c)
+	fillShortURL(postVo, c)
+	return postVo, nil
 }
 
-// GetOnePostByIDOrTitle 根据 ID 或 Title 获取文章
+// GetOnePostByIDOrTitle 根据 ID 或 Title 获取文章，未登录时只能获取已发布且不是仅登录可见的文章，
+// 密码保护的文章未解锁时不返回正文；按 ID 获取且传入 lang 时，文章有该语言的翻译则返回翻译
 func GetOnePostByIDOrTitle(req *dto.GetOnePostRequest, c echo.Context) (interface{}, error) {
 	if req.ID == 0 && req.Title == "" {
 		utils.BizLogger(c).Error("参数 id 和 title 不能同时为空")
@@ -115,6 +152,14 @@ func GetOnePostByIDOrTitle(req *dto.GetOnePostRequest, c echo.Context) (interfac
 			utils.BizLogger(c).Errorf("文章不存在: %v", err)
 			return nil, fmt.Errorf("文章不存在: %v", err)
 		}
+		if pos.Status != model.StatusPublished && !canViewUnpublished(pos, c) {
+			utils.BizLogger(c).Errorf("文章 %d 未发布，访客不能查看", pos.ID)
+			return nil, fmt.Errorf("文章不存在")
+		}
+		if err := checkPostAccess(pos, c); err != nil {
+			return nil, err
+		}
+		pos = negotiateTranslation(pos, req.Lang, c)
 
 		vo, err := utils.MapModelToVO(pos, &post.PostsVo{})
 		if err != nil {
@@ -122,7 +167,17 @@ func GetOnePostByIDOrTitle(req *dto.GetOnePostRequest, c echo.Context) (interfac
 			return nil, fmt.Errorf("获取文章时映射 vo 失败: %v", err)
 		}
 
-		return vo.(*post.PostsVo), nil
+		postVo := vo.(*post.PostsVo)
+		fillAuthors([]*post.PostsVo{postVo}, c)
+		fillTOC(postVo)
+		fillShortURL(postVo, c)
+		fillBookmarkState(postVo, c)
+		fillReactionState(postVo, c)
+		fillSeriesNav(postVo, c)
+		fillTranslations(postVo, pos, c)
+		lockPostContent([]*post.PostsVo{postVo}, c)
+		countView(pos, postVo, c)
+		return postVo, nil
 	}
 
 	// 如果没有传 ID，使用 Title 查询
@@ -131,6 +186,13 @@ func GetOnePostByIDOrTitle(req *dto.GetOnePostRequest, c echo.Context) (interfac
 		utils.BizLogger(c).Errorf("根据标题获取文章失败: %v", err)
 		return nil, fmt.Errorf("根据标题获取文章失败: %v", err)
 	}
+	visible := posts[:0]
+	for _, pos := range posts {
+		if (pos.Status == model.StatusPublished || canViewUnpublished(&pos, c)) && checkPostAccess(&pos, c) == nil {
+			visible = append(visible, pos)
+		}
+	}
+	posts = visible
 	if len(posts) == 0 {
 		utils.BizLogger(c).Errorf("没有找到与标题 \"%s\" 匹配的文章", req.Title)
 		return nil, fmt.Errorf("没有找到与标题 \"%s\" 匹配的文章", req.Title)
@@ -145,35 +207,41 @@ func GetOnePostByIDOrTitle(req *dto.GetOnePostRequest, c echo.Context) (interfac
 		}
 
 		postResponse[i] = vo.(*post.PostsVo)
+		fillTOC(postResponse[i])
+		fillShortURL(postResponse[i], c)
+		fillBookmarkState(postResponse[i], c)
+		fillReactionState(postResponse[i], c)
+		fillSeriesNav(postResponse[i], c)
 	}
+	fillAuthors(postResponse, c)
+	lockPostContent(postResponse, c)
 	return postResponse, nil
 }
 
-// GetAllPostsWithPagingAndFormat 获取格式化后的分页文章列表、总页数和当前页数
-func GetAllPostsWithPagingAndFormat(page, pageSize int, c echo.Context) (map[string]interface{}, error) {
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 5
+// GetAllPostsWithPagingAndFormat 获取格式化后的分页文章列表与分页元数据，置顶的文章排在最前，非管理员只返回已发布的文章，未登录时不包含仅登录可见的文章；
+// 有翻译的文章按 listLanguage 协商的语言只返回一个版本
+func GetAllPostsWithPagingAndFormat(req *dto.GetAllPostsRequest, page vo.PageRequest, c echo.Context) ([]*post.PostsVo, *vo.PageMeta, error) {
+	status := req.Status
+	if !isAdminViewer(c) {
+		status = model.StatusPublished
 	}
 
-	// 获取分页数据和总页数
-	posts, total, err := mapper.GetAllPostsWithPaging(page, pageSize)
+	// 获取分页数据和文章总数
+	posts, total, err := mapper.GetAllPostsWithPaging(status, visibleAccess(c), listLanguage(req.Lang, c), true, page.Offset(), page.Limit())
 	if err != nil {
 		utils.BizLogger(c).Errorf("获取文章列表失败: %v", err)
-		return nil, fmt.Errorf("获取文章列表失败: %v", err)
+		return nil, nil, fmt.Errorf("获取文章列表失败: %v", err)
 	}
 
 	postResponse := make([]*post.PostsVo, len(posts))
 	for i, pos := range posts {
-		vo, err := utils.MapModelToVO(pos, &post.PostsVo{})
+		mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
 		if err != nil {
 			utils.BizLogger(c).Errorf("获取文章列表时映射 vo 失败: %v", err)
-			return nil, fmt.Errorf("获取文章列表时映射 vo 失败: %v", err)
+			return nil, nil, fmt.Errorf("获取文章列表时映射 vo 失败: %v", err)
 		}
 
-		postVo := vo.(*post.PostsVo)
+		postVo := mapped.(*post.PostsVo)
 
 		// 只保留 ContentHTML 的前 150 个字符
 		if len(postVo.ContentHTML) > 150 {
@@ -182,12 +250,10 @@ func GetAllPostsWithPagingAndFormat(page, pageSize int, c echo.Context) (map[str
 
 		postResponse[i] = postVo
 	}
+	fillAuthors(postResponse, c)
+	lockPostContent(postResponse, c)
 
-	return map[string]interface{}{
-		"posts":       &postResponse,
-		"totalPages":  int(math.Ceil(float64(total) / float64(pageSize))),
-		"currentPage": page,
-	}, nil
+	return postResponse, vo.NewPageMeta(page, total), nil
 }
 
 // UpdateOnePost 更新文章
@@ -234,6 +300,9 @@ func UpdateOnePost(req *dto.UpdateOnePostRequest, c echo.Context) (*post.PostsVo
 		return nil, fmt.Errorf("获取文章失败: %v", err)
 	}
 
+	ensureBaseRevision(pos, c)
+	oldTitle, wasVisible := pos.Title, pos.Visibility
+	oldAccess, oldPassword := pos.Access, pos.AccessPassword
 	if req.Title != "" {
 		pos.Title = req.Title
 	}
@@ -242,6 +311,7 @@ func UpdateOnePost(req *dto.UpdateOnePostRequest, c echo.Context) (*post.PostsVo
 	}
 	if req.Visibility != false {
 		pos.Visibility = req.Visibility
+		pos.Status = model.StatusPublished
 	}
 	if ContentMarkdown != "" {
 		pos.ContentMarkdown = ContentMarkdown
@@ -253,17 +323,78 @@ func UpdateOnePost(req *dto.UpdateOnePostRequest, c echo.Context) (*post.PostsVo
 	if len(CategoryIDs) > 0 {
 		pos.CategoryIDs = CategoryIDs
 	}
+	if err := applyAccess(pos, req.Access, req.AccessPassword); err != nil {
+		utils.BizLogger(c).Errorf("设置文章 %d 的访问权限失败: %v", pos.ID, err)
+		return nil, err
+	}
+	if err := applyLanguage(pos, req.Language); err != nil {
+		utils.BizLogger(c).Errorf("设置文章 %d 的语言失败: %v", pos.ID, err)
+		return nil, err
+	}
+
+	// 更新时仅提示疑似重复的文章，不阻止保存
+	var duplicates []int64
+	fp := fingerprint(pos)
+	fingerprintChanged := fp != pos.Fingerprint
+	if fingerprintChanged {
+		pos.Fingerprint = fp
+		duplicates, _ = checkDuplicates(pos, c)
+	}
+	readingChanged := fillReadingStats(pos)
+
+	// 未填写别名时保留原别名，尚未生成别名的旧文章由标题生成
+	var slugErr error
+	switch {
+	case req.Slug != "":
+		slugErr = changeSlug(pos, req.Slug)
+	case pos.Slug == "":
+		slugErr = assignSlug(pos, "")
+	}
+	if slugErr != nil {
+		utils.BizLogger(c).Errorf("更新文章 %d 的别名失败: %v", pos.ID, slugErr)
+		return nil, slugErr
+	}
 
 	if err := mapper.UpdateOnePostByID(req.ID, pos); err != nil {
 		return nil, fmt.Errorf("更新文章失败: %v", err)
 	}
+	// 按结构体更新时会忽略零值，指纹与字数需单独写入
+	if fingerprintChanged {
+		if err := mapper.UpdatePostFingerprint(pos.ID, fp); err != nil {
+			utils.BizLogger(c).Errorf("更新文章 %d 的指纹失败: %v", pos.ID, err)
+		}
+	}
+	if readingChanged {
+		if err := mapper.UpdatePostReadingStats(pos.ID, pos.WordCount, pos.ReadingTime); err != nil {
+			utils.BizLogger(c).Errorf("更新文章 %d 的字数失败: %v", pos.ID, err)
+		}
+	}
+	if pos.Access != oldAccess || pos.AccessPassword != oldPassword {
+		if err := mapper.UpdatePostAccess(pos.ID, pos.Access, pos.AccessPassword); err != nil {
+			utils.BizLogger(c).Errorf("更新文章 %d 的访问权限失败: %v", pos.ID, err)
+			return nil, fmt.Errorf("更新文章访问权限失败: %v", err)
+		}
+	}
+	recordPostRevision(pos, revision.ActionUpdate, 0, c)
+	if wasVisible && (oldTitle != pos.Title || !pos.Visibility || pos.Access == model.AccessLogin) {
+		removePostSuggestion(pos.ID, oldTitle, c)
+	}
+	indexPostSuggestions(pos, c)
+	searchService.IndexPost(pos)
+	invalidateArchiveCache(c)
+	clearDraft(pos.ID, c)
+	if !wasVisible && pos.Visibility {
+		notifyPostsPublished(pos)
+	}
 
 	vo, err := utils.MapModelToVO(pos, &post.PostsVo{})
 	if err != nil {
 		return nil, fmt.Errorf("更新文章时映射 vo 失败: %v", err)
 	}
 
-	return vo.(*post.PostsVo), nil
+	postVo := vo.(*post.PostsVo)
+	postVo.Duplicates = duplicates
+	return postVo, nil
 }
 
 // DeleteOnePost 删除文章
@@ -282,6 +413,14 @@ func DeleteOnePost(req *dto.DeleteOnePostRequest, c echo.Context) error {
 		utils.BizLogger(c).Errorf("删除文章失败: %v", err)
 		return fmt.Errorf("删除文章失败: %v", err)
 	}
+	// 删除的文章可能是翻译组的原文，移出翻译组以便组内其他文章重新选出原文
+	if err := leaveTranslationGroup(pos); err != nil {
+		utils.BizLogger(c).Errorf("解除文章 %d 的翻译关联失败: %v", pos.ID, err)
+	}
+	removePostSuggestion(pos.ID, pos.Title, c)
+	searchService.RemovePost(pos.ID)
+	invalidateArchiveCache(c)
+	clearEditLock(pos.ID, c)
 
 	return nil
 }
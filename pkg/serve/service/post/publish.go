@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/export"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/internal/webhook"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	sitemapService "jank.com/jank_blog/pkg/serve/service/sitemap"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+var ErrPublishAtPassed = errors.New("定时发布时间需晚于当前时间")
+
+var (
+	staticExportMu      sync.Mutex
+	staticExportRunning bool // 是否正在导出静态站点
+	staticExportPending bool // 导出期间是否又有文章发布，导出结束后需重新导出
+)
+
+// SchedulePost 为草稿或归档的文章设置定时发布时间，到期后由定时任务 scheduled_publish 发布，publish_at 为 0 时取消定时发布；
+// 已发布的文章不能定时发布，审核流程中的文章由审核通过时指定发布时间
+func SchedulePost(req *dto.SchedulePostRequest, c echo.Context) (*post.PostsVo, error) {
+	pos, err := mapper.GetPostByID(req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+	if pos.ReviewStatus == model.ReviewStatusPending || pos.ReviewStatus == model.ReviewStatusScheduled {
+		return nil, ErrPostInReview
+	}
+	if req.PublishAt != 0 {
+		if !canTransitPostStatus(pos.Status, model.StatusPublished) {
+			return nil, fmt.Errorf("%w: %s 状态的文章不能定时发布", ErrPostStatusTransition, pos.Status)
+		}
+		if req.PublishAt <= time.Now().Unix() {
+			return nil, ErrPublishAtPassed
+		}
+	}
+
+	scheduled, err := mapper.SchedulePost(pos.ID, pos.Status, req.PublishAt)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	if !scheduled {
+		return nil, fmt.Errorf("%w: 文章的发布状态已被修改，请刷新后重试", ErrPostStatusTransition)
+	}
+	pos.PublishAt = req.PublishAt
+	if req.PublishAt == 0 {
+		utils.BizLogger(c).Infof("文章 %d 取消定时发布", pos.ID)
+	} else {
+		utils.BizLogger(c).Infof("文章 %d 将于 %s 定时发布", pos.ID, time.Unix(req.PublishAt, 0).Format(time.DateTime))
+	}
+
+	mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("设置定时发布时映射 vo 失败: %v", err)
+		return nil, fmt.Errorf("设置定时发布时映射 vo 失败: %v", err)
+	}
+	return mapped.(*post.PostsVo), nil
+}
+
+// publishDirectScheduledPosts 发布作者直接定时发布且发布时间已到的文章，返回发布的文章
+func publishDirectScheduledPosts(now int64) ([]*model.Post, error) {
+	posts, err := mapper.GetDueDirectScheduledPosts(now)
+	if err != nil {
+		return nil, err
+	}
+
+	published := make([]*model.Post, 0, len(posts))
+	for _, pos := range posts {
+		ok, err := mapper.PublishDirectScheduledPost(pos.ID, pos.PublishAt)
+		if err != nil {
+			return published, err
+		}
+		if !ok {
+			// 其他实例已发布，或作者在此期间取消、修改了定时发布
+			continue
+		}
+		pos.Status, pos.Visibility, pos.PublishAt = model.StatusPublished, true, 0
+		published = append(published, pos)
+	}
+	return published, nil
+}
+
+// onScheduledPostsPublished 定时发布文章后更新搜索建议、清除归档缓存与相关文章缓存，并通知订阅方
+func onScheduledPostsPublished(ctx context.Context, posts []*model.Post) {
+	if len(posts) == 0 {
+		return
+	}
+	for _, pos := range posts {
+		if suggestions, err := postSuggestions(pos); err == nil {
+			if err := search.AddSuggestions(ctx, suggestions...); err != nil {
+				global.SysLog.Errorf("更新文章 %d 的搜索建议失败: %v", pos.ID, err)
+			}
+		}
+		searchService.IndexPost(pos)
+	}
+	invalidateRelatedPosts()
+	if err := clearArchiveCache(ctx); err != nil {
+		global.SysLog.Errorf("清除文章归档缓存失败: %v", err)
+	}
+	notifyPostsPublished(posts...)
+}
+
+// notifyPostsPublished 文章发布后发送 post.published Webhook、通知搜索引擎站点地图已更新，并在配置了导出目录时重新导出静态站点、订阅源与站点地图
+func notifyPostsPublished(posts ...*model.Post) {
+	if len(posts) == 0 {
+		return
+	}
+	config, err := configs.LoadConfig()
+	if err != nil {
+		global.SysLog.Errorf("文章发布后加载配置失败: %v", err)
+		return
+	}
+
+	siteURL := strings.TrimRight(config.SiteConfig.SiteURL, "/")
+	now := time.Now().Unix()
+	for _, pos := range posts {
+		data := webhook.PostPublishedData{ID: pos.ID, Title: pos.Title, PublishedAt: now}
+		if siteURL != "" {
+			data.URL = fmt.Sprintf("%s/posts/%d/", siteURL, pos.ID)
+		}
+		webhook.Fire(webhook.EventPostPublished, data)
+	}
+
+	sitemapService.NotifyPublished()
+
+	if config.SiteConfig.SiteStaticExportDir != "" {
+		go exportStaticSite(config)
+	}
+}
+
+// exportStaticSite 重新导出静态站点，同一时间只导出一次，导出期间再有文章发布时在结束后重新导出
+func exportStaticSite(config *configs.Config) {
+	staticExportMu.Lock()
+	if staticExportRunning {
+		staticExportPending = true
+		staticExportMu.Unlock()
+		return
+	}
+	staticExportRunning = true
+	staticExportMu.Unlock()
+
+	for {
+		report, err := export.ExportStatic(export.StaticOptions{
+			OutputDir:  config.SiteConfig.SiteStaticExportDir,
+			ThemeDir:   config.SiteConfig.SiteTheme,
+			SiteTitle:  config.SiteConfig.SiteTitle,
+			SiteURL:    config.SiteConfig.SiteURL,
+			SiteAuthor: config.SiteConfig.SiteAuthor,
+		})
+		if err != nil {
+			global.SysLog.Errorf("发布文章后导出静态站点失败: %v", err)
+		} else {
+			global.SysLog.Infof("发布文章后已重新导出静态站点: %s", report)
+		}
+
+		staticExportMu.Lock()
+		if !staticExportPending {
+			staticExportRunning = false
+			staticExportMu.Unlock()
+			return
+		}
+		staticExportPending = false
+		staticExportMu.Unlock()
+	}
+}
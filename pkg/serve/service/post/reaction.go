@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	reactionService "jank.com/jank_blog/pkg/serve/service/reaction"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// fillReactionState 填充文章每种表态的数量与当前用户或访客已表态的类型，失败时仅记录日志
+func fillReactionState(postVo *post.PostsVo, c echo.Context) {
+	counts, reacted, err := reactionService.ReactionState(postVo.ID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的表态状态失败: %v", postVo.ID, err)
+		return
+	}
+	postVo.Reactions = counts
+	postVo.Reacted = reacted
+}
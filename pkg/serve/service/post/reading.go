@@ -0,0 +1,41 @@
+package service
+
+import (
+	"fmt"
+
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// fillReadingStats 根据渲染后的正文计算文章字数与预计阅读时间，返回是否有变化
+func fillReadingStats(pos *model.Post) bool {
+	words, minutes := utils.ReadingStats(pos.ContentHTML)
+	changed := words != pos.WordCount || minutes != pos.ReadingTime
+	pos.WordCount, pos.ReadingTime = words, minutes
+	return changed
+}
+
+// BackfillReadingStats 为升级前创建、尚未计算字数的文章计算字数与预计阅读时间；启动时调用
+func BackfillReadingStats() error {
+	posts, err := mapper.GetPostsWithoutReadingStats()
+	if err != nil {
+		return fmt.Errorf("获取待计算字数的文章失败: %v", err)
+	}
+
+	updated := 0
+	for _, pos := range posts {
+		if !fillReadingStats(pos) {
+			continue
+		}
+		if err := mapper.UpdatePostReadingStats(pos.ID, pos.WordCount, pos.ReadingTime); err != nil {
+			return fmt.Errorf("写入文章 %d 的字数失败: %v", pos.ID, err)
+		}
+		updated++
+	}
+	if updated > 0 {
+		global.SysLog.Infof("已为 %d 篇文章计算字数与阅读时间", updated)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// GetRecommendedPosts 获取推荐文章，传入文章 ID 时按共同类目数量排序，其余按发布时间倒序；登录用户会排除已读文章
+func GetRecommendedPosts(req *dto.GetRecommendedPostsRequest, c echo.Context) ([]*post.PostsVo, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+
+	posts, err := mapper.GetAllPublishedPosts(visibleAccess(c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取推荐文章失败: %v", err)
+		return nil, fmt.Errorf("获取推荐文章失败: %v", err)
+	}
+
+	excluded := map[int64]bool{req.PostID: true}
+	if accountID := utils.OptionalAccountIDFromJWT(c.Request().Header.Get("Authorization")); accountID > 0 {
+		readIDs, err := mapper.GetReadPostIDs(accountID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取阅读历史失败: %v", err)
+			return nil, fmt.Errorf("获取阅读历史失败: %v", err)
+		}
+		for _, id := range readIDs {
+			excluded[id] = true
+		}
+	}
+
+	related := make(map[int64]bool)
+	for _, pos := range posts {
+		if pos.ID == req.PostID {
+			for _, id := range pos.CategoryIDs {
+				related[id] = true
+			}
+			break
+		}
+	}
+
+	candidates := make([]*model.Post, 0, len(posts))
+	shared := make(map[int64]int, len(posts))
+	for _, pos := range posts {
+		if excluded[pos.ID] {
+			continue
+		}
+		for _, id := range pos.CategoryIDs {
+			if related[id] {
+				shared[pos.ID]++
+			}
+		}
+		candidates = append(candidates, pos)
+	}
+	// 文章列表已按发布时间倒序，稳定排序保证共同类目数量相同时新文章在前
+	sort.SliceStable(candidates, func(i, j int) bool { return shared[candidates[i].ID] > shared[candidates[j].ID] })
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	result := make([]*post.PostsVo, len(candidates))
+	for i, pos := range candidates {
+		mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取推荐文章时映射 vo 失败: %v", err)
+			return nil, fmt.Errorf("获取推荐文章时映射 vo 失败: %v", err)
+		}
+
+		postVo := mapped.(*post.PostsVo)
+		if len(postVo.ContentHTML) > 150 {
+			postVo.ContentHTML = postVo.ContentHTML[:150]
+		}
+		result[i] = postVo
+	}
+	fillAuthors(result, c)
+	lockPostContent(result, c)
+	return result, nil
+}
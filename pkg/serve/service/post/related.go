@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const (
+	RelatedCacheExpireTime = time.Minute * 30 // 相关文章缓存有效期，文章变更时主动失效
+	defaultRelatedLimit    = 5                // 未指定时返回的相关文章数量
+	maxRelatedLimit        = 20               // 每篇文章缓存的相关文章数量，即可返回的最大数量
+	relatedContentWeight   = 0.7              // 内容 TF-IDF 相似度在相关度中的权重
+	relatedCategoryWeight  = 0.3              // 类目重合度在相关度中的权重
+)
+
+// relatedHit 相关文章及其相关度
+type relatedHit struct {
+	PostID int64
+	Score  float64
+}
+
+// relatedIndex 已发布文章的 TF-IDF 语料库，以及已计算过的相关文章
+type relatedIndex struct {
+	corpus  *search.Corpus
+	posts   map[int64]*model.Post
+	order   []int64 // 文章 ID，按发布时间倒序
+	results map[int64][]relatedHit
+}
+
+var (
+	relatedMu     sync.Mutex
+	relatedCached *relatedIndex // 失效后为 nil
+	relatedUntil  time.Time
+)
+
+// GetRelatedPosts 获取与文章相关的已发布文章，相关度由内容的 TF-IDF 相似度与类目重合度加权得出；
+// 结果缓存在本地，文章变更后失效，最长缓存 RelatedCacheExpireTime
+func GetRelatedPosts(req *dto.GetRelatedPostsRequest, c echo.Context) ([]*post.RelatedPostVo, error) {
+	pos, err := mapper.GetPostByID(req.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章失败: %v", err)
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+	if pos.Status != model.StatusPublished && !canViewUnpublished(pos, c) {
+		return nil, ErrPostNotFound
+	}
+	if err := checkPostAccess(pos, c); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultRelatedLimit
+	}
+
+	relatedMu.Lock()
+	defer relatedMu.Unlock()
+	if relatedCached == nil || time.Now().After(relatedUntil) {
+		index, err := buildRelatedIndex()
+		if err != nil {
+			utils.BizLogger(c).Errorf("构建相关文章语料库失败: %v", err)
+			return nil, fmt.Errorf("构建相关文章语料库失败: %v", err)
+		}
+		relatedCached, relatedUntil = index, time.Now().Add(RelatedCacheExpireTime)
+	}
+
+	hits, ok := relatedCached.results[pos.ID]
+	if !ok {
+		hits = relatedCached.related(pos)
+		// 未发布的文章不在语料库中，内容可能随时修改，不缓存
+		if pos.Status == model.StatusPublished {
+			relatedCached.results[pos.ID] = hits
+		}
+	}
+	// 语料库包含仅登录可见的文章，未登录时从结果中剔除
+	if !isLoggedIn(c) {
+		visible := make([]relatedHit, 0, len(hits))
+		for _, hit := range hits {
+			if relatedCached.posts[hit.PostID].Access != model.AccessLogin {
+				visible = append(visible, hit)
+			}
+		}
+		hits = visible
+	}
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	result := make([]*post.RelatedPostVo, len(hits))
+	for i, hit := range hits {
+		related := relatedCached.posts[hit.PostID]
+		result[i] = &post.RelatedPostVo{
+			ID:          related.ID,
+			Title:       related.Title,
+			Slug:        related.Slug,
+			Image:       related.Image,
+			CategoryIDs: related.CategoryIDs,
+			Score:       math.Round(hit.Score*1000) / 1000,
+		}
+	}
+	return result, nil
+}
+
+// invalidateRelatedPosts 文章变更后清除相关文章缓存，下次访问时重新构建
+func invalidateRelatedPosts() {
+	relatedMu.Lock()
+	relatedCached = nil
+	relatedMu.Unlock()
+}
+
+// buildRelatedIndex 根据所有已发布文章构建 TF-IDF 语料库，包含仅登录可见的文章，文章的类目只保留未删除的类目
+func buildRelatedIndex() (*relatedIndex, error) {
+	posts, err := mapper.GetAllPublishedPosts(nil)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := mapper.GetAllActivatedCategories()
+	if err != nil {
+		return nil, err
+	}
+	activated := make(map[int64]bool, len(categories))
+	for _, cat := range categories {
+		activated[cat.ID] = true
+	}
+
+	index := &relatedIndex{
+		posts:   make(map[int64]*model.Post, len(posts)),
+		order:   make([]int64, len(posts)),
+		results: make(map[int64][]relatedHit),
+	}
+	docs := make([]search.Document, len(posts))
+	for i, pos := range posts {
+		valid := make(model.CategoryIDsArray, 0, len(pos.CategoryIDs))
+		for _, id := range pos.CategoryIDs {
+			if activated[id] {
+				valid = append(valid, id)
+			}
+		}
+		pos.CategoryIDs = valid
+		index.posts[pos.ID] = pos
+		index.order[i] = pos.ID
+		docs[i] = search.Document{ID: pos.ID, Title: pos.Title, Content: pos.ContentMarkdown}
+	}
+	index.corpus = search.NewCorpus(docs)
+	return index, nil
+}
+
+// related 计算与文章最相关的至多 maxRelatedLimit 篇已发布文章，相关度为 0 的文章不返回，相关度相同时新文章在前
+func (index *relatedIndex) related(pos *model.Post) []relatedHit {
+	scores := make(map[int64]float64)
+	for _, hit := range index.corpus.Similar(search.Document{Title: pos.Title, Content: pos.ContentMarkdown}, 0) {
+		scores[hit.ID] += relatedContentWeight * hit.Score
+	}
+	for id, candidate := range index.posts {
+		if overlap := categoryOverlap(pos.CategoryIDs, candidate.CategoryIDs); overlap > 0 {
+			scores[id] += relatedCategoryWeight * overlap
+		}
+	}
+
+	hits := make([]relatedHit, 0, len(scores))
+	for _, id := range index.order {
+		if score, ok := scores[id]; ok && id != pos.ID {
+			hits = append(hits, relatedHit{PostID: id, Score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > maxRelatedLimit {
+		hits = hits[:maxRelatedLimit]
+	}
+	return hits
+}
+
+// categoryOverlap 计算两篇文章类目的 Jaccard 系数，任一文章没有类目时为 0
+func categoryOverlap(a, b []int64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := make(map[int64]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	shared, union := 0, len(set)
+	seen := make(map[int64]bool, len(b))
+	for _, id := range b {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if set[id] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/post"
+	review "jank.com/jank_blog/internal/model/review"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// SubmitForReview 作者提交文章审核，仅未发布且未在审核中的文章可以提交
+func SubmitForReview(req *dto.SubmitForReviewRequest, c echo.Context) error {
+	pos, err := mapper.GetPostByID(req.PostID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return fmt.Errorf("文章不存在: %v", err)
+	}
+	if pos.Visibility {
+		utils.BizLogger(c).Errorf("文章 %d 已发布，无需审核", pos.ID)
+		return fmt.Errorf("文章已发布，无需审核")
+	}
+	if pos.ReviewStatus != model.ReviewStatusNone && pos.ReviewStatus != model.ReviewStatusChangesRequested {
+		utils.BizLogger(c).Errorf("文章 %d 的审核状态为 %s，不能重复提交", pos.ID, pos.ReviewStatus)
+		return fmt.Errorf("文章已提交审核，不能重复提交")
+	}
+
+	// 提交审核后由审核通过时指定发布时间，取消作者此前设置的定时发布，避免退回修改后仍按原时间发布
+	return transitReview(pos, review.ActionSubmit, model.ReviewStatusPending, req.Comment,
+		map[string]interface{}{"publish_at": 0}, c)
+}
+
+// ApproveReview 编辑审核通过，未指定发布时间或发布时间已过时立即发布，否则等待定时发布
+func ApproveReview(req *dto.ApproveReviewRequest, c echo.Context) error {
+	pos, err := pendingReviewPost(req.PostID, c)
+	if err != nil {
+		return err
+	}
+
+	if req.PublishAt > time.Now().Unix() {
+		return transitReview(pos, review.ActionSchedule, model.ReviewStatusScheduled, req.Comment,
+			map[string]interface{}{"publish_at": req.PublishAt}, c)
+	}
+
+	err = transitReview(pos, review.ActionApprove, model.ReviewStatusApproved, req.Comment,
+		map[string]interface{}{"visibility": true, "status": model.StatusPublished, "publish_at": 0}, c)
+	if err != nil {
+		return err
+	}
+
+	pos.Visibility, pos.Status, pos.PublishAt = true, model.StatusPublished, 0
+	indexPostSuggestions(pos, c)
+	searchService.IndexPost(pos)
+	invalidateArchiveCache(c)
+	notifyPostsPublished(pos)
+	return nil
+}
+
+// RequestReviewChanges 编辑退回文章并附上修改意见，作者修改后可重新提交
+func RequestReviewChanges(req *dto.RequestReviewChangesRequest, c echo.Context) error {
+	pos, err := pendingReviewPost(req.PostID, c)
+	if err != nil {
+		return err
+	}
+	return transitReview(pos, review.ActionRequestChanges, model.ReviewStatusChangesRequested, req.Comment, nil, c)
+}
+
+// GetReviewQueue 获取等待审核的文章分页列表，等待最久的排在前面
+func GetReviewQueue(page vo.PageRequest, c echo.Context) ([]*post.PostsVo, *vo.PageMeta, error) {
+	posts, total, err := mapper.GetPostsByReviewStatusWithPaging(model.ReviewStatusPending, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取审核队列失败: %v", err)
+		return nil, nil, fmt.Errorf("获取审核队列失败: %v", err)
+	}
+
+	result := make([]*post.PostsVo, len(posts))
+	for i, pos := range posts {
+		mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("获取审核队列时映射 vo 失败: %v", err)
+			return nil, nil, fmt.Errorf("获取审核队列时映射 vo 失败: %v", err)
+		}
+
+		postVo := mapped.(*post.PostsVo)
+		if len(postVo.ContentHTML) > 150 {
+			postVo.ContentHTML = postVo.ContentHTML[:150]
+		}
+		result[i] = postVo
+	}
+
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// GetReviewHistory 获取文章的审核记录，按时间正序排列
+func GetReviewHistory(req *dto.GetReviewHistoryRequest, c echo.Context) ([]*post.ReviewRecordVo, error) {
+	records, err := mapper.GetPostReviewsByPostID(req.PostID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取审核记录失败: %v", err)
+		return nil, fmt.Errorf("获取审核记录失败: %v", err)
+	}
+
+	result := make([]*post.ReviewRecordVo, len(records))
+	for i, record := range records {
+		result[i] = &post.ReviewRecordVo{
+			ID:         record.ID,
+			PostID:     record.PostID,
+			AccountID:  record.AccountID,
+			Action:     record.Action,
+			FromStatus: record.FromStatus,
+			ToStatus:   record.ToStatus,
+			Comment:    record.Comment,
+			GmtCreate:  record.GmtCreate,
+		}
+	}
+	return result, nil
+}
+
+// PublishScheduledPosts 发布定时发布时间已到的文章，返回发布的文章数，供定时任务调用
+func PublishScheduledPosts(ctx context.Context) (int, error) {
+	posts, err := mapper.GetDueScheduledPosts(time.Now().Unix())
+	if err != nil {
+		return 0, err
+	}
+
+	published := make([]*model.Post, 0, len(posts))
+	for _, pos := range posts {
+		err := mapper.TransitPostReviewStatus(&review.PostReview{
+			PostID:     pos.ID,
+			Action:     review.ActionPublish,
+			FromStatus: model.ReviewStatusScheduled,
+			ToStatus:   model.ReviewStatusApproved,
+		}, map[string]interface{}{"visibility": true, "status": model.StatusPublished, "publish_at": 0})
+		if errors.Is(err, mapper.ErrReviewStatusChanged) {
+			continue
+		}
+		if err != nil {
+			onScheduledPostsPublished(ctx, published)
+			return len(published), err
+		}
+		pos.Visibility, pos.Status, pos.PublishAt = true, model.StatusPublished, 0
+		published = append(published, pos)
+	}
+
+	direct, err := publishDirectScheduledPosts(time.Now().Unix())
+	published = append(published, direct...)
+	onScheduledPostsPublished(ctx, published)
+	return len(published), err
+}
+
+// pendingReviewPost 获取等待审核的文章
+func pendingReviewPost(postID int64, c echo.Context) (*model.Post, error) {
+	pos, err := mapper.GetPostByID(postID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+	if pos.ReviewStatus != model.ReviewStatusPending {
+		utils.BizLogger(c).Errorf("文章 %d 的审核状态为 %q，不在审核队列中", pos.ID, pos.ReviewStatus)
+		return nil, fmt.Errorf("文章不在审核队列中")
+	}
+	return pos, nil
+}
+
+// transitReview 以当前账户变更文章审核状态并写入审核记录
+func transitReview(pos *model.Post, action, toStatus, comment string, updates map[string]interface{}, c echo.Context) error {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析访问令牌失败: %v", err)
+		return fmt.Errorf("解析访问令牌失败: %v", err)
+	}
+
+	err = mapper.TransitPostReviewStatus(&review.PostReview{
+		PostID:     pos.ID,
+		AccountID:  accountID,
+		Action:     action,
+		FromStatus: pos.ReviewStatus,
+		ToStatus:   toStatus,
+		Comment:    comment,
+	}, updates)
+	if err != nil {
+		utils.BizLogger(c).Errorf("变更文章 %d 的审核状态失败: %v", pos.ID, err)
+		return fmt.Errorf("变更文章审核状态失败: %v", err)
+	}
+
+	pos.ReviewStatus = toStatus
+	return nil
+}
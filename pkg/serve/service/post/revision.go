@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/post"
+	revision "jank.com/jank_blog/internal/model/revision"
+	"jank.com/jank_blog/internal/textdiff"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// revisionDiffContext 修订对比时每处修改前后保留的相同行数
+const revisionDiffContext = 3
+
+var ErrRevisionNotFound = errors.New("文章修订不存在")
+
+// GetPostRevisions 获取文章的修订分页列表，按版本号倒序排列
+func GetPostRevisions(req *dto.GetPostRevisionsRequest, page vo.PageRequest, c echo.Context) ([]*post.PostRevisionVo, *vo.PageMeta, error) {
+	revs, total, err := mapper.GetPostRevisionsWithPaging(req.PostID, page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, nil, err
+	}
+
+	result := make([]*post.PostRevisionVo, len(revs))
+	for i, rev := range revs {
+		result[i] = &post.PostRevisionVo{
+			ID:           rev.ID,
+			PostID:       rev.PostID,
+			Version:      rev.Version,
+			AccountID:    rev.AccountID,
+			Action:       rev.Action,
+			RestoredFrom: rev.RestoredFrom,
+			Title:        rev.Title,
+			GmtCreate:    rev.GmtCreate,
+		}
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// GetRevisionDiff 对比文章的两个修订，to 为 0 时与文章当前内容对比
+func GetRevisionDiff(req *dto.GetRevisionDiffRequest, c echo.Context) (*post.RevisionDiffVo, error) {
+	from, err := postRevision(req.PostID, req.From, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *revision.PostRevision
+	toName := "current"
+	if req.To > 0 {
+		if to, err = postRevision(req.PostID, req.To, c); err != nil {
+			return nil, err
+		}
+		toName = fmt.Sprintf("v%d", req.To)
+	} else {
+		pos, err := mapper.GetPostByID(req.PostID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("文章不存在: %v", err)
+			return nil, fmt.Errorf("文章不存在: %v", err)
+		}
+		to = snapshotPost(pos)
+	}
+
+	diff := textdiff.Compare(from.ContentMarkdown, to.ContentMarkdown)
+	return &post.RevisionDiffVo{
+		PostID:          req.PostID,
+		From:            req.From,
+		To:              req.To,
+		FromTitle:       from.Title,
+		ToTitle:         to.Title,
+		FromImage:       from.Image,
+		ToImage:         to.Image,
+		FromCategoryIDs: from.CategoryIDs,
+		ToCategoryIDs:   to.CategoryIDs,
+		Diff:            diff.Unified(fmt.Sprintf("v%d", req.From), toName, revisionDiffContext),
+		Additions:       diff.Additions,
+		Deletions:       diff.Deletions,
+	}, nil
+}
+
+// RestorePostRevision 将文章的标题、封面、正文与分类恢复为指定修订的内容，发布状态与审核状态不变；
+// 恢复后记为一次新的修订，此前的修订均保留，可再次恢复
+func RestorePostRevision(req *dto.RestoreRevisionRequest, c echo.Context) (*post.PostsVo, error) {
+	rev, err := postRevision(req.PostID, req.Version, c)
+	if err != nil {
+		return nil, err
+	}
+	pos, err := mapper.GetPostByID(req.PostID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+
+	oldTitle := pos.Title
+	pos.Title, pos.Image, pos.ContentMarkdown, pos.CategoryIDs = rev.Title, rev.Image, rev.ContentMarkdown, rev.CategoryIDs
+	if pos.ContentHTML, err = utils.RenderMarkdown([]byte(pos.ContentMarkdown)); err != nil {
+		return nil, fmt.Errorf("渲染 Markdown 失败: %v", err)
+	}
+	pos.Fingerprint = fingerprint(pos)
+	fillReadingStats(pos)
+	if err := mapper.UpdatePostContent(pos); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+	utils.BizLogger(c).Infof("文章 %d 已恢复到修订 v%d", pos.ID, rev.Version)
+
+	recordPostRevision(pos, revision.ActionRestore, rev.Version, c)
+	if pos.Visibility && oldTitle != pos.Title {
+		removePostSuggestion(pos.ID, oldTitle, c)
+	}
+	indexPostSuggestions(pos, c)
+	searchService.IndexPost(pos)
+	invalidateArchiveCache(c)
+	clearDraft(pos.ID, c)
+
+	mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("恢复修订时映射 vo 失败: %v", err)
+		return nil, fmt.Errorf("恢复修订时映射 vo 失败: %v", err)
+	}
+	return mapped.(*post.PostsVo), nil
+}
+
+// recordPostRevision 保存文章后写入修订记录，并按 REVISION_MAX_PER_POST 清理最早的修订；
+// 内容与最新修订相同时（如仅变更发布状态）不重复记录，恢复操作始终记录；写入失败时仅记录日志，不影响文章保存
+func recordPostRevision(pos *model.Post, action string, restoredFrom int, c echo.Context) {
+	rev := snapshotPost(pos)
+	rev.Action, rev.RestoredFrom = action, restoredFrom
+	rev.AccountID = utils.OptionalAccountIDFromJWT(c.Request().Header.Get("Authorization"))
+
+	if action != revision.ActionRestore {
+		latest, err := mapper.GetLatestPostRevision(pos.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("%v", err)
+		} else if latest != nil && sameRevisionContent(latest, rev) {
+			return
+		}
+	}
+	if err := mapper.CreatePostRevision(rev); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil || config.RevisionConfig.RevisionMaxPerPost <= 0 {
+		return
+	}
+	if _, err := mapper.PrunePostRevisions(pos.ID, config.RevisionConfig.RevisionMaxPerPost); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+	}
+}
+
+// ensureBaseRevision 更新文章前调用，文章还没有修订时（如启用修订记录前创建的文章）先记录更新前的内容，保存人记为 0
+func ensureBaseRevision(pos *model.Post, c echo.Context) {
+	latest, err := mapper.GetLatestPostRevision(pos.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return
+	}
+	if latest != nil {
+		return
+	}
+	rev := snapshotPost(pos)
+	rev.Action = revision.ActionCreate
+	if err := mapper.CreatePostRevision(rev); err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+	}
+}
+
+// postRevision 获取文章的指定版本修订，不存在时返回 ErrRevisionNotFound
+func postRevision(postID int64, version int, c echo.Context) (*revision.PostRevision, error) {
+	rev, err := mapper.GetPostRevisionByVersion(postID, version)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRevisionNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的修订 v%d 失败: %v", postID, version, err)
+		return nil, fmt.Errorf("获取文章修订失败: %v", err)
+	}
+	return rev, nil
+}
+
+// snapshotPost 以文章当前的内容生成修订快照
+func snapshotPost(pos *model.Post) *revision.PostRevision {
+	return &revision.PostRevision{
+		PostID:          pos.ID,
+		Title:           pos.Title,
+		Image:           pos.Image,
+		ContentMarkdown: pos.ContentMarkdown,
+		CategoryIDs:     pos.CategoryIDs,
+	}
+}
+
+// sameRevisionContent 两个修订的标题、封面、正文与分类是否相同
+func sameRevisionContent(a, b *revision.PostRevision) bool {
+	return a.Title == b.Title && a.Image == b.Image && a.ContentMarkdown == b.ContentMarkdown &&
+		slices.Equal(a.CategoryIDs, b.CategoryIDs)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// SearchPosts 按关键词搜索已发布文章，结果按相关度排序并分页，未登录时不包含仅登录可见的文章
+func SearchPosts(req *dto.SearchPostsRequest, page vo.PageRequest, c echo.Context) ([]*post.SearchResultVo, *vo.PageMeta, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载搜索配置失败: %v", err)
+		return nil, nil, fmt.Errorf("加载搜索配置失败: %v", err)
+	}
+
+	posts, err := mapper.GetAllPublishedPosts(visibleAccess(c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("搜索文章失败: %v", err)
+		return nil, nil, fmt.Errorf("搜索文章失败: %v", err)
+	}
+
+	// 密码保护的文章只按标题匹配，避免通过搜索结果推断正文
+	docs := make([]search.Document, len(posts))
+	for i, pos := range posts {
+		docs[i] = search.Document{ID: pos.ID, Title: pos.Title, Content: pos.ContentMarkdown}
+		if pos.Access == model.AccessPassword {
+			docs[i].Content = ""
+		}
+	}
+	hits := search.Match(req.Keyword, docs, search.Options{
+		Fuzzy:       config.SearchConfig.SearchFuzzyEnabled,
+		MaxDistance: config.SearchConfig.SearchFuzzyMaxDistance,
+		Penalty:     config.SearchConfig.SearchFuzzyPenalty,
+	})
+
+	byID := make(map[int64]int, len(posts))
+	for i, pos := range posts {
+		byID[pos.ID] = i
+	}
+
+	start, end := page.Offset(), page.Offset()+page.Limit()
+	if start > len(hits) {
+		start = len(hits)
+	}
+	if end > len(hits) {
+		end = len(hits)
+	}
+
+	results := make([]*post.SearchResultVo, 0, end-start)
+	postVos := make([]*post.PostsVo, 0, end-start)
+	for _, hit := range hits[start:end] {
+		mapped, err := utils.MapModelToVO(posts[byID[hit.ID]], &post.PostsVo{})
+		if err != nil {
+			utils.BizLogger(c).Errorf("搜索文章时映射 vo 失败: %v", err)
+			return nil, nil, fmt.Errorf("搜索文章时映射 vo 失败: %v", err)
+		}
+
+		postVo := mapped.(*post.PostsVo)
+		if len(postVo.ContentHTML) > 150 {
+			postVo.ContentHTML = postVo.ContentHTML[:150]
+		}
+		results = append(results, &post.SearchResultVo{Post: postVo, Score: hit.Score, Fuzzy: hit.Fuzzy})
+		postVos = append(postVos, postVo)
+	}
+	fillAuthors(postVos, c)
+	lockPostContent(postVos, c)
+
+	return results, vo.NewPageMeta(page, int64(len(hits))), nil
+}
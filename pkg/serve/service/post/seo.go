@@ -0,0 +1,141 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/llm"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const maxPromptContentLength = 8000 // 发送给大模型的正文最大字符数
+
+// seoFields 可生成的 SEO 文本字段及其生成要求
+var seoFields = []struct {
+	key         string
+	requirement string
+}{
+	{model.ExtSummary, "文章摘要，概括文章要点，不超过 200 字"},
+	{model.ExtMetaDescription, "页面 meta 描述，适合搜索引擎展示，不超过 80 字"},
+	{model.ExtSocialBlurb, "社交媒体分享文案，口吻轻松、吸引点击，不超过 100 字"},
+}
+
+// GenerateSeoText 使用大模型生成文章摘要、meta 描述与分享文案
+// Apply 为 true 时仅写入文章中尚未填写的字段，已有文本一律保留
+func GenerateSeoText(req *dto.GenerateSeoTextRequest, c echo.Context) (*post.SeoTextVo, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载大模型配置失败: %v", err)
+		return nil, fmt.Errorf("加载大模型配置失败: %v", err)
+	}
+	provider, err := llm.New(config)
+	if err != nil {
+		utils.BizLogger(c).Errorf("创建大模型服务失败: %v", err)
+		return nil, fmt.Errorf("创建大模型服务失败: %w", err)
+	}
+
+	pos, err := mapper.GetPostByID(req.PostID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+
+	wanted := make(map[string]bool)
+	for _, field := range req.Fields {
+		wanted[field] = true
+	}
+	var keys, requirements []string
+	for _, field := range seoFields {
+		if len(wanted) == 0 || wanted[field.key] {
+			keys = append(keys, field.key)
+			requirements = append(requirements, fmt.Sprintf("- %s: %s", field.key, field.requirement))
+		}
+	}
+
+	content := []rune(pos.ContentMarkdown)
+	if len(content) > maxPromptContentLength {
+		content = content[:maxPromptContentLength]
+	}
+	reply, err := provider.Complete(c.Request().Context(), []llm.Message{
+		{Role: llm.RoleSystem, Content: "你是一名博客编辑，负责为文章撰写摘要与推广文案。只输出一个 JSON 对象，不要输出其他内容。"},
+		{Role: llm.RoleUser, Content: fmt.Sprintf("请为下面的文章生成以下字段，语言与文章保持一致：\n%s\n\n标题：%s\n\n正文：\n%s",
+			strings.Join(requirements, "\n"), pos.Title, string(content))},
+	})
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成文章摘要失败: %v", err)
+		return nil, fmt.Errorf("生成文章摘要失败: %v", err)
+	}
+
+	generated, err := parseJSONObject(reply)
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析大模型生成结果失败: %v", err)
+		return nil, fmt.Errorf("解析大模型生成结果失败: %v", err)
+	}
+
+	result := &post.SeoTextVo{
+		PostID:          pos.ID,
+		Summary:         generated[model.ExtSummary],
+		MetaDescription: generated[model.ExtMetaDescription],
+		SocialBlurb:     generated[model.ExtSocialBlurb],
+		Applied:         []string{},
+		Skipped:         []string{},
+	}
+	if !req.Apply {
+		return result, nil
+	}
+
+	if pos.Ext == nil {
+		pos.Ext = make(map[string]interface{})
+	}
+	for _, key := range keys {
+		if existing, _ := pos.Ext[key].(string); strings.TrimSpace(existing) != "" {
+			result.Skipped = append(result.Skipped, key)
+			continue
+		}
+		if generated[key] == "" {
+			continue
+		}
+		pos.Ext[key] = generated[key]
+		result.Applied = append(result.Applied, key)
+	}
+
+	if len(result.Applied) > 0 {
+		if err := mapper.UpdateOnePostByID(pos.ID, pos); err != nil {
+			utils.BizLogger(c).Errorf("写入文章摘要失败: %v", err)
+			return nil, fmt.Errorf("写入文章摘要失败: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// parseJSONObject 从大模型回复中提取 JSON 对象中的字符串字段
+func parseJSONObject(reply string) (map[string]string, error) {
+	var raw map[string]interface{}
+	if err := decodeJSONObject(reply, &raw); err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if text, ok := value.(string); ok {
+			result[key] = strings.TrimSpace(text)
+		}
+	}
+	return result, nil
+}
+
+// decodeJSONObject 解析大模型回复中的 JSON 对象，兼容包裹在代码块中的输出
+func decodeJSONObject(reply string, v interface{}) error {
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("回复中没有 JSON 对象")
+	}
+	return json.Unmarshal([]byte(reply[start:end+1]), v)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	seriesService "jank.com/jank_blog/pkg/serve/service/series"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// fillSeriesNav 填充文章所属系列及上一篇、下一篇文章，失败时仅记录日志
+func fillSeriesNav(postVo *post.PostsVo, c echo.Context) {
+	nav, err := seriesService.SeriesNav(postVo.ID, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的系列导航失败: %v", postVo.ID, err)
+		return
+	}
+	postVo.Series = nav
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	shortLinkService "jank.com/jank_blog/pkg/serve/service/shortlink"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// fillShortURL 填充文章短链接，文章尚无短链接时生成，失败时仅记录日志
+func fillShortURL(postVo *post.PostsVo, c echo.Context) {
+	link, err := shortLinkService.EnsureShortLink(postVo.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("生成文章 %d 的短链接失败: %v", postVo.ID, err)
+		return
+	}
+	postVo.ShortURL = shortLinkService.ShortURL(link.Code)
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/slug"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+const (
+	fallbackSlug    = "post"   // 标题无法转写出任何字符时使用的别名
+	maxSlugSuffix   = 1000     // 别名冲突时追加的最大数字后缀
+	importOriginKey = "import" // 扩展字段中导入来源信息的键
+	importSlugKey   = "slug"   // 导入来源信息中源站点别名的键
+)
+
+var (
+	ErrSlugInvalid  = errors.New("别名需包含字母、数字或常用汉字")
+	ErrPostNotFound = errors.New("文章不存在")
+)
+
+// uniqueSlug 以 base 为基础生成未被其他文章使用的别名，冲突时依次追加 -2、-3 等后缀；
+// 别名不是数据库唯一索引，并发创建同名文章时仍可能重复，以先写入的文章为准
+func uniqueSlug(base string, postID int64) (string, error) {
+	if base == "" {
+		base = fallbackSlug
+	}
+	candidate := base
+	for n := 2; n <= maxSlugSuffix; n++ {
+		taken, err := mapper.IsPostSlugTaken(candidate, postID)
+		if err != nil {
+			return "", fmt.Errorf("检查别名是否被占用失败: %v", err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = slug.WithSuffix(base, n)
+	}
+	return "", fmt.Errorf("生成别名失败: %q 连续 %d 次冲突", base, maxSlugSuffix)
+}
+
+// assignSlug 为新文章生成别名，requested 为用户填写的别名，为空时由标题生成
+func assignSlug(pos *model.Post, requested string) error {
+	base := slug.Make(pos.Title)
+	if requested != "" {
+		if base = slug.Make(requested); base == "" {
+			return ErrSlugInvalid
+		}
+	}
+
+	s, err := uniqueSlug(base, pos.ID)
+	if err != nil {
+		return err
+	}
+	pos.Slug = s
+	return nil
+}
+
+// changeSlug 修改文章的别名，旧别名记为历史别名，访问时重定向到新别名；修改标题不会改变别名，以保持文章地址稳定
+func changeSlug(pos *model.Post, requested string) error {
+	base := slug.Make(requested)
+	if base == "" {
+		return ErrSlugInvalid
+	}
+	if base == pos.Slug {
+		return nil
+	}
+
+	s, err := uniqueSlug(base, pos.ID)
+	if err != nil {
+		return err
+	}
+	if s == pos.Slug {
+		return nil
+	}
+	if err := mapper.UpdatePostSlug(pos.ID, pos.Slug, s); err != nil {
+		return fmt.Errorf("修改文章别名失败: %v", err)
+	}
+	pos.Slug = s
+	return nil
+}
+
+// BackfillSlugs 为尚未生成别名的文章生成别名，导入的文章优先沿用源站点的别名；启动时与导入内容后调用
+func BackfillSlugs() error {
+	posts, err := mapper.GetPostsWithoutSlug()
+	if err != nil {
+		return fmt.Errorf("获取待生成别名的文章失败: %v", err)
+	}
+
+	for _, pos := range posts {
+		base := slug.Make(importedSlug(pos))
+		if base == "" {
+			base = slug.Make(pos.Title)
+		}
+		s, err := uniqueSlug(base, pos.ID)
+		if err != nil {
+			return err
+		}
+		if err := mapper.UpdatePostSlug(pos.ID, "", s); err != nil {
+			return fmt.Errorf("写入文章 %d 的别名失败: %v", pos.ID, err)
+		}
+	}
+	if len(posts) > 0 {
+		global.SysLog.Infof("已为 %d 篇文章生成别名", len(posts))
+	}
+	return nil
+}
+
+// importedSlug 读取导入来源信息中源站点的别名
+func importedSlug(pos *model.Post) string {
+	origin, ok := pos.Ext[importOriginKey].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	s, _ := origin[importSlugKey].(string)
+	return s
+}
+
+// GetPostBySlug 根据别名获取文章，未登录时只能获取已发布的文章；
+// 别名是文章修改前的历史别名，或传入 lang 且文章有该语言的翻译时，返回应跳转的别名，由调用方重定向
+func GetPostBySlug(req *dto.GetPostBySlugRequest, c echo.Context) (*post.PostsVo, string, error) {
+	s := slug.Make(req.Slug)
+	if s == "" {
+		return nil, "", ErrPostNotFound
+	}
+
+	pos, err := mapper.GetPostBySlug(s)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if current := resolveSlugHistory(s, c); current != "" {
+			return nil, current, nil
+		}
+		return nil, "", ErrPostNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("根据别名获取文章失败: %v", err)
+		return nil, "", fmt.Errorf("根据别名获取文章失败: %v", err)
+	}
+	if pos.Status != model.StatusPublished && !canViewUnpublished(pos, c) {
+		return nil, "", ErrPostNotFound
+	}
+	if err := checkPostAccess(pos, c); err != nil {
+		return nil, "", err
+	}
+	if s != req.Slug {
+		// 别名大小写或格式不规范时重定向到规范地址
+		return nil, pos.Slug, nil
+	}
+	if translated := negotiateTranslation(pos, req.Lang, c); translated.ID != pos.ID {
+		return nil, translated.Slug, nil
+	}
+
+	vo, err := utils.MapModelToVO(pos, &post.PostsVo{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章时映射 vo 失败: %v", err)
+		return nil, "", fmt.Errorf("获取文章时映射 vo 失败: %v", err)
+	}
+
+	postVo := vo.(*post.PostsVo)
+	fillAuthors([]*post.PostsVo{postVo}, c)
+	fillShortURL(postVo, c)
+	fillBookmarkState(postVo, c)
+	fillReactionState(postVo, c)
+	fillSeriesNav(postVo, c)
+	fillTranslations(postVo, pos, c)
+	lockPostContent([]*post.PostsVo{postVo}, c)
+	countView(pos, postVo, c)
+	return postVo, "", nil
+}
+
+// resolveSlugHistory 查找历史别名对应文章的当前别名，文章不存在或对访客不可见时返回空字符串
+func resolveSlugHistory(s string, c echo.Context) string {
+	history, err := mapper.GetPostSlugHistory(s)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.BizLogger(c).Errorf("获取历史别名失败: %v", err)
+		}
+		return ""
+	}
+	pos, err := mapper.GetPostByID(history.PostID)
+	if err != nil || pos.Slug == "" || pos.Status != model.StatusPublished && !canViewUnpublished(pos, c) {
+		return ""
+	}
+	return pos.Slug
+}
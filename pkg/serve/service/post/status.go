@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+var (
+	ErrPostStatusTransition = errors.New("不允许的文章发布状态变更")
+	ErrPostInReview         = errors.New("文章正在审核或等待定时发布，不能直接变更发布状态")
+)
+
+// postStatusTransitions 允许的发布状态变更，已发布的文章可撤回为草稿或归档，归档的文章可重新发布或转为草稿
+var postStatusTransitions = map[string][]string{
+	model.StatusDraft:     {model.StatusPublished},
+	model.StatusPublished: {model.StatusDraft, model.StatusArchived},
+	model.StatusArchived:  {model.StatusPublished, model.StatusDraft},
+}
+
+// UpdatePostStatus 变更文章的发布状态，目标状态与当前状态相同时不做处理；
+// 正在审核或等待定时发布的文章需先完成审核流程
+func UpdatePostStatus(req *dto.UpdatePostStatusRequest, c echo.Context) (*post.PostsVo, error) {
+	pos, err := mapper.GetPostByID(req.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+
+	if pos.Status != req.Status {
+		if pos.ReviewStatus == model.ReviewStatusPending || pos.ReviewStatus == model.ReviewStatusScheduled {
+			return nil, ErrPostInReview
+		}
+		if !canTransitPostStatus(pos.Status, req.Status) {
+			return nil, fmt.Errorf("%w: %s 不能变更为 %s", ErrPostStatusTransition, pos.Status, req.Status)
+		}
+		updated, err := mapper.UpdatePostStatus(pos.ID, pos.Status, req.Status)
+		if err != nil {
+			utils.BizLogger(c).Errorf("%v", err)
+			return nil, err
+		}
+		if !updated {
+			return nil, fmt.Errorf("%w: 文章的发布状态已被修改，请刷新后重试", ErrPostStatusTransition)
+		}
+
+		utils.BizLogger(c).Infof("文章 %d 的发布状态由 %s 变更为 %s", pos.ID, pos.Status, req.Status)
+		wasVisible := pos.Visibility
+		pos.Status, pos.Visibility, pos.PublishAt = req.Status, req.Status == model.StatusPublished, 0
+		if wasVisible && !pos.Visibility {
+			removePostSuggestion(pos.ID, pos.Title, c)
+		}
+		indexPostSuggestions(pos, c)
+		searchService.IndexPost(pos)
+		invalidateArchiveCache(c)
+		if !wasVisible && pos.Visibility {
+			notifyPostsPublished(pos)
+		}
+	}
+
+	mapped, err := utils.MapModelToVO(pos, &post.PostsVo{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("变更发布状态时映射 vo 失败: %v", err)
+		return nil, fmt.Errorf("变更发布状态时映射 vo 失败: %v", err)
+	}
+	return mapped.(*post.PostsVo), nil
+}
+
+// canTransitPostStatus 发布状态能否由 from 变更为 to
+func canTransitPostStatus(from, to string) bool {
+	for _, allowed := range postStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// canViewUnpublished 当前请求能否查看未发布的文章 pos，仅文章作者与管理员可以查看草稿与归档，登录状态需对应未结束的登录会话
+func canViewUnpublished(pos *model.Post, c echo.Context) bool {
+	accountID := authMiddleware.OptionalAccountID(c)
+	if accountID == 0 {
+		return false
+	}
+	if isAdminAccount(accountID) {
+		return true
+	}
+	authors, err := mapper.GetPostAuthors([]int64{pos.ID})
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章作者失败: %v", err)
+		return false
+	}
+	for _, author := range authors {
+		if author.AccountID == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdminViewer 当前请求是否为管理员，管理员在文章列表中可以查看全部发布状态的文章
+func isAdminViewer(c echo.Context) bool {
+	accountID := authMiddleware.OptionalAccountID(c)
+	return accountID > 0 && isAdminAccount(accountID)
+}
+
+// isLoggedIn 当前请求是否已登录，仅登录可见的文章对登录用户可见
+func isLoggedIn(c echo.Context) bool {
+	return authMiddleware.OptionalAccountID(c) > 0
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// SuggestPosts 根据前缀获取文章标题与标签补全建议
+func SuggestPosts(req *dto.SuggestPostsRequest, c echo.Context) ([]*post.SuggestionVo, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	suggestions, err := search.Suggest(c.Request().Context(), req.Prefix, limit)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取搜索建议失败: %v", err)
+		return nil, fmt.Errorf("获取搜索建议失败: %v", err)
+	}
+
+	result := make([]*post.SuggestionVo, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = &post.SuggestionVo{Type: s.Type, ID: s.ID, Text: s.Text}
+	}
+	return result, nil
+}
+
+// postSuggestions 生成已发布文章的标题与标签建议
+func postSuggestions(pos *model.Post) ([]search.Suggestion, error) {
+	suggestions := []search.Suggestion{{Type: search.SuggestTitle, ID: pos.ID, Text: pos.Title}}
+
+	categories, err := mapper.GetCategoriesByIDs(pos.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, cat := range categories {
+		suggestions = append(suggestions, search.Suggestion{Type: search.SuggestTag, ID: cat.ID, Text: cat.Name})
+	}
+	return suggestions, nil
+}
+
+// indexPostSuggestions 文章发布后写入搜索建议索引，仅登录可见的文章不写入，失败时仅记录日志
+func indexPostSuggestions(pos *model.Post, c echo.Context) {
+	if !pos.Visibility || pos.Access == model.AccessLogin {
+		return
+	}
+
+	suggestions, err := postSuggestions(pos)
+	if err == nil {
+		err = search.AddSuggestions(c.Request().Context(), suggestions...)
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("更新文章 %d 的搜索建议失败: %v", pos.ID, err)
+	}
+}
+
+// removePostSuggestion 从搜索建议索引中移除文章标题，标签可能被其他文章使用，由定时重建清理
+func removePostSuggestion(postID int64, title string, c echo.Context) {
+	err := search.RemoveSuggestions(c.Request().Context(), search.Suggestion{Type: search.SuggestTitle, ID: postID, Text: title})
+	if err != nil {
+		utils.BizLogger(c).Errorf("移除文章 %d 的搜索建议失败: %v", postID, err)
+	}
+}
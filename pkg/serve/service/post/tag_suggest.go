@@ -0,0 +1,178 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/llm"
+	category "jank.com/jank_blog/internal/model/category"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// 标签推荐方式
+const (
+	TagSourceTFIDF = "tfidf" // 按 TF-IDF 找出相似文章，汇总其类目
+	TagSourceLLM   = "llm"   // 由大模型从已有类目中挑选
+)
+
+const (
+	similarPostLimit = 20  // 参与类目汇总的相似文章数
+	titleNameBoost   = 1.0 // 草稿标题包含类目名称时的加分
+	contentNameBoost = 0.5 // 草稿正文包含类目名称时的加分
+)
+
+// SuggestTags 为草稿推荐已有的类目，只返回已存在的类目以保持分类体系一致
+func SuggestTags(req *dto.SuggestTagsRequest, c echo.Context) ([]*post.TagSuggestionVo, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 5
+	}
+
+	categories, err := mapper.GetAllActivatedCategories()
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取类目失败: %v", err)
+		return nil, fmt.Errorf("获取类目失败: %v", err)
+	}
+	if len(categories) == 0 {
+		return []*post.TagSuggestionVo{}, nil
+	}
+
+	var scores map[int64]float64
+	if req.Source == TagSourceLLM {
+		scores, err = llmTagScores(req, categories, c)
+	} else {
+		scores, err = tfidfTagScores(req, categories)
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("推荐标签失败: %v", err)
+		return nil, fmt.Errorf("推荐标签失败: %w", err)
+	}
+
+	var best float64
+	for _, score := range scores {
+		best = max(best, score)
+	}
+
+	result := make([]*post.TagSuggestionVo, 0, len(scores))
+	for _, cat := range categories {
+		if score, ok := scores[cat.ID]; ok && score > 0 {
+			result = append(result, &post.TagSuggestionVo{
+				CategoryID: cat.ID,
+				Name:       cat.Name,
+				Path:       cat.Path,
+				Score:      math.Round(score/best*1000) / 1000,
+			})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].CategoryID < result[j].CategoryID
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// tfidfTagScores 按草稿与已发布文章的 TF-IDF 相似度汇总类目得分，草稿中直接出现的类目名称额外加分
+func tfidfTagScores(req *dto.SuggestTagsRequest, categories []*category.Category) (map[int64]float64, error) {
+	posts, err := mapper.GetAllPublishedPosts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]search.Document, 0, len(posts))
+	categoryIDs := make(map[int64][]int64, len(posts))
+	for _, pos := range posts {
+		if pos.ID == req.PostID {
+			continue
+		}
+		docs = append(docs, search.Document{ID: pos.ID, Title: pos.Title, Content: pos.ContentMarkdown})
+		categoryIDs[pos.ID] = pos.CategoryIDs
+	}
+
+	scores := make(map[int64]float64)
+	draft := search.Document{Title: req.Title, Content: req.ContentMarkdown}
+	for _, hit := range search.NewCorpus(docs).Similar(draft, similarPostLimit) {
+		for _, id := range categoryIDs[hit.ID] {
+			scores[id] += hit.Score
+		}
+	}
+
+	title, content := strings.ToLower(req.Title), strings.ToLower(req.ContentMarkdown)
+	for _, cat := range categories {
+		name := strings.ToLower(strings.TrimSpace(cat.Name))
+		switch {
+		case name == "":
+		case strings.Contains(title, name):
+			scores[cat.ID] += titleNameBoost
+		case strings.Contains(content, name):
+			scores[cat.ID] += contentNameBoost
+		}
+	}
+	return scores, nil
+}
+
+// llmTagScores 由大模型从已有类目中挑选最相关的若干项，按返回顺序计分
+func llmTagScores(req *dto.SuggestTagsRequest, categories []*category.Category, c echo.Context) (map[int64]float64, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := llm.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]int64, len(categories))
+	names := make([]string, 0, len(categories))
+	for _, cat := range categories {
+		key := strings.ToLower(strings.TrimSpace(cat.Name))
+		if _, ok := byName[key]; !ok {
+			byName[key] = cat.ID
+			names = append(names, cat.Name)
+		}
+	}
+
+	content := []rune(req.ContentMarkdown)
+	if len(content) > maxPromptContentLength {
+		content = content[:maxPromptContentLength]
+	}
+	reply, err := provider.Complete(c.Request().Context(), []llm.Message{
+		{Role: llm.RoleSystem, Content: `你是一名博客编辑，负责为文章挑选分类标签。只能从给定的标签中选择，只输出形如 {"tags": ["标签"]} 的 JSON 对象，按相关度从高到低排列。`},
+		{Role: llm.RoleUser, Content: fmt.Sprintf("可选标签：%s\n\n标题：%s\n\n正文：\n%s",
+			strings.Join(names, "、"), req.Title, string(content))},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var picked struct {
+		Tags []string `json:"tags"`
+	}
+	if err := decodeJSONObject(reply, &picked); err != nil {
+		return nil, fmt.Errorf("解析大模型生成结果失败: %v", err)
+	}
+
+	// 忽略大模型编造的标签，只保留已有类目
+	scores := make(map[int64]float64)
+	for i, name := range picked.Tags {
+		if id, ok := byName[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, seen := scores[id]; !seen {
+				scores[id] = 1 - float64(i)/float64(len(picked.Tags))
+			}
+		}
+	}
+	return scores, nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// fillTOC 根据文章 HTML 中的标题生成嵌套目录，并为缺少锚点的标题注入 ID；
+// 标题级别跳跃时（如 h2 之后直接是 h4）归入最近的上一级标题
+func fillTOC(postVo *post.PostsVo) {
+	content, headings := utils.ExtractHeadings(postVo.ContentHTML)
+	postVo.ContentHTML = content
+
+	var toc []*post.TOCItemVo
+	var stack []*post.TOCItemVo
+	for _, heading := range headings {
+		item := &post.TOCItemVo{Level: heading.Level, Text: heading.Text, ID: heading.ID}
+		for len(stack) > 0 && stack[len(stack)-1].Level >= item.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			toc = append(toc, item)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, item)
+		}
+		stack = append(stack, item)
+	}
+	postVo.TOC = toc
+}
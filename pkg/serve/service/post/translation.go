@@ -0,0 +1,250 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/i18n"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+var (
+	// ErrTranslationInvalid 关联自身或关联已属于其他翻译组的文章
+	ErrTranslationInvalid = errors.New("文章翻译关联无效")
+	// ErrTranslationConflict 翻译组中已有相同语言的文章
+	ErrTranslationConflict = errors.New("翻译组中已有该语言的文章")
+)
+
+// LinkPostTranslation 将文章关联为另一篇文章的翻译，两篇文章加入同一翻译组，组 ID 取原文的文章 ID；
+// 翻译组中每种语言只能有一篇文章
+func LinkPostTranslation(req *dto.LinkPostTranslationRequest, c echo.Context) ([]*post.PostTranslationVo, error) {
+	if req.PostID == req.SourceID {
+		return nil, fmt.Errorf("%w: 不能关联文章自身", ErrTranslationInvalid)
+	}
+	pos, err := mapper.GetPostByID(req.PostID)
+	if err != nil || pos == nil {
+		return nil, ErrPostNotFound
+	}
+	source, err := mapper.GetPostByID(req.SourceID)
+	if err != nil || source == nil {
+		return nil, ErrPostNotFound
+	}
+
+	groupID := source.GroupID()
+	if pos.TranslationGroupID != 0 && pos.TranslationGroupID != groupID {
+		return nil, fmt.Errorf("%w: 文章已属于其他翻译组，需先解除关联", ErrTranslationInvalid)
+	}
+	members, err := mapper.GetPostTranslations(groupID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的翻译失败: %v", groupID, err)
+		return nil, err
+	}
+	for _, member := range members {
+		if member.ID != pos.ID && member.Language == pos.Language {
+			return nil, fmt.Errorf("%w: %s", ErrTranslationConflict, pos.Language)
+		}
+	}
+
+	if err := mapper.LinkPostTranslation(pos.ID, groupID); err != nil {
+		utils.BizLogger(c).Errorf("关联文章 %d 的翻译失败: %v", pos.ID, err)
+		return nil, fmt.Errorf("关联文章翻译失败: %v", err)
+	}
+	invalidateArchiveCache(c)
+
+	members, err = mapper.GetPostTranslations(groupID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的翻译失败: %v", groupID, err)
+		return nil, err
+	}
+	return translationVos(members, groupID), nil
+}
+
+// UnlinkPostTranslation 将文章移出所在的翻译组，文章没有关联翻译时不做处理
+func UnlinkPostTranslation(req *dto.UnlinkPostTranslationRequest, c echo.Context) error {
+	pos, err := mapper.GetPostByID(req.PostID)
+	if err != nil || pos == nil {
+		return ErrPostNotFound
+	}
+	if pos.TranslationGroupID == 0 {
+		return nil
+	}
+	if err := leaveTranslationGroup(pos); err != nil {
+		utils.BizLogger(c).Errorf("解除文章 %d 的翻译关联失败: %v", pos.ID, err)
+		return fmt.Errorf("解除文章翻译关联失败: %v", err)
+	}
+	invalidateArchiveCache(c)
+	return nil
+}
+
+// GetPostTranslations 获取文章所在翻译组中当前请求可见的文章，包含文章自身，文章没有关联翻译时返回空列表
+func GetPostTranslations(req *dto.GetPostTranslationsRequest, c echo.Context) ([]*post.PostTranslationVo, error) {
+	pos, err := mapper.GetPostByID(req.ID)
+	if err != nil || pos == nil {
+		return nil, ErrPostNotFound
+	}
+	if pos.Status != model.StatusPublished && !canViewUnpublished(pos, c) {
+		return nil, ErrPostNotFound
+	}
+	if err := checkPostAccess(pos, c); err != nil {
+		return nil, err
+	}
+
+	translations, err := visibleTranslations(pos, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的翻译失败: %v", pos.ID, err)
+		return nil, err
+	}
+	return translationVos(translations, pos.GroupID()), nil
+}
+
+// negotiateTranslation 按请求的语言选择翻译组中的文章，没有匹配的可见翻译时返回原文章
+func negotiateTranslation(pos *model.Post, lang string, c echo.Context) *model.Post {
+	if lang == "" || pos.TranslationGroupID == 0 {
+		return pos
+	}
+	translations, err := visibleTranslations(pos, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的翻译失败: %v", pos.ID, err)
+		return pos
+	}
+
+	languages := make([]string, len(translations))
+	for i, translation := range translations {
+		languages[i] = translation.Language
+	}
+	tag, ok := i18n.MatchAmong(lang, languages)
+	if !ok || tag == pos.Language {
+		return pos
+	}
+	for _, translation := range translations {
+		if translation.Language != tag {
+			continue
+		}
+		translated, err := mapper.GetPostByID(translation.ID)
+		if err != nil || translated == nil {
+			return pos
+		}
+		return translated
+	}
+	return pos
+}
+
+// fillTranslations 填充文章详情的语言响应头与翻译组中可见的文章，失败时仅记录日志
+func fillTranslations(postVo *post.PostsVo, pos *model.Post, c echo.Context) {
+	c.Response().Header().Set("Content-Language", pos.Language)
+	if pos.TranslationGroupID == 0 {
+		return
+	}
+	translations, err := visibleTranslations(pos, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章 %d 的翻译失败: %v", pos.ID, err)
+		return
+	}
+	postVo.Translations = translationVos(translations, pos.GroupID())
+}
+
+// listLanguage 文章列表合并翻译组使用的语言，在已发布文章的语言中协商：传入 lang 时按 lang 匹配，
+// 未传入时访客按 Accept-Language 匹配，均不匹配时使用默认语言；登录用户未传入 lang 时返回空字符串，列表包含全部语言
+func listLanguage(lang string, c echo.Context) string {
+	if lang == "" && isLoggedIn(c) {
+		return ""
+	}
+	if lang == "" {
+		c.Response().Header().Add("Vary", "Accept-Language")
+	}
+
+	languages, err := mapper.GetPublishedPostLanguages(visibleAccess(c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章语言失败: %v", err)
+		return model.DefaultLanguage
+	}
+	if lang != "" {
+		if tag, ok := i18n.MatchAmong(lang, languages); ok {
+			return tag
+		}
+		return i18n.Normalize(lang)
+	}
+	if tag, ok := i18n.Negotiate(c.Request(), languages); ok {
+		return tag
+	}
+	return model.DefaultLanguage
+}
+
+// applyLanguage 按请求设置文章语言，language 为空时保留原有语言；文章已关联翻译时，
+// 不能改为翻译组中其他文章已使用的语言
+func applyLanguage(pos *model.Post, language string) error {
+	if language == "" {
+		if pos.Language == "" {
+			pos.Language = model.DefaultLanguage
+		}
+		return nil
+	}
+	language = i18n.Normalize(language)
+	if language == pos.Language {
+		return nil
+	}
+	if pos.TranslationGroupID != 0 {
+		members, err := mapper.GetPostTranslations(pos.TranslationGroupID)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if member.ID != pos.ID && member.Language == language {
+				return fmt.Errorf("%w: %s", ErrTranslationConflict, language)
+			}
+		}
+	}
+	pos.Language = language
+	return nil
+}
+
+// leaveTranslationGroup 将文章移出翻译组，用于解除关联与删除文章
+func leaveTranslationGroup(pos *model.Post) error {
+	if pos.TranslationGroupID == 0 {
+		return nil
+	}
+	return mapper.UnlinkPostTranslation(pos.ID, pos.TranslationGroupID)
+}
+
+// visibleTranslations 获取文章所在翻译组中当前请求可见的文章，未发布的文章仅作者与管理员可见，未登录时不包含仅登录可见的文章
+func visibleTranslations(pos *model.Post, c echo.Context) ([]*model.Post, error) {
+	if pos.TranslationGroupID == 0 {
+		return nil, nil
+	}
+	members, err := mapper.GetPostTranslations(pos.TranslationGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if isAdminViewer(c) {
+		return members, nil
+	}
+
+	visible := make([]*model.Post, 0, len(members))
+	for _, member := range members {
+		if (member.Status == model.StatusPublished || canViewUnpublished(member, c)) && checkPostAccess(member, c) == nil {
+			visible = append(visible, member)
+		}
+	}
+	return visible, nil
+}
+
+// translationVos 将翻译组中的文章转换为翻译 vo，groupID 对应的文章标记为原文
+func translationVos(posts []*model.Post, groupID int64) []*post.PostTranslationVo {
+	vos := make([]*post.PostTranslationVo, 0, len(posts))
+	for _, pos := range posts {
+		vos = append(vos, &post.PostTranslationVo{
+			ID:        pos.ID,
+			Language:  pos.Language,
+			Title:     pos.Title,
+			Slug:      pos.Slug,
+			Canonical: pos.ID == groupID,
+		})
+	}
+	return vos
+}
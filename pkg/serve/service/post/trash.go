@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	searchService "jank.com/jank_blog/pkg/serve/service/search"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// ErrPostNotInTrash 回收站中不存在该文章
+var ErrPostNotInTrash = errors.New("回收站中不存在该文章")
+
+// GetTrashedPosts 分页获取回收站中的文章，按删除时间倒序排序，并计算预计永久删除的时间
+func GetTrashedPosts(page vo.PageRequest, c echo.Context) ([]*post.TrashPostVo, *vo.PageMeta, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载回收站配置失败: %v", err)
+		return nil, nil, fmt.Errorf("加载回收站配置失败: %v", err)
+	}
+	posts, total, err := mapper.GetDeletedPostsWithPaging(page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取回收站文章失败: %v", err)
+		return nil, nil, fmt.Errorf("获取回收站文章失败: %v", err)
+	}
+
+	days := config.TrashConfig.TrashPostRetentionDays
+	result := make([]*post.TrashPostVo, len(posts))
+	for i, pos := range posts {
+		result[i] = &post.TrashPostVo{
+			ID:        pos.ID,
+			Title:     pos.Title,
+			Slug:      pos.Slug,
+			Image:     pos.Image,
+			Status:    pos.Status,
+			GmtCreate: pos.GmtCreate,
+			DeletedAt: pos.GmtModified,
+			PurgeAt:   trashPurgeAt(pos.GmtModified, days),
+		}
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// RestorePost 从回收站恢复文章，恢复后重新加入搜索索引；删除期间别名被其他文章使用时重新生成别名
+func RestorePost(req *dto.RestorePostRequest, c echo.Context) error {
+	pos, err := mapper.GetDeletedPostByID(req.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPostNotInTrash
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取回收站文章失败: %v", err)
+		return fmt.Errorf("获取回收站文章失败: %v", err)
+	}
+
+	if pos.Slug != "" {
+		taken, err := mapper.IsPostSlugTaken(pos.Slug, pos.ID)
+		if err != nil {
+			utils.BizLogger(c).Errorf("检查别名是否被占用失败: %v", err)
+			return fmt.Errorf("检查别名是否被占用失败: %v", err)
+		}
+		if taken {
+			s, err := uniqueSlug(pos.Slug, pos.ID)
+			if err != nil {
+				utils.BizLogger(c).Errorf("重新生成别名失败: %v", err)
+				return err
+			}
+			// 原别名已属于其他文章，不记为历史别名
+			if err := mapper.UpdatePostSlug(pos.ID, "", s); err != nil {
+				utils.BizLogger(c).Errorf("重新生成别名失败: %v", err)
+				return fmt.Errorf("重新生成别名失败: %v", err)
+			}
+			pos.Slug = s
+		}
+	}
+
+	restored, err := mapper.RestorePost(pos.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("恢复文章失败: %v", err)
+		return fmt.Errorf("恢复文章失败: %v", err)
+	}
+	if !restored {
+		return ErrPostNotInTrash
+	}
+	pos.Deleted = false
+
+	indexPostSuggestions(pos, c)
+	searchService.IndexPost(pos)
+	invalidateArchiveCache(c)
+	utils.BizLogger(c).Infof("文章 %d 已从回收站恢复", pos.ID)
+	return nil
+}
+
+// trashPurgeAt 根据删除时间与保留天数计算预计永久删除的时间，保留天数为 0 时不清理，返回 0
+func trashPurgeAt(deletedAt int64, days int) int64 {
+	if days <= 0 {
+		return 0
+	}
+	return time.Unix(deletedAt, 0).AddDate(0, 0, days).Unix()
+}
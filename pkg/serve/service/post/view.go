@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/internal/visitor"
+	"jank.com/jank_blog/pkg/serve/controller/post/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/post"
+)
+
+// ViewFlushTask 浏览量写入数据库任务名称
+const ViewFlushTask = "post_view_flush"
+
+const (
+	ViewSeenKeyPrefix      = "POST:VIEW:SEEN:"    // 浏览去重标记，键为 POST:VIEW:SEEN:<文章 ID>:<访客哈希>
+	ViewPendingKey         = "POST:VIEW:PENDING"  // 待写入数据库的浏览量，字段为 <日期>:<文章 ID>
+	ViewFlushingKey        = "POST:VIEW:FLUSHING" // 正在写入数据库的浏览量，写入失败时保留到下次写入
+	defaultViewDedupWindow = 30 * time.Minute     // 未配置时的浏览去重窗口
+	defaultTrendingDays    = 7                    // 未配置时热门文章统计的天数
+	defaultTrendingLimit   = 10                   // 未指定时返回的热门文章数量
+	viewDayLayout          = "20060102"           // 每日浏览量的日期格式
+)
+
+// crawlerKeywords User-Agent 中包含这些关键词的请求视为爬虫，不计入浏览量
+var crawlerKeywords = []string{"bot", "spider", "crawl", "slurp", "curl", "wget", "python-requests"}
+
+// countView 记录一次文章浏览并填充文章的累计浏览量，浏览量包含尚未写入数据库的部分；
+// 仅统计已发布的文章，同一访客在去重窗口内重复浏览只计一次，失败时仅记录日志
+func countView(pos *model.Post, postVo *post.PostsVo, c echo.Context) {
+	config, err := configs.LoadConfig()
+	if err != nil || !config.ViewCounterConfig.ViewCounterEnabled || pos.Status != model.StatusPublished {
+		return
+	}
+	if isCrawler(c.Request().UserAgent()) {
+		postVo.ViewCount += pendingViews(pos.ID, c)
+		return
+	}
+
+	day := time.Now().Format(viewDayLayout)
+	if global.RedisClient == nil {
+		// Redis 未连接时直接写入数据库，无法去重
+		dayNum, _ := strconv.Atoi(day)
+		if err := mapper.AddPostViews(map[int]map[int64]int64{dayNum: {pos.ID: 1}}); err != nil {
+			utils.BizLogger(c).Errorf("记录文章 %d 的浏览量失败: %v", pos.ID, err)
+			return
+		}
+		postVo.ViewCount++
+		return
+	}
+
+	ctx := c.Request().Context()
+	seenKey := fmt.Sprintf("%s%d:%s", ViewSeenKeyPrefix, pos.ID, viewerHash(c))
+	first, err := global.RedisClient.SetNX(ctx, seenKey, 1, viewDedupWindow(config)).Result()
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录文章 %d 的浏览去重标记失败: %v", pos.ID, err)
+		return
+	}
+	if first {
+		if err := global.RedisClient.HIncrBy(ctx, ViewPendingKey, viewField(day, pos.ID), 1).Err(); err != nil {
+			utils.BizLogger(c).Errorf("累计文章 %d 的浏览量失败: %v", pos.ID, err)
+		}
+	}
+	postVo.ViewCount += pendingViews(pos.ID, c)
+}
+
+// pendingViews 读取文章今日尚未写入数据库的浏览量
+func pendingViews(postID int64, c echo.Context) int64 {
+	if global.RedisClient == nil {
+		return 0
+	}
+	n, err := global.RedisClient.HGet(c.Request().Context(), ViewPendingKey, viewField(time.Now().Format(viewDayLayout), postID)).Int64()
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// FlushViews 将 Redis 中累计的浏览量批量写入数据库，返回写入的浏览次数；
+// 累计的浏览量先整体改名再写入，写入期间的新浏览继续累计到新的键中，写入失败时保留到下次重试
+func FlushViews(ctx context.Context) (int64, error) {
+	if global.RedisClient == nil {
+		return 0, nil
+	}
+
+	// 上次写入失败时先写入遗留的浏览量，避免改名时覆盖
+	flushing, err := global.RedisClient.Exists(ctx, ViewFlushingKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("检查待写入的浏览量失败: %v", err)
+	}
+	if flushing == 0 {
+		pending, err := global.RedisClient.Exists(ctx, ViewPendingKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("检查累计的浏览量失败: %v", err)
+		}
+		if pending == 0 {
+			return 0, nil
+		}
+		if err := global.RedisClient.Rename(ctx, ViewPendingKey, ViewFlushingKey).Err(); err != nil {
+			return 0, fmt.Errorf("转移累计的浏览量失败: %v", err)
+		}
+	}
+
+	fields, err := global.RedisClient.HGetAll(ctx, ViewFlushingKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("读取待写入的浏览量失败: %v", err)
+	}
+
+	var total int64
+	views := make(map[int]map[int64]int64)
+	for field, value := range fields {
+		day, postID, ok := parseViewField(field)
+		n, err := strconv.ParseInt(value, 10, 64)
+		if !ok || err != nil || n <= 0 {
+			global.SysLog.Warnf("忽略无效的浏览量记录 %s=%s", field, value)
+			continue
+		}
+		if views[day] == nil {
+			views[day] = make(map[int64]int64)
+		}
+		views[day][postID] += n
+		total += n
+	}
+	if err := mapper.AddPostViews(views); err != nil {
+		return 0, fmt.Errorf("写入浏览量失败: %v", err)
+	}
+	if err := global.RedisClient.Del(ctx, ViewFlushingKey).Err(); err != nil {
+		return total, fmt.Errorf("清除已写入的浏览量失败: %v", err)
+	}
+	return total, nil
+}
+
+// GetTrendingPosts 获取最近若干天浏览量最高的已发布文章，尚未写入数据库的浏览量不计入
+func GetTrendingPosts(req *dto.GetTrendingPostsRequest, c echo.Context) ([]*post.TrendingPostVo, error) {
+	days, limit := req.Days, req.Limit
+	if days == 0 {
+		days = defaultTrendingDays
+		if config, err := configs.LoadConfig(); err == nil && config.ViewCounterConfig.TrendingDays > 0 {
+			days = config.ViewCounterConfig.TrendingDays
+		}
+	}
+	if limit == 0 {
+		limit = defaultTrendingLimit
+	}
+
+	since, _ := strconv.Atoi(time.Now().AddDate(0, 0, 1-days).Format(viewDayLayout))
+	counts, err := mapper.GetTrendingPostViews(since, limit, visibleAccess(c))
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取热门文章浏览量失败: %v", err)
+		return nil, fmt.Errorf("获取热门文章浏览量失败: %v", err)
+	}
+
+	ids := make([]int64, len(counts))
+	for i, count := range counts {
+		ids[i] = count.PostID
+	}
+	posts, err := mapper.GetPostsByIDs(ids)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取热门文章失败: %v", err)
+		return nil, fmt.Errorf("获取热门文章失败: %v", err)
+	}
+	byID := make(map[int64]*model.Post, len(posts))
+	for _, pos := range posts {
+		byID[pos.ID] = pos
+	}
+
+	result := make([]*post.TrendingPostVo, 0, len(counts))
+	for _, count := range counts {
+		pos, ok := byID[count.PostID]
+		if !ok {
+			continue
+		}
+		result = append(result, &post.TrendingPostVo{
+			ID:        pos.ID,
+			Title:     pos.Title,
+			Slug:      pos.Slug,
+			Image:     pos.Image,
+			Views:     count.Views,
+			ViewCount: pos.ViewCount,
+		})
+	}
+	return result, nil
+}
+
+// viewerHash 计算浏览去重使用的访客标识，持有访客 ID 时使用访客 ID，否则使用 IP 与 User-Agent
+func viewerHash(c echo.Context) string {
+	source := c.RealIP() + "|" + c.Request().UserAgent()
+	if id, ok := visitor.FromRequest(c); ok {
+		source = "visitor|" + id
+	}
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:16])
+}
+
+// isCrawler 判断请求是否来自爬虫或命令行工具，未携带 User-Agent 的请求同样视为爬虫
+func isCrawler(userAgent string) bool {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return true
+	}
+	for _, keyword := range crawlerKeywords {
+		if strings.Contains(ua, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// viewDedupWindow 读取配置中的浏览去重窗口
+func viewDedupWindow(config *configs.Config) time.Duration {
+	if seconds := config.ViewCounterConfig.ViewDedupWindow; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultViewDedupWindow
+}
+
+// viewField 生成累计浏览量的哈希字段
+func viewField(day string, postID int64) string {
+	return day + ":" + strconv.FormatInt(postID, 10)
+}
+
+// parseViewField 解析累计浏览量的哈希字段
+func parseViewField(field string) (int, int64, bool) {
+	dayStr, idStr, ok := strings.Cut(field, ":")
+	if !ok {
+		return 0, 0, false
+	}
+	day, err := strconv.Atoi(dayStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	postID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || postID <= 0 {
+		return 0, 0, false
+	}
+	return day, postID, true
+}
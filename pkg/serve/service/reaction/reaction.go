@@ -0,0 +1,274 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/internal/visitor"
+	"jank.com/jank_blog/pkg/serve/controller/reaction/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/reaction"
+)
+
+const (
+	ReactionCountCache           = "POST:REACTIONS" // 文章表态数缓存，键为 POST:REACTIONS:<文章 ID>，哈希字段为表态类型
+	ReactionCountCacheExpireTime = time.Hour        // 文章表态数缓存有效期，过期后从数据库重建
+)
+
+var (
+	ErrReactionTypeInvalid = errors.New("不支持的表态类型")
+	ErrIdentityRequired    = errors.New("请登录后再表态")
+	ErrPostNotFound        = errors.New("文章不存在")
+)
+
+// defaultReactionTypes 未配置 REACTION_TYPES 时允许的表态类型
+var defaultReactionTypes = []string{"like"}
+
+// adjustReactionScript 表态数缓存存在时调整对应表态的数量，缓存不存在时不做处理，由下次读取时从数据库重建
+var adjustReactionScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('HINCRBY', KEYS[1], ARGV[1], ARGV[2])
+end
+return 0
+`)
+
+// reactor 表态的用户或访客，登录用户仅有 AccountID，匿名访客仅有 VisitorID
+type reactor struct {
+	AccountID int64
+	VisitorID string
+}
+
+// AddReaction 对文章表态，重复表态不会报错；未登录的访客没有访客 ID 时签发新的访客 ID，返回文章最新的表态状态
+func AddReaction(req *dto.ReactionRequest, c echo.Context) (*reaction.ReactionStateVo, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载表态配置失败: %v", err)
+		return nil, fmt.Errorf("加载表态配置失败: %v", err)
+	}
+	if !isReactionType(req.Reaction, config) {
+		return nil, ErrReactionTypeInvalid
+	}
+	r, err := currentReactor(config, true, c)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := mapper.GetPostByID(req.PostID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && pos.Status != model.StatusPublished {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取文章失败: %v", err)
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+
+	added, err := mapper.SaveReaction(req.PostID, r.AccountID, r.VisitorID, req.Reaction)
+	if err != nil {
+		utils.BizLogger(c).Errorf("记录表态失败: %v", err)
+		return nil, fmt.Errorf("记录表态失败: %v", err)
+	}
+	if added {
+		adjustReactionCount(req.PostID, req.Reaction, 1, c)
+	}
+
+	return reactionStateVo(req.PostID, config, r, c)
+}
+
+// RemoveReaction 取消对文章的表态，未表态时不会报错，返回文章最新的表态状态
+func RemoveReaction(req *dto.ReactionRequest, c echo.Context) (*reaction.ReactionStateVo, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载表态配置失败: %v", err)
+		return nil, fmt.Errorf("加载表态配置失败: %v", err)
+	}
+	r, err := currentReactor(config, false, c)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := mapper.DeleteReactionSoftly(req.PostID, r.AccountID, r.VisitorID, req.Reaction)
+	if err != nil {
+		utils.BizLogger(c).Errorf("取消表态失败: %v", err)
+		return nil, fmt.Errorf("取消表态失败: %v", err)
+	}
+	if removed {
+		adjustReactionCount(req.PostID, req.Reaction, -1, c)
+	}
+
+	return reactionStateVo(req.PostID, config, r, c)
+}
+
+// GetReactions 获取文章每种表态的数量与当前用户或访客的表态，无需登录
+func GetReactions(req *dto.GetReactionsRequest, c echo.Context) (*reaction.ReactionStateVo, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载表态配置失败: %v", err)
+		return nil, fmt.Errorf("加载表态配置失败: %v", err)
+	}
+	r, _ := currentReactor(config, false, c)
+	return reactionStateVo(req.PostID, config, r, c)
+}
+
+// ReactionState 获取文章每种表态的数量与当前用户或访客已表态的类型，表态数优先读取缓存；
+// 未登录且没有访客 ID 时已表态的类型为空
+func ReactionState(postID int64, c echo.Context) (map[string]int64, []string, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	r, _ := currentReactor(config, false, c)
+	return reactionState(postID, config, r, c)
+}
+
+// reactionStateVo 生成文章表态状态 vo
+func reactionStateVo(postID int64, config *configs.Config, r *reactor, c echo.Context) (*reaction.ReactionStateVo, error) {
+	counts, reacted, err := reactionState(postID, config, r, c)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取表态状态失败: %v", err)
+		return nil, fmt.Errorf("获取表态状态失败: %v", err)
+	}
+	return &reaction.ReactionStateVo{PostID: postID, Reactions: counts, Reacted: reacted}, nil
+}
+
+// reactionState 获取文章的表态数与 r 已表态的类型，只返回 REACTION_TYPES 中配置的类型，r 为 nil 时已表态的类型为空
+func reactionState(postID int64, config *configs.Config, r *reactor, c echo.Context) (map[string]int64, []string, error) {
+	all, err := reactionCounts(postID, config, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	types := reactionTypes(config)
+	counts := make(map[string]int64, len(types))
+	for _, kind := range types {
+		counts[kind] = max(all[kind], 0)
+	}
+
+	reacted := []string{}
+	if r == nil {
+		return counts, reacted, nil
+	}
+	kinds, err := mapper.GetReactionsByReactor(postID, r.AccountID, r.VisitorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, kind := range kinds {
+		if _, ok := counts[kind]; ok {
+			reacted = append(reacted, kind)
+		}
+	}
+	return counts, reacted, nil
+}
+
+// reactionCounts 获取文章每种表态的数量，优先读取缓存，缓存不存在时从数据库统计并写入缓存；Redis 不可用时直接查询数据库
+func reactionCounts(postID int64, config *configs.Config, c echo.Context) (map[string]int64, error) {
+	ctx := c.Request().Context()
+	cacheKey := fmt.Sprintf("%s:%d", ReactionCountCache, postID)
+	if global.RedisClient != nil {
+		if cached, err := global.RedisClient.HGetAll(ctx, cacheKey).Result(); err == nil && len(cached) > 0 {
+			counts := make(map[string]int64, len(cached))
+			for kind, value := range cached {
+				if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+					counts[kind] = n
+				}
+			}
+			return counts, nil
+		}
+	}
+
+	rows, err := mapper.CountReactionsByPostID(postID)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Reaction] = row.Count
+	}
+
+	if global.RedisClient != nil {
+		// 配置的类型即使数量为 0 也写入缓存，避免没有表态的文章每次都查询数据库
+		fields := make(map[string]interface{})
+		for _, kind := range reactionTypes(config) {
+			fields[kind] = 0
+		}
+		for kind, n := range counts {
+			fields[kind] = n
+		}
+		pipe := global.RedisClient.TxPipeline()
+		pipe.HSet(ctx, cacheKey, fields)
+		pipe.Expire(ctx, cacheKey, ReactionCountCacheExpireTime)
+		if _, err := pipe.Exec(ctx); err != nil {
+			utils.BizLogger(c).Errorf("缓存文章表态数失败: %v", err)
+		}
+	}
+	return counts, nil
+}
+
+// adjustReactionCount 表态变更后调整缓存中的表态数，失败时清除缓存，由下次读取时从数据库重建
+func adjustReactionCount(postID int64, kind string, delta int64, c echo.Context) {
+	if global.RedisClient == nil {
+		return
+	}
+	ctx := c.Request().Context()
+	cacheKey := fmt.Sprintf("%s:%d", ReactionCountCache, postID)
+	if err := adjustReactionScript.Run(ctx, global.RedisClient, []string{cacheKey}, kind, delta).Err(); err != nil {
+		utils.BizLogger(c).Errorf("调整文章表态数缓存失败: %v", err)
+		if err := global.RedisClient.Del(ctx, cacheKey).Err(); err != nil {
+			utils.BizLogger(c).Errorf("清除文章表态数缓存失败: %v", err)
+		}
+	}
+}
+
+// currentReactor 获取当前请求的用户或访客，优先使用登录用户；未登录时使用访客 ID，issue 为 true 时没有访客 ID 则签发新的访客 ID；
+// 未开启访客表态或没有有效的访客 ID 时返回 ErrIdentityRequired
+func currentReactor(config *configs.Config, issue bool, c echo.Context) (*reactor, error) {
+	if accountID := utils.OptionalAccountIDFromJWT(c.Request().Header.Get("Authorization")); accountID > 0 {
+		return &reactor{AccountID: accountID}, nil
+	}
+	if !config.ReactionConfig.ReactionGuestEnabled {
+		return nil, ErrIdentityRequired
+	}
+
+	if !issue {
+		id, ok := visitor.FromRequest(c)
+		if !ok {
+			return nil, ErrIdentityRequired
+		}
+		return &reactor{VisitorID: id}, nil
+	}
+	identity, err := visitor.Ensure(c)
+	if errors.Is(err, visitor.ErrDisabled) {
+		return nil, ErrIdentityRequired
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("签发访客 ID 失败: %v", err)
+		return nil, fmt.Errorf("签发访客 ID 失败: %v", err)
+	}
+	return &reactor{VisitorID: identity.ID}, nil
+}
+
+// reactionTypes 读取配置中允许的表态类型
+func reactionTypes(config *configs.Config) []string {
+	if len(config.ReactionConfig.ReactionTypes) > 0 {
+		return config.ReactionConfig.ReactionTypes
+	}
+	return defaultReactionTypes
+}
+
+// isReactionType 判断表态类型是否为配置中允许的类型
+func isReactionType(kind string, config *configs.Config) bool {
+	for _, t := range reactionTypes(config) {
+		if t == kind {
+			return true
+		}
+	}
+	return false
+}
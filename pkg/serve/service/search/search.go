@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"regexp"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	commentModel "jank.com/jank_blog/internal/model/comment"
+	model "jank.com/jank_blog/internal/model/post"
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/internal/search"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/search/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	searchVo "jank.com/jank_blog/pkg/vo/search"
+)
+
+// RebuildTask 全文索引重建任务名称
+const RebuildTask = "search_index_rebuild"
+
+// writeQueueSize 待写入全文索引的变更数上限，队列已满时丢弃变更，由定时重建补全
+const writeQueueSize = 1024
+
+var (
+	ErrSearchIndexDisabled  = errors.New("站点未开启全文检索")
+	ErrSearchReindexRunning = errors.New("全文索引正在重建，请稍后再试")
+)
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+var (
+	indexerMu sync.RWMutex
+	indexer   search.Indexer // 全文索引服务，未开启或尚未初始化时为 nil
+
+	writes     = make(chan func(context.Context, search.Indexer), writeQueueSize)
+	writerOnce sync.Once
+)
+
+// InitIndex 启动时按 SEARCH_BACKEND 创建全文索引服务，未开启 SEARCH_INDEX_ENABLED 时不做处理；
+// 文章或评论的索引不存在、损坏或为空时从数据库重建
+func InitIndex(ctx context.Context) error {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !config.SearchConfig.SearchIndexEnabled {
+		return nil
+	}
+
+	ix, err := search.NewIndexer(config)
+	if err != nil {
+		return err
+	}
+	indexerMu.Lock()
+	indexer = ix
+	indexerMu.Unlock()
+	writerOnce.Do(func() { go runWrites() })
+
+	for docType, rebuild := range map[string]func(context.Context, search.Indexer) (int, error){
+		search.DocTypePost:    rebuildPosts,
+		search.DocTypeComment: rebuildComments,
+	} {
+		count, err := ix.Count(ctx, docType)
+		if err != nil {
+			global.SysLog.Errorf("读取 %s 全文索引 %s 失败，将重建索引: %v", ix.Name(), docType, err)
+		} else if count > 0 {
+			global.SysLog.Infof("%s 全文索引 %s 已加载，共 %d 条", ix.Name(), docType, count)
+			continue
+		}
+		if count, err = rebuild(ctx, ix); err != nil {
+			return err
+		}
+		global.SysLog.Infof("%s 全文索引 %s 重建完成，共 %d 条", ix.Name(), docType, count)
+	}
+	return nil
+}
+
+// RebuildIndex 根据已发布的文章及其评论重建全文索引，返回索引的文章数与评论数，全文索引未开启时不做处理
+func RebuildIndex(ctx context.Context) (posts, comments int, err error) {
+	ix := currentIndexer()
+	if ix == nil {
+		return 0, 0, nil
+	}
+	if posts, err = rebuildPosts(ctx, ix); err != nil {
+		return 0, 0, err
+	}
+	if comments, err = rebuildComments(ctx, ix); err != nil {
+		return posts, 0, err
+	}
+	return posts, comments, nil
+}
+
+// TriggerReindex 立即在后台运行全文索引重建任务，上一次重建未完成时返回 ErrSearchReindexRunning
+func TriggerReindex(c echo.Context) error {
+	if currentIndexer() == nil {
+		return ErrSearchIndexDisabled
+	}
+	err := scheduler.Trigger(RebuildTask)
+	if errors.Is(err, scheduler.ErrTaskRunning) {
+		return ErrSearchReindexRunning
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("触发全文索引重建失败: %v", err)
+		return err
+	}
+	utils.BizLogger(c).Infof("手动触发全文索引重建")
+	return nil
+}
+
+// IndexPost 文章变更后更新全文索引，已发布的文章及其评论写入索引，其他文章及其评论从索引中移除；全文索引未开启时不做处理
+func IndexPost(pos *model.Post) {
+	enqueue(func(ctx context.Context, ix search.Indexer) error {
+		comments, err := mapper.GetCommentsByPostID(pos.ID)
+		if err != nil {
+			global.SysLog.Errorf("获取文章 %d 的评论失败: %v", pos.ID, err)
+		}
+		if !indexable(pos) {
+			if err := ix.Delete(ctx, search.DocTypePost, pos.ID); err != nil {
+				return err
+			}
+			return ix.Delete(ctx, search.DocTypeComment, commentIDs(comments)...)
+		}
+
+		categories, err := mapper.GetCategoriesByIDs(pos.CategoryIDs)
+		if err != nil {
+			// 标签缺失不影响按标题与正文检索，由定时重建补全
+			global.SysLog.Errorf("获取文章 %d 的分类失败: %v", pos.ID, err)
+		}
+		tags := make([]string, 0, len(categories))
+		for _, cat := range categories {
+			tags = append(tags, cat.Name)
+		}
+		if err := ix.Put(ctx, search.DocTypePost, postDocument(pos, tags)); err != nil {
+			return err
+		}
+		return ix.Put(ctx, search.DocTypeComment, commentDocuments(comments)...)
+	})
+}
+
+// RemovePost 从全文索引中移除文章及其评论，全文索引未开启时不做处理
+func RemovePost(postID int64) {
+	enqueue(func(ctx context.Context, ix search.Indexer) error {
+		if err := ix.Delete(ctx, search.DocTypePost, postID); err != nil {
+			return err
+		}
+		comments, err := mapper.GetCommentsByPostID(postID)
+		if err != nil {
+			return err
+		}
+		return ix.Delete(ctx, search.DocTypeComment, commentIDs(comments)...)
+	})
+}
+
+// IndexComment 评论创建后写入全文索引，所属文章未发布时不写入；全文索引未开启时不做处理
+func IndexComment(com *commentModel.Comment) {
+	enqueue(func(ctx context.Context, ix search.Indexer) error {
+		pos, err := mapper.GetPostByID(com.PostId)
+		if err != nil || !indexable(pos) || com.Deleted {
+			return ix.Delete(ctx, search.DocTypeComment, com.ID)
+		}
+		return ix.Put(ctx, search.DocTypeComment, commentDocuments([]*commentModel.Comment{com})...)
+	})
+}
+
+// RemoveComment 从全文索引中移除评论，全文索引未开启时不做处理
+func RemoveComment(commentID int64) {
+	enqueue(func(ctx context.Context, ix search.Indexer) error {
+		return ix.Delete(ctx, search.DocTypeComment, commentID)
+	})
+}
+
+// Search 检索已发布的文章，返回高亮后的标题与正文片段，结果按相关度排序并分页；开启 SEARCH_FUZZY_ENABLED 时容忍关键词的拼写错误
+func Search(req *dto.SearchRequest, page vo.PageRequest, c echo.Context) ([]*searchVo.SearchHitVo, *vo.PageMeta, error) {
+	ix := currentIndexer()
+	if ix == nil {
+		return nil, nil, ErrSearchIndexDisabled
+	}
+
+	hits, total, err := ix.Search(c.Request().Context(), search.DocTypePost, query(req.Keyword, page))
+	if err != nil {
+		utils.BizLogger(c).Errorf("%s 全文检索失败: %v", ix.Name(), err)
+		return nil, nil, err
+	}
+	results := make([]*searchVo.SearchHitVo, len(hits))
+	for i, hit := range hits {
+		results[i] = &searchVo.SearchHitVo{
+			ID:      hit.ID,
+			Title:   hit.Title,
+			Snippet: hit.Snippet,
+			Tags:    hit.Tags,
+			Score:   hit.Score,
+		}
+	}
+	utils.BizLogger(c).Infof("全文检索 %q 命中 %d 篇文章", req.Keyword, total)
+	return results, vo.NewPageMeta(page, int64(total)), nil
+}
+
+// SearchComments 检索已发布文章下的评论，返回高亮后的评论片段与所属文章；
+// 索引尚未同步的、所属文章已不再发布的评论不返回，总数按索引中的命中数计算
+func SearchComments(req *dto.SearchRequest, page vo.PageRequest, c echo.Context) ([]*searchVo.CommentSearchHitVo, *vo.PageMeta, error) {
+	ix := currentIndexer()
+	if ix == nil {
+		return nil, nil, ErrSearchIndexDisabled
+	}
+
+	hits, total, err := ix.Search(c.Request().Context(), search.DocTypeComment, query(req.Keyword, page))
+	if err != nil {
+		utils.BizLogger(c).Errorf("%s 检索评论失败: %v", ix.Name(), err)
+		return nil, nil, err
+	}
+	postIDs := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		postIDs = append(postIDs, hit.ParentID)
+	}
+	posts, err := mapper.GetPostsByIDs(postIDs)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取评论所属文章失败: %v", err)
+		return nil, nil, err
+	}
+	titles := make(map[int64]string, len(posts))
+	for _, pos := range posts {
+		if indexable(pos) {
+			titles[pos.ID] = pos.Title
+		}
+	}
+
+	results := make([]*searchVo.CommentSearchHitVo, 0, len(hits))
+	for _, hit := range hits {
+		title, ok := titles[hit.ParentID]
+		if !ok {
+			continue
+		}
+		results = append(results, &searchVo.CommentSearchHitVo{
+			ID:        hit.ID,
+			PostID:    hit.ParentID,
+			PostTitle: title,
+			Snippet:   hit.Snippet,
+			Score:     hit.Score,
+		})
+	}
+	utils.BizLogger(c).Infof("全文检索评论 %q 命中 %d 条", req.Keyword, total)
+	return results, vo.NewPageMeta(page, int64(total)), nil
+}
+
+// rebuildPosts 根据已发布的文章重建文章索引
+func rebuildPosts(ctx context.Context, ix search.Indexer) (int, error) {
+	posts, err := mapper.GetAllPublishedPosts(nil)
+	if err != nil {
+		return 0, err
+	}
+	categories, err := mapper.GetAllActivatedCategories()
+	if err != nil {
+		return 0, err
+	}
+	names := make(map[int64]string, len(categories))
+	for _, cat := range categories {
+		names[cat.ID] = cat.Name
+	}
+
+	docs := make([]search.IndexDocument, 0, len(posts))
+	for _, pos := range posts {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if !indexable(pos) {
+			continue
+		}
+		var tags []string
+		for _, id := range pos.CategoryIDs {
+			if name, ok := names[id]; ok {
+				tags = append(tags, name)
+			}
+		}
+		docs = append(docs, postDocument(pos, tags))
+	}
+	if err := ix.Reindex(ctx, search.DocTypePost, docs); err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+// rebuildComments 根据已发布文章下的评论重建评论索引
+func rebuildComments(ctx context.Context, ix search.Indexer) (int, error) {
+	comments, err := mapper.GetCommentsOfPublishedPosts()
+	if err != nil {
+		return 0, err
+	}
+	docs := commentDocuments(comments)
+	if err := ix.Reindex(ctx, search.DocTypeComment, docs); err != nil {
+		return 0, err
+	}
+	return len(docs), nil
+}
+
+// enqueue 将索引变更加入写入队列，由后台按顺序执行，避免外部搜索服务拖慢请求；全文索引未开启时丢弃
+func enqueue(write func(context.Context, search.Indexer) error) {
+	ix := currentIndexer()
+	if ix == nil {
+		return
+	}
+	select {
+	case writes <- func(ctx context.Context, ix search.Indexer) {
+		if err := write(ctx, ix); err != nil {
+			global.SysLog.Errorf("更新 %s 全文索引失败: %v", ix.Name(), err)
+		}
+	}:
+	default:
+		global.SysLog.Errorf("全文索引写入队列已满，变更将在下次重建时同步")
+	}
+}
+
+// runWrites 依次执行写入队列中的索引变更
+func runWrites() {
+	for write := range writes {
+		if ix := currentIndexer(); ix != nil {
+			write(context.Background(), ix)
+		}
+	}
+}
+
+// currentIndexer 获取已创建的全文索引服务，配置中关闭全文检索后立即停止使用
+func currentIndexer() search.Indexer {
+	indexerMu.RLock()
+	ix := indexer
+	indexerMu.RUnlock()
+	if ix == nil {
+		return nil
+	}
+	if config, err := configs.LoadConfig(); err != nil || !config.SearchConfig.SearchIndexEnabled {
+		return nil
+	}
+	return ix
+}
+
+// query 按检索关键词、分页与模糊匹配配置构造检索条件
+func query(keyword string, page vo.PageRequest) search.Query {
+	q := search.Query{Keyword: keyword, Offset: page.Offset(), Limit: page.Limit()}
+	if config, err := configs.LoadConfig(); err == nil {
+		q.Options = search.Options{
+			Fuzzy:       config.SearchConfig.SearchFuzzyEnabled,
+			MaxDistance: config.SearchConfig.SearchFuzzyMaxDistance,
+			Penalty:     config.SearchConfig.SearchFuzzyPenalty,
+		}
+	}
+	return q
+}
+
+// indexable 判断文章是否应写入全文索引，仅已发布、未删除且公开的文章可被检索，避免通过检索结果泄露登录可见与密码保护文章的正文
+func indexable(pos *model.Post) bool {
+	return !pos.Deleted && pos.Visibility && pos.Status == model.StatusPublished && pos.Access == model.AccessPublic
+}
+
+// postDocument 将文章转换为全文索引文档，正文取渲染后 HTML 的纯文本
+func postDocument(pos *model.Post, tags []string) search.IndexDocument {
+	return search.IndexDocument{
+		ID:      pos.ID,
+		Title:   pos.Title,
+		Content: html.UnescapeString(htmlTagPattern.ReplaceAllString(pos.ContentHTML, " ")),
+		Tags:    tags,
+	}
+}
+
+// commentDocuments 将评论转换为全文索引文档，ParentID 为所属文章
+func commentDocuments(comments []*commentModel.Comment) []search.IndexDocument {
+	docs := make([]search.IndexDocument, 0, len(comments))
+	for _, com := range comments {
+		docs = append(docs, search.IndexDocument{ID: com.ID, ParentID: com.PostId, Content: com.Content})
+	}
+	return docs
+}
+
+// commentIDs 获取评论的 ID 列表
+func commentIDs(comments []*commentModel.Comment) []int64 {
+	ids := make([]int64, len(comments))
+	for i, com := range comments {
+		ids[i] = com.ID
+	}
+	return ids
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/internal/ipaccess"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/security/dto"
+	vo "jank.com/jank_blog/pkg/vo/security"
+)
+
+var (
+	ErrIPRuleInvalid     = errors.New("IP 或 CIDR 格式无效")
+	ErrIPRuleSelfLockout = errors.New("修改后当前 IP 将无法访问管理接口")
+)
+
+// ListIPRules 获取管理接口的 IP 访问规则
+func ListIPRules(c echo.Context) (*vo.IPRulesVo, error) {
+	rules, err := ipaccess.Load(c.Request().Context())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取 IP 访问规则失败: %v", err)
+		return nil, fmt.Errorf("获取 IP 访问规则失败: %v", err)
+	}
+	return ipRulesVo(rules, c), nil
+}
+
+// AddIPRule 添加管理接口的 IP 访问规则，规则已在另一个列表中时移入当前列表；添加后当前 IP 将无法访问时返回 ErrIPRuleSelfLockout
+func AddIPRule(req *dto.IPRuleRequest, c echo.Context) (*vo.IPRulesVo, error) {
+	return updateIPRule(req, true, c)
+}
+
+// RemoveIPRule 移除管理员添加的 IP 访问规则，规则不存在时不做处理；移除后当前 IP 将无法访问时返回 ErrIPRuleSelfLockout
+func RemoveIPRule(req *dto.IPRuleRequest, c echo.Context) (*vo.IPRulesVo, error) {
+	return updateIPRule(req, false, c)
+}
+
+// updateIPRule 校验修改后当前 IP 仍可访问管理接口，再写入规则
+func updateIPRule(req *dto.IPRuleRequest, add bool, c echo.Context) (*vo.IPRulesVo, error) {
+	rule, err := ipaccess.Normalize(req.Rule)
+	if err != nil {
+		return nil, ErrIPRuleInvalid
+	}
+	ctx := c.Request().Context()
+	rules, err := ipaccess.Load(ctx)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取 IP 访问规则失败: %v", err)
+		return nil, fmt.Errorf("获取 IP 访问规则失败: %v", err)
+	}
+
+	// 添加时规则从另一个列表移入当前列表，移除时只影响当前列表
+	next := rules
+	switch {
+	case add:
+		next.Allow, next.Deny = without(rules.Allow, rule), without(rules.Deny, rule)
+		if req.List == ipaccess.ListDeny {
+			next.Deny = append(next.Deny, rule)
+		} else {
+			next.Allow = append(next.Allow, rule)
+		}
+	case req.List == ipaccess.ListDeny:
+		next.Deny = without(rules.Deny, rule)
+	default:
+		next.Allow = without(rules.Allow, rule)
+	}
+	if next.Evaluate(c.RealIP()) != nil {
+		return nil, ErrIPRuleSelfLockout
+	}
+
+	action := "remove"
+	if add {
+		err = ipaccess.Add(ctx, req.List, rule)
+		action = "add"
+	} else {
+		err = ipaccess.Remove(ctx, req.List, rule)
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("写入 IP 访问规则失败: %v", err)
+		return nil, fmt.Errorf("写入 IP 访问规则失败: %v", err)
+	}
+
+	auditLog("ip_access", c).WithFields(logrus.Fields{
+		"list":   req.List,
+		"action": action,
+		"rule":   rule,
+	}).Infof("管理员修改了管理接口的 IP 访问规则: %s %s %s", action, req.List, rule)
+	return ListIPRules(c)
+}
+
+// without 返回去除指定规则后的列表副本
+func without(rules []string, rule string) []string {
+	result := make([]string, 0, len(rules))
+	for _, r := range rules {
+		if r != rule {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// ipRulesVo 将访问规则映射为 vo
+func ipRulesVo(rules ipaccess.Rules, c echo.Context) *vo.IPRulesVo {
+	return &vo.IPRulesVo{
+		ClientIP:        c.RealIP(),
+		Allowlist:       nonNil(rules.Allow),
+		Denylist:        nonNil(rules.Deny),
+		ConfigAllowlist: nonNil(rules.ConfigAllow),
+		ConfigDenylist:  nonNil(rules.ConfigDeny),
+	}
+}
+
+// nonNil 将空列表转为空切片，序列化为 [] 而不是 null
+func nonNil(rules []string) []string {
+	if rules == nil {
+		return []string{}
+	}
+	return rules
+}
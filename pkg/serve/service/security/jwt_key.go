@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/jwtkey"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/security/dto"
+	vo "jank.com/jank_blog/pkg/vo/security"
+)
+
+// ListJWTKeys 获取全部有效的 JWT 签名密钥，按创建时间倒序排列
+func ListJWTKeys(c echo.Context) ([]*vo.JWTKeyVo, error) {
+	keys, err := jwtkey.Keys()
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取 JWT 签名密钥失败: %v", err)
+		return nil, fmt.Errorf("获取 JWT 签名密钥失败: %v", err)
+	}
+
+	result := make([]*vo.JWTKeyVo, len(keys))
+	for i, key := range keys {
+		result[i] = jwtKeyVo(key)
+	}
+	return result, nil
+}
+
+// RotateJWTKey 立即轮换 JWT 签名密钥，返回新密钥；旧密钥立即失效时已签发的 token 全部作废，包括当前管理员的登录状态
+func RotateJWTKey(req *dto.RotateJWTKeyRequest, c echo.Context) ([]*vo.JWTKeyVo, error) {
+	keys, err := jwtkey.Rotate(req.RevokePrevious)
+	if err != nil {
+		utils.BizLogger(c).Errorf("轮换 JWT 签名密钥失败: %v", err)
+		return nil, fmt.Errorf("轮换 JWT 签名密钥失败: %v", err)
+	}
+
+	auditLog("jwt_key", c).WithField("revoke_previous", req.RevokePrevious).Info("管理员轮换了 JWT 签名密钥")
+
+	result := make([]*vo.JWTKeyVo, len(keys))
+	for i, key := range keys {
+		result[i] = jwtKeyVo(key)
+	}
+	return result, nil
+}
+
+// jwtKeyVo 将签名密钥映射为 vo，不包含密钥本身
+func jwtKeyVo(key *jwtkey.Key) *vo.JWTKeyVo {
+	return &vo.JWTKeyVo{
+		Kid:       key.Kid,
+		Purpose:   key.Purpose,
+		Current:   key.RetiredAt == 0,
+		CreatedAt: key.CreatedAt,
+		RetiredAt: key.RetiredAt,
+		ExpiresAt: key.ExpiresAt,
+	}
+}
+
+// auditLog 返回记录安全设置变更的系统日志，带有审计类型、操作的管理员与来源 IP
+func auditLog(audit string, c echo.Context) *logrus.Entry {
+	operatorID, _, _ := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	return global.SysLog.WithFields(logrus.Fields{
+		"audit":    audit,
+		"operator": operatorID,
+		"ip":       c.RealIP(),
+	})
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	authMiddleware "jank.com/jank_blog/internal/middleware/auth"
+	accountModel "jank.com/jank_blog/internal/model/account"
+	model "jank.com/jank_blog/internal/model/post"
+	seriesModel "jank.com/jank_blog/internal/model/series"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/series/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/series"
+)
+
+var (
+	ErrSeriesNotFound      = errors.New("系列不存在")
+	ErrSeriesPostConflict  = errors.New("文章已属于其他系列")
+	ErrSeriesOrderMismatch = errors.New("提交的文章与系列中的文章不一致")
+	ErrPostNotFound        = errors.New("文章不存在")
+)
+
+// CreateSeries 创建系列，创建者为当前登录用户
+func CreateSeries(req *dto.CreateSeriesRequest, c echo.Context) (*series.SeriesVo, error) {
+	accountID, _, err := utils.ParseAccountAndRoleIDFromJWT(c.Request().Header.Get("Authorization"))
+	if err != nil {
+		utils.BizLogger(c).Errorf("解析 access token 失败: %v", err)
+		return nil, fmt.Errorf("解析 access token 失败: %v", err)
+	}
+
+	s := &seriesModel.Series{Title: req.Title, Description: req.Description, AccountID: accountID}
+	if err := mapper.CreateSeries(s); err != nil {
+		utils.BizLogger(c).Errorf("创建系列失败: %v", err)
+		return nil, fmt.Errorf("创建系列失败: %v", err)
+	}
+	return seriesVo(s), nil
+}
+
+// UpdateSeries 更新系列的标题与简介
+func UpdateSeries(req *dto.UpdateSeriesRequest, c echo.Context) (*series.SeriesVo, error) {
+	s, err := getSeries(req.ID, c)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Title, s.Description = req.Title, req.Description
+	if err := mapper.UpdateSeries(s); err != nil {
+		utils.BizLogger(c).Errorf("更新系列失败: %v", err)
+		return nil, fmt.Errorf("更新系列失败: %v", err)
+	}
+	return seriesVo(s), nil
+}
+
+// DeleteSeries 删除系列，系列中的文章保留并可加入其他系列
+func DeleteSeries(req *dto.SeriesIDRequest, c echo.Context) error {
+	if _, err := getSeries(req.ID, c); err != nil {
+		return err
+	}
+	if err := mapper.DeleteSeriesSoftly(req.ID); err != nil {
+		utils.BizLogger(c).Errorf("删除系列失败: %v", err)
+		return fmt.Errorf("删除系列失败: %v", err)
+	}
+	return nil
+}
+
+// GetSeries 获取系列详情及按顺序排列的文章，未发布的文章仅对其作者与管理员可见
+func GetSeries(req *dto.SeriesIDRequest, c echo.Context) (*series.SeriesVo, error) {
+	s, err := getSeries(req.ID, c)
+	if err != nil {
+		return nil, err
+	}
+	includeUnpublished, authorID := unpublishedViewer(c)
+	return seriesDetailVo(s, includeUnpublished, authorID, c)
+}
+
+// ListSeries 获取系列分页列表，按创建时间倒序排序
+func ListSeries(page vo.PageRequest, c echo.Context) ([]*series.SeriesVo, *vo.PageMeta, error) {
+	records, total, err := mapper.GetSeriesWithPaging(page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取系列列表失败: %v", err)
+		return nil, nil, fmt.Errorf("获取系列列表失败: %v", err)
+	}
+
+	result := make([]*series.SeriesVo, len(records))
+	for i, s := range records {
+		result[i] = seriesVo(s)
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// AddSeriesPost 将文章加入系列，文章已在该系列中时不做处理，已属于其他系列时返回 ErrSeriesPostConflict
+func AddSeriesPost(req *dto.SeriesPostRequest, c echo.Context) (*series.SeriesVo, error) {
+	s, err := getSeries(req.SeriesID, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := mapper.GetPostByID(req.PostID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPostNotFound
+		}
+		utils.BizLogger(c).Errorf("获取文章失败: %v", err)
+		return nil, fmt.Errorf("获取文章失败: %v", err)
+	}
+
+	existing, err := mapper.GetSeriesPostByPostID(req.PostID)
+	switch {
+	case err == nil && existing.SeriesID != s.ID:
+		return nil, ErrSeriesPostConflict
+	case err == nil:
+		return seriesDetailVo(s, true, 0, c)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		utils.BizLogger(c).Errorf("获取文章所属系列失败: %v", err)
+		return nil, fmt.Errorf("获取文章所属系列失败: %v", err)
+	}
+
+	if err := mapper.AddSeriesPost(s.ID, req.PostID, req.Position); err != nil {
+		utils.BizLogger(c).Errorf("将文章加入系列失败: %v", err)
+		return nil, fmt.Errorf("将文章加入系列失败: %v", err)
+	}
+	return seriesDetailVo(s, true, 0, c)
+}
+
+// RemoveSeriesPost 将文章移出系列，文章不在系列中时不会报错
+func RemoveSeriesPost(req *dto.SeriesPostRequest, c echo.Context) (*series.SeriesVo, error) {
+	s, err := getSeries(req.SeriesID, c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := mapper.RemoveSeriesPost(s.ID, req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("将文章移出系列失败: %v", err)
+		return nil, fmt.Errorf("将文章移出系列失败: %v", err)
+	}
+	return seriesDetailVo(s, true, 0, c)
+}
+
+// ReorderSeriesPosts 按提交的顺序重新排列系列中的文章；提交的文章需与系列中未删除的文章一致，
+// 回收站中的文章不在提交范围内，排在末尾并保持原有顺序
+func ReorderSeriesPosts(req *dto.ReorderSeriesPostsRequest, c echo.Context) (*series.SeriesVo, error) {
+	s, err := getSeries(req.SeriesID, c)
+	if err != nil {
+		return nil, err
+	}
+	records, err := mapper.GetSeriesPosts(s.ID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取系列文章失败: %v", err)
+		return nil, fmt.Errorf("获取系列文章失败: %v", err)
+	}
+	posts, err := postsByID(records)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取系列文章失败: %v", err)
+		return nil, fmt.Errorf("获取系列文章失败: %v", err)
+	}
+
+	if len(req.PostIDs) != len(posts) {
+		return nil, ErrSeriesOrderMismatch
+	}
+	seen := make(map[int64]bool, len(req.PostIDs))
+	for _, id := range req.PostIDs {
+		if _, ok := posts[id]; !ok || seen[id] {
+			return nil, ErrSeriesOrderMismatch
+		}
+		seen[id] = true
+	}
+
+	order := append([]int64{}, req.PostIDs...)
+	for _, record := range records {
+		if _, ok := posts[record.PostID]; !ok {
+			order = append(order, record.PostID)
+		}
+	}
+	if err := mapper.ReorderSeriesPosts(s.ID, order); err != nil {
+		utils.BizLogger(c).Errorf("重新排序系列文章失败: %v", err)
+		return nil, fmt.Errorf("重新排序系列文章失败: %v", err)
+	}
+	return seriesDetailVo(s, true, 0, c)
+}
+
+// SeriesNav 获取文章所属系列及上一篇、下一篇文章，未发布的文章仅对其作者与管理员可见；文章不属于任何系列时返回 nil
+func SeriesNav(postID int64, c echo.Context) (*series.SeriesNavVo, error) {
+	record, err := mapper.GetSeriesPostByPostID(postID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, err := mapper.GetSeriesByID(record.SeriesID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	includeUnpublished, authorID := unpublishedViewer(c)
+	items, err := seriesPosts(s.ID, includeUnpublished, authorID)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		if item.ID != postID {
+			continue
+		}
+		nav := &series.SeriesNavVo{ID: s.ID, Title: s.Title, Position: item.Position, Total: len(items)}
+		if i > 0 {
+			nav.Prev = items[i-1]
+		}
+		if i < len(items)-1 {
+			nav.Next = items[i+1]
+		}
+		return nav, nil
+	}
+	return nil, nil
+}
+
+// getSeries 获取系列，不存在时返回 ErrSeriesNotFound
+func getSeries(id int64, c echo.Context) (*seriesModel.Series, error) {
+	s, err := mapper.GetSeriesByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSeriesNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取系列失败: %v", err)
+		return nil, fmt.Errorf("获取系列失败: %v", err)
+	}
+	return s, nil
+}
+
+// seriesDetailVo 生成包含文章列表的系列 vo
+func seriesDetailVo(s *seriesModel.Series, includeUnpublished bool, authorID int64, c echo.Context) (*series.SeriesVo, error) {
+	items, err := seriesPosts(s.ID, includeUnpublished, authorID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取系列文章失败: %v", err)
+		return nil, fmt.Errorf("获取系列文章失败: %v", err)
+	}
+	result := seriesVo(s)
+	result.Posts = items
+	return result, nil
+}
+
+// seriesPosts 获取系列中按顺序排列的文章，跳过回收站中的文章，includeUnpublished 为 false 时只包含已发布的文章与 authorID 署名的文章；
+// 序号按返回的文章重新从 1 编号
+func seriesPosts(seriesID int64, includeUnpublished bool, authorID int64) ([]*series.SeriesPostVo, error) {
+	records, err := mapper.GetSeriesPosts(seriesID)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := postsByID(records)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := authoredPosts(posts, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*series.SeriesPostVo, 0, len(records))
+	for _, record := range records {
+		pos, ok := posts[record.PostID]
+		if !ok || !includeUnpublished && pos.Status != model.StatusPublished && !owned[pos.ID] {
+			continue
+		}
+		items = append(items, &series.SeriesPostVo{
+			ID:       pos.ID,
+			Title:    pos.Title,
+			Slug:     pos.Slug,
+			Image:    pos.Image,
+			Status:   pos.Status,
+			Position: len(items) + 1,
+		})
+	}
+	return items, nil
+}
+
+// postsByID 获取关联中未删除的文章
+func postsByID(records []*seriesModel.SeriesPost) (map[int64]*model.Post, error) {
+	ids := make([]int64, len(records))
+	for i, record := range records {
+		ids[i] = record.PostID
+	}
+	posts, err := mapper.GetPostsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*model.Post, len(posts))
+	for _, pos := range posts {
+		byID[pos.ID] = pos
+	}
+	return byID, nil
+}
+
+// seriesVo 生成不含文章列表的系列 vo
+func seriesVo(s *seriesModel.Series) *series.SeriesVo {
+	return &series.SeriesVo{
+		ID:          s.ID,
+		Title:       s.Title,
+		Description: s.Description,
+		GmtCreate:   s.GmtCreate,
+		GmtModified: s.GmtModified,
+	}
+}
+
+// unpublishedViewer 当前请求可查看的未发布文章，与文章详情一致：管理员可查看全部，返回 true；
+// 其他登录用户仅可查看自己署名的文章，返回其账户 ID；登录状态需对应未结束的登录会话
+func unpublishedViewer(c echo.Context) (bool, int64) {
+	accountID := authMiddleware.OptionalAccountID(c)
+	if accountID == 0 {
+		return false, 0
+	}
+	codes, err := mapper.GetRoleCodesByAccountIDs([]int64{accountID})
+	if err == nil && codes[accountID] == accountModel.RoleCodeAdmin {
+		return true, 0
+	}
+	return false, accountID
+}
+
+// authoredPosts 返回 posts 中 authorID 署名的未发布文章，authorID 为 0 时返回空
+func authoredPosts(posts map[int64]*model.Post, authorID int64) (map[int64]bool, error) {
+	owned := make(map[int64]bool)
+	if authorID == 0 {
+		return owned, nil
+	}
+	ids := make([]int64, 0, len(posts))
+	for id, pos := range posts {
+		if pos.Status != model.StatusPublished {
+			ids = append(ids, id)
+		}
+	}
+	authors, err := mapper.GetPostAuthors(ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, author := range authors {
+		if author.AccountID == authorID {
+			owned[author.PostID] = true
+		}
+	}
+	return owned, nil
+}
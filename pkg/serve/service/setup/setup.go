@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	model "jank.com/jank_blog/internal/model/account"
+	settingModel "jank.com/jank_blog/internal/model/setting"
+	"jank.com/jank_blog/internal/password"
+	"jank.com/jank_blog/internal/redis"
+	"jank.com/jank_blog/internal/setting"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/setup/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/account"
+	"jank.com/jank_blog/pkg/vo/setup"
+)
+
+// GetSetupStatus 获取系统安装状态
+func GetSetupStatus(c echo.Context) (*setup.SetupStatusVo, error) {
+	completed, err := mapper.SettingExists(settingModel.KeySetupCompleted)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取安装状态失败: %v", err)
+		return nil, fmt.Errorf("获取安装状态失败: %v", err)
+	}
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+		return nil, fmt.Errorf("加载配置失败: %v", err)
+	}
+
+	return &setup.SetupStatusVo{
+		NeedSetup: !completed,
+		SiteTitle: config.SiteConfig.SiteTitle,
+		SiteURL:   config.SiteConfig.SiteURL,
+	}, nil
+}
+
+// TestConnection 检测数据库与 Redis 连通性，Redis 未连接时尝试重新连接
+func TestConnection(c echo.Context) (*setup.ConnectionVo, error) {
+	result := &setup.ConnectionVo{Errors: make(map[string]string)}
+
+	if sqlDB, err := global.DB.DB(); err != nil {
+		result.Errors["database"] = err.Error()
+	} else if err := sqlDB.PingContext(c.Request().Context()); err != nil {
+		result.Errors["database"] = err.Error()
+	} else {
+		result.Database = true
+	}
+
+	if global.RedisClient == nil {
+		config, err := configs.LoadConfig()
+		if err != nil {
+			utils.BizLogger(c).Errorf("加载配置失败: %v", err)
+			return nil, fmt.Errorf("加载配置失败: %v", err)
+		}
+		redis.New(config)
+	}
+
+	if global.RedisClient == nil {
+		result.Errors["redis"] = "Redis 连接失败，请检查 redis 配置"
+	} else if err := global.RedisClient.Ping(context.Background()).Err(); err != nil {
+		result.Errors["redis"] = err.Error()
+	} else {
+		result.Redis = true
+	}
+
+	return result, nil
+}
+
+// InitSetup 写入站点与邮件配置并创建管理员；先在数据库中写入安装完成标记，多个实例并发初始化时只有一个请求成功，
+// 初始化失败时删除标记以便重试
+func InitSetup(req *dto.InitSetupRequest, c echo.Context) (_ *account.RegisterAccountVo, err error) {
+	claimed, err := mapper.CreateSettingIfAbsent(settingModel.KeySetupCompleted, strconv.FormatInt(time.Now().Unix(), 10))
+	if err != nil {
+		utils.BizLogger(c).Errorf("写入安装状态失败: %v", err)
+		return nil, fmt.Errorf("写入安装状态失败: %v", err)
+	}
+	if !claimed {
+		utils.BizLogger(c).Errorf("系统已完成初始化，拒绝重复初始化")
+		return nil, fmt.Errorf("系统已完成初始化，拒绝重复初始化")
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if delErr := mapper.DeleteSetting(settingModel.KeySetupCompleted); delErr != nil {
+			utils.BizLogger(c).Errorf("初始化失败后恢复安装状态失败: %v", delErr)
+		}
+	}()
+
+	if existingUser, _ := mapper.GetAccountByEmail(req.Email); existingUser != nil {
+		utils.BizLogger(c).Errorf("「%s」邮箱已被注册", req.Email)
+		return nil, fmt.Errorf("「%s」邮箱已被注册", req.Email)
+	}
+
+	hashedPassword, err := password.Hash(req.Password)
+	if err != nil {
+		utils.BizLogger(c).Errorf("%v", err)
+		return nil, err
+	}
+
+	values := map[string]string{
+		"site.SITE_TITLE": req.SiteTitle,
+		"site.SITE_URL":   req.SiteURL,
+	}
+	if req.EmailType != "" {
+		values["app.EMAIL_TYPE"] = req.EmailType
+	}
+	if req.FromEmail != "" {
+		values["app.FROM_EMAIL"] = req.FromEmail
+	}
+	if req.EmailSmtp != "" {
+		values["app.EMAIL_SMTP"] = req.EmailSmtp
+	}
+	if err := setting.Update(values); err != nil {
+		utils.BizLogger(c).Errorf("写入站点配置失败: %v", err)
+		return nil, fmt.Errorf("写入站点配置失败: %v", err)
+	}
+
+	acc := &model.Account{
+		Email:             req.Email,
+		Password:          hashedPassword,
+		PasswordAlgorithm: password.Identify(hashedPassword),
+		Nickname:          req.Nickname,
+	}
+	if err := mapper.CreateAccount(acc); err != nil {
+		utils.BizLogger(c).Errorf("创建管理员失败: %v", err)
+		return nil, fmt.Errorf("创建管理员失败: %v", err)
+	}
+
+	// 获取管理员角色，如果没有则自动创建
+	role, err := mapper.GetRoleByCode(model.RoleCodeAdmin)
+	if err != nil {
+		role = &model.Role{
+			Code:        model.RoleCodeAdmin,
+			Description: "管理员",
+		}
+		if err := mapper.CreateRole(role); err != nil {
+			utils.BizLogger(c).Errorf("创建管理员角色失败: %v", err)
+			return nil, fmt.Errorf("创建管理员角色失败: %v", err)
+		}
+	}
+
+	if err := mapper.AssignRoleToAcc(acc.ID, role.ID); err != nil {
+		utils.BizLogger(c).Errorf("给管理员分配角色失败: %v", err)
+		return nil, fmt.Errorf("给管理员分配角色失败: %v", err)
+	}
+
+	vo, err := utils.MapModelToVO(acc, &account.RegisterAccountVo{})
+	if err != nil {
+		utils.BizLogger(c).Errorf("初始化管理员时映射 vo 失败: %v", err)
+		return nil, fmt.Errorf("初始化管理员时映射 vo 失败: %v", err)
+	}
+
+	return vo.(*account.RegisterAccountVo), nil
+}
+
+// BackfillSetupCompleted 为升级前已创建管理员的系统写入安装完成标记，避免升级后重新开放安装向导
+func BackfillSetupCompleted() error {
+	completed, err := mapper.SettingExists(settingModel.KeySetupCompleted)
+	if err != nil || completed {
+		return err
+	}
+	count, err := mapper.CountAccountsByRoleCode(model.RoleCodeAdmin)
+	if err != nil || count == 0 {
+		return err
+	}
+	_, err = mapper.CreateSettingIfAbsent(settingModel.KeySetupCompleted, strconv.FormatInt(time.Now().Unix(), 10))
+	return err
+}
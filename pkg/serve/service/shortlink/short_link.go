@@ -0,0 +1,165 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+
+	"jank.com/jank_blog/configs"
+	model "jank.com/jank_blog/internal/model/shortlink"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/shortlink/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo/shortlink"
+)
+
+const (
+	codeAlphabet   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	codeLength     = 6   // 短码长度
+	maxCodeRetries = 5   // 短码冲突时的最大重试次数
+	maxFieldLength = 255 // 点击记录中来源与 User-Agent 的最大长度
+	topReferers    = 10  // 统计展示的来源数量
+)
+
+// ErrShortLinkNotFound 短码不存在或对应的文章已删除
+var ErrShortLinkNotFound = errors.New("短链接不存在")
+
+// EnsureShortLink 获取文章的短链接，不存在时生成
+func EnsureShortLink(postID int64) (*model.ShortLink, error) {
+	link, err := mapper.GetShortLinkByPostID(postID)
+	if err == nil {
+		return link, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	for i := 0; i < maxCodeRetries; i++ {
+		code, err := randomCode()
+		if err != nil {
+			return nil, err
+		}
+		if count, err := mapper.CountShortLinksByCode(code); err != nil {
+			return nil, err
+		} else if count > 0 {
+			continue
+		}
+
+		link = &model.ShortLink{PostID: postID, Code: code}
+		if err := mapper.CreateShortLink(link); err != nil {
+			// 并发生成时以先写入的短链接为准
+			if existing, getErr := mapper.GetShortLinkByPostID(postID); getErr == nil {
+				return existing, nil
+			}
+			return nil, err
+		}
+		return link, nil
+	}
+	return nil, fmt.Errorf("生成短码失败: 连续 %d 次冲突", maxCodeRetries)
+}
+
+// ShortURL 生成短链接的完整地址
+func ShortURL(code string) string {
+	return siteURL() + "/s/" + code
+}
+
+// ResolveShortLink 解析短码并记录点击，返回文章页面地址
+func ResolveShortLink(code string, c echo.Context) (string, error) {
+	link, err := mapper.GetShortLinkByCode(code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrShortLinkNotFound
+	}
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取短链接失败: %v", err)
+		return "", fmt.Errorf("获取短链接失败: %v", err)
+	}
+
+	if _, err := mapper.GetPostByID(link.PostID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrShortLinkNotFound
+	} else if err != nil {
+		utils.BizLogger(c).Errorf("获取短链接对应的文章失败: %v", err)
+		return "", fmt.Errorf("获取短链接对应的文章失败: %v", err)
+	}
+
+	req := c.Request()
+	click := &model.ShortLinkClick{
+		ShortLinkID: link.ID,
+		Referer:     truncate(req.Referer()),
+		UserAgent:   truncate(req.UserAgent()),
+		IP:          c.RealIP(),
+	}
+	// 点击统计失败不影响跳转
+	if err := mapper.RecordShortLinkClick(click); err != nil {
+		utils.BizLogger(c).Errorf("记录短链接点击失败: %v", err)
+	}
+
+	return fmt.Sprintf("%s/posts/%d/", siteURL(), link.PostID), nil
+}
+
+// GetShortLinkStats 获取文章短链接的点击统计
+func GetShortLinkStats(req *dto.GetShortLinkStatsRequest, c echo.Context) (*shortlink.ShortLinkStatsVo, error) {
+	if _, err := mapper.GetPostByID(req.PostID); err != nil {
+		utils.BizLogger(c).Errorf("文章不存在: %v", err)
+		return nil, fmt.Errorf("文章不存在: %v", err)
+	}
+
+	link, err := EnsureShortLink(req.PostID)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取短链接失败: %v", err)
+		return nil, fmt.Errorf("获取短链接失败: %v", err)
+	}
+
+	referers, err := mapper.GetShortLinkTopReferers(link.ID, topReferers)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取短链接来源统计失败: %v", err)
+		return nil, fmt.Errorf("获取短链接来源统计失败: %v", err)
+	}
+
+	stats := &shortlink.ShortLinkStatsVo{
+		PostID:   link.PostID,
+		Code:     link.Code,
+		ShortURL: ShortURL(link.Code),
+		Clicks:   link.Clicks,
+		Referers: make([]*shortlink.RefererVo, len(referers)),
+	}
+	for i, r := range referers {
+		stats.Referers[i] = &shortlink.RefererVo{Referer: r.Referer, Count: r.Count}
+	}
+	return stats, nil
+}
+
+// randomCode 生成随机短码
+func randomCode() (string, error) {
+	var b strings.Builder
+	size := big.NewInt(int64(len(codeAlphabet)))
+	for i := 0; i < codeLength; i++ {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(codeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// siteURL 获取站点地址，未配置时返回空字符串，生成相对地址
+func siteURL() string {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(config.SiteConfig.SiteURL, "/")
+}
+
+// truncate 截断超长的点击记录字段
+func truncate(s string) string {
+	if len(s) > maxFieldLength {
+		return s[:maxFieldLength]
+	}
+	return s
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/configs"
+	"jank.com/jank_blog/internal/global"
+	"jank.com/jank_blog/internal/sitemap"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/mapper"
+)
+
+// IndexFile 站点地图入口文件，地址数超过上限时为站点地图索引
+const IndexFile = "sitemap.xml"
+
+// defaultCacheMaxAge 未配置时站点地图的缓存时间
+const defaultCacheMaxAge = time.Hour
+
+// pingTimeout 通知单个搜索引擎的超时时间
+const pingTimeout = 10 * time.Second
+
+var (
+	ErrSitemapDisabled = errors.New("站点未开启站点地图")
+	ErrSitemapNotFound = errors.New("站点地图不存在")
+)
+
+// Document 生成好的站点地图文件
+type Document struct {
+	Content []byte
+	ETag    string
+	ModTime time.Time // 生成时间，作为 Last-Modified
+	MaxAge  time.Duration
+}
+
+var (
+	cacheMu     sync.Mutex
+	cached      map[string]*Document // 文件名到站点地图的映射，失效后为 nil
+	cachedUntil time.Time
+)
+
+// GetSitemap 获取站点地图文件，file 为 sitemap.xml 或拆分后的 sitemap-1.xml 等；
+// 生成结果缓存在本地，文章或分类变更后失效，最长缓存 SITEMAP_CACHE_MAX_AGE
+func GetSitemap(file string, c echo.Context) (*Document, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		utils.BizLogger(c).Errorf("加载站点地图配置失败: %v", err)
+		return nil, fmt.Errorf("加载站点地图配置失败: %v", err)
+	}
+	if !config.SitemapConfig.SitemapEnabled {
+		return nil, ErrSitemapDisabled
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if cached == nil || time.Now().After(cachedUntil) {
+		docs, err := generate(config)
+		if err != nil {
+			utils.BizLogger(c).Errorf("生成站点地图失败: %v", err)
+			return nil, fmt.Errorf("生成站点地图失败: %v", err)
+		}
+		cached, cachedUntil = docs, time.Now().Add(cacheMaxAge(config))
+	}
+
+	doc, ok := cached[file]
+	if !ok {
+		return nil, ErrSitemapNotFound
+	}
+	return doc, nil
+}
+
+// Invalidate 文章或分类变更后清除站点地图缓存，下次访问时重新生成
+func Invalidate() {
+	cacheMu.Lock()
+	cached = nil
+	cacheMu.Unlock()
+}
+
+// NotifyPublished 文章发布后清除站点地图缓存，并在后台通知 SITEMAP_PING_URLS 中的搜索引擎，通知失败时仅记录日志
+func NotifyPublished() {
+	Invalidate()
+
+	config, err := configs.LoadConfig()
+	if err != nil || !config.SitemapConfig.SitemapEnabled || len(config.SitemapConfig.SitemapPingURLs) == 0 {
+		return
+	}
+	sitemapURL := strings.TrimRight(config.SiteConfig.SiteURL, "/") + "/" + IndexFile
+	go func() {
+		client := &http.Client{Timeout: pingTimeout}
+		for _, endpoint := range config.SitemapConfig.SitemapPingURLs {
+			if err := sitemap.Ping(context.Background(), client, endpoint, sitemapURL); err != nil {
+				global.SysLog.Errorf("通知搜索引擎站点地图更新失败: %v", err)
+			}
+		}
+	}()
+}
+
+// generate 根据已发布的文章与分类生成站点地图，地址数超过 SITEMAP_MAX_URLS 时拆分为多个文件并生成索引
+func generate(config *configs.Config) (map[string]*Document, error) {
+	siteURL := strings.TrimRight(config.SiteConfig.SiteURL, "/")
+	urls, err := collectURLs(config, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	maxAge := cacheMaxAge(config)
+	docs := make(map[string]*Document)
+	add := func(name string, content []byte) {
+		sum := sha256.Sum256(content)
+		docs[name] = &Document{Content: content, ETag: `"` + hex.EncodeToString(sum[:8]) + `"`, ModTime: now, MaxAge: maxAge}
+	}
+
+	groups := sitemap.Split(urls, config.SitemapConfig.SitemapMaxURLs)
+	if len(groups) == 1 {
+		content, err := sitemap.Build(groups[0])
+		if err != nil {
+			return nil, err
+		}
+		add(IndexFile, content)
+		return docs, nil
+	}
+
+	sitemaps := make([]sitemap.Sitemap, len(groups))
+	for i, group := range groups {
+		name := fmt.Sprintf("sitemap-%d.xml", i+1)
+		content, err := sitemap.Build(group)
+		if err != nil {
+			return nil, err
+		}
+		add(name, content)
+		sitemaps[i] = sitemap.Sitemap{Loc: siteURL + "/" + name, LastMod: sitemap.LastMod(group)}
+	}
+	content, err := sitemap.BuildIndex(sitemaps)
+	if err != nil {
+		return nil, err
+	}
+	add(IndexFile, content)
+	return docs, nil
+}
+
+// collectURLs 收集静态页面、年月归档、分类与已发布文章的地址，页面地址与静态导出保持一致；
+// 静态页面的 lastmod 为最近一次更新文章的时间，归档与分类为其中最近更新文章的时间
+func collectURLs(config *configs.Config, siteURL string) ([]sitemap.URL, error) {
+	posts, err := mapper.GetPublishedPostsForSitemap()
+	if err != nil {
+		return nil, fmt.Errorf("获取已发布文章失败: %v", err)
+	}
+	categories, err := mapper.GetAllActivatedCategories()
+	if err != nil {
+		return nil, fmt.Errorf("获取分类失败: %v", err)
+	}
+
+	var latest time.Time
+	archives := make(map[string]time.Time)
+	var archiveKeys []string
+	categoryMods := make(map[int64]time.Time)
+	postURLs := make([]sitemap.URL, 0, len(posts))
+	for _, pos := range posts {
+		modified := time.Unix(pos.GmtModified, 0)
+		if modified.After(latest) {
+			latest = modified
+		}
+		key := time.Unix(pos.GmtCreate, 0).Format("2006/01")
+		if _, ok := archives[key]; !ok {
+			archiveKeys = append(archiveKeys, key)
+		}
+		if modified.After(archives[key]) {
+			archives[key] = modified
+		}
+		for _, id := range pos.CategoryIDs {
+			if modified.After(categoryMods[id]) {
+				categoryMods[id] = modified
+			}
+		}
+		postURLs = append(postURLs, sitemap.URL{Loc: fmt.Sprintf("%s/posts/%d/", siteURL, pos.ID), LastMod: modified, ChangeFreq: "weekly", Priority: 0.8})
+	}
+
+	urls := make([]sitemap.URL, 0, len(config.SitemapConfig.SitemapStaticPages)+len(archives)+len(categories)+len(posts))
+	for _, page := range config.SitemapConfig.SitemapStaticPages {
+		u := sitemap.URL{Loc: siteURL + "/" + strings.TrimLeft(page, "/"), LastMod: latest, ChangeFreq: "daily", Priority: 0.5}
+		if page == "/" {
+			u.Priority = 1.0
+		}
+		urls = append(urls, u)
+	}
+	for _, key := range archiveKeys {
+		urls = append(urls, sitemap.URL{Loc: siteURL + "/archives/" + key + "/", LastMod: archives[key], ChangeFreq: "monthly", Priority: 0.3})
+	}
+	for _, cat := range categories {
+		modified := categoryMods[cat.ID]
+		if cat.GmtModified > 0 && modified.Before(time.Unix(cat.GmtModified, 0)) {
+			modified = time.Unix(cat.GmtModified, 0)
+		}
+		urls = append(urls, sitemap.URL{Loc: fmt.Sprintf("%s/categories/%d/", siteURL, cat.ID), LastMod: modified, ChangeFreq: "weekly", Priority: 0.5})
+	}
+	return append(urls, postURLs...), nil
+}
+
+// cacheMaxAge 读取配置中站点地图的缓存时间
+func cacheMaxAge(config *configs.Config) time.Duration {
+	if seconds := config.SitemapConfig.SitemapCacheMaxAge; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultCacheMaxAge
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/scheduler"
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/task/dto"
+	"jank.com/jank_blog/pkg/vo/task"
+)
+
+// ListTasks 列出所有定时任务及其运行状态
+func ListTasks(c echo.Context) ([]*task.TaskVo, error) {
+	statuses := scheduler.List()
+
+	tasks := make([]*task.TaskVo, len(statuses))
+	for i, st := range statuses {
+		tasks[i] = &task.TaskVo{
+			Name:            st.Name,
+			Description:     st.Description,
+			IntervalSeconds: int64(st.Interval / time.Second),
+			Paused:          st.Paused,
+			Running:         st.Running,
+			NextRun:         unix(st.NextRun),
+			LastRun:         unix(st.LastRun),
+			LastDurationMs:  st.LastDuration.Milliseconds(),
+			LastError:       st.LastError,
+		}
+	}
+	return tasks, nil
+}
+
+// TriggerTask 立即运行定时任务
+func TriggerTask(req *dto.TaskNameRequest, c echo.Context) error {
+	if err := scheduler.Trigger(req.Name); err != nil {
+		utils.BizLogger(c).Errorf("触发定时任务失败: %v", err)
+		return fmt.Errorf("触发定时任务失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("手动触发定时任务: %s", req.Name)
+	return nil
+}
+
+// PauseTask 暂停定时任务
+func PauseTask(req *dto.TaskNameRequest, c echo.Context) error {
+	if err := scheduler.Pause(req.Name); err != nil {
+		utils.BizLogger(c).Errorf("暂停定时任务失败: %v", err)
+		return fmt.Errorf("暂停定时任务失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("暂停定时任务: %s", req.Name)
+	return nil
+}
+
+// ResumeTask 恢复定时任务
+func ResumeTask(req *dto.TaskNameRequest, c echo.Context) error {
+	if err := scheduler.Resume(req.Name); err != nil {
+		utils.BizLogger(c).Errorf("恢复定时任务失败: %v", err)
+		return fmt.Errorf("恢复定时任务失败: %v", err)
+	}
+	utils.BizLogger(c).Infof("恢复定时任务: %s", req.Name)
+	return nil
+}
+
+// GetTaskLogs 获取定时任务的最近运行记录
+func GetTaskLogs(req *dto.TaskNameRequest, c echo.Context) ([]*task.TaskLogVo, error) {
+	logs, err := scheduler.Logs(req.Name)
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取定时任务运行记录失败: %v", err)
+		return nil, fmt.Errorf("获取定时任务运行记录失败: %v", err)
+	}
+
+	result := make([]*task.TaskLogVo, len(logs))
+	for i, log := range logs {
+		result[i] = &task.TaskLogVo{
+			Trigger:    log.Trigger,
+			StartedAt:  log.StartedAt.Unix(),
+			DurationMs: log.Duration.Milliseconds(),
+			Success:    log.Error == "",
+			Error:      log.Error,
+		}
+	}
+	return result, nil
+}
+
+// unix 转换为秒级时间戳，零值时返回 0
+func unix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
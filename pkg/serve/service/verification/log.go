@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/pkg/serve/controller/verification/dto"
+	"jank.com/jank_blog/pkg/serve/mapper"
+	"jank.com/jank_blog/pkg/vo"
+	"jank.com/jank_blog/pkg/vo/verification"
+)
+
+// ListVerificationLogs 按条件获取验证码审计日志分页列表，最新的排在前面
+func ListVerificationLogs(req *dto.ListVerificationLogsRequest, page vo.PageRequest, c echo.Context) ([]*verification.VerificationLogVo, *vo.PageMeta, error) {
+	logs, total, err := mapper.GetVerificationLogsWithPaging(logFilter(req), page.Offset(), page.Limit())
+	if err != nil {
+		utils.BizLogger(c).Errorf("获取验证码审计日志失败: %v", err)
+		return nil, nil, fmt.Errorf("获取验证码审计日志失败: %v", err)
+	}
+
+	result := make([]*verification.VerificationLogVo, len(logs))
+	for i, log := range logs {
+		result[i] = &verification.VerificationLogVo{
+			ID:        log.ID,
+			Event:     log.Event,
+			Channel:   log.Channel,
+			Target:    log.Target,
+			IP:        log.IP,
+			Result:    log.Result,
+			CreatedAt: log.GmtCreate,
+		}
+	}
+	return result, vo.NewPageMeta(page, total), nil
+}
+
+// GetVerificationLogStats 按事件、渠道与结果分组统计验证码审计日志
+func GetVerificationLogStats(req *dto.ListVerificationLogsRequest, c echo.Context) ([]*verification.VerificationLogStatVo, error) {
+	counts, err := mapper.CountVerificationLogs(logFilter(req))
+	if err != nil {
+		utils.BizLogger(c).Errorf("统计验证码审计日志失败: %v", err)
+		return nil, fmt.Errorf("统计验证码审计日志失败: %v", err)
+	}
+
+	result := make([]*verification.VerificationLogStatVo, len(counts))
+	for i, count := range counts {
+		result[i] = &verification.VerificationLogStatVo{
+			Event:   count.Event,
+			Channel: count.Channel,
+			Result:  count.Result,
+			Count:   count.Count,
+		}
+	}
+	return result, nil
+}
+
+// logFilter 将请求参数转换为查询条件，渠道统一为大写
+func logFilter(req *dto.ListVerificationLogsRequest) mapper.VerificationLogFilter {
+	return mapper.VerificationLogFilter{
+		Event:   req.Event,
+		Channel: strings.ToUpper(req.Channel),
+		Target:  req.Target,
+		IP:      req.IP,
+		Result:  req.Result,
+		Since:   req.Since,
+		Until:   req.Until,
+	}
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"jank.com/jank_blog/internal/utils"
+	"jank.com/jank_blog/internal/visitor"
+	vo "jank.com/jank_blog/pkg/vo/visitor"
+)
+
+// Identify 获取或签发当前访客的访客 ID，并顺延 Cookie 有效期；未开启时返回 visitor.ErrDisabled
+func Identify(c echo.Context) (*vo.VisitorVo, error) {
+	identity, err := visitor.Ensure(c)
+	if err != nil {
+		if !errors.Is(err, visitor.ErrDisabled) {
+			utils.BizLogger(c).Errorf("签发访客 ID 失败: %v", err)
+		}
+		return nil, err
+	}
+	if identity.Created {
+		utils.BizLogger(c).Infof("签发访客 ID %s", identity.ID)
+	}
+	return &vo.VisitorVo{VisitorID: identity.ID, ExpiresAt: identity.ExpiresAt, Created: identity.Created}, nil
+}
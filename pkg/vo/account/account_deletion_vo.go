@@ -0,0 +1,10 @@
+package account
+
+// AccountDeletionVo     注销账户申请结果
+// @Description	申请注销后账户进入冷静期，冷静期内重新登录即撤销注销
+// @Property			scheduled_at	body	int64	true	"永久删除账户的时间"
+// @Property			grace_days		body	int		true	"冷静期天数"
+type AccountDeletionVo struct {
+	ScheduledAt int64 `json:"scheduled_at"`
+	GraceDays   int   `json:"grace_days"`
+}
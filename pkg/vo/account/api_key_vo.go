@@ -0,0 +1,30 @@
+package account
+
+// APIKeyVo     API Key 信息
+// @Description	当前账户创建的 API Key，不包含完整的 Key
+// @Property			id				body	int64	true	"API Key ID"
+// @Property			name			body	string	true	"名称"
+// @Property			prefix			body	string	true	"Key 的前几位，用于区分"
+// @Property			scope			body	string	true	"权限范围"
+// @Property			expires_at		body	int64	true	"过期时间，为 0 时永不过期"
+// @Property			last_used_at	body	int64	true	"最近一次使用时间，未使用过时为 0"
+// @Property			last_used_ip	body	string	true	"最近一次使用的 IP"
+// @Property			gmt_create		body	int64	true	"创建时间"
+type APIKeyVo struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"`
+	Scope      string `json:"scope"`
+	ExpiresAt  int64  `json:"expires_at"`
+	LastUsedAt int64  `json:"last_used_at"`
+	LastUsedIP string `json:"last_used_ip"`
+	GmtCreate  int64  `json:"gmt_create"`
+}
+
+// APIKeyCreatedVo     新建的 API Key
+// @Description	完整的 Key 仅在创建时返回一次，请求时放在请求头 Authorization: ApiKey {key}
+// @Property			key	body	string	true	"完整的 API Key"
+type APIKeyCreatedVo struct {
+	APIKeyVo
+	Key string `json:"key"`
+}
@@ -0,0 +1,10 @@
+package account
+
+// DataExportVo     个人数据导出申请结果
+// @Description	导出在后台进行，完成后将下载链接发送到账户邮箱
+// @Property			email			body	string	true	"接收下载链接的邮箱"
+// @Property			expire_hours	body	int		true	"下载链接的有效期（小时）"
+type DataExportVo struct {
+	Email       string `json:"email"`
+	ExpireHours int    `json:"expire_hours"`
+}
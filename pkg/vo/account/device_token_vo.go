@@ -0,0 +1,28 @@
+package account
+
+// DeviceTokenVo     设备令牌信息
+// @Description	当前账户开启记住我的设备，不包含完整的令牌
+// @Property			id				body	int64	true	"设备令牌 ID"
+// @Property			device			body	string	true	"由 User-Agent 识别的设备"
+// @Property			last_used_at	body	int64	true	"最近一次开启记住我或换取 token 的时间"
+// @Property			last_used_ip	body	string	true	"最近一次开启记住我或换取 token 的 IP"
+// @Property			expires_at		body	int64	true	"过期时间，每次换取 token 时顺延"
+// @Property			current			body	bool	true	"是否为发起请求的会话所在的设备"
+// @Property			gmt_create		body	int64	true	"开启记住我的时间"
+type DeviceTokenVo struct {
+	ID         int64  `json:"id"`
+	Device     string `json:"device"`
+	LastUsedAt int64  `json:"last_used_at"`
+	LastUsedIP string `json:"last_used_ip"`
+	ExpiresAt  int64  `json:"expires_at"`
+	Current    bool   `json:"current"`
+	GmtCreate  int64  `json:"gmt_create"`
+}
+
+// DeviceTokenCreatedVo     新签发的设备令牌
+// @Description	完整的设备令牌仅在签发时返回一次，客户端需与设备标识一同保存，会话结束后调用 /account/device/exchangeDeviceToken 换取新的 token
+// @Property			device_token	body	string	true	"完整的设备令牌"
+type DeviceTokenCreatedVo struct {
+	DeviceTokenVo
+	DeviceToken string `json:"device_token"`
+}
@@ -6,8 +6,22 @@ package account
 // @Property			nickname	body	string	true	"用户昵称"
 // @Property			phone	    body	string	true	"用户手机号"
 // @Property			role_code	body	string	true	"用户角色编码"
+// @Property			totp_enabled	body	bool	true	"是否已开启两步验证"
+// @Property			messengers	body	[]string	true	"已绑定即时通讯账号的服务名称"
+// @Property			display_name	body	string	false	"公开资料中展示的名称"
+// @Property			avatar	body	string	false	"头像地址"
+// @Property			bio	body	string	false	"个人简介"
+// @Property			website	body	string	false	"个人网站"
+// @Property			social_links	body	map[string]string	false	"社交链接"
 type GetAccountVo struct {
-	Nickname string `json:"nickname"`
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
+	Nickname    string            `json:"nickname"`
+	Email       string            `json:"email"`
+	Phone       string            `json:"phone"`
+	TotpEnabled bool              `json:"totp_enabled"`
+	Messengers  []string          `json:"messengers"`
+	DisplayName string            `json:"display_name"`
+	Avatar      string            `json:"avatar"`
+	Bio         string            `json:"bio"`
+	Website     string            `json:"website"`
+	SocialLinks map[string]string `json:"social_links"`
 }
@@ -0,0 +1,35 @@
+package account
+
+// InviteCodeVo     邀请码
+// @Description	邀请码及其使用情况
+// @Property			id			body	int64	true	"邀请码 ID"
+// @Property			code		body	string	true	"邀请码"
+// @Property			creator_id	body	int64	true	"创建者的账户 ID"
+// @Property			max_uses	body	int		true	"可使用的次数"
+// @Property			used_count	body	int		true	"已使用的次数"
+// @Property			expires_at	body	int64	true	"过期时间，为 0 时永不过期"
+// @Property			note		body	string	false	"备注"
+// @Property			usable		body	bool	true	"是否仍可用于注册"
+// @Property			gmt_create	body	int64	true	"创建时间"
+type InviteCodeVo struct {
+	ID        int64  `json:"id"`
+	Code      string `json:"code"`
+	CreatorID int64  `json:"creator_id"`
+	MaxUses   int    `json:"max_uses"`
+	UsedCount int    `json:"used_count"`
+	ExpiresAt int64  `json:"expires_at"`
+	Note      string `json:"note,omitempty"`
+	Usable    bool   `json:"usable"`
+	GmtCreate int64  `json:"gmt_create"`
+}
+
+// InviteCheckVo     邀请码校验结果
+// @Description	注册页面据此决定是否展示邀请码输入框，并提前提示邀请码无效
+// @Property			required	body	bool	true	"注册是否需要邀请码"
+// @Property			valid		body	bool	true	"邀请码是否可用于注册，未传邀请码时为 false"
+// @Property			expires_at	body	int64	false	"邀请码的过期时间，为 0 时永不过期"
+type InviteCheckVo struct {
+	Required  bool  `json:"required"`
+	Valid     bool  `json:"valid"`
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,28 @@
+package account
+
+// LoginRecordVo     登录记录
+// @Description	一次成功或失败的登录
+// @Property			id				body	int64	true	"记录 ID"
+// @Property			time			body	int64	true	"登录时间"
+// @Property			method			body	string	true	"登录方式，可选值: password, passkey, magic_link, ldap, device, oauth:{provider}"
+// @Property			second_factor	body	string	false	"两步验证方式，可选值: totp, recovery_code"
+// @Property			success			body	bool	true	"是否登录成功"
+// @Property			fail_reason		body	string	false	"失败原因"
+// @Property			ip				body	string	true	"登录 IP"
+// @Property			location		body	string	false	"IP 对应的地理位置"
+// @Property			device			body	string	true	"由 User-Agent 识别的设备"
+// @Property			user_agent		body	string	true	"登录时的 User-Agent"
+// @Property			new_device		body	bool	true	"是否为首次在该设备上登录成功"
+type LoginRecordVo struct {
+	ID           int64  `json:"id"`
+	Time         int64  `json:"time"`
+	Method       string `json:"method"`
+	SecondFactor string `json:"second_factor,omitempty"`
+	Success      bool   `json:"success"`
+	FailReason   string `json:"fail_reason,omitempty"`
+	IP           string `json:"ip"`
+	Location     string `json:"location,omitempty"`
+	Device       string `json:"device"`
+	UserAgent    string `json:"user_agent"`
+	NewDevice    bool   `json:"new_device"`
+}
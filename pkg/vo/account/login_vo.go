@@ -1,10 +1,20 @@
 package account
 
 // LoginVo           返回给前端的登录信息
-// @Description	登录成功后返回的访问令牌和刷新令牌
-// @Property			access_token	body	string	true	"访问令牌"
-// @Property			refresh_token	body	string	true	"刷新令牌"
+// @Description	登录成功后返回的访问令牌和刷新令牌；账户开启两步验证时令牌为空，需使用 totp_ticket 提交动态码或恢复码完成登录
+// @Property			access_token		body	string	true	"访问令牌"
+// @Property			refresh_token		body	string	true	"刷新令牌"
+// @Property			totp_required		body	bool	true	"是否需要提交两步验证动态码"
+// @Property			totp_ticket			body	string	false	"提交动态码时使用的登录凭证"
+// @Property			totp_setup_required	body	bool	true	"账户角色要求开启两步验证但尚未开启，开启前不能访问管理接口"
+// @Property			deletion_cancelled	body	bool	false	"账户处于注销冷静期，本次登录已撤销注销"
+// @Property			device_token		body	string	false	"使用设备令牌换取 token 时返回轮换后的新设备令牌，旧令牌随即失效"
 type LoginVo struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken       string `json:"access_token"`
+	RefreshToken      string `json:"refresh_token"`
+	TotpRequired      bool   `json:"totp_required"`
+	TotpTicket        string `json:"totp_ticket,omitempty"`
+	TotpSetupRequired bool   `json:"totp_setup_required"`
+	DeletionCancelled bool   `json:"deletion_cancelled,omitempty"`
+	DeviceToken       string `json:"device_token,omitempty"`
 }
@@ -0,0 +1,30 @@
+package account
+
+// MagicLinkSentVo     已发送登录链接
+// @Description	发起请求的设备需保存 request_id，在本设备打开链接时一并提交，或在其他设备确认后凭其轮询登录结果
+// @Property			request_id	body	string	true	"登录请求 ID"
+type MagicLinkSentVo struct {
+	RequestID string `json:"request_id"`
+}
+
+// MagicLinkRequestVo     登录链接对应的登录请求
+// @Description	在其他设备上打开链接时返回，供用户核对后确认登录
+// @Property			email		body	string	true	"脱敏后的账户邮箱"
+// @Property			device		body	string	true	"发起请求的设备"
+// @Property			ip			body	string	true	"发起请求的 IP"
+// @Property			expires_at	body	int64	true	"链接过期时间"
+type MagicLinkRequestVo struct {
+	Email     string `json:"email"`
+	Device    string `json:"device"`
+	IP        string `json:"ip"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// MagicLinkPollVo     登录请求的确认结果
+// @Description	登录请求尚未确认时 approved 为 false，确认后返回登录信息，结果只能领取一次
+// @Property			approved	body	bool	true	"是否已在其他设备上确认"
+// @Property			login		body	object	false	"登录信息"
+type MagicLinkPollVo struct {
+	Approved bool     `json:"approved"`
+	Login    *LoginVo `json:"login,omitempty"`
+}
@@ -0,0 +1,79 @@
+package account
+
+// PasskeyCreationVo     通行密钥注册参数
+// @Description	字段与 WebAuthn 规范的 PublicKeyCredentialCreationOptionsJSON 一致，可直接传给 PublicKeyCredential.parseCreationOptionsFromJSON
+// @Property			challenge				body	string	true	"Base64URL 编码的挑战值"
+// @Property			rp						body	object	true	"依赖方"
+// @Property			user					body	object	true	"当前用户"
+// @Property			pubKeyCredParams		body	array	true	"支持的签名算法"
+// @Property			timeout					body	int		true	"超时时间（毫秒）"
+// @Property			excludeCredentials		body	array	true	"已绑定的凭证，避免在同一认证器上重复注册"
+// @Property			authenticatorSelection	body	object	true	"认证器要求，要求创建可发现凭证"
+// @Property			attestation				body	string	true	"证明方式，固定为 none"
+type PasskeyCreationVo struct {
+	Challenge              string                          `json:"challenge"`
+	RP                     PasskeyRPVo                     `json:"rp"`
+	User                   PasskeyUserVo                   `json:"user"`
+	PubKeyCredParams       []PasskeyCredParamVo            `json:"pubKeyCredParams"`
+	Timeout                int                             `json:"timeout"`
+	ExcludeCredentials     []PasskeyDescriptorVo           `json:"excludeCredentials"`
+	AuthenticatorSelection PasskeyAuthenticatorSelectionVo `json:"authenticatorSelection"`
+	Attestation            string                          `json:"attestation"`
+}
+
+// PasskeyRequestVo     通行密钥登录参数
+// @Description	字段与 WebAuthn 规范的 PublicKeyCredentialRequestOptionsJSON 一致，可直接传给 PublicKeyCredential.parseRequestOptionsFromJSON
+// @Property			challenge			body	string	true	"Base64URL 编码的挑战值"
+// @Property			timeout				body	int		true	"超时时间（毫秒）"
+// @Property			rpId				body	string	true	"依赖方 ID"
+// @Property			userVerification	body	string	true	"用户验证要求"
+type PasskeyRequestVo struct {
+	Challenge        string `json:"challenge"`
+	Timeout          int    `json:"timeout"`
+	RPID             string `json:"rpId"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PasskeyRPVo     依赖方
+type PasskeyRPVo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PasskeyUserVo     通行密钥关联的用户
+type PasskeyUserVo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// PasskeyCredParamVo     签名算法
+type PasskeyCredParamVo struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// PasskeyDescriptorVo     凭证描述
+type PasskeyDescriptorVo struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// PasskeyAuthenticatorSelectionVo     认证器要求
+type PasskeyAuthenticatorSelectionVo struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PasskeyVo     已绑定的通行密钥
+// @Description	当前账户绑定的通行密钥
+// @Property			id				body	int64	true	"通行密钥 ID"
+// @Property			name			body	string	true	"通行密钥名称"
+// @Property			gmt_create		body	int64	true	"绑定时间"
+// @Property			last_used_at	body	int64	true	"最近一次登录时间，未使用过时为 0"
+type PasskeyVo struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	GmtCreate  int64  `json:"gmt_create"`
+	LastUsedAt int64  `json:"last_used_at"`
+}
@@ -0,0 +1,18 @@
+package account
+
+// PasswordPolicyVo     密码强度策略
+// @Description	注册、修改密码与重置密码时服务端校验的密码规则，供前端提示与预校验
+// @Property			min_length			body	int			true	"最小长度"
+// @Property			max_length			body	int			true	"最大长度"
+// @Property			min_char_classes	body	int			true	"至少包含的字符类型数，字符类型为小写字母、大写字母、数字、符号"
+// @Property			require_classes		body	[]string	true	"必须包含的字符类型，可选值: lower, upper, digit, symbol"
+// @Property			ban_common			body	bool		true	"是否拒绝常见弱密码"
+// @Property			disallow_email		body	bool		true	"是否拒绝使用邮箱或邮箱用户名作为密码"
+type PasswordPolicyVo struct {
+	MinLength      int      `json:"min_length"`
+	MaxLength      int      `json:"max_length"`
+	MinCharClasses int      `json:"min_char_classes"`
+	RequireClasses []string `json:"require_classes"`
+	BanCommon      bool     `json:"ban_common"`
+	DisallowEmail  bool     `json:"disallow_email"`
+}
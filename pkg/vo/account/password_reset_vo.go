@@ -0,0 +1,10 @@
+package account
+
+// PasswordResetLinkVo     重置密码链接信息
+// @Description	重置密码链接有效时返回，供前端展示
+// @Property			email		body	string	true	"脱敏后的账户邮箱"
+// @Property			expires_at	body	int64	true	"链接过期时间"
+type PasswordResetLinkVo struct {
+	Email     string `json:"email"`
+	ExpiresAt int64  `json:"expires_at"`
+}
@@ -0,0 +1,10 @@
+package account
+
+// PreferencesVo     用户偏好设置
+// @Description	当前用户的偏好设置
+// @Property			reading_history	body	bool	true	"是否记录阅读历史"
+// @Property			login_alert		body	bool	true	"是否接收新设备登录提醒邮件"
+type PreferencesVo struct {
+	ReadingHistory bool `json:"reading_history"`
+	LoginAlert     bool `json:"login_alert"`
+}
@@ -0,0 +1,28 @@
+package account
+
+// ProfileVo     公开资料
+// @Description	任何人可见的账户资料，不包含邮箱、手机号等私密信息
+// @Property			id				body	int64				true	"账户 ID"
+// @Property			nickname		body	string				true	"昵称"
+// @Property			display_name	body	string				false	"展示名称"
+// @Property			avatar			body	string				false	"头像地址"
+// @Property			bio				body	string				false	"个人简介"
+// @Property			website			body	string				false	"个人网站"
+// @Property			social_links	body	map[string]string	false	"社交链接，键为平台名称"
+// @Property			joined_at		body	int64				true	"注册时间"
+type ProfileVo struct {
+	ID          int64             `json:"id"`
+	Nickname    string            `json:"nickname"`
+	DisplayName string            `json:"display_name,omitempty"`
+	Avatar      string            `json:"avatar,omitempty"`
+	Bio         string            `json:"bio,omitempty"`
+	Website     string            `json:"website,omitempty"`
+	SocialLinks map[string]string `json:"social_links,omitempty"`
+	JoinedAt    int64             `json:"joined_at"`
+}
+
+// AvatarVo     上传头像结果
+// @Property			avatar	body	string	true	"新头像的地址"
+type AvatarVo struct {
+	Avatar string `json:"avatar"`
+}
@@ -5,7 +5,9 @@ package account
 // @Property			email	    body	string	true	"用户邮箱"
 // @Property			nickname	body	string	true	"用户昵称"
 // @Property			role_code	body	string	true	"用户角色编码"
+// @Property			pending_approval	body	bool	false	"账户是否需要等待管理员审核通过后才能登录"
 type RegisterAccountVo struct {
-	Nickname string `json:"nickname"`
-	Email    string `json:"email"`
+	Nickname        string `json:"nickname"`
+	Email           string `json:"email"`
+	PendingApproval bool   `json:"pending_approval,omitempty"`
 }
@@ -0,0 +1,27 @@
+package account
+
+// SessionVo     登录会话
+// @Description	当前账户在各设备上的登录会话
+// @Property			session_id		body	string	true	"会话 ID"
+// @Property			device			body	string	true	"由 User-Agent 识别的设备"
+// @Property			user_agent		body	string	true	"登录时的 User-Agent"
+// @Property			ip				body	string	true	"最近一次访问的 IP"
+// @Property			created_at		body	int64	true	"登录时间"
+// @Property			last_seen_at	body	int64	true	"最近一次访问时间"
+// @Property			current			body	bool	true	"是否为发起请求的会话"
+type SessionVo struct {
+	SessionID  string `json:"session_id"`
+	Device     string `json:"device"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	Current    bool   `json:"current"`
+}
+
+// RevokeSessionsVo     移除会话结果
+// @Description	移除其他全部会话的结果
+// @Property			revoked	body	int	true	"移除的会话数量"
+type RevokeSessionsVo struct {
+	Revoked int `json:"revoked"`
+}
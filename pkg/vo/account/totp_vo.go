@@ -0,0 +1,19 @@
+package account
+
+// TotpSetupVo     两步验证绑定信息
+// @Description	验证器扫码或手动输入密钥绑定后，提交动态码开启两步验证
+// @Property			secret		body	string	true	"Base32 编码的 TOTP 密钥"
+// @Property			otpauth_url	body	string	true	"供验证器扫码的 otpauth 地址"
+// @Property			expires_in	body	int		true	"绑定信息的有效秒数，过期前需提交动态码"
+type TotpSetupVo struct {
+	Secret     string `json:"secret"`
+	OtpauthURL string `json:"otpauth_url"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+// TotpRecoveryCodesVo     两步验证恢复码
+// @Description	恢复码仅展示一次，验证器丢失时可代替动态码登录，每个恢复码只能使用一次，重新生成后旧恢复码失效
+// @Property			recovery_codes	body	[]string	true	"恢复码"
+type TotpRecoveryCodesVo struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
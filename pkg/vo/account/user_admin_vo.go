@@ -0,0 +1,126 @@
+package account
+
+// AdminUserVo     用户管理中的用户
+// @Description	用户列表中的一个账户
+// @Property			id						body	int64	true	"账户 ID"
+// @Property			email					body	string	true	"邮箱"
+// @Property			phone					body	string	false	"手机号"
+// @Property			nickname				body	string	true	"昵称"
+// @Property			display_name			body	string	false	"展示名称"
+// @Property			avatar					body	string	true	"头像地址"
+// @Property			role_code				body	string	false	"角色编码"
+// @Property			totp_enabled			body	bool	true	"是否已开启两步验证"
+// @Property			status					body	string	true	"账户状态，可选值: active, disabled, pending_deletion, pending_approval, rejected"
+// @Property			disabled_at				body	int64	false	"停用时间"
+// @Property			disabled_until			body	int64	false	"限时停用的到期时间，永久停用时不返回"
+// @Property			disabled_reason			body	string	false	"停用原因"
+// @Property			deletion_scheduled_at	body	int64	false	"申请注销后永久删除的时间"
+// @Property			approval_reason			body	string	false	"拒绝注册的原因"
+// @Property			created_at				body	int64	true	"注册时间"
+type AdminUserVo struct {
+	ID                  int64  `json:"id"`
+	Email               string `json:"email"`
+	Phone               string `json:"phone,omitempty"`
+	Nickname            string `json:"nickname"`
+	DisplayName         string `json:"display_name,omitempty"`
+	Avatar              string `json:"avatar"`
+	RoleCode            string `json:"role_code,omitempty"`
+	TotpEnabled         bool   `json:"totp_enabled"`
+	Status              string `json:"status"`
+	DisabledAt          int64  `json:"disabled_at,omitempty"`
+	DisabledUntil       int64  `json:"disabled_until,omitempty"`
+	DisabledReason      string `json:"disabled_reason,omitempty"`
+	DeletionScheduledAt int64  `json:"deletion_scheduled_at,omitempty"`
+	ApprovalReason      string `json:"approval_reason,omitempty"`
+	CreatedAt           int64  `json:"created_at"`
+}
+
+// AdminUserDetailVo     用户管理中的用户详情
+// @Description	账户资料、状态、登录方式与最近的登录记录
+// @Property			bio					body	string				false	"个人简介"
+// @Property			website				body	string				false	"个人网站"
+// @Property			social_links		body	map[string]string	false	"社交链接"
+// @Property			messengers			body	[]string			true	"已绑定即时通讯账号的服务名称"
+// @Property			linked_accounts		body	[]string			true	"已关联的第三方登录服务"
+// @Property			passkeys			body	int					true	"绑定的通行密钥数量"
+// @Property			api_keys			body	int					true	"API Key 数量"
+// @Property			active_sessions		body	int					true	"未结束的登录会话数量"
+// @Property			recent_logins		body	[]LoginRecordVo		true	"最近的登录记录"
+type AdminUserDetailVo struct {
+	AdminUserVo
+	Bio            string            `json:"bio,omitempty"`
+	Website        string            `json:"website,omitempty"`
+	SocialLinks    map[string]string `json:"social_links,omitempty"`
+	Messengers     []string          `json:"messengers"`
+	LinkedAccounts []string          `json:"linked_accounts"`
+	Passkeys       int               `json:"passkeys"`
+	APIKeys        int               `json:"api_keys"`
+	ActiveSessions int               `json:"active_sessions"`
+	RecentLogins   []*LoginRecordVo  `json:"recent_logins"`
+}
+
+// ForceLogoutVo     强制下线结果
+// @Property			sessions	body	int	true	"结束的会话数量"
+type ForceLogoutVo struct {
+	Sessions int `json:"sessions"`
+}
+
+// AdminAuditLogVo     管理员操作审计日志
+// @Property			id			body	int64	true	"日志 ID"
+// @Property			time		body	int64	true	"操作时间"
+// @Property			operator_id	body	int64	true	"执行操作的管理员账户 ID"
+// @Property			target_id	body	int64	true	"被操作的账户 ID"
+// @Property			action		body	string	true	"操作，可选值: reset_password, force_logout, change_role, disable, enable, ban_appeal, approve, reject, registration"
+// @Property			detail		body	string	false	"操作详情"
+// @Property			ip			body	string	true	"管理员的客户端 IP"
+type AdminAuditLogVo struct {
+	ID         int64  `json:"id"`
+	Time       int64  `json:"time"`
+	OperatorID int64  `json:"operator_id"`
+	TargetID   int64  `json:"target_id"`
+	Action     string `json:"action"`
+	Detail     string `json:"detail,omitempty"`
+	IP         string `json:"ip"`
+}
+
+// AccountDisabledVo     账户已停用、待审核或未通过注册审核
+// @Property			reason	body	string	false	"管理员填写的停用原因或拒绝注册的原因"
+// @Property			until	body	int64	false	"限时停用的到期时间，永久停用时不返回"
+type AccountDisabledVo struct {
+	Reason string `json:"reason,omitempty"`
+	Until  int64  `json:"until,omitempty"`
+}
+
+// AccountBanVo     账户停用记录
+// @Property			id			body	int64	true	"停用记录 ID"
+// @Property			account_id	body	int64	true	"被停用的账户 ID"
+// @Property			operator_id	body	int64	true	"执行停用的管理员账户 ID"
+// @Property			reason		body	string	false	"停用原因"
+// @Property			created_at	body	int64	true	"停用时间"
+// @Property			expires_at	body	int64	false	"到期自动解除的时间，永久停用时不返回"
+// @Property			lifted_at	body	int64	false	"解除停用的时间，仍在停用中时不返回"
+// @Property			lifted_by	body	int64	false	"解除停用的管理员账户 ID，到期自动解除时不返回"
+// @Property			lift_reason	body	string	false	"解除原因"
+// @Property			appeal_note	body	string	false	"用户的申诉内容与处理情况"
+type AccountBanVo struct {
+	ID         int64  `json:"id"`
+	AccountID  int64  `json:"account_id"`
+	OperatorID int64  `json:"operator_id"`
+	Reason     string `json:"reason,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	LiftedAt   int64  `json:"lifted_at,omitempty"`
+	LiftedBy   int64  `json:"lifted_by,omitempty"`
+	LiftReason string `json:"lift_reason,omitempty"`
+	AppealNote string `json:"appeal_note,omitempty"`
+}
+
+// RegistrationSettingsVo     注册设置
+// @Property			mode			body	string	true	"注册方式，可选值: open, approval, closed"
+// @Property			notify_admins	body	bool	true	"有新注册待审核时是否邮件通知管理员"
+// @Property			pending			body	int64	true	"待审核的用户数量"
+type RegistrationSettingsVo struct {
+	Mode         string `json:"mode"`
+	NotifyAdmins bool   `json:"notify_admins"`
+	Pending      int64  `json:"pending"`
+}
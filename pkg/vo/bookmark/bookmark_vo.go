@@ -0,0 +1,25 @@
+package bookmark
+
+// BookmarkVo    收藏的响应结构
+// @Description	用户收藏的文章
+// @Property			post_id			body	int64	true	"文章 ID"
+// @Property			title			body	string	true	"文章标题"
+// @Property			image			body	string	true	"文章封面图片 URL"
+// @Property			bookmarked_at	body	int64	true	"收藏时间"
+type BookmarkVo struct {
+	PostID       int64  `json:"post_id"`
+	Title        string `json:"title"`
+	Image        string `json:"image"`
+	BookmarkedAt int64  `json:"bookmarked_at"`
+}
+
+// BookmarkStateVo    文章收藏状态的响应结构
+// @Description	文章的收藏数与当前用户的收藏状态
+// @Property			post_id			body	int64	true	"文章 ID"
+// @Property			bookmark_count	body	int64	true	"收藏数"
+// @Property			bookmarked		body	bool	true	"当前用户是否已收藏"
+type BookmarkStateVo struct {
+	PostID        int64 `json:"post_id"`
+	BookmarkCount int64 `json:"bookmark_count"`
+	Bookmarked    bool  `json:"bookmarked"`
+}
@@ -3,7 +3,8 @@ package comment
 // CommentsVo 获取评论响应
 // @Description 获取单个评论的响应
 // @Property id                  body int64  			 true  "评论唯一标识"
-// @Property content             body string  			 true  "评论内容"
+// @Property content             body string  			 true  "评论内容，Markdown 格式"
+// @Property content_html        body string  			 true  "渲染并过滤后的评论 HTML"
 // @Property user_id             body int64             true  "评论所属用户ID"
 // @Property post_id             body int64             true  "评论所属文章ID"
 // @Property reply_to_comment_id body int64             false "回复的目标评论ID"
@@ -11,6 +12,7 @@ package comment
 type CommentsVo struct {
 	ID               int64         `json:"id"`
 	Content          string        `json:"content"`
+	ContentHTML      string        `json:"content_html"`
 	UserId           int64         `json:"user_id"`
 	PostId           int64         `json:"post_id"`
 	ReplyToCommentId int64         `json:"reply_to_comment_id"`
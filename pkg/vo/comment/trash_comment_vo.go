@@ -0,0 +1,22 @@
+package comment
+
+// TrashCommentVo 回收站中的评论
+// @Description 已删除、尚未被永久删除的评论，时间均为秒级时间戳
+// @Property id                  body int64  true  "评论 ID"
+// @Property content             body string true  "评论内容，Markdown 格式"
+// @Property user_id             body int64  true  "评论所属用户 ID"
+// @Property post_id             body int64  true  "评论所属文章 ID"
+// @Property reply_to_comment_id body int64  false "回复的目标评论 ID"
+// @Property gmt_create          body int64  true  "评论时间"
+// @Property deleted_at          body int64  true  "删除时间"
+// @Property purge_at            body int64  true  "预计永久删除的时间，清理任务每日执行，实际删除可能晚于该时间；不清理时为 0"
+type TrashCommentVo struct {
+	ID               int64  `json:"id"`
+	Content          string `json:"content"`
+	UserId           int64  `json:"user_id"`
+	PostId           int64  `json:"post_id"`
+	ReplyToCommentId int64  `json:"reply_to_comment_id"`
+	GmtCreate        int64  `json:"gmt_create"`
+	DeletedAt        int64  `json:"deleted_at"`
+	PurgeAt          int64  `json:"purge_at"`
+}
@@ -0,0 +1,14 @@
+package disposable
+
+// DisposableEmailVo     一次性邮箱域名黑名单状态
+// @Description	黑名单的来源域名数与管理员维护的域名，时间为秒级时间戳
+// @Property			loaded			body	int			true	"内置、文件与远程来源的域名数"
+// @Property			blocked			body	[]string	true	"管理员添加的域名"
+// @Property			allowed			body	[]string	true	"管理员从黑名单中移除的域名"
+// @Property			refreshed_at	body	int64		true	"上次刷新文件与远程列表的时间，未刷新过时为 0"
+type DisposableEmailVo struct {
+	Loaded      int      `json:"loaded"`
+	Blocked     []string `json:"blocked"`
+	Allowed     []string `json:"allowed"`
+	RefreshedAt int64    `json:"refreshed_at"`
+}
@@ -0,0 +1,16 @@
+package errcode
+
+// ErrorCodeVo     错误码定义
+// @Description	业务错误码及其对应的 HTTP 状态码、默认错误信息与国际化资源键
+// @Property			code			body	int		true	"业务错误码"
+// @Property			http_status		body	int		true	"对应的 HTTP 状态码"
+// @Property			message			body	string	true	"默认错误信息"
+// @Property			i18n_key		body	string	true	"国际化资源键"
+// @Property			description		body	string	false	"错误码说明"
+type ErrorCodeVo struct {
+	Code        int    `json:"code"`
+	HTTPStatus  int    `json:"http_status"`
+	Message     string `json:"message"`
+	I18nKey     string `json:"i18n_key"`
+	Description string `json:"description"`
+}
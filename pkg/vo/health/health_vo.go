@@ -0,0 +1,31 @@
+package health
+
+// HealthVo     服务健康状态
+// @Description	数据库、Redis、缓存驱动与邮件发送熔断器的状态
+// @Property			status			body	string				true	"整体状态，可选值: ok, degraded, down"
+// @Property			database		body	bool				true	"数据库是否可用"
+// @Property			redis			body	bool				true	"Redis 是否可用"
+// @Property			cache			body	string				true	"当前使用的缓存驱动，可选值: redis, memory"
+// @Property			mail_breaker	body	MailBreakerVo		true	"邮件发送熔断器状态"
+// @Property			errors			body	map[string]string	false	"不可用组件的错误信息"
+type HealthVo struct {
+	Status      string            `json:"status"`
+	Database    bool              `json:"database"`
+	Redis       bool              `json:"redis"`
+	Cache       string            `json:"cache"`
+	MailBreaker MailBreakerVo     `json:"mail_breaker"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// MailBreakerVo     邮件发送熔断器状态
+// @Description	熔断器的当前状态与连续失败次数，时间为秒级时间戳
+// @Property			state		body	string	true	"熔断状态，可选值: closed, open, half_open"
+// @Property			failures	body	int		true	"连续发送失败次数"
+// @Property			opened_at	body	int64	true	"最近一次熔断的时间，未熔断过时为 0"
+// @Property			retry_at	body	int64	true	"熔断中允许探测发送的时间，未熔断时为 0"
+type MailBreakerVo struct {
+	State    string `json:"state"`
+	Failures int    `json:"failures"`
+	OpenedAt int64  `json:"opened_at"`
+	RetryAt  int64  `json:"retry_at"`
+}
@@ -0,0 +1,16 @@
+package history
+
+// ReadingHistoryVo    阅读历史的响应结构
+// @Description	用户读过的文章及上次阅读位置
+// @Property			post_id		body	int64	true	"文章 ID"
+// @Property			title		body	string	true	"文章标题"
+// @Property			image		body	string	true	"文章封面图片 URL"
+// @Property			position	body	int64	true	"上次阅读位置"
+// @Property			read_at		body	int64	true	"最近阅读时间"
+type ReadingHistoryVo struct {
+	PostID   int64  `json:"post_id"`
+	Title    string `json:"title"`
+	Image    string `json:"image"`
+	Position int64  `json:"position"`
+	ReadAt   int64  `json:"read_at"`
+}
@@ -0,0 +1,24 @@
+package importer
+
+// ImportReportVo     内容导入结果
+// @Description	导入完成后各类数据的写入数量
+// @Property			format		body	string	true	"导入格式"
+// @Property			posts		body	int	true	"导入文章数"
+// @Property			categories	body	int	true	"新建类目数"
+// @Property			comments	body	int	true	"导入评论数"
+// @Property			media		body	int	true	"文章引用的媒体文件数，媒体文件不下载，地址记录在文章的 ext.import.media 中"
+// @Property			skipped		body	int	true	"跳过条目数"
+// @Property			warnings	body	[]string	false	"跳过原因"
+// @Property			dry_run		body	bool	true	"是否为试运行，试运行时上述数量为预计写入的数量，数据未写入"
+// @Property			elapsed_ms	body	int64	true	"耗时（毫秒）"
+type ImportReportVo struct {
+	Format     string   `json:"format"`
+	Posts      int      `json:"posts"`
+	Categories int      `json:"categories"`
+	Comments   int      `json:"comments"`
+	Media      int      `json:"media"`
+	Skipped    int      `json:"skipped"`
+	Warnings   []string `json:"warnings"`
+	DryRun     bool     `json:"dry_run"`
+	ElapsedMs  int64    `json:"elapsed_ms"`
+}
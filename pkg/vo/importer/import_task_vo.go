@@ -0,0 +1,26 @@
+package importer
+
+// ImportTaskVo     后台导入任务
+// @Description	后台导入任务的状态与进度，任务结束后保留 24 小时，时间均为秒级时间戳
+// @Property			id			body	string	true	"任务 ID"
+// @Property			format		body	string	true	"导入格式"
+// @Property			dry_run		body	bool	true	"是否为试运行，试运行只生成导入报告，不写入数据"
+// @Property			status		body	string	true	"任务状态，可选值: running, succeeded, failed"
+// @Property			total		body	int	true	"待导入的文章总数，解析导入源完成前为 0"
+// @Property			done		body	int	true	"已处理的文章数"
+// @Property			report		body	ImportReportVo	false	"导入报告，任务成功后返回"
+// @Property			error		body	string	false	"失败原因"
+// @Property			started_at	body	int64	true	"开始时间"
+// @Property			finished_at	body	int64	false	"结束时间，运行中为 0"
+type ImportTaskVo struct {
+	ID         string          `json:"id"`
+	Format     string          `json:"format"`
+	DryRun     bool            `json:"dry_run"`
+	Status     string          `json:"status"`
+	Total      int             `json:"total"`
+	Done       int             `json:"done"`
+	Report     *ImportReportVo `json:"report,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	StartedAt  int64           `json:"started_at"`
+	FinishedAt int64           `json:"finished_at"`
+}
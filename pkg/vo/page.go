@@ -0,0 +1,130 @@
+package vo
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	DefaultPageSize = 10  // 默认每页数量
+	MaxPageSize     = 100 // 每页数量上限
+	cursorPrefix    = "o:"
+)
+
+// PageRequest 分页请求参数
+// 支持 page/pageSize 页码分页与 cursor 游标分页，同时传入时以 cursor 为准
+type PageRequest struct {
+	Page     int    // 页码，从 1 开始
+	PageSize int    // 每页数量，超过 MaxPageSize 时截断
+	Cursor   string // 上一页返回的游标
+	offset   int
+}
+
+// PageMeta 分页响应元数据
+type PageMeta struct {
+	Page       int    `json:"page"`                 // 当前页码
+	PageSize   int    `json:"pageSize"`             // 每页数量
+	Total      int64  `json:"total"`                // 总条数
+	TotalPages int    `json:"totalPages"`           // 总页数
+	HasNext    bool   `json:"hasNext"`              // 是否存在下一页
+	NextCursor string `json:"nextCursor,omitempty"` // 下一页游标，不存在下一页时为空
+}
+
+// ParsePage 从查询参数中解析分页参数，pageSize 兼容 size 写法
+func ParsePage(c echo.Context, defaultPageSize ...int) PageRequest {
+	size := DefaultPageSize
+	if len(defaultPageSize) > 0 && defaultPageSize[0] > 0 {
+		size = defaultPageSize[0]
+	}
+
+	req := PageRequest{Cursor: c.QueryParam("cursor")}
+	req.Page, _ = strconv.Atoi(c.QueryParam("page"))
+	req.PageSize, _ = strconv.Atoi(c.QueryParam("pageSize"))
+	if req.PageSize == 0 {
+		req.PageSize, _ = strconv.Atoi(c.QueryParam("size"))
+	}
+	return req.normalize(size)
+}
+
+// normalize 规范化分页参数并计算偏移量
+func (p PageRequest) normalize(defaultPageSize int) PageRequest {
+	if p.PageSize < 1 {
+		p.PageSize = defaultPageSize
+	}
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	p.offset = (p.Page - 1) * p.PageSize
+
+	if offset, ok := decodeCursor(p.Cursor); ok {
+		p.offset = offset
+		p.Page = offset/p.PageSize + 1
+	}
+	return p
+}
+
+// Offset 查询偏移量
+func (p PageRequest) Offset() int {
+	return p.offset
+}
+
+// Limit 查询数量
+func (p PageRequest) Limit() int {
+	return p.PageSize
+}
+
+// NewPageMeta 根据分页参数与总条数生成分页元数据
+func NewPageMeta(req PageRequest, total int64) *PageMeta {
+	meta := &PageMeta{
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		Total:      total,
+		TotalPages: int((total + int64(req.PageSize) - 1) / int64(req.PageSize)),
+	}
+
+	next := req.offset + req.PageSize
+	if int64(next) < total {
+		meta.HasNext = true
+		meta.NextCursor = encodeCursor(next)
+	}
+	return meta
+}
+
+// SuccessWithPage 分页列表成功返回
+func SuccessWithPage(data interface{}, meta *PageMeta, c echo.Context) Result {
+	return Result{
+		Err:       nil,
+		Data:      data,
+		Page:      meta,
+		RequestId: c.Response().Header().Get(echo.HeaderXRequestID),
+		TimeStamp: time.Now().Unix(),
+	}
+}
+
+// encodeCursor 将偏移量编码为不透明游标
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor 解析游标中的偏移量，游标无效时返回 false
+func decodeCursor(cursor string) (int, bool) {
+	if cursor == "" {
+		return 0, false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, false
+	}
+	return offset, true
+}
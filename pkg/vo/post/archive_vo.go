@@ -0,0 +1,47 @@
+package post
+
+// ArchiveVo    文章归档的响应结构
+// @Description	按年月统计的已发布文章数量
+// @Property			year	body	int		true	"年份"
+// @Property			month	body	int		true	"月份"
+// @Property			count	body	int64	true	"文章数量"
+type ArchiveVo struct {
+	Year  int   `json:"year"`
+	Month int   `json:"month"`
+	Count int64 `json:"count"`
+}
+
+// ArchiveYearVo    按年分组的文章归档
+// @Description	某一年已发布的文章，按月分组，月份按时间倒序排列
+// @Property			year	body	int					true	"年份"
+// @Property			count	body	int					true	"全年文章数量"
+// @Property			months	body	[]ArchiveMonthVo	true	"按月分组的文章"
+type ArchiveYearVo struct {
+	Year   int               `json:"year"`
+	Count  int               `json:"count"`
+	Months []*ArchiveMonthVo `json:"months"`
+}
+
+// ArchiveMonthVo    按月分组的文章归档
+// @Description	某一月已发布的文章，按创建时间倒序排列
+// @Property			month	body	int					true	"月份"
+// @Property			count	body	int					true	"文章数量"
+// @Property			posts	body	[]ArchivePostVo	true	"文章列表"
+type ArchiveMonthVo struct {
+	Month int              `json:"month"`
+	Count int              `json:"count"`
+	Posts []*ArchivePostVo `json:"posts"`
+}
+
+// ArchivePostVo    归档中的文章
+// @Description	归档页展示的文章标题与地址
+// @Property			id			body	int64	true	"文章 ID"
+// @Property			title		body	string	true	"文章标题"
+// @Property			slug		body	string	true	"文章别名"
+// @Property			gmt_create	body	int64	true	"创建时间"
+type ArchivePostVo struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	GmtCreate int64  `json:"gmt_create"`
+}
@@ -0,0 +1,22 @@
+package post
+
+// DraftVo    自动保存的草稿
+// @Description	编辑器自动保存的草稿内容与版本信息
+// @Property			post_id				body	int64		true	"文章 ID，新文章为 0"
+// @Property			title				body	string		true	"草稿标题"
+// @Property			image				body	string		true	"草稿图片"
+// @Property			content_markdown	body	string		true	"草稿内容(markdown格式)"
+// @Property			category_ids		body	[]int64		true	"草稿分类ID列表"
+// @Property			version				body	int64		true	"草稿版本号，下次保存时携带"
+// @Property			saved_at			body	int64		true	"保存时间"
+// @Property			stale				body	bool		true	"开始编辑后文章是否已被更新"
+type DraftVo struct {
+	PostID          int64   `json:"post_id"`
+	Title           string  `json:"title"`
+	Image           string  `json:"image"`
+	ContentMarkdown string  `json:"content_markdown"`
+	CategoryIDs     []int64 `json:"category_ids"`
+	Version         int64   `json:"version"`
+	SavedAt         int64   `json:"saved_at"`
+	Stale           bool    `json:"stale"`
+}
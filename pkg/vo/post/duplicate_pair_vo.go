@@ -0,0 +1,16 @@
+package post
+
+// DuplicatePairVo    疑似重复的文章对
+// @Description	内容指纹相近的两篇文章，距离越小内容越接近
+// @Property			post_id				body	int64	true	"文章 ID"
+// @Property			post_title			body	string	true	"文章标题"
+// @Property			duplicate_id		body	int64	true	"疑似重复的文章 ID"
+// @Property			duplicate_title		body	string	true	"疑似重复的文章标题"
+// @Property			distance			body	int		true	"内容指纹的汉明距离，0 表示内容几乎完全相同"
+type DuplicatePairVo struct {
+	PostID         int64  `json:"post_id"`
+	PostTitle      string `json:"post_title"`
+	DuplicateID    int64  `json:"duplicate_id"`
+	DuplicateTitle string `json:"duplicate_title"`
+	Distance       int    `json:"distance"`
+}
@@ -0,0 +1,24 @@
+package post
+
+// EditLockVo    文章编辑锁
+// @Description	当前正在编辑文章的账户，锁需按心跳间隔续期，过期后自动释放
+// @Property			post_id				body	int64	true	"文章 ID"
+// @Property			account_id			body	int64	true	"持有锁的账户 ID"
+// @Property			nickname			body	string	true	"持有锁的账户昵称"
+// @Property			acquired_at			body	int64	true	"加锁时间"
+// @Property			heartbeat_at		body	int64	true	"最近一次续期时间"
+// @Property			expires_at			body	int64	true	"过期时间"
+// @Property			heartbeat_interval	body	int		true	"建议的续期间隔（秒）"
+// @Property			taken_over_from		body	int64	false	"被接管前的持有者账户 ID"
+// @Property			mine				body	bool	true	"是否由当前账户持有"
+type EditLockVo struct {
+	PostID            int64  `json:"post_id"`
+	AccountID         int64  `json:"account_id"`
+	Nickname          string `json:"nickname"`
+	AcquiredAt        int64  `json:"acquired_at"`
+	HeartbeatAt       int64  `json:"heartbeat_at"`
+	ExpiresAt         int64  `json:"expires_at"`
+	HeartbeatInterval int    `json:"heartbeat_interval"`
+	TakenOverFrom     int64  `json:"taken_over_from,omitempty"`
+	Mine              bool   `json:"mine"`
+}
@@ -0,0 +1,18 @@
+package post
+
+// LinkPreviewVo    外部链接预览的响应结构
+// @Description	文章中引用的外部链接的标题、描述与图片，用于渲染链接卡片
+// @Property			url			body	string	true	"链接地址"
+// @Property			title		body	string	true	"标题"
+// @Property			description	body	string	true	"描述"
+// @Property			image		body	string	true	"预览图片地址"
+// @Property			site_name	body	string	true	"站点名称"
+// @Property			error		body	string	false	"抓取失败的原因，成功时为空"
+type LinkPreviewVo struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	SiteName    string `json:"site_name"`
+	Error       string `json:"error"`
+}
@@ -0,0 +1,12 @@
+package post
+
+// PostAccessTokenVo    文章访问令牌
+// @Description	密码保护的文章解锁后签发的访问令牌，访问文章时通过 X-Post-Access-Token 请求头携带，多个令牌以逗号分隔
+// @Property			post_id			body	int64	true	"文章 ID"
+// @Property			access_token	body	string	true	"访问令牌，仅对该文章有效，修改访问密码后失效"
+// @Property			expires_at		body	int64	true	"过期时间"
+type PostAccessTokenVo struct {
+	PostID      int64  `json:"post_id"`
+	AccessToken string `json:"access_token"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
@@ -0,0 +1,34 @@
+package post
+
+import "jank.com/jank_blog/pkg/vo/account"
+
+// PostAuthorVo    文章署名作者
+// @Description	文章的署名作者，按署名顺序排列
+// @Property			id				body	int64	true	"作者账户 ID"
+// @Property			nickname		body	string	true	"昵称"
+// @Property			display_name	body	string	false	"展示名称"
+// @Property			avatar			body	string	false	"头像地址"
+// @Property			role			body	string	true	"署名角色，可选值: author, editor"
+type PostAuthorVo struct {
+	ID          int64  `json:"id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name,omitempty"`
+	Avatar      string `json:"avatar,omitempty"`
+	Role        string `json:"role"`
+}
+
+// AuthorVo    作者页资料
+// @Description	作者的公开资料与署名的已发布文章数量
+// @Property			id				body	int64				true	"作者账户 ID"
+// @Property			nickname		body	string				true	"昵称"
+// @Property			display_name	body	string				false	"展示名称"
+// @Property			avatar			body	string				false	"头像地址"
+// @Property			bio				body	string				false	"个人简介"
+// @Property			website			body	string				false	"个人网站"
+// @Property			social_links	body	map[string]string	false	"社交链接，键为平台名称"
+// @Property			joined_at		body	int64				true	"注册时间"
+// @Property			post_count		body	int64				true	"署名的已发布文章数量，包括以编辑署名的文章"
+type AuthorVo struct {
+	account.ProfileVo
+	PostCount int64 `json:"post_count"`
+}
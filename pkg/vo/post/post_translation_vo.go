@@ -0,0 +1,16 @@
+package post
+
+// PostTranslationVo    文章翻译
+// @Description	翻译组中的一篇文章，用于生成 hreflang 链接
+// @Property			id			body	int64	true	"文章 ID"
+// @Property			language	body	string	true	"文章语言，BCP 47 语言标签，可直接作为 hreflang"
+// @Property			title		body	string	true	"文章标题"
+// @Property			slug		body	string	true	"文章别名"
+// @Property			canonical	body	bool	true	"是否为翻译组的原文，可作为 x-default"
+type PostTranslationVo struct {
+	ID        int64  `json:"id"`
+	Language  string `json:"language"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Canonical bool   `json:"canonical"`
+}
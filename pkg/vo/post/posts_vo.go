@@ -1,19 +1,71 @@
 package post
 
+import "jank.com/jank_blog/pkg/vo/series"
+
 // PostsVo    获取帖子的响应结构
 // @Description	获取帖子时返回的响应数据
 // @Property			id			    	body	int64	true	"帖子唯一标识"
 // @Property			title			    body	string	true	"帖子标题"
+// @Property			slug			    body	string	true	"帖子别名，用于文章地址"
 // @Property			image			    body	string	true	"帖子封面图片 URL"
-// @Property			visibility		    body	bool	true	"帖子可见性状态"
+// @Property			visibility		    body	bool	true	"帖子可见性状态，仅 published 状态为 true"
+// @Property			status			    body	string	true	"发布状态，可选值: draft, published, archived"
+// @Property			access			    body	string	true	"访问权限，可选值: public, login, password"
+// @Property			locked			    body	bool	true	"密码保护的帖子尚未解锁，为 true 时不返回正文与目录"
+// @Property			language		    body	string	true	"帖子语言，BCP 47 语言标签"
+// @Property			translation_group_id	body	int64	true	"翻译组 ID，取组内原文的帖子 ID，没有关联翻译时为 0"
+// @Property			translations	    body	[]PostTranslationVo	false	"翻译组内可见的帖子，包含当前帖子，用于生成 hreflang 链接，仅文章详情返回"
 // @Property			content_html		body	string	true	"帖子 HTML 格式内容"
 // @Property			category_ids	    body	[]int64	true	"帖子所属分类 ID 列表"
+// @Property			authors			    body	[]PostAuthorVo	true	"帖子署名作者，按署名顺序排列"
+// @Property			short_url		    body	string	false	"帖子短链接，仅文章详情与创建返回"
+// @Property			pinned			    body	bool	true	"是否置顶"
+// @Property			pin_order		    body	int		true	"置顶顺序，数值小的在前"
+// @Property			featured		    body	bool	true	"是否精选"
+// @Property			feature_order	    body	int		true	"精选顺序，数值小的在前"
+// @Property			word_count		    body	int		true	"帖子字数，中日韩文字按字计数"
+// @Property			reading_time	    body	int		true	"预计阅读时间，单位为分钟"
+// @Property			view_count		    body	int64	true	"帖子累计浏览量，文章详情中包含尚未写入数据库的浏览量"
+// @Property			bookmark_count	    body	int64	false	"帖子收藏数，仅文章详情返回"
+// @Property			bookmarked		    body	bool	false	"当前用户是否已收藏，仅文章详情返回"
+// @Property			reactions		    body	map[string]int64	false	"帖子每种表态的数量，仅文章详情返回"
+// @Property			reacted			    body	[]string	false	"当前用户或访客已表态的类型，仅文章详情返回"
+// @Property			toc				    body	[]TOCItemVo	false	"帖子目录，按标题层级嵌套，锚点与 content_html 中标题的 id 一致，仅文章详情返回"
+// @Property			series			    body	series.SeriesNavVo	false	"帖子所属系列及上一篇、下一篇文章，不属于任何系列时为空，仅文章详情返回"
+// @Property			review_status	    body	string	false	"审核状态，可选值: in_review, changes_requested, scheduled, approved，未进入审核流程时为空"
+// @Property			publish_at		    body	int64	false	"定时发布时间，审核通过等待定时发布或作者直接定时发布时有效，发布后为 0"
+// @Property			duplicates		    body	[]int64	false	"内容疑似重复的文章 ID，仅创建与更新时返回"
 type PostsVo struct {
-	ID              int64   `json:"id"`
-	Title           string  `json:"title"`
-	Image           string  `json:"image"`
-	Visibility      bool    `json:"visibility"`
-	ContentMarkdown string  `json:"content_markdown"`
-	ContentHTML     string  `json:"content_html"`
-	CategoryIDs     []int64 `json:"category_ids"`
+	ID                 int64                `json:"id"`
+	Title              string               `json:"title"`
+	Slug               string               `json:"slug"`
+	Image              string               `json:"image"`
+	Visibility         bool                 `json:"visibility"`
+	Status             string               `json:"status"`
+	Access             string               `json:"access"`
+	Locked             bool                 `json:"locked"`
+	Language           string               `json:"language"`
+	TranslationGroupID int64                `json:"translation_group_id"`
+	Translations       []*PostTranslationVo `json:"translations,omitempty"`
+	ContentMarkdown    string               `json:"content_markdown"`
+	ContentHTML        string               `json:"content_html"`
+	CategoryIDs        []int64              `json:"category_ids"`
+	Authors            []*PostAuthorVo      `json:"authors"`
+	ShortURL           string               `json:"short_url"`
+	Pinned             bool                 `json:"pinned"`
+	PinOrder           int                  `json:"pin_order"`
+	Featured           bool                 `json:"featured"`
+	FeatureOrder       int                  `json:"feature_order"`
+	WordCount          int                  `json:"word_count"`
+	ReadingTime        int                  `json:"reading_time"`
+	ViewCount          int64                `json:"view_count"`
+	BookmarkCount      int64                `json:"bookmark_count"`
+	Bookmarked         bool                 `json:"bookmarked"`
+	Reactions          map[string]int64     `json:"reactions,omitempty"`
+	Reacted            []string             `json:"reacted,omitempty"`
+	TOC                []*TOCItemVo         `json:"toc,omitempty"`
+	Series             *series.SeriesNavVo  `json:"series,omitempty"`
+	ReviewStatus       string               `json:"review_status"`
+	PublishAt          int64                `json:"publish_at"`
+	Duplicates         []int64              `json:"duplicates,omitempty"`
 }
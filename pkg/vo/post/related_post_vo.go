@@ -0,0 +1,18 @@
+package post
+
+// RelatedPostVo    相关文章
+// @Description	与当前文章内容相近或类目相同的已发布文章
+// @Property			id				body	int64	true	"文章 ID"
+// @Property			title			body	string	true	"文章标题"
+// @Property			slug			body	string	true	"文章别名"
+// @Property			image			body	string	true	"文章封面图片 URL"
+// @Property			category_ids	body	[]int64	true	"文章所属分类 ID 列表"
+// @Property			score			body	float64	true	"相关度，由内容相似度与类目重合度加权得出，取值 0 到 1"
+type RelatedPostVo struct {
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Slug        string  `json:"slug"`
+	Image       string  `json:"image"`
+	CategoryIDs []int64 `json:"category_ids"`
+	Score       float64 `json:"score"`
+}
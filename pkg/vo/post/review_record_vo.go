@@ -0,0 +1,22 @@
+package post
+
+// ReviewRecordVo    文章审核记录
+// @Description	文章在审核流程中的一次状态变更
+// @Property			id			body	int64	true	"记录 ID"
+// @Property			post_id		body	int64	true	"文章 ID"
+// @Property			account_id	body	int64	true	"操作人 ID，定时发布时为 0"
+// @Property			action		body	string	true	"审核操作，可选值: submit, approve, schedule, request_changes, publish"
+// @Property			from		body	string	true	"变更前的审核状态"
+// @Property			to			body	string	true	"变更后的审核状态"
+// @Property			comment		body	string	true	"审核意见或提交说明"
+// @Property			gmt_create	body	int64	true	"操作时间"
+type ReviewRecordVo struct {
+	ID         int64  `json:"id"`
+	PostID     int64  `json:"post_id"`
+	AccountID  int64  `json:"account_id"`
+	Action     string `json:"action"`
+	FromStatus string `json:"from"`
+	ToStatus   string `json:"to"`
+	Comment    string `json:"comment"`
+	GmtCreate  int64  `json:"gmt_create"`
+}
@@ -0,0 +1,51 @@
+package post
+
+// PostRevisionVo    文章修订记录
+// @Description	文章的一次保存，不含正文，正文差异见修订对比
+// @Property			id				body	int64	true	"记录 ID"
+// @Property			post_id			body	int64	true	"文章 ID"
+// @Property			version			body	int		true	"修订版本号，同一文章内从 1 开始递增"
+// @Property			account_id		body	int64	true	"保存人 ID"
+// @Property			action			body	string	true	"产生修订的操作，可选值: create, update, restore"
+// @Property			restored_from	body	int		false	"恢复时来源的修订版本号，其他操作为 0"
+// @Property			title			body	string	true	"标题"
+// @Property			gmt_create		body	int64	true	"保存时间"
+type PostRevisionVo struct {
+	ID           int64  `json:"id"`
+	PostID       int64  `json:"post_id"`
+	Version      int    `json:"version"`
+	AccountID    int64  `json:"account_id"`
+	Action       string `json:"action"`
+	RestoredFrom int    `json:"restored_from"`
+	Title        string `json:"title"`
+	GmtCreate    int64  `json:"gmt_create"`
+}
+
+// RevisionDiffVo    文章修订对比结果
+// @Description	两个修订之间标题、封面、分类的变化与正文的逐行差异
+// @Property			post_id				body	int64	true	"文章 ID"
+// @Property			from				body	int		true	"旧修订的版本号"
+// @Property			to					body	int		true	"新修订的版本号，0 表示文章当前内容"
+// @Property			from_title			body	string	true	"旧修订的标题"
+// @Property			to_title			body	string	true	"新修订的标题"
+// @Property			from_image			body	string	true	"旧修订的封面"
+// @Property			to_image			body	string	true	"新修订的封面"
+// @Property			from_category_ids	body	[]int64	true	"旧修订的分类 ID"
+// @Property			to_category_ids		body	[]int64	true	"新修订的分类 ID"
+// @Property			diff				body	string	true	"Markdown 正文的统一格式差异，正文相同时为空"
+// @Property			additions			body	int		true	"正文新增行数"
+// @Property			deletions			body	int		true	"正文删除行数"
+type RevisionDiffVo struct {
+	PostID          int64   `json:"post_id"`
+	From            int     `json:"from"`
+	To              int     `json:"to"`
+	FromTitle       string  `json:"from_title"`
+	ToTitle         string  `json:"to_title"`
+	FromImage       string  `json:"from_image"`
+	ToImage         string  `json:"to_image"`
+	FromCategoryIDs []int64 `json:"from_category_ids"`
+	ToCategoryIDs   []int64 `json:"to_category_ids"`
+	Diff            string  `json:"diff"`
+	Additions       int     `json:"additions"`
+	Deletions       int     `json:"deletions"`
+}
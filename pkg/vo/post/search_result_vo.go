@@ -0,0 +1,12 @@
+package post
+
+// SearchResultVo    搜索文章的响应结构
+// @Description	命中的文章及其相关度
+// @Property			post	body	PostsVo	true	"文章信息，content_html 仅保留前 150 个字符"
+// @Property			score	body	float64	true	"相关度，越大越相关"
+// @Property			fuzzy	body	bool	true	"是否仅通过模糊匹配命中"
+type SearchResultVo struct {
+	Post  *PostsVo `json:"post"`
+	Score float64  `json:"score"`
+	Fuzzy bool     `json:"fuzzy"`
+}
@@ -0,0 +1,18 @@
+package post
+
+// SeoTextVo    生成的文章摘要与 SEO 文本
+// @Description	大模型生成的文本，以及写入文章时实际写入与因已有文本而跳过的字段
+// @Property			post_id				body	int64		true	"文章 ID"
+// @Property			summary				body	string		false	"文章摘要"
+// @Property			meta_description	body	string		false	"页面 meta 描述"
+// @Property			social_blurb		body	string		false	"社交媒体分享文案"
+// @Property			applied				body	[]string	true	"已写入文章的字段"
+// @Property			skipped				body	[]string	true	"已有文本、未覆盖的字段"
+type SeoTextVo struct {
+	PostID          int64    `json:"post_id"`
+	Summary         string   `json:"summary"`
+	MetaDescription string   `json:"meta_description"`
+	SocialBlurb     string   `json:"social_blurb"`
+	Applied         []string `json:"applied"`
+	Skipped         []string `json:"skipped"`
+}
@@ -0,0 +1,12 @@
+package post
+
+// SuggestionVo    搜索建议的响应结构
+// @Description	按前缀补全的文章标题或标签
+// @Property			type	body	string	true	"建议类型，可选值: title, tag"
+// @Property			id		body	int64	true	"类型为 title 时为文章 ID，为 tag 时为类目 ID"
+// @Property			text	body	string	true	"补全文本"
+type SuggestionVo struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+	Text string `json:"text"`
+}
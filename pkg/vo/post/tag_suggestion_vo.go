@@ -0,0 +1,14 @@
+package post
+
+// TagSuggestionVo    标签推荐的响应结构
+// @Description	为草稿推荐的已有类目，按相关度倒序排列
+// @Property			category_id	body	int64	true	"类目 ID"
+// @Property			name		body	string	true	"类目名称"
+// @Property			path		body	string	true	"类目路径"
+// @Property			score		body	float64	true	"相关度，取值 0 ~ 1"
+type TagSuggestionVo struct {
+	CategoryID int64   `json:"category_id"`
+	Name       string  `json:"name"`
+	Path       string  `json:"path"`
+	Score      float64 `json:"score"`
+}
@@ -0,0 +1,14 @@
+package post
+
+// TOCItemVo    文章目录项
+// @Description	文章详情中的目录，按标题层级嵌套
+// @Property			level		body	int			true	"标题级别，1 至 6"
+// @Property			text		body	string		true	"标题文本"
+// @Property			id			body	string		true	"标题锚点 ID，与 content_html 中标题的 id 属性一致"
+// @Property			children	body	[]TOCItemVo	false	"下一级标题"
+type TOCItemVo struct {
+	Level    int          `json:"level"`
+	Text     string       `json:"text"`
+	ID       string       `json:"id"`
+	Children []*TOCItemVo `json:"children,omitempty"`
+}
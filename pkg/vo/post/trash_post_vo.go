@@ -0,0 +1,22 @@
+package post
+
+// TrashPostVo    回收站中的文章
+// @Description	已删除、尚未被永久删除的文章，时间均为秒级时间戳
+// @Property			id			body	int64	true	"文章 ID"
+// @Property			title		body	string	true	"文章标题"
+// @Property			slug		body	string	true	"文章别名，恢复时别名已被其他文章使用则重新生成"
+// @Property			image		body	string	true	"文章封面图片 URL"
+// @Property			status		body	string	true	"删除前的发布状态"
+// @Property			gmt_create	body	int64	true	"创建时间"
+// @Property			deleted_at	body	int64	true	"删除时间"
+// @Property			purge_at	body	int64	true	"预计永久删除的时间，清理任务每日执行，实际删除可能晚于该时间；不清理时为 0"
+type TrashPostVo struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Image     string `json:"image"`
+	Status    string `json:"status"`
+	GmtCreate int64  `json:"gmt_create"`
+	DeletedAt int64  `json:"deleted_at"`
+	PurgeAt   int64  `json:"purge_at"`
+}
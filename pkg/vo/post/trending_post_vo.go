@@ -0,0 +1,18 @@
+package post
+
+// TrendingPostVo    热门文章
+// @Description	统计区间内浏览量最高的已发布文章
+// @Property			id				body	int64	true	"文章 ID"
+// @Property			title			body	string	true	"文章标题"
+// @Property			slug			body	string	true	"文章别名"
+// @Property			image			body	string	true	"文章封面图片 URL"
+// @Property			views			body	int64	true	"统计区间内的浏览量"
+// @Property			view_count		body	int64	true	"累计浏览量"
+type TrendingPostVo struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Image     string `json:"image"`
+	Views     int64  `json:"views"`
+	ViewCount int64  `json:"view_count"`
+}
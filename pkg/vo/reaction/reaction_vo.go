@@ -0,0 +1,12 @@
+package reaction
+
+// ReactionStateVo    文章表态状态的响应结构
+// @Description	文章每种表态的数量与当前用户或访客的表态
+// @Property			post_id		body	int64				true	"文章 ID"
+// @Property			reactions	body	map[string]int64	true	"每种表态的数量，包含 REACTION_TYPES 中的全部类型"
+// @Property			reacted		body	[]string			true	"当前用户或访客已表态的类型，未登录且没有访客 ID 时为空"
+type ReactionStateVo struct {
+	PostID    int64            `json:"post_id"`
+	Reactions map[string]int64 `json:"reactions"`
+	Reacted   []string         `json:"reacted"`
+}
@@ -11,6 +11,7 @@ import (
 type Result struct {
 	*bizErr.Err
 	Data      interface{} `json:"data"`
+	Page      *PageMeta   `json:"page,omitempty"`
 	RequestId interface{} `json:"requestId"`
 	TimeStamp interface{} `json:"timeStamp"`
 }
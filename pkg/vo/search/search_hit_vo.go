@@ -0,0 +1,31 @@
+package search
+
+// SearchHitVo    全文检索结果
+// @Description	命中关键词的文章，标题与正文片段中的关键词以 <mark> 标记，其余内容已转义为 HTML
+// @Property			id		body	int64		true	"文章 ID"
+// @Property			title	body	string		true	"高亮后的标题"
+// @Property			snippet	body	string		true	"正文中命中关键词的片段，正文未命中时为开头的片段"
+// @Property			tags	body	[]string	true	"文章的标签"
+// @Property			score	body	float64		true	"相关度"
+type SearchHitVo struct {
+	ID      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Snippet string   `json:"snippet"`
+	Tags    []string `json:"tags"`
+	Score   float64  `json:"score"`
+}
+
+// CommentSearchHitVo    评论检索结果
+// @Description	命中关键词的评论，片段中的关键词以 <mark> 标记，其余内容已转义为 HTML
+// @Property			id			body	int64		true	"评论 ID"
+// @Property			post_id		body	int64		true	"所属文章 ID"
+// @Property			post_title	body	string		true	"所属文章标题"
+// @Property			snippet		body	string		true	"评论中命中关键词的片段"
+// @Property			score		body	float64		true	"相关度"
+type CommentSearchHitVo struct {
+	ID        int64   `json:"id"`
+	PostID    int64   `json:"post_id"`
+	PostTitle string  `json:"post_title"`
+	Snippet   string  `json:"snippet"`
+	Score     float64 `json:"score"`
+}
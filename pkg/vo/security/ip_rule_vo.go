@@ -0,0 +1,16 @@
+package security
+
+// IPRulesVo     管理接口 IP 访问规则
+// @Description	管理接口的白名单与黑名单，黑名单优先于白名单，白名单为空时不限制来源，规则均为 CIDR
+// @Property			client_ip			body	string		true	"当前请求的来源 IP"
+// @Property			allowlist			body	[]string	true	"管理员添加的白名单"
+// @Property			denylist			body	[]string	true	"管理员添加的黑名单"
+// @Property			config_allowlist	body	[]string	true	"配置文件中的白名单，只能修改配置文件调整"
+// @Property			config_denylist		body	[]string	true	"配置文件中的黑名单，只能修改配置文件调整"
+type IPRulesVo struct {
+	ClientIP        string   `json:"client_ip"`
+	Allowlist       []string `json:"allowlist"`
+	Denylist        []string `json:"denylist"`
+	ConfigAllowlist []string `json:"config_allowlist"`
+	ConfigDenylist  []string `json:"config_denylist"`
+}
@@ -0,0 +1,18 @@
+package security
+
+// JWTKeyVo     JWT 签名密钥
+// @Description	签名密钥的 ID、用途与状态，不包含密钥本身，时间为秒级时间戳
+// @Property			kid			body	string	true	"密钥 ID，即 token 的 kid 头部"
+// @Property			purpose		body	string	true	"用途，可选值: access, refresh"
+// @Property			current		body	bool	true	"是否为当前用于签发的密钥"
+// @Property			created_at	body	int64	true	"创建时间"
+// @Property			retired_at	body	int64	false	"被新密钥替换的时间"
+// @Property			expires_at	body	int64	false	"停止校验该密钥签发的 token 的时间"
+type JWTKeyVo struct {
+	Kid       string `json:"kid"`
+	Purpose   string `json:"purpose"`
+	Current   bool   `json:"current"`
+	CreatedAt int64  `json:"created_at"`
+	RetiredAt int64  `json:"retired_at,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,52 @@
+package series
+
+// SeriesVo    系列的响应结构
+// @Description	系列信息，系列详情中包含按顺序排列的文章
+// @Property			id				body	int64			true	"系列 ID"
+// @Property			title			body	string			true	"系列标题"
+// @Property			description		body	string			true	"系列简介"
+// @Property			gmt_create		body	int64			true	"创建时间"
+// @Property			gmt_modified	body	int64			true	"更新时间"
+// @Property			posts			body	[]SeriesPostVo	false	"系列中的文章，仅系列详情返回；未登录时只包含已发布的文章"
+type SeriesVo struct {
+	ID          int64           `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	GmtCreate   int64           `json:"gmt_create"`
+	GmtModified int64           `json:"gmt_modified"`
+	Posts       []*SeriesPostVo `json:"posts,omitempty"`
+}
+
+// SeriesPostVo    系列中的文章
+// @Description	系列中的一篇文章
+// @Property			id			body	int64	true	"文章 ID"
+// @Property			title		body	string	true	"文章标题"
+// @Property			slug		body	string	true	"文章别名"
+// @Property			image		body	string	true	"文章封面图片 URL"
+// @Property			status		body	string	true	"发布状态"
+// @Property			position	body	int		true	"在系列中的序号，从 1 开始"
+type SeriesPostVo struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	Image    string `json:"image"`
+	Status   string `json:"status"`
+	Position int    `json:"position"`
+}
+
+// SeriesNavVo    文章的系列导航
+// @Description	文章所属系列及上一篇、下一篇文章
+// @Property			id			body	int64			true	"系列 ID"
+// @Property			title		body	string			true	"系列标题"
+// @Property			position	body	int				true	"文章在系列中的序号，从 1 开始"
+// @Property			total		body	int				true	"系列中的文章数"
+// @Property			prev		body	SeriesPostVo	false	"上一篇文章，第一篇时为空"
+// @Property			next		body	SeriesPostVo	false	"下一篇文章，最后一篇时为空"
+type SeriesNavVo struct {
+	ID       int64         `json:"id"`
+	Title    string        `json:"title"`
+	Position int           `json:"position"`
+	Total    int           `json:"total"`
+	Prev     *SeriesPostVo `json:"prev"`
+	Next     *SeriesPostVo `json:"next"`
+}
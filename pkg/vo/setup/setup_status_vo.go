@@ -0,0 +1,23 @@
+package setup
+
+// SetupStatusVo     安装状态
+// @Description	系统是否需要执行首次启动安装向导
+// @Property			need_setup	body	bool	true	"是否需要初始化"
+// @Property			site_title	body	string	true	"当前站点标题"
+// @Property			site_url	body	string	true	"当前站点地址"
+type SetupStatusVo struct {
+	NeedSetup bool   `json:"need_setup"`
+	SiteTitle string `json:"site_title"`
+	SiteURL   string `json:"site_url"`
+}
+
+// ConnectionVo     依赖组件连通性检测结果
+// @Description	数据库与 Redis 的连通性
+// @Property			database	body	bool	true	"数据库是否可用"
+// @Property			redis		body	bool	true	"Redis 是否可用"
+// @Property			errors		body	map[string]string	false	"不可用组件的错误信息"
+type ConnectionVo struct {
+	Database bool              `json:"database"`
+	Redis    bool              `json:"redis"`
+	Errors   map[string]string `json:"errors"`
+}
@@ -0,0 +1,25 @@
+package shortlink
+
+// ShortLinkStatsVo    短链接点击统计
+// @Description	文章短链接的累计点击次数与主要来源
+// @Property			post_id		body	int64			true	"文章 ID"
+// @Property			code		body	string			true	"短码"
+// @Property			short_url	body	string			true	"短链接地址"
+// @Property			clicks		body	int64			true	"累计点击次数"
+// @Property			referers	body	[]RefererVo		true	"点击量最高的来源页面，直接访问时来源为空"
+type ShortLinkStatsVo struct {
+	PostID   int64        `json:"post_id"`
+	Code     string       `json:"code"`
+	ShortURL string       `json:"short_url"`
+	Clicks   int64        `json:"clicks"`
+	Referers []*RefererVo `json:"referers"`
+}
+
+// RefererVo    短链接来源统计
+// @Description	单个来源页面的点击次数
+// @Property			referer	body	string	true	"来源页面"
+// @Property			count	body	int64	true	"点击次数"
+type RefererVo struct {
+	Referer string `json:"referer"`
+	Count   int64  `json:"count"`
+}
@@ -0,0 +1,39 @@
+package task
+
+// TaskVo     定时任务状态
+// @Description	定时任务的调度周期与最近运行情况，时间均为秒级时间戳
+// @Property			name				body	string	true	"任务名称"
+// @Property			description			body	string	true	"任务说明"
+// @Property			interval_seconds	body	int64	true	"运行周期（秒）"
+// @Property			paused				body	bool	true	"是否已暂停"
+// @Property			running				body	bool	true	"是否正在运行"
+// @Property			next_run			body	int64	true	"下次运行时间，暂停时为 0"
+// @Property			last_run			body	int64	true	"上次运行时间，未运行过时为 0"
+// @Property			last_duration_ms	body	int64	true	"上次运行耗时（毫秒）"
+// @Property			last_error			body	string	false	"上次运行的错误信息"
+type TaskVo struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	IntervalSeconds int64  `json:"interval_seconds"`
+	Paused          bool   `json:"paused"`
+	Running         bool   `json:"running"`
+	NextRun         int64  `json:"next_run"`
+	LastRun         int64  `json:"last_run"`
+	LastDurationMs  int64  `json:"last_duration_ms"`
+	LastError       string `json:"last_error"`
+}
+
+// TaskLogVo     定时任务运行记录
+// @Description	单次运行的触发方式、开始时间、耗时与结果
+// @Property			trigger		body	string	true	"触发方式，可选值: schedule, manual"
+// @Property			started_at	body	int64	true	"开始时间"
+// @Property			duration_ms	body	int64	true	"运行耗时（毫秒）"
+// @Property			success		body	bool	true	"是否成功"
+// @Property			error		body	string	false	"错误信息"
+type TaskLogVo struct {
+	Trigger    string `json:"trigger"`
+	StartedAt  int64  `json:"started_at"`
+	DurationMs int64  `json:"duration_ms"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error"`
+}
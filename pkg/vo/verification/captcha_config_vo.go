@@ -0,0 +1,10 @@
+package verification
+
+// CaptchaConfigVo          人机验证配置
+// @Description             前端渲染人机验证组件所需的配置
+// @Property		provider	body	string	true	"人机验证服务，可选值: builtin, slider, turnstile, recaptcha, hcaptcha"
+// @Property		site_key	body	string	false	"托管服务的站点公钥，内置图形验证码与滑动拼图为空"
+type CaptchaConfigVo struct {
+	Provider string `json:"provider"`
+	SiteKey  string `json:"site_key"`
+}
@@ -0,0 +1,10 @@
+package verification
+
+// CooldownVo               验证码冷却信息
+// @Description             验证码仍在有效期内时返回
+// @Property		resend_after	body	int	true	"距离可重新发送的秒数，0 表示可立即调用重新发送接口"
+// @Property		expires_in		body	int	true	"当前验证码的剩余有效秒数"
+type CooldownVo struct {
+	ResendAfter int `json:"resend_after"`
+	ExpiresIn   int `json:"expires_in"`
+}
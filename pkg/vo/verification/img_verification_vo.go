@@ -3,6 +3,8 @@ package verification
 // ImgVerificationVo        图片验证码
 // @Description             图片验证码
 // @Property		img	body	string	true	"图片的base64编码"
+// @Property		nonce	body	string	true	"客户端随机数，同时作为图形验证码 ID，获取邮箱验证码与提交表单时需携带"
 type ImgVerificationVo struct {
 	ImgBase64 string `json:"imgBase64"`
+	Nonce     string `json:"nonce"`
 }
@@ -0,0 +1,8 @@
+package verification
+
+// LoginCaptchaVo           登录人机验证要求
+// @Description             使用该邮箱登录时是否需要完成人机验证
+// @Property		required	body	bool	true	"是否需要人机验证，为 true 时登录请求需携带图形验证码或验证凭证"
+type LoginCaptchaVo struct {
+	Required bool `json:"required"`
+}
@@ -0,0 +1,8 @@
+package verification
+
+// RetryAfterVo             发送受限时的等待时间
+// @Description             验证码发送过于频繁或仍在冷却期时返回
+// @Property		retry_after	body	int	true	"距离可再次发送的秒数"
+type RetryAfterVo struct {
+	RetryAfter int `json:"retry_after"`
+}
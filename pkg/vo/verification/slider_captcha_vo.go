@@ -0,0 +1,18 @@
+package verification
+
+// SliderCaptchaVo          滑动拼图
+// @Description             滑动拼图，用户将拼图块拖动到背景图中的缺口后提交拼图块的横坐标
+// @Property		background	body	string	true	"挖去拼图块的背景图，Base64 编码的 PNG"
+// @Property		piece		body	string	true	"拼图块，Base64 编码的 PNG"
+// @Property		y			body	int		true	"拼图块在背景图中的纵坐标（像素）"
+// @Property		width		body	int		true	"背景图宽度（像素）"
+// @Property		height		body	int		true	"背景图高度（像素）"
+// @Property		nonce		body	string	true	"客户端随机数，同时作为滑动拼图 ID，提交表单时需携带"
+type SliderCaptchaVo struct {
+	Background string `json:"background"`
+	Piece      string `json:"piece"`
+	Y          int    `json:"y"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	Nonce      string `json:"nonce"`
+}
@@ -0,0 +1,10 @@
+package verification
+
+// TicketVo                 验证凭证
+// @Description             验证码校验通过后返回，可代替验证码提交给注册、重置密码等接口
+// @Property		ticket		body	string	true	"验证凭证，只能使用一次"
+// @Property		expires_in	body	int		true	"验证凭证的有效秒数"
+type TicketVo struct {
+	Ticket    string `json:"ticket"`
+	ExpiresIn int    `json:"expires_in"`
+}
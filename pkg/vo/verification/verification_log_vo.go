@@ -0,0 +1,33 @@
+package verification
+
+// VerificationLogVo    验证码审计日志
+// @Description	单次发送或校验验证码的记录，时间为秒级时间戳
+// @Property		id			body	int64	true	"日志 ID"
+// @Property		event		body	string	true	"事件，send 或 verify"
+// @Property		channel		body	string	true	"渠道，IMG、EMAIL、SMS、TOTP、MESSENGER"
+// @Property		target		body	string	true	"接收方，邮箱或手机号"
+// @Property		ip			body	string	true	"客户端 IP"
+// @Property		result		body	string	true	"结果"
+// @Property		created_at	body	int64	true	"记录时间"
+type VerificationLogVo struct {
+	ID        int64  `json:"id"`
+	Event     string `json:"event"`
+	Channel   string `json:"channel"`
+	Target    string `json:"target"`
+	IP        string `json:"ip"`
+	Result    string `json:"result"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// VerificationLogStatVo    验证码审计日志统计
+// @Description	按事件、渠道与结果分组的日志条数
+// @Property		event	body	string	true	"事件"
+// @Property		channel	body	string	true	"渠道"
+// @Property		result	body	string	true	"结果"
+// @Property		count	body	int64	true	"条数"
+type VerificationLogStatVo struct {
+	Event   string `json:"event"`
+	Channel string `json:"channel"`
+	Result  string `json:"result"`
+	Count   int64  `json:"count"`
+}
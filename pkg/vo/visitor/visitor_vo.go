@@ -0,0 +1,12 @@
+package visitor
+
+// VisitorVo    访客身份的响应结构
+// @Description	匿名访客的访客 ID，同时写入名为 jank_visitor 的 Cookie
+// @Property			visitor_id		body	string	true	"访客 ID"
+// @Property			expires_at		body	int64	true	"Cookie 过期时间"
+// @Property			created			body	bool	true	"是否为本次新签发"
+type VisitorVo struct {
+	VisitorID string `json:"visitor_id"`
+	ExpiresAt int64  `json:"expires_at"`
+	Created   bool   `json:"created"`
+}